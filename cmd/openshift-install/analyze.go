@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/analyze"
+)
+
+func newAnalyzeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "analyze",
+		Short: "Looks for the likely causes of a failed install",
+		Long: strings.TrimSpace(`
+Looks for the likely causes of a failed install, such as exhausted cloud
+quotas, rejected pull secrets, and unresolvable DNS names, and suggests how to
+fix them.
+
+The installer log and any log bundles collected by 'gather bootstrap' in the
+asset directory are searched. If the cluster's API is reachable with the
+kubeconfig in the asset directory, the cluster version's conditions are
+searched too.
+`),
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			findings, err := runAnalyzeCmd(rootOpts.dir)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+
+			if len(findings) == 0 {
+				logrus.Info("No known causes of failure were found")
+				return
+			}
+			for i, finding := range findings {
+				if i > 0 {
+					fmt.Println()
+				}
+				fmt.Printf("Likely cause: %s\n", finding.Cause)
+				fmt.Printf("  Evidence (%s, %d matching lines): %s\n", finding.Source, finding.Count, finding.Evidence)
+				fmt.Printf("  Remediation: %s\n", finding.Remediation)
+			}
+		},
+	}
+}
+
+func runAnalyzeCmd(directory string) ([]analyze.Finding, error) {
+	findings, err := analyze.Directory(directory)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to analyze the asset directory")
+	}
+
+	conditions, err := clusterVersionConditions(directory)
+	if err != nil {
+		logrus.Debugf("Not analyzing the cluster: %v", err)
+		return findings, nil
+	}
+	return append(findings, analyze.Text("cluster version", conditions)...), nil
+}
+
+// clusterVersionConditions returns the messages of the cluster version's
+// conditions, one per line, from the cluster the kubeconfig in directory
+// points at.
+func clusterVersionConditions(directory string) (string, error) {
+	kubeconfig := filepath.Join(directory, "auth", "kubeconfig")
+	if _, err := os.Stat(kubeconfig); err != nil {
+		return "", err
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return "", errors.Wrap(err, "loading kubeconfig")
+	}
+	config.Timeout = 10 * time.Second
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return "", errors.Wrap(err, "creating a Kubernetes client")
+	}
+
+	data, err := client.Discovery().RESTClient().Get().AbsPath("/apis", configv1.GroupName, configv1.GroupVersion.Version, "clusterversions", "version").DoRaw()
+	if err != nil {
+		return "", errors.Wrap(err, "getting the cluster version")
+	}
+
+	cv := &configv1.ClusterVersion{}
+	if err := json.Unmarshal(data, cv); err != nil {
+		return "", errors.Wrap(err, "decoding the cluster version")
+	}
+
+	messages := make([]string, 0, len(cv.Status.Conditions))
+	for _, condition := range cv.Status.Conditions {
+		messages = append(messages, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+	}
+	return strings.Join(messages, "\n"), nil
+}
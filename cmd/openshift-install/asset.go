@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+var (
+	createAssetOpts struct {
+		name string
+	}
+)
+
+func newCreateAssetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "asset",
+		Short: "Generates a single asset and its dependencies",
+		Long: strings.TrimSpace(`
+Generates a single asset and its dependencies, for debugging the generation
+of assets such as certificates and Ignition configs without running a whole
+target.
+
+The asset is named by its type, such as 'tls.RootCA', as shown by 'graph', or
+by its human-friendly name, such as 'Root CA'. Assets which write files are
+written to the asset directory; other assets are printed as JSON. The files
+consumed by the asset are kept in the asset directory.
+`),
+		Hidden: true,
+		Args:   cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			if err := runCreateAssetCmd(rootOpts.dir, createAssetOpts.name); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&createAssetOpts.name, "name", "", "the type or name of the asset to generate")
+	return cmd
+}
+
+func runCreateAssetCmd(directory string, name string) error {
+	a, err := findAsset(name)
+	if err != nil {
+		return err
+	}
+
+	asset.KeepConsumed = true
+	store, err := asset.NewStore(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+	if err := store.Fetch(a); err != nil {
+		return errors.Wrapf(err, "failed to fetch %s", a.Name())
+	}
+
+	if wa, ok := a.(asset.WritableAsset); ok {
+		if err := asset.PersistToFile(wa, directory); err != nil {
+			return errors.Wrapf(err, "failed to write asset (%s) to disk", a.Name())
+		}
+		for _, f := range wa.Files() {
+			logrus.Infof("Wrote %s", f.Filename)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", a.Name())
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
+
+// findAsset returns a new instance of the asset, among those the targets
+// depend on, with the given type or human-friendly name.
+func findAsset(name string) (asset.Asset, error) {
+	byType := map[string]asset.Asset{}
+	var walk func(a asset.Asset)
+	walk = func(a asset.Asset) {
+		typeName := reflect.TypeOf(a).Elem().String()
+		if _, ok := byType[typeName]; ok {
+			return
+		}
+		byType[typeName] = a
+		for _, dep := range a.Dependencies() {
+			walk(dep)
+		}
+	}
+	for _, t := range targets {
+		for _, a := range t.assets() {
+			walk(a)
+		}
+	}
+
+	var matches []string
+	for typeName, a := range byType {
+		if typeName == name || strings.EqualFold(a.Name(), name) {
+			matches = append(matches, typeName)
+		}
+	}
+	sort.Strings(matches)
+
+	switch len(matches) {
+	case 0:
+		return nil, errors.Errorf("no asset named %q; see 'openshift-install graph' for the assets", name)
+	case 1:
+		return reflect.New(reflect.TypeOf(byType[matches[0]]).Elem()).Interface().(asset.Asset), nil
+	default:
+		return nil, errors.Errorf("%q names more than one asset, name one of them by its type instead: %s", name, strings.Join(matches, ", "))
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/kubeconfig"
+	"github.com/openshift/installer/pkg/asset/password"
+)
+
+const (
+	// kubeadminSecretNamespace and kubeadminSecretName locate the secret
+	// holding the hash of the kubeadmin password.
+	kubeadminSecretNamespace = "kube-system"
+	kubeadminSecretName      = "kubeadmin"
+)
+
+func newAuthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Recover the credentials of an OpenShift cluster",
+		Long: strings.TrimSpace(`
+Recovers the credentials of a cluster whose auth directory was lost, without
+touching the cluster's infrastructure.
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newAuthKubeconfigCmd())
+	cmd.AddCommand(newAuthResetKubeadminPasswordCmd())
+	return cmd
+}
+
+func newAuthKubeconfigCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "kubeconfig",
+		Short: "Rewrites the admin kubeconfig from the asset state",
+		Long: strings.TrimSpace(`
+Rewrites auth/kubeconfig in the asset directory from the state file written
+when the Ignition configs were created. The credentials are the ones the
+cluster was installed with; nothing is regenerated.
+`),
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			if err := runAuthKubeconfigCmd(rootOpts.dir); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+}
+
+func runAuthKubeconfigCmd(directory string) error {
+	store, err := asset.NewStore(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+
+	admin, err := store.Load(&kubeconfig.Admin{})
+	if err != nil {
+		return err
+	}
+	if admin == nil {
+		return errors.New("there is no admin kubeconfig in the state file; it is only kept once 'create ignition-configs' or 'create cluster' has run in the asset directory")
+	}
+
+	if err := asset.PersistToFile(admin.(asset.WritableAsset), directory); err != nil {
+		return errors.Wrap(err, "failed to write the admin kubeconfig")
+	}
+	logrus.Infof("Wrote the admin kubeconfig to %q", filepath.Join(directory, "auth", "kubeconfig"))
+	return nil
+}
+
+func newAuthResetKubeadminPasswordCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset-kubeadmin-password",
+		Short: "Sets a new password for the kubeadmin user",
+		Long: strings.TrimSpace(`
+Generates a new password for the kubeadmin user, stores its hash in the
+cluster using the admin kubeconfig, and writes it to auth/kubeadmin-password
+in the asset directory.
+
+The kubeadmin secret is re-created if it was removed. The admin kubeconfig
+can be recovered first with 'auth kubeconfig'.
+`),
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			if err := runAuthResetKubeadminPasswordCmd(rootOpts.dir); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+}
+
+func runAuthResetKubeadminPasswordCmd(directory string) error {
+	config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(directory, "auth", "kubeconfig"))
+	if err != nil {
+		return errors.Wrap(err, "loading kubeconfig; run 'auth kubeconfig' to recover it from the asset state")
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "creating a Kubernetes client")
+	}
+
+	pw := &password.KubeadminPassword{}
+	if err := pw.GenerateRandom(); err != nil {
+		return errors.Wrap(err, "failed to generate a password")
+	}
+
+	secrets := client.CoreV1().Secrets(kubeadminSecretNamespace)
+	secret, err := secrets.Get(kubeadminSecretName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		logrus.Infof("Re-creating the %s/%s secret", kubeadminSecretNamespace, kubeadminSecretName)
+		_, err = secrets.Create(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: kubeadminSecretNamespace,
+				Name:      kubeadminSecretName,
+			},
+			Data: map[string][]byte{kubeadminSecretName: pw.PasswordHash},
+		})
+	case err == nil:
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[kubeadminSecretName] = pw.PasswordHash
+		_, err = secrets.Update(secret)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to store the password in the %s/%s secret", kubeadminSecretNamespace, kubeadminSecretName)
+	}
+
+	path := filepath.Join(directory, "auth", "kubeadmin-password")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, []byte(pw.Password), 0600); err != nil {
+		return errors.Wrapf(err, "the password was reset to %q but could not be written", pw.Password)
+	}
+
+	logrus.Infof("The kubeadmin password was reset and written to %q", path)
+	logrus.Infof("Login with user: kubeadmin, password: %s", pw.Password)
+	return nil
+}
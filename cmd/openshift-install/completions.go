@@ -1,6 +1,8 @@
 package main
 
 import (
+	"bytes"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -37,6 +39,20 @@ For examples of loading/evaluating the completions see:
       source <(openshift-install completion zsh)
   # Set the openshift-install completion code for zsh[1] to autoload on startup
       openshift-install completion zsh > "${fpath[1]}/_openshift-install"`
+
+	// bashCompletionFunctions are the custom completion functions referenced
+	// by the root command's flag annotations.
+	bashCompletionFunctions = `
+__openshift-install_log_levels()
+{
+    COMPREPLY=( $(compgen -W "trace debug info warn error" -- "$cur") )
+}
+
+__openshift-install_log_formats()
+{
+    COMPREPLY=( $(compgen -W "text json" -- "$cur") )
+}
+`
 )
 
 func newCompletionCmd() *cobra.Command {
@@ -56,16 +72,172 @@ func newCompletionCmd() *cobra.Command {
 	}
 	completionCmd.AddCommand(bashCompletionCmd)
 
-	// The zsh completions didn't work for crawford, so commenting them out
-	//zshCompletionCmd := &cobra.Command{
-	//Use:     "zsh",
-	//Short:   "Outputs the zsh shell completions",
-	//Example: completionExampleZsh,
-	//RunE: func(cmd *cobra.Command, _ []string) error {
-	//return cmd.Root().GenZshCompletion(os.Stdout)
-	//},
-	//}
-	//completionCmd.AddCommand(zshCompletionCmd)
+	zshCompletionCmd := &cobra.Command{
+		Use:     "zsh",
+		Short:   "Outputs the zsh shell completions",
+		Example: completionExampleZsh,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return genZshCompletion(os.Stdout, cmd.Root())
+		},
+	}
+	completionCmd.AddCommand(zshCompletionCmd)
 
 	return completionCmd
 }
+
+// genZshCompletion writes zsh completions for root. Cobra's native zsh
+// generator does not handle subcommand flags, so, like kubectl, this wraps
+// the bash completions with zsh's bashcompinit.
+func genZshCompletion(out io.Writer, root *cobra.Command) error {
+	var bash bytes.Buffer
+	if err := root.GenBashCompletion(&bash); err != nil {
+		return err
+	}
+
+	for _, chunk := range [][]byte{[]byte(zshCompletionHead), bash.Bytes(), []byte(zshCompletionTail)} {
+		if _, err := out.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const zshCompletionHead = `#compdef openshift-install
+
+__openshift-install_bash_source() {
+	alias shopt=':'
+	alias _expand=_bash_expand
+	alias _complete=_bash_comp
+	emulate -L sh
+	setopt kshglob noshglob braceexpand
+
+	source "$@"
+}
+
+__openshift-install_type() {
+	# -t is not supported by zsh
+	if [ "$1" == "-t" ]; then
+		shift
+
+		# fake Bash 4 to disable "complete -o nospace".
+		if [ "$1" = "__openshift-install_compopt" ]; then
+			echo builtin
+			return 0
+		fi
+	fi
+	type "$@"
+}
+
+__openshift-install_compgen() {
+	local completions w
+	completions=( $(compgen "$@") ) || return $?
+
+	# filter by given word as prefix
+	while [[ "$1" = -* && "$1" != -- ]]; do
+		shift
+		shift
+	done
+	if [[ "$1" == -- ]]; then
+		shift
+	fi
+	for w in "${completions[@]}"; do
+		if [[ "${w}" = "$1"* ]]; then
+			echo "${w}"
+		fi
+	done
+}
+
+__openshift-install_compopt() {
+	true # not supported by bashcompinit in zsh
+}
+
+__openshift-install_ltrim_colon_completions()
+{
+	if [[ "$1" == *:* && "$COMP_WORDBREAKS" == *:* ]]; then
+		# Remove colon-word prefix from COMPREPLY items
+		local colon_word=${1%${1##*:}}
+		local i=${#COMPREPLY[*]}
+		while [[ $((--i)) -ge 0 ]]; do
+			COMPREPLY[$i]=${COMPREPLY[$i]#"$colon_word"}
+		done
+	fi
+}
+
+__openshift-install_get_comp_words_by_ref() {
+	cur="${COMP_WORDS[COMP_CWORD]}"
+	prev="${COMP_WORDS[${COMP_CWORD}-1]}"
+	words=("${COMP_WORDS[@]}")
+	cword=("${COMP_CWORD[@]}")
+}
+
+__openshift-install_filedir() {
+	local RET OLD_IFS w qw
+
+	OLD_IFS="$IFS"
+	IFS=$'\n'
+	if [ "$1" = "-d" ]; then
+		shift
+		RET=( $(compgen -d) )
+	else
+		RET=( $(compgen -f) )
+	fi
+	IFS="$OLD_IFS"
+
+	for w in ${RET[@]}; do
+		if [[ ! "${w}" = "${cur}"* ]]; then
+			continue
+		fi
+		if eval "[[ \"\${w}\" = *.$1 || -d \"\${w}\" ]]"; then
+			qw="$(__openshift-install_quote "${w}")"
+			if [ -d "${w}" ]; then
+				COMPREPLY+=("${qw}/")
+			else
+				COMPREPLY+=("${qw}")
+			fi
+		fi
+	done
+}
+
+__openshift-install_quote() {
+	if [[ $1 == \'* || $1 == \"* ]]; then
+		# Leave out first character
+		printf %q "${1:1}"
+	else
+		printf %q "$1"
+	fi
+}
+
+autoload -U +X bashcompinit && bashcompinit
+
+# use word boundary patterns for BSD or GNU sed
+LWORD='[[:<:]]'
+RWORD='[[:>:]]'
+if sed --help 2>&1 | grep -q GNU; then
+	LWORD='\<'
+	RWORD='\>'
+fi
+
+__openshift-install_convert_bash_to_zsh() {
+	sed \
+	-e 's/declare -F/whence -w/' \
+	-e 's/_get_comp_words_by_ref "\$@"/_get_comp_words_by_ref "\$*"/' \
+	-e 's/local \([a-zA-Z0-9_]*\)=/local \1; \1=/' \
+	-e 's/flags+=("\(--.*\)=")/flags+=("\1"); two_word_flags+=("\1")/' \
+	-e 's/must_have_one_flag+=("\(--.*\)=")/must_have_one_flag+=("\1")/' \
+	-e "s/${LWORD}_filedir${RWORD}/__openshift-install_filedir/g" \
+	-e "s/${LWORD}_get_comp_words_by_ref${RWORD}/__openshift-install_get_comp_words_by_ref/g" \
+	-e "s/${LWORD}__ltrim_colon_completions${RWORD}/__openshift-install_ltrim_colon_completions/g" \
+	-e "s/${LWORD}compgen${RWORD}/__openshift-install_compgen/g" \
+	-e "s/${LWORD}compopt${RWORD}/__openshift-install_compopt/g" \
+	-e "s/${LWORD}declare${RWORD}/builtin declare/g" \
+	-e "s/\\\$(type${RWORD}/\$(__openshift-install_type/g" \
+	<<'BASH_COMPLETION_EOF'
+`
+
+const zshCompletionTail = `
+BASH_COMPLETION_EOF
+}
+
+__openshift-install_bash_source <(__openshift-install_convert_bash_to_zsh)
+_complete openshift-install 2>/dev/null
+`
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/rhcos"
+)
+
+func newCoreOSCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "coreos",
+		Short: "Commands for operating on CoreOS boot images",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newCoreOSPrintStreamJSONCmd())
+	return cmd
+}
+
+func newCoreOSPrintStreamJSONCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "print-stream-json",
+		Short: "Outputs the CoreOS build the installer uses as JSON",
+		Long: strings.TrimSpace(`
+Outputs the CoreOS stream metadata of the RHCOS build this installer
+installs: for each architecture, the location and checksum of the image of
+each platform, and the AMI for each AWS region. Tooling which provisions its
+own infrastructure can use it to boot exactly the images this installer was
+tested with.
+`),
+		Args: cobra.ExactArgs(0),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+			defer cancel()
+
+			stream, err := rhcos.LoadStream(ctx)
+			if err != nil {
+				return errors.Wrap(err, "failed to load the RHCOS build")
+			}
+
+			data, err := json.MarshalIndent(stream, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		},
+	}
+}
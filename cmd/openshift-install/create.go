@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -19,23 +21,25 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
+	configv1 "github.com/openshift/api/config/v1"
 	routeclient "github.com/openshift/client-go/route/clientset/versioned"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/cluster"
-	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
+	"github.com/openshift/installer/pkg/asset/ignition"
 	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/kubeconfig"
-	"github.com/openshift/installer/pkg/asset/manifests"
-	"github.com/openshift/installer/pkg/asset/templates"
+	targetassets "github.com/openshift/installer/pkg/asset/targets"
 	"github.com/openshift/installer/pkg/asset/tls"
 	destroybootstrap "github.com/openshift/installer/pkg/destroy/bootstrap"
+	"github.com/openshift/installer/pkg/terraform"
+	"github.com/openshift/installer/pkg/types"
 )
 
 type target struct {
 	name    string
 	command *cobra.Command
-	assets  []asset.WritableAsset
+	assets  func() []asset.WritableAsset
 }
 
 // each target is a variable to preserve the order when creating subcommands and still
@@ -49,7 +53,7 @@ var (
 			// FIXME: add longer descriptions for our commands with examples for better UX.
 			// Long:  "",
 		},
-		assets: []asset.WritableAsset{&installconfig.InstallConfig{}},
+		assets: targetassets.InstallConfig,
 	}
 
 	manifestsTarget = target{
@@ -57,10 +61,16 @@ var (
 		command: &cobra.Command{
 			Use:   "manifests",
 			Short: "Generates the Kubernetes manifests",
-			// FIXME: add longer descriptions for our commands with examples for better UX.
-			// Long:  "",
+			Long: strings.TrimSpace(`
+Generates the Kubernetes manifests that will be installed on the cluster into
+the manifests and openshift directories of the asset directory.
+
+The manifests may be reviewed and edited before continuing with a later target
+such as 'create ignition-configs' or 'create cluster', which will consume the
+on-disk manifests instead of generating them again.
+`),
 		},
-		assets: []asset.WritableAsset{&manifests.Manifests{}, &manifests.Openshift{}},
+		assets: targetassets.Manifests,
 	}
 
 	manifestTemplatesTarget = target{
@@ -70,7 +80,7 @@ var (
 			Short: "Generates the unrendered Kubernetes manifest templates",
 			Long:  "",
 		},
-		assets: []asset.WritableAsset{&templates.Templates{}},
+		assets: targetassets.ManifestTemplates,
 	}
 
 	ignitionConfigsTarget = target{
@@ -81,7 +91,66 @@ var (
 			// FIXME: add longer descriptions for our commands with examples for better UX.
 			// Long:  "",
 		},
-		assets: []asset.WritableAsset{&bootstrap.Bootstrap{}, &machine.Master{}, &machine.Worker{}, &kubeconfig.Admin{}, &cluster.Metadata{}},
+		assets: targetassets.IgnitionConfigs,
+	}
+
+	pxeConfigTarget = target{
+		name: "PXE Config",
+		command: &cobra.Command{
+			Use:   "pxe-config",
+			Short: "Generates the files to netboot the cluster's machines",
+			Long: strings.TrimSpace(`
+Generates, into the pxe directory of the asset directory, an iPXE script which
+installs RHCOS to the disk of a bare-metal machine, and the Ignition configs of
+the bootstrap, master, and worker roles which the installation is pointed at.
+
+The script fetches the RHCOS kernel, initramfs, and metal image from the RHCOS
+release server, and the Ignition configs from ${base-url}, which defaults to
+the HTTP server of the DHCP next-server. It asks for the role unless ${role}
+is already set. Only the 'none' platform is supported.
+`),
+		},
+		assets: targetassets.PXEConfig,
+	}
+
+	upiArtifactsTarget = target{
+		name: "UPI Artifacts",
+		command: &cobra.Command{
+			Use:   "upi-artifacts",
+			Short: "Generates the files to create the cluster on user-provisioned infrastructure",
+			Long: strings.TrimSpace(`
+Generates the Ignition configs and, into the upi directory of the asset
+directory, the DNS records which the cluster expects, the variables of the
+cluster for Ansible playbooks, and on AWS the parameters of CloudFormation
+templates, without creating any infrastructure.
+
+Create the infrastructure with them, boot the machines with the Ignition
+configs, and follow the installation with 'wait-for bootstrap-complete' and
+'wait-for install-complete'.
+`),
+		},
+		assets: targetassets.UPIArtifacts,
+	}
+
+	capiManifestsTarget = target{
+		name: "Cluster API Manifests",
+		command: &cobra.Command{
+			Use:   "capi-manifests",
+			Short: "Generates the Cluster API resources of the cluster",
+			Long: strings.TrimSpace(`
+Generates, into the capi directory of the asset directory, the machines of
+the cluster as Cluster API resources: the Cluster and AWSCluster, a Machine
+and AWSMachine for each master, a MachineDeployment and AWSMachineTemplate
+for each worker machineset, and the secrets of their Ignition configs, in a
+namespace named after the infrastructure name of the cluster. Only the aws
+platform is supported.
+
+The AWSCluster is annotated as managed by the installer, because 'create
+cluster' creates the network, load balancers and security groups of the
+cluster with Terraform, which the machines refer to by their tags.
+`),
+		},
+		assets: targetassets.CAPIManifests,
 	}
 
 	clusterTarget = target{
@@ -92,6 +161,10 @@ var (
 			// FIXME: add longer descriptions for our commands with examples for better UX.
 			// Long:  "",
 			PostRun: func(_ *cobra.Command, _ []string) {
+				if createClusterOpts.dryRun {
+					return
+				}
+
 				ctx := context.Background()
 
 				cleanup := setupFileHook(rootOpts.dir)
@@ -102,88 +175,248 @@ var (
 					logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
 				}
 
-				err = destroyBootstrap(ctx, config, rootOpts.dir)
+				setPhase("wait-for bootstrap-complete")
+				clusterProgress.startPhase(1)
+				stopJournal := followBootstrapJournal(ctx, rootOpts.dir)
+				err = waitForBootstrapComplete(ctx, config, defaultBootstrapTimeout)
+				stopJournal()
 				if err != nil {
+					logGatherBootstrap(rootOpts.dir)
 					logrus.Fatal(err)
 				}
+				recordMilestone(types.MilestoneBootstrapComplete)
 
-				consoleURL, err := waitForConsole(ctx, config, rootOpts.dir)
+				setPhase("destroy bootstrap")
+				clusterProgress.startPhase(2)
+				logrus.Info("Destroying the bootstrap resources...")
+				err = destroybootstrap.Destroy(rootOpts.dir)
 				if err != nil {
 					logrus.Fatal(err)
 				}
 
-				err = logComplete(rootOpts.dir, consoleURL)
+				setPhase("wait-for install-complete")
+				clusterProgress.startPhase(3)
+				err = waitForInstallComplete(ctx, config, rootOpts.dir, defaultInstallTimeout)
 				if err != nil {
+					logGatherBootstrap(rootOpts.dir)
 					logrus.Fatal(err)
 				}
+				recordMilestone(types.MilestoneInstallComplete)
+				clusterProgress.finish()
+				reportInstall(false)
 			},
 		},
-		assets: []asset.WritableAsset{&cluster.TerraformVariables{}, &kubeconfig.Admin{}, &tls.JournalCertKey{}, &cluster.Metadata{}, &cluster.Cluster{}},
+		assets: targetassets.Cluster,
 	}
 
-	targets = []target{installConfigTarget, manifestTemplatesTarget, manifestsTarget, ignitionConfigsTarget, clusterTarget}
+	targets = []target{installConfigTarget, manifestTemplatesTarget, manifestsTarget, ignitionConfigsTarget, pxeConfigTarget, upiArtifactsTarget, capiManifestsTarget, clusterTarget}
+)
+
+var (
+	createOpts struct {
+		rollback        bool
+		extraManifests  string
+		mergeKubeconfig string
+	}
+
+	createClusterOpts struct {
+		dryRun          bool
+		reportMetricsTo string
+	}
+
+	// clusterProgress reports the progress of 'create cluster' through
+	// clusterPhases.
+	clusterProgress = newProgressReporter(clusterPhases)
 )
 
 func newCreateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create part of an OpenShift cluster",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := runRootCmd(cmd, args); err != nil {
+				return err
+			}
+			if err := tls.CheckValidity(); err != nil {
+				return err
+			}
+			return ignition.CheckSpecVersion()
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 
+	cmd.PersistentFlags().DurationVar(&tls.RootCAValidity, "root-ca-validity", tls.RootCAValidity, "the validity of the generated root and etcd CAs")
+	cmd.PersistentFlags().DurationVar(&tls.CAValidity, "ca-validity", tls.CAValidity, "the validity of the generated CAs signed by the root CA")
+	cmd.PersistentFlags().DurationVar(&tls.CertValidity, "cert-validity", tls.CertValidity, "the validity of the other generated certificates")
+	cmd.PersistentFlags().StringVar(&ignition.SpecVersion, "ignition-version", ignition.SpecVersion, fmt.Sprintf("the version of the Ignition config spec in which the Ignition configs are written (%q or %q)", ignition.SpecV2, ignition.SpecV3))
+	cmd.PersistentFlags().BoolVar(&createOpts.rollback, "rollback-on-failure", false, "if the target fails, remove the files written to the asset directory and restore the files removed from it")
+	cmd.PersistentFlags().StringVar(&createOpts.extraManifests, "extra-manifests", "", "directory whose manifests and openshift subdirectories hold manifests to add to the generated ones")
+	cmd.PersistentFlags().SetAnnotation("extra-manifests", cobra.BashCompSubdirsInDir, []string{})
+	cmd.PersistentFlags().StringVar(&machine.ExternalConfigsDir, "external-configs", "", "directory of externally generated MachineConfig manifests, and of master and worker subdirectories of Ignition configs, to merge into the generated Ignition configs")
+	cmd.PersistentFlags().SetAnnotation("external-configs", cobra.BashCompSubdirsInDir, []string{})
+	cmd.PersistentFlags().StringVar(&createOpts.mergeKubeconfig, "merge-kubeconfig", "", "merge the admin kubeconfig into this kubeconfig file, or into the default one of $KUBECONFIG or ~/.kube/config if no file is given, under a context named after the cluster")
+	cmd.PersistentFlags().Lookup("merge-kubeconfig").NoOptDefVal = clientcmd.NewDefaultPathOptions().GetDefaultFilename()
+	cmd.PersistentFlags().BoolVar(&asset.KeepConsumed, "keep-inputs", false, "keep the files consumed by the target, such as install-config.yaml, in the asset directory instead of removing them")
+
 	for _, t := range targets {
-		t.command.Run = runTargetCmd(t.assets...)
+		t.command.Run = runTargetCmd(t.assets)
 		cmd.AddCommand(t.command)
 	}
+	cmd.AddCommand(newCreateAssetCmd())
+
+	createCluster := clusterTarget.command.Run
+	clusterTarget.command.Run = func(cmd *cobra.Command, args []string) {
+		if !createClusterOpts.dryRun {
+			cluster.RecordMilestone = recordMilestone
+			// Failures exit through logrus.Fatal.
+			logrus.RegisterExitHandler(func() { reportInstall(true) })
+			clusterProgress.startPhase(0)
+			createCluster(cmd, args)
+			return
+		}
+
+		cleanup := setupFileHook(rootOpts.dir)
+		defer cleanup()
+
+		if err := runClusterDryRun(rootOpts.dir); err != nil {
+			logrus.Fatal(err)
+		}
+	}
+	clusterTarget.command.Flags().BoolVar(&createClusterOpts.dryRun, "dry-run", false, "generate the assets in a temporary directory and show the resources that would be created, without creating them")
+	clusterTarget.command.Flags().BoolVar(&cluster.SkipDNSPreflight, "skip-dns-preflight", false, "do not check the delegation of the base domain and whether the cluster's DNS records already exist before creating the cluster resources")
+	clusterTarget.command.Flags().BoolVar(&cluster.SkipQuotaChecks, "skip-quota-checks", false, "do not check that the quotas of the platform's account leave room for the cluster's resources before creating them")
+	clusterTarget.command.Flags().BoolVar(&cluster.SkipProxyPreflight, "skip-proxy-preflight", false, "do not check that the registries of the release image can be reached through the proxy before creating the cluster resources")
+	clusterTarget.command.Flags().BoolVar(&cluster.SkipPermissionsPreflight, "skip-permissions-preflight", false, "do not simulate the policies of the AWS credentials to check for missing permissions before creating the cluster resources")
+	clusterTarget.command.Flags().StringVar(&createClusterOpts.reportMetricsTo, "report-metrics-to", "", "opt in to posting anonymous metrics of the outcome of the install (the platform, the duration of each phase and the category of any failure) to this URL")
+	clusterTarget.command.Flags().BoolVar(&cluster.ConfirmPlan, "confirm", false, "show a summary of the Terraform plan and ask for its approval before creating the cluster resources")
 
 	return cmd
 }
 
-func runTargetCmd(targets ...asset.WritableAsset) func(cmd *cobra.Command, args []string) {
+func runTargetCmd(assets func() []asset.WritableAsset) func(cmd *cobra.Command, args []string) {
 	runner := func(directory string) error {
-		assetStore, err := asset.NewStore(directory)
+		targets := assets()
+		if createOpts.rollback {
+			// The Terraform state and the metadata record the created
+			// resources, so the directory is not rolled back once they
+			// are written.
+			guarded := []string{terraform.StateFileName, "metadata.json"}
+			return asset.RunWithRollback(directory, []string{logFileName}, guarded, func() error {
+				return fetchTargets(directory, targets)
+			})
+		}
+		return fetchTargets(directory, targets)
+	}
+
+	return func(cmd *cobra.Command, args []string) {
+		cleanup := setupFileHook(rootOpts.dir)
+		defer cleanup()
+
+		err := runner(rootOpts.dir)
 		if err != nil {
-			return errors.Wrapf(err, "failed to create asset store")
+			logrus.Fatal(err)
 		}
+	}
+}
 
-		for _, a := range targets {
-			err := assetStore.Fetch(a)
-			if err != nil {
-				err = errors.Wrapf(err, "failed to fetch %s", a.Name())
-			}
+// fetchTargets fetches the targets from the asset store of the directory,
+// and writes them to it.
+func fetchTargets(directory string, targets []asset.WritableAsset) error {
+	if createOpts.extraManifests != "" {
+		if err := copyExtraManifests(createOpts.extraManifests, directory); err != nil {
+			return err
+		}
+	}
 
-			if err2 := asset.PersistToFile(a, directory); err2 != nil {
-				err2 = errors.Wrapf(err2, "failed to write asset (%s) to disk", a.Name())
-				if err != nil {
-					logrus.Error(err2)
-					return err
-				}
-				return err2
-			}
+	assetStore, err := asset.NewStore(directory)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create asset store")
+	}
+
+	for _, a := range targets {
+		err := assetStore.Fetch(a)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to fetch %s", a.Name())
+		}
 
+		if err2 := asset.PersistToFile(a, directory); err2 != nil {
+			err2 = errors.Wrapf(err2, "failed to write asset (%s) to disk", a.Name())
 			if err != nil {
+				logrus.Error(err2)
 				return err
 			}
+			return err2
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if admin, ok := a.(*kubeconfig.Admin); ok && createOpts.mergeKubeconfig != "" {
+			mergeKubeconfig(admin, createOpts.mergeKubeconfig)
 		}
-		return nil
 	}
+	return nil
+}
 
-	return func(cmd *cobra.Command, args []string) {
-		cleanup := setupFileHook(rootOpts.dir)
-		defer cleanup()
+// mergeKubeconfig merges the admin kubeconfig into the kubeconfig file at
+// path. It only warns on failure, because the admin kubeconfig is still
+// written to the asset directory.
+func mergeKubeconfig(admin *kubeconfig.Admin, path string) {
+	context, err := admin.Merge(path)
+	if err != nil {
+		logrus.Warnf("Failed to merge the admin kubeconfig into %s: %v", path, err)
+		return
+	}
+	logrus.Infof("Merged the admin kubeconfig into %s as the current context %q", path, context)
+}
 
-		err := runner(rootOpts.dir)
-		if err != nil {
-			logrus.Fatal(err)
+// recordMilestone records the milestone of the creation of the cluster in
+// the metadata of the asset directory, for the analysis of slow installs.
+// It only warns on failure, which must not fail the install.
+func recordMilestone(name string) {
+	if err := cluster.AddMilestone(rootOpts.dir, name); err != nil {
+		logrus.Warnf("Failed to record the %s milestone: %v", name, err)
+	}
+}
+
+// copyExtraManifests copies the manifests in the manifests and openshift
+// subdirectories of src to the extra-manifests directory of the asset
+// directory, from which they are loaded and added to the generated ones.
+func copyExtraManifests(src string, directory string) error {
+	for _, dir := range []string{"manifests", "openshift"} {
+		files, err := ioutil.ReadDir(filepath.Join(src, dir))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return errors.Wrap(err, "failed to read the extra manifests")
+		}
+
+		dest := filepath.Join(directory, "extra-manifests", dir)
+		if err := os.MkdirAll(dest, 0755); err != nil {
+			return errors.Wrap(err, "failed to create the extra manifests directory")
+		}
+		for _, file := range files {
+			if file.IsDir() {
+				continue
+			}
+			data, err := ioutil.ReadFile(filepath.Join(src, dir, file.Name()))
+			if err != nil {
+				return errors.Wrap(err, "failed to read the extra manifests")
+			}
+			if err := ioutil.WriteFile(filepath.Join(dest, file.Name()), data, 0644); err != nil {
+				return errors.Wrap(err, "failed to copy the extra manifests")
+			}
 		}
 	}
+	return nil
 }
 
-// FIXME: pulling the kubeconfig and metadata out of the root
-// directory is a bit cludgy when we already have them in memory.
-func destroyBootstrap(ctx context.Context, config *rest.Config, directory string) (err error) {
+// waitForBootstrapComplete waits, for up to timeout each, for the Kubernetes
+// API to come up and for the bootstrap-complete event to be emitted.
+func waitForBootstrapComplete(ctx context.Context, config *rest.Config, timeout time.Duration) (err error) {
 	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return errors.Wrap(err, "creating a Kubernetes client")
@@ -191,7 +424,7 @@ func destroyBootstrap(ctx context.Context, config *rest.Config, directory string
 
 	discovery := client.Discovery()
 
-	apiTimeout := 30 * time.Minute
+	apiTimeout := timeout
 	logrus.Infof("Waiting up to %v for the Kubernetes API...", apiTimeout)
 	apiContext, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
@@ -227,7 +460,7 @@ func destroyBootstrap(ctx context.Context, config *rest.Config, directory string
 
 	events := client.CoreV1().Events("kube-system")
 
-	eventTimeout := 30 * time.Minute
+	eventTimeout := timeout
 	logrus.Infof("Waiting up to %v for the bootstrap-complete event...", eventTimeout)
 	eventContext, cancel := context.WithTimeout(ctx, eventTimeout)
 	defer cancel()
@@ -274,8 +507,94 @@ func destroyBootstrap(ctx context.Context, config *rest.Config, directory string
 		return errors.Wrap(err, "waiting for bootstrap-complete")
 	}
 
-	logrus.Info("Destroying the bootstrap resources...")
-	return destroybootstrap.Destroy(rootOpts.dir)
+	return nil
+}
+
+// waitForInitializedCluster polls the cluster version, for up to timeout,
+// until it reports that the release payload has been applied.
+func waitForInitializedCluster(ctx context.Context, config *rest.Config, timeout time.Duration) error {
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "creating a Kubernetes client")
+	}
+
+	restClient := client.Discovery().RESTClient()
+	// The operators are first summarized once they have had a chance to
+	// report their status.
+	operators := &operatorSummary{restClient: restClient, last: time.Now()}
+
+	logrus.Infof("Waiting up to %v for the cluster to initialize...", timeout)
+	cvContext, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	// Poll quickly, but only log when the cluster-version operator's
+	// message changes or when we've seen 15 of the same errors in a row.
+	logDownsample := 15
+	silenceRemaining := logDownsample
+	previousMessage := ""
+	wait.Until(func() {
+		data, err := restClient.Get().AbsPath("/apis", configv1.GroupName, configv1.GroupVersion.Version, "clusterversions", "version").DoRaw()
+		if err != nil {
+			silenceRemaining--
+			if silenceRemaining == 0 {
+				logrus.Debugf("Still waiting for the cluster version: %v", err)
+				silenceRemaining = logDownsample
+			}
+			return
+		}
+
+		cv := &configv1.ClusterVersion{}
+		if err := json.Unmarshal(data, cv); err != nil {
+			logrus.Debugf("Failed to decode the cluster version: %v", err)
+			return
+		}
+
+		message := ""
+		for _, condition := range cv.Status.Conditions {
+			if condition.Status != configv1.ConditionTrue {
+				continue
+			}
+			switch condition.Type {
+			case configv1.OperatorAvailable:
+				logrus.Debugf("Cluster is initialized: %s", condition.Message)
+				cancel()
+				return
+			case configv1.OperatorFailing:
+				message = condition.Message
+			case configv1.OperatorProgressing:
+				if message == "" {
+					message = condition.Message
+				}
+			}
+		}
+		if message != "" && message != previousMessage {
+			logrus.Debugf("Still waiting for the cluster to initialize: %s", message)
+			previousMessage = message
+		}
+		operators.log()
+	}, 2*time.Second, cvContext.Done())
+	err = cvContext.Err()
+	if err != nil && err != context.Canceled {
+		if previousMessage != "" {
+			return errors.Wrapf(err, "failed to initialize the cluster: %s", previousMessage)
+		}
+		return errors.Wrap(err, "failed to initialize the cluster")
+	}
+	return nil
+}
+
+// waitForInstallComplete waits for the cluster to initialize and its console
+// to come up, and then prints the information needed to access the cluster.
+func waitForInstallComplete(ctx context.Context, config *rest.Config, directory string, timeout time.Duration) error {
+	if err := waitForInitializedCluster(ctx, config, timeout); err != nil {
+		return err
+	}
+
+	consoleURL, err := waitForConsole(ctx, config, directory)
+	if err != nil {
+		return err
+	}
+
+	return logComplete(directory, consoleURL)
 }
 
 // waitForconsole returns the console URL from the route 'console' in namespace openshift-console
@@ -339,16 +658,44 @@ func logComplete(directory, consoleURL string) error {
 	if err != nil {
 		return err
 	}
-	kubeconfig := filepath.Join(absDir, "auth", "kubeconfig")
-	pwFile := filepath.Join(absDir, "auth", "kubeadmin-password")
-	pw, err := ioutil.ReadFile(pwFile)
+	disabled, err := kubeadminDisabled(directory)
 	if err != nil {
 		return err
 	}
+	kubeconfig := filepath.Join(absDir, "auth", "kubeconfig")
 	logrus.Info("Install complete!")
 	logrus.Infof("Run 'export KUBECONFIG=%s' to manage the cluster with 'oc', the OpenShift CLI.", kubeconfig)
-	logrus.Infof("The cluster is ready when 'oc login -u kubeadmin -p %s' succeeds (wait a few minutes).", pw)
+	if disabled {
+		logrus.Infof("Access the OpenShift web-console here: %s", consoleURL)
+		logrus.Info("There is no kubeadmin user; login to the console with the identity providers of the install-config")
+		return nil
+	}
+
+	// The password is redacted from the logs, so point at its file
+	// instead.
+	pwFile := filepath.Join(absDir, "auth", "kubeadmin-password")
+	if _, err := os.Stat(pwFile); err != nil {
+		return err
+	}
+	logrus.Infof("The cluster is ready when 'oc login -u kubeadmin -p \"$(cat %s)\"' succeeds (wait a few minutes).", pwFile)
 	logrus.Infof("Access the OpenShift web-console here: %s", consoleURL)
-	logrus.Infof("Login to the console with user: kubeadmin, password: %s", pw)
+	logrus.Infof("Login to the console with user: kubeadmin, and the password in %s", pwFile)
 	return nil
 }
+
+// kubeadminDisabled returns whether the install-config recorded in the
+// state file of the directory disabled the kubeadmin user.
+func kubeadminDisabled(directory string) (bool, error) {
+	store, err := asset.NewStore(directory)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create asset store")
+	}
+	config, err := store.Load(&installconfig.InstallConfig{})
+	if err != nil {
+		return false, err
+	}
+	if config == nil {
+		return false, nil
+	}
+	return config.(*installconfig.InstallConfig).Config.Kubeadmin == types.DisabledKubeadminPolicy, nil
+}
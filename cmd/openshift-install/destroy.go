@@ -1,15 +1,34 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster"
 	"github.com/openshift/installer/pkg/destroy"
+	_ "github.com/openshift/installer/pkg/destroy/aws"
 	"github.com/openshift/installer/pkg/destroy/bootstrap"
 	_ "github.com/openshift/installer/pkg/destroy/libvirt"
 	_ "github.com/openshift/installer/pkg/destroy/openstack"
+	"github.com/openshift/installer/pkg/types"
 )
 
 func newDestroyCmd() *cobra.Command {
@@ -26,40 +45,293 @@ func newDestroyCmd() *cobra.Command {
 	return cmd
 }
 
+var (
+	destroyClusterOpts struct {
+		dryRun      bool
+		timeout     time.Duration
+		metadata    string
+		kubeconfig  string
+		region      string
+		credentials string
+
+		resourceTypes         []string
+		excludedResourceTypes []string
+
+		yes              bool
+		confirmThreshold int
+	}
+)
+
 func newDestroyClusterCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "cluster",
 		Short: "Destroy an OpenShift cluster",
 		Run: func(_ *cobra.Command, _ []string) {
 			cleanup := setupFileHook(rootOpts.dir)
 			defer cleanup()
 
-			err := runDestroyCmd(rootOpts.dir)
+			var err error
+			if destroyClusterOpts.dryRun {
+				err = runDestroyDryRun(rootOpts.dir, os.Stdout)
+			} else {
+				err = runDestroyCmd(rootOpts.dir, destroyClusterOpts.timeout)
+			}
 			if err != nil {
 				logrus.Fatal(err)
 			}
 		},
 	}
+	cmd.Flags().BoolVar(&destroyClusterOpts.dryRun, "dry-run", false, "list the resources that would be deleted, grouped by type, without deleting them")
+	cmd.Flags().DurationVar(&destroyClusterOpts.timeout, "timeout", 0, "stop after this long and save the progress, from which the next run resumes (0 for no timeout)")
+	cmd.Flags().StringVar(&destroyClusterOpts.metadata, "metadata", "", "destroy the cluster of this metadata.json instead of that of the asset directory, whose assets are then kept")
+	cmd.Flags().StringVar(&destroyClusterOpts.kubeconfig, "from-cluster", "", "destroy the cluster which this kubeconfig points at, whose metadata is read from the cluster, instead of that of the asset directory, whose assets are then kept")
+	cmd.Flags().StringVar(&destroyClusterOpts.region, "region", "", "override the region of the cluster metadata (AWS only)")
+	cmd.Flags().StringVar(&destroyClusterOpts.credentials, "credentials", "", "destroy with these credentials: the profile of the shared AWS credentials, or the cloud of the OpenStack clouds.yaml")
+	cmd.Flags().StringSliceVar(&destroyClusterOpts.resourceTypes, "resource-types", nil, "only destroy the resources of these types, as listed by --dry-run, such as ec2:instance or elasticloadbalancing, and mark the metadata as partially destroyed")
+	cmd.Flags().StringSliceVar(&destroyClusterOpts.excludedResourceTypes, "exclude-resource-types", nil, "do not destroy the resources of these types, and mark the metadata as partially destroyed")
+	cmd.Flags().BoolVar(&destroyClusterOpts.yes, "yes", false, "destroy without confirmation, even if more resources than --confirm-threshold match the cluster")
+	cmd.Flags().IntVar(&destroyClusterOpts.confirmThreshold, "confirm-threshold", 300, "require --yes, or an interactive confirmation, to destroy more resources than this")
+	return cmd
+}
+
+// partialDestroy returns true if destroy cluster is scoped to some resource
+// types.
+func partialDestroy() bool {
+	return len(destroyClusterOpts.resourceTypes) > 0 || len(destroyClusterOpts.excludedResourceTypes) > 0
 }
 
-func runDestroyCmd(directory string) error {
-	destroyer, err := destroy.New(logrus.StandardLogger(), directory)
+// newClusterDestroyer returns the destroyer of the cluster of the asset
+// directory or, with --metadata, of that metadata file or, with
+// --from-cluster, of the live cluster, using the region and credentials of
+// the flags if they are set.
+func newClusterDestroyer(directory string) (destroy.Destroyer, error) {
+	var metadata *types.ClusterMetadata
+	var err error
+	switch {
+	case destroyClusterOpts.metadata != "" && destroyClusterOpts.kubeconfig != "":
+		return nil, errors.New("--metadata and --from-cluster are mutually exclusive")
+	case destroyClusterOpts.metadata != "":
+		metadata, err = cluster.LoadMetadataFile(destroyClusterOpts.metadata)
+	case destroyClusterOpts.kubeconfig != "":
+		metadata, err = liveClusterMetadata(destroyClusterOpts.kubeconfig)
+	default:
+		metadata, err = cluster.LoadMetadata(directory)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, partial := range metadata.PartialDestroys {
+		logrus.Infof("The cluster was partially destroyed at %s", partial.Time.Format(time.RFC3339))
+	}
+
+	if destroyClusterOpts.region != "" {
+		if metadata.AWS == nil {
+			return nil, errors.New("--region is not supported on this platform")
+		}
+		metadata.AWS.Region = destroyClusterOpts.region
+	}
+
+	destroyer, err := destroy.NewFromMetadata(logrus.StandardLogger(), metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if destroyClusterOpts.credentials != "" {
+		setter, ok := destroyer.(destroy.CredentialsSetter)
+		if !ok {
+			return nil, errors.New("--credentials is not supported on this platform")
+		}
+		setter.SetCredentials(destroyClusterOpts.credentials)
+	}
+
+	if partialDestroy() {
+		scoper, ok := destroyer.(destroy.TypeScoper)
+		if !ok {
+			return nil, errors.New("--resource-types and --exclude-resource-types are not supported on this platform")
+		}
+		scoper.ScopeTypes(destroyClusterOpts.resourceTypes, destroyClusterOpts.excludedResourceTypes)
+	}
+	return destroyer, nil
+}
+
+// markPartiallyDestroyed records the partial destroy in the metadata file,
+// so that the cluster is not mistaken for an intact one.
+func markPartiallyDestroyed(directory string) error {
+	if destroyClusterOpts.kubeconfig != "" {
+		logrus.Warn("The cluster is partially destroyed, but its metadata was read from the cluster and cannot be marked")
+		return nil
+	}
+
+	path := destroyClusterOpts.metadata
+	if path == "" {
+		path = filepath.Join(directory, "metadata.json")
+	}
+	metadata, err := cluster.LoadMetadataFile(path)
+	if err != nil {
+		return err
+	}
+	metadata.PartialDestroys = append(metadata.PartialDestroys, types.PartialDestroy{
+		Time:                  time.Now().UTC(),
+		ResourceTypes:         destroyClusterOpts.resourceTypes,
+		ExcludedResourceTypes: destroyClusterOpts.excludedResourceTypes,
+	})
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to mark %s as partially destroyed", path)
+	}
+	logrus.Infof("Marked %s as partially destroyed", path)
+	return nil
+}
+
+// liveClusterMetadata returns the metadata of the cluster which the
+// kubeconfig points at, for clusters whose asset directory was lost. It is
+// built from the install config in kube-system/cluster-config-v1 and the
+// cluster ID of the cluster version, like the metadata.json of the asset
+// directory, and is refused unless the Infrastructure object agrees on the
+// name and platform of the cluster.
+func liveClusterMetadata(kubeconfig string) (*types.ClusterMetadata, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading kubeconfig")
+	}
+	config.Timeout = 30 * time.Second
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating a Kubernetes client")
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps("kube-system").Get("cluster-config-v1", metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting kube-system/cluster-config-v1")
+	}
+	installConfig := &types.InstallConfig{}
+	if err := yaml.Unmarshal([]byte(configMap.Data["install-config"]), installConfig); err != nil {
+		return nil, errors.Wrap(err, "decoding the install config of kube-system/cluster-config-v1")
+	}
+
+	data, err := client.Discovery().RESTClient().Get().AbsPath("/apis", configv1.GroupName, configv1.GroupVersion.Version, "clusterversions", "version").DoRaw()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting the cluster version")
+	}
+	cv := &configv1.ClusterVersion{}
+	if err := json.Unmarshal(data, cv); err != nil {
+		return nil, errors.Wrap(err, "decoding the cluster version")
+	}
+	if cv.Spec.ClusterID == "" {
+		return nil, errors.New("the cluster version has no cluster ID")
+	}
+
+	data, err = client.Discovery().RESTClient().Get().AbsPath("/apis", configv1.GroupName, configv1.GroupVersion.Version, "infrastructures", "cluster").DoRaw()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting the infrastructure")
+	}
+	// The vendored Infrastructure lacks the infrastructure name.
+	infra := &struct {
+		Status struct {
+			InfrastructureName string                `json:"infrastructureName"`
+			Platform           configv1.PlatformType `json:"platform"`
+		} `json:"status"`
+	}{}
+	if err := json.Unmarshal(data, infra); err != nil {
+		return nil, errors.Wrap(err, "decoding the infrastructure")
+	}
+	if infra.Status.InfrastructureName != installConfig.ObjectMeta.Name {
+		return nil, errors.Errorf("the infrastructure name %q does not match the cluster name %q of the install config", infra.Status.InfrastructureName, installConfig.ObjectMeta.Name)
+	}
+
+	metadata, err := cluster.NewMetadata(string(cv.Spec.ClusterID), installConfig)
+	if err != nil {
+		return nil, err
+	}
+	if platform := metadata.Platform(); !strings.EqualFold(platform, string(infra.Status.Platform)) {
+		return nil, errors.Errorf("the infrastructure platform %q does not match the platform %q of the install config", infra.Status.Platform, platform)
+	}
+	logrus.Infof("Destroying cluster %s (%s) on %s, as read from the cluster", metadata.ClusterName, metadata.ClusterID, metadata.Platform())
+	return metadata, nil
+}
+
+func runDestroyCmd(directory string, timeout time.Duration) error {
+	destroyer, err := newClusterDestroyer(directory)
 	if err != nil {
 		return errors.Wrap(err, "Failed while preparing to destroy cluster")
 	}
-	if err := destroyer.Run(); err != nil {
-		return errors.Wrap(err, "Failed to destroy cluster")
+
+	progressPath := filepath.Join(directory, destroy.ProgressFilename)
+	resumer, resumable := destroyer.(destroy.Resumer)
+	if resumable {
+		progress, err := ioutil.ReadFile(progressPath)
+		if err == nil {
+			logrus.Infof("Resuming from the progress saved in %s", progressPath)
+			if err := resumer.Resume(progress); err != nil {
+				return errors.Wrapf(err, "failed to resume from %s", progressPath)
+			}
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+		if timeout > 0 {
+			resumer.SetDeadline(time.Now().Add(timeout))
+		}
+	} else if timeout > 0 {
+		return errors.New("--timeout is not supported on this platform")
+	}
+
+	if err := confirmDestroy(destroyer); err != nil {
+		return err
+	}
+
+	runErr := destroyer.Run()
+	if reporter, ok := destroyer.(destroy.Reporter); ok {
+		if err := reporter.Report().Write(directory); err != nil {
+			logrus.Warn(err)
+		} else {
+			logrus.Infof("The destroyed resources are listed in %s", filepath.Join(directory, destroy.ReportFilename))
+		}
+	}
+	if runErr != nil {
+		if resumable {
+			progress, err := resumer.Progress()
+			if err == nil {
+				err = ioutil.WriteFile(progressPath, progress, 0644)
+			}
+			if err != nil {
+				logrus.Warn(errors.Wrap(err, "failed to save the progress"))
+			} else {
+				return errors.Wrapf(runErr, "Failed to destroy cluster; the progress was saved to %s, from which running destroy cluster again resumes", progressPath)
+			}
+		}
+		return errors.Wrap(runErr, "Failed to destroy cluster")
+	}
+	if resumable {
+		if err := os.Remove(progressPath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove %s", progressPath)
+		}
+	}
+
+	if partialDestroy() {
+		// The assets are still needed to destroy the rest of the cluster.
+		return markPartiallyDestroyed(directory)
+	}
+	if destroyClusterOpts.metadata != "" || destroyClusterOpts.kubeconfig != "" {
+		// The asset directory need not be that of the cluster.
+		return nil
 	}
 
 	store, err := asset.NewStore(directory)
 	if err != nil {
 		return errors.Wrapf(err, "failed to create asset store")
 	}
-	for _, asset := range clusterTarget.assets {
+	for _, asset := range clusterTarget.assets() {
 		if err := store.Destroy(asset); err != nil {
 			return errors.Wrapf(err, "failed to destroy asset %q", asset.Name())
 		}
 	}
+	if err := cluster.RemoveFailedApply(directory); err != nil {
+		return errors.Wrap(err, "failed to remove the files of the failed cluster creation")
+	}
 	// delete the state file as well
 	err = store.DestroyState()
 	if err != nil {
@@ -69,14 +341,108 @@ func runDestroyCmd(directory string) error {
 	return nil
 }
 
+// runDestroyDryRun writes the resources the cluster's destroyer would delete
+// to out, grouped by type.
+func runDestroyDryRun(directory string, out io.Writer) error {
+	destroyer, err := newClusterDestroyer(directory)
+	if err != nil {
+		return errors.Wrap(err, "Failed while preparing to destroy cluster")
+	}
+	lister, ok := destroyer.(destroy.Lister)
+	if !ok {
+		return errors.New("listing the resources to destroy is not supported on this platform")
+	}
+
+	resources, err := lister.List()
+	if err != nil {
+		return errors.Wrap(err, "failed to list the cluster resources")
+	}
+
+	resourceTypes, byType := groupByType(resources)
+	for _, resourceType := range resourceTypes {
+		ids := byType[resourceType]
+		fmt.Fprintf(out, "%s (%d)\n", resourceType, len(ids))
+		for _, id := range ids {
+			fmt.Fprintf(out, "  %s\n", id)
+		}
+	}
+	logrus.Infof("Destroying the cluster would delete %d resources", len(resources))
+	return nil
+}
+
+// confirmDestroy prints the number of resources of each type which the
+// destroyer matches and, if there are more than --confirm-threshold, asks
+// for a confirmation unless --yes was passed, as a guard against filters
+// which match shared infrastructure. Destroyers which cannot list their
+// resources are not guarded.
+func confirmDestroy(destroyer destroy.Destroyer) error {
+	lister, ok := destroyer.(destroy.Lister)
+	if !ok {
+		return nil
+	}
+
+	resources, err := lister.List()
+	if err != nil {
+		return errors.Wrap(err, "failed to list the cluster resources")
+	}
+	resourceTypes, byType := groupByType(resources)
+	for _, resourceType := range resourceTypes {
+		logrus.Infof("%5d %s", len(byType[resourceType]), resourceType)
+	}
+	logrus.Infof("Destroying %d resources", len(resources))
+
+	if destroyClusterOpts.yes || len(resources) <= destroyClusterOpts.confirmThreshold {
+		return nil
+	}
+	if !asset.Interactive {
+		return errors.Errorf("refusing to destroy %d resources, more than --confirm-threshold=%d, without --yes; check with --dry-run that they all belong to the cluster", len(resources), destroyClusterOpts.confirmThreshold)
+	}
+	confirmed := false
+	if err := survey.AskOne(&survey.Confirm{
+		Message: fmt.Sprintf("Destroy all %d resources?", len(resources)),
+		Help:    "More resources than expected match the cluster; check with --dry-run that they all belong to it.",
+	}, &confirmed, nil); err != nil {
+		return errors.Wrap(err, "failed UserInput for confirmation")
+	}
+	if !confirmed {
+		return errors.New("destroy was not confirmed")
+	}
+	return nil
+}
+
+// groupByType returns the sorted types of the resources and their sorted
+// IDs by type.
+func groupByType(resources []destroy.Resource) ([]string, map[string][]string) {
+	byType := map[string][]string{}
+	for _, resource := range resources {
+		byType[resource.Type] = append(byType[resource.Type], resource.ID)
+	}
+	resourceTypes := make([]string, 0, len(byType))
+	for resourceType, ids := range byType {
+		resourceTypes = append(resourceTypes, resourceType)
+		sort.Strings(ids)
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes, byType
+}
+
 func newDestroyBootstrapCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "bootstrap",
 		Short: "Destroy the bootstrap resources",
+		Long: strings.TrimSpace(`
+Destroys the bootstrap machine and the resources that are only needed while
+the control plane is being bootstrapped, such as the bootstrap Ignition config
+storage, load balancer registrations, and security group rules.
+
+This is safe to run once the bootstrap-complete event has been emitted, and is
+run automatically by 'create cluster'.
+`),
 		Run: func(cmd *cobra.Command, args []string) {
 			cleanup := setupFileHook(rootOpts.dir)
 			defer cleanup()
 
+			logrus.Info("Destroying the bootstrap resources...")
 			err := bootstrap.Destroy(rootOpts.dir)
 			if err != nil {
 				logrus.Fatal(err)
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+// runClusterDryRun generates the cluster assets from a copy of directory
+// in a temporary directory and logs the resources Terraform would create,
+// without creating any of them or modifying the assets in directory.
+func runClusterDryRun(directory string) error {
+	tmpDir, err := ioutil.TempDir("", "openshift-install-dry-run-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temp dir for the dry run")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	assetDir := filepath.Join(tmpDir, "assets")
+	if err := copyDir(directory, assetDir); err != nil {
+		return errors.Wrap(err, "failed to copy the asset directory")
+	}
+
+	assetStore, err := asset.NewStore(assetDir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create asset store")
+	}
+
+	installConfig := &installconfig.InstallConfig{}
+	terraformVariables := &cluster.TerraformVariables{}
+	for _, a := range []asset.Asset{installConfig, terraformVariables} {
+		if err := assetStore.Fetch(a); err != nil {
+			return errors.Wrapf(err, "failed to fetch %s", a.Name())
+		}
+	}
+
+	platform := installConfig.Config.Platform.Name()
+	if platform == "none" {
+		return errors.New("cluster cannot be created with platform set to 'none'")
+	}
+
+	terraformDir := filepath.Join(tmpDir, "terraform")
+	terraformVariablesData, err := terraformVariables.Data()
+	if err != nil {
+		return errors.Wrapf(err, "failed to decrypt %s", cluster.TfVarsFileName)
+	}
+	if err := os.MkdirAll(terraformDir, 0700); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(terraformDir, cluster.TfVarsFileName), terraformVariablesData, 0600); err != nil {
+		return errors.Wrap(err, "failed to write terraform.tfvars file")
+	}
+
+	logrus.Info("Planning the cluster resources...")
+	plan, err := terraform.Plan(terraformDir, platform)
+	if err != nil {
+		return err
+	}
+
+	for _, resourceType := range plan.CreatedTypes() {
+		logrus.Infof("  %s: %d", resourceType, plan.Created[resourceType])
+	}
+	logrus.Infof("Terraform would create %d resources", plan.Add)
+	return nil
+}
+
+// copyDir recursively copies the regular files in src to dst.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == src {
+				return nil
+			}
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/redact"
+)
+
+// event is an entry of the event stream, which GUIs and orchestration
+// systems read to render the progress of the installer without parsing
+// its logs.
+type event struct {
+	// Timestamp is when the event happened.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Phase is the installer's phase, e.g. "create cluster".
+	Phase string `json:"phase"`
+
+	// Level is the level of the log entry, e.g. "info".
+	Level string `json:"level"`
+
+	// Message is the message of the log entry.
+	Message string `json:"message"`
+
+	// Percent is the estimated overall progress of 'create cluster',
+	// once it started provisioning the cluster.
+	Percent *int `json:"percent,omitempty"`
+}
+
+// eventHook writes the info, warning and error log entries to the event
+// stream, one JSON event per line.
+type eventHook struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func newEventHook(stream io.Writer) *eventHook {
+	return &eventHook{encoder: json.NewEncoder(stream)}
+}
+
+func (h *eventHook) Levels() []logrus.Level {
+	var levels []logrus.Level
+	for _, level := range logrus.AllLevels {
+		if level <= logrus.InfoLevel {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+func (h *eventHook) Fire(entry *logrus.Entry) error {
+	e := &event{
+		Timestamp: entry.Time,
+		Phase:     currentPhase(),
+		Level:     entry.Level.String(),
+		Message:   redact.String(entry.Message),
+	}
+	if percent, ok := clusterProgress.reportedPercent(); ok {
+		e.Percent = &percent
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.encoder.Encode(e)
+}
+
+// openEventStream opens the target of --event-stream: the number of a file
+// descriptor which the caller opened, e.g. 3, or the path of a file, to
+// which the events are appended.
+func openEventStream(target string) (io.Writer, error) {
+	if fd, err := strconv.Atoi(target); err == nil {
+		if fd < 0 {
+			return nil, fmt.Errorf("invalid file descriptor %d", fd)
+		}
+		return os.NewFile(uintptr(fd), fmt.Sprintf("fd %d", fd)), nil
+	}
+	return os.OpenFile(target, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+}
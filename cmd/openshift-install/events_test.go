@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/redact"
+)
+
+func TestEventHookLevels(t *testing.T) {
+	assert.Equal(t, []logrus.Level{
+		logrus.PanicLevel,
+		logrus.FatalLevel,
+		logrus.ErrorLevel,
+		logrus.WarnLevel,
+		logrus.InfoLevel,
+	}, (&eventHook{}).Levels())
+}
+
+func TestEventHookFire(t *testing.T) {
+	redact.AddSecret("event-stream-secret")
+	setPhase("create cluster")
+	defer setPhase("")
+	defer clusterProgress.setReported(-1)
+
+	timestamp := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := []struct {
+		name     string
+		percent  int
+		entry    *logrus.Entry
+		expected string
+	}{
+		{
+			name:    "no progress",
+			percent: -1,
+			entry: &logrus.Entry{
+				Time:    timestamp,
+				Level:   logrus.InfoLevel,
+				Message: "Creating infrastructure resources...",
+			},
+			expected: `{"timestamp":"2019-01-02T03:04:05Z","phase":"create cluster","level":"info","message":"Creating infrastructure resources..."}
+`,
+		},
+		{
+			name:    "progress",
+			percent: 42,
+			entry: &logrus.Entry{
+				Time:    timestamp,
+				Level:   logrus.WarnLevel,
+				Message: "Cluster operator ingress is still progressing",
+			},
+			expected: `{"timestamp":"2019-01-02T03:04:05Z","phase":"create cluster","level":"warning","message":"Cluster operator ingress is still progressing","percent":42}
+`,
+		},
+		{
+			name:    "secret",
+			percent: -1,
+			entry: &logrus.Entry{
+				Time:    timestamp,
+				Level:   logrus.ErrorLevel,
+				Message: "failed to log in with event-stream-secret",
+			},
+			expected: `{"timestamp":"2019-01-02T03:04:05Z","phase":"create cluster","level":"error","message":"failed to log in with REDACTED"}
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterProgress.setReported(tc.percent)
+			var stream bytes.Buffer
+			if !assert.NoError(t, newEventHook(&stream).Fire(tc.entry)) {
+				return
+			}
+			assert.Equal(t, tc.expected, stream.String())
+		})
+	}
+}
+
+func TestOpenEventStream(t *testing.T) {
+	_, err := openEventStream("-1")
+	assert.EqualError(t, err, "invalid file descriptor -1")
+
+	dir, err := ioutil.TempDir("", "openshift-install-")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events")
+	if !assert.NoError(t, ioutil.WriteFile(path, []byte("earlier\n"), 0644)) {
+		return
+	}
+	stream, err := openEventStream(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = stream.Write([]byte("later\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, stream.(*os.File).Close())
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "earlier\nlater\n", string(data))
+}
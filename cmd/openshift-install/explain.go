@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/explain"
+)
+
+var explainOpts struct {
+	schema bool
+}
+
+func newExplainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain [FIELD]",
+		Short: "Describe the fields of the install-config",
+		Long: strings.TrimSpace(`
+Prints the documentation, type, default, and supported platforms of an
+install-config field.
+
+Fields are identified by the dot-separated path of their names in
+install-config.yaml, e.g. platform.aws.region or machines.replicas. Without
+a field, the top-level install-config fields are described.
+
+With --schema, the JSON Schema of the install-config is printed instead,
+for validating install-configs without the installer.
+`),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if explainOpts.schema {
+				if len(args) > 0 {
+					return errors.New("--schema does not take a field")
+				}
+				return explain.WriteSchema(os.Stdout)
+			}
+
+			var path string
+			if len(args) > 0 {
+				path = args[0]
+			}
+			return explain.Explain(os.Stdout, path)
+		},
+	}
+	cmd.Flags().BoolVar(&explainOpts.schema, "schema", false, "print the JSON Schema of the install-config")
+	return cmd
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/gather"
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+const (
+	// maxCloudErrors is how many of the recent errors of cloud APIs in
+	// the installer log are added to a log bundle.
+	maxCloudErrors = 100
+)
+
+var (
+	gatherBootstrapOpts struct {
+		bootstrap string
+		masters   []string
+		keys      []string
+	}
+)
+
+func newGatherCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gather",
+		Short: "Gather debugging data for a given installation failure",
+		Long: strings.TrimSpace(`
+Gather debugging data for a given installation failure.
+
+When installation for OpenShift cluster fails, gathering all the data useful
+for debugging can become a difficult task. This command helps users to
+collect the most relevant information that can be used to debug the
+installation failures.
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newGatherBootstrapCmd())
+	return cmd
+}
+
+func newGatherBootstrapCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bootstrap",
+		Short: "Gather debugging data for a failing-to-bootstrap control plane",
+		Long: strings.TrimSpace(`
+Connects over SSH to the bootstrap machine and, through it, to the masters,
+and collects their journals, bootkube output, and container logs into a
+log-bundle tarball in the asset directory, with a summary of the Terraform
+state and the recent cloud API errors of the installer log. If the bootstrap
+machine cannot be reached, the tarball only holds the latter.
+
+The machine addresses are read from the Terraform state in the asset
+directory unless they are given with --bootstrap and --master.
+`),
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			hosts := &gather.Hosts{
+				Bootstrap: gatherBootstrapOpts.bootstrap,
+				Masters:   gatherBootstrapOpts.masters,
+			}
+			err := runGatherBootstrapCmd(rootOpts.dir, hosts, gatherBootstrapOpts.keys)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&gatherBootstrapOpts.bootstrap, "bootstrap", "", "hostname or IP of the bootstrap host")
+	cmd.Flags().StringArrayVar(&gatherBootstrapOpts.masters, "master", nil, "hostnames or IPs of the masters, reached through the bootstrap host")
+	cmd.Flags().StringArrayVar(&gatherBootstrapOpts.keys, "key", nil, "path to an SSH private key for the core user (default ~/.ssh/id_rsa, id_ecdsa, and id_ed25519)")
+	return cmd
+}
+
+func runGatherBootstrapCmd(directory string, hosts *gather.Hosts, keys []string) error {
+	if hosts.Bootstrap == "" {
+		stateHosts, err := hostsFromState(directory)
+		if err != nil {
+			logrus.Warnf("Not gathering logs from the machines: failed to find the bootstrap host (use --bootstrap to set it): %v", err)
+		} else {
+			hosts.Bootstrap = stateHosts.Bootstrap
+			if len(hosts.Masters) == 0 {
+				hosts.Masters = stateHosts.Masters
+			}
+		}
+	}
+
+	bundle, err := gather.Bootstrap(directory, hosts, keys, installerFiles(directory))
+	if err != nil {
+		return errors.Wrap(err, "failed to gather bootstrap logs")
+	}
+	logrus.Infof("Bootstrap gather logs captured here %q", bundle)
+	return nil
+}
+
+// installerFiles returns the installer's side of a failed install in
+// directory for the log bundle: a summary of the Terraform state and the
+// recent errors of cloud APIs in the installer log. Files which cannot be
+// read are left out.
+func installerFiles(directory string) []gather.File {
+	var files []gather.File
+
+	state, err := readState(directory)
+	if err != nil {
+		logrus.Debugf("Not summarizing the Terraform state: %v", err)
+	} else {
+		files = append(files, gather.File{Name: "terraform-resources.txt", Data: stateSummary(state)})
+	}
+
+	log, err := os.Open(filepath.Join(directory, logFileName))
+	if err != nil {
+		logrus.Debugf("Not collecting the cloud errors: %v", err)
+		return files
+	}
+	defer log.Close()
+	cloudErrors, err := gather.RecentCloudErrors(log, maxCloudErrors)
+	if err != nil {
+		logrus.Debugf("Not collecting the cloud errors: %v", err)
+		return files
+	}
+	return append(files, gather.File{Name: "cloud-errors.log", Data: cloudErrors})
+}
+
+// hostsFromState looks up the bootstrap and master addresses in the
+// Terraform state in directory.
+func hostsFromState(directory string) (*gather.Hosts, error) {
+	metadata, err := cluster.LoadMetadata(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := readState(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var bootstrap, masters []string
+	switch platform := metadata.Platform(); platform {
+	case "aws":
+		bootstrap = instanceAttributes(state.LookupResource("root.bootstrap", "aws_instance", "bootstrap"), "public_ip")
+		masters = instanceAttributes(state.LookupResource("root.masters", "aws_instance", "master"), "private_ip")
+	case "libvirt":
+		bootstrap = instanceAttributes(state.LookupResource("root.bootstrap", "libvirt_domain", "bootstrap"), "network_interface.0.addresses.0")
+		masters = instanceAttributes(state.LookupResource("root", "libvirt_domain", "master"), "network_interface.0.addresses.0")
+	case "openstack":
+		bootstrap = instanceAttributes(state.LookupResource("root.bootstrap", "openstack_compute_instance_v2", "bootstrap"), "access_ip_v4")
+		masters = instanceAttributes(state.LookupResource("root.masters", "openstack_compute_instance_v2", "master_conf"), "access_ip_v4")
+	default:
+		return nil, errors.Errorf("gathering logs is not supported on %q", platform)
+	}
+
+	if len(bootstrap) == 0 {
+		return nil, errors.New("no bootstrap machine in the Terraform state")
+	}
+	return &gather.Hosts{Bootstrap: bootstrap[0], Masters: masters}, nil
+}
+
+// readState reads the Terraform state of the whole cluster in directory,
+// which is in the state files of the stages of its platform which were
+// applied.
+func readState(directory string) (*terraform.State, error) {
+	state := &terraform.State{}
+	for i, stateFileName := range terraform.StateFileNames() {
+		data, err := ioutil.ReadFile(filepath.Join(directory, stateFileName))
+		if err != nil {
+			if i > 0 && os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		data, err = asset.DecryptSensitive(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decrypt %s", stateFileName)
+		}
+		stageState, err := terraform.ParseState(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal %s", stateFileName)
+		}
+		state.Modules = append(state.Modules, stageState.Modules...)
+	}
+	return state, nil
+}
+
+// stateSummary lists the resources in the Terraform state, one per line,
+// with the module, key and ID of each. It shows what was created without
+// copying the state, which holds the bootstrap Ignition config, into the
+// bundle.
+func stateSummary(state *terraform.State) []byte {
+	var lines []string
+	for _, module := range state.Modules {
+		path := strings.Join(module.Path, ".")
+		for key, resource := range module.Resources {
+			lines = append(lines, fmt.Sprintf("%s\t%s\t%s\n", path, key, resource.Primary.ID))
+		}
+	}
+	sort.Strings(lines)
+	return []byte(strings.Join(lines, ""))
+}
+
+func instanceAttributes(instances []terraform.StateInstance, name string) []string {
+	values := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		if value := instance.Attributes[name]; value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// logGatherBootstrap gathers logs from the bootstrap machine, if it is
+// still there, and the installer's side of a failed install, logging
+// rather than returning any error so that the original failure is the one
+// reported.
+func logGatherBootstrap(directory string) {
+	logrus.Info("Attempting to gather logs of the failed install...")
+	if err := runGatherBootstrapCmd(directory, &gather.Hosts{}, nil); err != nil {
+		logrus.Error(err)
+	}
+}
+
+// followBootstrapJournal follows the journal of the critical units of the
+// bootstrap machine in the debug log, so that a hung bootstrap can be
+// watched, until the returned function is called. The machine is looked
+// up in the Terraform state in directory, so nothing is followed for
+// user-provisioned infrastructure.
+func followBootstrapJournal(ctx context.Context, directory string) (stop func()) {
+	hosts, err := hostsFromState(directory)
+	if err != nil {
+		logrus.Debugf("Not following the journal of the bootstrap machine: %v", err)
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		printLine := func(args ...interface{}) {
+			logrus.Debug(append([]interface{}{"bootstrap: "}, args...)...)
+		}
+		if err := gather.FollowJournal(ctx, hosts.Bootstrap, nil, printLine); err != nil {
+			logrus.Debugf("Failed to follow the journal of the bootstrap machine: %v", err)
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
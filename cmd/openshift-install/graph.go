@@ -5,8 +5,10 @@ import (
 	"io"
 	"os"
 	"reflect"
+	"strings"
 
 	"github.com/awalterschulze/gographviz"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
@@ -16,6 +18,7 @@ import (
 var (
 	graphOpts struct {
 		outputFile string
+		target     string
 	}
 )
 
@@ -23,14 +26,30 @@ func newGraphCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "graph",
 		Short: "Outputs the internal dependency graph for installer",
-		Long:  "",
-		RunE:  runGraphCmd,
+		Long: strings.TrimSpace(`
+Outputs the graph of assets the installer generates, in DOT format.
+
+Each node is labeled with the name of the asset, and whether it contains
+secrets such as private keys. Assets that have already been generated in the
+asset directory are also labeled with the files they wrote, so the graph shows
+which assets will be regenerated when one of those files is edited.
+
+With --target, only the assets feeding the given target, such as
+'ignition-configs', are output.
+`),
+		RunE: runGraphCmd,
 	}
 	cmd.PersistentFlags().StringVar(&graphOpts.outputFile, "output-file", "", "file where the graph is written, if empty prints the graph to Stdout.")
+	cmd.PersistentFlags().StringVar(&graphOpts.target, "target", "", "the target whose assets are output, if empty outputs the assets of all targets.")
 	return cmd
 }
 
 func runGraphCmd(cmd *cobra.Command, args []string) error {
+	store, err := asset.NewStore(rootOpts.dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create asset store")
+	}
+
 	g := gographviz.NewGraph()
 	g.SetName("G")
 	g.SetDir(true)
@@ -40,13 +59,23 @@ func runGraphCmd(cmd *cobra.Command, args []string) error {
 		string(gographviz.Shape): "box",
 		string(gographviz.Style): "filled",
 	}
+	found := false
 	for _, t := range targets {
+		if graphOpts.target != "" && t.command.Name() != graphOpts.target {
+			continue
+		}
+		found = true
 		name := fmt.Sprintf("%q", fmt.Sprintf("Target %s", t.name))
 		g.AddNode("G", name, tNodeAttr)
-		for _, dep := range t.assets {
-			addEdge(g, name, dep)
+		for _, dep := range t.assets() {
+			if err := addEdge(g, store, name, dep); err != nil {
+				return err
+			}
 		}
 	}
+	if !found {
+		return errors.Errorf("no target named %q", graphOpts.target)
+	}
 
 	out := os.Stdout
 	if graphOpts.outputFile != "" {
@@ -64,22 +93,48 @@ func runGraphCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func addEdge(g *gographviz.Graph, parent string, asset asset.Asset) {
-	name := fmt.Sprintf("%q", reflect.TypeOf(asset).Elem())
+func addEdge(g *gographviz.Graph, store asset.Store, parent string, a asset.Asset) error {
+	name := fmt.Sprintf("%q", reflect.TypeOf(a).Elem())
 
 	if !g.IsNode(name) {
+		label, err := nodeLabel(store, a)
+		if err != nil {
+			return err
+		}
 		logrus.Debugf("adding node %s", name)
-		g.AddNode("G", name, nil)
+		g.AddNode("G", name, map[string]string{string(gographviz.Label): label})
 	}
 	if !isEdge(g, name, parent) {
 		logrus.Debugf("adding edge %s -> %s", name, parent)
 		g.AddEdge(name, parent, true, nil)
 	}
 
-	deps := asset.Dependencies()
+	deps := a.Dependencies()
 	for _, dep := range deps {
-		addEdge(g, name, dep)
+		if err := addEdge(g, store, name, dep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodeLabel returns the label for the node of the given asset: its name,
+// whether it contains secrets, and any files it has written, one per line.
+func nodeLabel(store asset.Store, a asset.Asset) (string, error) {
+	lines := []string{a.Name()}
+	if sensitive, ok := a.(asset.Sensitive); ok && sensitive.Sensitive() {
+		lines = append(lines, "(contains secrets)")
+	}
+	stored, err := store.Load(a)
+	if err != nil {
+		return "", err
+	}
+	if wa, ok := stored.(asset.WritableAsset); ok {
+		for _, f := range wa.Files() {
+			lines = append(lines, f.Filename)
+		}
 	}
+	return fmt.Sprintf("%q", strings.Join(lines, "\n")), nil
 }
 
 func isEdge(g *gographviz.Graph, src, dst string) bool {
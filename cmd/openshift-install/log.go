@@ -4,9 +4,16 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/apitrace"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/redact"
+	"github.com/openshift/installer/pkg/terraform"
+	texec "github.com/openshift/installer/pkg/terraform/exec"
 )
 
 type fileHook struct {
@@ -40,16 +47,28 @@ func (h *fileHook) Fire(entry *logrus.Entry) error {
 		return err
 	}
 
-	_, err = h.file.Write(line)
+	_, err = h.file.Write(redact.Bytes(line))
 	return err
 }
 
+// logFileName is the name of the file, in the asset directory, to which the
+// logs are written.
+const logFileName = ".openshift_install.log"
+
+// traceFileName is the name of the file, in the asset directory, to which
+// the requests to cloud APIs are recorded at the trace log level.
+const traceFileName = ".openshift_install_trace.log"
+
+// terraformLogFileName is the name of the file, in the asset directory, to
+// which the Terraform logs are written at all levels.
+const terraformLogFileName = ".openshift_install_terraform.log"
+
 func setupFileHook(baseDir string) func() {
 	if err := os.MkdirAll(baseDir, 0755); err != nil {
 		logrus.Fatal(errors.Wrap(err, "failed to create base directory for logs"))
 	}
 
-	logfile, err := os.OpenFile(filepath.Join(baseDir, ".openshift_install.log"), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	logfile, err := os.OpenFile(filepath.Join(baseDir, logFileName), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
 	if err != nil {
 		logrus.Fatal(errors.Wrap(err, "failed to open log file"))
 	}
@@ -65,8 +84,82 @@ func setupFileHook(baseDir string) func() {
 		DisableLevelTruncation: false,
 	}))
 
+	// The Terraform logs at the trace level hold the bootstrap Ignition
+	// config, so they are not written in plain text next to a state file
+	// which is encrypted.
+	closeTerraformLog := func() {}
+	if asset.StateEncrypted() {
+		logrus.Debugf("Not writing the Terraform logs to %s, because the state file is encrypted", terraformLogFileName)
+	} else {
+		terraformLogfile, err := os.OpenFile(filepath.Join(baseDir, terraformLogFileName), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			logrus.Fatal(errors.Wrap(err, "failed to open Terraform log file"))
+		}
+		terraformLog := redact.NewWriter(terraformLogfile)
+		texec.LogWriter = terraformLog
+		closeTerraformLog = func() {
+			terraformLog.Close()
+			terraformLogfile.Close()
+		}
+	}
+	terraform.SnapshotDir = baseDir
+
+	stopTrace := func() {}
+	if rootOpts.traceAPICalls {
+		traceFile, err := os.OpenFile(filepath.Join(baseDir, traceFileName), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			logrus.Fatal(errors.Wrap(err, "failed to open trace file"))
+		}
+		restore := apitrace.Install(traceFile)
+		stopTrace = func() {
+			restore()
+			traceFile.Close()
+		}
+	}
+
 	return func() {
 		logfile.Close()
 		logrus.StandardLogger().ReplaceHooks(originalHooks)
+		texec.LogWriter = nil
+		terraform.SnapshotDir = ""
+		closeTerraformLog()
+		stopTrace()
+	}
+}
+
+// phase is the installer's current phase (e.g. "create cluster"), which is
+// included in structured log entries.
+var phase struct {
+	sync.Mutex
+	name string
+}
+
+// setPhase sets the phase included in subsequent structured log entries.
+func setPhase(name string) {
+	phase.Lock()
+	defer phase.Unlock()
+	phase.name = name
+}
+
+func currentPhase() string {
+	phase.Lock()
+	defer phase.Unlock()
+	return phase.name
+}
+
+// phaseFormatter wraps a formatter, adding the current phase to the fields
+// of each entry.
+type phaseFormatter struct {
+	formatter logrus.Formatter
+}
+
+func (f *phaseFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	// Copy the entry so the phase does not leak into other hooks.
+	withPhase := *entry
+	withPhase.Data = make(logrus.Fields, len(entry.Data)+1)
+	for k, v := range entry.Data {
+		withPhase.Data[k] = v
 	}
+	withPhase.Data["phase"] = currentPhase()
+	return f.formatter.Format(&withPhase)
 }
@@ -11,13 +11,24 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
 
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/rhcos"
 	"github.com/openshift/installer/pkg/terraform/exec/plugins"
+	libvirttfvars "github.com/openshift/installer/pkg/tfvars/libvirt"
 )
 
 var (
 	rootOpts struct {
-		dir      string
-		logLevel string
+		dir               string
+		logLevel          string
+		logFormat         string
+		refreshImageCache bool
+		noCache           bool
+		eventStream       string
+
+		// traceAPICalls is set at the trace log level, at which the
+		// requests to cloud APIs are recorded.
+		traceAPICalls bool
 	}
 )
 
@@ -40,8 +51,16 @@ func installerMain() {
 	for _, subCmd := range []*cobra.Command{
 		newCreateCmd(),
 		newDestroyCmd(),
+		newWaitForCmd(),
+		newValidateCmd(),
+		newGatherCmd(),
+		newAnalyzeCmd(),
+		newAuthCmd(),
+		newMigrateCmd(),
 		newVersionCmd(),
 		newGraphCmd(),
+		newExplainCmd(),
+		newCoreOSCmd(),
 		newCompletionCmd(),
 	} {
 		rootCmd.AddCommand(subCmd)
@@ -54,15 +73,23 @@ func installerMain() {
 
 func newRootCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:               "openshift-install",
-		Short:             "Creates OpenShift clusters",
-		Long:              "",
-		PersistentPreRunE: runRootCmd,
-		SilenceErrors:     true,
-		SilenceUsage:      true,
+		Use:                    "openshift-install",
+		Short:                  "Creates OpenShift clusters",
+		Long:                   "",
+		PersistentPreRunE:      runRootCmd,
+		SilenceErrors:          true,
+		SilenceUsage:           true,
+		BashCompletionFunction: bashCompletionFunctions,
 	}
 	cmd.PersistentFlags().StringVar(&rootOpts.dir, "dir", ".", "assets directory")
-	cmd.PersistentFlags().StringVar(&rootOpts.logLevel, "log-level", "info", "log level (e.g. \"debug | info | warn | error\")")
+	cmd.PersistentFlags().SetAnnotation("dir", cobra.BashCompSubdirsInDir, []string{})
+	cmd.PersistentFlags().StringVar(&rootOpts.logLevel, "log-level", "info", "log level (e.g. \"trace | debug | info | warn | error\"); trace also records the requests to cloud APIs in the asset directory")
+	cmd.PersistentFlags().SetAnnotation("log-level", cobra.BashCompCustom, []string{"__openshift-install_log_levels"})
+	cmd.PersistentFlags().StringVar(&rootOpts.logFormat, "log-format", "text", "log format (e.g. \"text | json\")")
+	cmd.PersistentFlags().SetAnnotation("log-format", cobra.BashCompCustom, []string{"__openshift-install_log_formats"})
+	cmd.PersistentFlags().BoolVar(&rootOpts.refreshImageCache, "refresh-image-cache", false, "fetch the OS image again instead of using the copy cached by earlier invocations")
+	cmd.PersistentFlags().BoolVar(&rootOpts.noCache, "no-cache", false, "fetch the RHCOS metadata again instead of using the copy cached by earlier invocations")
+	cmd.PersistentFlags().StringVar(&rootOpts.eventStream, "event-stream", "", "file, or number of an open file descriptor, to which progress events are written as newline-delimited JSON")
 	return cmd
 }
 
@@ -74,17 +101,39 @@ func runRootCmd(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return errors.Wrap(err, "invalid log-level")
 	}
+	rootOpts.traceAPICalls = level == logrus.TraceLevel
+
+	var formatter logrus.Formatter
+	switch rootOpts.logFormat {
+	case "text":
+		formatter = &logrus.TextFormatter{
+			// Setting ForceColors is necessary because logrus.TextFormatter determines
+			// whether or not to enable colors by looking at the output of the logger.
+			// In this case, the output is ioutil.Discard, which is not a terminal.
+			// Overriding it here allows the same check to be done, but against the
+			// hook's output instead of the logger's output.
+			ForceColors:            terminal.IsTerminal(int(os.Stderr.Fd())),
+			DisableTimestamp:       true,
+			DisableLevelTruncation: true,
+		}
+	case "json":
+		formatter = &phaseFormatter{formatter: &logrus.JSONFormatter{}}
+	default:
+		return errors.Errorf("invalid log-format %q", rootOpts.logFormat)
+	}
+	logrus.AddHook(newFileHook(os.Stderr, level, formatter))
+	if rootOpts.eventStream != "" {
+		stream, err := openEventStream(rootOpts.eventStream)
+		if err != nil {
+			return errors.Wrap(err, "failed to open the event stream")
+		}
+		logrus.AddHook(newEventHook(stream))
+	}
 
-	logrus.AddHook(newFileHook(os.Stderr, level, &logrus.TextFormatter{
-		// Setting ForceColors is necessary because logrus.TextFormatter determines
-		// whether or not to enable colors by looking at the output of the logger.
-		// In this case, the output is ioutil.Discard, which is not a terminal.
-		// Overriding it here allows the same check to be done, but against the
-		// hook's output instead of the logger's output.
-		ForceColors:            terminal.IsTerminal(int(os.Stderr.Fd())),
-		DisableTimestamp:       true,
-		DisableLevelTruncation: true,
-	}))
+	asset.Interactive = terminal.IsTerminal(int(os.Stdin.Fd()))
+	libvirttfvars.RefreshCache = rootOpts.refreshImageCache
+	rhcos.Cache = !rootOpts.noCache
 
+	setPhase(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "))
 	return nil
 }
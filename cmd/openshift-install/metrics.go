@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/analyze"
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/version"
+)
+
+const (
+	// metricsTimeout is how long to wait for the metrics endpoint.
+	metricsTimeout = 10 * time.Second
+)
+
+// installReport is the anonymous report of the outcome of 'create cluster'
+// which is sent to the endpoint of --report-metrics-to. It names no
+// cluster, account, region or address, and holds no log lines.
+type installReport struct {
+	InstallerVersion string `json:"installerVersion"`
+	Platform         string `json:"platform,omitempty"`
+
+	// Outcome is "success" or "failure".
+	Outcome string `json:"outcome"`
+
+	// FailedPhase is the phase of the installer which failed, such as
+	// "wait-for bootstrap-complete".
+	FailedPhase string `json:"failedPhase,omitempty"`
+
+	// FailureCategory is the category of the likely cause of the
+	// failure found by 'analyze', such as "quota", or "unknown".
+	FailureCategory string `json:"failureCategory,omitempty"`
+
+	// Durations are the durations from each milestone of the install to
+	// the next.
+	Durations []milestoneDuration `json:"durations,omitempty"`
+}
+
+// milestoneDuration is how long it took to reach a milestone of the
+// install from the previous one.
+type milestoneDuration struct {
+	Milestone string `json:"milestone"`
+	Seconds   int64  `json:"seconds"`
+}
+
+// reportInstall sends the report of the outcome of the install in the
+// asset directory, if it was opted in to with --report-metrics-to. It
+// only warns on failure, which must not change the outcome of the install.
+func reportInstall(failed bool) {
+	if createClusterOpts.reportMetricsTo == "" {
+		return
+	}
+
+	report := newInstallReport(rootOpts.dir, failed)
+	if err := sendInstallReport(createClusterOpts.reportMetricsTo, report); err != nil {
+		logrus.Warnf("Failed to report the install metrics: %v", err)
+		return
+	}
+	logrus.Debugf("Reported the install metrics to %s", createClusterOpts.reportMetricsTo)
+}
+
+// newInstallReport returns the report of the outcome of the install in
+// directory.
+func newInstallReport(directory string, failed bool) *installReport {
+	report := &installReport{
+		InstallerVersion: version.Raw,
+		Outcome:          "success",
+	}
+
+	if metadata, err := cluster.LoadMetadata(directory); err == nil {
+		report.Platform = metadata.Platform()
+		for i := 1; i < len(metadata.Timeline); i++ {
+			report.Durations = append(report.Durations, milestoneDuration{
+				Milestone: metadata.Timeline[i].Name,
+				Seconds:   int64(metadata.Timeline[i].Time.Sub(metadata.Timeline[i-1].Time) / time.Second),
+			})
+		}
+	}
+
+	if failed {
+		report.Outcome = "failure"
+		report.FailedPhase = currentPhase()
+		report.FailureCategory = "unknown"
+		if findings, err := analyze.Directory(directory); err == nil && len(findings) > 0 {
+			report.FailureCategory = findings[0].Category
+		}
+	}
+	return report
+}
+
+// sendInstallReport posts the report to the endpoint as JSON.
+func sendInstallReport(endpoint string, report *installReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), metricsTimeout)
+	defer cancel()
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("%s responded with %s", endpoint, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func newMigrateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrades the asset directory of an earlier installer",
+		Long: strings.TrimSpace(`
+Upgrades the state file and the on-disk assets written by an earlier version
+of the installer to the form this version expects, so that the cluster can
+still be waited on, gathered from, or destroyed from the asset directory.
+
+Running it again, or on an asset directory which is already current, changes
+nothing.
+`),
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			applied, err := asset.Migrate(rootOpts.dir)
+			for _, description := range applied {
+				logrus.Info(description)
+			}
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to migrate the asset directory"))
+			}
+			if len(applied) == 0 {
+				logrus.Info("The asset directory is already current")
+			}
+		},
+	}
+}
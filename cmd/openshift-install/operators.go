@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+const (
+	// operatorSummaryInterval is how often the cluster operators which
+	// have not settled are logged while the cluster initializes.
+	operatorSummaryInterval = 2 * time.Minute
+
+	// maxOperatorMessageLength is the length of the longest message of a
+	// cluster operator in the summary, beyond which it is truncated.
+	maxOperatorMessageLength = 120
+)
+
+// operatorSummary logs the cluster operators which have not settled yet,
+// at most once per operatorSummaryInterval.
+type operatorSummary struct {
+	restClient rest.Interface
+	last       time.Time
+}
+
+// log logs the cluster operators which are failing, progressing or not
+// yet available, if operatorSummaryInterval has passed since they were
+// last logged.
+func (s *operatorSummary) log() {
+	if time.Since(s.last) < operatorSummaryInterval {
+		return
+	}
+	s.last = time.Now()
+
+	operators, err := listClusterOperators(s.restClient)
+	if err != nil {
+		logrus.Debugf("Failed to list the cluster operators: %v", err)
+		return
+	}
+	table := operatorTable(operators)
+	if table == "" {
+		return
+	}
+	logrus.Info("Cluster operators which have not settled yet:")
+	for _, line := range strings.Split(strings.TrimSuffix(table, "\n"), "\n") {
+		logrus.Info("  " + line)
+	}
+}
+
+// listClusterOperators lists the cluster operators.
+func listClusterOperators(restClient rest.Interface) ([]configv1.ClusterOperator, error) {
+	data, err := restClient.Get().AbsPath("/apis", configv1.GroupName, configv1.GroupVersion.Version, "clusteroperators").DoRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	list := &configv1.ClusterOperatorList{}
+	if err := json.Unmarshal(data, list); err != nil {
+		return nil, errors.Wrap(err, "decoding the cluster operators")
+	}
+	return list.Items, nil
+}
+
+// operatorTable returns a table of the cluster operators which are
+// failing, progressing or not yet available, with the status of those
+// conditions and the message of the most pressing one, or an empty string
+// if all the operators have settled.
+func operatorTable(operators []configv1.ClusterOperator) string {
+	sort.Slice(operators, func(i, j int) bool { return operators[i].Name < operators[j].Name })
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	rows := 0
+	for _, operator := range operators {
+		conditions := map[configv1.ClusterStatusConditionType]configv1.ClusterOperatorStatusCondition{}
+		for _, condition := range operator.Status.Conditions {
+			conditions[condition.Type] = condition
+		}
+		available := conditions[configv1.OperatorAvailable]
+		progressing := conditions[configv1.OperatorProgressing]
+		failing := conditions[configv1.OperatorFailing]
+
+		var message string
+		switch {
+		case failing.Status == configv1.ConditionTrue:
+			message = failing.Message
+		case progressing.Status == configv1.ConditionTrue:
+			message = progressing.Message
+		case available.Status != configv1.ConditionTrue:
+			message = available.Message
+		default:
+			continue
+		}
+
+		if rows == 0 {
+			fmt.Fprintln(w, "NAME\tAVAILABLE\tPROGRESSING\tFAILING\tMESSAGE")
+		}
+		rows++
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", operator.Name, conditionStatus(available), conditionStatus(progressing), conditionStatus(failing), truncateMessage(message))
+	}
+	w.Flush()
+	return buf.String()
+}
+
+// conditionStatus returns the status of the condition, which is Unknown
+// if the operator does not report it.
+func conditionStatus(condition configv1.ClusterOperatorStatusCondition) configv1.ConditionStatus {
+	if condition.Status == "" {
+		return configv1.ConditionUnknown
+	}
+	return condition.Status
+}
+
+// truncateMessage returns the message on a single line of at most
+// maxOperatorMessageLength characters.
+func truncateMessage(message string) string {
+	message = strings.Join(strings.Fields(message), " ")
+	if len(message) > maxOperatorMessageLength {
+		message = message[:maxOperatorMessageLength-3] + "..."
+	}
+	return message
+}
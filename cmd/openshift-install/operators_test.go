@@ -0,0 +1,107 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+func clusterOperator(name string, conditions ...configv1.ClusterOperatorStatusCondition) configv1.ClusterOperator {
+	return configv1.ClusterOperator{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     configv1.ClusterOperatorStatus{Conditions: conditions},
+	}
+}
+
+func condition(conditionType configv1.ClusterStatusConditionType, status configv1.ConditionStatus, message string) configv1.ClusterOperatorStatusCondition {
+	return configv1.ClusterOperatorStatusCondition{Type: conditionType, Status: status, Message: message}
+}
+
+func TestOperatorTable(t *testing.T) {
+	cases := []struct {
+		name      string
+		operators []configv1.ClusterOperator
+		expected  string
+	}{
+		{
+			name: "settled",
+			operators: []configv1.ClusterOperator{
+				clusterOperator("dns",
+					condition(configv1.OperatorAvailable, configv1.ConditionTrue, "available"),
+					condition(configv1.OperatorProgressing, configv1.ConditionFalse, ""),
+					condition(configv1.OperatorFailing, configv1.ConditionFalse, ""),
+				),
+			},
+		},
+		{
+			name: "unsettled",
+			operators: []configv1.ClusterOperator{
+				clusterOperator("network",
+					condition(configv1.OperatorAvailable, configv1.ConditionFalse, "not available"),
+				),
+				clusterOperator("ingress",
+					condition(configv1.OperatorAvailable, configv1.ConditionFalse, "not available"),
+					condition(configv1.OperatorProgressing, configv1.ConditionTrue, "rolling out"),
+					condition(configv1.OperatorFailing, configv1.ConditionTrue, "router crashing"),
+				),
+				clusterOperator("dns",
+					condition(configv1.OperatorAvailable, configv1.ConditionTrue, "available"),
+					condition(configv1.OperatorProgressing, configv1.ConditionFalse, ""),
+					condition(configv1.OperatorFailing, configv1.ConditionFalse, ""),
+				),
+				clusterOperator("console",
+					condition(configv1.OperatorAvailable, configv1.ConditionTrue, "available"),
+					condition(configv1.OperatorProgressing, configv1.ConditionTrue, "rolling out"),
+				),
+			},
+			expected: `NAME     AVAILABLE  PROGRESSING  FAILING  MESSAGE
+console  True       True         Unknown  rolling out
+ingress  False      True         True     router crashing
+network  False      Unknown      Unknown  not available
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, operatorTable(tc.operators))
+		})
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	cases := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{
+			name:     "short",
+			message:  "rolling out",
+			expected: "rolling out",
+		},
+		{
+			name:     "multi-line",
+			message:  "rolling out\n  2 of 3 replicas\tready\n",
+			expected: "rolling out 2 of 3 replicas ready",
+		},
+		{
+			name:     "maximum length",
+			message:  strings.Repeat("a", 120),
+			expected: strings.Repeat("a", 120),
+		},
+		{
+			name:     "long",
+			message:  strings.Repeat("a", 121),
+			expected: strings.Repeat("a", 117) + "...",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, truncateMessage(tc.message))
+		})
+	}
+}
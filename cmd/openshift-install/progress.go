@@ -0,0 +1,155 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// progressInterval is how often the progress of a long-running phase
+	// is reported.
+	progressInterval = time.Minute
+)
+
+// progressPhase is a step of a long-running operation.
+type progressPhase struct {
+	// name describes the phase to the user.
+	name string
+
+	// expected is roughly how long the phase usually takes. It is only
+	// used to estimate the overall progress.
+	expected time.Duration
+
+	// interactive is set for phases which may prompt the user, and which
+	// are therefore not reported periodically.
+	interactive bool
+}
+
+// clusterPhases are the phases of 'create cluster'.
+var clusterPhases = []progressPhase{
+	{name: "Provisioning infrastructure", expected: 10 * time.Minute, interactive: true},
+	{name: "Bootstrapping the control plane", expected: 15 * time.Minute},
+	{name: "Control plane up, destroying the bootstrap resources", expected: time.Minute},
+	{name: "Waiting for the cluster operators to converge", expected: 15 * time.Minute},
+}
+
+// progressReporter logs the transitions between the phases of a
+// long-running operation, and periodically logs the elapsed time and an
+// estimate of the overall progress while a phase is running.
+type progressReporter struct {
+	phases []progressPhase
+
+	mu         sync.Mutex
+	now        func() time.Time
+	start      time.Time
+	current    int
+	phaseStart time.Time
+	stop       chan struct{}
+
+	// reported is the last reported percentage, or -1. It is read by
+	// the event stream without mu, which is held while reporting.
+	reported int32
+}
+
+func newProgressReporter(phases []progressPhase) *progressReporter {
+	return &progressReporter{
+		phases:   phases,
+		now:      time.Now,
+		current:  -1,
+		reported: -1,
+	}
+}
+
+// reportedPercent returns the last reported estimate of the overall
+// progress, if there is one.
+func (p *progressReporter) reportedPercent() (int, bool) {
+	percent := atomic.LoadInt32(&p.reported)
+	return int(percent), percent >= 0
+}
+
+func (p *progressReporter) setReported(percent int) int {
+	atomic.StoreInt32(&p.reported, int32(percent))
+	return percent
+}
+
+// startPhase ends the current phase, if any, and starts the given one.
+// Phases must be started in order.
+func (p *progressReporter) startPhase(phase int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.current < 0 {
+		p.start = now
+	} else {
+		logrus.Debugf("%s took %s", p.phases[p.current].name, now.Sub(p.phaseStart).Round(time.Second))
+	}
+	p.current = phase
+	p.phaseStart = now
+	logrus.Infof("Phase %d/%d: %s (%d%% complete, %s elapsed)", phase+1, len(p.phases), p.phases[phase].name, p.setReported(p.percent(now)), now.Sub(p.start).Round(time.Second))
+
+	if p.stop == nil {
+		p.stop = make(chan struct{})
+		go p.report(p.stop)
+	}
+}
+
+// finish ends the current phase and stops the periodic reports.
+func (p *progressReporter) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stop != nil {
+		close(p.stop)
+		p.stop = nil
+	}
+	if p.current >= 0 {
+		p.setReported(100)
+		logrus.Infof("Finished after %s", p.now().Sub(p.start).Round(time.Second))
+	}
+}
+
+func (p *progressReporter) report(stop <-chan struct{}) {
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.mu.Lock()
+			if phase := p.phases[p.current]; !phase.interactive {
+				now := p.now()
+				logrus.Infof("%s... (%d%% complete, %s elapsed)", phase.name, p.setReported(p.percent(now)), now.Sub(p.start).Round(time.Second))
+			}
+			p.mu.Unlock()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// percent estimates how much of the operation is complete from the
+// expected durations of the phases. Time spent in the current phase counts
+// for at most 90% of its expected duration, so the estimate doesn't reach
+// the next phase's share before that phase has started.
+func (p *progressReporter) percent(now time.Time) int {
+	var total, done time.Duration
+	for i, phase := range p.phases {
+		total += phase.expected
+		if i < p.current {
+			done += phase.expected
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	inPhase := now.Sub(p.phaseStart)
+	if limit := p.phases[p.current].expected * 9 / 10; inPhase > limit {
+		inPhase = limit
+	}
+	done += inPhase
+	return int(100 * done / total)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset/validation"
+	openstackvalidation "github.com/openshift/installer/pkg/types/openstack/validation"
+	"github.com/openshift/installer/pkg/version"
+)
+
+var (
+	validateOpts struct {
+		cloud      bool
+		registries bool
+		output     string
+	}
+)
+
+func newValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the install-config and manifests in the asset directory",
+		Long: strings.TrimSpace(`
+Checks install-config.yaml, and any manifests in the manifests and openshift
+directories, without generating or modifying any assets.
+
+The issues found are printed to stdout, each with its severity, field path,
+message and, where there is a usual fix, a suggestion; with --output json,
+as a list of objects with those keys. The command exits non-zero if any
+issue is an error rather than a warning. With --cloud, values which can only be checked against the
+cloud (like OpenStack flavors and networks) are checked as well. With
+--registries, the pull secret is checked to grant access to the release
+image, and to the mirrors of its repository, by contacting the registries.
+`),
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			errorCount, err := runValidateCmd(rootOpts.dir)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			if errorCount > 0 {
+				logrus.Fatalf("Found %d errors", errorCount)
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&validateOpts.cloud, "cloud", false, "also check values against the cloud APIs")
+	cmd.Flags().BoolVar(&validateOpts.registries, "registries", false, "also check that the pull secret grants access to the release image and its mirrors")
+	cmd.Flags().StringVar(&validateOpts.output, "output", "text", "output format (e.g. \"text | json\")")
+	return cmd
+}
+
+// runValidateCmd prints the issues of the asset directory, and returns the
+// number of them which are errors.
+func runValidateCmd(directory string) (int, error) {
+	var fetcher openstackvalidation.ValidValuesFetcher
+	if validateOpts.cloud {
+		fetcher = openstackvalidation.NewValidValuesFetcher()
+	}
+
+	issues, err := validation.Validate(directory, fetcher)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to validate the asset directory")
+	}
+
+	if validateOpts.registries {
+		releaseImage := version.DefaultReleaseImage
+		if ri, ok := os.LookupEnv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE"); ok && ri != "" {
+			releaseImage = ri
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		registryIssues, err := validation.ValidateRegistryAccess(ctx, directory, releaseImage)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to validate the access to the registries")
+		}
+		issues = append(issues, registryIssues...)
+	}
+
+	switch validateOpts.output {
+	case "text":
+		for _, issue := range issues {
+			fmt.Printf("%s: %s\n", issue.Severity, issue.String())
+		}
+	case "json":
+		if issues == nil {
+			issues = []validation.Issue{}
+		}
+		data, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return 0, err
+		}
+		fmt.Println(string(data))
+	default:
+		return 0, errors.Errorf("invalid output %q", validateOpts.output)
+	}
+	errorCount := 0
+	for _, issue := range issues {
+		if issue.Severity == validation.SeverityError {
+			errorCount++
+		}
+	}
+	return errorCount, nil
+}
@@ -3,12 +3,14 @@ package main
 import (
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/spf13/cobra"
-)
 
-var (
-	version = "was not built correctly" // set in hack/build.sh
+	texec "github.com/openshift/installer/pkg/terraform/exec"
+	"github.com/openshift/installer/pkg/terraform/exec/plugins"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/version"
 )
 
 func newVersionCmd() *cobra.Command {
@@ -21,6 +23,22 @@ func newVersionCmd() *cobra.Command {
 }
 
 func runVersionCmd(cmd *cobra.Command, args []string) error {
-	fmt.Printf("%s %s\n", os.Args[0], version)
+	fmt.Printf("%s %s\n", os.Args[0], version.Raw)
+	if version.Commit != "" {
+		fmt.Printf("built from commit %s\n", version.Commit)
+	}
+	fmt.Printf("release image %s\n", version.DefaultReleaseImage)
+	fmt.Printf("terraform %s\n", texec.Version())
+
+	names := make([]string, 0, len(plugins.KnownPluginVersions))
+	for name := range plugins.KnownPluginVersions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("%s %s\n", name, plugins.KnownPluginVersions[name])
+	}
+
+	fmt.Printf("install-config apiVersions %s\n", types.InstallConfigVersion)
 	return nil
 }
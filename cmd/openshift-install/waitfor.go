@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// defaultBootstrapTimeout is how long to wait for each of the Kubernetes
+	// API and the bootstrap-complete event.
+	defaultBootstrapTimeout = 30 * time.Minute
+
+	// defaultInstallTimeout is how long to wait for the cluster to
+	// initialize after bootstrapping has completed.
+	defaultInstallTimeout = 30 * time.Minute
+)
+
+var (
+	waitForOpts struct {
+		bootstrapTimeout time.Duration
+		installTimeout   time.Duration
+	}
+)
+
+func newWaitForCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait-for",
+		Short: "Wait for install-time events",
+		Long: strings.TrimSpace(`
+Wait for install-time events.
+
+'create cluster' has a few stages that wait for cluster events.  But
+these waits can also be useful on their own when the infrastructure is
+provisioned outside of the installer (e.g. user-provisioned
+infrastructure).  This command exposes them as separate targets.
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newWaitForBootstrapCompleteCmd())
+	cmd.AddCommand(newWaitForInstallCompleteCmd())
+	return cmd
+}
+
+func newWaitForBootstrapCompleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bootstrap-complete",
+		Short: "Wait until cluster bootstrapping has completed",
+		Args:  cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			ctx := context.Background()
+
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(rootOpts.dir, "auth", "kubeconfig"))
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
+			}
+
+			stopJournal := followBootstrapJournal(ctx, rootOpts.dir)
+			err = waitForBootstrapComplete(ctx, config, waitForOpts.bootstrapTimeout)
+			stopJournal()
+			if err != nil {
+				logGatherBootstrap(rootOpts.dir)
+				logrus.Fatal(err)
+			}
+
+			logrus.Info("It is now safe to remove the bootstrap resources")
+		},
+	}
+	cmd.Flags().DurationVar(&waitForOpts.bootstrapTimeout, "timeout", defaultBootstrapTimeout, "how long to wait for each of the Kubernetes API and the bootstrap-complete event")
+	return cmd
+}
+
+func newWaitForInstallCompleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install-complete",
+		Short: "Wait until the cluster is ready",
+		Args:  cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			ctx := context.Background()
+
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(rootOpts.dir, "auth", "kubeconfig"))
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
+			}
+
+			err = waitForInstallComplete(ctx, config, rootOpts.dir, waitForOpts.installTimeout)
+			if err != nil {
+				logGatherBootstrap(rootOpts.dir)
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().DurationVar(&waitForOpts.installTimeout, "timeout", defaultInstallTimeout, "how long to wait for the cluster to initialize")
+	return cmd
+}
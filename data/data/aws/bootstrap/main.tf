@@ -1,5 +1,10 @@
 resource "aws_s3_bucket" "ignition" {
-  acl = "private"
+  bucket = "${var.ignition_bucket}"
+  acl    = "private"
+
+  # Allow the bucket to be removed with the rest of the bootstrap resources
+  # even if it holds objects besides the bootstrap Ignition config.
+  force_destroy = true
 
   tags = "${var.tags}"
 
@@ -23,9 +28,12 @@ resource "aws_s3_bucket_object" "ignition" {
   }
 }
 
+# The bootstrap Ignition config is too large for the user data, so the
+# bootstrap node fetches it from its presigned URL, which needs no S3
+# permissions.
 data "ignition_config" "redirect" {
   replace {
-    source = "s3://${aws_s3_bucket.ignition.id}/bootstrap.ign"
+    source = "${var.ignition_url}"
   }
 }
 
@@ -90,13 +98,6 @@ resource "aws_iam_role_policy" "bootstrap" {
       "Effect": "Allow",
       "Action": "ec2:DetachVolume",
       "Resource": "*"
-    },
-    {
-      "Action" : [
-        "s3:GetObject"
-      ],
-      "Resource": "arn:aws:s3:::*",
-      "Effect": "Allow"
     }
   ]
 }
@@ -109,7 +110,7 @@ resource "aws_instance" "bootstrap" {
   iam_instance_profile        = "${aws_iam_instance_profile.bootstrap.name}"
   instance_type               = "${var.instance_type}"
   subnet_id                   = "${var.subnet_id}"
-  user_data                   = "${data.ignition_config.redirect.rendered}"
+  user_data_base64            = "${base64gzip(data.ignition_config.redirect.rendered)}"
   vpc_security_group_ids      = ["${var.vpc_security_group_ids}", "${aws_security_group.bootstrap.id}"]
   associate_public_ip_address = true
 
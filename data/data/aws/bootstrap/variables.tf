@@ -19,6 +19,16 @@ variable "ignition" {
   description = "The content of the bootstrap ignition file."
 }
 
+variable "ignition_bucket" {
+  type        = "string"
+  description = "The name of the S3 bucket to create for the bootstrap ignition file."
+}
+
+variable "ignition_url" {
+  type        = "string"
+  description = "The presigned URL of the bootstrap ignition file in the S3 bucket, from which the bootstrap node fetches it."
+}
+
 variable "instance_type" {
   type        = "string"
   default     = "m4.large"
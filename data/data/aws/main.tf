@@ -1,5 +1,6 @@
 locals {
   private_zone_id = "${aws_route53_zone.int.zone_id}"
+  ami             = "${var.aws_ami_region == "" ? var.aws_ec2_ami_override : join("", aws_ami_copy.main.*.id)}"
 
   tags = "${merge(map(
       "openshiftClusterID", "${var.cluster_id}"
@@ -10,24 +11,6 @@ provider "aws" {
   region = "${var.aws_region}"
 }
 
-module "bootstrap" {
-  source = "./bootstrap"
-
-  ami                      = "${var.aws_ec2_ami_override}"
-  cluster_name             = "${var.cluster_name}"
-  iam_role                 = "${var.aws_master_iam_role_name}"
-  ignition                 = "${var.ignition_bootstrap}"
-  subnet_id                = "${module.vpc.master_subnet_ids[0]}"
-  target_group_arns        = "${module.vpc.aws_lb_target_group_arns}"
-  target_group_arns_length = "${module.vpc.aws_lb_target_group_arns_length}"
-  vpc_id                   = "${module.vpc.vpc_id}"
-  vpc_security_group_ids   = "${list(module.vpc.master_sg_id)}"
-
-  tags = "${merge(map(
-      "Name", "${var.cluster_name}-bootstrap",
-    ), local.tags)}"
-}
-
 module "masters" {
   source = "./master"
 
@@ -49,10 +32,27 @@ module "masters" {
   subnet_ids               = "${module.vpc.master_subnet_ids}"
   target_group_arns        = "${module.vpc.aws_lb_target_group_arns}"
   target_group_arns_length = "${module.vpc.aws_lb_target_group_arns_length}"
-  ec2_ami                  = "${var.aws_ec2_ami_override}"
+  ec2_ami                  = "${local.ami}"
   user_data_ign            = "${var.ignition_master}"
 }
 
+// The RHCOS AMI is copied into regions in which it is not published. The
+// machine API finds the copy by its Name tag, and destroy deletes it with
+// the other resources of the cluster.
+resource "aws_ami_copy" "main" {
+  count = "${var.aws_ami_region == "" ? 0 : 1}"
+
+  name              = "${var.cluster_name}-ami-${var.aws_region}"
+  source_ami_id     = "${var.aws_ec2_ami_override}"
+  source_ami_region = "${var.aws_ami_region}"
+  encrypted         = "${var.aws_ami_encrypted}"
+
+  tags = "${merge(map(
+      "Name", "${var.cluster_name}-ami-${var.aws_region}",
+      "kubernetes.io/cluster/${var.cluster_name}", "owned",
+    ), local.tags)}"
+}
+
 module "iam" {
   source = "./iam"
 
@@ -84,6 +84,7 @@ module "vpc" {
   cidr_block   = "${var.machine_cidr}"
   cluster_id   = "${var.cluster_id}"
   cluster_name = "${var.cluster_name}"
+  infra_id     = "${var.infra_id}"
   region       = "${var.aws_region}"
 
   tags = "${merge(map(
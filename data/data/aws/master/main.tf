@@ -83,7 +83,7 @@ resource "aws_instance" "master" {
   iam_instance_profile = "${aws_iam_instance_profile.master.name}"
   instance_type        = "${var.ec2_type}"
   subnet_id            = "${element(var.subnet_ids, count.index)}"
-  user_data            = "${var.user_data_ign}"
+  user_data_base64     = "${base64gzip(var.user_data_ign)}"
 
   vpc_security_group_ids      = ["${var.master_sg_ids}"]
   associate_public_ip_address = true
@@ -0,0 +1,26 @@
+// These outputs are the variables of the bootstrap stage in
+// stages/bootstrap, which is applied with its own state after this one.
+
+output "vpc_id" {
+  value = "${module.vpc.vpc_id}"
+}
+
+output "bootstrap_subnet_id" {
+  value = "${module.vpc.master_subnet_ids[0]}"
+}
+
+output "master_sg_id" {
+  value = "${module.vpc.master_sg_id}"
+}
+
+output "aws_lb_target_group_arns" {
+  value = "${module.vpc.aws_lb_target_group_arns}"
+}
+
+output "aws_lb_target_group_arns_length" {
+  value = "${module.vpc.aws_lb_target_group_arns_length}"
+}
+
+output "aws_ami" {
+  value = "${local.ami}"
+}
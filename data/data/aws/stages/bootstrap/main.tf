@@ -0,0 +1,34 @@
+// The bootstrap stage holds the bootstrap resources in their own state, so
+// that 'destroy bootstrap' removes them without touching the rest of the
+// cluster. Its variables are those of terraform.tfvars and the outputs of
+// the infra stage in the top level of the AWS modules.
+
+locals {
+  tags = "${merge(map(
+      "openshiftClusterID", "${var.cluster_id}"
+    ), var.aws_extra_tags)}"
+}
+
+provider "aws" {
+  region = "${var.aws_region}"
+}
+
+module "bootstrap" {
+  source = "../../bootstrap"
+
+  ami                      = "${var.aws_ami}"
+  cluster_name             = "${var.cluster_name}"
+  iam_role                 = "${var.aws_master_iam_role_name}"
+  ignition                 = "${var.ignition_bootstrap}"
+  ignition_bucket          = "${var.aws_bootstrap_ignition_bucket}"
+  ignition_url             = "${var.aws_bootstrap_ignition_url}"
+  subnet_id                = "${var.bootstrap_subnet_id}"
+  target_group_arns        = "${var.aws_lb_target_group_arns}"
+  target_group_arns_length = "${var.aws_lb_target_group_arns_length}"
+  vpc_id                   = "${var.vpc_id}"
+  vpc_security_group_ids   = "${list(var.master_sg_id)}"
+
+  tags = "${merge(map(
+      "Name", "${var.cluster_name}-bootstrap",
+    ), local.tags)}"
+}
@@ -0,0 +1,57 @@
+variable "aws_ami" {
+  type        = "string"
+  description = "(internal) The AMI of the nodes, or its copy in the region of the cluster, from the infra stage."
+}
+
+variable "aws_extra_tags" {
+  type        = "map"
+  description = "(optional) Extra AWS tags to be applied to created resources."
+  default     = {}
+}
+
+variable "aws_region" {
+  type        = "string"
+  description = "The target AWS region for the cluster."
+}
+
+variable "aws_master_iam_role_name" {
+  type        = "string"
+  default     = ""
+  description = "(optional) Name of IAM role to use for the instance profile of the bootstrap node."
+}
+
+variable "aws_bootstrap_ignition_bucket" {
+  type        = "string"
+  description = "(internal) The name of the S3 bucket which holds the bootstrap Ignition config."
+}
+
+variable "aws_bootstrap_ignition_url" {
+  type        = "string"
+  default     = ""
+  description = "(internal) The presigned URL of the bootstrap Ignition config in the S3 bucket. It is set when Terraform is applied, because it expires."
+}
+
+variable "vpc_id" {
+  type        = "string"
+  description = "(internal) The ID of the VPC of the cluster, from the infra stage."
+}
+
+variable "bootstrap_subnet_id" {
+  type        = "string"
+  description = "(internal) The ID of the subnet of the bootstrap node, from the infra stage."
+}
+
+variable "master_sg_id" {
+  type        = "string"
+  description = "(internal) The ID of the security group of the masters, from the infra stage."
+}
+
+variable "aws_lb_target_group_arns" {
+  type        = "list"
+  description = "(internal) The ARNs of the target groups of the load balancers, from the infra stage."
+}
+
+variable "aws_lb_target_group_arns_length" {
+  type        = "string"
+  description = "(internal) The length of aws_lb_target_group_arns, from the infra stage."
+}
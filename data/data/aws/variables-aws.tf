@@ -87,3 +87,26 @@ Example:
  * Role Name = openshift-installer
 EOF
 }
+
+variable "aws_bootstrap_ignition_bucket" {
+  type        = "string"
+  description = "(internal) The name of the S3 bucket which holds the bootstrap Ignition config."
+}
+
+variable "aws_bootstrap_ignition_url" {
+  type        = "string"
+  default     = ""
+  description = "(internal) The presigned URL of the bootstrap Ignition config in the S3 bucket. It is set when Terraform is applied, because it expires."
+}
+
+variable "aws_ami_region" {
+  type        = "string"
+  default     = ""
+  description = "(internal) The region of aws_ec2_ami_override, if it is not in aws_region and is copied into it."
+}
+
+variable "aws_ami_encrypted" {
+  type        = "string"
+  default     = "false"
+  description = "(internal) Whether the snapshots of the AMI in aws_ami_region are encrypted, and the copy must be too."
+}
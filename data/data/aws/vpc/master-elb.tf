@@ -1,5 +1,5 @@
 resource "aws_lb" "api_internal" {
-  name                             = "${var.cluster_name}-int"
+  name                             = "${var.infra_id}-int"
   load_balancer_type               = "network"
   subnets                          = ["${local.master_subnet_ids}"]
   internal                         = true
@@ -12,7 +12,7 @@ resource "aws_lb" "api_internal" {
 }
 
 resource "aws_lb" "api_external" {
-  name                             = "${var.cluster_name}-ext"
+  name                             = "${var.infra_id}-ext"
   load_balancer_type               = "network"
   subnets                          = ["${local.master_subnet_ids}"]
   internal                         = false
@@ -25,7 +25,7 @@ resource "aws_lb" "api_external" {
 }
 
 resource "aws_lb_target_group" "api_internal" {
-  name     = "${var.cluster_name}-api-int"
+  name     = "${var.infra_id}-aint"
   protocol = "TCP"
   port     = 6443
   vpc_id   = "${local.vpc_id}"
@@ -45,7 +45,7 @@ resource "aws_lb_target_group" "api_internal" {
 }
 
 resource "aws_lb_target_group" "api_external" {
-  name     = "${var.cluster_name}-api-ext"
+  name     = "${var.infra_id}-aext"
   protocol = "TCP"
   port     = 6443
   vpc_id   = "${local.vpc_id}"
@@ -65,7 +65,7 @@ resource "aws_lb_target_group" "api_external" {
 }
 
 resource "aws_lb_target_group" "services" {
-  name     = "${var.cluster_name}-services"
+  name     = "${var.infra_id}-sint"
   protocol = "TCP"
   port     = 49500
   vpc_id   = "${local.vpc_id}"
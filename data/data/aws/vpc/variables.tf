@@ -14,6 +14,11 @@ variable "cluster_name" {
   type = "string"
 }
 
+variable "infra_id" {
+  type        = "string"
+  description = "The prefix of the names of the load balancers and target groups, which are limited to 32 characters."
+}
+
 variable "private_master_endpoints" {
   description = "If set to true, private-facing ingress resources are created."
   default     = true
@@ -0,0 +1,61 @@
+#!/usr/bin/env bash
+set -e
+
+# Runs on the installed system of a single-node cluster which bootstrapped
+# in place. It approves the certificate signing requests of the kubelet
+# until the operators rolled out the control plane, then removes the
+# bootstrap control plane which bootstrap-in-place.sh carried over from the
+# live system.
+
+KUBECONFIG=/etc/kubernetes/bootstrap-in-place/kubeconfig
+
+required_pods_running() {
+	for pod in openshift-kube-apiserver/openshift-kube-apiserver openshift-kube-scheduler/openshift-kube-scheduler openshift-kube-controller-manager/openshift-kube-controller-manager openshift-cluster-version/cluster-version-operator
+	do
+		if ! oc --config="${KUBECONFIG}" get pods --namespace="${pod%%/*}" --field-selector=status.phase=Running --output=name 2>/dev/null | grep --quiet "^pod/${pod#*/}"
+		then
+			return 1
+		fi
+	done
+}
+
+echo "Waiting for the control plane..."
+until required_pods_running
+do
+	# Nothing approves the requests of the kubelet before the control
+	# plane is up.
+	oc --config="${KUBECONFIG}" get csr --output=go-template='{{range .items}}{{if not .status}}{{.metadata.name}}{{"\n"}}{{end}}{{end}}' 2>/dev/null |
+		xargs --no-run-if-empty oc --config="${KUBECONFIG}" adm certificate approve || true
+	sleep 5
+done
+
+echo "Reporting install progress..."
+timestamp="$(date -u +'%Y-%m-%dT%H:%M:%SZ')"
+while ! oc --config="${KUBECONFIG}" create -f - <<-EOT
+	apiVersion: v1
+	kind: Event
+	metadata:
+	  name: bootstrap-complete
+	  namespace: kube-system
+	involvedObject:
+	  namespace: kube-system
+	message: cluster bootstrapping has completed
+	firstTimestamp: "${timestamp}"
+	lastTimestamp: "${timestamp}"
+	count: 1
+	source:
+	  component: cluster
+	  host: $(hostname)
+EOT
+do
+	sleep 5
+done
+
+echo "Removing the bootstrap control plane..."
+while read -r manifest
+do
+	rm --force "/etc/kubernetes/manifests/${manifest}"
+done < /etc/kubernetes/bootstrap-in-place/manifests
+rm --recursive --force /etc/kubernetes/bootstrap-configs /etc/kubernetes/bootstrap-secrets
+
+touch /etc/kubernetes/bootstrap-in-place/.done
@@ -0,0 +1,52 @@
+#!/usr/bin/env bash
+
+# Collects logs useful for debugging a failed install and writes them to
+# stdout as a gzipped tarball.  This is run by 'openshift-install gather
+# bootstrap' on the bootstrap and control-plane machines, but can also be run
+# by hand:
+#
+#   sudo installer-gather.sh > log-bundle.tar.gz
+
+set -o pipefail
+
+ARTIFACTS="$(mktemp --directory)"
+trap 'rm -rf "${ARTIFACTS}"' EXIT
+
+echo "Gathering node journals..." >&2
+mkdir -p "${ARTIFACTS}/journals"
+for service in bootkube openshift kubelet crio progress
+do
+	journalctl --boot --no-pager --output=short --unit="${service}" > "${ARTIFACTS}/journals/${service}.log" 2>&1
+done
+journalctl --boot --no-pager --output=short > "${ARTIFACTS}/journals/journal.log" 2>&1
+
+echo "Gathering bootkube output..." >&2
+mkdir -p "${ARTIFACTS}/bootkube"
+if [ -d /opt/openshift ]
+then
+	# The asset directory holds private keys, so only record what is there.
+	find /opt/openshift -printf '%M %u %g %10s %TY-%Tm-%Td %TH:%TM %p\n' > "${ARTIFACTS}/bootkube/opt-openshift.list" 2>&1
+fi
+
+echo "Gathering container logs..." >&2
+mkdir -p "${ARTIFACTS}/containers"
+if command -v crictl >/dev/null
+then
+	crictl ps --all > "${ARTIFACTS}/containers/crictl-ps.txt" 2>&1
+	for container in $(crictl ps --all --quiet)
+	do
+		crictl inspect "${container}" > "${ARTIFACTS}/containers/${container}.inspect" 2>&1
+		crictl logs "${container}" > "${ARTIFACTS}/containers/${container}.log" 2>&1
+	done
+fi
+if command -v podman >/dev/null
+then
+	podman ps --all > "${ARTIFACTS}/containers/podman-ps.txt" 2>&1
+	for container in $(podman ps --all --quiet)
+	do
+		podman inspect "${container}" > "${ARTIFACTS}/containers/${container}.inspect" 2>&1
+		podman logs "${container}" > "${ARTIFACTS}/containers/${container}.log" 2>&1
+	done
+fi
+
+tar --create --gzip --directory "${ARTIFACTS}" .
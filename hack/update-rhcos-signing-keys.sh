@@ -0,0 +1,19 @@
+#!/bin/sh
+#
+# Embed the OpenPGP public keys which sign the RHCOS artifacts, by fetching
+# the Red Hat release keys into data/data/rhcos-signing-keys.asc.
+# Usage:
+#
+#   $ hack/update-rhcos-signing-keys.sh
+#
+# KEYS_URL defaults to Red Hat's release key 2, which signs RHCOS.
+
+set -e
+
+KEYS_URL="${KEYS_URL:-https://www.redhat.com/security/data/fd431d51.txt}"
+
+cd "$(dirname "$0")/.."
+
+curl --silent --fail "${KEYS_URL}" >data/data/rhcos-signing-keys.asc.tmp
+grep --quiet -- '-----BEGIN PGP PUBLIC KEY BLOCK-----' data/data/rhcos-signing-keys.asc.tmp
+mv data/data/rhcos-signing-keys.asc.tmp data/data/rhcos-signing-keys.asc
@@ -0,0 +1,39 @@
+#!/bin/sh
+#
+# Pin the RHCOS build the installer installs, by converting the metadata
+# of the build into the stream metadata in data/data/rhcos-stream.json.
+# Usage:
+#
+#   $ hack/update-rhcos-stream.sh [BUILD]
+#
+# BUILD defaults to the latest build of the channel.
+
+set -e
+
+CHANNEL="${CHANNEL:-maipo}"
+BASE_URL="${BASE_URL:-https://releases-rhcos.svc.ci.openshift.org/storage/releases}/${CHANNEL}"
+BUILD="${1:-$(curl --silent --fail "${BASE_URL}/builds.json" | jq --raw-output '.builds[0]')}"
+
+cd "$(dirname "$0")/.."
+
+curl --silent --fail "${BASE_URL}/${BUILD}/meta.json" | jq --sort-keys \
+	--arg channel "${CHANNEL}" \
+	--arg url "${BASE_URL}/${BUILD}" '
+	def artifact: {location: "\($url)/\(.path)", sha256: .sha256};
+	."ostree-version" as $release |
+	{
+		stream: $channel,
+		architectures: {
+			x86_64: {
+				artifacts: ({
+					qemu: {release: $release, formats: {qcow2: {disk: (.images.qemu | artifact)}}}
+				} + if .images.metal then {
+					metal: {release: $release, formats: {
+						"raw.gz": {disk: (.images.metal | artifact)},
+						pxe: {kernel: (.images.kernel | artifact), initramfs: (.images.initramfs | artifact)}
+					}}
+				} else {} end),
+				images: {aws: {regions: (.amis | map({key: .name, value: {release: $release, image: .hvm}}) | from_entries)}}
+			}
+		}
+	}' >data/data/rhcos-stream.json
@@ -0,0 +1,218 @@
+// Package analyze looks for the likely causes of failed installs in the
+// logs the installer leaves in its asset directory.
+package analyze
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// logFileName is the name of the installer's log file in the asset
+	// directory.
+	logFileName = ".openshift_install.log"
+
+	// bundlePattern matches the log bundles written by 'gather bootstrap'.
+	bundlePattern = "log-bundle-*.tar.gz"
+
+	// maxLineLength is the length of the longest line which can be
+	// scanned.
+	maxLineLength = 1024 * 1024
+)
+
+// Finding is a likely cause of a failed install.
+type Finding struct {
+	// Category names the likely cause, such as "quota", for tools.
+	Category string `json:"category"`
+
+	// Cause describes the likely cause.
+	Cause string `json:"cause"`
+
+	// Remediation suggests how to fix the cause.
+	Remediation string `json:"remediation"`
+
+	// Source is where the evidence was found, such as a file in the
+	// asset directory or a file in a log bundle.
+	Source string `json:"source"`
+
+	// Evidence is the first line which matched.
+	Evidence string `json:"evidence"`
+
+	// Count is how many lines matched, in all sources.
+	Count int `json:"count"`
+}
+
+type rule struct {
+	pattern     *regexp.Regexp
+	category    string
+	cause       string
+	remediation string
+}
+
+// rules are the known causes of failed installs, in the order in which
+// they are reported.
+var rules = []rule{
+	{
+		pattern:     regexp.MustCompile(`(InvalidClientTokenId|SignatureDoesNotMatch|AuthFailure|UnrecognizedClientException|ExpiredToken)`),
+		category:    "credentials",
+		cause:       "The cloud credentials were rejected.",
+		remediation: "Check that the credentials used by the installer are valid and have not expired.",
+	},
+	{
+		pattern:     regexp.MustCompile(`(UnauthorizedOperation|AccessDenied|is not authorized to perform)`),
+		category:    "permissions",
+		cause:       "The cloud credentials lack a required permission.",
+		remediation: "Grant the permissions listed in docs/user/aws/iam.md to the installer's credentials.",
+	},
+	{
+		pattern:     regexp.MustCompile(`(LimitExceeded|[Ll]imit [Ee]xceeded|[Qq]uota exceeded|QuotaExceeded|InsufficientInstanceCapacity)`),
+		category:    "quota",
+		cause:       "A cloud quota or service limit was reached.",
+		remediation: "Remove unused resources or request a limit increase; docs/user/aws/limits.md lists the limits an AWS install needs.",
+	},
+	{
+		pattern:     regexp.MustCompile(`(no public Route 53 hosted zones found|NoSuchHostedZone|HostedZoneNotFound)`),
+		category:    "hosted-zone",
+		cause:       "The base domain has no usable public hosted zone.",
+		remediation: "Create a public hosted zone for the base domain as described in docs/user/aws/route53.md.",
+	},
+	{
+		pattern:     regexp.MustCompile(`(unauthorized: authentication required|pull access denied|unauthorized: access to the requested resource is not authorized|invalid username/password|Error: unable to pull|error pulling image)`),
+		category:    "pull-secret",
+		cause:       "Container images could not be pulled with the pull secret.",
+		remediation: "Download a current pull secret and set it as pullSecret in install-config.yaml, and check that the machines can reach the image registries.",
+	},
+	{
+		pattern:     regexp.MustCompile(`(no such host|NXDOMAIN|server misbehaving)`),
+		category:    "dns",
+		cause:       "A DNS name could not be resolved.",
+		remediation: "Check that the base domain is delegated to the cloud's DNS service and that the machines can reach a DNS server.",
+	},
+	{
+		pattern:     regexp.MustCompile(`x509: certificate has expired or is not yet valid`),
+		category:    "certificate",
+		cause:       "A certificate was not yet valid or had expired.",
+		remediation: "Check that the clocks of the installer host and the machines are synchronized, and regenerate assets older than a day.",
+	},
+}
+
+type analyzer struct {
+	byRule map[int]*Finding
+}
+
+// Directory looks for the likely causes of a failed install in the
+// installer log and the log bundles in directory.
+func Directory(directory string) ([]Finding, error) {
+	a := &analyzer{byRule: map[int]*Finding{}}
+
+	file, err := os.Open(filepath.Join(directory, logFileName))
+	if err == nil {
+		err = a.scan(logFileName, file)
+		file.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", logFileName)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	bundles, err := filepath.Glob(filepath.Join(directory, bundlePattern))
+	if err != nil {
+		return nil, err
+	}
+	for _, bundle := range bundles {
+		if err := a.scanBundle(bundle); err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", filepath.Base(bundle))
+		}
+	}
+
+	return a.result(), nil
+}
+
+// Text looks for the likely causes of a failed install in text, whose
+// origin is described by source.
+func Text(source string, text string) []Finding {
+	a := &analyzer{byRule: map[int]*Finding{}}
+	// Reading from a strings.Reader cannot fail.
+	a.scan(source, strings.NewReader(text))
+	return a.result()
+}
+
+// scanBundle scans the regular files in the log bundle at filename.
+func (a *analyzer) scanBundle(filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := a.scan(path.Join(filepath.Base(filename), header.Name), tarReader); err != nil {
+			return err
+		}
+	}
+}
+
+func (a *analyzer) scan(source string, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	for scanner.Scan() {
+		line := scanner.Text()
+		for i, rule := range rules {
+			if !rule.pattern.MatchString(line) {
+				continue
+			}
+
+			if finding, ok := a.byRule[i]; ok {
+				finding.Count++
+				continue
+			}
+			a.byRule[i] = &Finding{
+				Category:    rule.category,
+				Cause:       rule.cause,
+				Remediation: rule.remediation,
+				Source:      source,
+				Evidence:    strings.TrimSpace(line),
+				Count:       1,
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func (a *analyzer) result() []Finding {
+	findings := make([]Finding, 0, len(a.byRule))
+	for i := range rules {
+		if finding, ok := a.byRule[i]; ok {
+			findings = append(findings, *finding)
+		}
+	}
+	return findings
+}
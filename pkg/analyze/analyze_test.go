@@ -0,0 +1,129 @@
+package analyze
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeBundle(t *testing.T, filename string, files map[string]string) {
+	file, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, data := range files {
+		header := &tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(data))}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDirectory(t *testing.T) {
+	cases := []struct {
+		name     string
+		log      string
+		bundle   map[string]string
+		expected []Finding
+	}{
+		{
+			name:     "empty",
+			expected: []Finding{},
+		},
+		{
+			name: "quota",
+			log: `time="2019-01-01T00:00:00Z" level=debug msg="module.vpc.aws_eip.nat_eip.1: Error creating EIP: AddressLimitExceeded: The maximum number of addresses has been reached."
+time="2019-01-01T00:00:01Z" level=debug msg="module.vpc.aws_eip.nat_eip.2: Error creating EIP: AddressLimitExceeded: The maximum number of addresses has been reached."
+`,
+			expected: []Finding{
+				{
+					Category:    "quota",
+					Cause:       "A cloud quota or service limit was reached.",
+					Remediation: "Remove unused resources or request a limit increase; docs/user/aws/limits.md lists the limits an AWS install needs.",
+					Source:      ".openshift_install.log",
+					Evidence:    `time="2019-01-01T00:00:00Z" level=debug msg="module.vpc.aws_eip.nat_eip.1: Error creating EIP: AddressLimitExceeded: The maximum number of addresses has been reached."`,
+					Count:       2,
+				},
+			},
+		},
+		{
+			name: "bundle",
+			log:  `time="2019-01-01T00:00:00Z" level=debug msg="Still waiting for the Kubernetes API: Get https://api.test.example.com:6443/version: dial tcp: lookup api.test.example.com: no such host"`,
+			bundle: map[string]string{
+				"bootstrap/journals/bootkube.log": "Error: unable to pull quay.io/openshift-release-dev/ocp-release:4.0: unauthorized: authentication required\n",
+			},
+			expected: []Finding{
+				{
+					Category:    "pull-secret",
+					Cause:       "Container images could not be pulled with the pull secret.",
+					Remediation: "Download a current pull secret and set it as pullSecret in install-config.yaml, and check that the machines can reach the image registries.",
+					Source:      "log-bundle-20190101000000.tar.gz/bootstrap/journals/bootkube.log",
+					Evidence:    "Error: unable to pull quay.io/openshift-release-dev/ocp-release:4.0: unauthorized: authentication required",
+					Count:       1,
+				},
+				{
+					Category:    "dns",
+					Cause:       "A DNS name could not be resolved.",
+					Remediation: "Check that the base domain is delegated to the cloud's DNS service and that the machines can reach a DNS server.",
+					Source:      ".openshift_install.log",
+					Evidence:    `time="2019-01-01T00:00:00Z" level=debug msg="Still waiting for the Kubernetes API: Get https://api.test.example.com:6443/version: dial tcp: lookup api.test.example.com: no such host"`,
+					Count:       1,
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "openshift-install-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			if tc.log != "" {
+				if err := ioutil.WriteFile(filepath.Join(dir, logFileName), []byte(tc.log), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.bundle != nil {
+				writeBundle(t, filepath.Join(dir, "log-bundle-20190101000000.tar.gz"), tc.bundle)
+			}
+
+			findings, err := Directory(dir)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, findings)
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	findings := Text("cluster version", "Could not update deployment: Get https://quay.io/v2/: x509: certificate has expired or is not yet valid")
+	assert.Equal(t, []Finding{
+		{
+			Category:    "certificate",
+			Cause:       "A certificate was not yet valid or had expired.",
+			Remediation: "Check that the clocks of the installer host and the machines are synchronized, and regenerate assets older than a day.",
+			Source:      "cluster version",
+			Evidence:    "Could not update deployment: Get https://quay.io/v2/: x509: certificate has expired or is not yet valid",
+			Count:       1,
+		},
+	}, findings)
+}
@@ -0,0 +1,145 @@
+// Package apitrace records the HTTP requests which the installer sends to
+// cloud APIs, for debugging throttling and permission issues. Only the
+// method, endpoint, operation, status, latency and request ID of each
+// request are recorded; bodies, query strings and headers, which may hold
+// credentials, are left out.
+package apitrace
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// maxFormLength is the length of the longest form-encoded body which
+	// is parsed for its Action, as sent by the AWS query APIs.
+	maxFormLength = 64 * 1024
+)
+
+// requestIDHeaders are the response headers in which the clouds return
+// the IDs of requests, in order of preference.
+var requestIDHeaders = []string{
+	"X-Amzn-Requestid",
+	"X-Amz-Request-Id",
+	"X-Openstack-Request-Id",
+	"X-Compute-Request-Id",
+}
+
+// Call is the record of a request.
+type Call struct {
+	Time time.Time `json:"time"`
+
+	Method string `json:"method"`
+
+	// Endpoint is the URL of the request, without its query string.
+	Endpoint string `json:"endpoint"`
+
+	// Operation is the AWS operation of the request, if it names one in
+	// its X-Amz-Target header or in the Action of its form.
+	Operation string `json:"operation,omitempty"`
+
+	Status int `json:"status,omitempty"`
+
+	LatencyMS int64 `json:"latencyMS"`
+
+	RequestID string `json:"requestID,omitempty"`
+
+	// Error is set if no response was received.
+	Error string `json:"error,omitempty"`
+}
+
+// Transport is an http.RoundTripper which records each request it sends
+// through Wrapped to Writer, as a line of JSON.
+type Transport struct {
+	Wrapped http.RoundTripper
+	Writer  io.Writer
+
+	mu sync.Mutex
+}
+
+// RoundTrip sends the request through the wrapped transport and records
+// it.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := *req.URL
+	endpoint.RawQuery = ""
+	endpoint.User = nil
+	call := &Call{
+		Time:      time.Now().UTC(),
+		Method:    req.Method,
+		Endpoint:  endpoint.String(),
+		Operation: req.Header.Get("X-Amz-Target"),
+	}
+	if call.Operation == "" {
+		var action string
+		req, action = formAction(req)
+		call.Operation = action
+	}
+
+	resp, err := t.Wrapped.RoundTrip(req)
+	call.LatencyMS = int64(time.Since(call.Time) / time.Millisecond)
+	if err != nil {
+		call.Error = err.Error()
+	} else {
+		call.Status = resp.StatusCode
+		for _, header := range requestIDHeaders {
+			if id := resp.Header.Get(header); id != "" {
+				call.RequestID = id
+				break
+			}
+		}
+	}
+	t.record(call)
+	return resp, err
+}
+
+func (t *Transport) record(call *Call) {
+	data, err := json.Marshal(call)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Writer.Write(append(data, '\n'))
+}
+
+// formAction returns the Action of the form-encoded body of the request,
+// and the request with a body which can be read again.
+func formAction(req *http.Request) (*http.Request, string) {
+	if req.Body == nil || req.ContentLength <= 0 || req.ContentLength > maxFormLength {
+		return req, ""
+	}
+	if mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err != nil || mediaType != "application/x-www-form-urlencoded" {
+		return req, ""
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	clone := req.WithContext(req.Context())
+	clone.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return clone, ""
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return clone, ""
+	}
+	return clone, values.Get("Action")
+}
+
+// Install wraps http.DefaultTransport, which the AWS and OpenStack clients
+// send their requests through, to record the requests to w, and returns a
+// function which restores it.
+func Install(w io.Writer) func() {
+	original := http.DefaultTransport
+	http.DefaultTransport = &Transport{Wrapped: original, Writer: w}
+	return func() {
+		http.DefaultTransport = original
+	}
+}
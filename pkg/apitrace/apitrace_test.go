@@ -0,0 +1,76 @@
+package apitrace
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransport(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("X-Amzn-Requestid", "request-1")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	var trace bytes.Buffer
+	client := &http.Client{Transport: &Transport{Wrapped: http.DefaultTransport, Writer: &trace}}
+
+	// An AWS query API request, whose Action is in its form, and an AWS
+	// JSON API request, whose operation is in its X-Amz-Target header.
+	form := "Action=DescribeImages&Version=2016-11-15&ImageId.1=ami-1"
+	req, err := http.NewRequest("POST", server.URL+"/?X-Amz-Signature=hunter2", strings.NewReader(form))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	req, err = http.NewRequest("POST", server.URL+"/", strings.NewReader(`{"SecretString":"hunter2"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.CreateSecret")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// The bodies reach the server unchanged, but not the trace.
+	assert.Equal(t, []string{form, `{"SecretString":"hunter2"}`}, bodies)
+	assert.NotContains(t, trace.String(), "hunter2")
+
+	var calls []Call
+	decoder := json.NewDecoder(&trace)
+	for decoder.More() {
+		var call Call
+		if err := decoder.Decode(&call); err != nil {
+			t.Fatal(err)
+		}
+		calls = append(calls, call)
+	}
+	if assert.Len(t, calls, 2) {
+		for i, operation := range []string{"DescribeImages", "secretsmanager.CreateSecret"} {
+			assert.Equal(t, "POST", calls[i].Method)
+			assert.Equal(t, server.URL+"/", calls[i].Endpoint)
+			assert.Equal(t, operation, calls[i].Operation)
+			assert.Equal(t, http.StatusForbidden, calls[i].Status)
+			assert.Equal(t, "request-1", calls[i].RequestID)
+		}
+	}
+}
@@ -35,6 +35,31 @@ type WritableAsset interface {
 	Load(FileFetcher) (found bool, err error)
 }
 
+// Sensitive is implemented by assets which contain secrets, such as private
+// keys, pull secrets, and passwords.
+type Sensitive interface {
+	// Sensitive returns whether the asset contains secrets.
+	Sensitive() bool
+}
+
+// Expirer is implemented by assets which expire, such as certificates. An
+// asset in the state file which has expired, or is about to, is regenerated
+// along with the assets which depend on it.
+type Expirer interface {
+	// Expired returns whether the asset has expired or is about to.
+	Expired() bool
+}
+
+// Merger is implemented by assets whose files other commands add records
+// to, such as the cluster metadata. When the asset is written, its files
+// are merged with the copies in the asset directory instead of replacing
+// them.
+type Merger interface {
+	// Merge returns the data to write for the file, given the data of the
+	// copy in the asset directory.
+	Merge(file *File, existing []byte) ([]byte, error)
+}
+
 // File is a file for an Asset.
 type File struct {
 	// Filename is the name of the file.
@@ -51,7 +76,19 @@ func PersistToFile(asset WritableAsset, directory string) error {
 		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 			return errors.Wrap(err, "failed to create dir")
 		}
-		if err := ioutil.WriteFile(path, f.Data, 0644); err != nil {
+		data := f.Data
+		if merger, ok := asset.(Merger); ok {
+			existing, err := ioutil.ReadFile(path)
+			if err == nil {
+				data, err = merger.Merge(f, existing)
+				if err != nil {
+					return errors.Wrapf(err, "failed to merge %s", f.Filename)
+				}
+			} else if !os.IsNotExist(err) {
+				return errors.Wrap(err, "failed to read file")
+			}
+		}
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
 			return errors.Wrap(err, "failed to write file")
 		}
 	}
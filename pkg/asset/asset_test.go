@@ -86,6 +86,35 @@ func TestPersistToFile(t *testing.T) {
 	}
 }
 
+type mergingPersistAsset struct {
+	writablePersistAsset
+}
+
+func (a *mergingPersistAsset) Merge(file *File, existing []byte) ([]byte, error) {
+	return append(existing, file.Data...), nil
+}
+
+// TestPersistToFileMerger tests that the files of an asset which merges
+// them, such as the cluster metadata of a resumed creation, keep the
+// records added to the copies in the asset directory.
+func TestPersistToFileMerger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestPersistToFileMerger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	asset := &mergingPersistAsset{writablePersistAsset{FileList: []*File{{Filename: "metadata.json", Data: []byte("generated")}}}}
+	assert.NoError(t, PersistToFile(asset, dir))
+	verifyFilesCreated(t, dir, map[string][]byte{filepath.Join(dir, "metadata.json"): []byte("generated")})
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "metadata.json"), []byte("recorded,"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, PersistToFile(asset, dir))
+	verifyFilesCreated(t, dir, map[string][]byte{filepath.Join(dir, "metadata.json"): []byte("recorded,generated")})
+}
+
 func verifyFilesCreated(t *testing.T, dir string, expectedFiles map[string][]byte) {
 	dirContents, err := ioutil.ReadDir(dir)
 	assert.NoError(t, err, "could not read contents of directory %q", dir)
@@ -0,0 +1,227 @@
+package capi
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	awsprovider "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsproviderconfig/v1alpha1"
+	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// awsCluster returns the Cluster and AWSCluster of the install-config. The
+// AWSCluster is managed by the installer, whose Terraform creates the
+// network, load balancers and security groups of the cluster.
+func awsCluster(infraID string, config *types.InstallConfig) []runtime.Object {
+	infrastructureRef := &corev1.ObjectReference{
+		APIVersion: infrastructureAPIVersion,
+		Kind:       "AWSCluster",
+		Namespace:  infraID,
+		Name:       infraID,
+	}
+	infrastructure := &object{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: infrastructureAPIVersion,
+			Kind:       "AWSCluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   infraID,
+			Name:        infraID,
+			Annotations: map[string]string{managedByAnnotation: "openshift-install"},
+		},
+		Spec: &awsClusterSpec{
+			Region:               config.Platform.AWS.Region,
+			ControlPlaneEndpoint: controlPlaneEndpoint(config),
+			AdditionalTags:       config.Platform.AWS.UserTags,
+		},
+	}
+	return []runtime.Object{cluster(infraID, config, infrastructureRef), infrastructure}
+}
+
+// awsMachines returns a Machine and an AWSMachine for each of the master
+// machines.
+func awsMachines(infraID string, machines []clusterapi.Machine) ([]runtime.Object, error) {
+	var objects []runtime.Object
+	for _, m := range machines {
+		provider, err := awsProvider(m.Spec.ProviderSpec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the provider spec of %s", m.Name)
+		}
+		spec, err := newAWSMachineSpec(provider)
+		if err != nil {
+			return nil, errors.Wrap(err, m.Name)
+		}
+
+		labels := map[string]string{
+			clusterNameLabel:  infraID,
+			controlPlaneLabel: "",
+		}
+		infrastructureRef := corev1.ObjectReference{
+			APIVersion: infrastructureAPIVersion,
+			Kind:       "AWSMachine",
+			Namespace:  infraID,
+			Name:       m.Name,
+		}
+		machine := machineObject(infraID, m.Name, labels, machineSpec{
+			ClusterName:       infraID,
+			Bootstrap:         bootstrap{DataSecretName: userDataSecretName(provider)},
+			InfrastructureRef: infrastructureRef,
+			FailureDomain:     provider.Placement.AvailabilityZone,
+		})
+		objects = append(objects, machine, &object{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: infrastructureAPIVersion,
+				Kind:       "AWSMachine",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: infraID,
+				Name:      m.Name,
+				Labels:    labels,
+			},
+			Spec: spec,
+		})
+	}
+	return objects, nil
+}
+
+// awsMachineDeployments returns a MachineDeployment and an
+// AWSMachineTemplate for each of the worker machinesets, with the same
+// name and replicas.
+func awsMachineDeployments(infraID string, sets []clusterapi.MachineSet) ([]runtime.Object, error) {
+	var objects []runtime.Object
+	for _, set := range sets {
+		provider, err := awsProvider(set.Spec.Template.Spec.ProviderSpec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the provider spec of %s", set.Name)
+		}
+		spec, err := newAWSMachineSpec(provider)
+		if err != nil {
+			return nil, errors.Wrap(err, set.Name)
+		}
+
+		labels := map[string]string{
+			clusterNameLabel: infraID,
+			deploymentLabel:  set.Name,
+		}
+		objects = append(objects, &object{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: clusterAPIVersion,
+				Kind:       "MachineDeployment",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: infraID,
+				Name:      set.Name,
+				Labels:    map[string]string{clusterNameLabel: infraID},
+			},
+			Spec: &machineDeploymentSpec{
+				ClusterName: infraID,
+				Replicas:    set.Spec.Replicas,
+				Selector:    metav1.LabelSelector{MatchLabels: labels},
+				Template: machineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: machineSpec{
+						ClusterName: infraID,
+						Bootstrap:   bootstrap{DataSecretName: userDataSecretName(provider)},
+						InfrastructureRef: corev1.ObjectReference{
+							APIVersion: infrastructureAPIVersion,
+							Kind:       "AWSMachineTemplate",
+							Namespace:  infraID,
+							Name:       set.Name,
+						},
+						FailureDomain: provider.Placement.AvailabilityZone,
+					},
+				},
+			},
+		}, &object{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: infrastructureAPIVersion,
+				Kind:       "AWSMachineTemplate",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: infraID,
+				Name:      set.Name,
+				Labels:    map[string]string{clusterNameLabel: infraID},
+			},
+			Spec: &awsMachineTemplateSpec{
+				Template: awsMachineTemplateResource{Spec: *spec},
+			},
+		})
+	}
+	return objects, nil
+}
+
+// awsProvider decodes the AWS provider spec of a machine.
+func awsProvider(providerSpec clusterapi.ProviderSpec) (*awsprovider.AWSMachineProviderConfig, error) {
+	if providerSpec.Value == nil {
+		return nil, errors.New("no provider spec")
+	}
+	provider := &awsprovider.AWSMachineProviderConfig{}
+	if err := json.Unmarshal(providerSpec.Value.Raw, provider); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// newAWSMachineSpec returns the AWSMachine spec of the machines of the
+// provider spec. They boot the Ignition config of their bootstrap data
+// secret unchanged, as their user data.
+func newAWSMachineSpec(provider *awsprovider.AWSMachineProviderConfig) (*awsMachineSpec, error) {
+	if provider.AMI.ID == nil {
+		return nil, errors.Errorf("the AMI in %s is copied from another region by 'create cluster', so its ID is not known yet; set the osImage of the platform to an AMI of %s", provider.Placement.Region, provider.Placement.Region)
+	}
+	spec := &awsMachineSpec{
+		AMI:          amiReference{ID: provider.AMI.ID},
+		InstanceType: provider.InstanceType,
+		Subnet:       awsReference(provider.Subnet),
+		PublicIP:     provider.PublicIP,
+		Ignition: &ignition{
+			Version:     "2.3",
+			StorageType: "UnencryptedUserData",
+		},
+	}
+	if provider.IAMInstanceProfile != nil && provider.IAMInstanceProfile.ID != nil {
+		spec.IAMInstanceProfile = *provider.IAMInstanceProfile.ID
+	}
+	if len(provider.Tags) > 0 {
+		spec.AdditionalTags = map[string]string{}
+		for _, tag := range provider.Tags {
+			spec.AdditionalTags[tag.Name] = tag.Value
+		}
+	}
+	for _, group := range provider.SecurityGroups {
+		spec.AdditionalSecurityGroups = append(spec.AdditionalSecurityGroups, *awsReference(group))
+	}
+	for _, device := range provider.BlockDevices {
+		if device.EBS == nil || device.EBS.VolumeSize == nil {
+			continue
+		}
+		spec.RootVolume = &volume{Size: *device.EBS.VolumeSize}
+		if device.EBS.VolumeType != nil {
+			spec.RootVolume.Type = *device.EBS.VolumeType
+		}
+		if device.EBS.Iops != nil {
+			spec.RootVolume.IOPS = *device.EBS.Iops
+		}
+		break
+	}
+	return spec, nil
+}
+
+func awsReference(reference awsprovider.AWSResourceReference) *awsResourceReference {
+	result := &awsResourceReference{ID: reference.ID}
+	for _, f := range reference.Filters {
+		result.Filters = append(result.Filters, filter{Name: f.Name, Values: f.Values})
+	}
+	return result
+}
+
+func userDataSecretName(provider *awsprovider.AWSMachineProviderConfig) *string {
+	if provider.UserDataSecret == nil {
+		return nil
+	}
+	return &provider.UserDataSecret.Name
+}
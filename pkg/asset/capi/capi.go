@@ -0,0 +1,288 @@
+// Package capi contains the assets which describe the infrastructure of the
+// cluster as Cluster API resources, for management clusters which manage
+// the machines of their clusters with Cluster API.
+package capi
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition/machine"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/machines"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+const (
+	capiDir = "capi"
+
+	clusterFilename           = "01_cluster.yaml"
+	userDataFilename          = "02_user-data-secrets.yaml"
+	masterMachinesFilename    = "03_master-machines.yaml"
+	workerDeploymentsFilename = "04_worker-machinedeployments.yaml"
+
+	// managedByAnnotation marks the infrastructure cluster as managed by
+	// the installer, which creates it with Terraform, so that the
+	// Cluster API provider does not reconcile it.
+	managedByAnnotation = "cluster.x-k8s.io/managed-by"
+
+	clusterNameLabel  = "cluster.x-k8s.io/cluster-name"
+	controlPlaneLabel = "cluster.x-k8s.io/control-plane"
+	deploymentLabel   = "cluster.x-k8s.io/deployment-name"
+)
+
+// Manifests is an asset that generates the Cluster API resources of the
+// cluster: its Cluster and infrastructure cluster, a Machine and an
+// infrastructure machine for each master, a MachineDeployment and an
+// infrastructure machine template for each worker machineset, and the
+// secrets of their Ignition configs. They are in a namespace named after
+// the infrastructure name of the cluster.
+type Manifests struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Manifests)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (m *Manifests) Name() string {
+	return "Cluster API Manifests"
+}
+
+// Dependencies returns the assets on which the Manifests asset depends.
+func (m *Manifests) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.ClusterID{},
+		&installconfig.InstallConfig{},
+		&machine.Master{},
+		&machine.Worker{},
+		&machines.Master{},
+		&machines.Worker{},
+	}
+}
+
+// Generate generates the Cluster API resources from the machines and
+// machinesets of the cluster.
+func (m *Manifests) Generate(dependencies asset.Parents) error {
+	clusterID := &installconfig.ClusterID{}
+	installConfig := &installconfig.InstallConfig{}
+	masterIgn := &machine.Master{}
+	workerIgn := &machine.Worker{}
+	masters := &machines.Master{}
+	workers := &machines.Worker{}
+	dependencies.Get(clusterID, installConfig, masterIgn, workerIgn, masters, workers)
+
+	config := installConfig.Config
+	if platform := config.Platform.Name(); platform != aws.Name {
+		return errors.Errorf("Cluster API manifests are not supported on %s", platform)
+	}
+
+	infraID := types.InfraID(config.ObjectMeta.Name, clusterID.ClusterID)
+	masterMachines, err := decodeMachines(masters.MachinesRaw)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode the master machines")
+	}
+	workerSets, err := decodeMachineSets(workers.MachineSetRaw)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode the worker machinesets")
+	}
+
+	clusterObjects := append([]runtime.Object{namespace(infraID)}, awsCluster(infraID, config)...)
+	userData := []runtime.Object{
+		userDataSecret(infraID, "master-user-data", masterIgn.File.Data),
+		userDataSecret(infraID, "worker-user-data", workerIgn.File.Data),
+	}
+	masterObjects, err := awsMachines(infraID, masterMachines)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the master machines")
+	}
+	workerObjects, err := awsMachineDeployments(infraID, workerSets)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the worker machine deployments")
+	}
+
+	m.FileList = nil
+	for _, f := range []struct {
+		name    string
+		objects []runtime.Object
+	}{
+		{name: clusterFilename, objects: clusterObjects},
+		{name: userDataFilename, objects: userData},
+		{name: masterMachinesFilename, objects: masterObjects},
+		{name: workerDeploymentsFilename, objects: workerObjects},
+	} {
+		if len(f.objects) == 0 {
+			continue
+		}
+		data, err := yaml.Marshal(list(f.objects))
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal %s", f.name)
+		}
+		m.FileList = append(m.FileList, &asset.File{
+			Filename: filepath.Join(capiDir, f.name),
+			Data:     data,
+		})
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (m *Manifests) Files() []*asset.File {
+	return m.FileList
+}
+
+// Load returns false, because the manifests are always generated from the
+// machines of the cluster.
+func (m *Manifests) Load(f asset.FileFetcher) (found bool, err error) {
+	return false, nil
+}
+
+// DeepCopyObject makes object a runtime.Object, so that it can be an item
+// of a list.
+func (o *object) DeepCopyObject() runtime.Object {
+	out := *o
+	o.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return &out
+}
+
+func list(objects []runtime.Object) *metav1.List {
+	l := &metav1.List{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "List",
+		},
+	}
+	for _, o := range objects {
+		l.Items = append(l.Items, runtime.RawExtension{Object: o})
+	}
+	return l
+}
+
+func namespace(infraID string) *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: infraID,
+		},
+	}
+}
+
+// userDataSecret returns the bootstrap data secret of Cluster API, which
+// holds the Ignition config of a role.
+func userDataSecret(infraID, name string, ignition []byte) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: infraID,
+			Name:      name,
+			Labels:    map[string]string{clusterNameLabel: infraID},
+		},
+		Type: "cluster.x-k8s.io/secret",
+		Data: map[string][]byte{
+			"format": []byte("ignition"),
+			"value":  ignition,
+		},
+	}
+}
+
+// cluster returns the Cluster of the install-config, whose infrastructure
+// cluster is infrastructureRef.
+func cluster(infraID string, config *types.InstallConfig, infrastructureRef *corev1.ObjectReference) *object {
+	network := &clusterNetwork{}
+	for _, n := range config.Networking.ClusterNetworks {
+		if network.Pods == nil {
+			network.Pods = &networkRanges{}
+		}
+		network.Pods.CIDRBlocks = append(network.Pods.CIDRBlocks, n.CIDR)
+	}
+	if config.Networking.ServiceCIDR != nil {
+		network.Services = &networkRanges{CIDRBlocks: []string{config.Networking.ServiceCIDR.String()}}
+	}
+
+	return &object{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterAPIVersion,
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: infraID,
+			Name:      infraID,
+		},
+		Spec: &clusterSpec{
+			ClusterNetwork:       network,
+			ControlPlaneEndpoint: controlPlaneEndpoint(config),
+			InfrastructureRef:    infrastructureRef,
+		},
+	}
+}
+
+func controlPlaneEndpoint(config *types.InstallConfig) apiEndpoint {
+	return apiEndpoint{
+		Host: fmt.Sprintf("%s-api.%s", config.ObjectMeta.Name, config.BaseDomain),
+		Port: 6443,
+	}
+}
+
+// machineObject returns a Machine.
+func machineObject(infraID, name string, labels map[string]string, spec machineSpec) *object {
+	return &object{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterAPIVersion,
+			Kind:       "Machine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: infraID,
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: &spec,
+	}
+}
+
+// decodeMachines decodes the list of machines of the Master asset.
+func decodeMachines(data []byte) ([]clusterapi.Machine, error) {
+	l := &metav1.List{}
+	if err := yaml.Unmarshal(data, l); err != nil {
+		return nil, err
+	}
+	result := make([]clusterapi.Machine, len(l.Items))
+	for i, item := range l.Items {
+		if err := yaml.Unmarshal(item.Raw, &result[i]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// decodeMachineSets decodes the list of machinesets of the Worker asset,
+// which is empty if the workers are user-provisioned.
+func decodeMachineSets(data []byte) ([]clusterapi.MachineSet, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	l := &metav1.List{}
+	if err := yaml.Unmarshal(data, l); err != nil {
+		return nil, err
+	}
+	result := make([]clusterapi.MachineSet, len(l.Items))
+	for i, item := range l.Items {
+		if err := yaml.Unmarshal(item.Raw, &result[i]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,169 @@
+package capi
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	machinesaws "github.com/openshift/installer/pkg/asset/machines/aws"
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func testInstallConfig() *types.InstallConfig {
+	return &types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		BaseDomain: "example.com",
+		Networking: &types.Networking{
+			MachineCIDR: ipnet.MustParseCIDR("10.0.0.0/16"),
+			ServiceCIDR: ipnet.MustParseCIDR("172.30.0.0/16"),
+		},
+		Platform: types.Platform{
+			AWS: &aws.Platform{Region: "us-east-1"},
+		},
+	}
+}
+
+func testPool(name string, replicas int64) *types.MachinePool {
+	return &types.MachinePool{
+		Name:     name,
+		Replicas: &replicas,
+		Platform: types.MachinePoolPlatform{
+			AWS: &aws.MachinePool{
+				Zones:         []string{"us-east-1a", "us-east-1b"},
+				InstanceType:  "m4.large",
+				EC2RootVolume: aws.EC2RootVolume{Type: "gp2", Size: 120},
+			},
+		},
+	}
+}
+
+// roundTrip marshals the objects as the machines assets do, and returns
+// the YAML.
+func roundTrip(t *testing.T, objects []runtime.Object) []byte {
+	data, err := yaml.Marshal(list(objects))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestAWSMachines(t *testing.T) {
+	config := testInstallConfig()
+	machines, err := machinesaws.Machines("test-cluster-id", config, testPool("master", 3), "ami-0123456789", "master", "master-user-data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects := make([]runtime.Object, len(machines))
+	for i := range machines {
+		objects[i] = &machines[i]
+	}
+	decoded, err := decodeMachines(roundTrip(t, objects))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := awsMachines("test-infra", decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Len(t, result, 6) {
+		return
+	}
+
+	machine := result[2].(*object)
+	assert.Equal(t, "Machine", machine.Kind)
+	assert.Equal(t, "test-cluster-master-1", machine.Name)
+	assert.Equal(t, "", machine.Labels[controlPlaneLabel])
+	spec := machine.Spec.(*machineSpec)
+	assert.Equal(t, "test-infra", spec.ClusterName)
+	assert.Equal(t, "master-user-data", *spec.Bootstrap.DataSecretName)
+	assert.Equal(t, "us-east-1b", spec.FailureDomain)
+	assert.Equal(t, "AWSMachine", spec.InfrastructureRef.Kind)
+
+	infrastructure := result[3].(*object)
+	assert.Equal(t, "AWSMachine", infrastructure.Kind)
+	awsSpec := infrastructure.Spec.(*awsMachineSpec)
+	assert.Equal(t, "ami-0123456789", *awsSpec.AMI.ID)
+	assert.Equal(t, "m4.large", awsSpec.InstanceType)
+	assert.Equal(t, "test-cluster-master-profile", awsSpec.IAMInstanceProfile)
+	assert.Equal(t, &volume{Size: 120, Type: "gp2"}, awsSpec.RootVolume)
+	assert.Equal(t, []filter{{Name: "tag:Name", Values: []string{"test-cluster-master-us-east-1b"}}}, awsSpec.Subnet.Filters)
+	assert.Equal(t, "owned", awsSpec.AdditionalTags["kubernetes.io/cluster/test-cluster"])
+}
+
+func TestAWSMachineDeployments(t *testing.T) {
+	config := testInstallConfig()
+	sets, err := machinesaws.MachineSets("test-cluster-id", config, testPool("worker", 3), "ami-0123456789", "worker", "worker-user-data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects := make([]runtime.Object, len(sets))
+	for i := range sets {
+		objects[i] = &sets[i]
+	}
+	decoded, err := decodeMachineSets(roundTrip(t, objects))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := awsMachineDeployments("test-infra", decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Len(t, result, 4) {
+		return
+	}
+
+	deployment := result[0].(*object)
+	assert.Equal(t, "MachineDeployment", deployment.Kind)
+	assert.Equal(t, "test-cluster-worker-us-east-1a", deployment.Name)
+	spec := deployment.Spec.(*machineDeploymentSpec)
+	assert.Equal(t, int32(2), *spec.Replicas)
+	assert.Equal(t, spec.Selector.MatchLabels, spec.Template.ObjectMeta.Labels)
+	assert.Equal(t, "worker-user-data", *spec.Template.Spec.Bootstrap.DataSecretName)
+	assert.Equal(t, "AWSMachineTemplate", spec.Template.Spec.InfrastructureRef.Kind)
+	assert.Equal(t, "test-cluster-worker-us-east-1a", spec.Template.Spec.InfrastructureRef.Name)
+
+	template := result[1].(*object)
+	assert.Equal(t, "AWSMachineTemplate", template.Kind)
+	assert.Equal(t, "m4.large", template.Spec.(*awsMachineTemplateSpec).Template.Spec.InstanceType)
+	assert.Equal(t, int32(1), *result[2].(*object).Spec.(*machineDeploymentSpec).Replicas)
+}
+
+func TestAWSCopiedAMI(t *testing.T) {
+	config := testInstallConfig()
+	generated, err := machinesaws.Machines("test-cluster-id", config, testPool("master", 1), "ami-0123456789,us-west-2", "master", "master-user-data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	machines, err := decodeMachines(roundTrip(t, []runtime.Object{&generated[0]}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = awsMachines("test-infra", machines)
+	assert.Contains(t, err.Error(), "copied from another region")
+}
+
+func TestAWSCluster(t *testing.T) {
+	objects := awsCluster("test-infra", testInstallConfig())
+	if !assert.Len(t, objects, 2) {
+		return
+	}
+
+	cluster := objects[0].(*object)
+	assert.Equal(t, "Cluster", cluster.Kind)
+	spec := cluster.Spec.(*clusterSpec)
+	assert.Equal(t, apiEndpoint{Host: "test-cluster-api.example.com", Port: 6443}, spec.ControlPlaneEndpoint)
+	assert.Equal(t, []string{"172.30.0.0/16"}, spec.ClusterNetwork.Services.CIDRBlocks)
+	assert.Equal(t, "AWSCluster", spec.InfrastructureRef.Kind)
+
+	infrastructure := objects[1].(*object)
+	assert.Equal(t, "openshift-install", infrastructure.Annotations[managedByAnnotation])
+	assert.Equal(t, "us-east-1", infrastructure.Spec.(*awsClusterSpec).Region)
+}
@@ -0,0 +1,115 @@
+package capi
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The types below are the subsets of the Cluster API and Cluster API
+// Provider AWS types which the manifests set, with the same JSON names.
+
+const (
+	clusterAPIVersion        = "cluster.x-k8s.io/v1beta1"
+	infrastructureAPIVersion = "infrastructure.cluster.x-k8s.io/v1beta2"
+)
+
+// object is a Kubernetes object with a spec.
+type object struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              interface{} `json:"spec,omitempty"`
+}
+
+type clusterSpec struct {
+	ClusterNetwork       *clusterNetwork         `json:"clusterNetwork,omitempty"`
+	ControlPlaneEndpoint apiEndpoint             `json:"controlPlaneEndpoint"`
+	InfrastructureRef    *corev1.ObjectReference `json:"infrastructureRef"`
+}
+
+type clusterNetwork struct {
+	Pods     *networkRanges `json:"pods,omitempty"`
+	Services *networkRanges `json:"services,omitempty"`
+}
+
+type networkRanges struct {
+	CIDRBlocks []string `json:"cidrBlocks"`
+}
+
+type apiEndpoint struct {
+	Host string `json:"host"`
+	Port int32  `json:"port"`
+}
+
+type machineSpec struct {
+	ClusterName       string                 `json:"clusterName"`
+	Bootstrap         bootstrap              `json:"bootstrap"`
+	InfrastructureRef corev1.ObjectReference `json:"infrastructureRef"`
+	FailureDomain     string                 `json:"failureDomain,omitempty"`
+}
+
+type bootstrap struct {
+	DataSecretName *string `json:"dataSecretName"`
+}
+
+type machineDeploymentSpec struct {
+	ClusterName string               `json:"clusterName"`
+	Replicas    *int32               `json:"replicas,omitempty"`
+	Selector    metav1.LabelSelector `json:"selector"`
+	Template    machineTemplateSpec  `json:"template"`
+}
+
+type machineTemplateSpec struct {
+	ObjectMeta metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec       machineSpec       `json:"spec"`
+}
+
+type awsClusterSpec struct {
+	Region               string            `json:"region"`
+	ControlPlaneEndpoint apiEndpoint       `json:"controlPlaneEndpoint"`
+	AdditionalTags       map[string]string `json:"additionalTags,omitempty"`
+}
+
+type awsMachineSpec struct {
+	AMI                      amiReference           `json:"ami"`
+	InstanceType             string                 `json:"instanceType"`
+	IAMInstanceProfile       string                 `json:"iamInstanceProfile,omitempty"`
+	AdditionalTags           map[string]string      `json:"additionalTags,omitempty"`
+	AdditionalSecurityGroups []awsResourceReference `json:"additionalSecurityGroups,omitempty"`
+	Subnet                   *awsResourceReference  `json:"subnet,omitempty"`
+	PublicIP                 *bool                  `json:"publicIP,omitempty"`
+	RootVolume               *volume                `json:"rootVolume,omitempty"`
+	Ignition                 *ignition              `json:"ignition,omitempty"`
+}
+
+type awsMachineTemplateSpec struct {
+	Template awsMachineTemplateResource `json:"template"`
+}
+
+type awsMachineTemplateResource struct {
+	Spec awsMachineSpec `json:"spec"`
+}
+
+type amiReference struct {
+	ID *string `json:"id,omitempty"`
+}
+
+type awsResourceReference struct {
+	ID      *string  `json:"id,omitempty"`
+	Filters []filter `json:"filters,omitempty"`
+}
+
+type filter struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+type volume struct {
+	Size int64  `json:"size"`
+	Type string `json:"type,omitempty"`
+	IOPS int64  `json:"iops,omitempty"`
+}
+
+type ignition struct {
+	Version     string `json:"version"`
+	StorageType string `json:"storageType"`
+}
@@ -11,7 +11,8 @@ import (
 // Metadata converts an install configuration to AWS metadata.
 func Metadata(clusterID string, config *types.InstallConfig) *aws.Metadata {
 	return &aws.Metadata{
-		Region: config.Platform.AWS.Region,
+		Region:     config.Platform.AWS.Region,
+		BaseDomain: config.BaseDomain,
 		Identifier: []map[string]string{
 			{
 				"openshiftClusterID": clusterID,
@@ -1,22 +1,41 @@
 package cluster
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	icaws "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	"github.com/openshift/installer/pkg/asset/password"
 	"github.com/openshift/installer/pkg/terraform"
+	awstfvars "github.com/openshift/installer/pkg/tfvars/aws"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/version"
 )
 
 var (
 	// kubeadminPasswordPath is the path where kubeadmin user password is stored.
 	kubeadminPasswordPath = filepath.Join("auth", "kubeadmin-password")
+
+	// ConfirmPlan makes Generate show the Terraform plan and ask for its
+	// approval before applying it, as a checkpoint between generating the
+	// assets and changing the cloud.
+	ConfirmPlan = false
+
+	// RecordMilestone, if set, is called with the name of each milestone
+	// of the creation of the cluster which Generate reaches, such as the
+	// start and end of Terraform.
+	RecordMilestone func(name string)
 )
 
 // Cluster uses the terraform executable to launch a cluster
@@ -32,6 +51,11 @@ func (c *Cluster) Name() string {
 	return "Cluster"
 }
 
+// Sensitive returns true, because the Terraform state contains the bootstrap Ignition config.
+func (c *Cluster) Sensitive() bool {
+	return true
+}
+
 // Dependencies returns the direct dependency for launching
 // the cluster.
 func (c *Cluster) Dependencies() []asset.Asset {
@@ -40,6 +64,7 @@ func (c *Cluster) Dependencies() []asset.Asset {
 		&installconfig.InstallConfig{},
 		&TerraformVariables{},
 		&password.KubeadminPassword{},
+		&FailedApply{},
 	}
 }
 
@@ -49,11 +74,14 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 	installConfig := &installconfig.InstallConfig{}
 	terraformVariables := &TerraformVariables{}
 	kubeadminPassword := &password.KubeadminPassword{}
-	parents.Get(clusterID, installConfig, terraformVariables, kubeadminPassword)
+	failedApply := &FailedApply{}
+	parents.Get(clusterID, installConfig, terraformVariables, kubeadminPassword, failedApply)
 
+	c.FileList = nil
 	if installConfig.Config.Platform.None != nil {
 		return errors.New("cluster cannot be created with platform set to 'none'")
 	}
+	recordMilestone(types.MilestoneAssetsGenerated)
 
 	// Copy the terraform.tfvars to a temp directory where the terraform will be invoked within.
 	tmpDir, err := ioutil.TempDir("", "openshift-install-")
@@ -62,48 +90,203 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 	}
 	defer os.RemoveAll(tmpDir)
 
-	terraformVariablesFile := terraformVariables.Files()[0]
-	if err := ioutil.WriteFile(filepath.Join(tmpDir, terraformVariablesFile.Filename), terraformVariablesFile.Data, 0600); err != nil {
+	terraformVariablesData, err := terraformVariables.Data()
+	if err != nil {
+		return errors.Wrapf(err, "failed to decrypt %s", TfVarsFileName)
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, TfVarsFileName), terraformVariablesData, 0600); err != nil {
 		return errors.Wrap(err, "failed to write terraform.tfvars file")
 	}
 
-	c.FileList = []*asset.File{
-		{
+	if failedApply.State != nil {
+		if err := resume(tmpDir, failedApply); err != nil {
+			return err
+		}
+	}
+
+	c.FileList = []*asset.File{}
+	if kubeadminPassword.Password != "" {
+		c.FileList = append(c.FileList, &asset.File{
 			Filename: kubeadminPasswordPath,
 			Data:     []byte(kubeadminPassword.Password),
-		},
+		})
+	}
+
+	if !SkipDNSPreflight {
+		// The records of a resumed attempt are the cluster's own.
+		if err := checkDNS(installConfig.Config, failedApply.State == nil); err != nil {
+			return err
+		}
+	}
+
+	if !SkipProxyPreflight {
+		releaseImage := version.DefaultReleaseImage
+		if ri, ok := os.LookupEnv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE"); ok && ri != "" {
+			releaseImage = ri
+		}
+		if err := checkProxy(installConfig.Config, releaseImage); err != nil {
+			return err
+		}
+	}
+
+	if !SkipPermissionsPreflight {
+		if err := checkPermissions(installConfig.Config); err != nil {
+			return err
+		}
+	}
+
+	// A resumed attempt already holds some of the resources it needs.
+	if !SkipQuotaChecks && failedApply.State == nil {
+		if err := installconfig.CheckQuotas(installConfig.Config); err != nil {
+			return err
+		}
+	}
+
+	extraArgs, err := terraformArgs(clusterID.ClusterID, installConfig.Config)
+	if err != nil {
+		return err
+	}
+
+	if ConfirmPlan {
+		if err := confirmPlan(tmpDir, installConfig.Config.Platform.Name(), extraArgs...); err != nil {
+			return err
+		}
 	}
 
 	logrus.Infof("Creating cluster...")
-	stateFile, err := terraform.Apply(tmpDir, installConfig.Config.Platform.Name())
+	recordMilestone(types.MilestoneTerraformStarted)
+	err = terraform.ApplyStages(tmpDir, installConfig.Config.Platform.Name(), extraArgs...)
+	recordMilestone(types.MilestoneTerraformFinished)
 	if err != nil {
-		err = errors.Wrap(err, "failed to create cluster")
+		err = errors.Wrap(err, "failed to create cluster; run 'create cluster' again to retry from the resources which were created, or 'destroy cluster' to delete them")
+		c.FileList = append(c.FileList, &asset.File{
+			Filename: failedApplyFileName,
+			Data:     []byte(err.Error()),
+		})
 	}
 
-	data, err2 := ioutil.ReadFile(stateFile)
-	if err2 == nil {
+	// The stages after the first have no state unless they were reached,
+	// by this attempt or by the one it resumed.
+	for i, stateFileName := range terraform.StateFileNames() {
+		data, err2 := ioutil.ReadFile(filepath.Join(tmpDir, stateFileName))
+		if err2 != nil {
+			if i > 0 && os.IsNotExist(err2) {
+				continue
+			}
+			if err == nil {
+				err = err2
+			} else {
+				logrus.Errorf("Failed to read tfstate: %v", err2)
+			}
+			continue
+		}
+		data, err2 = asset.EncryptSensitive(data)
+		if err2 != nil {
+			if err == nil {
+				err = errors.Wrapf(err2, "failed to encrypt %s", stateFileName)
+			} else {
+				logrus.Errorf("Failed to encrypt tfstate: %v", err2)
+			}
+			continue
+		}
 		c.FileList = append(c.FileList, &asset.File{
-			Filename: terraform.StateFileName,
+			Filename: stateFileName,
 			Data:     data,
 		})
-	} else {
-		if err == nil {
-			err = err2
-		} else {
-			logrus.Errorf("Failed to read tfstate: %v", err2)
-		}
 	}
 
 	return err
 }
 
+func recordMilestone(name string) {
+	if RecordMilestone != nil {
+		RecordMilestone(name)
+	}
+}
+
+// terraformArgs returns the arguments which set the Terraform variables
+// that are only valid for a while, so that they are generated each time
+// Terraform runs rather than kept with the Terraform variables: on AWS, the
+// presigned URL of the bootstrap Ignition config.
+func terraformArgs(clusterID string, config *types.InstallConfig) ([]string, error) {
+	if config.Platform.AWS == nil {
+		return nil, nil
+	}
+
+	ssn, err := icaws.GetSession()
+	if err != nil {
+		return nil, err
+	}
+	client := s3.New(ssn, awssdk.NewConfig().WithRegion(config.Platform.AWS.Region))
+	url, err := awstfvars.PresignBootstrapIgnitionURL(client, awstfvars.BootstrapIgnitionBucket(clusterID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to presign the URL of the bootstrap Ignition config")
+	}
+	return []string{fmt.Sprintf("-var=aws_bootstrap_ignition_url=%s", url)}, nil
+}
+
+// confirmPlan logs a summary of the Terraform plan in dir and asks for its
+// approval.
+func confirmPlan(dir string, platform string, extraArgs ...string) error {
+	if !asset.Interactive {
+		return errors.New("confirming the Terraform plan requires an interactive terminal")
+	}
+
+	logrus.Info("Planning the cluster resources...")
+	plan, err := terraform.Plan(dir, platform, extraArgs...)
+	if err != nil {
+		return err
+	}
+	for _, resourceType := range plan.CreatedTypes() {
+		logrus.Infof("  %s: %d", resourceType, plan.Created[resourceType])
+	}
+	logrus.Infof("Terraform will add %d, change %d and destroy %d resources", plan.Add, plan.Change, plan.Destroy)
+
+	confirmed := false
+	if err := survey.AskOne(&survey.Confirm{
+		Message: fmt.Sprintf("Apply the plan to %d resources?", plan.Add+plan.Change+plan.Destroy),
+		Help:    "Nothing has been created yet. Declining stops before any cloud resources are changed, and running 'create cluster' again plans again.",
+	}, &confirmed, nil); err != nil {
+		return errors.Wrap(err, "failed UserInput for the plan confirmation")
+	}
+	if !confirmed {
+		return errors.New("the Terraform plan was not approved")
+	}
+	return nil
+}
+
+// resume writes the Terraform state of the failed attempt to dir, where
+// Terraform will pick it up, and describes what will be retried.
+func resume(dir string, failedApply *FailedApply) error {
+	created := 0
+	for filename, data := range failedApply.States() {
+		path := filepath.Join(dir, filename)
+		if err := ioutil.WriteFile(path, data, 0600); err != nil {
+			return errors.Wrap(err, "failed to write the Terraform state of the previous attempt")
+		}
+
+		state, err := terraform.ReadState(path)
+		if err != nil {
+			return errors.Wrap(err, "failed to read the Terraform state of the previous attempt")
+		}
+		for _, module := range state.Modules {
+			created += len(module.Resources)
+		}
+	}
+
+	logrus.Infof("Resuming the previous attempt to create the cluster, which failed: %s", strings.TrimSpace(failedApply.Failure))
+	logrus.Infof("The %d resources it created will be kept, and the rest will be created", created)
+	return nil
+}
+
 // Files returns the FileList generated by the asset.
 func (c *Cluster) Files() []*asset.File {
 	return c.FileList
 }
 
 // Load returns error if the tfstate file is already on-disk, because we want to
-// prevent user from accidentally re-launching the cluster.
+// prevent user from accidentally re-launching the cluster. The tfstate file of
+// a failed attempt is allowed, and is resumed from by Generate.
 func (c *Cluster) Load(f asset.FileFetcher) (found bool, err error) {
 	_, err = f.FetchByName(terraform.StateFileName)
 	if err != nil {
@@ -113,5 +296,13 @@ func (c *Cluster) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, err
 	}
 
+	_, err = f.FetchByName(failedApplyFileName)
+	if err == nil {
+		return false, nil
+	}
+	if !os.IsNotExist(err) {
+		return false, err
+	}
+
 	return true, errors.Errorf("%q already exists.  There may already be a running cluster", terraform.StateFileName)
 }
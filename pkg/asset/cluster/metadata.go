@@ -4,14 +4,18 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/cluster/aws"
 	"github.com/openshift/installer/pkg/asset/cluster/libvirt"
 	"github.com/openshift/installer/pkg/asset/cluster/openstack"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/rhcos"
 	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/validation"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -23,7 +27,10 @@ type Metadata struct {
 	file *asset.File
 }
 
-var _ asset.WritableAsset = (*Metadata)(nil)
+var (
+	_ asset.WritableAsset = (*Metadata)(nil)
+	_ asset.Merger        = (*Metadata)(nil)
+)
 
 // Name returns the human-friendly name of the asset.
 func (m *Metadata) Name() string {
@@ -36,6 +43,7 @@ func (m *Metadata) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&installconfig.ClusterID{},
 		&installconfig.InstallConfig{},
+		new(rhcos.Image),
 	}
 }
 
@@ -43,27 +51,18 @@ func (m *Metadata) Dependencies() []asset.Asset {
 func (m *Metadata) Generate(parents asset.Parents) (err error) {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
-	parents.Get(clusterID, installConfig)
+	rhcosImage := new(rhcos.Image)
+	parents.Get(clusterID, installConfig, rhcosImage)
 
 	if installConfig.Config.Platform.None != nil {
 		return nil
 	}
 
-	metadata := &types.ClusterMetadata{
-		ClusterName: installConfig.Config.ObjectMeta.Name,
-		ClusterID:   clusterID.ClusterID,
-	}
-
-	switch {
-	case installConfig.Config.Platform.AWS != nil:
-		metadata.ClusterPlatformMetadata.AWS = aws.Metadata(clusterID.ClusterID, installConfig.Config)
-	case installConfig.Config.Platform.Libvirt != nil:
-		metadata.ClusterPlatformMetadata.Libvirt = libvirt.Metadata(installConfig.Config)
-	case installConfig.Config.Platform.OpenStack != nil:
-		metadata.ClusterPlatformMetadata.OpenStack = openstack.Metadata(clusterID.ClusterID, installConfig.Config)
-	default:
-		return errors.Errorf("no known platform")
+	metadata, err := NewMetadata(clusterID.ClusterID, installConfig.Config)
+	if err != nil {
+		return err
 	}
+	metadata.OSImage = string(*rhcosImage)
 
 	data, err := json.Marshal(metadata)
 	if err != nil {
@@ -92,9 +91,65 @@ func (m *Metadata) Load(f asset.FileFetcher) (found bool, err error) {
 	return false, nil
 }
 
+// Merge keeps the records of the metadata in the asset directory, such as
+// the timeline of earlier attempts, which the generated metadata does not
+// have, if it is the metadata of the same cluster.
+func (m *Metadata) Merge(file *asset.File, existing []byte) ([]byte, error) {
+	previous := &types.ClusterMetadata{}
+	if err := json.Unmarshal(existing, previous); err != nil {
+		logrus.Warnf("Replacing the %s which could not be read: %v", file.Filename, err)
+		return file.Data, nil
+	}
+
+	metadata := &types.ClusterMetadata{}
+	if err := json.Unmarshal(file.Data, metadata); err != nil {
+		return nil, errors.Wrap(err, "failed to Unmarshal ClusterMetadata")
+	}
+	if previous.ClusterID != metadata.ClusterID {
+		return file.Data, nil
+	}
+	metadata.MergeRecords(previous)
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to Marshal ClusterMetadata")
+	}
+	return data, nil
+}
+
+// NewMetadata returns the metadata of the cluster with the ID and install
+// configuration.
+func NewMetadata(clusterID string, config *types.InstallConfig) (*types.ClusterMetadata, error) {
+	metadata := &types.ClusterMetadata{
+		APIVersion:  types.ClusterMetadataVersion,
+		ClusterName: config.ObjectMeta.Name,
+		ClusterID:   clusterID,
+		InfraID:     types.InfraID(config.ObjectMeta.Name, clusterID),
+	}
+
+	switch {
+	case config.Platform.AWS != nil:
+		metadata.ClusterPlatformMetadata.AWS = aws.Metadata(clusterID, config)
+	case config.Platform.Libvirt != nil:
+		metadata.ClusterPlatformMetadata.Libvirt = libvirt.Metadata(config)
+	case config.Platform.OpenStack != nil:
+		metadata.ClusterPlatformMetadata.OpenStack = openstack.Metadata(clusterID, config)
+	default:
+		return nil, errors.Errorf("no known platform")
+	}
+	metadata.PlatformName = config.Platform.Name()
+	return metadata, nil
+}
+
 // LoadMetadata loads the cluster metadata from an asset directory.
 func LoadMetadata(dir string) (*types.ClusterMetadata, error) {
-	path := filepath.Join(dir, metadataFileName)
+	return LoadMetadataFile(filepath.Join(dir, metadataFileName))
+}
+
+// LoadMetadataFile loads the cluster metadata from a file, such as a copy of
+// the metadata.json of an asset directory. It fails if the metadata is of a
+// version or platform which this installer does not support.
+func LoadMetadataFile(path string) (*types.ClusterMetadata, error) {
 	raw, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -104,6 +159,31 @@ func LoadMetadata(dir string) (*types.ClusterMetadata, error) {
 	if err = json.Unmarshal(raw, &metadata); err != nil {
 		return nil, errors.Wrapf(err, "failed to Unmarshal data from %q to types.ClusterMetadata", path)
 	}
+	if metadata == nil {
+		return nil, errors.Errorf("no cluster metadata in %q", path)
+	}
+	if err := validation.ValidateClusterMetadata(metadata).ToAggregate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid cluster metadata in %q", path)
+	}
 
-	return metadata, err
+	return metadata, nil
+}
+
+// AddMilestone records the milestone as reached now in the metadata of
+// the asset directory.
+func AddMilestone(dir string, name string) error {
+	path := filepath.Join(dir, metadataFileName)
+	metadata, err := LoadMetadataFile(path)
+	if err != nil {
+		return err
+	}
+	metadata.Timeline = append(metadata.Timeline, types.Milestone{
+		Name: name,
+		Time: time.Now().UTC(),
+	})
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(err, "failed to Marshal ClusterMetadata")
+	}
+	return errors.Wrapf(ioutil.WriteFile(path, data, 0644), "failed to record the %s milestone in %s", name, path)
 }
@@ -0,0 +1,53 @@
+package cluster
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func init() {
+	asset.RegisterMigration(asset.Migration{
+		Description: "Converted the AWS identifier in metadata.json from a single tag filter to a list of filters",
+		Filename:    metadataFileName,
+		File:        migrateAWSIdentifier,
+	})
+}
+
+// migrateAWSIdentifier converts the AWS identifier of metadata written by
+// installers which matched resources with a single tag filter, such as
+// {"tectonicClusterID": "..."}, to the list of filters the destroyer now
+// expects.
+func migrateAWSIdentifier(data []byte) ([]byte, error) {
+	metadata := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, errors.Wrap(err, "failed to Unmarshal metadata")
+	}
+
+	rawAWS, ok := metadata["aws"]
+	if !ok {
+		return nil, nil
+	}
+	aws := map[string]json.RawMessage{}
+	if err := json.Unmarshal(rawAWS, &aws); err != nil {
+		return nil, errors.Wrap(err, "failed to Unmarshal AWS metadata")
+	}
+
+	var filter map[string]string
+	if err := json.Unmarshal(aws["identifier"], &filter); err != nil || filter == nil {
+		// Not a single filter, so the identifier is current.
+		return nil, nil
+	}
+
+	identifier, err := json.Marshal([]map[string]string{filter})
+	if err != nil {
+		return nil, err
+	}
+	aws["identifier"] = identifier
+	if metadata["aws"], err = json.Marshal(aws); err != nil {
+		return nil, err
+	}
+	return json.Marshal(metadata)
+}
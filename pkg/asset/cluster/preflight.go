@@ -0,0 +1,258 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/util/errors"
+
+	icaws "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/libvirt"
+	"github.com/openshift/installer/pkg/validate"
+)
+
+// SkipDNSPreflight makes Generate skip the checks of the base domain before
+// creating the cluster's resources, for base domains which the installer
+// cannot resolve, such as those of private networks.
+var SkipDNSPreflight = false
+
+// SkipQuotaChecks makes Generate skip the checks of the quotas of the
+// platform's account before creating the cluster's resources.
+var SkipQuotaChecks = false
+
+// SkipPermissionsPreflight makes Generate skip the simulation of the
+// policies of the credentials before creating the cluster's resources,
+// for policies whose conditions the simulation cannot evaluate.
+var SkipPermissionsPreflight = false
+
+// SkipProxyPreflight makes Generate skip the checks of the proxy before
+// creating the cluster's resources, for proxies which only the cluster's
+// network can reach.
+var SkipProxyPreflight = false
+
+// checkDNS checks, before the cluster's resources are created, that the
+// base domain is delegated to name servers which resolve it, and on AWS
+// to those of its public hosted zone, and unless checkCollisions is false,
+// that the records of the cluster do not exist yet, because another
+// cluster with the same name owns them. The libvirt base domains are only
+// resolved by the cluster's network, so they are not checked.
+func checkDNS(config *types.InstallConfig, checkCollisions bool) error {
+	if config.Platform.Name() == libvirt.Name {
+		return nil
+	}
+
+	baseDomain := strings.TrimSuffix(config.BaseDomain, ".")
+	apiName := fmt.Sprintf("%s-api.%s", config.ObjectMeta.Name, baseDomain)
+	appsDomain := fmt.Sprintf("apps.%s.%s", config.ObjectMeta.Name, baseDomain)
+	logrus.Debugf("Checking the DNS of the base domain %s", baseDomain)
+
+	records, err := net.LookupNS(baseDomain)
+	if err != nil || len(records) == 0 {
+		return errors.Errorf("the base domain %s has no resolvable NS delegation; delegate it to the name servers of its zone, or pass --skip-dns-preflight if only the cluster's network resolves it", baseDomain)
+	}
+	delegated := make([]string, 0, len(records))
+	for _, record := range records {
+		delegated = append(delegated, normalizeHost(record.Host))
+	}
+	sort.Strings(delegated)
+
+	var errs []error
+	if config.Platform.Name() == aws.Name {
+		zoneID, err := icaws.GetPublicZone(baseDomain)
+		if err != nil {
+			return err
+		}
+
+		nameServers, err := icaws.PublicZoneNameServers(zoneID)
+		if err != nil {
+			return err
+		}
+		if !intersects(delegated, nameServers) {
+			for i := range nameServers {
+				nameServers[i] = normalizeHost(nameServers[i])
+			}
+			sort.Strings(nameServers)
+			errs = append(errs, errors.Errorf("the public hosted zone %s is not authoritative for %s: the domain is delegated to %s, not to the name servers of the zone, %s", zoneID, baseDomain, strings.Join(delegated, ", "), strings.Join(nameServers, ", ")))
+		}
+
+		if checkCollisions {
+			existing, err := icaws.ExistingPublicRecords(zoneID, []string{apiName}, []string{appsDomain})
+			if err != nil {
+				return err
+			}
+			if len(existing) > 0 {
+				errs = append(errs, errors.Errorf("the records %s already exist in the public hosted zone %s; another cluster named %s may own them", strings.Join(existing, ", "), zoneID, config.ObjectMeta.Name))
+			}
+		}
+	} else if checkCollisions {
+		// The ingress records are wildcards, so any name in the apps
+		// subdomain resolves if they exist.
+		for _, name := range []string{apiName, fmt.Sprintf("console-openshift-console.%s", appsDomain)} {
+			if addrs, err := net.LookupHost(name); err == nil && len(addrs) > 0 {
+				errs = append(errs, errors.Errorf("%s already resolves to %s; another cluster named %s may own it", name, strings.Join(addrs, ", "), config.ObjectMeta.Name))
+			}
+		}
+	}
+
+	if err := k8serrors.NewAggregate(errs); err != nil {
+		return errors.Errorf("the DNS preflight checks failed (pass --skip-dns-preflight to skip them): %v", err)
+	}
+	return nil
+}
+
+func normalizeHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// intersects returns true if the normalized hosts share a host with the
+// others.
+func intersects(normalized []string, others []string) bool {
+	for _, other := range others {
+		for _, host := range normalized {
+			if host == normalizeHost(other) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkPermissions checks, before the cluster's resources are created,
+// that the policies of the AWS credentials allow the actions which the
+// installer and the operators perform, so that missing permissions are
+// reported by name rather than as an AccessDenied error of Terraform or
+// of a degraded operator. If the policies cannot be simulated, it only
+// warns.
+func checkPermissions(config *types.InstallConfig) error {
+	if config.Platform.Name() != aws.Name {
+		return nil
+	}
+
+	logrus.Debug("Simulating the policies of the AWS credentials")
+	missing, err := icaws.MissingPermissions()
+	if errors.Cause(err) == icaws.ErrSimulationUnavailable {
+		logrus.Warnf("Skipping the check of the AWS permissions: %v", err)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var groups []string
+	for _, group := range icaws.PermissionGroups {
+		if actions, ok := missing[group.Name]; ok {
+			groups = append(groups, fmt.Sprintf("%s needs %s", group.Name, strings.Join(actions, ", ")))
+		}
+	}
+	return errors.Errorf("the AWS credentials lack permissions (pass --skip-permissions-preflight to skip this check): %s", strings.Join(groups, "; "))
+}
+
+// checkProxy checks, before the cluster's resources are created, that the
+// registries which the machines pull the release image from, which are the
+// mirrors of its repository if it has any, can be reached through the
+// proxy, and warns about the common misconfigurations of the proxy. The
+// cluster's own hosts and networks are always added to noProxy, so only
+// the user's entries are checked.
+func checkProxy(config *types.InstallConfig, releaseImage string) error {
+	proxy := config.Proxy
+	if proxy == nil {
+		return nil
+	}
+
+	var noProxy []string
+	for _, entry := range strings.Split(proxy.NoProxy, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			noProxy = append(noProxy, entry)
+		}
+	}
+	for _, entry := range noProxy {
+		if entry == "*" {
+			logrus.Warn("The noProxy of the proxy is \"*\", so the machines never use the proxy")
+			return nil
+		}
+	}
+	if proxy.HTTPSProxy == "" {
+		logrus.Warn("The proxy has no httpsProxy, so the machines pull the release image, and reach any other HTTPS endpoint, without the proxy")
+		return nil
+	}
+
+	images := []string{releaseImage}
+	repository := releaseImage
+	if i := strings.Index(repository, "@"); i >= 0 {
+		repository = repository[:i]
+	} else if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	for _, source := range config.ImageContentSources {
+		if source.Source == repository {
+			images = source.Mirrors
+		}
+	}
+
+	var errs []error
+	checked := map[string]bool{}
+	for _, image := range images {
+		host := strings.SplitN(image, "/", 2)[0]
+		if checked[host] {
+			continue
+		}
+		checked[host] = true
+		if noProxyMatches(noProxy, host) {
+			logrus.Debugf("Skipping the check of the registry %s, which the machines reach without the proxy", host)
+			continue
+		}
+
+		logrus.Debugf("Checking that the registry %s can be reached through the proxy", host)
+		ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+		err := validate.RegistryReachable(ctx, proxy.HTTPSProxy, image)
+		cancel()
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, host))
+		}
+	}
+	if err := k8serrors.NewAggregate(errs); err != nil {
+		return errors.Errorf("the registries of the release image cannot be reached through the proxy (pass --skip-proxy-preflight to skip this check): %v", err)
+	}
+	return nil
+}
+
+// noProxyMatches returns true if an entry of noProxy matches the host, an
+// optional port aside, as the proxy environment variables of the machines
+// do: "*", the host's IP address or a CIDR which contains it, or the
+// host's domain or a parent domain of it, with or without a leading dot.
+func noProxyMatches(noProxy []string, host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = normalizeHost(host)
+	ip := net.ParseIP(host)
+	for _, entry := range noProxy {
+		if entry == "*" {
+			return true
+		}
+		if ip != nil {
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && cidr.Contains(ip) {
+				return true
+			}
+			if entryIP := net.ParseIP(entry); entryIP != nil && entryIP.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		domain := normalizeHost(strings.TrimPrefix(entry, "."))
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeHost(t *testing.T) {
+	assert.Equal(t, "ns-1.awsdns-01.org", normalizeHost("NS-1.awsdns-01.org."))
+	assert.Equal(t, "ns-1.awsdns-01.org", normalizeHost("ns-1.awsdns-01.org"))
+}
+
+func TestIntersects(t *testing.T) {
+	delegated := []string{"ns-1.awsdns-01.org", "ns-2.awsdns-02.com"}
+
+	cases := []struct {
+		name     string
+		others   []string
+		expected bool
+	}{
+		{
+			name: "no name servers",
+		},
+		{
+			name:     "delegated",
+			others:   []string{"ns-3.awsdns-03.net", "NS-2.awsdns-02.com."},
+			expected: true,
+		},
+		{
+			name:   "delegated elsewhere",
+			others: []string{"ns-3.awsdns-03.net", "ns-4.awsdns-04.co.uk"},
+		},
+		{
+			name:   "parent domain",
+			others: []string{"awsdns-01.org"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, intersects(delegated, tc.others))
+		})
+	}
+}
+
+func TestNoProxyMatches(t *testing.T) {
+	noProxy := []string{".example.com", "internal.test", "10.0.0.0/16", "192.0.2.10"}
+
+	cases := []struct {
+		host     string
+		expected bool
+	}{
+		{host: "registry.example.com", expected: true},
+		{host: "example.com", expected: true},
+		{host: "Registry.Example.Com.:5000", expected: true},
+		{host: "mirror.internal.test", expected: true},
+		{host: "notexample.com"},
+		{host: "quay.io"},
+		{host: "10.0.1.2:5000", expected: true},
+		{host: "10.1.0.2"},
+		{host: "192.0.2.10", expected: true},
+		{host: "192.0.2.11"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.host, func(t *testing.T) {
+			assert.Equal(t, tc.expected, noProxyMatches(noProxy, tc.host))
+		})
+	}
+
+	assert.True(t, noProxyMatches([]string{"*"}, "quay.io"))
+}
@@ -0,0 +1,139 @@
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+const (
+	// failedApplyFileName is the name of the file Cluster writes, next to
+	// the Terraform state, when creating the cluster's resources fails.
+	// It holds the error.
+	failedApplyFileName = ".openshift_install_apply_failed"
+)
+
+// FailedApply is the Terraform state left in the asset directory by a
+// Cluster which failed to create all its resources. Cluster resumes from
+// it, so that a transient failure doesn't require destroying the
+// resources which were created.
+type FailedApply struct {
+	// State is the Terraform state of the failed attempt. It is not kept
+	// in the state file, so a failed attempt is only resumed while its
+	// Terraform state is in the asset directory.
+	State []byte `json:"-"`
+
+	// StageStates maps the names of the state files of the stages after
+	// the first, which the failed attempt reached, to their states.
+	StageStates map[string][]byte `json:"-"`
+
+	// Failure is the error with which the attempt failed.
+	Failure string `json:"-"`
+
+	files []*asset.File
+}
+
+var _ asset.WritableAsset = (*FailedApply)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (a *FailedApply) Name() string {
+	return "Failed Cluster Creation"
+}
+
+// Dependencies returns no dependencies.
+func (a *FailedApply) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate does nothing, because there is no failed attempt unless one
+// is loaded from disk.
+func (a *FailedApply) Generate(asset.Parents) error {
+	return nil
+}
+
+// Files returns the files of the failed attempt, which are replaced by
+// those of the next attempt.
+func (a *FailedApply) Files() []*asset.File {
+	return a.files
+}
+
+// Load loads the state of a failed attempt from the asset directory.
+func (a *FailedApply) Load(f asset.FileFetcher) (found bool, err error) {
+	failure, err := f.FetchByName(failedApplyFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	a.Failure = string(failure.Data)
+	a.files = []*asset.File{failure}
+
+	// Without its Terraform state, the failed attempt cannot be resumed,
+	// but the error is still loaded so that it is replaced.
+	state, err := f.FetchByName(terraform.StateFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	a.State, err = asset.DecryptSensitive(state.Data)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to decrypt %s", state.Filename)
+	}
+	a.files = append(a.files, state)
+
+	for _, filename := range terraform.StateFileNames()[1:] {
+		state, err := f.FetchByName(filename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return false, err
+		}
+		if a.StageStates == nil {
+			a.StageStates = map[string][]byte{}
+		}
+		a.StageStates[filename], err = asset.DecryptSensitive(state.Data)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to decrypt %s", filename)
+		}
+		a.files = append(a.files, state)
+	}
+	return true, nil
+}
+
+// States maps the names of the state files of the failed attempt to their
+// states.
+func (a *FailedApply) States() map[string][]byte {
+	states := map[string][]byte{terraform.StateFileName: a.State}
+	for filename, data := range a.StageStates {
+		states[filename] = data
+	}
+	return states
+}
+
+// RemoveFailedApply removes the files of a failed attempt from directory,
+// once its resources have been destroyed, so that it is not resumed.
+func RemoveFailedApply(directory string) error {
+	if _, err := os.Stat(filepath.Join(directory, failedApplyFileName)); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, filename := range append(terraform.StateFileNames(), failedApplyFileName) {
+		if err := os.Remove(filepath.Join(directory, filename)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package cluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+func TestFailedApplyLoad(t *testing.T) {
+	if !assert.True(t, len(terraform.StateFileNames()) > 1, "no stage has its own state file") {
+		return
+	}
+	stageStateFileName := terraform.StateFileNames()[1]
+	failure := &asset.File{Filename: failedApplyFileName, Data: []byte("failed to apply")}
+	state := &asset.File{Filename: terraform.StateFileName, Data: []byte(`{"version": 3}`)}
+	stageState := &asset.File{Filename: stageStateFileName, Data: []byte(`{"version": 3, "serial": 2}`)}
+
+	cases := []struct {
+		name           string
+		files          []*asset.File
+		expectedFound  bool
+		expectedStates map[string][]byte
+	}{
+		{
+			name: "no failed attempt",
+		},
+		{
+			name:           "without state",
+			files:          []*asset.File{failure},
+			expectedFound:  true,
+			expectedStates: map[string][]byte{terraform.StateFileName: nil},
+		},
+		{
+			name:           "first stage",
+			files:          []*asset.File{failure, state},
+			expectedFound:  true,
+			expectedStates: map[string][]byte{terraform.StateFileName: state.Data},
+		},
+		{
+			name:          "later stage",
+			files:         []*asset.File{failure, state, stageState},
+			expectedFound: true,
+			expectedStates: map[string][]byte{
+				terraform.StateFileName: state.Data,
+				stageStateFileName:      stageState.Data,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			failedApply := &FailedApply{}
+			found, err := failedApply.Load(asset.NewMemoryFileFetcher(tc.files...))
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.expectedFound, found)
+			if !found {
+				return
+			}
+			assert.Equal(t, "failed to apply", failedApply.Failure)
+			assert.Equal(t, tc.expectedStates, failedApply.States())
+			assert.Len(t, failedApply.Files(), len(tc.files))
+		})
+	}
+}
+
+func TestRemoveFailedApply(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestRemoveFailedApply")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Without a failed attempt, the state of a created cluster is kept.
+	statePath := filepath.Join(dir, terraform.StateFileName)
+	if err := ioutil.WriteFile(statePath, []byte(`{"version": 3}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if assert.NoError(t, RemoveFailedApply(dir)) {
+		assert.FileExists(t, statePath)
+	}
+
+	for _, filename := range append(terraform.StateFileNames()[1:], failedApplyFileName) {
+		if err := ioutil.WriteFile(filepath.Join(dir, filename), []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if assert.NoError(t, RemoveFailedApply(dir)) {
+		files, err := ioutil.ReadDir(dir)
+		if assert.NoError(t, err) {
+			assert.Empty(t, files)
+		}
+	}
+}
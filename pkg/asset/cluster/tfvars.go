@@ -19,7 +19,9 @@ const (
 )
 
 // TerraformVariables depends on InstallConfig and
-// Ignition to generate the terrafor.tfvars.
+// Ignition to generate the terrafor.tfvars. The file holds the bootstrap
+// Ignition config, so it is encrypted like the Terraform state when the
+// state file is; Data returns its decrypted contents.
 type TerraformVariables struct {
 	File *asset.File
 }
@@ -31,6 +33,11 @@ func (t *TerraformVariables) Name() string {
 	return tfvarsAssetName
 }
 
+// Sensitive returns true, because the variables contain the bootstrap Ignition config.
+func (t *TerraformVariables) Sensitive() bool {
+	return true
+}
+
 // Dependencies returns the dependency of the TerraformVariable
 func (t *TerraformVariables) Dependencies() []asset.Asset {
 	return []asset.Asset{
@@ -39,6 +46,7 @@ func (t *TerraformVariables) Dependencies() []asset.Asset {
 		new(rhcos.Image),
 		&bootstrap.Bootstrap{},
 		&machine.Master{},
+		&TerraformVariablesOverride{},
 	}
 }
 
@@ -49,7 +57,8 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 	bootstrap := &bootstrap.Bootstrap{}
 	master := &machine.Master{}
 	rhcosImage := new(rhcos.Image)
-	parents.Get(clusterID, installConfig, bootstrap, master, rhcosImage)
+	override := &TerraformVariablesOverride{}
+	parents.Get(clusterID, installConfig, bootstrap, master, rhcosImage, override)
 
 	bootstrapIgn := string(bootstrap.Files()[0].Data)
 	masterIgn := string(master.Files()[0].Data)
@@ -58,6 +67,14 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to get Tfvars")
 	}
+	data, err = override.apply(data)
+	if err != nil {
+		return err
+	}
+	data, err = asset.EncryptSensitive(data)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encrypt %s", TfVarsFileName)
+	}
 	t.File = &asset.File{
 		Filename: TfVarsFileName,
 		Data:     data,
@@ -84,6 +101,17 @@ func (t *TerraformVariables) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, err
 	}
 
+	if _, err := asset.DecryptSensitive(file.Data); err != nil {
+		return false, errors.Wrapf(err, "failed to decrypt %s", TfVarsFileName)
+	}
 	t.File = file
 	return true, nil
 }
+
+// Data returns the decrypted contents of terraform.tfvars.
+func (t *TerraformVariables) Data() ([]byte, error) {
+	if t.File == nil {
+		return nil, errors.New("no Terraform variables")
+	}
+	return asset.DecryptSensitive(t.File.Data)
+}
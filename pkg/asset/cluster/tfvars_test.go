@@ -0,0 +1,47 @@
+package cluster
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestTerraformVariablesEncryption(t *testing.T) {
+	defer os.Unsetenv("OPENSHIFT_INSTALL_STATE_PASSPHRASE")
+	os.Setenv("OPENSHIFT_INSTALL_STATE_PASSPHRASE", "passphrase")
+
+	data := []byte(`{"ignition_bootstrap": "secret"}`)
+	encrypted, err := asset.EncryptSensitive(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tfvars := &TerraformVariables{}
+	found, err := tfvars.Load(asset.NewMemoryFileFetcher(&asset.File{Filename: TfVarsFileName, Data: encrypted}))
+	if !assert.NoError(t, err) || !assert.True(t, found) {
+		return
+	}
+	assert.NotContains(t, string(tfvars.Files()[0].Data), "ignition_bootstrap")
+	decrypted, err := tfvars.Data()
+	if assert.NoError(t, err) {
+		assert.Equal(t, data, decrypted)
+	}
+
+	os.Setenv("OPENSHIFT_INSTALL_STATE_PASSPHRASE", "wrong")
+	_, err = (&TerraformVariables{}).Load(asset.NewMemoryFileFetcher(&asset.File{Filename: TfVarsFileName, Data: encrypted}))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "failed to decrypt terraform.tfvars")
+	}
+
+	os.Unsetenv("OPENSHIFT_INSTALL_STATE_PASSPHRASE")
+	tfvars = &TerraformVariables{}
+	_, err = tfvars.Load(asset.NewMemoryFileFetcher(&asset.File{Filename: TfVarsFileName, Data: data}))
+	if assert.NoError(t, err) {
+		decrypted, err = tfvars.Data()
+		assert.NoError(t, err)
+		assert.Equal(t, data, decrypted)
+	}
+}
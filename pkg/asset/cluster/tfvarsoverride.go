@@ -0,0 +1,81 @@
+package cluster
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/tfvars"
+)
+
+const (
+	// TfVarsOverrideFileName is the name of the file, in the asset
+	// directory, whose variables override the generated Terraform
+	// variables.
+	TfVarsOverrideFileName = "terraform.tfvars.override.json"
+)
+
+// TerraformVariablesOverride is the Terraform variables which the user
+// provides to override the generated ones, as an escape hatch for provider
+// settings which the install config does not model yet. Only the variables
+// which tfvars.Overridable allows are applied. It generates no file; it is
+// only provided by the user.
+type TerraformVariablesOverride struct {
+	File *asset.File
+}
+
+var _ asset.WritableAsset = (*TerraformVariablesOverride)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (t *TerraformVariablesOverride) Name() string {
+	return "Terraform Variables Override"
+}
+
+// Dependencies returns no dependencies.
+func (t *TerraformVariablesOverride) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate generates no overrides; they are only provided by the user.
+func (t *TerraformVariablesOverride) Generate(asset.Parents) error {
+	t.File = nil
+	return nil
+}
+
+// Files returns the files of the asset.
+func (t *TerraformVariablesOverride) Files() []*asset.File {
+	if t.File != nil {
+		return []*asset.File{t.File}
+	}
+	return []*asset.File{}
+}
+
+// Load reads the overrides from disk, after checking that they are a JSON
+// object.
+func (t *TerraformVariablesOverride) Load(f asset.FileFetcher) (found bool, err error) {
+	file, err := f.FetchByName(TfVarsOverrideFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var overrides map[string]json.RawMessage
+	if err := json.Unmarshal(file.Data, &overrides); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal %s", TfVarsOverrideFileName)
+	}
+	t.File = file
+	return true, nil
+}
+
+// apply returns the Terraform variables with the overrides merged over
+// them, with tfvars.Override.
+func (t *TerraformVariablesOverride) apply(data []byte) ([]byte, error) {
+	if t.File == nil {
+		return data, nil
+	}
+	return tfvars.Override(data, t.File.Data, TfVarsOverrideFileName)
+}
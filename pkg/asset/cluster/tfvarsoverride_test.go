@@ -0,0 +1,62 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestTerraformVariablesOverride(t *testing.T) {
+	cases := []struct {
+		name          string
+		files         []*asset.File
+		expectedFound bool
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "no overrides",
+			expected: `{"cluster_id":"test-id"}`,
+		},
+		{
+			name:          "overrides",
+			files:         []*asset.File{{Filename: TfVarsOverrideFileName, Data: []byte(`{"cluster_id":"other-id","openstack_extra_tags":{"team":"test"}}`)}},
+			expectedFound: true,
+			expected: `{
+  "cluster_id": "test-id",
+  "openstack_extra_tags": {
+    "team": "test"
+  }
+}`,
+		},
+		{
+			name:          "not an object",
+			files:         []*asset.File{{Filename: TfVarsOverrideFileName, Data: []byte(`["openstack_extra_tags"]`)}},
+			expectedError: "failed to unmarshal terraform.tfvars.override.json",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			override := &TerraformVariablesOverride{}
+			found, err := override.Load(asset.NewMemoryFileFetcher(tc.files...))
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.expectedFound, found)
+			assert.Len(t, override.Files(), len(tc.files))
+
+			data, err := override.apply([]byte(`{"cluster_id":"test-id"}`))
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, string(data))
+			}
+		})
+	}
+}
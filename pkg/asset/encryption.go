@@ -0,0 +1,136 @@
+package asset
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// statePassphraseEnv is the environment variable holding the
+	// passphrase with which the state file is encrypted.
+	statePassphraseEnv = "OPENSHIFT_INSTALL_STATE_PASSPHRASE"
+
+	stateEncryption = "aes-256-gcm+pbkdf2-hmac-sha256"
+	kdfIterations   = 100000
+	saltSize        = 16
+	keySize         = 32
+
+	// minKDFIterations and maxKDFIterations bound the iterations read from
+	// an encrypted file, so that a tampered file can neither weaken the key
+	// derivation nor make it run for hours.
+	minKDFIterations = 10000
+	maxKDFIterations = 10000000
+)
+
+// encryptedState is the state file when it is encrypted. The state file
+// holds the private keys, pull secret and kubeadmin password of the
+// cluster, so it is encrypted when the asset directory is kept on shared
+// storage.
+type encryptedState struct {
+	Encryption string `json:"encryption"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// statePassphrase returns the passphrase with which the state file is
+// encrypted, or an empty string if it is not encrypted.
+func statePassphrase() string {
+	return os.Getenv(statePassphraseEnv)
+}
+
+// StateEncrypted returns whether the state file, and the sensitive files
+// written next to it, are encrypted with a passphrase.
+func StateEncrypted() bool {
+	return statePassphrase() != ""
+}
+
+// EncryptSensitive encrypts the contents of a sensitive file which is
+// written next to the state file, such as the Terraform state, with the
+// passphrase of the state file, so that it is encrypted at rest when the
+// state file is. Without a passphrase, it returns the data unchanged.
+func EncryptSensitive(data []byte) ([]byte, error) {
+	passphrase := statePassphrase()
+	if passphrase == "" {
+		return data, nil
+	}
+	return encryptState(data, passphrase)
+}
+
+// DecryptSensitive returns the decrypted contents of a file which was
+// encrypted by EncryptSensitive, or the data unchanged if it was not
+// encrypted.
+func DecryptSensitive(data []byte) ([]byte, error) {
+	decrypted, _, err := decryptState(data, statePassphrase())
+	return decrypted, err
+}
+
+// encryptState encrypts the contents of the state file with the passphrase.
+func encryptState(data []byte, passphrase string) ([]byte, error) {
+	state := &encryptedState{
+		Encryption: stateEncryption,
+		Iterations: kdfIterations,
+		Salt:       make([]byte, saltSize),
+	}
+	if _, err := rand.Read(state.Salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+
+	gcm, err := stateCipher(passphrase, state.Salt, state.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	state.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(state.Nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	state.Data = gcm.Seal(nil, state.Nonce, data, nil)
+	return json.MarshalIndent(state, "", "    ")
+}
+
+// decryptState returns the decrypted contents of the state file, and
+// whether it was encrypted at all.
+func decryptState(data []byte, passphrase string) ([]byte, bool, error) {
+	state := &encryptedState{}
+	if err := json.Unmarshal(data, state); err != nil || state.Encryption == "" {
+		return data, false, nil
+	}
+	if state.Encryption != stateEncryption {
+		return nil, true, errors.Errorf("unsupported encryption %q", state.Encryption)
+	}
+	if passphrase == "" {
+		return nil, true, errors.Errorf("the state file is encrypted; set %s to its passphrase", statePassphraseEnv)
+	}
+
+	gcm, err := stateCipher(passphrase, state.Salt, state.Iterations)
+	if err != nil {
+		return nil, true, err
+	}
+	if len(state.Nonce) != gcm.NonceSize() {
+		return nil, true, errors.New("invalid nonce")
+	}
+	decrypted, err := gcm.Open(nil, state.Nonce, state.Data, nil)
+	if err != nil {
+		return nil, true, errors.Errorf("failed to decrypt the state file; check %s", statePassphraseEnv)
+	}
+	return decrypted, true, nil
+}
+
+func stateCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations < minKDFIterations || iterations > maxKDFIterations {
+		return nil, errors.Errorf("unsupported number of key derivation iterations %d; must be between %d and %d", iterations, minKDFIterations, maxKDFIterations)
+	}
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, iterations, keySize, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
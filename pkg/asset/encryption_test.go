@@ -0,0 +1,143 @@
+package asset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func TestPBKDF2(t *testing.T) {
+	// The PBKDF2-HMAC-SHA256 test vectors of RFC 7914.
+	cases := []struct {
+		password   string
+		salt       string
+		iterations int
+		expected   string
+	}{
+		{
+			password:   "passwd",
+			salt:       "salt",
+			iterations: 1,
+			expected:   "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783",
+		},
+		{
+			password:   "Password",
+			salt:       "NaCl",
+			iterations: 80000,
+			expected:   "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.password, func(t *testing.T) {
+			key := pbkdf2.Key([]byte(tc.password), []byte(tc.salt), tc.iterations, 64, sha256.New)
+			assert.Equal(t, tc.expected, hex.EncodeToString(key))
+		})
+	}
+}
+
+func TestStateEncryption(t *testing.T) {
+	data := []byte(`{"*tls.RootCA": {}}`)
+
+	encrypted, err := encryptState(data, "passphrase")
+	assert.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "RootCA")
+
+	decrypted, wasEncrypted, err := decryptState(encrypted, "passphrase")
+	assert.NoError(t, err)
+	assert.True(t, wasEncrypted)
+	assert.Equal(t, data, decrypted)
+
+	_, _, err = decryptState(encrypted, "wrong")
+	assert.EqualError(t, err, "failed to decrypt the state file; check OPENSHIFT_INSTALL_STATE_PASSPHRASE")
+
+	_, _, err = decryptState(encrypted, "")
+	assert.EqualError(t, err, "the state file is encrypted; set OPENSHIFT_INSTALL_STATE_PASSPHRASE to its passphrase")
+
+	plain, wasEncrypted, err := decryptState(data, "passphrase")
+	assert.NoError(t, err)
+	assert.False(t, wasEncrypted)
+	assert.Equal(t, data, plain)
+}
+
+func TestStateEncryptionIterations(t *testing.T) {
+	encrypted, err := encryptState([]byte(`{}`), "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, iterations := range []int{0, minKDFIterations - 1, maxKDFIterations + 1, 1 << 40} {
+		state := &encryptedState{}
+		if err := json.Unmarshal(encrypted, state); err != nil {
+			t.Fatal(err)
+		}
+		state.Iterations = iterations
+		data, err := json.Marshal(state)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, wasEncrypted, err := decryptState(data, "passphrase")
+		assert.True(t, wasEncrypted)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "unsupported number of key derivation iterations")
+		}
+	}
+}
+
+func TestStoreEncryptedState(t *testing.T) {
+	clearAssetBehaviors()
+	defer os.Unsetenv(statePassphraseEnv)
+	os.Setenv(statePassphraseEnv, "passphrase")
+
+	dir, err := ioutil.TempDir("", "TestStoreEncryptedState")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewStore(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Fetch(&testStoreAssetA{}))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, stateFileName))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "testStoreAssetA")
+
+	store, err = NewStore(dir)
+	assert.NoError(t, err)
+	loaded, err := store.Load(&testStoreAssetA{})
+	assert.NoError(t, err)
+	assert.IsType(t, &testStoreAssetA{}, loaded)
+
+	os.Unsetenv(statePassphraseEnv)
+	_, err = NewStore(dir)
+	assert.Error(t, err)
+}
+
+func TestSensitiveEncryption(t *testing.T) {
+	defer os.Unsetenv(statePassphraseEnv)
+	data := []byte(`{"version": 3}`)
+
+	plain, err := EncryptSensitive(data)
+	assert.NoError(t, err)
+	assert.Equal(t, data, plain)
+
+	os.Setenv(statePassphraseEnv, "passphrase")
+	encrypted, err := EncryptSensitive(data)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "version")
+
+	decrypted, err := DecryptSensitive(encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+
+	decrypted, err = DecryptSensitive(data)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+}
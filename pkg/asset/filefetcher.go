@@ -2,6 +2,7 @@ package asset
 
 import (
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"sort"
 )
@@ -20,6 +21,12 @@ type fileFetcher struct {
 	directory string
 }
 
+// NewFileFetcher returns a FileFetcher which fetches the files of the
+// directory, for assets which read files outside of the asset directory.
+func NewFileFetcher(directory string) FileFetcher {
+	return &fileFetcher{directory: directory}
+}
+
 // FetchByName returns the file with the given name.
 func (f *fileFetcher) FetchByName(name string) (*File, error) {
 	data, err := ioutil.ReadFile(filepath.Join(f.directory, name))
@@ -57,3 +64,67 @@ func (f *fileFetcher) FetchByPattern(pattern string) (files []*File, err error)
 	sort.Slice(files, func(i, j int) bool { return files[i].Filename < files[j].Filename })
 	return files, nil
 }
+
+// NewMemoryFileFetcher returns a FileFetcher which fetches the given files
+// from memory instead of from an asset directory.
+func NewMemoryFileFetcher(files ...*File) FileFetcher {
+	f := &memoryFileFetcher{files: make(map[string]*File, len(files))}
+	for _, file := range files {
+		f.files[filepath.Clean(file.Filename)] = file
+	}
+	return f
+}
+
+type memoryFileFetcher struct {
+	files map[string]*File
+}
+
+// FetchByName returns the file with the given name.
+func (f *memoryFileFetcher) FetchByName(name string) (*File, error) {
+	file, ok := f.files[filepath.Clean(name)]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return file, nil
+}
+
+// FetchByPattern returns the files whose name match the given glob.
+func (f *memoryFileFetcher) FetchByPattern(pattern string) ([]*File, error) {
+	var files []*File
+	for name, file := range f.files {
+		match, err := filepath.Match(pattern, name)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			files = append(files, file)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Filename < files[j].Filename })
+	return files, nil
+}
+
+// recordingFileFetcher is a FileFetcher which records the files it fetches.
+type recordingFileFetcher struct {
+	FileFetcher
+	fetched []*File
+}
+
+// FetchByName returns the file with the given name.
+func (f *recordingFileFetcher) FetchByName(name string) (*File, error) {
+	file, err := f.FileFetcher.FetchByName(name)
+	if err == nil {
+		f.fetched = append(f.fetched, file)
+	}
+	return file, err
+}
+
+// FetchByPattern returns the files whose name match the given glob.
+func (f *recordingFileFetcher) FetchByPattern(pattern string) ([]*File, error) {
+	files, err := f.FileFetcher.FetchByPattern(pattern)
+	if err == nil {
+		f.fetched = append(f.fetched, files...)
+	}
+	return files, err
+}
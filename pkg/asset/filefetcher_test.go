@@ -172,3 +172,26 @@ func TestFetchByPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestMemoryFileFetcher(t *testing.T) {
+	f := NewMemoryFileFetcher(
+		&File{Filename: "install-config.yaml", Data: []byte("some data 0")},
+		&File{Filename: "manifests/0", Data: []byte("some data 1")},
+		&File{Filename: "manifests/some", Data: []byte("some data 2")},
+		&File{Filename: "amanifests/a", Data: []byte("some data 3")},
+	)
+
+	file, err := f.FetchByName("install-config.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, &File{Filename: "install-config.yaml", Data: []byte("some data 0")}, file)
+
+	_, err = f.FetchByName("master.ign")
+	assert.True(t, os.IsNotExist(err), "missing files should not exist")
+
+	files, err := f.FetchByPattern(filepath.Join("manifests", "*"))
+	assert.NoError(t, err)
+	assert.Equal(t, []*File{
+		{Filename: "manifests/0", Data: []byte("some data 1")},
+		{Filename: "manifests/some", Data: []byte("some data 2")},
+	}, files)
+}
@@ -2,13 +2,14 @@ package bootstrap
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -20,11 +21,13 @@ import (
 	"github.com/openshift/installer/data"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/kubeconfig"
 	"github.com/openshift/installer/pkg/asset/manifests"
 	"github.com/openshift/installer/pkg/asset/tls"
 	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/version"
 )
 
 const (
@@ -35,18 +38,16 @@ const (
 	ignitionUser         = "core"
 )
 
-var (
-	defaultReleaseImage = "registry.svc.ci.openshift.org/openshift/origin-release:v4.0"
-)
-
 // bootstrapTemplateData is the data to use to replace values in bootstrap
 // template files.
 type bootstrapTemplateData struct {
-	EtcdCertSignerImage string
-	EtcdCluster         string
-	EtcdctlImage        string
-	PullSecret          string
-	ReleaseImage        string
+	BootstrapInPlace        *types.BootstrapInPlace
+	EtcdCertSignerImage     string
+	EtcdCluster             string
+	EtcdctlImage            string
+	MachineConfigServerPort int
+	PullSecret              string
+	ReleaseImage            string
 }
 
 // Bootstrap is an asset that generates the ignition config for bootstrap nodes.
@@ -78,6 +79,8 @@ func (a *Bootstrap) Dependencies() []asset.Asset {
 		&kubeconfig.Kubelet{},
 		&manifests.Manifests{},
 		&manifests.Openshift{},
+		&machine.MachineConfigs{},
+		&Customizations{},
 	}
 }
 
@@ -105,14 +108,46 @@ func (a *Bootstrap) Generate(dependencies asset.Parents) error {
 	if err != nil {
 		return err
 	}
+	if installConfig.Config.BootstrapInPlace != nil {
+		if err := a.addBootstrapInPlace(templateData); err != nil {
+			return errors.Wrap(err, "failed to add the bootstrap-in-place files")
+		}
+	}
 	a.addParentFiles(dependencies)
+	if len(installConfig.Config.NTPServers) > 0 {
+		a.Config.Storage.Files = append(a.Config.Storage.Files, ignition.ChronyFile(installConfig.Config.NTPServers))
+	}
+	if len(installConfig.Config.ImageContentSources) > 0 {
+		a.Config.Storage.Files = append(a.Config.Storage.Files, ignition.RegistriesFile(installConfig.Config.ImageContentSources))
+	}
+	if installConfig.Config.Proxy != nil {
+		dropin := ignition.ProxyDropin(installConfig.Config)
+		for _, unit := range []string{"bootkube.service", "crio.service", "kubelet.service"} {
+			a.Config.Systemd.Units = ignition.MergeDropin(a.Config.Systemd.Units, unit, dropin)
+		}
+	}
+
+	// The bootstrap machine acts as a temporary control plane, so the
+	// MachineConfigs of the masters apply to it too, except for their
+	// kernel arguments, which need a reboot.
+	machineConfigs := &machine.MachineConfigs{}
+	dependencies.Get(machineConfigs)
+	if err := machineConfigs.Merge(a.Config, "master"); err != nil {
+		return errors.Wrap(err, "failed to merge MachineConfigs")
+	}
+
+	customizations := &Customizations{}
+	dependencies.Get(customizations)
+	if err := customizations.Merge(a.Config); err != nil {
+		return errors.Wrap(err, "failed to merge the bootstrap customizations")
+	}
 
 	a.Config.Passwd.Users = append(
 		a.Config.Passwd.Users,
 		igntypes.PasswdUser{Name: "core", SSHAuthorizedKeys: []igntypes.SSHAuthorizedKey{igntypes.SSHAuthorizedKey(installConfig.Config.SSHKey)}},
 	)
 
-	data, err := json.Marshal(a.Config)
+	data, err := ignition.Marshal(a.Config)
 	if err != nil {
 		return errors.Wrap(err, "failed to Marshal Ignition config")
 	}
@@ -129,6 +164,11 @@ func (a *Bootstrap) Name() string {
 	return "Bootstrap Ignition Config"
 }
 
+// Sensitive returns true, because the bootstrap Ignition config contains private keys.
+func (a *Bootstrap) Sensitive() bool {
+	return true
+}
+
 // Files returns the files generated by the asset.
 func (a *Bootstrap) Files() []*asset.File {
 	if a.File != nil {
@@ -144,18 +184,22 @@ func (a *Bootstrap) getTemplateData(installConfig *types.InstallConfig) (*bootst
 		etcdEndpoints[i] = fmt.Sprintf("https://%s-etcd-%d.%s:2379", installConfig.ObjectMeta.Name, i, installConfig.BaseDomain)
 	}
 
-	releaseImage := defaultReleaseImage
+	releaseImage := version.DefaultReleaseImage
 	if ri, ok := os.LookupEnv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE"); ok && ri != "" {
 		logrus.Warn("Found override for ReleaseImage. Please be warned, this is not advised")
 		releaseImage = ri
 	}
 
+	_, mcsPort := installConfig.MachineConfigServerEndpoint()
+
 	return &bootstrapTemplateData{
-		EtcdCertSignerImage: etcdCertSignerImage,
-		EtcdctlImage:        etcdctlImage,
-		PullSecret:          installConfig.PullSecret,
-		ReleaseImage:        releaseImage,
-		EtcdCluster:         strings.Join(etcdEndpoints, ","),
+		BootstrapInPlace:        installConfig.BootstrapInPlace,
+		EtcdCertSignerImage:     etcdCertSignerImage,
+		EtcdctlImage:            etcdctlImage,
+		MachineConfigServerPort: mcsPort,
+		PullSecret:              installConfig.PullSecret,
+		ReleaseImage:            releaseImage,
+		EtcdCluster:             strings.Join(etcdEndpoints, ","),
 	}, nil
 }
 
@@ -172,7 +216,7 @@ func (a *Bootstrap) addStorageFiles(base string, uri string, templateData *boots
 	}
 
 	if info.IsDir() {
-		children, err := file.Readdir(0)
+		children, err := readdir(file)
 		if err != nil {
 			return err
 		}
@@ -221,6 +265,7 @@ func (a *Bootstrap) addStorageFiles(base string, uri string, templateData *boots
 
 func (a *Bootstrap) addSystemdUnits(uri string, templateData *bootstrapTemplateData) (err error) {
 	enabled := map[string]struct{}{
+		"bootstrap-in-place.service":      {},
 		"progress.service":                {},
 		"kubelet.service":                 {},
 		"systemd-journal-gatewayd.socket": {},
@@ -232,7 +277,7 @@ func (a *Bootstrap) addSystemdUnits(uri string, templateData *bootstrapTemplateD
 	}
 	defer directory.Close()
 
-	children, err := directory.Readdir(0)
+	children, err := readdir(directory)
 	if err != nil {
 		return err
 	}
@@ -256,7 +301,7 @@ func (a *Bootstrap) addSystemdUnits(uri string, templateData *bootstrapTemplateD
 				continue
 			}
 
-			children, err := file.Readdir(0)
+			children, err := readdir(file)
 			if err != nil {
 				return err
 			}
@@ -312,6 +357,27 @@ func (a *Bootstrap) addSystemdUnits(uri string, templateData *bootstrapTemplateD
 	return nil
 }
 
+// addBootstrapInPlace adds the files and units which install the
+// bootstrapped single-node cluster to its disk. The bootstrap control plane
+// stops as soon as cluster-bootstrap created the manifests, so the units
+// which wait for it are masked; the installed system reports the completion
+// of the bootstrap instead.
+func (a *Bootstrap) addBootstrapInPlace(templateData *bootstrapTemplateData) error {
+	if err := a.addStorageFiles("/", "bootstrap/bootstrap-in-place/files", templateData); err != nil {
+		return err
+	}
+	if err := a.addSystemdUnits("bootstrap/bootstrap-in-place/systemd/units", templateData); err != nil {
+		return err
+	}
+	for i, unit := range a.Config.Systemd.Units {
+		switch unit.Name {
+		case "openshift.service", "progress.service":
+			a.Config.Systemd.Units[i] = igntypes.Unit{Name: unit.Name, Mask: true}
+		}
+	}
+	return nil
+}
+
 // Read data from the string reader, and, if the name ends with
 // '.template', strip that extension from the name and render the
 // template.
@@ -338,7 +404,8 @@ func readFile(name string, reader io.Reader, templateData interface{}) (finalNam
 func (a *Bootstrap) addParentFiles(dependencies asset.Parents) {
 	mfsts := &manifests.Manifests{}
 	openshiftManifests := &manifests.Openshift{}
-	dependencies.Get(mfsts, openshiftManifests)
+	machineConfigs := &machine.MachineConfigs{}
+	dependencies.Get(mfsts, openshiftManifests, machineConfigs)
 
 	a.Config.Storage.Files = append(
 		a.Config.Storage.Files,
@@ -349,6 +416,18 @@ func (a *Bootstrap) addParentFiles(dependencies asset.Parents) {
 		ignition.FilesFromAsset(rootDir, "root", 0644, openshiftManifests)...,
 	)
 
+	// Apply the MachineConfigs to the cluster too, unless the openshift
+	// manifests loaded from the asset directory include them already.
+	openshiftFiles := map[string]bool{}
+	for _, f := range openshiftManifests.Files() {
+		openshiftFiles[f.Filename] = true
+	}
+	for _, f := range machineConfigs.Files() {
+		if !openshiftFiles[f.Filename] {
+			a.Config.Storage.Files = append(a.Config.Storage.Files, ignition.FileFromBytes(filepath.Join(rootDir, f.Filename), "root", 0644, f.Data))
+		}
+	}
+
 	for _, asset := range []asset.WritableAsset{
 		&kubeconfig.Admin{},
 		&kubeconfig.Kubelet{},
@@ -377,6 +456,17 @@ func (a *Bootstrap) addParentFiles(dependencies asset.Parents) {
 	a.Config.Storage.Files = append(a.Config.Storage.Files, ignition.FilesFromAsset(rootDir, "systemd-journal-gateway", 0600, journal)...)
 }
 
+// readdir returns the entries of the directory sorted by name, so that the
+// Ignition config lists the files and units in the same order every time.
+func readdir(directory http.File) ([]os.FileInfo, error) {
+	children, err := directory.Readdir(0)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].Name() < children[j].Name() })
+	return children, nil
+}
+
 func applyTemplateData(template *template.Template, templateData interface{}) string {
 	buf := &bytes.Buffer{}
 	if err := template.Execute(buf, templateData); err != nil {
@@ -395,10 +485,13 @@ func (a *Bootstrap) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, err
 	}
 
-	config := &igntypes.Config{}
-	if err := json.Unmarshal(file.Data, config); err != nil {
+	config, err := ignition.Unmarshal(file.Data)
+	if err != nil {
 		return false, errors.Wrapf(err, "failed to unmarshal")
 	}
+	if err := ignition.Validate(config); err != nil {
+		return false, errors.Wrapf(err, "failed to validate %s", bootstrapIgnFilename)
+	}
 
 	a.File, a.Config = file, config
 	return true, nil
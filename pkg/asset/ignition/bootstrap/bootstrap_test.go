@@ -0,0 +1,58 @@
+package bootstrap
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/data"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// TestBootstrapInPlaceMachineConfigServerPort tests that the bootstrap in
+// place script fetches the master config from the port of the machine
+// config server.
+func TestBootstrapInPlaceMachineConfigServerPort(t *testing.T) {
+	defer func(assets http.FileSystem) { data.Assets = assets }(data.Assets)
+	data.Assets = http.Dir("../../../../data/data")
+
+	cases := []struct {
+		mcs      *types.MachineConfigServer
+		expected string
+	}{
+		{nil, "https://localhost:49500/config/master"},
+		{&types.MachineConfigServer{Host: "lb.example.com", Port: 22623}, "https://localhost:22623/config/master"},
+	}
+	for _, tc := range cases {
+		installConfig := &types.InstallConfig{
+			ObjectMeta:          metav1.ObjectMeta{Name: "test-cluster"},
+			BaseDomain:          "test-domain",
+			BootstrapInPlace:    &types.BootstrapInPlace{InstallationDisk: "/dev/sda"},
+			MachineConfigServer: tc.mcs,
+		}
+		a := &Bootstrap{Config: &igntypes.Config{}}
+		templateData, err := a.getTemplateData(installConfig)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		if !assert.NoError(t, a.addStorageFiles("/", "bootstrap/bootstrap-in-place/files", templateData)) {
+			continue
+		}
+
+		var script string
+		for _, file := range a.Config.Storage.Files {
+			if file.Path == "/usr/local/bin/bootstrap-in-place.sh" {
+				url, err := dataurl.DecodeString(file.Contents.Source)
+				if assert.NoError(t, err) {
+					script = string(url.Data)
+				}
+			}
+		}
+		assert.True(t, strings.Contains(script, tc.expected), "the script should fetch %s", tc.expected)
+	}
+}
@@ -0,0 +1,133 @@
+package bootstrap
+
+import (
+	"bytes"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/coreos/ignition/config/util"
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition"
+)
+
+const (
+	// customizationsDir is the directory of the files and systemd units
+	// which the user adds to the bootstrap Ignition config.
+	customizationsDir = "bootstrap-customizations"
+)
+
+// unitTypes are the types of the systemd units loaded from the
+// customizations directory.
+var unitTypes = []string{"mount", "path", "service", "socket", "target", "timer"}
+
+// Customizations is an asset which loads the files and systemd units added
+// to the bootstrap-customizations directory, which are merged into the
+// bootstrap Ignition config. The directory holds Ignition configs (*.ign),
+// whose files, directories, links and systemd units are added, systemd
+// units (such as *.service), which are enabled if they have an [Install]
+// section, and systemd drop-ins (<unit>.d/*.conf). Files and units
+// replace those of the bootstrap Ignition config with the same path or
+// name. It generates no files; they are only provided by the user.
+type Customizations struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Customizations)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (a *Customizations) Name() string {
+	return "Bootstrap Customizations"
+}
+
+// Dependencies returns no dependencies.
+func (a *Customizations) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate generates no customizations; they are only provided by the user.
+func (a *Customizations) Generate(asset.Parents) error {
+	a.FileList = nil
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (a *Customizations) Files() []*asset.File {
+	return a.FileList
+}
+
+// Load returns the customizations in the bootstrap-customizations directory.
+func (a *Customizations) Load(f asset.FileFetcher) (bool, error) {
+	patterns := []string{"*.ign"}
+	for _, unitType := range unitTypes {
+		patterns = append(patterns, "*."+unitType)
+	}
+	patterns = append(patterns, filepath.Join("*.d", "*.conf"))
+
+	a.FileList = nil
+	for _, pattern := range patterns {
+		files, err := f.FetchByPattern(filepath.Join(customizationsDir, pattern))
+		if err != nil {
+			return false, err
+		}
+		for _, file := range files {
+			if filepath.Ext(file.Filename) == ".ign" {
+				if _, err := ignition.Unmarshal(file.Data); err != nil {
+					return false, errors.Wrapf(err, "failed to unmarshal %s", file.Filename)
+				}
+			}
+			a.FileList = append(a.FileList, file)
+		}
+	}
+	return len(a.FileList) > 0, nil
+}
+
+// Merge merges the customizations into the Ignition config: first the
+// Ignition configs, in the order of their names, then the units, then the
+// drop-ins.
+func (a *Customizations) Merge(config *igntypes.Config) error {
+	for _, file := range a.FileList {
+		if filepath.Ext(file.Filename) != ".ign" {
+			continue
+		}
+		c, err := ignition.Unmarshal(file.Data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal %s", file.Filename)
+		}
+		for _, f := range c.Storage.Files {
+			config.Storage.Files = ignition.MergeFile(config.Storage.Files, f)
+		}
+		config.Storage.Directories = append(config.Storage.Directories, c.Storage.Directories...)
+		config.Storage.Links = append(config.Storage.Links, c.Storage.Links...)
+		for _, unit := range c.Systemd.Units {
+			config.Systemd.Units = ignition.MergeUnit(config.Systemd.Units, unit)
+		}
+	}
+
+	for _, file := range a.FileList {
+		name := path.Base(filepath.ToSlash(file.Filename))
+		dir := path.Base(path.Dir(filepath.ToSlash(file.Filename)))
+		switch {
+		case filepath.Ext(name) == ".ign":
+			continue
+		case strings.HasSuffix(dir, ".d"):
+			config.Systemd.Units = ignition.MergeDropin(config.Systemd.Units, strings.TrimSuffix(dir, ".d"), igntypes.SystemdDropin{
+				Name:     name,
+				Contents: string(file.Data),
+			})
+		default:
+			unit := igntypes.Unit{
+				Name:     name,
+				Contents: string(file.Data),
+			}
+			if bytes.Contains(file.Data, []byte("[Install]")) {
+				unit.Enabled = util.BoolToPtr(true)
+			}
+			config.Systemd.Units = ignition.MergeUnit(config.Systemd.Units, unit)
+		}
+	}
+	return nil
+}
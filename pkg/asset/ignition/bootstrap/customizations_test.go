@@ -0,0 +1,99 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/coreos/ignition/config/util"
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const (
+	testCustomizationConfig = `{
+  "ignition": {"version": "2.2.0"},
+  "storage": {
+    "files": [{
+      "filesystem": "root",
+      "path": "/etc/motd",
+      "mode": 420,
+      "contents": {"source": "data:,custom"}
+    }]
+  },
+  "systemd": {
+    "units": [{"name": "auditd.service", "enabled": true}]
+  }
+}`
+	testCustomizationUnit = `[Unit]
+Description=Audit agent
+
+[Service]
+ExecStart=/usr/local/bin/agent
+
+[Install]
+WantedBy=multi-user.target
+`
+)
+
+func TestCustomizationsLoad(t *testing.T) {
+	fileFetcher := asset.NewMemoryFileFetcher(
+		&asset.File{Filename: "bootstrap-customizations/10-motd.ign", Data: []byte(testCustomizationConfig)},
+		&asset.File{Filename: "bootstrap-customizations/agent.service", Data: []byte(testCustomizationUnit)},
+		&asset.File{Filename: "bootstrap-customizations/kubelet.service.d/10-verbosity.conf", Data: []byte("[Service]\nEnvironment=KUBELET_LOG_LEVEL=4\n")},
+		&asset.File{Filename: "bootstrap-customizations/README", Data: []byte("notes")},
+	)
+
+	customizations := &Customizations{}
+	found, err := customizations.Load(fileFetcher)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	var filenames []string
+	for _, f := range customizations.Files() {
+		filenames = append(filenames, f.Filename)
+	}
+	assert.Equal(t, []string{
+		"bootstrap-customizations/10-motd.ign",
+		"bootstrap-customizations/agent.service",
+		"bootstrap-customizations/kubelet.service.d/10-verbosity.conf",
+	}, filenames)
+
+	_, err = (&Customizations{}).Load(asset.NewMemoryFileFetcher(
+		&asset.File{Filename: "bootstrap-customizations/broken.ign", Data: []byte("{")},
+	))
+	assert.EqualError(t, err, "failed to unmarshal bootstrap-customizations/broken.ign: unexpected end of JSON input")
+}
+
+func TestCustomizationsMerge(t *testing.T) {
+	customizations := &Customizations{
+		FileList: []*asset.File{
+			{Filename: "bootstrap-customizations/10-motd.ign", Data: []byte(testCustomizationConfig)},
+			{Filename: "bootstrap-customizations/agent.service", Data: []byte(testCustomizationUnit)},
+			{Filename: "bootstrap-customizations/debug.target", Data: []byte("[Unit]\nDescription=Debugging\n")},
+			{Filename: "bootstrap-customizations/kubelet.service.d/10-verbosity.conf", Data: []byte("[Service]\nEnvironment=KUBELET_LOG_LEVEL=4\n")},
+		},
+	}
+
+	config := &igntypes.Config{
+		Storage: igntypes.Storage{
+			Files: []igntypes.File{{Node: igntypes.Node{Path: "/etc/motd"}}},
+		},
+		Systemd: igntypes.Systemd{
+			Units: []igntypes.Unit{{Name: "kubelet.service", Contents: "[Service]\n"}},
+		},
+	}
+	assert.NoError(t, customizations.Merge(config))
+	if assert.Len(t, config.Storage.Files, 1) {
+		assert.Equal(t, "data:,custom", config.Storage.Files[0].Contents.Source)
+	}
+	assert.Equal(t, []igntypes.Unit{
+		{
+			Name:     "kubelet.service",
+			Contents: "[Service]\n",
+			Dropins:  []igntypes.SystemdDropin{{Name: "10-verbosity.conf", Contents: "[Service]\nEnvironment=KUBELET_LOG_LEVEL=4\n"}},
+		},
+		{Name: "auditd.service", Enabled: util.BoolToPtr(true)},
+		{Name: "agent.service", Contents: testCustomizationUnit, Enabled: util.BoolToPtr(true)},
+		{Name: "debug.target", Contents: "[Unit]\nDescription=Debugging\n"},
+	}, config.Systemd.Units)
+}
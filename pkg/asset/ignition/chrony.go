@@ -0,0 +1,25 @@
+package ignition
+
+import (
+	"bytes"
+	"fmt"
+
+	ignition "github.com/coreos/ignition/config/v2_2/types"
+)
+
+// ChronyFile creates the ignition-config file of the chrony configuration,
+// which synchronizes the clock with the NTP servers instead of the default
+// pools of the operating system.
+func ChronyFile(servers []string) ignition.File {
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "# Generated by the installer from the ntpServers of the install-config.")
+	for _, server := range servers {
+		fmt.Fprintf(buf, "server %s iburst\n", server)
+	}
+	fmt.Fprint(buf, `driftfile /var/lib/chrony/drift
+makestep 1.0 3
+rtcsync
+logdir /var/log/chrony
+`)
+	return FileFromBytes("/etc/chrony.conf", "root", 0644, buf.Bytes())
+}
@@ -0,0 +1,25 @@
+package ignition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+)
+
+func TestChronyFile(t *testing.T) {
+	file := ChronyFile([]string{"ntp.example.com", "10.0.0.2"})
+	assert.Equal(t, "/etc/chrony.conf", file.Path)
+	assert.Equal(t, 0644, *file.Mode)
+
+	contents, err := dataurl.DecodeString(file.Contents.Source)
+	assert.NoError(t, err)
+	assert.Equal(t, `# Generated by the installer from the ntpServers of the install-config.
+server ntp.example.com iburst
+server 10.0.0.2 iburst
+driftfile /var/lib/chrony/drift
+makestep 1.0 3
+rtcsync
+logdir /var/log/chrony
+`, string(contents.Data))
+}
@@ -0,0 +1,123 @@
+// Package hosts contains the asset which generates the Ignition configs of
+// the machines listed in the install-config.
+package hosts
+
+import (
+	"fmt"
+	"path/filepath"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
+	"github.com/openshift/installer/pkg/asset/ignition/machine"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+const (
+	hostsDir = "hosts"
+)
+
+// Hosts is an asset which generates an Ignition config for each of the
+// hosts in the install-config: the config of the host's role, with its
+// hostname and static network configuration added.
+type Hosts struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Hosts)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (a *Hosts) Name() string {
+	return "Host Ignition Configs"
+}
+
+// Sensitive returns true, because the Ignition config of the bootstrap host
+// contains private keys.
+func (a *Hosts) Sensitive() bool {
+	return true
+}
+
+// Dependencies returns the assets on which the Hosts asset depends.
+func (a *Hosts) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+		&bootstrap.Bootstrap{},
+		&machine.Master{},
+		&machine.Worker{},
+	}
+}
+
+// Generate generates the Ignition configs of the hosts.
+func (a *Hosts) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	bootstrapIgn := &bootstrap.Bootstrap{}
+	masterIgn := &machine.Master{}
+	workerIgn := &machine.Worker{}
+	dependencies.Get(installConfig, bootstrapIgn, masterIgn, workerIgn)
+
+	roles := map[string]*igntypes.Config{
+		"bootstrap": bootstrapIgn.Config,
+		"master":    masterIgn.Config,
+		"worker":    workerIgn.Config,
+	}
+	if installConfig.Config.BootstrapInPlace != nil {
+		// The single master boots the bootstrap config on its live system
+		// and installs itself from there.
+		roles["master"] = bootstrapIgn.Config
+	}
+
+	a.FileList = nil
+	for _, host := range installConfig.Config.Hosts {
+		role, ok := roles[host.Role]
+		if !ok {
+			return errors.Errorf("host %s has the unsupported role %q", host.Name, host.Role)
+		}
+
+		config := *role
+		config.Storage.Files = append([]igntypes.File{}, role.Storage.Files...)
+		config.Storage.Files = append(config.Storage.Files, ignition.FileFromString("/etc/hostname", "root", 0644, fmt.Sprintln(host.Name)))
+		config.Storage.Files = append(config.Storage.Files, networkFiles(&host)...)
+
+		data, err := ignition.Marshal(&config)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the Ignition config of host %s", host.Name)
+		}
+		a.FileList = append(a.FileList, &asset.File{
+			Filename: Filename(host.Name),
+			Data:     data,
+		})
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (a *Hosts) Files() []*asset.File {
+	return a.FileList
+}
+
+// Load returns the Ignition configs of the hosts from disk.
+func (a *Hosts) Load(f asset.FileFetcher) (bool, error) {
+	fileList, err := f.FetchByPattern(filepath.Join(hostsDir, "*.ign"))
+	if err != nil {
+		return false, err
+	}
+	for _, file := range fileList {
+		config, err := ignition.Unmarshal(file.Data)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to unmarshal %s", file.Filename)
+		}
+		if err := ignition.Validate(config); err != nil {
+			return false, errors.Wrapf(err, "failed to validate %s", file.Filename)
+		}
+	}
+	a.FileList = fileList
+	return len(fileList) > 0, nil
+}
+
+// Filename returns the name of the file of the host's Ignition config.
+func Filename(host string) string {
+	return filepath.Join(hostsDir, fmt.Sprintf("%s.ign", host))
+}
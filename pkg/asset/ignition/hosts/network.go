@@ -0,0 +1,232 @@
+package hosts
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+
+	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	connectionsDir  = "/etc/NetworkManager/system-connections"
+	defaultBondMode = "active-backup"
+)
+
+// connectionID returns the NetworkManager connection ID of the interface,
+// which is also the name dracut gives to interfaces matched by their MAC
+// address.
+func connectionID(index int, iface *types.NetworkInterface) string {
+	if iface.Name != "" {
+		return iface.Name
+	}
+	return fmt.Sprintf("static%d", index)
+}
+
+// memberID returns the NetworkManager connection ID of the bond member,
+// which is also the name dracut gives to members matched by their MAC
+// address.
+func memberID(bond *types.NetworkBond, index int, member string) string {
+	if _, err := net.ParseMAC(member); err == nil {
+		return fmt.Sprintf("%sp%d", bond.Name, index)
+	}
+	return member
+}
+
+func bondMode(bond *types.NetworkBond) string {
+	if bond.Mode != "" {
+		return bond.Mode
+	}
+	return defaultBondMode
+}
+
+// networkFiles returns the NetworkManager keyfiles of the host's static
+// network configuration.
+func networkFiles(host *types.Host) []igntypes.File {
+	if host.NetworkConfig == nil {
+		return nil
+	}
+
+	var files []igntypes.File
+	add := func(id string, contents string) {
+		files = append(files, ignition.FileFromString(path.Join(connectionsDir, fmt.Sprintf("%s.nmconnection", id)), "root", 0600, contents))
+	}
+	for i, iface := range host.NetworkConfig.Interfaces {
+		id := connectionID(i, &iface)
+		add(id, interfaceKeyfile(id, &iface))
+	}
+	for _, bond := range host.NetworkConfig.Bonds {
+		add(bond.Name, bondKeyfile(&bond))
+		for j, member := range bond.Interfaces {
+			id := fmt.Sprintf("%s-%s", bond.Name, memberID(&bond, j, member))
+			add(id, memberKeyfile(id, &bond, member))
+		}
+	}
+	for _, vlan := range host.NetworkConfig.VLANs {
+		add(vlan.DeviceName(), vlanKeyfile(&vlan))
+	}
+	return files
+}
+
+// interfaceKeyfile returns the NetworkManager keyfile of the interface.
+func interfaceKeyfile(id string, iface *types.NetworkInterface) string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "[connection]\nid=%s\ntype=ethernet\n", id)
+	if iface.Name != "" {
+		fmt.Fprintf(buf, "interface-name=%s\n", iface.Name)
+	}
+	if iface.MACAddress != "" {
+		writeMACAddress(buf, iface.MACAddress)
+	}
+	writeAddresses(buf, &iface.NetworkAddresses)
+	return buf.String()
+}
+
+// bondKeyfile returns the NetworkManager keyfile of the bond.
+func bondKeyfile(bond *types.NetworkBond) string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "[connection]\nid=%[1]s\ntype=bond\ninterface-name=%[1]s\n", bond.Name)
+	fmt.Fprintf(buf, "\n[bond]\nmode=%s\nmiimon=100\n", bondMode(bond))
+	writeAddresses(buf, &bond.NetworkAddresses)
+	return buf.String()
+}
+
+// memberKeyfile returns the NetworkManager keyfile of the member of the
+// bond, which is given by its name or MAC address.
+func memberKeyfile(id string, bond *types.NetworkBond, member string) string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "[connection]\nid=%s\ntype=ethernet\n", id)
+	_, err := net.ParseMAC(member)
+	if err != nil {
+		fmt.Fprintf(buf, "interface-name=%s\n", member)
+	}
+	fmt.Fprintf(buf, "master=%s\nslave-type=bond\n", bond.Name)
+	if err == nil {
+		writeMACAddress(buf, member)
+	}
+	return buf.String()
+}
+
+// vlanKeyfile returns the NetworkManager keyfile of the VLAN.
+func vlanKeyfile(vlan *types.NetworkVLAN) string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "[connection]\nid=%[1]s\ntype=vlan\ninterface-name=%[1]s\n", vlan.DeviceName())
+	fmt.Fprintf(buf, "\n[vlan]\nid=%d\nparent=%s\n", vlan.ID, vlan.Interface)
+	writeAddresses(buf, &vlan.NetworkAddresses)
+	return buf.String()
+}
+
+func writeMACAddress(buf *bytes.Buffer, address string) {
+	mac, _ := net.ParseMAC(address)
+	fmt.Fprintf(buf, "\n[ethernet]\nmac-address=%s\n", strings.ToUpper(mac.String()))
+}
+
+// writeAddresses writes the ipv4 and ipv6 sections of a keyfile.
+func writeAddresses(buf *bytes.Buffer, addresses *types.NetworkAddresses) {
+	for _, family := range []struct {
+		section  string
+		ipv4     bool
+		disabled string
+	}{
+		{section: "ipv4", ipv4: true, disabled: "disabled"},
+		{section: "ipv6", ipv4: false, disabled: "ignore"},
+	} {
+		fmt.Fprintf(buf, "\n[%s]\n", family.section)
+		n := 0
+		for _, address := range addresses.Addresses {
+			if (address.IP.To4() != nil) != family.ipv4 {
+				continue
+			}
+			n++
+			fmt.Fprintf(buf, "address%d=%s\n", n, address.String())
+		}
+		if n == 0 {
+			fmt.Fprintf(buf, "method=%s\n", family.disabled)
+			continue
+		}
+		if addresses.Gateway != nil && (addresses.Gateway.To4() != nil) == family.ipv4 {
+			fmt.Fprintf(buf, "gateway=%s\n", addresses.Gateway)
+		}
+		var dns []string
+		for _, server := range addresses.DNS {
+			if (server.To4() != nil) == family.ipv4 {
+				dns = append(dns, server.String())
+			}
+		}
+		if len(dns) > 0 {
+			fmt.Fprintf(buf, "dns=%s;\n", strings.Join(dns, ";"))
+		}
+		fmt.Fprintf(buf, "method=manual\n")
+	}
+}
+
+// KernelArguments returns the dracut kernel arguments which configure the
+// host's static network in the initramfs, so that it can fetch its
+// Ignition config, or an empty string if the host uses DHCP. Only the
+// first address of each interface is configured.
+func KernelArguments(host *types.Host) string {
+	if host.NetworkConfig == nil {
+		return ""
+	}
+
+	var args []string
+	for i, iface := range host.NetworkConfig.Interfaces {
+		id := connectionID(i, &iface)
+		if iface.MACAddress != "" {
+			mac, _ := net.ParseMAC(iface.MACAddress)
+			args = append(args, fmt.Sprintf("ifname=%s:%s", id, mac))
+		}
+		args = append(args, ipArguments(host.Name, id, &iface.NetworkAddresses)...)
+	}
+	for _, bond := range host.NetworkConfig.Bonds {
+		var members []string
+		for j, member := range bond.Interfaces {
+			id := memberID(&bond, j, member)
+			if mac, err := net.ParseMAC(member); err == nil {
+				args = append(args, fmt.Sprintf("ifname=%s:%s", id, mac))
+			}
+			members = append(members, id)
+		}
+		args = append(args, fmt.Sprintf("bond=%s:%s:mode=%s,miimon=100", bond.Name, strings.Join(members, ","), bondMode(&bond)))
+		args = append(args, ipArguments(host.Name, bond.Name, &bond.NetworkAddresses)...)
+	}
+	for _, vlan := range host.NetworkConfig.VLANs {
+		args = append(args, fmt.Sprintf("vlan=%s:%s", vlan.DeviceName(), vlan.Interface))
+		args = append(args, ipArguments(host.Name, vlan.DeviceName(), &vlan.NetworkAddresses)...)
+	}
+	return strings.Join(args, " ")
+}
+
+// ipArguments returns the ip= and nameserver= kernel arguments of the
+// first address of the device, if any.
+func ipArguments(hostname string, device string, addresses *types.NetworkAddresses) []string {
+	if len(addresses.Addresses) == 0 {
+		return nil
+	}
+
+	address := addresses.Addresses[0]
+	ipv4 := address.IP.To4() != nil
+	ones, _ := address.Mask.Size()
+	client, netmask, gateway := address.IP.String(), fmt.Sprint(ones), ""
+	if addresses.Gateway != nil && (addresses.Gateway.To4() != nil) == ipv4 {
+		gateway = addresses.Gateway.String()
+	}
+	if ipv4 {
+		netmask = net.IP(address.Mask).String()
+	} else {
+		client = fmt.Sprintf("[%s]", client)
+		if gateway != "" {
+			gateway = fmt.Sprintf("[%s]", gateway)
+		}
+	}
+	args := []string{fmt.Sprintf("ip=%s::%s:%s:%s:%s:none", client, gateway, netmask, hostname, device)}
+	for _, server := range addresses.DNS {
+		args = append(args, fmt.Sprintf("nameserver=%s", server))
+	}
+	return args
+}
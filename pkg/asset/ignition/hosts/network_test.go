@@ -0,0 +1,209 @@
+package hosts
+
+import (
+	"net"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func testHost() *types.Host {
+	return &types.Host{
+		Name: "master-0",
+		Role: "master",
+		NetworkConfig: &types.NetworkConfig{
+			Interfaces: []types.NetworkInterface{
+				{
+					Name: "eno1",
+					NetworkAddresses: types.NetworkAddresses{
+						Addresses: []ipnet.IPNet{
+							{IPNet: net.IPNet{IP: net.IPv4(10, 0, 0, 10).To4(), Mask: net.CIDRMask(24, 32)}},
+							{IPNet: net.IPNet{IP: net.ParseIP("fd00::10"), Mask: net.CIDRMask(64, 128)}},
+						},
+						Gateway: net.IPv4(10, 0, 0, 1),
+						DNS:     []net.IP{net.IPv4(10, 0, 0, 2), net.ParseIP("fd00::2")},
+					},
+				},
+				{
+					MACAddress: "52:54:00:00:00:0a",
+					NetworkAddresses: types.NetworkAddresses{
+						Addresses: []ipnet.IPNet{
+							{IPNet: net.IPNet{IP: net.ParseIP("fd01::10"), Mask: net.CIDRMask(64, 128)}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testBondedHost() *types.Host {
+	return &types.Host{
+		Name: "worker-0",
+		Role: "worker",
+		NetworkConfig: &types.NetworkConfig{
+			Bonds: []types.NetworkBond{{
+				Name:       "bond0",
+				Interfaces: []string{"eno1", "52:54:00:00:00:0b"},
+			}},
+			VLANs: []types.NetworkVLAN{{
+				ID:        100,
+				Interface: "bond0",
+				NetworkAddresses: types.NetworkAddresses{
+					Addresses: []ipnet.IPNet{
+						{IPNet: net.IPNet{IP: net.IPv4(10, 0, 100, 10).To4(), Mask: net.CIDRMask(24, 32)}},
+					},
+					Gateway: net.IPv4(10, 0, 100, 1),
+				},
+			}},
+		},
+	}
+}
+
+func TestKeyfile(t *testing.T) {
+	host := testHost()
+	cases := []struct {
+		name     string
+		iface    *types.NetworkInterface
+		expected string
+	}{
+		{
+			name:  "name",
+			iface: &host.NetworkConfig.Interfaces[0],
+			expected: `[connection]
+id=eno1
+type=ethernet
+interface-name=eno1
+
+[ipv4]
+address1=10.0.0.10/24
+gateway=10.0.0.1
+dns=10.0.0.2;
+method=manual
+
+[ipv6]
+address1=fd00::10/64
+dns=fd00::2;
+method=manual
+`,
+		},
+		{
+			name:  "MAC address",
+			iface: &host.NetworkConfig.Interfaces[1],
+			expected: `[connection]
+id=static1
+type=ethernet
+
+[ethernet]
+mac-address=52:54:00:00:00:0A
+
+[ipv4]
+method=disabled
+
+[ipv6]
+address1=fd01::10/64
+method=manual
+`,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, interfaceKeyfile(connectionID(i, tc.iface), tc.iface))
+		})
+	}
+}
+
+func TestNetworkFiles(t *testing.T) {
+	var paths []string
+	for _, f := range networkFiles(testHost()) {
+		paths = append(paths, f.Path)
+		assert.Equal(t, 0600, *f.Mode)
+	}
+	assert.Equal(t, []string{
+		"/etc/NetworkManager/system-connections/eno1.nmconnection",
+		"/etc/NetworkManager/system-connections/static1.nmconnection",
+	}, paths)
+
+	assert.Empty(t, networkFiles(&types.Host{Name: "worker-0", Role: "worker"}))
+}
+
+func TestBondedNetworkFiles(t *testing.T) {
+	files := map[string]string{}
+	var paths []string
+	for _, f := range networkFiles(testBondedHost()) {
+		contents, err := dataurl.DecodeString(f.Contents.Source)
+		assert.NoError(t, err)
+		paths = append(paths, f.Path)
+		files[path.Base(f.Path)] = string(contents.Data)
+	}
+	assert.Equal(t, []string{
+		"/etc/NetworkManager/system-connections/bond0.nmconnection",
+		"/etc/NetworkManager/system-connections/bond0-eno1.nmconnection",
+		"/etc/NetworkManager/system-connections/bond0-bond0p1.nmconnection",
+		"/etc/NetworkManager/system-connections/bond0.100.nmconnection",
+	}, paths)
+
+	assert.Equal(t, `[connection]
+id=bond0
+type=bond
+interface-name=bond0
+
+[bond]
+mode=active-backup
+miimon=100
+
+[ipv4]
+method=disabled
+
+[ipv6]
+method=ignore
+`, files["bond0.nmconnection"])
+	assert.Equal(t, `[connection]
+id=bond0-eno1
+type=ethernet
+interface-name=eno1
+master=bond0
+slave-type=bond
+`, files["bond0-eno1.nmconnection"])
+	assert.Equal(t, `[connection]
+id=bond0-bond0p1
+type=ethernet
+master=bond0
+slave-type=bond
+
+[ethernet]
+mac-address=52:54:00:00:00:0B
+`, files["bond0-bond0p1.nmconnection"])
+	assert.Equal(t, `[connection]
+id=bond0.100
+type=vlan
+interface-name=bond0.100
+
+[vlan]
+id=100
+parent=bond0
+
+[ipv4]
+address1=10.0.100.10/24
+gateway=10.0.100.1
+method=manual
+
+[ipv6]
+method=ignore
+`, files["bond0.100.nmconnection"])
+}
+
+func TestKernelArguments(t *testing.T) {
+	assert.Equal(t,
+		"ip=10.0.0.10::10.0.0.1:255.255.255.0:master-0:eno1:none nameserver=10.0.0.2 nameserver=fd00::2 ifname=static1:52:54:00:00:00:0a ip=[fd01::10]:::64:master-0:static1:none",
+		KernelArguments(testHost()))
+	assert.Equal(t,
+		"ifname=bond0p1:52:54:00:00:00:0b bond=bond0:eno1,bond0p1:mode=active-backup,miimon=100 vlan=bond0.100:bond0 ip=10.0.100.10::10.0.100.1:255.255.255.0:worker-0:bond0.100:none",
+		KernelArguments(testBondedHost()))
+	assert.Equal(t, "", KernelArguments(&types.Host{Name: "worker-0", Role: "worker"}))
+}
@@ -0,0 +1,186 @@
+package machine
+
+import (
+	"path/filepath"
+	"reflect"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition"
+)
+
+// ExternalConfigsDir is the directory of the MachineConfigs and Ignition
+// config fragments generated outside of the installer, for example by a
+// site configuration tool, which are merged into the Ignition configs of
+// the machines. It is not read if it is empty.
+var ExternalConfigsDir string
+
+// externalRoles are the roles whose subdirectories of ExternalConfigsDir
+// hold Ignition config fragments.
+var externalRoles = []string{"master", "worker"}
+
+// ExternalConfigs is an asset which reads ExternalConfigsDir. Its
+// MachineConfig manifests (*.yaml, *.yml) are added to the openshift
+// manifests and, like those of the openshift directory, merged into the
+// master Ignition config to apply on first boot. The Ignition configs
+// (*.ign) of its master and worker subdirectories are merged into the
+// Ignition config of that role; the machine config operator does not
+// manage their files and units after the install. It generates no files,
+// and is regenerated when the contents of the directory change.
+type ExternalConfigs struct {
+	FileList []*asset.File
+}
+
+var _ asset.Asset = (*ExternalConfigs)(nil)
+var _ asset.Expirer = (*ExternalConfigs)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (a *ExternalConfigs) Name() string {
+	return "External Machine Configs"
+}
+
+// Dependencies returns no dependencies.
+func (a *ExternalConfigs) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate reads and validates the MachineConfigs and Ignition configs of
+// ExternalConfigsDir.
+func (a *ExternalConfigs) Generate(asset.Parents) error {
+	files, err := readExternalConfigs(ExternalConfigsDir)
+	if err != nil {
+		return err
+	}
+	if err := validateExternalConfigs(files); err != nil {
+		return err
+	}
+	a.FileList = files
+	return nil
+}
+
+// Expired returns true if the contents of ExternalConfigsDir differ from
+// those read when the asset was generated, so that it is read again along
+// with the Ignition configs and manifests which merge it. If
+// ExternalConfigsDir is not set, the configs read by an earlier invocation
+// are kept.
+func (a *ExternalConfigs) Expired() bool {
+	if ExternalConfigsDir == "" {
+		return false
+	}
+	files, err := readExternalConfigs(ExternalConfigsDir)
+	if err != nil {
+		// Regenerate, to report the error.
+		return true
+	}
+	return !reflect.DeepEqual(files, a.FileList)
+}
+
+// MachineConfigs returns the MachineConfig manifests.
+func (a *ExternalConfigs) MachineConfigs() []*asset.File {
+	var files []*asset.File
+	for _, file := range a.FileList {
+		if filepath.Ext(file.Filename) != ".ign" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// Merge merges the files, directories, links and systemd units of the
+// Ignition configs for the role into the Ignition config, in the order of
+// their names. Files and units replace those with the same path or name.
+func (a *ExternalConfigs) Merge(config *igntypes.Config, role string) error {
+	for _, file := range a.FileList {
+		if filepath.Ext(file.Filename) != ".ign" || filepath.Dir(file.Filename) != role {
+			continue
+		}
+		c, err := ignition.Unmarshal(file.Data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal %s", file.Filename)
+		}
+		for _, f := range c.Storage.Files {
+			config.Storage.Files = ignition.MergeFile(config.Storage.Files, f)
+		}
+		config.Storage.Directories = append(config.Storage.Directories, c.Storage.Directories...)
+		config.Storage.Links = append(config.Storage.Links, c.Storage.Links...)
+		for _, unit := range c.Systemd.Units {
+			config.Systemd.Units = ignition.MergeUnit(config.Systemd.Units, unit)
+		}
+	}
+	return nil
+}
+
+// readExternalConfigs returns the MachineConfig manifests and the Ignition
+// configs of the roles in dir, if it is set.
+func readExternalConfigs(dir string) ([]*asset.File, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	patterns := []string{"*.yaml", "*.yml"}
+	for _, role := range externalRoles {
+		patterns = append(patterns, filepath.Join(role, "*.ign"))
+	}
+
+	fetcher := asset.NewFileFetcher(dir)
+	var files []*asset.File
+	for _, pattern := range patterns {
+		matches, err := fetcher.FetchByPattern(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", dir)
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// validateExternalConfigs checks that the manifests are MachineConfigs for
+// the master or worker role, which do not replace those generated by the
+// installer, and that the Ignition configs are valid.
+func validateExternalConfigs(files []*asset.File) error {
+	generated := map[string]bool{}
+	for _, role := range externalRoles {
+		for _, kind := range generatedMachineConfigKinds {
+			generated[generatedMachineConfigName(kind, role)] = true
+		}
+	}
+
+	names := map[string]string{}
+	for _, file := range files {
+		if filepath.Ext(file.Filename) == ".ign" {
+			config, err := ignition.Unmarshal(file.Data)
+			if err != nil {
+				return errors.Wrapf(err, "failed to unmarshal %s", file.Filename)
+			}
+			if err := ignition.Validate(config); err != nil {
+				return errors.Wrapf(err, "failed to validate %s", file.Filename)
+			}
+			if config.Passwd.Users != nil || config.Passwd.Groups != nil {
+				logrus.Warnf("Only the files, directories, links and systemd units of %s are merged", file.Filename)
+			}
+			continue
+		}
+
+		mc, ok, err := parseMachineConfig(file)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.Errorf("%s is not a %s MachineConfig", file.Filename, machineConfigAPIVersion)
+		}
+		if role := mc.Labels[machineConfigRoleLabel]; role != "master" && role != "worker" {
+			return errors.Errorf("%s must have the %s label of master or worker", file.Filename, machineConfigRoleLabel)
+		}
+		if generated[mc.Name] {
+			return errors.Errorf("%s has the name of the %s MachineConfig generated from the install-config; rename it", file.Filename, mc.Name)
+		}
+		if other, ok := names[mc.Name]; ok {
+			return errors.Errorf("%s and %s are both named %s", other, file.Filename, mc.Name)
+		}
+		names[mc.Name] = file.Filename
+	}
+	return nil
+}
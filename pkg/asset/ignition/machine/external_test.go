@@ -0,0 +1,143 @@
+package machine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testExternalIgnition = `{
+  "ignition": {"version": "2.2.0"},
+  "storage": {
+    "files": [{"filesystem": "root", "path": "/etc/site.conf", "contents": {"source": "data:,site"}}],
+    "links": [{"filesystem": "root", "path": "/etc/site.link", "target": "/etc/site.conf"}]
+  },
+  "systemd": {"units": [{"name": "site.service", "enabled": true}]}
+}`
+
+func writeExternalConfigs(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "TestExternalConfigs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestExternalConfigsGenerate(t *testing.T) {
+	dir := writeExternalConfigs(t, map[string]string{
+		"99-master-chrony.yaml": testMasterMachineConfig,
+		"master/10-site.ign":    testExternalIgnition,
+		"other/20-ignored.ign":  testExternalIgnition,
+	})
+	defer os.RemoveAll(dir)
+	defer func(old string) { ExternalConfigsDir = old }(ExternalConfigsDir)
+	ExternalConfigsDir = dir
+
+	externalConfigs := &ExternalConfigs{}
+	if !assert.NoError(t, externalConfigs.Generate(nil)) {
+		return
+	}
+	machineConfigs := externalConfigs.MachineConfigs()
+	if assert.Len(t, machineConfigs, 1) {
+		assert.Equal(t, "99-master-chrony.yaml", machineConfigs[0].Filename)
+	}
+
+	master := &igntypes.Config{}
+	assert.NoError(t, externalConfigs.Merge(master, "master"))
+	if assert.Len(t, master.Storage.Files, 1) {
+		assert.Equal(t, "/etc/site.conf", master.Storage.Files[0].Path)
+	}
+	assert.Len(t, master.Storage.Links, 1)
+	assert.Len(t, master.Systemd.Units, 1)
+
+	worker := &igntypes.Config{}
+	assert.NoError(t, externalConfigs.Merge(worker, "worker"))
+	assert.Empty(t, worker.Storage.Files)
+
+	assert.False(t, externalConfigs.Expired())
+	if err := os.Mkdir(filepath.Join(dir, "worker"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "worker", "10-site.ign"), []byte(testExternalIgnition), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, externalConfigs.Expired())
+
+	ExternalConfigsDir = ""
+	assert.False(t, externalConfigs.Expired())
+}
+
+func TestExternalConfigsValidate(t *testing.T) {
+	cases := []struct {
+		name  string
+		files map[string]string
+		err   string
+	}{
+		{
+			name: "valid",
+			files: map[string]string{
+				"99-master-chrony.yaml": testMasterMachineConfig,
+				"99-worker-chrony.yml":  testWorkerMachineConfig,
+				"worker/10-site.ign":    testExternalIgnition,
+			},
+		},
+		{
+			name:  "not a machine config",
+			files: map[string]string{"secret.yaml": "apiVersion: v1\nkind: Secret\n"},
+			err:   "secret.yaml is not a machineconfiguration.openshift.io/v1 MachineConfig",
+		},
+		{
+			name:  "no role",
+			files: map[string]string{"mc.yaml": "apiVersion: machineconfiguration.openshift.io/v1\nkind: MachineConfig\nmetadata:\n  name: 99-chrony\n"},
+			err:   "mc.yaml must have the machineconfiguration.openshift.io/role label of master or worker",
+		},
+		{
+			name:  "generated name",
+			files: map[string]string{"mc.yaml": "apiVersion: machineconfiguration.openshift.io/v1\nkind: MachineConfig\nmetadata:\n  name: 99-worker-ssh\n  labels:\n    machineconfiguration.openshift.io/role: worker\n"},
+			err:   "mc.yaml has the name of the 99-worker-ssh MachineConfig generated from the install-config; rename it",
+		},
+		{
+			name: "duplicate name",
+			files: map[string]string{
+				"a.yaml": testMasterMachineConfig,
+				"b.yaml": testMasterMachineConfig,
+			},
+			err: "a.yaml and b.yaml are both named 99-master-chrony",
+		},
+		{
+			name:  "invalid ignition",
+			files: map[string]string{"master/10-site.ign": "{"},
+			err:   "failed to unmarshal master/10-site.ign",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeExternalConfigs(t, tc.files)
+			defer os.RemoveAll(dir)
+
+			files, err := readExternalConfigs(dir)
+			if !assert.NoError(t, err) {
+				return
+			}
+			err = validateExternalConfigs(files)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package machine
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/coreos/ignition/config/util"
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition"
+)
+
+const (
+	// openshiftManifestDir is the directory of the manifests which are
+	// created after the cluster's control plane is up.
+	openshiftManifestDir = "openshift"
+
+	machineConfigAPIVersion = "machineconfiguration.openshift.io/v1"
+	machineConfigRoleLabel  = "machineconfiguration.openshift.io/role"
+
+	kernelArgumentsStamp = "/var/lib/machine-config-kernel-arguments.stamp"
+)
+
+// machineConfig is the machineconfiguration.openshift.io/v1 MachineConfig,
+// which is not vendored.
+type machineConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              machineConfigSpec `json:"spec"`
+}
+
+type machineConfigSpec struct {
+	Config          igntypes.Config `json:"config"`
+	KernelArguments []string        `json:"kernelArguments,omitempty"`
+}
+
+// generatedMachineConfigKinds are the kinds of the MachineConfigs generated
+// from the machine pools.
+var generatedMachineConfigKinds = []string{"storage", "ssh"}
+
+// MachineConfigs is an asset which loads the MachineConfig manifests added
+// to the openshift directory, so that their files, systemd units and kernel
+// arguments apply on the first boot of the machines instead of when the
+// machine config operator rolls them out. It generates no files; the
+// manifests are also applied to the cluster by the bootstrap machine.
+type MachineConfigs struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*MachineConfigs)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (a *MachineConfigs) Name() string {
+	return "Machine Configs"
+}
+
+// Dependencies returns no dependencies.
+func (a *MachineConfigs) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate generates no MachineConfigs; they are only provided by the user.
+func (a *MachineConfigs) Generate(asset.Parents) error {
+	a.FileList = nil
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (a *MachineConfigs) Files() []*asset.File {
+	return a.FileList
+}
+
+// Load returns the MachineConfig manifests in the openshift directory.
+func (a *MachineConfigs) Load(f asset.FileFetcher) (bool, error) {
+	files, err := f.FetchByPattern(filepath.Join(openshiftManifestDir, "*"))
+	if err != nil {
+		return false, err
+	}
+
+	// The MachineConfigs generated from the machine pools of the
+	// install-config are added to the pointer configs directly, and must
+	// not apply to the bootstrap machine.
+	generated := map[string]bool{}
+	for _, role := range []string{"master", "worker"} {
+		for _, kind := range generatedMachineConfigKinds {
+			generated[generatedMachineConfigName(kind, role)] = true
+		}
+	}
+
+	a.FileList = nil
+	for _, file := range files {
+		if mc, ok, err := parseMachineConfig(file); err != nil {
+			return false, err
+		} else if ok && !generated[mc.Name] {
+			a.FileList = append(a.FileList, file)
+		}
+	}
+	return len(a.FileList) > 0, nil
+}
+
+// with returns the MachineConfigs with the files added, except those whose
+// MachineConfig is already among them, such as the external MachineConfigs
+// which were written to the openshift directory with the manifests.
+func (a *MachineConfigs) with(files []*asset.File) *MachineConfigs {
+	names := map[string]bool{}
+	for _, file := range a.FileList {
+		if mc, ok, err := parseMachineConfig(file); err == nil && ok {
+			names[mc.Name] = true
+		}
+	}
+
+	result := &MachineConfigs{FileList: append([]*asset.File{}, a.FileList...)}
+	for _, file := range files {
+		if mc, ok, err := parseMachineConfig(file); err == nil && ok && !names[mc.Name] {
+			result.FileList = append(result.FileList, file)
+		}
+	}
+	return result
+}
+
+// Merge merges the files and systemd units of the MachineConfigs for the
+// role into the Ignition config, in the order of their names. Files and
+// units replace those with the same path or name.
+func (a *MachineConfigs) Merge(config *igntypes.Config, role string) error {
+	configs, err := a.configs(role)
+	if err != nil {
+		return err
+	}
+
+	for _, mc := range configs {
+		for _, file := range mc.Spec.Config.Storage.Files {
+			config.Storage.Files = ignition.MergeFile(config.Storage.Files, file)
+		}
+		for _, unit := range mc.Spec.Config.Systemd.Units {
+			config.Systemd.Units = ignition.MergeUnit(config.Systemd.Units, unit)
+		}
+	}
+	return nil
+}
+
+// KernelArguments returns the kernel arguments of the MachineConfigs for the
+// role, in the order of their names.
+func (a *MachineConfigs) KernelArguments(role string) ([]string, error) {
+	configs, err := a.configs(role)
+	if err != nil {
+		return nil, err
+	}
+
+	var args []string
+	seen := map[string]bool{}
+	for _, mc := range configs {
+		for _, arg := range mc.Spec.KernelArguments {
+			if !seen[arg] {
+				seen[arg] = true
+				args = append(args, arg)
+			}
+		}
+	}
+	return args, nil
+}
+
+// configs returns the MachineConfigs for the role, sorted by name.
+func (a *MachineConfigs) configs(role string) ([]*machineConfig, error) {
+	var configs []*machineConfig
+	for _, file := range a.FileList {
+		mc, _, err := parseMachineConfig(file)
+		if err != nil {
+			return nil, err
+		}
+		if mc.Labels[machineConfigRoleLabel] != role {
+			continue
+		}
+		if mc.Spec.Config.Passwd.Users != nil || len(mc.Spec.Config.Storage.Directories) > 0 || len(mc.Spec.Config.Storage.Links) > 0 {
+			logrus.Warnf("Only the files, systemd units and kernel arguments of %s apply on first boot", file.Filename)
+		}
+		configs = append(configs, mc)
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Name < configs[j].Name })
+	return configs, nil
+}
+
+// generatedMachineConfigName returns the name of the MachineConfig of the
+// kind generated from the machine pool for the role.
+func generatedMachineConfigName(kind string, role string) string {
+	return fmt.Sprintf("99-%s-%s", role, kind)
+}
+
+// newMachineConfig returns an empty MachineConfig of the kind generated
+// from the machine pool for the role.
+func newMachineConfig(kind string, role string) *machineConfig {
+	return &machineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: machineConfigAPIVersion,
+			Kind:       "MachineConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   generatedMachineConfigName(kind, role),
+			Labels: map[string]string{machineConfigRoleLabel: role},
+		},
+		Spec: machineConfigSpec{
+			Config: igntypes.Config{
+				Ignition: igntypes.Ignition{Version: igntypes.MaxVersion.String()},
+			},
+		},
+	}
+}
+
+// marshalMachineConfig returns the manifest of the MachineConfig.
+func marshalMachineConfig(mc *machineConfig) ([]byte, error) {
+	data, err := yaml.Marshal(mc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal the %s MachineConfig", mc.Name)
+	}
+	return data, nil
+}
+
+// parseMachineConfig returns the MachineConfig in the file, and whether the
+// file holds a MachineConfig at all.
+func parseMachineConfig(file *asset.File) (*machineConfig, bool, error) {
+	mc := &machineConfig{}
+	if err := yaml.Unmarshal(file.Data, &mc.TypeMeta); err != nil || mc.APIVersion != machineConfigAPIVersion || mc.Kind != "MachineConfig" {
+		return nil, false, nil
+	}
+	if err := yaml.Unmarshal(file.Data, mc); err != nil {
+		return nil, true, errors.Wrapf(err, "failed to unmarshal %s", file.Filename)
+	}
+	return mc, true, nil
+}
+
+// kernelArgumentsUnit returns a unit which adds the kernel arguments with
+// rpm-ostree and reboots, once, on first boot, because Ignition cannot set
+// kernel arguments.
+func kernelArgumentsUnit(args []string) igntypes.Unit {
+	appends := make([]string, 0, len(args))
+	for _, arg := range args {
+		appends = append(appends, fmt.Sprintf("--append=%s", arg))
+	}
+	return igntypes.Unit{
+		Name:    "machine-config-kernel-arguments.service",
+		Enabled: util.BoolToPtr(true),
+		Contents: fmt.Sprintf(`[Unit]
+Description=Add the kernel arguments of the MachineConfigs
+ConditionPathExists=!%[1]s
+Before=kubelet.service
+
+[Service]
+Type=oneshot
+ExecStart=/usr/bin/rpm-ostree kargs %[2]s
+ExecStart=/usr/bin/touch %[1]s
+ExecStart=/usr/bin/systemctl --no-block reboot
+
+[Install]
+WantedBy=multi-user.target
+`, kernelArgumentsStamp, strings.Join(appends, " ")),
+	}
+}
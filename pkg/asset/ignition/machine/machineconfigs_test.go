@@ -0,0 +1,134 @@
+package machine
+
+import (
+	"testing"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/mock"
+)
+
+const (
+	testMasterMachineConfig = `apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  name: 99-master-chrony
+  labels:
+    machineconfiguration.openshift.io/role: master
+spec:
+  config:
+    ignition:
+      version: 2.2.0
+    storage:
+      files:
+      - filesystem: root
+        path: /etc/chrony.conf
+        mode: 420
+        contents:
+          source: data:,server%20ntp.example.com
+    systemd:
+      units:
+      - name: chronyd.service
+        enabled: true
+  kernelArguments:
+  - nosmt
+`
+	testEarlierMasterMachineConfig = `apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  name: 50-master-chrony
+  labels:
+    machineconfiguration.openshift.io/role: master
+spec:
+  config:
+    ignition:
+      version: 2.2.0
+    storage:
+      files:
+      - filesystem: root
+        path: /etc/chrony.conf
+        contents:
+          source: data:,server%20old.example.com
+  kernelArguments:
+  - nosmt
+  - audit=1
+`
+	testWorkerMachineConfig = `apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  name: 99-worker-chrony
+  labels:
+    machineconfiguration.openshift.io/role: worker
+spec:
+  config:
+    ignition:
+      version: 2.2.0
+    storage:
+      files:
+      - filesystem: root
+        path: /etc/chrony.conf
+        contents:
+          source: data:,server%20worker.example.com
+`
+)
+
+func TestMachineConfigsLoad(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+	fileFetcher.EXPECT().FetchByPattern("openshift/*").Return([]*asset.File{
+		{Filename: "openshift/99_kubeadmin-password-secret.yaml", Data: []byte("apiVersion: v1\nkind: Secret\n")},
+		{Filename: "openshift/99-master-chrony.yaml", Data: []byte(testMasterMachineConfig)},
+		{Filename: "openshift/99-worker-chrony.yaml", Data: []byte(testWorkerMachineConfig)},
+		{Filename: "openshift/99_openshift-machineconfig_99-master-storage.yaml", Data: []byte("apiVersion: machineconfiguration.openshift.io/v1\nkind: MachineConfig\nmetadata:\n  name: 99-master-storage\n")},
+		{Filename: "openshift/99_openshift-machineconfig_99-worker-ssh.yaml", Data: []byte("apiVersion: machineconfiguration.openshift.io/v1\nkind: MachineConfig\nmetadata:\n  name: 99-worker-ssh\n")},
+	}, nil)
+
+	machineConfigs := &MachineConfigs{}
+	found, err := machineConfigs.Load(fileFetcher)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	var filenames []string
+	for _, f := range machineConfigs.Files() {
+		filenames = append(filenames, f.Filename)
+	}
+	assert.Equal(t, []string{"openshift/99-master-chrony.yaml", "openshift/99-worker-chrony.yaml"}, filenames)
+}
+
+func TestMachineConfigsMerge(t *testing.T) {
+	machineConfigs := &MachineConfigs{
+		FileList: []*asset.File{
+			{Filename: "openshift/99-master-chrony.yaml", Data: []byte(testMasterMachineConfig)},
+			{Filename: "openshift/99-worker-chrony.yaml", Data: []byte(testWorkerMachineConfig)},
+			{Filename: "openshift/50-master-chrony.yaml", Data: []byte(testEarlierMasterMachineConfig)},
+		},
+	}
+
+	config := &igntypes.Config{
+		Systemd: igntypes.Systemd{
+			Units: []igntypes.Unit{{Name: "kubelet.service"}},
+		},
+	}
+	assert.NoError(t, machineConfigs.Merge(config, "master"))
+	if assert.Len(t, config.Storage.Files, 1) {
+		assert.Equal(t, "/etc/chrony.conf", config.Storage.Files[0].Path)
+		assert.Equal(t, "data:,server%20ntp.example.com", config.Storage.Files[0].Contents.Source)
+	}
+	var units []string
+	for _, u := range config.Systemd.Units {
+		units = append(units, u.Name)
+	}
+	assert.Equal(t, []string{"kubelet.service", "chronyd.service"}, units)
+
+	args, err := machineConfigs.KernelArguments("master")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"nosmt", "audit=1"}, args)
+
+	args, err = machineConfigs.KernelArguments("worker")
+	assert.NoError(t, err)
+	assert.Empty(t, args)
+}
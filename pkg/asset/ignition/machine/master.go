@@ -1,13 +1,13 @@
 package machine
 
 import (
-	"encoding/json"
 	"os"
 
 	igntypes "github.com/coreos/ignition/config/v2_2/types"
 	"github.com/pkg/errors"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/tls"
 )
@@ -29,6 +29,8 @@ func (a *Master) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&installconfig.InstallConfig{},
 		&tls.RootCA{},
+		&MachineConfigs{},
+		&ExternalConfigs{},
 	}
 }
 
@@ -36,11 +38,41 @@ func (a *Master) Dependencies() []asset.Asset {
 func (a *Master) Generate(dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	rootCA := &tls.RootCA{}
-	dependencies.Get(installConfig, rootCA)
+	machineConfigs := &MachineConfigs{}
+	externalConfigs := &ExternalConfigs{}
+	dependencies.Get(installConfig, rootCA, machineConfigs, externalConfigs)
+	machineConfigs = machineConfigs.with(externalConfigs.MachineConfigs())
 
 	a.Config = pointerIgnitionConfig(installConfig.Config, rootCA.Cert(), "master")
+	var kernelArguments []string
+	if pool := machinePool(installConfig.Config.Machines, "master"); pool != nil && pool.Storage != nil {
+		mergeStorage(a.Config, pool.Storage)
+		kernelArguments = storageKernelArguments(pool.Storage)
+	}
+	if err := machineConfigs.Merge(a.Config, "master"); err != nil {
+		return errors.Wrap(err, "failed to merge MachineConfigs")
+	}
+	if err := externalConfigs.Merge(a.Config, "master"); err != nil {
+		return errors.Wrap(err, "failed to merge the external Ignition configs")
+	}
+	machineConfigArguments, err := machineConfigs.KernelArguments("master")
+	if err != nil {
+		return errors.Wrap(err, "failed to merge MachineConfigs")
+	}
+	seen := map[string]bool{}
+	for _, arg := range kernelArguments {
+		seen[arg] = true
+	}
+	for _, arg := range machineConfigArguments {
+		if !seen[arg] {
+			kernelArguments = append(kernelArguments, arg)
+		}
+	}
+	if len(kernelArguments) > 0 {
+		a.Config.Systemd.Units = append(a.Config.Systemd.Units, kernelArgumentsUnit(kernelArguments))
+	}
 
-	data, err := json.Marshal(a.Config)
+	data, err := ignition.Marshal(a.Config)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal Ignition config")
 	}
@@ -75,10 +107,13 @@ func (a *Master) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, err
 	}
 
-	config := &igntypes.Config{}
-	if err := json.Unmarshal(file.Data, config); err != nil {
+	config, err := ignition.Unmarshal(file.Data)
+	if err != nil {
 		return false, errors.Wrapf(err, "failed to unmarshal")
 	}
+	if err := ignition.Validate(config); err != nil {
+		return false, errors.Wrapf(err, "failed to validate %s", masterIgnFilename)
+	}
 
 	a.File, a.Config = file, config
 	return true, nil
@@ -2,26 +2,30 @@ package machine
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"strconv"
 
 	ignition "github.com/coreos/ignition/config/v2_2/types"
 	"github.com/vincent-petithory/dataurl"
 
+	assetignition "github.com/openshift/installer/pkg/asset/ignition"
 	"github.com/openshift/installer/pkg/types"
 )
 
 // pointerIgnitionConfig generates a config which references the remote config
 // served by the machine config server.
 func pointerIgnitionConfig(installConfig *types.InstallConfig, rootCA []byte, role string) *ignition.Config {
-	return &ignition.Config{
+	config := &ignition.Config{
 		Ignition: ignition.Ignition{
 			Version: ignition.MaxVersion.String(),
 			Config: ignition.IgnitionConfig{
 				Append: []ignition.ConfigReference{{
 					Source: func() *url.URL {
+						host, port := installConfig.MachineConfigServerEndpoint()
 						return &url.URL{
 							Scheme: "https",
-							Host:   fmt.Sprintf("%s-api.%s:49500", installConfig.ObjectMeta.Name, installConfig.BaseDomain),
+							Host:   net.JoinHostPort(host, strconv.Itoa(port)),
 							Path:   fmt.Sprintf("/config/%s", role),
 						}
 					}().String(),
@@ -36,4 +40,16 @@ func pointerIgnitionConfig(installConfig *types.InstallConfig, rootCA []byte, ro
 			},
 		},
 	}
+	if len(installConfig.NTPServers) > 0 {
+		config.Storage.Files = append(config.Storage.Files, assetignition.ChronyFile(installConfig.NTPServers))
+	}
+	if len(installConfig.ImageContentSources) > 0 {
+		config.Storage.Files = append(config.Storage.Files, assetignition.RegistriesFile(installConfig.ImageContentSources))
+	}
+	if pool := machinePool(installConfig.Machines, role); pool != nil {
+		if user := coreUser(installConfig, pool); user != nil {
+			config.Passwd.Users = append(config.Passwd.Users, *user)
+		}
+	}
+	return config
 }
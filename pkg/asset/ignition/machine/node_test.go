@@ -0,0 +1,57 @@
+package machine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// TestPointerIgnitionConfig tests that the pointer config verifies the
+// machine config server with the root CA.
+func TestPointerIgnitionConfig(t *testing.T) {
+	installConfig := &types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
+		BaseDomain: "test-domain",
+	}
+	rootCA := []byte("-----BEGIN CERTIFICATE-----\ntest\n-----END CERTIFICATE-----\n")
+
+	config := pointerIgnitionConfig(installConfig, rootCA, "worker")
+	if assert.Len(t, config.Ignition.Config.Append, 1) {
+		assert.Equal(t, "https://test-cluster-api.test-domain:49500/config/worker", config.Ignition.Config.Append[0].Source)
+	}
+	if assert.Len(t, config.Ignition.Security.TLS.CertificateAuthorities, 1) {
+		ca, err := dataurl.DecodeString(config.Ignition.Security.TLS.CertificateAuthorities[0].Source)
+		assert.NoError(t, err)
+		assert.Equal(t, rootCA, ca.Data)
+	}
+}
+
+// TestPointerIgnitionConfigMachineConfigServer tests that the pointer config
+// references the configured machine config server endpoint.
+func TestPointerIgnitionConfigMachineConfigServer(t *testing.T) {
+	installConfig := &types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
+		BaseDomain: "test-domain",
+	}
+
+	for _, tc := range []struct {
+		mcs      *types.MachineConfigServer
+		expected string
+	}{
+		{&types.MachineConfigServer{Port: 22623}, "https://test-cluster-api.test-domain:22623/config/master"},
+		{&types.MachineConfigServer{Host: "lb.example.com"}, "https://lb.example.com:49500/config/master"},
+		{&types.MachineConfigServer{Host: "fd00::1", Port: 8443}, "https://[fd00::1]:8443/config/master"},
+	} {
+		installConfig.MachineConfigServer = tc.mcs
+		config := pointerIgnitionConfig(installConfig, nil, "master")
+		assert.Equal(t, tc.expected, config.Ignition.Config.Append[0].Source)
+	}
+}
@@ -0,0 +1,51 @@
+package machine
+
+import (
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// coreUser returns the core user of the machines in the pool, with the SSH
+// keys of the cluster and of the pool and the password hash of the pool,
+// or nil if the pool adds neither SSH keys nor a password hash. The
+// machine config server also returns the core user, with the SSH key of
+// the cluster.
+func coreUser(installConfig *types.InstallConfig, pool *types.MachinePool) *igntypes.PasswdUser {
+	if len(pool.SSHKeys) == 0 && pool.PasswordHash == "" {
+		return nil
+	}
+
+	user := &igntypes.PasswdUser{Name: "core"}
+	if installConfig.SSHKey != "" {
+		user.SSHAuthorizedKeys = append(user.SSHAuthorizedKeys, igntypes.SSHAuthorizedKey(installConfig.SSHKey))
+	}
+	for _, key := range pool.SSHKeys {
+		user.SSHAuthorizedKeys = append(user.SSHAuthorizedKeys, igntypes.SSHAuthorizedKey(key))
+	}
+	if pool.PasswordHash != "" {
+		hash := pool.PasswordHash
+		user.PasswordHash = &hash
+	}
+	return user
+}
+
+// SSHMachineConfig returns the manifest of the MachineConfig with the SSH
+// keys of the machine pool for the role, or nil if the pool has none, so
+// that the machine config operator keeps them when it updates the SSH keys
+// of the core user. The password hash is only set on first boot, because
+// the machine config operator cannot change it.
+func SSHMachineConfig(installConfig *types.InstallConfig, role string) ([]byte, error) {
+	pool := machinePool(installConfig.Machines, role)
+	if pool == nil || len(pool.SSHKeys) == 0 {
+		return nil, nil
+	}
+
+	mc := newMachineConfig("ssh", role)
+	user := igntypes.PasswdUser{Name: "core"}
+	for _, key := range pool.SSHKeys {
+		user.SSHAuthorizedKeys = append(user.SSHAuthorizedKeys, igntypes.SSHAuthorizedKey(key))
+	}
+	mc.Spec.Config.Passwd.Users = []igntypes.PasswdUser{user}
+	return marshalMachineConfig(mc)
+}
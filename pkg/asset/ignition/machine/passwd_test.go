@@ -0,0 +1,60 @@
+package machine
+
+import (
+	"testing"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const testPasswordHash = "$6$saltsalt$8HLKCpH5ycA6sXVNyVZ6euq5v2Rfd2VXFHoXzbOFsp9KsOP5I5B8ny.WHpJkqGFUOzDQ.iAAzRgHYJat6w1ID1"
+
+func testPasswdInstallConfig() *types.InstallConfig {
+	return &types.InstallConfig{
+		SSHKey: "ssh-ed25519 cluster",
+		Machines: []types.MachinePool{
+			{Name: "master", SSHKeys: []string{"ssh-ed25519 ops"}, PasswordHash: testPasswordHash},
+			{Name: "worker"},
+		},
+	}
+}
+
+func TestPointerIgnitionConfigCoreUser(t *testing.T) {
+	installConfig := testPasswdInstallConfig()
+
+	config := pointerIgnitionConfig(installConfig, nil, "master")
+	if assert.Len(t, config.Passwd.Users, 1) {
+		user := config.Passwd.Users[0]
+		assert.Equal(t, "core", user.Name)
+		assert.Equal(t, []igntypes.SSHAuthorizedKey{"ssh-ed25519 cluster", "ssh-ed25519 ops"}, user.SSHAuthorizedKeys)
+		if assert.NotNil(t, user.PasswordHash) {
+			assert.Equal(t, testPasswordHash, *user.PasswordHash)
+		}
+	}
+
+	config = pointerIgnitionConfig(installConfig, nil, "worker")
+	assert.Empty(t, config.Passwd.Users)
+}
+
+func TestSSHMachineConfig(t *testing.T) {
+	installConfig := testPasswdInstallConfig()
+
+	data, err := SSHMachineConfig(installConfig, "master")
+	assert.NoError(t, err)
+	mc, ok, err := parseMachineConfig(&asset.File{Filename: "master", Data: data})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "99-master-ssh", mc.Name)
+	assert.Equal(t, "master", mc.Labels[machineConfigRoleLabel])
+	assert.Equal(t, []igntypes.PasswdUser{{
+		Name:              "core",
+		SSHAuthorizedKeys: []igntypes.SSHAuthorizedKey{"ssh-ed25519 ops"},
+	}}, mc.Spec.Config.Passwd.Users)
+
+	data, err = SSHMachineConfig(installConfig, "worker")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
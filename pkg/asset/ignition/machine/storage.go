@@ -0,0 +1,147 @@
+package machine
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/coreos/ignition/config/util"
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+
+	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	// sectorsPerMiB converts MiB to the 512-byte sectors with which
+	// Ignition sizes partitions.
+	sectorsPerMiB = 2048
+)
+
+// multipathKernelArguments enable multipath in the initramfs and boot from
+// the multipathed root device.
+var multipathKernelArguments = []string{"rd.multipath=default", "root=/dev/disk/by-label/dm-mpath-root"}
+
+// machinePool returns the machine pool with the name, or nil if there is
+// none.
+func machinePool(pools []types.MachinePool, name string) *types.MachinePool {
+	for i := range pools {
+		if pools[i].Name == name {
+			return &pools[i]
+		}
+	}
+	return nil
+}
+
+// partitionLabel returns the label of the partition and of its filesystem,
+// which is named after its mount path (e.g. var-lib-containers).
+func partitionLabel(p *types.Partition) string {
+	return strings.Replace(strings.TrimPrefix(p.MountPath, "/"), "/", "-", -1)
+}
+
+// storageDisks returns the disks of the partitions, with the partitions of
+// each disk in the order they were given.
+func storageDisks(storage *types.MachinePoolStorage) []igntypes.Disk {
+	var disks []igntypes.Disk
+	index := map[string]int{}
+	for _, p := range storage.Partitions {
+		i, ok := index[p.Device]
+		if !ok {
+			i = len(disks)
+			index[p.Device] = i
+			disks = append(disks, igntypes.Disk{Device: p.Device})
+		}
+		disks[i].Partitions = append(disks[i].Partitions, igntypes.Partition{
+			Label: partitionLabel(&p),
+			Start: p.StartMiB * sectorsPerMiB,
+			Size:  p.SizeMiB * sectorsPerMiB,
+		})
+	}
+	return disks
+}
+
+// storageFilesystems returns the XFS filesystems of the partitions.
+func storageFilesystems(storage *types.MachinePoolStorage) []igntypes.Filesystem {
+	var filesystems []igntypes.Filesystem
+	for _, p := range storage.Partitions {
+		label := partitionLabel(&p)
+		filesystems = append(filesystems, igntypes.Filesystem{
+			Name: label,
+			Mount: &igntypes.Mount{
+				Device:         path.Join("/dev/disk/by-partlabel", label),
+				Format:         "xfs",
+				Label:          util.StrToPtr(label),
+				WipeFilesystem: true,
+			},
+		})
+	}
+	return filesystems
+}
+
+// storageMountUnits returns the systemd mount units of the partitions.
+// Ignition only mounts filesystems while it runs, in the initramfs.
+func storageMountUnits(storage *types.MachinePoolStorage) []igntypes.Unit {
+	var units []igntypes.Unit
+	for _, p := range storage.Partitions {
+		label := partitionLabel(&p)
+		units = append(units, igntypes.Unit{
+			Name:    fmt.Sprintf("%s.mount", label),
+			Enabled: util.BoolToPtr(true),
+			Contents: fmt.Sprintf(`[Unit]
+Description=Mount %[1]s
+Before=local-fs.target
+
+[Mount]
+What=/dev/disk/by-partlabel/%[2]s
+Where=%[1]s
+Type=xfs
+
+[Install]
+WantedBy=local-fs.target
+`, p.MountPath, label),
+		})
+	}
+	return units
+}
+
+// storageKernelArguments returns the kernel arguments of the storage.
+func storageKernelArguments(storage *types.MachinePoolStorage) []string {
+	if storage.Multipath {
+		return multipathKernelArguments
+	}
+	return nil
+}
+
+// mergeStorage adds the partitions of the storage, their filesystems and
+// their mount units to the Ignition config.
+func mergeStorage(config *igntypes.Config, storage *types.MachinePoolStorage) {
+	config.Storage.Disks = append(config.Storage.Disks, storageDisks(storage)...)
+	config.Storage.Filesystems = append(config.Storage.Filesystems, storageFilesystems(storage)...)
+	for _, unit := range storageMountUnits(storage) {
+		config.Systemd.Units = ignition.MergeUnit(config.Systemd.Units, unit)
+	}
+}
+
+// StorageMachineConfig returns the manifest of the MachineConfig with the
+// storage of the machine pool for the role, or nil if the pool has no
+// storage, so that the machine config operator keeps its mount units and
+// kernel arguments. The worker MachineConfig also creates the partitions,
+// because the workers get it with the rest of their config from the
+// machine config server on first boot. The masters create theirs from
+// their pointer config instead, because they boot before the manifests
+// are applied, and the machine config operator cannot change disks later.
+func StorageMachineConfig(installConfig *types.InstallConfig, role string) ([]byte, error) {
+	pool := machinePool(installConfig.Machines, role)
+	if pool == nil || pool.Storage == nil {
+		return nil, nil
+	}
+
+	mc := newMachineConfig("storage", role)
+	mc.Spec.KernelArguments = storageKernelArguments(pool.Storage)
+	if role != "master" {
+		mc.Spec.Config.Storage.Disks = storageDisks(pool.Storage)
+		mc.Spec.Config.Storage.Filesystems = storageFilesystems(pool.Storage)
+	}
+	mc.Spec.Config.Systemd.Units = storageMountUnits(pool.Storage)
+	return marshalMachineConfig(mc)
+}
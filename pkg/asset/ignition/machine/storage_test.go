@@ -0,0 +1,106 @@
+package machine
+
+import (
+	"testing"
+
+	"github.com/coreos/ignition/config/util"
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func testStorage() *types.MachinePoolStorage {
+	return &types.MachinePoolStorage{
+		Partitions: []types.Partition{
+			{Device: "/dev/sda", MountPath: "/var/log", StartMiB: 25000, SizeMiB: 10000},
+			{Device: "/dev/sdb", MountPath: "/var/lib/containers"},
+			{Device: "/dev/sda", MountPath: "/var/lib/etcd", SizeMiB: 8192},
+		},
+		Multipath: true,
+	}
+}
+
+func TestMergeStorage(t *testing.T) {
+	config := &igntypes.Config{}
+	mergeStorage(config, testStorage())
+
+	assert.Equal(t, []igntypes.Disk{
+		{
+			Device: "/dev/sda",
+			Partitions: []igntypes.Partition{
+				{Label: "var-log", Start: 51200000, Size: 20480000},
+				{Label: "var-lib-etcd", Size: 16777216},
+			},
+		},
+		{
+			Device:     "/dev/sdb",
+			Partitions: []igntypes.Partition{{Label: "var-lib-containers"}},
+		},
+	}, config.Storage.Disks)
+	assert.Equal(t, igntypes.Filesystem{
+		Name: "var-lib-containers",
+		Mount: &igntypes.Mount{
+			Device:         "/dev/disk/by-partlabel/var-lib-containers",
+			Format:         "xfs",
+			Label:          util.StrToPtr("var-lib-containers"),
+			WipeFilesystem: true,
+		},
+	}, config.Storage.Filesystems[1])
+
+	var names []string
+	for _, unit := range config.Systemd.Units {
+		names = append(names, unit.Name)
+	}
+	assert.Equal(t, []string{"var-log.mount", "var-lib-containers.mount", "var-lib-etcd.mount"}, names)
+	assert.Equal(t, `[Unit]
+Description=Mount /var/lib/containers
+Before=local-fs.target
+
+[Mount]
+What=/dev/disk/by-partlabel/var-lib-containers
+Where=/var/lib/containers
+Type=xfs
+
+[Install]
+WantedBy=local-fs.target
+`, config.Systemd.Units[1].Contents)
+}
+
+func TestStorageMachineConfig(t *testing.T) {
+	installConfig := &types.InstallConfig{
+		Machines: []types.MachinePool{
+			{Name: "master", Storage: testStorage()},
+			{Name: "worker", Storage: &types.MachinePoolStorage{
+				Partitions: []types.Partition{{Device: "/dev/sdb", MountPath: "/var/lib/containers"}},
+			}},
+		},
+	}
+
+	data, err := StorageMachineConfig(installConfig, "master")
+	assert.NoError(t, err)
+	mc, ok, err := parseMachineConfig(&asset.File{Filename: "master", Data: data})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "99-master-storage", mc.Name)
+	assert.Equal(t, "master", mc.Labels[machineConfigRoleLabel])
+	assert.Equal(t, []string{"rd.multipath=default", "root=/dev/disk/by-label/dm-mpath-root"}, mc.Spec.KernelArguments)
+	assert.Empty(t, mc.Spec.Config.Storage.Disks)
+	assert.Len(t, mc.Spec.Config.Systemd.Units, 3)
+
+	data, err = StorageMachineConfig(installConfig, "worker")
+	assert.NoError(t, err)
+	mc, _, err = parseMachineConfig(&asset.File{Filename: "worker", Data: data})
+	assert.NoError(t, err)
+	assert.Empty(t, mc.Spec.KernelArguments)
+	assert.Equal(t, []igntypes.Disk{{
+		Device:     "/dev/sdb",
+		Partitions: []igntypes.Partition{{Label: "var-lib-containers"}},
+	}}, mc.Spec.Config.Storage.Disks)
+	assert.Len(t, mc.Spec.Config.Storage.Filesystems, 1)
+
+	data, err = StorageMachineConfig(&types.InstallConfig{Machines: []types.MachinePool{{Name: "worker"}}}, "worker")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
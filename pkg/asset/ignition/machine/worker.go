@@ -1,13 +1,13 @@
 package machine
 
 import (
-	"encoding/json"
 	"os"
 
 	igntypes "github.com/coreos/ignition/config/v2_2/types"
 	"github.com/pkg/errors"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/tls"
 )
@@ -29,6 +29,7 @@ func (a *Worker) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&installconfig.InstallConfig{},
 		&tls.RootCA{},
+		&ExternalConfigs{},
 	}
 }
 
@@ -36,11 +37,15 @@ func (a *Worker) Dependencies() []asset.Asset {
 func (a *Worker) Generate(dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	rootCA := &tls.RootCA{}
-	dependencies.Get(installConfig, rootCA)
+	externalConfigs := &ExternalConfigs{}
+	dependencies.Get(installConfig, rootCA, externalConfigs)
 
 	a.Config = pointerIgnitionConfig(installConfig.Config, rootCA.Cert(), "worker")
+	if err := externalConfigs.Merge(a.Config, "worker"); err != nil {
+		return errors.Wrap(err, "failed to merge the external Ignition configs")
+	}
 
-	data, err := json.Marshal(a.Config)
+	data, err := ignition.Marshal(a.Config)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal Ignition config")
 	}
@@ -75,10 +80,13 @@ func (a *Worker) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, err
 	}
 
-	config := &igntypes.Config{}
-	if err := json.Unmarshal(file.Data, config); err != nil {
+	config, err := ignition.Unmarshal(file.Data)
+	if err != nil {
 		return false, errors.Wrapf(err, "failed to unmarshal")
 	}
+	if err := ignition.Validate(config); err != nil {
+		return false, errors.Wrapf(err, "failed to validate %s", workerIgnFilename)
+	}
 
 	a.File, a.Config = file, config
 	return true, nil
@@ -0,0 +1,49 @@
+package ignition
+
+import (
+	ignition "github.com/coreos/ignition/config/v2_2/types"
+)
+
+// MergeFile adds the file to the files, replacing the file with the same
+// path, if any.
+func MergeFile(files []ignition.File, file ignition.File) []ignition.File {
+	for i := range files {
+		if files[i].Path == file.Path {
+			files[i] = file
+			return files
+		}
+	}
+	return append(files, file)
+}
+
+// MergeUnit adds the systemd unit to the units, replacing the unit with the
+// same name, if any.
+func MergeUnit(units []ignition.Unit, unit ignition.Unit) []ignition.Unit {
+	for i := range units {
+		if units[i].Name == unit.Name {
+			units[i] = unit
+			return units
+		}
+	}
+	return append(units, unit)
+}
+
+// MergeDropin adds the systemd drop-in to the unit with the name, replacing
+// the drop-in with the same name, if any. The unit is added, with only the
+// drop-in, if there is none with the name.
+func MergeDropin(units []ignition.Unit, name string, dropin ignition.SystemdDropin) []ignition.Unit {
+	for i := range units {
+		if units[i].Name != name {
+			continue
+		}
+		for j := range units[i].Dropins {
+			if units[i].Dropins[j].Name == dropin.Name {
+				units[i].Dropins[j] = dropin
+				return units
+			}
+		}
+		units[i].Dropins = append(units[i].Dropins, dropin)
+		return units
+	}
+	return append(units, ignition.Unit{Name: name, Dropins: []ignition.SystemdDropin{dropin}})
+}
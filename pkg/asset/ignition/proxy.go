@@ -0,0 +1,75 @@
+package ignition
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	ignition "github.com/coreos/ignition/config/v2_2/types"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// ProxyDropin creates the systemd drop-in which sets the proxy environment
+// variables of a unit, so that it reaches the networks outside of the
+// cluster, such as the registry of the release image, through the proxy of
+// the install-config. The cluster's own hosts and networks are reached
+// without the proxy.
+func ProxyDropin(installConfig *types.InstallConfig) ignition.SystemdDropin {
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "[Service]")
+	for _, env := range []struct {
+		name  string
+		value string
+	}{
+		{name: "HTTP_PROXY", value: installConfig.Proxy.HTTPProxy},
+		{name: "HTTPS_PROXY", value: installConfig.Proxy.HTTPSProxy},
+		{name: "NO_PROXY", value: noProxy(installConfig)},
+	} {
+		if env.value != "" {
+			// systemd expands specifiers starting with %.
+			fmt.Fprintf(buf, "Environment=\"%s=%s\"\n", env.name, strings.Replace(env.value, "%", "%%", -1))
+		}
+	}
+	return ignition.SystemdDropin{
+		Name:     "10-proxy.conf",
+		Contents: buf.String(),
+	}
+}
+
+// noProxy returns the hosts and networks of the cluster, followed by the
+// user's noProxy list.
+func noProxy(installConfig *types.InstallConfig) string {
+	hosts := []string{
+		"localhost",
+		"127.0.0.1",
+		".svc",
+		".cluster.local",
+		// The metadata service of the cloud platforms.
+		"169.254.169.254",
+		fmt.Sprintf("%s-api.%s", installConfig.ObjectMeta.Name, installConfig.BaseDomain),
+	}
+	for i := 0; i < installConfig.MasterCount(); i++ {
+		hosts = append(hosts, fmt.Sprintf("%s-etcd-%d.%s", installConfig.ObjectMeta.Name, i, installConfig.BaseDomain))
+	}
+	if n := installConfig.Networking; n != nil {
+		if n.MachineCIDR != nil {
+			hosts = append(hosts, n.MachineCIDR.String())
+		}
+		if n.ServiceCIDR != nil {
+			hosts = append(hosts, n.ServiceCIDR.String())
+		}
+		for _, network := range n.ClusterNetworks {
+			hosts = append(hosts, network.CIDR)
+		}
+		if len(n.ClusterNetworks) == 0 && n.PodCIDR != nil {
+			hosts = append(hosts, n.PodCIDR.String())
+		}
+	}
+	for _, host := range strings.Split(installConfig.Proxy.NoProxy, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return strings.Join(hosts, ",")
+}
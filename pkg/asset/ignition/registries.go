@@ -0,0 +1,27 @@
+package ignition
+
+import (
+	"bytes"
+	"fmt"
+
+	ignition "github.com/coreos/ignition/config/v2_2/types"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// RegistriesFile creates the ignition-config file of the containers registry
+// configuration, which pulls the images of each source repository from its
+// mirrors, so that the machines can pull the release payload before any
+// operator manages the configuration.
+func RegistriesFile(sources []types.ImageContentSource) ignition.File {
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "# Generated by the installer from the imageContentSources of the install-config.")
+	fmt.Fprintln(buf, `unqualified-search-registries = ["registry.access.redhat.com", "docker.io"]`)
+	for _, source := range sources {
+		fmt.Fprintf(buf, "\n[[registry]]\nlocation = %q\nmirror-by-digest-only = true\n", source.Source)
+		for _, mirror := range source.Mirrors {
+			fmt.Fprintf(buf, "\n[[registry.mirror]]\nlocation = %q\n", mirror)
+		}
+	}
+	return FileFromBytes("/etc/containers/registries.conf", "root", 0644, buf.Bytes())
+}
@@ -0,0 +1,48 @@
+package ignition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestRegistriesFile(t *testing.T) {
+	file := RegistriesFile([]types.ImageContentSource{
+		{
+			Source:  "quay.io/openshift-release-dev/ocp-release",
+			Mirrors: []string{"mirror.example.com:5000/ocp/release", "10.0.0.5/ocp/release"},
+		},
+		{
+			Source:  "quay.io/openshift-release-dev/ocp-v4.0-art-dev",
+			Mirrors: []string{"mirror.example.com:5000/ocp/release"},
+		},
+	})
+	assert.Equal(t, "/etc/containers/registries.conf", file.Path)
+	assert.Equal(t, 0644, *file.Mode)
+
+	contents, err := dataurl.DecodeString(file.Contents.Source)
+	assert.NoError(t, err)
+	assert.Equal(t, `# Generated by the installer from the imageContentSources of the install-config.
+unqualified-search-registries = ["registry.access.redhat.com", "docker.io"]
+
+[[registry]]
+location = "quay.io/openshift-release-dev/ocp-release"
+mirror-by-digest-only = true
+
+[[registry.mirror]]
+location = "mirror.example.com:5000/ocp/release"
+
+[[registry.mirror]]
+location = "10.0.0.5/ocp/release"
+
+[[registry]]
+location = "quay.io/openshift-release-dev/ocp-v4.0-art-dev"
+mirror-by-digest-only = true
+
+[[registry.mirror]]
+location = "mirror.example.com:5000/ocp/release"
+`, string(contents.Data))
+}
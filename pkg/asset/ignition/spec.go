@@ -0,0 +1,293 @@
+package ignition
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SpecV2 is the version 2.2 of the Ignition config specification.
+	SpecV2 = "2.2"
+
+	// SpecV3 is the version 3.0 of the Ignition config specification.
+	SpecV3 = "3.0"
+)
+
+// SpecVersion is the version of the Ignition config specification in which
+// the Ignition configs are written. The configs are generated in spec 2.2
+// and translated when they are written in spec 3.0. It defaults to spec 2.2,
+// because that is the spec which the RHCOS images and the machine config
+// server, whose configs the pointer configs merge, are speaking.
+var SpecVersion = SpecV2
+
+// CheckSpecVersion returns an error if SpecVersion is not a supported
+// version of the Ignition config specification.
+func CheckSpecVersion() error {
+	switch SpecVersion {
+	case SpecV2, SpecV3:
+		return nil
+	default:
+		return errors.Errorf("unsupported Ignition spec version %q; must be %q or %q", SpecVersion, SpecV2, SpecV3)
+	}
+}
+
+// Marshal validates the Ignition config and returns it, written in
+// SpecVersion.
+func Marshal(config *igntypes.Config) ([]byte, error) {
+	if err := Validate(config); err != nil {
+		return nil, err
+	}
+	if SpecVersion != SpecV3 {
+		return json.Marshal(config)
+	}
+	translated, err := translateToV3(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to translate Ignition config to spec 3")
+	}
+	return json.Marshal(translated)
+}
+
+// Unmarshal returns the Ignition config in the data, which is written in
+// either spec 2.2 or spec 3.0.
+func Unmarshal(data []byte) (*igntypes.Config, error) {
+	version := &struct {
+		Ignition struct {
+			Version string `json:"version"`
+		} `json:"ignition"`
+	}{}
+	if err := json.Unmarshal(data, version); err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(version.Ignition.Version, "3.") {
+		config := &igntypes.Config{}
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, err
+		}
+		return config, nil
+	}
+
+	config := &v3Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	return translateFromV3(config)
+}
+
+// translateToV3 translates the spec 2.2 config to spec 3.0. Spec 3.0 drops
+// the filesystem of files, which must be on the root filesystem, does not
+// overwrite files by default, and merges instead of appending configs. Only
+// the parts of the spec which the installer generates are translated.
+func translateToV3(config *igntypes.Config) (*v3Config, error) {
+	if !reflect.DeepEqual(config.Networkd, igntypes.Networkd{}) {
+		return nil, errors.New("networkd units are not supported in spec 3")
+	}
+	if len(config.Passwd.Groups) > 0 {
+		return nil, errors.New("translating groups is not supported")
+	}
+	storage := config.Storage
+	storage.Files = nil
+	if !reflect.DeepEqual(storage, igntypes.Storage{}) {
+		return nil, errors.New("translating storage other than files is not supported")
+	}
+
+	translated := &v3Config{
+		Ignition: v3Ignition{
+			Version: "3.0.0",
+			Config: v3IgnitionConfig{
+				Replace: translateReferenceToV3(config.Ignition.Config.Replace),
+			},
+			Timeouts: v3Timeouts(config.Ignition.Timeouts),
+		},
+	}
+	for _, reference := range config.Ignition.Config.Append {
+		translated.Ignition.Config.Merge = append(translated.Ignition.Config.Merge, *translateReferenceToV3(&reference))
+	}
+	for _, ca := range config.Ignition.Security.TLS.CertificateAuthorities {
+		translated.Ignition.Security.TLS.CertificateAuthorities = append(translated.Ignition.Security.TLS.CertificateAuthorities, v3Resource{
+			Source:       ca.Source,
+			Verification: v3Verification(ca.Verification),
+		})
+	}
+
+	for _, user := range config.Passwd.Users {
+		rest := user
+		rest.Name, rest.PasswordHash, rest.SSHAuthorizedKeys = "", nil, nil
+		if !reflect.DeepEqual(rest, igntypes.PasswdUser{}) {
+			return nil, errors.Errorf("translating user %q: only the password hash and SSH keys are supported", user.Name)
+		}
+		translatedUser := v3PasswdUser{
+			Name:         user.Name,
+			PasswordHash: user.PasswordHash,
+		}
+		for _, key := range user.SSHAuthorizedKeys {
+			translatedUser.SSHAuthorizedKeys = append(translatedUser.SSHAuthorizedKeys, string(key))
+		}
+		translated.Passwd.Users = append(translated.Passwd.Users, translatedUser)
+	}
+
+	for _, file := range config.Storage.Files {
+		if file.Filesystem != "root" {
+			return nil, errors.Errorf("translating %s: only files on the root filesystem are supported", file.Path)
+		}
+		translatedFile := v3File{
+			Path:      file.Path,
+			Overwrite: file.Overwrite,
+			Mode:      file.Mode,
+		}
+		if translatedFile.Overwrite == nil {
+			overwrite := true
+			translatedFile.Overwrite = &overwrite
+		}
+		if file.User != nil {
+			translatedFile.User = &v3NodeUser{ID: file.User.ID, Name: file.User.Name}
+		}
+		if file.Group != nil {
+			translatedFile.Group = &v3NodeUser{ID: file.Group.ID, Name: file.Group.Name}
+		}
+		contents := v3FileContents{
+			Compression:  file.Contents.Compression,
+			Source:       file.Contents.Source,
+			Verification: v3Verification(file.Contents.Verification),
+		}
+		if file.Append {
+			translatedFile.Append = []v3FileContents{contents}
+		} else {
+			translatedFile.Contents = &contents
+		}
+		translated.Storage.Files = append(translated.Storage.Files, translatedFile)
+	}
+
+	for _, unit := range config.Systemd.Units {
+		translatedUnit := v3Unit{
+			Name:     unit.Name,
+			Contents: unit.Contents,
+			Enabled:  unit.Enabled,
+		}
+		if unit.Enable && translatedUnit.Enabled == nil {
+			enabled := true
+			translatedUnit.Enabled = &enabled
+		}
+		if unit.Mask {
+			mask := true
+			translatedUnit.Mask = &mask
+		}
+		for _, dropin := range unit.Dropins {
+			translatedUnit.Dropins = append(translatedUnit.Dropins, v3Dropin(dropin))
+		}
+		translated.Systemd.Units = append(translated.Systemd.Units, translatedUnit)
+	}
+
+	return translated, nil
+}
+
+// translateFromV3 translates the spec 3.0 config, as written by
+// translateToV3, back to spec 2.2.
+func translateFromV3(config *v3Config) (*igntypes.Config, error) {
+	translated := &igntypes.Config{
+		Ignition: igntypes.Ignition{
+			Version: igntypes.MaxVersion.String(),
+			Config: igntypes.IgnitionConfig{
+				Replace: translateReferenceFromV3(config.Ignition.Config.Replace),
+			},
+			Timeouts: igntypes.Timeouts(config.Ignition.Timeouts),
+		},
+	}
+	for _, reference := range config.Ignition.Config.Merge {
+		translated.Ignition.Config.Append = append(translated.Ignition.Config.Append, *translateReferenceFromV3(&reference))
+	}
+	for _, ca := range config.Ignition.Security.TLS.CertificateAuthorities {
+		translated.Ignition.Security.TLS.CertificateAuthorities = append(translated.Ignition.Security.TLS.CertificateAuthorities, igntypes.CaReference{
+			Source:       ca.Source,
+			Verification: igntypes.Verification(ca.Verification),
+		})
+	}
+
+	for _, user := range config.Passwd.Users {
+		translatedUser := igntypes.PasswdUser{
+			Name:         user.Name,
+			PasswordHash: user.PasswordHash,
+		}
+		for _, key := range user.SSHAuthorizedKeys {
+			translatedUser.SSHAuthorizedKeys = append(translatedUser.SSHAuthorizedKeys, igntypes.SSHAuthorizedKey(key))
+		}
+		translated.Passwd.Users = append(translated.Passwd.Users, translatedUser)
+	}
+
+	for _, file := range config.Storage.Files {
+		translatedFile := igntypes.File{
+			Node: igntypes.Node{
+				Filesystem: "root",
+				Path:       file.Path,
+				Overwrite:  file.Overwrite,
+			},
+			FileEmbedded1: igntypes.FileEmbedded1{
+				Mode: file.Mode,
+			},
+		}
+		if translatedFile.Overwrite != nil && *translatedFile.Overwrite {
+			translatedFile.Overwrite = nil
+		}
+		if file.User != nil {
+			translatedFile.User = &igntypes.NodeUser{ID: file.User.ID, Name: file.User.Name}
+		}
+		if file.Group != nil {
+			translatedFile.Group = &igntypes.NodeGroup{ID: file.Group.ID, Name: file.Group.Name}
+		}
+		contents := file.Contents
+		switch {
+		case len(file.Append) > 1:
+			return nil, errors.Errorf("translating %s: appending more than one source is not supported", file.Path)
+		case len(file.Append) == 1:
+			translatedFile.Append = true
+			contents = &file.Append[0]
+		}
+		if contents != nil {
+			translatedFile.Contents = igntypes.FileContents{
+				Compression:  contents.Compression,
+				Source:       contents.Source,
+				Verification: igntypes.Verification(contents.Verification),
+			}
+		}
+		translated.Storage.Files = append(translated.Storage.Files, translatedFile)
+	}
+
+	for _, unit := range config.Systemd.Units {
+		translatedUnit := igntypes.Unit{
+			Name:     unit.Name,
+			Contents: unit.Contents,
+			Enabled:  unit.Enabled,
+			Mask:     unit.Mask != nil && *unit.Mask,
+		}
+		for _, dropin := range unit.Dropins {
+			translatedUnit.Dropins = append(translatedUnit.Dropins, igntypes.SystemdDropin(dropin))
+		}
+		translated.Systemd.Units = append(translated.Systemd.Units, translatedUnit)
+	}
+
+	return translated, nil
+}
+
+func translateReferenceToV3(reference *igntypes.ConfigReference) *v3Resource {
+	if reference == nil {
+		return nil
+	}
+	return &v3Resource{
+		Source:       reference.Source,
+		Verification: v3Verification(reference.Verification),
+	}
+}
+
+func translateReferenceFromV3(reference *v3Resource) *igntypes.ConfigReference {
+	if reference == nil {
+		return nil
+	}
+	return &igntypes.ConfigReference{
+		Source:       reference.Source,
+		Verification: igntypes.Verification(reference.Verification),
+	}
+}
@@ -0,0 +1,119 @@
+package ignition
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/coreos/ignition/config/util"
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testConfig() *igntypes.Config {
+	config := &igntypes.Config{
+		Ignition: igntypes.Ignition{
+			Version: igntypes.MaxVersion.String(),
+			Config: igntypes.IgnitionConfig{
+				Append: []igntypes.ConfigReference{{Source: "https://test-cluster-api.test-domain:49500/config/master"}},
+			},
+			Security: igntypes.Security{
+				TLS: igntypes.TLS{
+					CertificateAuthorities: []igntypes.CaReference{{Source: "data:text/plain;charset=utf-8;base64,Y2E="}},
+				},
+			},
+		},
+		Passwd: igntypes.Passwd{
+			Users: []igntypes.PasswdUser{{Name: "core", SSHAuthorizedKeys: []igntypes.SSHAuthorizedKey{"ssh-ed25519 AAAA"}}},
+		},
+		Systemd: igntypes.Systemd{
+			Units: []igntypes.Unit{
+				{Name: "bootkube.service", Contents: "[Service]\n"},
+				{Name: "kubelet.service", Enabled: util.BoolToPtr(true), Dropins: []igntypes.SystemdDropin{{Name: "10-env.conf", Contents: "[Service]\n"}}},
+			},
+		},
+	}
+	config.Storage.Files = append(config.Storage.Files, FileFromString("/opt/openshift/auth/kubeconfig", "root", 0600, "kubeconfig"))
+	return config
+}
+
+func TestTranslateToV3(t *testing.T) {
+	translated, err := translateToV3(testConfig())
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(translated)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "ignition": {
+    "config": {"merge": [{"source": "https://test-cluster-api.test-domain:49500/config/master", "verification": {}}]},
+    "security": {"tls": {"certificateAuthorities": [{"source": "data:text/plain;charset=utf-8;base64,Y2E=", "verification": {}}]}},
+    "timeouts": {},
+    "version": "3.0.0"
+  },
+  "passwd": {"users": [{"name": "core", "sshAuthorizedKeys": ["ssh-ed25519 AAAA"]}]},
+  "storage": {"files": [{
+    "path": "/opt/openshift/auth/kubeconfig",
+    "overwrite": true,
+    "user": {"name": "root"},
+    "contents": {"source": "data:text/plain;charset=utf-8;base64,a3ViZWNvbmZpZw==", "verification": {}},
+    "mode": 384
+  }]},
+  "systemd": {"units": [
+    {"name": "bootkube.service", "contents": "[Service]\n"},
+    {"name": "kubelet.service", "enabled": true, "dropins": [{"name": "10-env.conf", "contents": "[Service]\n"}]}
+  ]}
+}`, string(data))
+}
+
+func TestTranslateToV3Unsupported(t *testing.T) {
+	cases := []struct {
+		name     string
+		edit     func(*igntypes.Config)
+		expected string
+	}{
+		{
+			name: "non-root filesystem",
+			edit: func(c *igntypes.Config) {
+				c.Storage.Files[0].Filesystem = "oem"
+			},
+			expected: `translating /opt/openshift/auth/kubeconfig: only files on the root filesystem are supported`,
+		},
+		{
+			name: "directories",
+			edit: func(c *igntypes.Config) {
+				c.Storage.Directories = []igntypes.Directory{{Node: igntypes.Node{Filesystem: "root", Path: "/opt"}}}
+			},
+			expected: `translating storage other than files is not supported`,
+		},
+		{
+			name: "user groups",
+			edit: func(c *igntypes.Config) {
+				c.Passwd.Users[0].Groups = []igntypes.Group{"wheel"}
+			},
+			expected: `translating user "core": only the password hash and SSH keys are supported`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := testConfig()
+			tc.edit(config)
+			_, err := translateToV3(config)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	defer func(version string) { SpecVersion = version }(SpecVersion)
+
+	for _, version := range []string{SpecV2, SpecV3} {
+		t.Run(version, func(t *testing.T) {
+			SpecVersion = version
+			data, err := Marshal(testConfig())
+			assert.NoError(t, err)
+
+			config, err := Unmarshal(data)
+			assert.NoError(t, err)
+			assert.Equal(t, testConfig(), config)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package ignition
+
+// The types of the parts of the Ignition config spec 3.0 which the
+// installer generates. The spec 3 types are not vendored.
+
+type v3Config struct {
+	Ignition v3Ignition `json:"ignition"`
+	Passwd   v3Passwd   `json:"passwd,omitempty"`
+	Storage  v3Storage  `json:"storage,omitempty"`
+	Systemd  v3Systemd  `json:"systemd,omitempty"`
+}
+
+type v3Ignition struct {
+	Config   v3IgnitionConfig `json:"config,omitempty"`
+	Security v3Security       `json:"security,omitempty"`
+	Timeouts v3Timeouts       `json:"timeouts,omitempty"`
+	Version  string           `json:"version"`
+}
+
+type v3IgnitionConfig struct {
+	Merge   []v3Resource `json:"merge,omitempty"`
+	Replace *v3Resource  `json:"replace,omitempty"`
+}
+
+type v3Resource struct {
+	Source       string         `json:"source,omitempty"`
+	Verification v3Verification `json:"verification,omitempty"`
+}
+
+type v3Verification struct {
+	Hash *string `json:"hash,omitempty"`
+}
+
+type v3Security struct {
+	TLS v3TLS `json:"tls,omitempty"`
+}
+
+type v3TLS struct {
+	CertificateAuthorities []v3Resource `json:"certificateAuthorities,omitempty"`
+}
+
+type v3Timeouts struct {
+	HTTPResponseHeaders *int `json:"httpResponseHeaders,omitempty"`
+	HTTPTotal           *int `json:"httpTotal,omitempty"`
+}
+
+type v3Passwd struct {
+	Users []v3PasswdUser `json:"users,omitempty"`
+}
+
+type v3PasswdUser struct {
+	Name              string   `json:"name"`
+	PasswordHash      *string  `json:"passwordHash,omitempty"`
+	SSHAuthorizedKeys []string `json:"sshAuthorizedKeys,omitempty"`
+}
+
+type v3Storage struct {
+	Files []v3File `json:"files,omitempty"`
+}
+
+type v3File struct {
+	Path      string           `json:"path"`
+	Overwrite *bool            `json:"overwrite,omitempty"`
+	User      *v3NodeUser      `json:"user,omitempty"`
+	Group     *v3NodeUser      `json:"group,omitempty"`
+	Append    []v3FileContents `json:"append,omitempty"`
+	Contents  *v3FileContents  `json:"contents,omitempty"`
+	Mode      *int             `json:"mode,omitempty"`
+}
+
+type v3NodeUser struct {
+	ID   *int   `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type v3FileContents struct {
+	Compression  string         `json:"compression,omitempty"`
+	Source       string         `json:"source,omitempty"`
+	Verification v3Verification `json:"verification,omitempty"`
+}
+
+type v3Systemd struct {
+	Units []v3Unit `json:"units,omitempty"`
+}
+
+type v3Unit struct {
+	Name     string     `json:"name"`
+	Enabled  *bool      `json:"enabled,omitempty"`
+	Mask     *bool      `json:"mask,omitempty"`
+	Contents string     `json:"contents,omitempty"`
+	Dropins  []v3Dropin `json:"dropins,omitempty"`
+}
+
+type v3Dropin struct {
+	Contents string `json:"contents,omitempty"`
+	Name     string `json:"name"`
+}
@@ -0,0 +1,160 @@
+package ignition
+
+import (
+	"fmt"
+	"strings"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/coreos/ignition/config/validate/report"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// validator collects the problems of an Ignition config, with the location
+// of the part of the config which each one concerns.
+type validator struct {
+	errors   []string
+	warnings []string
+}
+
+func (v *validator) add(location string, r report.Report) {
+	for _, entry := range r.Entries {
+		problem := fmt.Sprintf("%s: %s", location, entry.Message)
+		if entry.Kind == report.EntryError {
+			v.errors = append(v.errors, problem)
+		} else {
+			v.warnings = append(v.warnings, problem)
+		}
+	}
+}
+
+func (v *validator) addError(location string, format string, args ...interface{}) {
+	v.errors = append(v.errors, fmt.Sprintf("%s: %s", location, fmt.Sprintf(format, args...)))
+}
+
+// Validate checks the Ignition config against the spec 2.2 rules, and for
+// mistakes which the spec allows but which break the first boot of the
+// machines, such as files written twice.
+// It returns an error listing every problem with its location in the config,
+// such as storage.files[3] (/etc/motd), and logs the warnings.
+func Validate(config *igntypes.Config) error {
+	v := &validator{}
+
+	v.add("config", config.Validate())
+	v.add("ignition.version", config.Ignition.Validate())
+	for i, reference := range config.Ignition.Config.Append {
+		v.add(fmt.Sprintf("ignition.config.append[%d]", i), reference.ValidateSource())
+	}
+	if reference := config.Ignition.Config.Replace; reference != nil {
+		v.add("ignition.config.replace", reference.ValidateSource())
+	}
+	for i, ca := range config.Ignition.Security.TLS.CertificateAuthorities {
+		v.add(fmt.Sprintf("ignition.security.tls.certificateAuthorities[%d]", i), ca.ValidateSource())
+	}
+
+	for i, disk := range config.Storage.Disks {
+		location := fmt.Sprintf("storage.disks[%d] (%s)", i, disk.Device)
+		v.add(location, disk.Validate())
+		v.add(location, disk.ValidateDevice())
+		v.add(location, disk.ValidatePartitions())
+		for j, partition := range disk.Partitions {
+			location := fmt.Sprintf("%s.partitions[%d]", location, j)
+			v.add(location, partition.ValidateLabel())
+			v.add(location, partition.ValidateTypeGUID())
+			v.add(location, partition.ValidateGUID())
+		}
+	}
+	for i, raid := range config.Storage.Raid {
+		location := fmt.Sprintf("storage.raid[%d] (%s)", i, raid.Name)
+		v.add(location, raid.ValidateLevel())
+		v.add(location, raid.ValidateDevices())
+	}
+	for i, filesystem := range config.Storage.Filesystems {
+		location := fmt.Sprintf("storage.filesystems[%d] (%s)", i, filesystem.Name)
+		v.add(location, filesystem.Validate())
+		v.add(location, filesystem.ValidatePath())
+		if mount := filesystem.Mount; mount != nil {
+			v.add(location, mount.Validate())
+			v.add(location, mount.ValidateDevice())
+			v.add(location, mount.ValidateLabel())
+		}
+	}
+
+	nodes := map[string]string{}
+	node := func(location string, n igntypes.Node) {
+		v.add(location, n.ValidateFilesystem())
+		v.add(location, n.ValidatePath())
+		if n.User != nil {
+			v.add(location, n.User.Validate())
+		}
+		if n.Group != nil {
+			v.add(location, n.Group.Validate())
+		}
+		key := n.Filesystem + ":" + n.Path
+		if previous, ok := nodes[key]; ok {
+			v.addError(location, "path is also written by %s", previous)
+		} else {
+			nodes[key] = location
+		}
+	}
+	for i, file := range config.Storage.Files {
+		location := fmt.Sprintf("storage.files[%d] (%s)", i, file.Path)
+		node(location, file.Node)
+		v.add(location, file.Validate())
+		v.add(location, file.ValidateMode())
+		v.add(location, file.Contents.ValidateCompression())
+		v.add(location, file.Contents.ValidateSource())
+		v.add(location, file.Contents.Verification.Validate())
+	}
+	for i, directory := range config.Storage.Directories {
+		location := fmt.Sprintf("storage.directories[%d] (%s)", i, directory.Path)
+		node(location, directory.Node)
+		v.add(location, directory.ValidateMode())
+	}
+	for i, link := range config.Storage.Links {
+		location := fmt.Sprintf("storage.links[%d] (%s)", i, link.Path)
+		node(location, link.Node)
+		v.add(location, link.ValidateTarget())
+	}
+
+	units := map[string]bool{}
+	for i, unit := range config.Systemd.Units {
+		location := fmt.Sprintf("systemd.units[%d] (%s)", i, unit.Name)
+		v.add(location, unit.ValidateName())
+		v.add(location, unit.ValidateContents())
+		if units[unit.Name] {
+			v.addError(location, "duplicate unit")
+		}
+		units[unit.Name] = true
+		dropins := map[string]bool{}
+		for j, dropin := range unit.Dropins {
+			location := fmt.Sprintf("%s.dropins[%d] (%s)", location, j, dropin.Name)
+			v.add(location, dropin.Validate())
+			if dropins[dropin.Name] {
+				v.addError(location, "duplicate drop-in")
+			}
+			dropins[dropin.Name] = true
+		}
+	}
+	for i, unit := range config.Networkd.Units {
+		v.add(fmt.Sprintf("networkd.units[%d] (%s)", i, unit.Name), unit.Validate())
+	}
+
+	users := map[string]bool{}
+	for i, user := range config.Passwd.Users {
+		location := fmt.Sprintf("passwd.users[%d] (%s)", i, user.Name)
+		v.add(location, user.Validate())
+		if users[user.Name] {
+			v.addError(location, "duplicate user")
+		}
+		users[user.Name] = true
+	}
+
+	for _, warning := range v.warnings {
+		logrus.Warnf("Ignition config: %s", warning)
+	}
+	if len(v.errors) > 0 {
+		return errors.Errorf("invalid Ignition config: %s", strings.Join(v.errors, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package ignition
+
+import (
+	"testing"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name          string
+		config        func(*igntypes.Config)
+		expectedError string
+	}{
+		{
+			name:   "valid",
+			config: func(*igntypes.Config) {},
+		},
+		{
+			name: "unsupported version",
+			config: func(c *igntypes.Config) {
+				c.Ignition.Version = "3.0.0"
+			},
+			expectedError: `^invalid Ignition config: ignition\.version: incorrect config version \(too new\)$`,
+		},
+		{
+			name: "duplicate file",
+			config: func(c *igntypes.Config) {
+				c.Storage.Files = append(c.Storage.Files, FileFromString("/etc/motd", "root", 0644, "other"))
+			},
+			expectedError: `^invalid Ignition config: storage\.files\[1\] \(/etc/motd\): path is also written by storage\.files\[0\] \(/etc/motd\)$`,
+		},
+		{
+			name: "directory at the path of a file",
+			config: func(c *igntypes.Config) {
+				c.Storage.Directories = append(c.Storage.Directories, igntypes.Directory{Node: igntypes.Node{Filesystem: "root", Path: "/etc/motd"}})
+			},
+			expectedError: `^invalid Ignition config: storage\.directories\[0\] \(/etc/motd\): path is also written by storage\.files\[0\] \(/etc/motd\)$`,
+		},
+		{
+			name: "relative path and bad data URL",
+			config: func(c *igntypes.Config) {
+				file := FileFromString("etc/issue", "root", 0644, "")
+				file.Contents.Source = "data:text/plain;base64,!!!"
+				c.Storage.Files = append(c.Storage.Files, file)
+			},
+			expectedError: `^invalid Ignition config: storage\.files\[1\] \(etc/issue\): path not absolute, storage\.files\[1\] \(etc/issue\): invalid data character$`,
+		},
+		{
+			name: "bad unit",
+			config: func(c *igntypes.Config) {
+				c.Systemd.Units = append(c.Systemd.Units,
+					igntypes.Unit{Name: "kubelet.service", Dropins: []igntypes.SystemdDropin{{Name: "10-debug"}}},
+					igntypes.Unit{Name: "agent", Contents: "[Service"},
+				)
+			},
+			expectedError: `^invalid Ignition config: systemd\.units\[1\] \(kubelet\.service\): duplicate unit, systemd\.units\[1\] \(kubelet\.service\)\.dropins\[0\] \(10-debug\): invalid systemd drop-in extension, systemd\.units\[2\] \(agent\): invalid systemd unit extension, systemd\.units\[2\] \(agent\): invalid unit content: unable to find end of section$`,
+		},
+		{
+			name: "duplicate user",
+			config: func(c *igntypes.Config) {
+				c.Passwd.Users = []igntypes.PasswdUser{{Name: "core"}, {Name: "core"}}
+			},
+			expectedError: `^invalid Ignition config: passwd\.users\[1\] \(core\): duplicate user$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &igntypes.Config{
+				Ignition: igntypes.Ignition{Version: igntypes.MaxVersion.String()},
+				Storage: igntypes.Storage{
+					Files: []igntypes.File{FileFromString("/etc/motd", "root", 0644, "hello")},
+				},
+				Systemd: igntypes.Systemd{
+					Units: []igntypes.Unit{{Name: "kubelet.service", Contents: "[Service]\nExecStart=/usr/bin/kubelet\n"}},
+				},
+			}
+			tc.config(config)
+			err := Validate(config)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expectedError, err)
+			}
+		})
+	}
+}
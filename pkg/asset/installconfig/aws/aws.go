@@ -16,29 +16,20 @@ import (
 	"github.com/sirupsen/logrus"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/redact"
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/aws/validation"
 )
 
 // Platform collects AWS-specific configuration.
 func Platform() (*aws.Platform, error) {
-	longRegions := make([]string, 0, len(validation.Regions))
-	shortRegions := make([]string, 0, len(validation.Regions))
-	for id, location := range validation.Regions {
-		longRegions = append(longRegions, fmt.Sprintf("%s (%s)", id, location))
-		shortRegions = append(shortRegions, id)
-	}
-	regionTransform := survey.TransformString(func(s string) string {
-		return strings.SplitN(s, " ", 2)[0]
-	})
-
 	defaultRegion := "us-east-1"
 	_, ok := validation.Regions[defaultRegion]
 	if !ok {
 		panic(fmt.Sprintf("installer bug: invalid default AWS region %q", defaultRegion))
 	}
 
-	ssn, err := getSession()
+	ssn, err := GetSession()
 	if err != nil {
 		return nil, err
 	}
@@ -53,16 +44,32 @@ func Platform() (*aws.Platform, error) {
 		}
 	}
 
+	availableRegions := regions(ssn, defaultRegion)
+	longRegions := make([]string, 0, len(availableRegions))
+	shortRegions := make([]string, 0, len(availableRegions))
+	for id, location := range availableRegions {
+		longRegions = append(longRegions, fmt.Sprintf("%s (%s)", id, location))
+		shortRegions = append(shortRegions, id)
+	}
+	regionTransform := survey.TransformString(func(s string) string {
+		return strings.SplitN(s, " ", 2)[0]
+	})
+
 	sort.Strings(longRegions)
 	sort.Strings(shortRegions)
 
+	defaultOption := fmt.Sprintf("%s (%s)", defaultRegion, validation.Regions[defaultRegion])
+	if _, ok := availableRegions[defaultRegion]; !ok {
+		defaultOption = longRegions[0]
+	}
+
 	var region string
 	err = survey.Ask([]*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Region",
 				Help:    "The AWS region to be used for installation.",
-				Default: fmt.Sprintf("%s (%s)", defaultRegion, validation.Regions[defaultRegion]),
+				Default: defaultOption,
 				Options: longRegions,
 			},
 			Validate: survey.ComposeValidators(survey.Required, func(ans interface{}) error {
@@ -85,14 +92,11 @@ func Platform() (*aws.Platform, error) {
 	}, nil
 }
 
-func getSession() (*session.Session, error) {
-	ssn := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-	}))
-	ssn.Config.Credentials = credentials.NewChainCredentials([]credentials.Provider{
-		&credentials.EnvProvider{},
-		&credentials.SharedCredentialsProvider{},
-	})
+// GetSession returns an AWS session with the credentials of the
+// environment or of the shared credentials file, and asks for them if
+// there are none.
+func GetSession() (*session.Session, error) {
+	ssn := newSession()
 	_, err := ssn.Config.Credentials.Get()
 	if err == credentials.ErrNoValidProvidersFoundInChain {
 		err = getCredentials()
@@ -101,9 +105,26 @@ func getSession() (*session.Session, error) {
 		}
 	}
 
+	if creds, err := ssn.Config.Credentials.Get(); err == nil {
+		redact.AddSecret(creds.SecretAccessKey)
+		redact.AddSecret(creds.SessionToken)
+	}
 	return ssn, nil
 }
 
+// newSession returns an AWS session with the credentials of the
+// environment or of the shared credentials file, without asking for them.
+func newSession() *session.Session {
+	ssn := session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	ssn.Config.Credentials = credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvProvider{},
+		&credentials.SharedCredentialsProvider{},
+	})
+	return ssn
+}
+
 func getCredentials() error {
 	var keyID string
 	err := survey.Ask([]*survey.Question{
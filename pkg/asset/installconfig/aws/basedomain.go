@@ -22,7 +22,7 @@ func IsForbidden(err error) bool {
 // GetBaseDomain returns a base domain chosen from among the account's
 // public routes.
 func GetBaseDomain() (string, error) {
-	session, err := getSession()
+	session, err := GetSession()
 	if err != nil {
 		return "", err
 	}
@@ -78,3 +78,32 @@ func GetBaseDomain() (string, error) {
 
 	return domain, nil
 }
+
+// GetPublicZone returns the ID of the public Route 53 hosted zone of the
+// base domain, in which the installer creates the records of the cluster.
+func GetPublicZone(name string) (string, error) {
+	session, err := GetSession()
+	if err != nil {
+		return "", err
+	}
+
+	client := route53.New(session)
+	fqdn := strings.TrimSuffix(name, ".") + "."
+	var id string
+	err = client.ListHostedZonesPages(&route53.ListHostedZonesInput{}, func(response *route53.ListHostedZonesOutput, lastPage bool) bool {
+		for _, zone := range response.HostedZones {
+			if *zone.Name == fqdn && !*zone.Config.PrivateZone {
+				id = strings.TrimPrefix(*zone.Id, "/hostedzone/")
+				return false
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "list hosted zones")
+	}
+	if id == "" {
+		return "", errors.Errorf("no public Route 53 hosted zone found for %s", name)
+	}
+	return id, nil
+}
@@ -0,0 +1,86 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+)
+
+// PublicZoneNameServers returns the name servers of the delegation set of
+// the public Route 53 hosted zone, which must be those to which its domain
+// is delegated for the zone to be authoritative.
+func PublicZoneNameServers(zoneID string) ([]string, error) {
+	session, err := GetSession()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := route53.New(session).GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "get hosted zone %s", zoneID)
+	}
+	if response.DelegationSet == nil {
+		return nil, nil
+	}
+	return aws.StringValueSlice(response.DelegationSet.NameServers), nil
+}
+
+// ExistingPublicRecords returns the names of the records of the public
+// Route 53 hosted zone which are one of the given names, or in one of
+// the given subdomains.
+func ExistingPublicRecords(zoneID string, names []string, subdomains []string) ([]string, error) {
+	session, err := GetSession()
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []string
+	found := map[string]bool{}
+	err = route53.New(session).ListResourceRecordSetsPages(
+		&route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(zoneID)},
+		func(response *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+			for _, name := range collidingRecords(response.ResourceRecordSets, names, subdomains) {
+				if !found[name] {
+					found[name] = true
+					existing = append(existing, name)
+				}
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list the records of hosted zone %s", zoneID)
+	}
+	return existing, nil
+}
+
+// collidingRecords returns the fully qualified, lowercase names of the
+// record sets which are one of the given names, or in one of the given
+// subdomains.
+func collidingRecords(recordSets []*route53.ResourceRecordSet, names []string, subdomains []string) []string {
+	fqdn := func(name string) string {
+		return strings.ToLower(strings.TrimSuffix(name, ".") + ".")
+	}
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[fqdn(name)] = true
+	}
+
+	var colliding []string
+	for _, recordSet := range recordSets {
+		// Route 53 escapes the asterisk of wildcard records.
+		name := strings.ToLower(strings.Replace(aws.StringValue(recordSet.Name), `\052`, "*", -1))
+		collides := wanted[name]
+		for _, subdomain := range subdomains {
+			if name == fqdn(subdomain) || strings.HasSuffix(name, "."+fqdn(subdomain)) {
+				collides = true
+			}
+		}
+		if collides {
+			colliding = append(colliding, name)
+		}
+	}
+	return colliding
+}
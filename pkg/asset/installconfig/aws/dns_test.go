@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollidingRecords(t *testing.T) {
+	recordSets := []*route53.ResourceRecordSet{
+		{Name: awssdk.String("example.com.")},
+		{Name: awssdk.String("test-cluster-api.example.com.")},
+		{Name: awssdk.String(`\052.apps.test-cluster.example.com.`)},
+		{Name: awssdk.String("Console.Apps.Test-Cluster.example.com.")},
+		{Name: awssdk.String("apps.test-cluster.example.com.")},
+		{Name: awssdk.String("apps.test-cluster-2.example.com.")},
+		{Name: awssdk.String("test-cluster-api.example.com.other.")},
+	}
+
+	cases := []struct {
+		name       string
+		names      []string
+		subdomains []string
+		expected   []string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:     "names",
+			names:    []string{"test-cluster-api.example.com", "missing.example.com."},
+			expected: []string{"test-cluster-api.example.com."},
+		},
+		{
+			name:       "subdomains",
+			subdomains: []string{"apps.test-cluster.example.com"},
+			expected: []string{
+				"*.apps.test-cluster.example.com.",
+				"console.apps.test-cluster.example.com.",
+				"apps.test-cluster.example.com.",
+			},
+		},
+		{
+			name:       "names and subdomains",
+			names:      []string{"TEST-CLUSTER-API.example.com."},
+			subdomains: []string{"apps.test-cluster-2.example.com."},
+			expected: []string{
+				"test-cluster-api.example.com.",
+				"apps.test-cluster-2.example.com.",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, collidingRecords(recordSets, tc.names, tc.subdomains))
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+)
+
+// PermissionGroup is a set of IAM actions which a component of the
+// cluster performs with the installer's credentials.
+type PermissionGroup struct {
+	// Name is the name of the component, e.g. "installer".
+	Name string
+
+	// Actions are the IAM actions, e.g. "ec2:RunInstances".
+	Actions []string
+}
+
+// PermissionGroups are the IAM actions which the installer performs to
+// create and destroy the cluster, and which the operators perform with the
+// credentials the installer passes to the cluster.
+var PermissionGroups = []PermissionGroup{
+	{
+		Name: "installer",
+		Actions: []string{
+			"ec2:AllocateAddress",
+			"ec2:AssociateAddress",
+			"ec2:AssociateRouteTable",
+			"ec2:AttachInternetGateway",
+			"ec2:AuthorizeSecurityGroupEgress",
+			"ec2:AuthorizeSecurityGroupIngress",
+			"ec2:CopyImage",
+			"ec2:CreateInternetGateway",
+			"ec2:CreateNatGateway",
+			"ec2:CreateRoute",
+			"ec2:CreateRouteTable",
+			"ec2:CreateSecurityGroup",
+			"ec2:CreateSubnet",
+			"ec2:CreateTags",
+			"ec2:CreateVpc",
+			"ec2:CreateVpcEndpoint",
+			"ec2:DeleteInternetGateway",
+			"ec2:DeleteNatGateway",
+			"ec2:DeleteRoute",
+			"ec2:DeleteRouteTable",
+			"ec2:DeleteSecurityGroup",
+			"ec2:DeleteSnapshot",
+			"ec2:DeleteSubnet",
+			"ec2:DeleteVpc",
+			"ec2:DeleteVpcEndpoints",
+			"ec2:DeregisterImage",
+			"ec2:DescribeAvailabilityZones",
+			"ec2:DescribeImages",
+			"ec2:DescribeInstances",
+			"ec2:DescribeInternetGateways",
+			"ec2:DescribeNatGateways",
+			"ec2:DescribeRegions",
+			"ec2:DescribeRouteTables",
+			"ec2:DescribeSecurityGroups",
+			"ec2:DescribeSubnets",
+			"ec2:DescribeVpcs",
+			"ec2:DisassociateRouteTable",
+			"ec2:ModifyVpcAttribute",
+			"ec2:ReleaseAddress",
+			"ec2:ReplaceRouteTableAssociation",
+			"ec2:RevokeSecurityGroupEgress",
+			"ec2:RevokeSecurityGroupIngress",
+			"ec2:RunInstances",
+			"ec2:TerminateInstances",
+			"elasticloadbalancing:AddTags",
+			"elasticloadbalancing:CreateListener",
+			"elasticloadbalancing:CreateLoadBalancer",
+			"elasticloadbalancing:CreateTargetGroup",
+			"elasticloadbalancing:DeleteLoadBalancer",
+			"elasticloadbalancing:DeleteTargetGroup",
+			"elasticloadbalancing:DescribeLoadBalancers",
+			"elasticloadbalancing:DescribeTargetGroups",
+			"elasticloadbalancing:ModifyLoadBalancerAttributes",
+			"elasticloadbalancing:ModifyTargetGroupAttributes",
+			"elasticloadbalancing:RegisterTargets",
+			"iam:AddRoleToInstanceProfile",
+			"iam:CreateInstanceProfile",
+			"iam:CreateRole",
+			"iam:DeleteInstanceProfile",
+			"iam:DeleteRole",
+			"iam:DeleteRolePolicy",
+			"iam:GetRole",
+			"iam:PassRole",
+			"iam:PutRolePolicy",
+			"iam:RemoveRoleFromInstanceProfile",
+			"route53:ChangeResourceRecordSets",
+			"route53:CreateHostedZone",
+			"route53:DeleteHostedZone",
+			"route53:GetHostedZone",
+			"route53:ListHostedZones",
+			"route53:ListResourceRecordSets",
+			"s3:CreateBucket",
+			"s3:DeleteBucket",
+			"s3:DeleteObject",
+			"s3:PutObject",
+			"tag:GetResources",
+		},
+	},
+	{
+		Name: "machine-api-operator",
+		Actions: []string{
+			"ec2:CreateTags",
+			"ec2:DescribeImages",
+			"ec2:DescribeInstances",
+			"ec2:DescribeSecurityGroups",
+			"ec2:DescribeSubnets",
+			"ec2:RunInstances",
+			"ec2:TerminateInstances",
+			"elasticloadbalancing:RegisterTargets",
+			"iam:PassRole",
+		},
+	},
+	{
+		Name: "cluster-image-registry-operator",
+		Actions: []string{
+			"s3:CreateBucket",
+			"s3:DeleteBucket",
+			"s3:GetObject",
+			"s3:ListBucket",
+			"s3:PutBucketTagging",
+			"s3:PutObject",
+		},
+	},
+	{
+		Name: "cluster-ingress-operator",
+		Actions: []string{
+			"elasticloadbalancing:DescribeLoadBalancers",
+			"route53:ChangeResourceRecordSets",
+			"route53:ListHostedZones",
+			"tag:GetResources",
+		},
+	},
+	{
+		Name: "cloud-credential-operator",
+		Actions: []string{
+			"iam:CreateAccessKey",
+			"iam:CreateUser",
+			"iam:DeleteAccessKey",
+			"iam:DeleteUser",
+			"iam:DeleteUserPolicy",
+			"iam:GetUser",
+			"iam:PutUserPolicy",
+			"iam:TagUser",
+		},
+	},
+}
+
+// ErrSimulationUnavailable is returned by MissingPermissions when the
+// policies of the credentials cannot be simulated, because they are the
+// account's root credentials, which are allowed everything, or because
+// they are not allowed to simulate their own policies.
+var ErrSimulationUnavailable = errors.New("the policies of the credentials cannot be simulated")
+
+// MissingPermissions simulates the IAM policies of the principal of the
+// credentials with iam:SimulatePrincipalPolicy, and returns the actions of
+// PermissionGroups which they do not allow, by the name of their group.
+func MissingPermissions() (map[string][]string, error) {
+	session, err := GetSession()
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := sts.New(session).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "get the identity of the credentials")
+	}
+	client := iam.New(session)
+	principal, err := principalARN(client, aws.StringValue(identity.Arn))
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []string
+	seen := map[string]bool{}
+	for _, group := range PermissionGroups {
+		for _, action := range group.Actions {
+			if !seen[action] {
+				seen[action] = true
+				actions = append(actions, action)
+			}
+		}
+	}
+
+	denied := map[string]bool{}
+	// The simulation evaluates at most 128 actions per request.
+	for start := 0; start < len(actions); start += 128 {
+		end := start + 128
+		if end > len(actions) {
+			end = len(actions)
+		}
+		err = client.SimulatePrincipalPolicyPages(
+			&iam.SimulatePrincipalPolicyInput{
+				PolicySourceArn: aws.String(principal),
+				ActionNames:     aws.StringSlice(actions[start:end]),
+			},
+			func(response *iam.SimulatePolicyResponse, lastPage bool) bool {
+				for _, result := range response.EvaluationResults {
+					if aws.StringValue(result.EvalDecision) != iam.PolicyEvaluationDecisionTypeAllowed {
+						denied[aws.StringValue(result.EvalActionName)] = true
+					}
+				}
+				return !lastPage
+			},
+		)
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "AccessDenied" {
+				return nil, errors.Wrapf(ErrSimulationUnavailable, "%s is not allowed iam:SimulatePrincipalPolicy", principal)
+			}
+			return nil, errors.Wrapf(err, "simulate the policies of %s", principal)
+		}
+	}
+
+	missing := map[string][]string{}
+	for _, group := range PermissionGroups {
+		for _, action := range group.Actions {
+			if denied[action] {
+				missing[group.Name] = append(missing[group.Name], action)
+			}
+		}
+	}
+	return missing, nil
+}
+
+// principalARN returns the ARN of the IAM user or role whose policies
+// apply to the caller with the given ARN. The sessions of assumed roles
+// are simulated as their role, whose ARN includes a path which the ARN of
+// the session does not.
+func principalARN(client *iam.IAM, caller string) (string, error) {
+	parsed, err := arn.Parse(caller)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse the ARN of the caller %q", caller)
+	}
+	switch {
+	case parsed.Service == "iam" && parsed.Resource == "root":
+		return "", errors.Wrapf(ErrSimulationUnavailable, "%s is the root user of the account", caller)
+	case parsed.Service == "sts" && strings.HasPrefix(parsed.Resource, "assumed-role/"):
+		name := strings.Split(parsed.Resource, "/")[1]
+		role, err := client.GetRole(&iam.GetRoleInput{RoleName: aws.String(name)})
+		if err != nil {
+			return "", errors.Wrapf(err, "get the role %s of the caller", name)
+		}
+		return aws.StringValue(role.Role.Arn), nil
+	case parsed.Service == "iam":
+		return caller, nil
+	}
+	return "", errors.Wrapf(ErrSimulationUnavailable, "%s is not an IAM user or role", caller)
+}
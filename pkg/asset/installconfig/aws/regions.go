@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/types/aws/validation"
+)
+
+// regions returns the regions which are available to the session's
+// account and supported by the installer, keyed by short name with their
+// long names as values. If the regions cannot be listed, all the
+// supported regions are returned.
+func regions(ssn *session.Session, defaultRegion string) map[string]string {
+	available, err := describeRegions(ssn, defaultRegion)
+	if err != nil {
+		logrus.Warnf("Failed to list the AWS regions available to your account, showing all supported regions: %v", err)
+		return validation.Regions
+	}
+
+	regions := make(map[string]string, len(available))
+	for _, region := range available {
+		location, ok := validation.Regions[region]
+		if !ok {
+			logrus.Debugf("Skipping AWS region %q, which is not supported by the installer", region)
+			continue
+		}
+		regions[region] = location
+	}
+	if len(regions) == 0 {
+		logrus.Warn("None of the AWS regions available to your account are supported, showing all supported regions")
+		return validation.Regions
+	}
+	return regions
+}
+
+// describeRegions lists the names of the regions which are available to
+// the session's account, using the EC2 endpoint in endpointRegion.
+func describeRegions(ssn *session.Session, endpointRegion string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	client := ec2.New(ssn, awssdk.NewConfig().WithRegion(endpointRegion))
+	output, err := client.DescribeRegionsWithContext(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "describe regions")
+	}
+
+	regions := make([]string, 0, len(output.Regions))
+	for _, region := range output.Regions {
+		regions = append(regions, awssdk.StringValue(region.RegionName))
+	}
+	return regions, nil
+}
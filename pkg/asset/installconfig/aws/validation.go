@@ -0,0 +1,176 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/rhcos"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// ValidateCloud checks the region of the install config against the
+// regions which are available to the account, and the zones of its machine
+// pools against the availability zones of the region, and that the AMIs of
+// its machine pools are available and for the architecture of the
+// installer's RHCOS build. It does not ask for
+// credentials, and returns an error instead of the field errors if there
+// are none or if the EC2 API cannot be reached, in which case only the
+// embedded list of regions is checked, by ValidateInstallConfig.
+func ValidateCloud(config *types.InstallConfig) (field.ErrorList, error) {
+	ssn := newSession()
+	if _, err := ssn.Config.Credentials.Get(); err != nil {
+		return nil, errors.Wrap(err, "no credentials")
+	}
+
+	region := config.Platform.AWS.Region
+	regions, err := describeRegions(ssn, region)
+	if err != nil {
+		return nil, err
+	}
+	client := ec2.New(ssn, awssdk.NewConfig().WithRegion(region))
+	zones, err := describeAvailabilityZones(client)
+	if err != nil {
+		return nil, err
+	}
+	allErrs := validateRegionAndZones(config, regions, zones)
+	if len(allErrs) > 0 {
+		return allErrs, nil
+	}
+
+	architectures, err := describeImageArchitectures(client, poolImages(config))
+	if err != nil {
+		return nil, err
+	}
+	return validateImageArchitectures(config, architectures), nil
+}
+
+// validateRegionAndZones checks the region of the install config against
+// the available regions, and the zones of its machine pools against the
+// available zones of the region.
+func validateRegionAndZones(config *types.InstallConfig, regions, zones []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	platform := config.Platform.AWS
+	if !contains(regions, platform.Region) {
+		sort.Strings(regions)
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("platform", "aws", "region"), platform.Region, regions))
+		// The zones of another region say nothing about the zones.
+		return allErrs
+	}
+
+	sort.Strings(zones)
+	if platform.DefaultMachinePlatform != nil {
+		allErrs = append(allErrs, validateZones(platform.DefaultMachinePlatform.Zones, zones, field.NewPath("platform", "aws", "defaultMachinePlatform", "zones"))...)
+	}
+	for i, pool := range config.Machines {
+		if pool.Platform.AWS != nil {
+			allErrs = append(allErrs, validateZones(pool.Platform.AWS.Zones, zones, field.NewPath("machines").Index(i).Child("platform", "aws", "zones"))...)
+		}
+	}
+	return allErrs
+}
+
+func validateZones(zones, available []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, zone := range zones {
+		if !contains(available, zone) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i), zone, available))
+		}
+	}
+	return allErrs
+}
+
+// poolImages returns the AMIs of the machine pools.
+func poolImages(config *types.InstallConfig) []string {
+	var images []string
+	for _, pool := range config.Machines {
+		if pool.Platform.AWS != nil && pool.Platform.AWS.OSImage != "" {
+			images = append(images, pool.Platform.AWS.OSImage)
+		}
+	}
+	return images
+}
+
+// validateImageArchitectures checks the AMIs of the machine pools against
+// the architectures of the AMIs of the region, keyed by ID. The machine
+// sets of a pool with another architecture, or an AMI which is missing
+// from the region, would fail to create any machines.
+func validateImageArchitectures(config *types.InstallConfig, architectures map[string]string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, pool := range config.Machines {
+		if pool.Platform.AWS == nil || pool.Platform.AWS.OSImage == "" {
+			continue
+		}
+		fldPath := field.NewPath("machines").Index(i).Child("platform", "aws", "osImage")
+		image := pool.Platform.AWS.OSImage
+		architecture, ok := architectures[image]
+		switch {
+		case !ok:
+			allErrs = append(allErrs, field.NotFound(fldPath, image))
+		case architecture != rhcos.DefaultArchitecture:
+			allErrs = append(allErrs, field.Invalid(fldPath, image, fmt.Sprintf("the AMI is for %s machines, not %s", architecture, rhcos.DefaultArchitecture)))
+		}
+	}
+	return allErrs
+}
+
+// describeImageArchitectures returns the architectures of the AMIs in the
+// client's region, keyed by ID. AMIs which are missing from the region are
+// omitted.
+func describeImageArchitectures(client *ec2.EC2, ids []string) (map[string]string, error) {
+	architectures := map[string]string{}
+	if len(ids) == 0 {
+		return architectures, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	// DescribeImages fails for all the IDs if any of them is missing, so
+	// filter by ID instead.
+	output, err := client.DescribeImagesWithContext(ctx, &ec2.DescribeImagesInput{
+		Filters: []*ec2.Filter{{Name: awssdk.String("image-id"), Values: awssdk.StringSlice(ids)}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describe images")
+	}
+	for _, image := range output.Images {
+		architectures[awssdk.StringValue(image.ImageId)] = awssdk.StringValue(image.Architecture)
+	}
+	return architectures, nil
+}
+
+// describeAvailabilityZones lists the names of the available zones of the
+// client's region.
+func describeAvailabilityZones(client *ec2.EC2) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	output, err := client.DescribeAvailabilityZonesWithContext(ctx, &ec2.DescribeAvailabilityZonesInput{
+		Filters: []*ec2.Filter{{Name: awssdk.String("state"), Values: awssdk.StringSlice([]string{"available"})}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describe availability zones")
+	}
+
+	zones := make([]string, 0, len(output.AvailabilityZones))
+	for _, zone := range output.AvailabilityZones {
+		zones = append(zones, awssdk.StringValue(zone.ZoneName))
+	}
+	return zones, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
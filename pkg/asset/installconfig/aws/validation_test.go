@@ -0,0 +1,106 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestValidateRegionAndZones(t *testing.T) {
+	cases := []struct {
+		name           string
+		region         string
+		defaultZones   []string
+		workerZones    []string
+		expectedErrors []string
+	}{
+		{
+			name:         "valid",
+			region:       "us-east-1",
+			defaultZones: []string{"us-east-1a"},
+			workerZones:  []string{"us-east-1a", "us-east-1b"},
+		},
+		{
+			name:           "unavailable region",
+			region:         "ap-northeast-3",
+			workerZones:    []string{"ap-northeast-3a"},
+			expectedErrors: []string{`platform.aws.region: Unsupported value: "ap-northeast-3": supported values: "eu-west-1", "us-east-1"`},
+		},
+		{
+			name:         "unavailable zones",
+			region:       "us-east-1",
+			defaultZones: []string{"us-east-1c"},
+			workerZones:  []string{"us-east-1a", "us-west-2a"},
+			expectedErrors: []string{
+				`platform.aws.defaultMachinePlatform.zones[0]: Unsupported value: "us-east-1c": supported values: "us-east-1a", "us-east-1b"`,
+				`machines[0].platform.aws.zones[1]: Unsupported value: "us-west-2a": supported values: "us-east-1a", "us-east-1b"`,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &types.InstallConfig{
+				Platform: types.Platform{AWS: &aws.Platform{
+					Region:                 tc.region,
+					DefaultMachinePlatform: &aws.MachinePool{Zones: tc.defaultZones},
+				}},
+				Machines: []types.MachinePool{{
+					Name:     "worker",
+					Platform: types.MachinePoolPlatform{AWS: &aws.MachinePool{Zones: tc.workerZones}},
+				}},
+			}
+			allErrs := validateRegionAndZones(config, []string{"us-east-1", "eu-west-1"}, []string{"us-east-1b", "us-east-1a"})
+			var errs []string
+			for _, err := range allErrs {
+				errs = append(errs, err.Error())
+			}
+			assert.Equal(t, tc.expectedErrors, errs)
+		})
+	}
+}
+
+func TestValidateImageArchitectures(t *testing.T) {
+	config := &types.InstallConfig{
+		Machines: []types.MachinePool{
+			{Name: "master", Platform: types.MachinePoolPlatform{AWS: &aws.MachinePool{}}},
+			{Name: "worker", Platform: types.MachinePoolPlatform{AWS: &aws.MachinePool{OSImage: "ami-1"}}},
+			{Name: "infra", Platform: types.MachinePoolPlatform{AWS: &aws.MachinePool{OSImage: "ami-2"}}},
+		},
+	}
+	cases := []struct {
+		name           string
+		architectures  map[string]string
+		expectedErrors []string
+	}{
+		{
+			name:          "valid",
+			architectures: map[string]string{"ami-1": "x86_64", "ami-2": "x86_64"},
+		},
+		{
+			name:          "other architecture",
+			architectures: map[string]string{"ami-1": "x86_64", "ami-2": "arm64"},
+			expectedErrors: []string{
+				`machines[2].platform.aws.osImage: Invalid value: "ami-2": the AMI is for arm64 machines, not x86_64`,
+			},
+		},
+		{
+			name:          "missing",
+			architectures: map[string]string{"ami-2": "x86_64"},
+			expectedErrors: []string{
+				`machines[1].platform.aws.osImage: Not found: "ami-1"`,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errs []string
+			for _, err := range validateImageArchitectures(config, tc.architectures) {
+				errs = append(errs, err.Error())
+			}
+			assert.Equal(t, tc.expectedErrors, errs)
+		})
+	}
+}
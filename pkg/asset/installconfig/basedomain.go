@@ -26,6 +26,10 @@ func (a *baseDomain) Dependencies() []asset.Asset {
 
 // Generate queries for the base domain from the user.
 func (a *baseDomain) Generate(parents asset.Parents) error {
+	if !asset.Interactive {
+		return asset.NewMissingInputError(a)
+	}
+
 	platform := &platform{}
 	parents.Get(platform)
 
@@ -56,3 +60,8 @@ func (a *baseDomain) Generate(parents asset.Parents) error {
 func (a *baseDomain) Name() string {
 	return "Base Domain"
 }
+
+// Prompts returns whether generating the asset prompts the user.
+func (a *baseDomain) Prompts() bool {
+	return asset.Interactive
+}
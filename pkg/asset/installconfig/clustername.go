@@ -20,6 +20,10 @@ func (a *clusterName) Dependencies() []asset.Asset {
 
 // Generate queries for the cluster name from the user.
 func (a *clusterName) Generate(asset.Parents) error {
+	if !asset.Interactive {
+		return asset.NewMissingInputError(a)
+	}
+
 	return survey.Ask([]*survey.Question{
 		{
 			Prompt: &survey.Input{
@@ -27,7 +31,7 @@ func (a *clusterName) Generate(asset.Parents) error {
 				Help:    "The name of the cluster.  This will be used when generating sub-domains.\n\nFor libvirt, choose a name that is unique enough to be used as a prefix during cluster deletion.  For example, if you use 'demo' as your cluster name, `openshift-install destroy cluster` may destroy all domains, networks, pools, and volumes that begin with 'demo'.",
 			},
 			Validate: survey.ComposeValidators(survey.Required, func(ans interface{}) error {
-				return validate.DomainName(ans.(string))
+				return validate.DNSLabel(ans.(string))
 			}),
 		},
 	}, &a.ClusterName)
@@ -37,3 +41,8 @@ func (a *clusterName) Generate(asset.Parents) error {
 func (a *clusterName) Name() string {
 	return "Cluster Name"
 }
+
+// Prompts returns whether generating the asset prompts the user.
+func (a *clusterName) Prompts() bool {
+	return asset.Interactive
+}
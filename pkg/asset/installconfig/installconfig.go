@@ -5,9 +5,13 @@ import (
 
 	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/asset"
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/redact"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/defaults"
 	openstackvalidation "github.com/openshift/installer/pkg/types/openstack/validation"
@@ -55,7 +59,7 @@ func (a *InstallConfig) Generate(parents asset.Parents) error {
 
 	a.Config = &types.InstallConfig{
 		TypeMeta: metav1.TypeMeta{
-			APIVersion: "v1beta1",
+			APIVersion: types.InstallConfigVersion,
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name: clusterName.ClusterName,
@@ -64,6 +68,7 @@ func (a *InstallConfig) Generate(parents asset.Parents) error {
 		BaseDomain: baseDomain.BaseDomain,
 		PullSecret: pullSecret.PullSecret,
 	}
+	redact.AddPullSecret(a.Config.PullSecret)
 
 	a.Config.AWS = platform.AWS
 	a.Config.Libvirt = platform.Libvirt
@@ -77,6 +82,9 @@ func (a *InstallConfig) Generate(parents asset.Parents) error {
 	if err := validation.ValidateInstallConfig(a.Config, openstackvalidation.NewValidValuesFetcher()).ToAggregate(); err != nil {
 		return errors.Wrap(err, "invalid install config")
 	}
+	if err := validateCloud(a.Config).ToAggregate(); err != nil {
+		return errors.Wrap(err, "invalid install config")
+	}
 
 	data, err := yaml.Marshal(a.Config)
 	if err != nil {
@@ -94,6 +102,11 @@ func (a *InstallConfig) Name() string {
 	return "Install Config"
 }
 
+// Sensitive returns true, because the install-config contains the pull secret.
+func (a *InstallConfig) Sensitive() bool {
+	return true
+}
+
 // Files returns the files generated by the asset.
 func (a *InstallConfig) Files() []*asset.File {
 	if a.File != nil {
@@ -117,6 +130,7 @@ func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, errors.Wrapf(err, "failed to unmarshal")
 	}
 	a.Config = config
+	redact.AddPullSecret(a.Config.PullSecret)
 
 	if err := a.setDefaults(); err != nil {
 		return false, errors.Wrapf(err, "failed to set defaults for install config")
@@ -125,6 +139,9 @@ func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 	if err := validation.ValidateInstallConfig(a.Config, openstackvalidation.NewValidValuesFetcher()).ToAggregate(); err != nil {
 		return false, errors.Wrapf(err, "invalid %q file", installConfigFilename)
 	}
+	if err := validateCloud(a.Config).ToAggregate(); err != nil {
+		return false, errors.Wrapf(err, "invalid %q file", installConfigFilename)
+	}
 
 	data, err := yaml.Marshal(a.Config)
 	if err != nil {
@@ -142,3 +159,20 @@ func (a *InstallConfig) setDefaults() error {
 	defaults.SetInstallConfigDefaults(a.Config)
 	return nil
 }
+
+// validateCloud checks the values of the install config which depend on the
+// account, like the AWS region and zones, against the cloud. If the cloud
+// cannot be reached, e.g. when the install config is generated offline, a
+// warning is logged instead, and the values are only checked against the
+// embedded lists.
+func validateCloud(config *types.InstallConfig) field.ErrorList {
+	if config.Platform.AWS == nil {
+		return nil
+	}
+	allErrs, err := awsconfig.ValidateCloud(config)
+	if err != nil {
+		logrus.Warnf("Failed to check the AWS region and zones against your account, only checking the region against the regions known to the installer: %v", err)
+		return nil
+	}
+	return allErrs
+}
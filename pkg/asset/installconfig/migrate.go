@@ -0,0 +1,55 @@
+package installconfig
+
+import (
+	"encoding/json"
+
+	"github.com/ghodss/yaml"
+	netopv1 "github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func init() {
+	asset.RegisterMigration(asset.Migration{
+		Description: "Replaced the deprecated networking.podCIDR with networking.clusterNetworks in the stored install-config",
+		Asset:       &InstallConfig{},
+		State:       migratePodCIDR,
+	})
+}
+
+// migratePodCIDR replaces the deprecated PodCIDR of a stored install-config
+// with the equivalent ClusterNetworks.
+func migratePodCIDR(data json.RawMessage) (json.RawMessage, error) {
+	installConfig := &InstallConfig{}
+	if err := json.Unmarshal(data, installConfig); err != nil {
+		return nil, err
+	}
+
+	if installConfig.Config == nil || installConfig.Config.Networking == nil {
+		return nil, nil
+	}
+	networking := installConfig.Config.Networking
+	if networking.PodCIDR == nil || len(networking.ClusterNetworks) != 0 {
+		return nil, nil
+	}
+
+	// Use the host subnet length the PodCIDR was always installed with.
+	networking.ClusterNetworks = []netopv1.ClusterNetwork{
+		{
+			CIDR:             networking.PodCIDR.String(),
+			HostSubnetLength: 9,
+		},
+	}
+	networking.PodCIDR = nil
+
+	fileData, err := yaml.Marshal(installConfig.Config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to Marshal InstallConfig")
+	}
+	installConfig.File = &asset.File{
+		Filename: installConfigFilename,
+		Data:     fileData,
+	}
+	return json.Marshal(installConfig)
+}
@@ -0,0 +1,68 @@
+package installconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	netopv1 "github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestMigratePodCIDR(t *testing.T) {
+	cases := []struct {
+		name       string
+		networking *types.Networking
+		expected   []netopv1.ClusterNetwork
+	}{
+		{
+			name: "pod CIDR",
+			networking: &types.Networking{
+				PodCIDR: ipnet.MustParseCIDR("10.128.0.0/14"),
+			},
+			expected: []netopv1.ClusterNetwork{{CIDR: "10.128.0.0/14", HostSubnetLength: 9}},
+		},
+		{
+			name: "cluster networks",
+			networking: &types.Networking{
+				ClusterNetworks: []netopv1.ClusterNetwork{{CIDR: "10.128.0.0/14", HostSubnetLength: 9}},
+			},
+		},
+		{
+			name: "no networking",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := validInstallConfig()
+			config.Networking = tc.networking
+			data, err := json.Marshal(&InstallConfig{Config: config})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			migrated, err := migratePodCIDR(data)
+			assert.NoError(t, err)
+			if tc.expected == nil {
+				assert.Nil(t, migrated)
+				return
+			}
+
+			installConfig := &InstallConfig{}
+			if err := json.Unmarshal(migrated, installConfig); err != nil {
+				t.Fatal(err)
+			}
+			assert.Nil(t, installConfig.Config.Networking.PodCIDR)
+			assert.Equal(t, tc.expected, installConfig.Config.Networking.ClusterNetworks)
+
+			fileConfig := &types.InstallConfig{}
+			if err := yaml.Unmarshal(installConfig.File.Data, fileConfig); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, installConfig.Config, fileConfig)
+		})
+	}
+}
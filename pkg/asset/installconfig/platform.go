@@ -31,6 +31,10 @@ func (a *platform) Dependencies() []asset.Asset {
 
 // Generate queries for input from the user.
 func (a *platform) Generate(asset.Parents) error {
+	if !asset.Interactive {
+		return asset.NewMissingInputError(a)
+	}
+
 	platform, err := a.queryUserForPlatform()
 	if err != nil {
 		return err
@@ -66,6 +70,11 @@ func (a *platform) Name() string {
 	return "Platform"
 }
 
+// Prompts returns whether generating the asset prompts the user.
+func (a *platform) Prompts() bool {
+	return asset.Interactive
+}
+
 func (a *platform) queryUserForPlatform() (platform string, err error) {
 	err = survey.Ask([]*survey.Question{
 		{
@@ -20,6 +20,10 @@ func (a *pullSecret) Dependencies() []asset.Asset {
 
 // Generate queries for the pull secret from the user.
 func (a *pullSecret) Generate(asset.Parents) error {
+	if !asset.Interactive {
+		return asset.NewMissingInputError(a)
+	}
+
 	return survey.Ask([]*survey.Question{
 		{
 			Prompt: &survey.Password{
@@ -37,3 +41,13 @@ func (a *pullSecret) Generate(asset.Parents) error {
 func (a *pullSecret) Name() string {
 	return "Pull Secret"
 }
+
+// Sensitive returns true, because the asset is the pull secret.
+func (a *pullSecret) Sensitive() bool {
+	return true
+}
+
+// Prompts returns whether generating the asset prompts the user.
+func (a *pullSecret) Prompts() bool {
+	return asset.Interactive
+}
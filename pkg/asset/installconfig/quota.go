@@ -0,0 +1,101 @@
+package installconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/openstack"
+)
+
+// Quota is the quota of a resource in the account of a platform, with the
+// amount of it which the cluster needs.
+type Quota struct {
+	// Resource is the name of the resource, e.g. "compute cores".
+	Resource string
+
+	// Limit is the amount of the resource which the account may use, or
+	// a negative amount if it is unlimited.
+	Limit int64
+
+	// Used is the amount of the resource which the account uses.
+	Used int64
+
+	// Required is the amount of the resource which the cluster needs.
+	Required int64
+}
+
+// Sufficient returns true if the account may use the amount of the
+// resource which the cluster needs, on top of what it already uses.
+func (q Quota) Sufficient() bool {
+	return q.Limit < 0 || q.Limit-q.Used >= q.Required
+}
+
+// QuotaChecker is implemented by the platforms whose accounts limit the
+// resources the cluster needs, such as compute cores, volumes, floating
+// IPs or load balancers.
+type QuotaChecker interface {
+	// Quotas returns the quotas of the resources which the cluster
+	// needs.
+	Quotas(config *types.InstallConfig) ([]Quota, error)
+}
+
+// QuotaCheckers are the quota checkers of the platforms, by the name of
+// the platform. Platforms without one are not checked.
+var QuotaCheckers = map[string]QuotaChecker{
+	aws.Name:       awsQuotaChecker{},
+	openstack.Name: openstackQuotaChecker{},
+}
+
+// CheckQuotas checks that the quotas of the account of the platform leave
+// room for the resources which the cluster needs, and returns an error
+// which names each resource whose quota does not.
+func CheckQuotas(config *types.InstallConfig) error {
+	checker, ok := QuotaCheckers[config.Platform.Name()]
+	if !ok {
+		return nil
+	}
+
+	quotas, err := checker.Quotas(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch the quotas")
+	}
+
+	var insufficient []string
+	for _, q := range quotas {
+		if !q.Sufficient() {
+			insufficient = append(insufficient, fmt.Sprintf("%s: %d required, but %d of the limit of %d are used", q.Resource, q.Required, q.Used, q.Limit))
+		}
+	}
+	if len(insufficient) > 0 {
+		return errors.Errorf("the quotas of the %s account are insufficient for the cluster: %s", config.Platform.Name(), strings.Join(insufficient, "; "))
+	}
+	return nil
+}
+
+// machines returns the number of machines which the installer creates: the
+// bootstrap machine and those of every pool which the user does not
+// provision.
+func machines(config *types.InstallConfig) int64 {
+	count := int64(1)
+	for _, p := range config.Machines {
+		if !p.UserProvisioned && p.Replicas != nil {
+			count += *p.Replicas
+		}
+	}
+	return count
+}
+
+// replicas returns the number of machines of the named pool.
+func replicas(config *types.InstallConfig, pool string) int64 {
+	var count int64
+	for _, p := range config.Machines {
+		if p.Name == pool && p.Replicas != nil {
+			count += *p.Replicas
+		}
+	}
+	return count
+}
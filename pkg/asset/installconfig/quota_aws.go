@@ -0,0 +1,177 @@
+package installconfig
+
+import (
+	"strconv"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// awsQuotaChecker checks the limits of the AWS account in the region of
+// the cluster. The limits of VPCs, NAT gateways and volumes are not
+// exposed by the EC2 API, so only docs/user/aws/limits.md covers them.
+type awsQuotaChecker struct{}
+
+func (awsQuotaChecker) Quotas(config *types.InstallConfig) ([]Quota, error) {
+	session, err := awsconfig.GetSession()
+	if err != nil {
+		return nil, err
+	}
+	regional := awssdk.NewConfig().WithRegion(config.Platform.AWS.Region)
+	ec2Client := ec2.New(session, regional)
+
+	attributes, err := ec2Client.DescribeAccountAttributes(&ec2.DescribeAccountAttributesInput{
+		AttributeNames: awssdk.StringSlice([]string{"max-instances", "vpc-max-elastic-ips"}),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describe the account attributes")
+	}
+	limits, err := accountLimits(attributes.AccountAttributes, "max-instances", "vpc-max-elastic-ips")
+	if err != nil {
+		return nil, err
+	}
+
+	var instances int64
+	err = ec2Client.DescribeInstancesPages(
+		&ec2.DescribeInstancesInput{
+			Filters: []*ec2.Filter{{Name: awssdk.String("instance-state-name"), Values: awssdk.StringSlice([]string{"pending", "running"})}},
+		},
+		func(response *ec2.DescribeInstancesOutput, lastPage bool) bool {
+			for _, reservation := range response.Reservations {
+				instances += int64(len(reservation.Instances))
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "describe the instances")
+	}
+
+	addresses, err := ec2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{{Name: awssdk.String("domain"), Values: awssdk.StringSlice([]string{"vpc"})}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describe the elastic IPs")
+	}
+
+	// The installer creates a NAT gateway, with its elastic IP, in each
+	// availability zone of the region.
+	zones, err := ec2Client.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		Filters: []*ec2.Filter{{Name: awssdk.String("state"), Values: awssdk.StringSlice([]string{"available"})}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describe the availability zones")
+	}
+
+	networkLBLimit, err := elbv2Limit(elbv2.New(session, regional), "network-load-balancers")
+	if err != nil {
+		return nil, err
+	}
+	var networkLBs int64
+	err = elbv2.New(session, regional).DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{}, func(response *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+		for _, lb := range response.LoadBalancers {
+			if awssdk.StringValue(lb.Type) == elbv2.LoadBalancerTypeEnumNetwork {
+				networkLBs++
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describe the network load balancers")
+	}
+
+	classicLBLimit, err := elbLimit(elb.New(session, regional), "classic-load-balancers")
+	if err != nil {
+		return nil, err
+	}
+	var classicLBs int64
+	err = elb.New(session, regional).DescribeLoadBalancersPages(&elb.DescribeLoadBalancersInput{}, func(response *elb.DescribeLoadBalancersOutput, lastPage bool) bool {
+		classicLBs += int64(len(response.LoadBalancerDescriptions))
+		return !lastPage
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describe the classic load balancers")
+	}
+
+	return []Quota{
+		{
+			Resource: "instances",
+			Limit:    limits["max-instances"],
+			Used:     instances,
+			Required: machines(config),
+		},
+		{
+			Resource: "elastic IPs",
+			Limit:    limits["vpc-max-elastic-ips"],
+			Used:     int64(len(addresses.Addresses)),
+			Required: int64(len(zones.AvailabilityZones)),
+		},
+		{
+			// The internal and external load balancers of the API.
+			Resource: "network load balancers",
+			Limit:    networkLBLimit,
+			Used:     networkLBs,
+			Required: 2,
+		},
+		{
+			// The load balancer of the router, which the ingress
+			// operator creates.
+			Resource: "classic load balancers",
+			Limit:    classicLBLimit,
+			Used:     classicLBs,
+			Required: 1,
+		},
+	}, nil
+}
+
+// accountLimits returns the limits of the named EC2 account attributes.
+// Attributes which the account does not have are unlimited.
+func accountLimits(attributes []*ec2.AccountAttribute, names ...string) (map[string]int64, error) {
+	limits := map[string]int64{}
+	for _, name := range names {
+		limits[name] = -1
+	}
+	for _, attribute := range attributes {
+		if len(attribute.AttributeValues) == 0 {
+			continue
+		}
+		limit, err := strconv.ParseInt(awssdk.StringValue(attribute.AttributeValues[0].AttributeValue), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse the account attribute %s", awssdk.StringValue(attribute.AttributeName))
+		}
+		limits[awssdk.StringValue(attribute.AttributeName)] = limit
+	}
+	return limits, nil
+}
+
+func elbv2Limit(client *elbv2.ELBV2, name string) (int64, error) {
+	response, err := client.DescribeAccountLimits(&elbv2.DescribeAccountLimitsInput{})
+	if err != nil {
+		return 0, errors.Wrap(err, "describe the load balancer limits")
+	}
+	for _, limit := range response.Limits {
+		if awssdk.StringValue(limit.Name) == name {
+			return strconv.ParseInt(awssdk.StringValue(limit.Max), 10, 64)
+		}
+	}
+	return -1, nil
+}
+
+func elbLimit(client *elb.ELB, name string) (int64, error) {
+	response, err := client.DescribeAccountLimits(&elb.DescribeAccountLimitsInput{})
+	if err != nil {
+		return 0, errors.Wrap(err, "describe the classic load balancer limits")
+	}
+	for _, limit := range response.Limits {
+		if awssdk.StringValue(limit.Name) == name {
+			return strconv.ParseInt(awssdk.StringValue(limit.Max), 10, 64)
+		}
+	}
+	return -1, nil
+}
@@ -0,0 +1,95 @@
+package installconfig
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+	openstacktypes "github.com/openshift/installer/pkg/types/openstack"
+)
+
+// openstackQuotaChecker checks the absolute limits of the project of the
+// cloud. The machines boot from their images, so the cluster needs no
+// volumes.
+type openstackQuotaChecker struct{}
+
+func (openstackQuotaChecker) Quotas(config *types.InstallConfig) ([]Quota, error) {
+	platform := config.Platform.OpenStack
+	conn, err := clientconfig.NewServiceClient("compute", &clientconfig.ClientOpts{Cloud: platform.Cloud})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create compute client")
+	}
+
+	var limits struct {
+		Limits struct {
+			Absolute struct {
+				MaxTotalCores        int64 `json:"maxTotalCores"`
+				TotalCoresUsed       int64 `json:"totalCoresUsed"`
+				MaxTotalInstances    int64 `json:"maxTotalInstances"`
+				TotalInstancesUsed   int64 `json:"totalInstancesUsed"`
+				MaxTotalFloatingIps  int64 `json:"maxTotalFloatingIps"`
+				TotalFloatingIpsUsed int64 `json:"totalFloatingIpsUsed"`
+			} `json:"absolute"`
+		} `json:"limits"`
+	}
+	if _, err := conn.Get(conn.ServiceURL("limits"), &limits, nil); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch the compute limits")
+	}
+	absolute := limits.Limits.Absolute
+
+	// The bootstrap machine and the masters use the flavor of the
+	// platform, and the workers that of their pool.
+	workerFlavor := openstacktypes.MachinePool{FlavorName: platform.FlavorName}
+	workerFlavor.Set(platform.DefaultMachinePlatform)
+	for _, p := range config.Machines {
+		if p.Name == "worker" {
+			workerFlavor.Set(p.Platform.OpenStack)
+		}
+	}
+	masterCores, err := flavorCores(conn, platform.FlavorName)
+	if err != nil {
+		return nil, err
+	}
+	workerCores, err := flavorCores(conn, workerFlavor.FlavorName)
+	if err != nil {
+		return nil, err
+	}
+	masters := replicas(config, "master")
+	workers := replicas(config, "worker")
+
+	return []Quota{
+		{
+			Resource: "compute cores",
+			Limit:    absolute.MaxTotalCores,
+			Used:     absolute.TotalCoresUsed,
+			Required: (1+masters)*masterCores + workers*workerCores,
+		},
+		{
+			Resource: "instances",
+			Limit:    absolute.MaxTotalInstances,
+			Used:     absolute.TotalInstancesUsed,
+			Required: 1 + masters + workers,
+		},
+		{
+			// The floating IP of the API.
+			Resource: "floating IPs",
+			Limit:    absolute.MaxTotalFloatingIps,
+			Used:     absolute.TotalFloatingIpsUsed,
+			Required: 1,
+		},
+	}, nil
+}
+
+func flavorCores(conn *gophercloud.ServiceClient, name string) (int64, error) {
+	id, err := flavors.IDFromName(conn, name)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to find flavor %s", name)
+	}
+	flavor, err := flavors.Get(conn, id).Extract()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get flavor %s", name)
+	}
+	return int64(flavor.VCPUs), nil
+}
@@ -0,0 +1,98 @@
+package installconfig
+
+import (
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/libvirt"
+)
+
+type fakeQuotaChecker struct {
+	quotas []Quota
+	err    error
+}
+
+func (c fakeQuotaChecker) Quotas(config *types.InstallConfig) ([]Quota, error) {
+	return c.quotas, c.err
+}
+
+func TestCheckQuotas(t *testing.T) {
+	cases := []struct {
+		name          string
+		checker       QuotaChecker
+		expectedError string
+	}{
+		{
+			name: "no checker",
+		},
+		{
+			name: "sufficient",
+			checker: fakeQuotaChecker{quotas: []Quota{
+				{Resource: "compute cores", Limit: 20, Used: 4, Required: 16},
+				{Resource: "floating IPs", Limit: -1, Used: 50, Required: 1},
+			}},
+		},
+		{
+			name: "insufficient",
+			checker: fakeQuotaChecker{quotas: []Quota{
+				{Resource: "compute cores", Limit: 20, Used: 8, Required: 16},
+				{Resource: "instances", Limit: 10, Used: 0, Required: 7},
+				{Resource: "floating IPs", Limit: 2, Used: 2, Required: 1},
+			}},
+			expectedError: "the quotas of the libvirt account are insufficient for the cluster: compute cores: 16 required, but 8 of the limit of 20 are used; floating IPs: 1 required, but 2 of the limit of 2 are used",
+		},
+		{
+			name:          "fetch error",
+			checker:       fakeQuotaChecker{err: errors.New("unauthorized")},
+			expectedError: "failed to fetch the quotas: unauthorized",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.checker != nil {
+				QuotaCheckers[libvirt.Name] = tc.checker
+				defer delete(QuotaCheckers, libvirt.Name)
+			}
+			config := &types.InstallConfig{Platform: types.Platform{Libvirt: &libvirt.Platform{}}}
+			err := CheckQuotas(config)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestMachines(t *testing.T) {
+	three, two := int64(3), int64(2)
+	config := &types.InstallConfig{Machines: []types.MachinePool{
+		{Name: "master", Replicas: &three},
+		{Name: "worker", Replicas: &three},
+		{Name: "infra", Replicas: &two},
+		{Name: "edge", Replicas: &two, UserProvisioned: true},
+	}}
+	assert.Equal(t, int64(9), machines(config))
+}
+
+func TestAccountLimits(t *testing.T) {
+	attribute := func(name, value string) *ec2.AccountAttribute {
+		return &ec2.AccountAttribute{
+			AttributeName:   awssdk.String(name),
+			AttributeValues: []*ec2.AccountAttributeValue{{AttributeValue: awssdk.String(value)}},
+		}
+	}
+
+	limits, err := accountLimits([]*ec2.AccountAttribute{attribute("max-instances", "20")}, "max-instances", "vpc-max-elastic-ips")
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]int64{"max-instances": 20, "vpc-max-elastic-ips": -1}, limits)
+	}
+
+	_, err = accountLimits([]*ec2.AccountAttribute{attribute("max-instances", "many")}, "max-instances")
+	assert.EqualError(t, err, `parse the account attribute max-instances: strconv.ParseInt: parsing "many": invalid syntax`)
+}
@@ -72,6 +72,10 @@ func (a *sshPublicKey) Generate(asset.Parents) error {
 		return nil
 	}
 
+	if !asset.Interactive {
+		return asset.NewMissingInputError(a)
+	}
+
 	var paths []string
 	for path := range pubKeys {
 		paths = append(paths, path)
@@ -103,3 +107,8 @@ func (a *sshPublicKey) Generate(asset.Parents) error {
 func (a sshPublicKey) Name() string {
 	return "SSH Key"
 }
+
+// Prompts returns whether generating the asset prompts the user.
+func (a sshPublicKey) Prompts() bool {
+	return asset.Interactive
+}
@@ -0,0 +1,51 @@
+package asset
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Interactive is whether assets may prompt the user for input. When it is
+// false, assets which would prompt return a MissingInputsError instead.
+var Interactive = true
+
+// Prompter is implemented by assets which may prompt the user for input
+// when they are generated. The store does not generate any other asset while
+// it generates one which prompts, so that the prompts are not interleaved.
+type Prompter interface {
+	// Prompts returns whether generating the asset may prompt the user.
+	Prompts() bool
+}
+
+// MissingInputsError is returned when assets need input from the user but
+// the installer is not running interactively.
+type MissingInputsError struct {
+	// Inputs are the human-friendly names of the missing inputs.
+	Inputs []string
+}
+
+// NewMissingInputError returns a MissingInputsError for the given asset,
+// which needs input from the user.
+func NewMissingInputError(asset Asset) error {
+	return &MissingInputsError{Inputs: []string{asset.Name()}}
+}
+
+func (e *MissingInputsError) Error() string {
+	return fmt.Sprintf("cannot prompt for %s because the installer is not running interactively; provide an install-config.yaml in the asset directory instead", strings.Join(e.Inputs, ", "))
+}
+
+// add adds the inputs of other to e, skipping any already present.
+func (e *MissingInputsError) add(other *MissingInputsError) {
+	for _, input := range other.Inputs {
+		found := false
+		for _, existing := range e.Inputs {
+			if existing == input {
+				found = true
+				break
+			}
+		}
+		if !found {
+			e.Inputs = append(e.Inputs, input)
+		}
+	}
+}
@@ -31,8 +31,8 @@ func (k *kubeconfig) generate(
 			{
 				Name: installConfig.ObjectMeta.Name,
 				Cluster: clientcmd.Cluster{
-					Server: fmt.Sprintf("https://%s-api.%s:6443", installConfig.ObjectMeta.Name, installConfig.BaseDomain),
-					CertificateAuthorityData: []byte(rootCA.Cert()),
+					Server:                   fmt.Sprintf("https://%s-api.%s:6443", installConfig.ObjectMeta.Name, installConfig.BaseDomain),
+					CertificateAuthorityData: certificateAuthorityData(rootCA, installConfig),
 				},
 			},
 		},
@@ -70,6 +70,22 @@ func (k *kubeconfig) generate(
 	return nil
 }
 
+// certificateAuthorityData returns the certificates trusted for the API URL:
+// the root CA, and the chain of the serving certificate from the
+// install-config if there is one, so that clients trust the API server
+// whether it serves that certificate or one signed by the root CA.
+func certificateAuthorityData(rootCA tls.CertKeyInterface, installConfig *types.InstallConfig) []byte {
+	data := append([]byte{}, rootCA.Cert()...)
+	if installConfig.APIServer != nil && installConfig.APIServer.ServingCertificate != nil {
+		chain := []byte(installConfig.APIServer.ServingCertificate.Certificate)
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			data = append(data, '\n')
+		}
+		data = append(data, chain...)
+	}
+	return data
+}
+
 // Files returns the files generated by the asset.
 func (k *kubeconfig) Files() []*asset.File {
 	if k.File != nil {
@@ -78,6 +94,11 @@ func (k *kubeconfig) Files() []*asset.File {
 	return []*asset.File{}
 }
 
+// Sensitive returns true, because the kubeconfig contains a client key.
+func (k *kubeconfig) Sensitive() bool {
+	return true
+}
+
 // load returns the kubeconfig from disk.
 func (k *kubeconfig) load(f asset.FileFetcher, name string) (found bool, err error) {
 	file, err := f.FetchByName(name)
@@ -1,12 +1,19 @@
 package kubeconfig
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientcmd "k8s.io/client-go/tools/clientcmd/api/v1"
 
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/tls"
 	"github.com/openshift/installer/pkg/types"
 )
@@ -117,3 +124,127 @@ users:
 	}
 
 }
+
+func TestCertificateAuthorityData(t *testing.T) {
+	rootCA := &testCertKey{cert: "ROOT CA\n"}
+	installConfig := &types.InstallConfig{}
+	assert.Equal(t, "ROOT CA\n", string(certificateAuthorityData(rootCA, installConfig)))
+
+	installConfig.APIServer = &types.APIServer{
+		ServingCertificate: &types.ServingCertificate{
+			Certificate: "SERVING CERT\nINTERMEDIATE CA\n",
+		},
+	}
+	assert.Equal(t, "ROOT CA\nSERVING CERT\nINTERMEDIATE CA\n", string(certificateAuthorityData(rootCA, installConfig)))
+
+	rootCA.cert = "ROOT CA"
+	assert.Equal(t, "ROOT CA\nSERVING CERT\nINTERMEDIATE CA\n", string(certificateAuthorityData(rootCA, installConfig)))
+}
+
+func TestUsersGenerate(t *testing.T) {
+	rootCA := &tls.RootCA{}
+	assert.NoError(t, rootCA.Generate(nil))
+	parents := asset.Parents{}
+	parents.Add(rootCA)
+	kubeCA := &tls.KubeCA{}
+	assert.NoError(t, kubeCA.Generate(parents))
+	installConfig := &installconfig.InstallConfig{
+		Config: &types.InstallConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-cluster-name",
+			},
+			BaseDomain: "test.example.com",
+			Kubeconfigs: []types.Kubeconfig{
+				{Name: "reader", Groups: []string{"system:cluster-readers"}},
+			},
+		},
+	}
+
+	parents.Add(kubeCA, installConfig)
+
+	users := &Users{}
+	assert.NoError(t, users.Generate(parents))
+	if assert.Len(t, users.Files(), 1) {
+		assert.Equal(t, "auth/kubeconfig-reader", users.Files()[0].Filename)
+	}
+}
+
+func TestKubeconfigMerge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestKubeconfigMerge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, ".kube", "config")
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	existing := `apiVersion: v1
+kind: Config
+clusters:
+- name: other
+  cluster:
+    server: https://other:6443
+- name: test-cluster-name
+  cluster:
+    server: https://stale:6443
+users:
+- name: other-user
+  user:
+    token: abc
+contexts:
+- name: other
+  context:
+    cluster: other
+    user: other-user
+current-context: other
+`
+	if err := ioutil.WriteFile(path, []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	k := &kubeconfig{}
+	err = k.generate(
+		&testCertKey{cert: "ROOT CA"},
+		&testCertKey{key: "ADMIN KEY", cert: "ADMIN CERT"},
+		&types.InstallConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-name"},
+			BaseDomain: "test.example.com",
+		},
+		"admin",
+		"kubeconfig",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	context, err := k.Merge(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "test-cluster-name", context)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := &clientcmd.Config{}
+	if err := yaml.Unmarshal(data, merged); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "test-cluster-name", merged.CurrentContext)
+	if assert.Len(t, merged.Clusters, 2) {
+		assert.Equal(t, "https://other:6443", merged.Clusters[0].Cluster.Server)
+		assert.Equal(t, "https://test-cluster-name-api.test.example.com:6443", merged.Clusters[1].Cluster.Server)
+	}
+	if assert.Len(t, merged.AuthInfos, 2) {
+		assert.Equal(t, "admin/test-cluster-name", merged.AuthInfos[1].Name)
+		assert.Equal(t, []byte("ADMIN KEY"), merged.AuthInfos[1].AuthInfo.ClientKeyData)
+	}
+	if assert.Len(t, merged.Contexts, 2) {
+		assert.Equal(t, clientcmd.NamedContext{
+			Name:    "test-cluster-name",
+			Context: clientcmd.Context{Cluster: "test-cluster-name", AuthInfo: "admin/test-cluster-name"},
+		}, merged.Contexts[1])
+	}
+}
@@ -12,7 +12,11 @@ var (
 	kubeconfigKubeletPath = filepath.Join("auth", "kubeconfig-kubelet")
 )
 
-// Kubelet is the asset for the kubelet kubeconfig.
+// Kubelet is the asset for the kubelet kubeconfig. It is a bootstrap
+// credential, served to the nodes by the machine-config server: the kubelets
+// use it only to request their client and serving certificates through
+// certificate signing requests, so no per-node certificate is generated at
+// install time.
 type Kubelet struct {
 	kubeconfig
 }
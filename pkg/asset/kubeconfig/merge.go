@@ -0,0 +1,96 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	clientcmd "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+// Merge merges the cluster, user and context of the kubeconfig into the
+// kubeconfig file at path, creating it if it does not exist, and makes the
+// context current. The context and cluster are named after the cluster,
+// and the user after the user and the cluster, so that the kubeconfigs of
+// several clusters can be merged into the same file; entries with the same
+// names are replaced. It returns the name of the context.
+func (k *kubeconfig) Merge(path string) (string, error) {
+	if k.Config == nil || len(k.Config.Clusters) == 0 || len(k.Config.AuthInfos) == 0 {
+		return "", errors.New("no cluster and user in the kubeconfig")
+	}
+	clusterName := k.Config.Clusters[0].Name
+	userName := fmt.Sprintf("%s/%s", k.Config.AuthInfos[0].Name, clusterName)
+
+	config := &clientcmd.Config{}
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return "", errors.Wrapf(err, "failed to unmarshal %s", path)
+	}
+	config.APIVersion = "v1"
+	config.Kind = "Config"
+
+	config.Clusters = mergeCluster(config.Clusters, clientcmd.NamedCluster{
+		Name:    clusterName,
+		Cluster: k.Config.Clusters[0].Cluster,
+	})
+	config.AuthInfos = mergeAuthInfo(config.AuthInfos, clientcmd.NamedAuthInfo{
+		Name:     userName,
+		AuthInfo: k.Config.AuthInfos[0].AuthInfo,
+	})
+	config.Contexts = mergeContext(config.Contexts, clientcmd.NamedContext{
+		Name: clusterName,
+		Context: clientcmd.Context{
+			Cluster:  clusterName,
+			AuthInfo: userName,
+		},
+	})
+	config.CurrentContext = clusterName
+
+	data, err = yaml.Marshal(config)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the kubeconfig")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+	return clusterName, nil
+}
+
+func mergeCluster(clusters []clientcmd.NamedCluster, cluster clientcmd.NamedCluster) []clientcmd.NamedCluster {
+	for i := range clusters {
+		if clusters[i].Name == cluster.Name {
+			clusters[i] = cluster
+			return clusters
+		}
+	}
+	return append(clusters, cluster)
+}
+
+func mergeAuthInfo(authInfos []clientcmd.NamedAuthInfo, authInfo clientcmd.NamedAuthInfo) []clientcmd.NamedAuthInfo {
+	for i := range authInfos {
+		if authInfos[i].Name == authInfo.Name {
+			authInfos[i] = authInfo
+			return authInfos
+		}
+	}
+	return append(authInfos, authInfo)
+}
+
+func mergeContext(contexts []clientcmd.NamedContext, context clientcmd.NamedContext) []clientcmd.NamedContext {
+	for i := range contexts {
+		if contexts[i].Name == context.Name {
+			contexts[i] = context
+			return contexts
+		}
+	}
+	return append(contexts, context)
+}
@@ -0,0 +1,81 @@
+package kubeconfig
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+// Users is the asset for the kubeconfigs of the users listed in the
+// install-config, which authenticate with client certificates for their
+// groups.
+type Users struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Users)(nil)
+
+// Dependencies returns the dependency of the kubeconfigs.
+func (k *Users) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&tls.RootCA{},
+		&tls.KubeCA{},
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the kubeconfigs.
+func (k *Users) Generate(parents asset.Parents) error {
+	rootCA := &tls.RootCA{}
+	kubeCA := &tls.KubeCA{}
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(rootCA, kubeCA, installConfig)
+
+	k.FileList = nil
+	for _, user := range installConfig.Config.Kubeconfigs {
+		cfg := &tls.CertCfg{
+			Subject:      pkix.Name{CommonName: user.Name, Organization: user.Groups},
+			KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+			ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			Validity:     tls.CertValidity,
+		}
+		certKey := &tls.CertKey{}
+		if err := certKey.Generate(cfg, kubeCA, user.Name, tls.DoNotAppendParent); err != nil {
+			return errors.Wrapf(err, "failed to generate the client certificate of %s", user.Name)
+		}
+
+		kc := &kubeconfig{}
+		if err := kc.generate(rootCA, certKey, installConfig.Config, user.Name, filepath.Join("auth", "kubeconfig-"+user.Name)); err != nil {
+			return err
+		}
+		k.FileList = append(k.FileList, kc.File)
+	}
+	return nil
+}
+
+// Name returns the human-friendly name of the asset.
+func (k *Users) Name() string {
+	return "Kubeconfig Users"
+}
+
+// Files returns the files generated by the asset.
+func (k *Users) Files() []*asset.File {
+	return k.FileList
+}
+
+// Sensitive returns true, because the kubeconfigs contain client keys.
+func (k *Users) Sensitive() bool {
+	return true
+}
+
+// Load returns false, because the kubeconfigs are generated with the
+// client keys they contain.
+func (k *Users) Load(asset.FileFetcher) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,78 @@
+package machines
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/pkg/errors"
+	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+
+	"github.com/openshift/installer/pkg/asset/machines/replicas"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const clusterAutoscaler = `apiVersion: autoscaling.openshift.io/v1alpha1
+kind: ClusterAutoscaler
+metadata:
+  name: default
+spec: {}
+`
+
+var machineAutoscalerListTmpl = template.Must(template.New("machine-autoscaler-list").Parse(`
+kind: List
+apiVersion: v1
+metadata:
+  resourceVersion: ""
+  selfLink: ""
+items:
+{{- range . }}
+- apiVersion: autoscaling.openshift.io/v1alpha1
+  kind: MachineAutoscaler
+  metadata:
+    name: {{.Name}}
+    namespace: {{.Namespace}}
+  spec:
+    minReplicas: {{.MinReplicas}}
+    maxReplicas: {{.MaxReplicas}}
+    scaleTargetRef:
+      apiVersion: cluster.k8s.io/v1alpha1
+      kind: MachineSet
+      name: {{.Name}}
+{{- end}}
+`))
+
+type machineAutoscaler struct {
+	Name        string
+	Namespace   string
+	MinReplicas int64
+	MaxReplicas int64
+}
+
+// machineAutoscalers returns the MachineAutoscalers of the machinesets of
+// the pool, which split the autoscaling range of the pool between the
+// machinesets the same way their replicas are split.
+// Machinesets whose share of the maximum is zero are not autoscaled.
+func machineAutoscalers(autoscaling *types.MachinePoolAutoscaling, sets []clusterapi.MachineSet) []machineAutoscaler {
+	var autoscalers []machineAutoscaler
+	for idx, set := range sets {
+		maxReplicas := replicas.Share(autoscaling.MaxReplicas, len(sets), idx)
+		if maxReplicas == 0 {
+			continue
+		}
+		autoscalers = append(autoscalers, machineAutoscaler{
+			Name:        set.Name,
+			Namespace:   set.Namespace,
+			MinReplicas: replicas.Share(autoscaling.MinReplicas, len(sets), idx),
+			MaxReplicas: maxReplicas,
+		})
+	}
+	return autoscalers
+}
+
+func machineAutoscalerList(autoscalers []machineAutoscaler) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := machineAutoscalerListTmpl.Execute(buf, autoscalers); err != nil {
+		return nil, errors.Wrap(err, "failed to execute machineAutoscalerListTmpl")
+	}
+	return buf.Bytes(), nil
+}
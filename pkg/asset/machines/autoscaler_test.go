@@ -0,0 +1,47 @@
+package machines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestMachineAutoscalers(t *testing.T) {
+	var sets []clusterapi.MachineSet
+	for _, name := range []string{"c-worker-a", "c-worker-b", "c-worker-c"} {
+		sets = append(sets, clusterapi.MachineSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openshift-cluster-api"}})
+	}
+
+	cases := []struct {
+		name        string
+		autoscaling types.MachinePoolAutoscaling
+		expected    []machineAutoscaler
+	}{
+		{
+			name:        "even",
+			autoscaling: types.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 6},
+			expected: []machineAutoscaler{
+				{Name: "c-worker-a", Namespace: "openshift-cluster-api", MinReplicas: 1, MaxReplicas: 2},
+				{Name: "c-worker-b", Namespace: "openshift-cluster-api", MinReplicas: 1, MaxReplicas: 2},
+				{Name: "c-worker-c", Namespace: "openshift-cluster-api", MinReplicas: 1, MaxReplicas: 2},
+			},
+		},
+		{
+			name:        "uneven",
+			autoscaling: types.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 2},
+			expected: []machineAutoscaler{
+				{Name: "c-worker-a", Namespace: "openshift-cluster-api", MinReplicas: 1, MaxReplicas: 1},
+				{Name: "c-worker-b", Namespace: "openshift-cluster-api", MinReplicas: 0, MaxReplicas: 1},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, machineAutoscalers(&tc.autoscaling, sets))
+		})
+	}
+}
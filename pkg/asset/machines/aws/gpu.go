@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"strconv"
+)
+
+// Accelerator is the GPU model of an instance type, and how many GPUs of
+// that model the instance type has.
+type Accelerator struct {
+	// Name identifies the GPU model (e.g. nvidia-tesla-v100).
+	Name string
+
+	// Count is the number of GPUs.
+	Count int
+}
+
+const (
+	// AcceleratorLabel is the node label which identifies the GPU model of
+	// the node, which the cluster autoscaler uses to scale GPU pools.
+	AcceleratorLabel = "cluster-api/accelerator"
+
+	// gpuCapacityAnnotation is the machineset annotation with the number
+	// of GPUs of its machines, with which the autoscaler can scale the
+	// machineset up from zero replicas.
+	gpuCapacityAnnotation = "machine.openshift.io/GPU"
+)
+
+// gpuInstanceTypes are the EC2 instance types with NVIDIA GPUs.
+var gpuInstanceTypes = map[string]Accelerator{
+	"p2.xlarge":     {Name: "nvidia-tesla-k80", Count: 1},
+	"p2.8xlarge":    {Name: "nvidia-tesla-k80", Count: 8},
+	"p2.16xlarge":   {Name: "nvidia-tesla-k80", Count: 16},
+	"p3.2xlarge":    {Name: "nvidia-tesla-v100", Count: 1},
+	"p3.8xlarge":    {Name: "nvidia-tesla-v100", Count: 4},
+	"p3.16xlarge":   {Name: "nvidia-tesla-v100", Count: 8},
+	"p3dn.24xlarge": {Name: "nvidia-tesla-v100", Count: 8},
+	"g3s.xlarge":    {Name: "nvidia-tesla-m60", Count: 1},
+	"g3.4xlarge":    {Name: "nvidia-tesla-m60", Count: 1},
+	"g3.8xlarge":    {Name: "nvidia-tesla-m60", Count: 2},
+	"g3.16xlarge":   {Name: "nvidia-tesla-m60", Count: 4},
+	"g4dn.xlarge":   {Name: "nvidia-tesla-t4", Count: 1},
+	"g4dn.2xlarge":  {Name: "nvidia-tesla-t4", Count: 1},
+	"g4dn.4xlarge":  {Name: "nvidia-tesla-t4", Count: 1},
+	"g4dn.8xlarge":  {Name: "nvidia-tesla-t4", Count: 1},
+	"g4dn.12xlarge": {Name: "nvidia-tesla-t4", Count: 4},
+	"g4dn.16xlarge": {Name: "nvidia-tesla-t4", Count: 1},
+	"g4dn.metal":    {Name: "nvidia-tesla-t4", Count: 8},
+}
+
+// GPU returns the GPUs of the instance type, and whether it has any.
+func GPU(instanceType string) (Accelerator, bool) {
+	accelerator, ok := gpuInstanceTypes[instanceType]
+	return accelerator, ok
+}
+
+// gpuLabels returns the node labels of the instance type, which identify
+// its GPU model, or nil if it has no GPUs.
+func gpuLabels(instanceType string) map[string]string {
+	accelerator, ok := GPU(instanceType)
+	if !ok {
+		return nil
+	}
+	return map[string]string{AcceleratorLabel: accelerator.Name}
+}
+
+// gpuAnnotations returns the machineset annotations of the instance type,
+// with the number of its GPUs, or nil if it has no GPUs.
+func gpuAnnotations(instanceType string) map[string]string {
+	accelerator, ok := GPU(instanceType)
+	if !ok {
+		return nil
+	}
+	return map[string]string{gpuCapacityAnnotation: strconv.Itoa(accelerator.Count)}
+}
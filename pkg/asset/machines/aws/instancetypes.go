@@ -0,0 +1,73 @@
+package aws
+
+// InstanceType is the number of vCPUs and the memory of an instance type.
+type InstanceType struct {
+	// CPUs is the number of vCPUs.
+	CPUs int64
+
+	// MemoryMiB is the memory, in MiB.
+	MemoryMiB int64
+}
+
+// instanceTypes are the current general purpose, compute optimized,
+// memory optimized and burstable instance types. EC2 API versions before
+// DescribeInstanceTypes do not describe instance types, so others are
+// unknown.
+var instanceTypes = map[string]InstanceType{
+	"c4.large":    {CPUs: 2, MemoryMiB: 3840},
+	"c4.xlarge":   {CPUs: 4, MemoryMiB: 7680},
+	"c4.2xlarge":  {CPUs: 8, MemoryMiB: 15360},
+	"c4.4xlarge":  {CPUs: 16, MemoryMiB: 30720},
+	"c4.8xlarge":  {CPUs: 36, MemoryMiB: 61440},
+	"c5.large":    {CPUs: 2, MemoryMiB: 4096},
+	"c5.xlarge":   {CPUs: 4, MemoryMiB: 8192},
+	"c5.2xlarge":  {CPUs: 8, MemoryMiB: 16384},
+	"c5.4xlarge":  {CPUs: 16, MemoryMiB: 32768},
+	"c5.9xlarge":  {CPUs: 36, MemoryMiB: 73728},
+	"c5.12xlarge": {CPUs: 48, MemoryMiB: 98304},
+	"c5.18xlarge": {CPUs: 72, MemoryMiB: 147456},
+	"c5.24xlarge": {CPUs: 96, MemoryMiB: 196608},
+	"m4.large":    {CPUs: 2, MemoryMiB: 8192},
+	"m4.xlarge":   {CPUs: 4, MemoryMiB: 16384},
+	"m4.2xlarge":  {CPUs: 8, MemoryMiB: 32768},
+	"m4.4xlarge":  {CPUs: 16, MemoryMiB: 65536},
+	"m4.10xlarge": {CPUs: 40, MemoryMiB: 163840},
+	"m4.16xlarge": {CPUs: 64, MemoryMiB: 262144},
+	"m5.large":    {CPUs: 2, MemoryMiB: 8192},
+	"m5.xlarge":   {CPUs: 4, MemoryMiB: 16384},
+	"m5.2xlarge":  {CPUs: 8, MemoryMiB: 32768},
+	"m5.4xlarge":  {CPUs: 16, MemoryMiB: 65536},
+	"m5.8xlarge":  {CPUs: 32, MemoryMiB: 131072},
+	"m5.12xlarge": {CPUs: 48, MemoryMiB: 196608},
+	"m5.16xlarge": {CPUs: 64, MemoryMiB: 262144},
+	"m5.24xlarge": {CPUs: 96, MemoryMiB: 393216},
+	"r4.large":    {CPUs: 2, MemoryMiB: 15616},
+	"r4.xlarge":   {CPUs: 4, MemoryMiB: 31232},
+	"r4.2xlarge":  {CPUs: 8, MemoryMiB: 62464},
+	"r4.4xlarge":  {CPUs: 16, MemoryMiB: 124928},
+	"r4.8xlarge":  {CPUs: 32, MemoryMiB: 249856},
+	"r4.16xlarge": {CPUs: 64, MemoryMiB: 499712},
+	"r5.large":    {CPUs: 2, MemoryMiB: 16384},
+	"r5.xlarge":   {CPUs: 4, MemoryMiB: 32768},
+	"r5.2xlarge":  {CPUs: 8, MemoryMiB: 65536},
+	"r5.4xlarge":  {CPUs: 16, MemoryMiB: 131072},
+	"r5.8xlarge":  {CPUs: 32, MemoryMiB: 262144},
+	"r5.12xlarge": {CPUs: 48, MemoryMiB: 393216},
+	"r5.16xlarge": {CPUs: 64, MemoryMiB: 524288},
+	"r5.24xlarge": {CPUs: 96, MemoryMiB: 786432},
+	"t2.medium":   {CPUs: 2, MemoryMiB: 4096},
+	"t2.large":    {CPUs: 2, MemoryMiB: 8192},
+	"t2.xlarge":   {CPUs: 4, MemoryMiB: 16384},
+	"t2.2xlarge":  {CPUs: 8, MemoryMiB: 32768},
+	"t3.medium":   {CPUs: 2, MemoryMiB: 4096},
+	"t3.large":    {CPUs: 2, MemoryMiB: 8192},
+	"t3.xlarge":   {CPUs: 4, MemoryMiB: 16384},
+	"t3.2xlarge":  {CPUs: 8, MemoryMiB: 32768},
+}
+
+// LookupInstanceType returns the vCPUs and memory of the instance type,
+// and whether it is known.
+func LookupInstanceType(name string) (InstanceType, bool) {
+	instanceType, ok := instanceTypes[name]
+	return instanceType, ok
+}
@@ -13,6 +13,7 @@ import (
 	awsprovider "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsproviderconfig/v1alpha1"
 	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
 
+	"github.com/openshift/installer/pkg/rhcos"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/aws"
 )
@@ -56,6 +57,9 @@ func Machines(clusterID string, config *types.InstallConfig, pool *types.Machine
 				},
 			},
 			Spec: clusterapi.MachineSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: gpuLabels(mpool.InstanceType),
+				},
 				ProviderSpec: clusterapi.ProviderSpec{
 					Value: &runtime.RawExtension{Object: provider},
 				},
@@ -71,7 +75,6 @@ func Machines(clusterID string, config *types.InstallConfig, pool *types.Machine
 
 func provider(clusterID, clusterName string, platform *aws.Platform, mpool *aws.MachinePool, osImage string, azIdx int, role, userDataSecret string) (*awsprovider.AWSMachineProviderConfig, error) {
 	az := mpool.Zones[azIdx]
-	amiID := osImage
 	tags, err := tagsFromUserTags(clusterID, clusterName, platform.UserTags)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create awsprovider.TagSpecifications from UserTags")
@@ -91,17 +94,12 @@ func provider(clusterID, clusterName string, platform *aws.Platform, mpool *aws.
 				},
 			},
 		},
-		AMI:                awsprovider.AWSResourceReference{ID: &amiID},
+		AMI:                ami(clusterID, clusterName, platform.Region, osImage),
 		Tags:               tags,
 		IAMInstanceProfile: &awsprovider.AWSResourceReference{ID: pointer.StringPtr(fmt.Sprintf("%s-%s-profile", clusterName, role))},
 		UserDataSecret:     &corev1.LocalObjectReference{Name: userDataSecret},
-		Subnet: awsprovider.AWSResourceReference{
-			Filters: []awsprovider.Filter{{
-				Name:   "tag:Name",
-				Values: []string{fmt.Sprintf("%s-%s-%s", clusterName, role, az)},
-			}},
-		},
-		Placement: awsprovider.Placement{Region: platform.Region, AvailabilityZone: az},
+		Subnet:             subnet(clusterName, mpool, role, az),
+		Placement:          awsprovider.Placement{Region: platform.Region, AvailabilityZone: az},
 		SecurityGroups: []awsprovider.AWSResourceReference{{
 			Filters: []awsprovider.Filter{{
 				Name:   "tag:Name",
@@ -111,6 +109,44 @@ func provider(clusterID, clusterName string, platform *aws.Platform, mpool *aws.
 	}, nil
 }
 
+// ami returns the reference to the AMI of the machines. An AMI of another
+// region is copied into the region of the cluster by Terraform, and is
+// found by the tags of the copy.
+func ami(clusterID, clusterName, region, osImage string) awsprovider.AWSResourceReference {
+	id, sourceRegion := rhcos.SplitAMI(osImage)
+	if sourceRegion == "" {
+		return awsprovider.AWSResourceReference{ID: &id}
+	}
+	return awsprovider.AWSResourceReference{
+		Filters: []awsprovider.Filter{{
+			Name:   "tag:Name",
+			Values: []string{fmt.Sprintf("%s-ami-%s", clusterName, region)},
+		}, {
+			Name:   "tag:openshiftClusterID",
+			Values: []string{clusterID},
+		}},
+	}
+}
+
+// subnet returns the reference to the subnet of the zone in which the
+// machines of the pool are placed. The installer tags the public subnets
+// after the masters and the private ones after the workers.
+func subnet(clusterName string, mpool *aws.MachinePool, role, az string) awsprovider.AWSResourceReference {
+	tier := role
+	switch mpool.Subnet {
+	case aws.PublicSubnet:
+		tier = "master"
+	case aws.PrivateSubnet:
+		tier = "worker"
+	}
+	return awsprovider.AWSResourceReference{
+		Filters: []awsprovider.Filter{{
+			Name:   "tag:Name",
+			Values: []string{fmt.Sprintf("%s-%s-%s", clusterName, tier, az)},
+		}},
+	}
+}
+
 func tagsFromUserTags(clusterID, clusterName string, usertags map[string]string) ([]awsprovider.TagSpecification, error) {
 	tags := []awsprovider.TagSpecification{
 		{Name: "openshiftClusterID", Value: clusterID},
@@ -130,17 +166,17 @@ func tagsFromUserTags(clusterID, clusterName string, usertags map[string]string)
 }
 
 // ConfigMasters sets the PublicIP flag and assigns a set of load balancers to the given machines
-func ConfigMasters(machines []clusterapi.Machine, clusterName string) {
+func ConfigMasters(machines []clusterapi.Machine, infraID string) {
 	for _, machine := range machines {
 		providerSpec := machine.Spec.ProviderSpec.Value.Object.(*awsprovider.AWSMachineProviderConfig)
 		providerSpec.PublicIP = pointer.BoolPtr(true)
 		providerSpec.LoadBalancers = []awsprovider.LoadBalancerReference{
 			{
-				Name: fmt.Sprintf("%s-ext", clusterName),
+				Name: fmt.Sprintf("%s-ext", infraID),
 				Type: awsprovider.NetworkLoadBalancerType,
 			},
 			{
-				Name: fmt.Sprintf("%s-int", clusterName),
+				Name: fmt.Sprintf("%s-int", infraID),
 				Type: awsprovider.NetworkLoadBalancerType,
 			},
 		}
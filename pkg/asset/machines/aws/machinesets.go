@@ -8,12 +8,15 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
 
+	"github.com/openshift/installer/pkg/asset/machines/naming"
+	"github.com/openshift/installer/pkg/asset/machines/replicas"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/pkg/errors"
 )
 
-// MachineSets returns a list of machinesets for a machinepool.
+// MachineSets returns a list of machinesets for a machinepool, one for each
+// of its availability zones, between which its replicas are spread.
 func MachineSets(clusterID string, config *types.InstallConfig, pool *types.MachinePool, osImage, role, userDataSecret string) ([]clusterapi.MachineSet, error) {
 	if configPlatform := config.Platform.Name(); configPlatform != aws.Name {
 		return nil, fmt.Errorf("non-AWS configuration: %q", configPlatform)
@@ -30,27 +33,27 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 	if pool.Replicas != nil {
 		total = *pool.Replicas
 	}
-	numOfAZs := int64(len(azs))
 	var machinesets []clusterapi.MachineSet
 	for idx, az := range azs {
-		replicas := int32(total / numOfAZs)
-		if int64(idx) < total%numOfAZs {
-			replicas++
-		}
+		setReplicas := int32(replicas.Share(total, len(azs), idx))
 
 		provider, err := provider(clusterID, clustername, platform, mpool, osImage, idx, role, userDataSecret)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create provider")
 		}
-		name := fmt.Sprintf("%s-%s-%s", clustername, pool.Name, az)
+		name, err := naming.MachineSetName(pool.NameTemplate, fmt.Sprintf("%s-%s-%s", clustername, pool.Name, az), clustername, role, az)
+		if err != nil {
+			return nil, err
+		}
 		mset := clusterapi.MachineSet{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: "cluster.k8s.io/v1alpha1",
 				Kind:       "MachineSet",
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				Namespace: "openshift-cluster-api",
-				Name:      name,
+				Namespace:   "openshift-cluster-api",
+				Name:        name,
+				Annotations: gpuAnnotations(mpool.InstanceType),
 				Labels: map[string]string{
 					"sigs.k8s.io/cluster-api-cluster":      clustername,
 					"sigs.k8s.io/cluster-api-machine-role": role,
@@ -58,7 +61,7 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 				},
 			},
 			Spec: clusterapi.MachineSetSpec{
-				Replicas: &replicas,
+				Replicas: &setReplicas,
 				Selector: metav1.LabelSelector{
 					MatchLabels: map[string]string{
 						"sigs.k8s.io/cluster-api-machineset": name,
@@ -75,6 +78,9 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 						},
 					},
 					Spec: clusterapi.MachineSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: gpuLabels(mpool.InstanceType),
+						},
 						ProviderSpec: clusterapi.ProviderSpec{
 							Value: &runtime.RawExtension{Object: provider},
 						},
@@ -46,3 +46,42 @@ func fetchAvailabilityZones(client *ec2.EC2, region string) ([]string, error) {
 	}
 	return zones, nil
 }
+
+// InstanceTypeZones retrieves the availability zones of the region which
+// offer the instance type.
+func InstanceTypeZones(region, instanceType string) ([]string, error) {
+	ec2Client := ec2Client(region)
+	zones, err := fetchInstanceTypeZones(ec2Client, instanceType)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch availability zones of instance type %s: %v", instanceType, err)
+	}
+	return zones, nil
+}
+
+// fetchInstanceTypeZones lists the zones of the reserved instance offerings
+// of the instance type, because EC2 has one in every zone which offers it.
+func fetchInstanceTypeZones(client *ec2.EC2, instanceType string) ([]string, error) {
+	req := &ec2.DescribeReservedInstancesOfferingsInput{
+		InstanceType:       aws.String(instanceType),
+		IncludeMarketplace: aws.Bool(false),
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("product-description"),
+			Values: []*string{aws.String("Linux/UNIX")},
+		}},
+	}
+	seen := map[string]bool{}
+	zones := []string{}
+	err := client.DescribeReservedInstancesOfferingsPages(req, func(resp *ec2.DescribeReservedInstancesOfferingsOutput, lastPage bool) bool {
+		for _, offering := range resp.ReservedInstancesOfferings {
+			if zone := aws.StringValue(offering.AvailabilityZone); zone != "" && !seen[zone] {
+				seen[zone] = true
+				zones = append(zones, zone)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
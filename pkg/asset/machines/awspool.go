@@ -0,0 +1,77 @@
+package machines
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset/machines/aws"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+// gpuRootVolumeSize is the default root volume size, in GiB, of machines
+// with GPUs, which need room for the GPU drivers and the large images of
+// GPU workloads.
+const gpuRootVolumeSize = 120
+
+// setAWSMachinePool sets the platform and pool configuration on the pool
+// defaults. Pools of GPU instance types default to a root volume of at
+// least gpuRootVolumeSize.
+func setAWSMachinePool(mpool *awstypes.MachinePool, required ...*awstypes.MachinePool) {
+	for _, r := range required {
+		mpool.Set(r)
+	}
+	if _, ok := aws.GPU(mpool.InstanceType); ok && mpool.Size < gpuRootVolumeSize {
+		// Set again, so that the configured sizes still win.
+		mpool.Size = gpuRootVolumeSize
+		for _, r := range required {
+			mpool.Set(r)
+		}
+	}
+}
+
+// setAWSZones defaults the zones of the pool to the zones of the region.
+// Pools of GPU instance types are limited to the zones which offer the
+// instance type.
+func setAWSZones(mpool *awstypes.MachinePool, region string) error {
+	configured := len(mpool.Zones) > 0
+	if !configured {
+		azs, err := aws.AvailabilityZones(region)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch availability zones")
+		}
+		mpool.Zones = azs
+	}
+	if _, ok := aws.GPU(mpool.InstanceType); !ok {
+		return nil
+	}
+	offered, err := aws.InstanceTypeZones(region, mpool.InstanceType)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch availability zones")
+	}
+	zones, err := offeringZones(mpool.InstanceType, mpool.Zones, offered, configured)
+	if err != nil {
+		return err
+	}
+	mpool.Zones = zones
+	return nil
+}
+
+// offeringZones returns the zones which offer the instance type. It fails
+// if a configured zone does not, or if none of the zones do.
+func offeringZones(instanceType string, zones, offered []string, configured bool) ([]string, error) {
+	offers := map[string]bool{}
+	for _, zone := range offered {
+		offers[zone] = true
+	}
+	var result []string
+	for _, zone := range zones {
+		if offers[zone] {
+			result = append(result, zone)
+		} else if configured {
+			return nil, errors.Errorf("instance type %s is not offered in zone %s", instanceType, zone)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.Errorf("instance type %s is not offered in any zone of the region", instanceType)
+	}
+	return result, nil
+}
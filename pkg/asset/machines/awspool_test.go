@@ -0,0 +1,79 @@
+package machines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestSetAWSMachinePool(t *testing.T) {
+	cases := []struct {
+		name         string
+		required     *awstypes.MachinePool
+		expectedSize int
+	}{
+		{
+			name:         "default",
+			expectedSize: 32,
+		},
+		{
+			name:         "GPU",
+			required:     &awstypes.MachinePool{InstanceType: "p3.2xlarge"},
+			expectedSize: gpuRootVolumeSize,
+		},
+		{
+			name:         "GPU with configured size",
+			required:     &awstypes.MachinePool{InstanceType: "g4dn.xlarge", EC2RootVolume: awstypes.EC2RootVolume{Size: 64}},
+			expectedSize: 64,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mpool := defaultAWSMachinePoolPlatform()
+			mpool.InstanceType = "m4.large"
+			setAWSMachinePool(&mpool, nil, tc.required)
+			assert.Equal(t, tc.expectedSize, mpool.Size)
+		})
+	}
+}
+
+func TestOfferingZones(t *testing.T) {
+	offered := []string{"us-east-1a", "us-east-1c"}
+	cases := []struct {
+		name          string
+		zones         []string
+		configured    bool
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:     "default zones",
+			zones:    []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+			expected: []string{"us-east-1a", "us-east-1c"},
+		},
+		{
+			name:          "configured zone without offering",
+			zones:         []string{"us-east-1a", "us-east-1b"},
+			configured:    true,
+			expectedError: `^instance type p3\.2xlarge is not offered in zone us-east-1b$`,
+		},
+		{
+			name:          "no offering",
+			zones:         []string{"us-east-1b"},
+			expectedError: `^instance type p3\.2xlarge is not offered in any zone of the region$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			zones, err := offeringZones("p3.2xlarge", tc.zones, offered, tc.configured)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, zones)
+			} else {
+				assert.Regexp(t, tc.expectedError, err)
+			}
+		})
+	}
+}
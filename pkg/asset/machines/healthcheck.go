@@ -0,0 +1,80 @@
+package machines
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+var machineHealthCheckTmpl = template.Must(template.New("machine-health-check").Parse(`
+apiVersion: healthchecking.openshift.io/v1alpha1
+kind: MachineHealthCheck
+metadata:
+  name: {{.Name}}
+  namespace: openshift-cluster-api
+spec:
+  selector:
+    matchLabels:
+      sigs.k8s.io/cluster-api-cluster: {{.ClusterName}}
+      sigs.k8s.io/cluster-api-machine-role: {{.Role}}
+`))
+
+// unhealthyConditionsTmpl is the ConfigMap from which the machine API
+// reads the node conditions which mark the machines of all the health
+// checks unhealthy, instead of its defaults of the Ready condition being
+// False or Unknown for 5 minutes.
+var unhealthyConditionsTmpl = template.Must(template.New("node-unhealthy-conditions").Parse(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: node-unhealthy-conditions
+  namespace: openshift-cluster-api
+data:
+  conditions: |
+    items:
+{{- range .}}
+    - name: {{printf "%q" .Type}}
+      status: {{printf "%q" .Status}}
+      timeout: {{printf "%q" .Timeout}}
+{{- end}}
+`))
+
+// machineHealthCheck returns the MachineHealthCheck of the machines of the
+// pool with the role.
+func machineHealthCheck(clusterName string, pool *types.MachinePool, role string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := machineHealthCheckTmpl.Execute(buf, map[string]interface{}{
+		"Name":        clusterName + "-" + pool.Name,
+		"ClusterName": clusterName,
+		"Role":        role,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute machineHealthCheckTmpl")
+	}
+	return buf.Bytes(), nil
+}
+
+// unhealthyConditions returns the ConfigMap of the unhealthy conditions
+// of the pools, which validation checked are the same for all of them,
+// or nil if no pool sets them.
+func unhealthyConditions(pools []types.MachinePool) ([]byte, error) {
+	var conditions []types.UnhealthyCondition
+	for _, pool := range pools {
+		if pool.HealthCheck != nil && len(pool.HealthCheck.UnhealthyConditions) > 0 {
+			conditions = pool.HealthCheck.UnhealthyConditions
+			break
+		}
+	}
+	if conditions == nil {
+		return nil, nil
+	}
+
+	buf := &bytes.Buffer{}
+	if err := unhealthyConditionsTmpl.Execute(buf, conditions); err != nil {
+		return nil, errors.Wrap(err, "failed to execute unhealthyConditionsTmpl")
+	}
+	return buf.Bytes(), nil
+}
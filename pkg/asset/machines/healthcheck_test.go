@@ -0,0 +1,92 @@
+package machines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestMachineHealthCheck(t *testing.T) {
+	cases := []struct {
+		pool     string
+		role     string
+		expected string
+	}{
+		{
+			pool: "worker",
+			role: "worker",
+			expected: `
+apiVersion: healthchecking.openshift.io/v1alpha1
+kind: MachineHealthCheck
+metadata:
+  name: c-worker
+  namespace: openshift-cluster-api
+spec:
+  selector:
+    matchLabels:
+      sigs.k8s.io/cluster-api-cluster: c
+      sigs.k8s.io/cluster-api-machine-role: worker
+`,
+		},
+		{
+			pool: "infra",
+			role: "infra",
+			expected: `
+apiVersion: healthchecking.openshift.io/v1alpha1
+kind: MachineHealthCheck
+metadata:
+  name: c-infra
+  namespace: openshift-cluster-api
+spec:
+  selector:
+    matchLabels:
+      sigs.k8s.io/cluster-api-cluster: c
+      sigs.k8s.io/cluster-api-machine-role: infra
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.pool, func(t *testing.T) {
+			data, err := machineHealthCheck("c", &types.MachinePool{Name: tc.pool, HealthCheck: &types.MachinePoolHealthCheck{}}, tc.role)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, string(data))
+		})
+	}
+}
+
+func TestUnhealthyConditions(t *testing.T) {
+	data, err := unhealthyConditions([]types.MachinePool{
+		{Name: "master"},
+		{Name: "worker", HealthCheck: &types.MachinePoolHealthCheck{}},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, data, "the defaults of the machine API should be used")
+
+	conditions := []types.UnhealthyCondition{
+		{Type: "Ready", Status: "Unknown", Timeout: "10m"},
+		{Type: "DiskPressure", Status: "True", Timeout: "5m"},
+	}
+	data, err = unhealthyConditions([]types.MachinePool{
+		{Name: "worker", HealthCheck: &types.MachinePoolHealthCheck{}},
+		{Name: "infra", HealthCheck: &types.MachinePoolHealthCheck{UnhealthyConditions: conditions}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: node-unhealthy-conditions
+  namespace: openshift-cluster-api
+data:
+  conditions: |
+    items:
+    - name: "Ready"
+      status: "Unknown"
+      timeout: "10m"
+    - name: "DiskPressure"
+      status: "True"
+      timeout: "5m"
+`, string(data))
+}
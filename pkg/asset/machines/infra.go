@@ -0,0 +1,146 @@
+package machines
+
+import (
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/rhcos"
+	"github.com/openshift/installer/pkg/types"
+	nonetypes "github.com/openshift/installer/pkg/types/none"
+)
+
+const (
+	// infraNodeRoleLabel is the label of the infra nodes, on which the
+	// routers and the monitoring stack run.
+	infraNodeRoleLabel = "node-role.kubernetes.io/infra"
+
+	// infraMonitoringConfig places the components of the monitoring
+	// stack on the infra nodes.
+	infraMonitoringConfig = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cluster-monitoring-config
+  namespace: openshift-monitoring
+data:
+  config.yaml: |
+    prometheusOperator: &infra
+      nodeSelector:
+        node-role.kubernetes.io/infra: ""
+      tolerations:
+      - key: node-role.kubernetes.io/infra
+        operator: Exists
+        effect: NoSchedule
+    prometheusK8s: *infra
+    alertmanagerMain: *infra
+    kubeStateMetrics: *infra
+    grafana: *infra
+    telemeterClient: *infra
+    k8sPrometheusAdapter: *infra
+`
+)
+
+// infraTaint keeps the workloads which do not tolerate it off the infra
+// nodes.
+var infraTaint = corev1.Taint{
+	Key:    infraNodeRoleLabel,
+	Effect: corev1.TaintEffectNoSchedule,
+}
+
+// Infra generates the machinesets for the `infra` machine pool, unless its
+// machines are user-provisioned, the health check for them when the pool
+// configures it, and the manifest which places the monitoring stack on
+// them. The machines boot with the worker Ignition config, and their nodes
+// are labeled and tainted as infra nodes.
+type Infra struct {
+	MachineSetRaw         []byte
+	MachineHealthCheckRaw []byte
+	MonitoringConfigRaw   []byte
+}
+
+var _ asset.Asset = (*Infra)(nil)
+
+// Name returns a human friendly name for the Infra Asset.
+func (i *Infra) Name() string {
+	return "Infra Machines"
+}
+
+// Dependencies returns all of the dependencies directly needed by the
+// Infra asset
+func (i *Infra) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.ClusterID{},
+		&installconfig.InstallConfig{},
+		new(rhcos.Image),
+	}
+}
+
+// Generate generates the Infra asset, which is empty if the install-config
+// has no infra pool.
+func (i *Infra) Generate(dependencies asset.Parents) error {
+	clusterID := &installconfig.ClusterID{}
+	installconfig := &installconfig.InstallConfig{}
+	rhcosImage := new(rhcos.Image)
+	dependencies.Get(clusterID, installconfig, rhcosImage)
+
+	ic := installconfig.Config
+	var pool *types.MachinePool
+	for idx := range ic.Machines {
+		if ic.Machines[idx].Name == "infra" {
+			pool = &ic.Machines[idx]
+		}
+	}
+	if pool == nil {
+		return nil
+	}
+	i.MonitoringConfigRaw = []byte(infraMonitoringConfig)
+	if pool.UserProvisioned || ic.Platform.Name() == nonetypes.Name {
+		return nil
+	}
+
+	// The infra machines use the worker instance profiles, security
+	// groups and subnets, and boot as workers, so that the machine config
+	// operator manages them with the worker pool.
+	infraPool := *pool
+	sets, err := machineSets(clusterID.ClusterID, ic, &infraPool, string(*rhcosImage), "worker", "worker-user-data")
+	if err != nil {
+		return errors.Wrap(err, "failed to create infra machine objects")
+	}
+	for idx := range sets {
+		setInfraRole(&sets[idx])
+	}
+	if err := patchMachineSets(&infraPool, sets); err != nil {
+		return err
+	}
+
+	i.MachineSetRaw, err = yaml.Marshal(listFromMachineSets(sets))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal")
+	}
+
+	if pool.HealthCheck != nil {
+		i.MachineHealthCheckRaw, err = machineHealthCheck(ic.ObjectMeta.Name, pool, "infra")
+		if err != nil {
+			return errors.Wrap(err, "failed to create infra machine health check")
+		}
+	}
+	return nil
+}
+
+// setInfraRole gives the machines of the machineset the infra role, and
+// labels and taints their nodes as infra nodes.
+func setInfraRole(set *clusterapi.MachineSet) {
+	for _, labels := range []map[string]string{set.Labels, set.Spec.Template.Labels} {
+		labels["sigs.k8s.io/cluster-api-machine-role"] = "infra"
+		labels["sigs.k8s.io/cluster-api-machine-type"] = "infra"
+	}
+	spec := &set.Spec.Template.Spec
+	if spec.Labels == nil {
+		spec.Labels = map[string]string{}
+	}
+	spec.Labels[infraNodeRoleLabel] = ""
+	spec.Taints = append(spec.Taints, infraTaint)
+}
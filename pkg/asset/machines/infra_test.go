@@ -0,0 +1,43 @@
+package machines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestSetInfraRole(t *testing.T) {
+	roleLabels := func() map[string]string {
+		return map[string]string{
+			"sigs.k8s.io/cluster-api-cluster":      "test-cluster",
+			"sigs.k8s.io/cluster-api-machine-role": "worker",
+			"sigs.k8s.io/cluster-api-machine-type": "worker",
+		}
+	}
+	set := clusterapi.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-infra-us-east-1a", Labels: roleLabels()},
+		Spec: clusterapi.MachineSetSpec{
+			Template: clusterapi.MachineTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: roleLabels()},
+				Spec: clusterapi.MachineSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"cluster-api/accelerator": "nvidia-tesla-k80"}},
+				},
+			},
+		},
+	}
+	setInfraRole(&set)
+
+	for _, labels := range []map[string]string{set.Labels, set.Spec.Template.Labels} {
+		assert.Equal(t, "test-cluster", labels["sigs.k8s.io/cluster-api-cluster"])
+		assert.Equal(t, "infra", labels["sigs.k8s.io/cluster-api-machine-role"])
+		assert.Equal(t, "infra", labels["sigs.k8s.io/cluster-api-machine-type"])
+	}
+	assert.Equal(t, map[string]string{
+		"cluster-api/accelerator":       "nvidia-tesla-k80",
+		"node-role.kubernetes.io/infra": "",
+	}, set.Spec.Template.Spec.Labels)
+	assert.Equal(t, []corev1.Taint{{Key: "node-role.kubernetes.io/infra", Effect: corev1.TaintEffectNoSchedule}}, set.Spec.Template.Spec.Taints)
+}
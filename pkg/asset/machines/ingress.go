@@ -0,0 +1,118 @@
+package machines
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	// maxRouterReplicas is the number of routers which the ingress operator
+	// runs by default, each on its own node.
+	maxRouterReplicas = 2
+
+	// DefaultCertificateSecretName is the name of the secret, in the
+	// openshift-ingress namespace, holding the default certificate of the
+	// install-config, which the default IngressController refers to. It is
+	// not the router-certs-default secret, which the ingress operator
+	// manages and would overwrite.
+	DefaultCertificateSecretName = "installer-ingress-default-certificate"
+)
+
+var ingressControllerTmpl = template.Must(template.New("ingress-controller").Parse(`apiVersion: operator.openshift.io/v1
+kind: IngressController
+metadata:
+  name: default
+  namespace: openshift-ingress-operator
+spec:
+{{- with .Replicas}}
+  replicas: {{.}}
+{{- end}}
+{{- with .DefaultCertificate}}
+  defaultCertificate:
+    name: {{.}}
+{{- end}}
+{{- if or .NodeSelector .Infra}}
+  nodePlacement:
+    nodeSelector:
+      matchLabels:
+{{- range $key, $value := .NodeSelector}}
+        {{printf "%q" $key}}: {{printf "%q" $value}}
+{{- end}}
+{{- if .Infra}}
+    tolerations:
+    - key: node-role.kubernetes.io/infra
+      operator: Exists
+      effect: NoSchedule
+{{- end}}
+{{- end}}
+{{- with .EndpointPublishingStrategy}}
+  endpointPublishingStrategy:
+    type: {{.}}
+{{- end}}
+`))
+
+// IngressController returns the manifest of the default IngressController,
+// or nil if the ingress operator defaults fit the install-config. It serves
+// the default certificate of the install-config, if there is one. The
+// routers run on the nodes selected by the ingress configuration, or else on
+// the infra nodes if there is an infra pool, and on the workers otherwise.
+// If the machines of that pool are user-provisioned, there are fewer routers
+// when the pool has fewer replicas than the default, because the installer
+// cannot scale the pool for them.
+func IngressController(config *types.InstallConfig) ([]byte, error) {
+	var pool *types.MachinePool
+	infra := false
+	for idx := range config.Machines {
+		switch config.Machines[idx].Name {
+		case "infra":
+			pool, infra = &config.Machines[idx], true
+		case "worker":
+			if !infra {
+				pool = &config.Machines[idx]
+			}
+		}
+	}
+
+	var replicas int64
+	if pool != nil && pool.UserProvisioned && pool.Replicas != nil && *pool.Replicas < maxRouterReplicas {
+		replicas = *pool.Replicas
+	}
+	nodeSelector := map[string]string{}
+	if infra {
+		nodeSelector["node-role.kubernetes.io/infra"] = ""
+	}
+	var strategy types.EndpointPublishingStrategy
+	var defaultCertificate string
+	if ingress := config.Ingress; ingress != nil {
+		if ingress.DefaultCertificate != nil {
+			defaultCertificate = DefaultCertificateSecretName
+		}
+		if ingress.Replicas != nil {
+			replicas = int64(*ingress.Replicas)
+		}
+		if len(ingress.NodeSelector) > 0 {
+			nodeSelector = ingress.NodeSelector
+		}
+		strategy = ingress.EndpointPublishingStrategy
+	}
+	if !infra && replicas == 0 && defaultCertificate == "" && len(nodeSelector) == 0 && strategy == "" {
+		return nil, nil
+	}
+
+	buf := &bytes.Buffer{}
+	data := struct {
+		Replicas                   int64
+		DefaultCertificate         string
+		NodeSelector               map[string]string
+		Infra                      bool
+		EndpointPublishingStrategy types.EndpointPublishingStrategy
+	}{Replicas: replicas, DefaultCertificate: defaultCertificate, NodeSelector: nodeSelector, Infra: infra, EndpointPublishingStrategy: strategy}
+	if err := ingressControllerTmpl.Execute(buf, data); err != nil {
+		return nil, errors.Wrap(err, "failed to execute ingress controller template")
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,135 @@
+package machines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestIngressController(t *testing.T) {
+	replicas := func(x int64) *int64 { return &x }
+	cases := []struct {
+		name     string
+		machines []types.MachinePool
+		ingress  *types.Ingress
+		expected string
+	}{
+		{
+			name:     "default",
+			machines: []types.MachinePool{{Name: "master"}, {Name: "worker", Replicas: replicas(1)}},
+		},
+		{
+			name:     "user-provisioned workers",
+			machines: []types.MachinePool{{Name: "master"}, {Name: "worker", Replicas: replicas(1), UserProvisioned: true}},
+			expected: `apiVersion: operator.openshift.io/v1
+kind: IngressController
+metadata:
+  name: default
+  namespace: openshift-ingress-operator
+spec:
+  replicas: 1
+`,
+		},
+		{
+			name:     "enough user-provisioned workers",
+			machines: []types.MachinePool{{Name: "master"}, {Name: "worker", Replicas: replicas(3), UserProvisioned: true}},
+		},
+		{
+			name: "infra",
+			machines: []types.MachinePool{
+				{Name: "worker", Replicas: replicas(1), UserProvisioned: true},
+				{Name: "infra", Replicas: replicas(3)},
+			},
+			expected: `apiVersion: operator.openshift.io/v1
+kind: IngressController
+metadata:
+  name: default
+  namespace: openshift-ingress-operator
+spec:
+  nodePlacement:
+    nodeSelector:
+      matchLabels:
+        "node-role.kubernetes.io/infra": ""
+    tolerations:
+    - key: node-role.kubernetes.io/infra
+      operator: Exists
+      effect: NoSchedule
+`,
+		},
+		{
+			name: "user-provisioned infra",
+			machines: []types.MachinePool{
+				{Name: "infra", Replicas: replicas(1), UserProvisioned: true},
+				{Name: "worker", Replicas: replicas(3)},
+			},
+			expected: `apiVersion: operator.openshift.io/v1
+kind: IngressController
+metadata:
+  name: default
+  namespace: openshift-ingress-operator
+spec:
+  replicas: 1
+  nodePlacement:
+    nodeSelector:
+      matchLabels:
+        "node-role.kubernetes.io/infra": ""
+    tolerations:
+    - key: node-role.kubernetes.io/infra
+      operator: Exists
+      effect: NoSchedule
+`,
+		},
+		{
+			name:     "ingress configuration",
+			machines: []types.MachinePool{{Name: "master"}, {Name: "worker", Replicas: replicas(3)}},
+			ingress: &types.Ingress{
+				Replicas:                   func(x int32) *int32 { return &x }(3),
+				NodeSelector:               map[string]string{"node-role.kubernetes.io/router": ""},
+				EndpointPublishingStrategy: types.HostNetworkStrategy,
+			},
+			expected: `apiVersion: operator.openshift.io/v1
+kind: IngressController
+metadata:
+  name: default
+  namespace: openshift-ingress-operator
+spec:
+  replicas: 3
+  nodePlacement:
+    nodeSelector:
+      matchLabels:
+        "node-role.kubernetes.io/router": ""
+  endpointPublishingStrategy:
+    type: HostNetwork
+`,
+		},
+		{
+			name:     "default certificate",
+			machines: []types.MachinePool{{Name: "master"}, {Name: "worker", Replicas: replicas(3)}},
+			ingress: &types.Ingress{
+				DefaultCertificate: &types.ServingCertificate{Certificate: "cert", Key: "key"},
+			},
+			expected: `apiVersion: operator.openshift.io/v1
+kind: IngressController
+metadata:
+  name: default
+  namespace: openshift-ingress-operator
+spec:
+  defaultCertificate:
+    name: installer-ingress-default-certificate
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := IngressController(&types.InstallConfig{Machines: tc.machines, Ingress: tc.ingress})
+			assert.NoError(t, err)
+			if tc.expected == "" {
+				assert.Nil(t, data)
+			} else {
+				assert.Equal(t, tc.expected, string(data))
+			}
+		})
+	}
+}
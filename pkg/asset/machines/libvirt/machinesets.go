@@ -9,6 +9,7 @@ import (
 	"k8s.io/utils/pointer"
 	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
 
+	"github.com/openshift/installer/pkg/asset/machines/naming"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/libvirt"
 )
@@ -33,7 +34,10 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 	}
 
 	provider := provider(clustername, config.Networking.MachineCIDR.String(), platform, userDataSecret)
-	name := fmt.Sprintf("%s-%s-%d", clustername, pool.Name, 0)
+	name, err := naming.MachineSetName(pool.NameTemplate, fmt.Sprintf("%s-%s-%d", clustername, pool.Name, 0), clustername, role, "")
+	if err != nil {
+		return nil, err
+	}
 	mset := clusterapi.MachineSet{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "cluster.k8s.io/v1alpha1",
@@ -64,81 +64,61 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 
 	ic := installconfig.Config
 	pool := masterPool(ic.Machines)
+	var machines []clusterapi.Machine
 	switch ic.Platform.Name() {
 	case awstypes.Name:
 		mpool := defaultAWSMachinePoolPlatform()
 		mpool.InstanceType = "m4.xlarge"
 		mpool.EC2RootVolume.Size = 120
-		mpool.Set(ic.Platform.AWS.DefaultMachinePlatform)
-		mpool.Set(pool.Platform.AWS)
-		if len(mpool.Zones) == 0 {
-			azs, err := aws.AvailabilityZones(ic.Platform.AWS.Region)
-			if err != nil {
-				return errors.Wrap(err, "failed to fetch availability zones")
-			}
-			mpool.Zones = azs
+		setAWSMachinePool(&mpool, ic.Platform.AWS.DefaultMachinePlatform, pool.Platform.AWS)
+		if err := setAWSZones(&mpool, ic.Platform.AWS.Region); err != nil {
+			return err
+		}
+		if err := checkAWSResources("master", &mpool); err != nil {
+			return err
 		}
 		pool.Platform.AWS = &mpool
-		machines, err := aws.Machines(clusterID.ClusterID, ic, &pool, string(*rhcosImage), "master", "master-user-data")
+		machines, err = aws.Machines(clusterID.ClusterID, ic, &pool, string(*rhcosImage), "master", "master-user-data")
 		if err != nil {
 			return errors.Wrap(err, "failed to create master machine objects")
 		}
-		aws.ConfigMasters(machines, ic.ObjectMeta.Name)
-
-		list := listFromMachines(machines)
-		raw, err := yaml.Marshal(list)
-		if err != nil {
-			return errors.Wrap(err, "failed to marshal")
-		}
-		m.MachinesRaw = raw
+		aws.ConfigMasters(machines, types.InfraID(ic.ObjectMeta.Name, clusterID.ClusterID))
 	case libvirttypes.Name:
 		mpool := defaultLibvirtMachinePoolPlatform()
 		mpool.Set(ic.Platform.Libvirt.DefaultMachinePlatform)
 		mpool.Set(pool.Platform.Libvirt)
 		pool.Platform.Libvirt = &mpool
-		machines, err := libvirt.Machines(clusterID.ClusterID, ic, &pool, "master", "master-user-data")
-		if err != nil {
-			return errors.Wrap(err, "failed to create master machine objects")
-		}
-
-		list := listFromMachines(machines)
-		raw, err := yaml.Marshal(list)
-		if err != nil {
-			return errors.Wrap(err, "failed to marshal")
-		}
-		m.MachinesRaw = raw
+		machines, err = libvirt.Machines(clusterID.ClusterID, ic, &pool, "master", "master-user-data")
 	case nonetypes.Name:
+		return nil
 	case openstacktypes.Name:
-		numOfMasters := int64(0)
-		if pool.Replicas != nil {
-			numOfMasters = *pool.Replicas
-		}
-		instances := []string{}
-		for i := 0; i < int(numOfMasters); i++ {
-			instances = append(instances, fmt.Sprintf("master-%d", i))
+		mpool := defaultOpenStackMachinePoolPlatform(ic.Platform.OpenStack.FlavorName)
+		mpool.Set(ic.Platform.OpenStack.DefaultMachinePlatform)
+		mpool.Set(pool.Platform.OpenStack)
+		pool.Platform.OpenStack = &mpool
+		if err := checkOpenStackResources("master", ic.Platform.OpenStack.Cloud, &mpool); err != nil {
+			return err
 		}
-		config := openstack.MasterConfig{
-			CloudName:   ic.Platform.OpenStack.Cloud,
-			ClusterName: ic.ObjectMeta.Name,
-			Instances:   instances,
-			Image:       string(*rhcosImage),
-			Region:      ic.Platform.OpenStack.Region,
-			Machine:     defaultOpenStackMachinePoolPlatform(ic.Platform.OpenStack.FlavorName),
-			Trunk:       trunkSupportBoolean(ic.Platform.OpenStack.TrunkSupport),
-		}
-
-		tags := map[string]string{
-			"openshiftClusterID": clusterID.ClusterID,
+		var accelerator string
+		accelerator, err = openstack.FlavorAccelerator(ic.Platform.OpenStack.Cloud, mpool.FlavorName)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch the accelerator of the master flavor")
 		}
-		config.Tags = tags
-
-		config.Machine.Set(ic.Platform.OpenStack.DefaultMachinePlatform)
-		config.Machine.Set(pool.Platform.OpenStack)
-
-		m.MachinesRaw = applyTemplateData(openstack.MasterMachinesTmpl, config)
+		machines, err = openstack.Machines(clusterID.ClusterID, ic, &pool, string(*rhcosImage), accelerator, "master", "master-user-data")
 	default:
 		return fmt.Errorf("invalid Platform")
 	}
+	if err != nil {
+		return errors.Wrap(err, "failed to create master machine objects")
+	}
+	if err := patchMachines(&pool, machines); err != nil {
+		return err
+	}
+
+	m.MachinesRaw, err = yaml.Marshal(listFromMachines(machines))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal")
+	}
 	return nil
 }
 
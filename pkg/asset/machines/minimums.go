@@ -0,0 +1,83 @@
+package machines
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/machines/aws"
+	"github.com/openshift/installer/pkg/asset/machines/openstack"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	openstacktypes "github.com/openshift/installer/pkg/types/openstack"
+)
+
+// resources are the vCPUs, memory and root disk of the machines of a pool.
+// Zero amounts are unknown, and are not checked.
+type resources struct {
+	cpus      int64
+	memoryMiB int64
+	diskGiB   int64
+}
+
+// minimumResources are the minimum resources of the machines of each role.
+// The masters run etcd and the control plane, on top of the workloads of
+// the workers. The other pools, such as infra, need those of the workers.
+var minimumResources = map[string]resources{
+	"master": {cpus: 4, memoryMiB: 16 * 1024, diskGiB: 120},
+	"worker": {cpus: 2, memoryMiB: 8 * 1024, diskGiB: 32},
+}
+
+// checkResources checks that the resources of the machines of the role,
+// of the named instance type or flavor, meet the minimum resources of the
+// role.
+func checkResources(role string, machineType string, actual resources) error {
+	minimum, ok := minimumResources[role]
+	if !ok {
+		minimum = minimumResources["worker"]
+	}
+
+	var short []string
+	if actual.cpus > 0 && actual.cpus < minimum.cpus {
+		short = append(short, fmt.Sprintf("%d vCPUs, but at least %d are required", actual.cpus, minimum.cpus))
+	}
+	if actual.memoryMiB > 0 && actual.memoryMiB < minimum.memoryMiB {
+		short = append(short, fmt.Sprintf("%d MiB of memory, but at least %d MiB are required", actual.memoryMiB, minimum.memoryMiB))
+	}
+	if actual.diskGiB > 0 && actual.diskGiB < minimum.diskGiB {
+		short = append(short, fmt.Sprintf("a %d GiB root disk, but at least %d GiB are required", actual.diskGiB, minimum.diskGiB))
+	}
+	if len(short) > 0 {
+		return errors.Errorf("%s is too small for the %s machines: it has %s", machineType, role, strings.Join(short, "; "))
+	}
+	return nil
+}
+
+// checkAWSResources checks the resources of the machines of the role, of
+// the instance type and root volume of the pool. Unknown instance types
+// only have their root volume checked.
+func checkAWSResources(role string, mpool *awstypes.MachinePool) error {
+	actual := resources{diskGiB: int64(mpool.Size)}
+	if instanceType, ok := aws.LookupInstanceType(mpool.InstanceType); ok {
+		actual.cpus = instanceType.CPUs
+		actual.memoryMiB = instanceType.MemoryMiB
+	} else {
+		logrus.Debugf("Skipping the check of the vCPUs and memory of the unknown instance type %s", mpool.InstanceType)
+	}
+	return checkResources(role, fmt.Sprintf("the %s instance type", mpool.InstanceType), actual)
+}
+
+// checkOpenStackResources checks the resources of the machines of the
+// role, of the flavor of the pool.
+func checkOpenStackResources(role string, cloud string, mpool *openstacktypes.MachinePool) error {
+	flavor, err := openstack.LookupFlavor(cloud, mpool.FlavorName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch the resources of the %s flavor", role)
+	}
+	return checkResources(role, fmt.Sprintf("the %s flavor", mpool.FlavorName), resources{
+		cpus:      flavor.CPUs,
+		memoryMiB: flavor.MemoryMiB,
+		diskGiB:   flavor.DiskGiB,
+	})
+}
@@ -0,0 +1,59 @@
+package machines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestCheckResources(t *testing.T) {
+	cases := []struct {
+		name          string
+		role          string
+		actual        resources
+		expectedError string
+	}{
+		{
+			name:   "master",
+			role:   "master",
+			actual: resources{cpus: 4, memoryMiB: 16384, diskGiB: 120},
+		},
+		{
+			name:          "small master",
+			role:          "master",
+			actual:        resources{cpus: 2, memoryMiB: 8192, diskGiB: 120},
+			expectedError: "m1.small is too small for the master machines: it has 2 vCPUs, but at least 4 are required; 8192 MiB of memory, but at least 16384 MiB are required",
+		},
+		{
+			name:   "unknown resources",
+			role:   "master",
+			actual: resources{},
+		},
+		{
+			name:          "infra",
+			role:          "infra",
+			actual:        resources{cpus: 2, memoryMiB: 8192, diskGiB: 16},
+			expectedError: "m1.small is too small for the infra machines: it has a 16 GiB root disk, but at least 32 GiB are required",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkResources(tc.role, "m1.small", tc.actual)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestCheckAWSResources(t *testing.T) {
+	assert.NoError(t, checkAWSResources("master", &awstypes.MachinePool{InstanceType: "m4.xlarge", EC2RootVolume: awstypes.EC2RootVolume{Size: 120}}))
+	assert.NoError(t, checkAWSResources("worker", &awstypes.MachinePool{InstanceType: "m4.large", EC2RootVolume: awstypes.EC2RootVolume{Size: 32}}))
+	assert.NoError(t, checkAWSResources("master", &awstypes.MachinePool{InstanceType: "x1.16xlarge", EC2RootVolume: awstypes.EC2RootVolume{Size: 120}}))
+	assert.EqualError(t, checkAWSResources("master", &awstypes.MachinePool{InstanceType: "m4.large", EC2RootVolume: awstypes.EC2RootVolume{Size: 120}}),
+		"the m4.large instance type is too small for the master machines: it has 2 vCPUs, but at least 4 are required; 8192 MiB of memory, but at least 16384 MiB are required")
+}
@@ -0,0 +1,57 @@
+// Package naming names the machinesets of a machine pool after its name
+// template.
+package naming
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// maxLength is the maximum length of a machineset name. The machineset
+// controller names its machines after it with a hyphen and 5 random
+// characters, and the hostnames of the machines must fit in a DNS label.
+const maxLength = validation.DNS1123LabelMaxLength - 6
+
+// hyphens matches the runs of hyphens which empty placeholders leave.
+var hyphens = regexp.MustCompile(`-{2,}`)
+
+// MachineSetName returns the name of the machineset of the zone, rendered
+// from the name template of the pool, or the default name if the template
+// is empty. An empty zone leaves out the {zone} placeholder with its hyphen.
+func MachineSetName(template, defaultName, clusterName, role, zone string) (string, error) {
+	if template == "" {
+		return defaultName, nil
+	}
+	name := strings.NewReplacer(
+		"{clusterName}", clusterName,
+		"{role}", role,
+		"{zone}", zone,
+	).Replace(template)
+	if zone == "" {
+		name = strings.Trim(hyphens.ReplaceAllString(name, "-"), "-")
+	}
+	if len(name) > maxLength {
+		return "", errors.Errorf("machineset name %q from template %q is longer than %d characters", name, template, maxLength)
+	}
+	if msgs := validation.IsDNS1123Label(name); len(msgs) > 0 {
+		return "", errors.Errorf("machineset name %q from template %q is invalid: %s", name, template, strings.Join(msgs, ", "))
+	}
+	return name, nil
+}
+
+// Unique checks that the names of the machinesets of a pool differ, which
+// requires the {zone} placeholder in the templates of pools with more than
+// one zone.
+func Unique(template string, names []string) error {
+	seen := map[string]bool{}
+	for _, name := range names {
+		if seen[name] {
+			return errors.Errorf("machineset name %q from template %q is used for more than one zone; add the {zone} placeholder", name, template)
+		}
+		seen[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package naming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMachineSetName(t *testing.T) {
+	cases := []struct {
+		name          string
+		template      string
+		zone          string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "default",
+			zone:     "us-east-1a",
+			expected: "test-cluster-worker-us-east-1a",
+		},
+		{
+			name:     "prefix",
+			template: "ocp-{role}-{zone}",
+			zone:     "us-east-1a",
+			expected: "ocp-worker-us-east-1a",
+		},
+		{
+			name:     "without zone",
+			template: "{clusterName}-{zone}-{role}",
+			expected: "test-cluster-worker",
+		},
+		{
+			name:     "trailing zone without zone",
+			template: "{clusterName}-{role}-{zone}",
+			expected: "test-cluster-worker",
+		},
+		{
+			name:          "too long",
+			template:      "{clusterName}-{role}-{zone}-0123456789012345678901234567890123456789",
+			zone:          "us-east-1a",
+			expectedError: `^machineset name "test-cluster-worker-us-east-1a-0123456789012345678901234567890123456789" from template ".*" is longer than 57 characters$`,
+		},
+		{
+			name:          "invalid",
+			template:      "{zone}-",
+			zone:          "us-east-1a",
+			expectedError: `^machineset name "us-east-1a-" from template "{zone}-" is invalid: `,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := MachineSetName(tc.template, "test-cluster-worker-"+tc.zone, "test-cluster", "worker", tc.zone)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, name)
+			} else {
+				assert.Regexp(t, tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestUnique(t *testing.T) {
+	assert.NoError(t, Unique("{role}-{zone}", []string{"worker-a", "worker-b"}))
+	assert.EqualError(t, Unique("{role}", []string{"worker", "worker"}), `machineset name "worker" from template "{role}" is used for more than one zone; add the {zone} placeholder`)
+}
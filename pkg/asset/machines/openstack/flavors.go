@@ -0,0 +1,41 @@
+package openstack
+
+import (
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/pkg/errors"
+)
+
+// Flavor is the number of vCPUs, the memory and the root disk of a flavor.
+type Flavor struct {
+	// CPUs is the number of vCPUs.
+	CPUs int64
+
+	// MemoryMiB is the memory, in MiB.
+	MemoryMiB int64
+
+	// DiskGiB is the size of the root disk, in GiB, or zero if the root
+	// disk takes the size of the image.
+	DiskGiB int64
+}
+
+// LookupFlavor returns the vCPUs, memory and root disk of the flavor.
+func LookupFlavor(cloud, name string) (Flavor, error) {
+	conn, err := clientconfig.NewServiceClient("compute", &clientconfig.ClientOpts{Cloud: cloud})
+	if err != nil {
+		return Flavor{}, errors.Wrap(err, "failed to create compute client")
+	}
+	id, err := flavors.IDFromName(conn, name)
+	if err != nil {
+		return Flavor{}, errors.Wrapf(err, "failed to find flavor %s", name)
+	}
+	flavor, err := flavors.Get(conn, id).Extract()
+	if err != nil {
+		return Flavor{}, errors.Wrapf(err, "failed to get flavor %s", name)
+	}
+	return Flavor{
+		CPUs:      int64(flavor.VCPUs),
+		MemoryMiB: int64(flavor.RAM),
+		DiskGiB:   int64(flavor.Disk),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package openstack
+
+import (
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/pkg/errors"
+)
+
+// AcceleratorLabel is the node label which identifies the GPU model of the
+// node, which the cluster autoscaler uses to scale GPU pools.
+const AcceleratorLabel = "cluster-api/accelerator"
+
+// pciPassthroughAlias is the flavor extra spec which lists the PCI devices,
+// such as GPUs, passed through to the servers of the flavor, as
+// comma-separated <alias>:<count> pairs.
+const pciPassthroughAlias = "pci_passthrough:alias"
+
+// FlavorAccelerator returns the alias of the PCI device, usually a GPU,
+// passed through to the servers of the flavor, or an empty string if the
+// flavor has none.
+func FlavorAccelerator(cloud, flavor string) (string, error) {
+	conn, err := clientconfig.NewServiceClient("compute", &clientconfig.ClientOpts{Cloud: cloud})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create compute client")
+	}
+	id, err := flavors.IDFromName(conn, flavor)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find flavor %s", flavor)
+	}
+	specs, err := flavors.ListExtraSpecs(conn, id).Extract()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to list the extra specs of flavor %s", flavor)
+	}
+	alias := strings.SplitN(specs[pciPassthroughAlias], ",", 2)[0]
+	return strings.SplitN(alias, ":", 2)[0], nil
+}
+
+// acceleratorLabels returns the node labels which identify the accelerator,
+// or nil if there is none.
+func acceleratorLabels(accelerator string) map[string]string {
+	if accelerator == "" {
+		return nil
+	}
+	return map[string]string{AcceleratorLabel: accelerator}
+}
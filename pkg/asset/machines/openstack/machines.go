@@ -0,0 +1,87 @@
+// Package openstack generates Machine objects for openstack.
+package openstack
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/openstack"
+)
+
+// Machines returns a list of machines for a machinepool.
+func Machines(clusterID string, config *types.InstallConfig, pool *types.MachinePool, osImage, accelerator, role, userDataSecret string) ([]clusterapi.Machine, error) {
+	if configPlatform := config.Platform.Name(); configPlatform != openstack.Name {
+		return nil, fmt.Errorf("non-OpenStack configuration: %q", configPlatform)
+	}
+	if poolPlatform := pool.Platform.Name(); poolPlatform != openstack.Name {
+		return nil, fmt.Errorf("non-OpenStack machine-pool: %q", poolPlatform)
+	}
+	clustername := config.ObjectMeta.Name
+	platform := config.Platform.OpenStack
+	mpool := pool.Platform.OpenStack
+
+	total := int64(1)
+	if pool.Replicas != nil {
+		total = *pool.Replicas
+	}
+	var machines []clusterapi.Machine
+	for idx := int64(0); idx < total; idx++ {
+		var zone string
+		if len(mpool.Zones) > 0 {
+			zone = mpool.Zones[int(idx)%len(mpool.Zones)]
+		}
+		provider := provider(clusterID, platform, mpool, osImage, zone, role, userDataSecret)
+		machine := clusterapi.Machine{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "cluster.k8s.io/v1alpha1",
+				Kind:       "Machine",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "openshift-cluster-api",
+				Name:      fmt.Sprintf("%s-%s-%d", clustername, pool.Name, idx),
+				Labels: map[string]string{
+					"sigs.k8s.io/cluster-api-cluster":      clustername,
+					"sigs.k8s.io/cluster-api-machine-role": role,
+					"sigs.k8s.io/cluster-api-machine-type": role,
+				},
+			},
+			Spec: clusterapi.MachineSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: acceleratorLabels(accelerator),
+				},
+				ProviderSpec: clusterapi.ProviderSpec{
+					Value: &runtime.RawExtension{Object: provider},
+				},
+				// we don't need to set Versions, because we control those via operators.
+			},
+		}
+		machines = append(machines, machine)
+	}
+
+	return machines, nil
+}
+
+func provider(clusterID string, platform *openstack.Platform, mpool *openstack.MachinePool, osImage, zone, role, userDataSecret string) *openstackProviderSpec {
+	return &openstackProviderSpec{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "openstack.cluster.k8s.io/v1alpha1",
+			Kind:       "OpenStackMachineProviderConfig",
+		},
+		CloudName:    platform.Cloud,
+		CloudsSecret: "openstack-credentials",
+		Image:        osImage,
+		Flavor:       mpool.FlavorName,
+		Placement:    openstackPlacement{Region: platform.Region, AvailabilityZone: zone},
+		Networks: []openstackNetwork{{
+			Filter: openstackNetworkFilter{Tags: fmt.Sprintf("openshiftClusterID=%s", clusterID)},
+		}},
+		SecurityGroups: []string{role},
+		UserDataSecret: &corev1.LocalObjectReference{Name: userDataSecret},
+		Trunk:          platform.TrunkSupport == "1",
+	}
+}
@@ -0,0 +1,99 @@
+package openstack
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+
+	"github.com/openshift/installer/pkg/asset/machines/naming"
+	"github.com/openshift/installer/pkg/asset/machines/replicas"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/openstack"
+)
+
+// MachineSets returns a list of machinesets for a machinepool, one for each
+// of its availability zones, between which its replicas are spread.
+func MachineSets(clusterID string, config *types.InstallConfig, pool *types.MachinePool, osImage, accelerator, role, userDataSecret string) ([]clusterapi.MachineSet, error) {
+	if configPlatform := config.Platform.Name(); configPlatform != openstack.Name {
+		return nil, fmt.Errorf("non-OpenStack configuration: %q", configPlatform)
+	}
+	if poolPlatform := pool.Platform.Name(); poolPlatform != openstack.Name {
+		return nil, fmt.Errorf("non-OpenStack machine-pool: %q", poolPlatform)
+	}
+	clustername := config.ObjectMeta.Name
+	platform := config.Platform.OpenStack
+	mpool := pool.Platform.OpenStack
+
+	total := int64(0)
+	if pool.Replicas != nil {
+		total = *pool.Replicas
+	}
+
+	// Without zones, a single machineset places the machines in the
+	// default zone of the region.
+	zones := mpool.Zones
+	if len(zones) == 0 {
+		zones = []string{""}
+	}
+	var machinesets []clusterapi.MachineSet
+	for idx, zone := range zones {
+		provider := provider(clusterID, platform, mpool, osImage, zone, role, userDataSecret)
+		defaultName := fmt.Sprintf("%s-%s", clustername, pool.Name)
+		if zone != "" {
+			defaultName = fmt.Sprintf("%s-%s", defaultName, zone)
+		}
+		name, err := naming.MachineSetName(pool.NameTemplate, defaultName, clustername, role, zone)
+		if err != nil {
+			return nil, err
+		}
+		mset := clusterapi.MachineSet{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "cluster.k8s.io/v1alpha1",
+				Kind:       "MachineSet",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "openshift-cluster-api",
+				Name:      name,
+				Labels: map[string]string{
+					"sigs.k8s.io/cluster-api-cluster":      clustername,
+					"sigs.k8s.io/cluster-api-machine-role": role,
+					"sigs.k8s.io/cluster-api-machine-type": role,
+				},
+			},
+			Spec: clusterapi.MachineSetSpec{
+				Replicas: pointer.Int32Ptr(int32(replicas.Share(total, len(zones), idx))),
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"sigs.k8s.io/cluster-api-machineset": name,
+						"sigs.k8s.io/cluster-api-cluster":    clustername,
+					},
+				},
+				Template: clusterapi.MachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"sigs.k8s.io/cluster-api-machineset":   name,
+							"sigs.k8s.io/cluster-api-cluster":      clustername,
+							"sigs.k8s.io/cluster-api-machine-role": role,
+							"sigs.k8s.io/cluster-api-machine-type": role,
+						},
+					},
+					Spec: clusterapi.MachineSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: acceleratorLabels(accelerator),
+						},
+						ProviderSpec: clusterapi.ProviderSpec{
+							Value: &runtime.RawExtension{Object: provider},
+						},
+						// we don't need to set Versions, because we control those via cluster operators.
+					},
+				},
+			},
+		}
+		machinesets = append(machinesets, mset)
+	}
+
+	return machinesets, nil
+}
@@ -0,0 +1,50 @@
+package openstack
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// openstackProviderSpec is the part of the OpenStack actuator's
+// OpenStackMachineProviderConfig which the installer sets. The actuator's
+// API is not vendored, so it is mirrored here.
+type openstackProviderSpec struct {
+	metav1.TypeMeta `json:",inline"`
+
+	CloudName      string                       `json:"cloudName"`
+	CloudsSecret   string                       `json:"cloudsSecret"`
+	Image          string                       `json:"image"`
+	Flavor         string                       `json:"flavor"`
+	Placement      openstackPlacement           `json:"placement"`
+	Networks       []openstackNetwork           `json:"networks"`
+	SecurityGroups []string                     `json:"securityGroups"`
+	UserDataSecret *corev1.LocalObjectReference `json:"userDataSecret"`
+	Trunk          bool                         `json:"trunk"`
+}
+
+type openstackPlacement struct {
+	Region           string `json:"region"`
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+}
+
+type openstackNetwork struct {
+	Filter openstackNetworkFilter `json:"filter"`
+}
+
+type openstackNetworkFilter struct {
+	Tags string `json:"tags"`
+}
+
+var _ runtime.Object = (*openstackProviderSpec)(nil)
+
+// DeepCopyObject returns a deep copy of the provider spec.
+func (p *openstackProviderSpec) DeepCopyObject() runtime.Object {
+	out := *p
+	out.Networks = append([]openstackNetwork(nil), p.Networks...)
+	out.SecurityGroups = append([]string(nil), p.SecurityGroups...)
+	if p.UserDataSecret != nil {
+		out.UserDataSecret = p.UserDataSecret.DeepCopy()
+	}
+	return &out
+}
@@ -0,0 +1,69 @@
+package machines
+
+import (
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// patchProviderSpec applies the providerSpec patch of a pool to the
+// providerSpec, whose value is replaced with the patched JSON.
+func patchProviderSpec(patch *types.ProviderSpecPatch, spec *clusterapi.ProviderSpec) error {
+	original, err := json.Marshal(spec.Value)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal providerSpec")
+	}
+
+	var patched []byte
+	switch patch.Type {
+	case "", types.MergePatchType:
+		patched, err = jsonpatch.MergePatch(original, patch.Patch)
+	case types.JSONPatchType:
+		var p jsonpatch.Patch
+		p, err = jsonpatch.DecodePatch(patch.Patch)
+		if err == nil {
+			patched, err = p.Apply(original)
+		}
+	default:
+		return errors.Errorf("unsupported providerSpec patch type %q", patch.Type)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to patch providerSpec")
+	}
+
+	spec.Value = &runtime.RawExtension{Raw: patched}
+	return nil
+}
+
+// patchMachines applies the providerSpec patch of the pool, if any, to the
+// machines.
+func patchMachines(pool *types.MachinePool, machines []clusterapi.Machine) error {
+	if pool.ProviderSpecPatch == nil {
+		return nil
+	}
+	for i := range machines {
+		if err := patchProviderSpec(pool.ProviderSpecPatch, &machines[i].Spec.ProviderSpec); err != nil {
+			return errors.Wrapf(err, "failed to patch machine %s", machines[i].Name)
+		}
+	}
+	return nil
+}
+
+// patchMachineSets applies the providerSpec patch of the pool, if any, to
+// the machine templates of the machinesets.
+func patchMachineSets(pool *types.MachinePool, sets []clusterapi.MachineSet) error {
+	if pool.ProviderSpecPatch == nil {
+		return nil
+	}
+	for i := range sets {
+		if err := patchProviderSpec(pool.ProviderSpecPatch, &sets[i].Spec.Template.Spec.ProviderSpec); err != nil {
+			return errors.Wrapf(err, "failed to patch machineset %s", sets[i].Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package machines
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	awsprovider "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsproviderconfig/v1alpha1"
+	clusterapi "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestPatchProviderSpec(t *testing.T) {
+	cases := []struct {
+		name          string
+		patch         types.ProviderSpecPatch
+		expected      map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:  "merge",
+			patch: types.ProviderSpecPatch{Patch: []byte(`{"instanceType":"m5.large","keyName":"ops"}`)},
+			expected: map[string]interface{}{
+				"instanceType":   "m5.large",
+				"keyName":        "ops",
+				"userDataSecret": map[string]interface{}{"name": "worker-user-data"},
+			},
+		},
+		{
+			name: "JSON patch",
+			patch: types.ProviderSpecPatch{
+				Type:  types.JSONPatchType,
+				Patch: []byte(`[{"op":"replace","path":"/instanceType","value":"m5.large"},{"op":"remove","path":"/userDataSecret"}]`),
+			},
+			expected: map[string]interface{}{
+				"instanceType":   "m5.large",
+				"userDataSecret": nil,
+			},
+		},
+		{
+			name: "JSON patch of a missing path",
+			patch: types.ProviderSpecPatch{
+				Type:  types.JSONPatchType,
+				Patch: []byte(`[{"op":"remove","path":"/keyName"}]`),
+			},
+			expectedError: `^failed to patch providerSpec: `,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &clusterapi.ProviderSpec{Value: &runtime.RawExtension{Object: &awsprovider.AWSMachineProviderConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "awsproviderconfig.k8s.io/v1alpha1",
+					Kind:       "AWSMachineProviderConfig",
+				},
+				InstanceType:   "m4.large",
+				UserDataSecret: &corev1.LocalObjectReference{Name: "worker-user-data"},
+			}}}
+			err := patchProviderSpec(&tc.patch, spec)
+			if tc.expectedError != "" {
+				assert.Regexp(t, tc.expectedError, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				var actual map[string]interface{}
+				assert.NoError(t, json.Unmarshal(spec.Value.Raw, &actual))
+				for key, value := range tc.expected {
+					assert.Equal(t, value, actual[key], key)
+				}
+			}
+		})
+	}
+}
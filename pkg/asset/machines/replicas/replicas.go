@@ -0,0 +1,18 @@
+// Package replicas spreads the replicas of a machine pool between the
+// machinesets of its zones.
+package replicas
+
+// Share returns the replicas of the machineset with the index idx, of the
+// total replicas of a pool spread as evenly as possible between count
+// machinesets. The remainder goes to the first machinesets, one each, so
+// that 5 replicas in 3 zones are spread as 2, 2 and 1.
+func Share(total int64, count int, idx int) int64 {
+	if count <= 0 {
+		return 0
+	}
+	share := total / int64(count)
+	if int64(idx) < total%int64(count) {
+		share++
+	}
+	return share
+}
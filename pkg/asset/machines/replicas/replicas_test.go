@@ -0,0 +1,50 @@
+package replicas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShare(t *testing.T) {
+	cases := []struct {
+		name     string
+		total    int64
+		count    int
+		expected []int64
+	}{
+		{
+			name:     "even",
+			total:    6,
+			count:    3,
+			expected: []int64{2, 2, 2},
+		},
+		{
+			name:     "remainder",
+			total:    5,
+			count:    3,
+			expected: []int64{2, 2, 1},
+		},
+		{
+			name:     "fewer replicas than zones",
+			total:    2,
+			count:    4,
+			expected: []int64{1, 1, 0, 0},
+		},
+		{
+			name:     "single zone",
+			total:    3,
+			count:    1,
+			expected: []int64{3},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var shares []int64
+			for idx := 0; idx < tc.count; idx++ {
+				shares = append(shares, Share(tc.total, tc.count, idx))
+			}
+			assert.Equal(t, tc.expected, shares)
+		})
+	}
+}
@@ -1,9 +1,7 @@
 package machines
 
 import (
-	"bytes"
 	"fmt"
-	"text/template"
 
 	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
@@ -16,6 +14,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/machines/aws"
 	"github.com/openshift/installer/pkg/asset/machines/libvirt"
+	"github.com/openshift/installer/pkg/asset/machines/naming"
 	"github.com/openshift/installer/pkg/asset/machines/openstack"
 	"github.com/openshift/installer/pkg/asset/rhcos"
 	"github.com/openshift/installer/pkg/types"
@@ -44,19 +43,17 @@ func defaultOpenStackMachinePoolPlatform(flavor string) openstacktypes.MachinePo
 	}
 }
 
-func trunkSupportBoolean(trunkSupport string) (result bool) {
-	if trunkSupport == "1" {
-		result = true
-	} else {
-		result = false
-	}
-	return
-}
-
-// Worker generates the machinesets for `worker` machine pool.
+// Worker generates the machinesets for `worker` machine pool, unless its
+// machines are user-provisioned, and the autoscalers and health check for
+// them when the pool configures them. It also generates the unhealthy
+// conditions of the health checks of all the compute pools.
 type Worker struct {
-	MachineSetRaw     []byte
-	UserDataSecretRaw []byte
+	MachineSetRaw          []byte
+	UserDataSecretRaw      []byte
+	ClusterAutoscalerRaw   []byte
+	MachineAutoscalersRaw  []byte
+	MachineHealthCheckRaw  []byte
+	UnhealthyConditionsRaw []byte
 }
 
 var _ asset.Asset = (*Worker)(nil)
@@ -94,76 +91,100 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 
 	ic := installconfig.Config
 	pool := workerPool(ic.Machines)
+	if pool.Replicas == nil && pool.Autoscaling != nil {
+		pool.Replicas = &pool.Autoscaling.MinReplicas
+	}
+	if ic.Platform.Name() == nonetypes.Name {
+		return nil
+	}
+	w.UnhealthyConditionsRaw, err = unhealthyConditions(ic.Machines)
+	if err != nil {
+		return err
+	}
+	if pool.UserProvisioned {
+		return nil
+	}
+	sets, err := machineSets(clusterID.ClusterID, ic, &pool, string(*rhcosImage), "worker", "worker-user-data")
+	if err != nil {
+		return errors.Wrap(err, "failed to create worker machine objects")
+	}
+	names := make([]string, len(sets))
+	for i := range sets {
+		names[i] = sets[i].Name
+	}
+	if err := naming.Unique(pool.NameTemplate, names); err != nil {
+		return err
+	}
+	if err := patchMachineSets(&pool, sets); err != nil {
+		return err
+	}
+
+	w.MachineSetRaw, err = yaml.Marshal(listFromMachineSets(sets))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal")
+	}
+
+	if pool.Autoscaling != nil {
+		w.ClusterAutoscalerRaw = []byte(clusterAutoscaler)
+		w.MachineAutoscalersRaw, err = machineAutoscalerList(machineAutoscalers(pool.Autoscaling, sets))
+		if err != nil {
+			return errors.Wrap(err, "failed to create worker machine autoscalers")
+		}
+	}
+	if pool.HealthCheck != nil {
+		w.MachineHealthCheckRaw, err = machineHealthCheck(ic.ObjectMeta.Name, &pool, "worker")
+		if err != nil {
+			return errors.Wrap(err, "failed to create worker machine health check")
+		}
+	}
+	return nil
+}
+
+// machineSets returns the machinesets of the pool on the platform of the
+// install-config, with the platform defaults applied to the pool. They boot
+// the osImage of the pool, if it has one.
+func machineSets(clusterID string, ic *types.InstallConfig, pool *types.MachinePool, osImage, role, userDataSecret string) ([]clusterapi.MachineSet, error) {
 	switch ic.Platform.Name() {
 	case awstypes.Name:
 		mpool := defaultAWSMachinePoolPlatform()
 		mpool.InstanceType = "m4.large"
-		mpool.Set(ic.Platform.AWS.DefaultMachinePlatform)
-		mpool.Set(pool.Platform.AWS)
-		if len(mpool.Zones) == 0 {
-			azs, err := aws.AvailabilityZones(ic.Platform.AWS.Region)
-			if err != nil {
-				return errors.Wrap(err, "failed to fetch availability zones")
-			}
-			mpool.Zones = azs
+		setAWSMachinePool(&mpool, ic.Platform.AWS.DefaultMachinePlatform, pool.Platform.AWS)
+		if err := setAWSZones(&mpool, ic.Platform.AWS.Region); err != nil {
+			return nil, err
 		}
-		pool.Platform.AWS = &mpool
-		sets, err := aws.MachineSets(clusterID.ClusterID, ic, &pool, string(*rhcosImage), "worker", "worker-user-data")
-		if err != nil {
-			return errors.Wrap(err, "failed to create worker machine objects")
+		if err := checkAWSResources(role, &mpool); err != nil {
+			return nil, err
 		}
-
-		list := listFromMachineSets(sets)
-		raw, err := yaml.Marshal(list)
-		if err != nil {
-			return errors.Wrap(err, "failed to marshal")
+		pool.Platform.AWS = &mpool
+		if mpool.OSImage != "" {
+			osImage = mpool.OSImage
 		}
-		w.MachineSetRaw = raw
+		return aws.MachineSets(clusterID, ic, pool, osImage, role, userDataSecret)
 	case libvirttypes.Name:
 		mpool := defaultLibvirtMachinePoolPlatform()
 		mpool.Set(ic.Platform.Libvirt.DefaultMachinePlatform)
 		mpool.Set(pool.Platform.Libvirt)
 		pool.Platform.Libvirt = &mpool
-		sets, err := libvirt.MachineSets(clusterID.ClusterID, ic, &pool, "worker", "worker-user-data")
-		if err != nil {
-			return errors.Wrap(err, "failed to create worker machine objects")
-		}
-
-		list := listFromMachineSets(sets)
-		raw, err := yaml.Marshal(list)
-		if err != nil {
-			return errors.Wrap(err, "failed to marshal")
-		}
-		w.MachineSetRaw = raw
-	case nonetypes.Name:
+		return libvirt.MachineSets(clusterID, ic, pool, role, userDataSecret)
 	case openstacktypes.Name:
-		numOfWorkers := int64(0)
-		if pool.Replicas != nil {
-			numOfWorkers = *pool.Replicas
+		mpool := defaultOpenStackMachinePoolPlatform(ic.Platform.OpenStack.FlavorName)
+		mpool.Set(ic.Platform.OpenStack.DefaultMachinePlatform)
+		mpool.Set(pool.Platform.OpenStack)
+		pool.Platform.OpenStack = &mpool
+		if err := checkOpenStackResources(role, ic.Platform.OpenStack.Cloud, &mpool); err != nil {
+			return nil, err
 		}
-		config := openstack.Config{
-			CloudName:   ic.Platform.OpenStack.Cloud,
-			ClusterName: ic.ObjectMeta.Name,
-			Replicas:    numOfWorkers,
-			Image:       string(*rhcosImage),
-			Region:      ic.Platform.OpenStack.Region,
-			Machine:     defaultOpenStackMachinePoolPlatform(ic.Platform.OpenStack.FlavorName),
-			Trunk:       trunkSupportBoolean(ic.Platform.OpenStack.TrunkSupport),
+		accelerator, err := openstack.FlavorAccelerator(ic.Platform.OpenStack.Cloud, mpool.FlavorName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch the accelerator of the %s flavor", pool.Name)
 		}
-
-		tags := map[string]string{
-			"openshiftClusterID": clusterID.ClusterID,
+		if mpool.OSImage != "" {
+			osImage = mpool.OSImage
 		}
-		config.Tags = tags
-
-		config.Machine.Set(ic.Platform.OpenStack.DefaultMachinePlatform)
-		config.Machine.Set(pool.Platform.OpenStack)
-
-		w.MachineSetRaw = applyTemplateData(openstack.WorkerMachineSetTmpl, config)
+		return openstack.MachineSets(clusterID, ic, pool, osImage, accelerator, role, userDataSecret)
 	default:
-		return fmt.Errorf("invalid Platform")
+		return nil, fmt.Errorf("invalid Platform")
 	}
-	return nil
 }
 
 func workerPool(pools []types.MachinePool) types.MachinePool {
@@ -175,14 +196,6 @@ func workerPool(pools []types.MachinePool) types.MachinePool {
 	return types.MachinePool{}
 }
 
-func applyTemplateData(template *template.Template, templateData interface{}) []byte {
-	buf := &bytes.Buffer{}
-	if err := template.Execute(buf, templateData); err != nil {
-		panic(err)
-	}
-	return buf.Bytes()
-}
-
 func listFromMachineSets(objs []clusterapi.MachineSet) *metav1.List {
 	list := &metav1.List{
 		TypeMeta: metav1.TypeMeta{
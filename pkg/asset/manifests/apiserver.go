@@ -0,0 +1,187 @@
+package manifests
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+const (
+	// apiServingCertSecretName is the name of the secret, in the
+	// openshift-config namespace, holding the serving certificate of the
+	// API URL.
+	apiServingCertSecretName = "api-serving-cert"
+)
+
+var (
+	apiServerCfgFilename    = filepath.Join(manifestDir, "cluster-apiserver-02-config.yml")
+	apiServerSecretFilename = filepath.Join(manifestDir, "cluster-apiserver-03-serving-cert-secret.yml")
+)
+
+// apiServer is the config.openshift.io/v1 APIServer, which is not vendored.
+type apiServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              apiServerSpec `json:"spec"`
+}
+
+type apiServerSpec struct {
+	ServingCerts *apiServerServingCerts `json:"servingCerts,omitempty"`
+	Audit        *apiServerAudit        `json:"audit,omitempty"`
+}
+
+type apiServerAudit struct {
+	Profile string `json:"profile"`
+}
+
+type apiServerServingCerts struct {
+	NamedCertificates []apiServerNamedServingCert `json:"namedCertificates"`
+}
+
+type apiServerNamedServingCert struct {
+	Names              []string            `json:"names"`
+	ServingCertificate secretNameReference `json:"servingCertificate"`
+}
+
+type secretNameReference struct {
+	Name string `json:"name"`
+}
+
+// APIServer generates the cluster-apiserver-*.yml files, which configure the
+// API server to serve the certificate from the install-config for the API
+// URL and to use the audit profile from the install-config. It generates no
+// files if the install-config has neither.
+type APIServer struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*APIServer)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*APIServer) Name() string {
+	return "API Server Config"
+}
+
+// Sensitive returns true, because the manifests contain the private key of
+// the serving certificate.
+func (*APIServer) Sensitive() bool {
+	return true
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*APIServer) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the API server config and the secret of its serving
+// certificate.
+func (a *APIServer) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	a.FileList = nil
+	apiServerConfig := installConfig.Config.APIServer
+	if apiServerConfig == nil || (apiServerConfig.ServingCertificate == nil && apiServerConfig.AuditProfile == "") {
+		return nil
+	}
+
+	config := &apiServer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "config.openshift.io/v1",
+			Kind:       "APIServer",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			// not namespaced
+		},
+	}
+	if apiServerConfig.ServingCertificate != nil {
+		config.Spec.ServingCerts = &apiServerServingCerts{
+			NamedCertificates: []apiServerNamedServingCert{
+				{
+					Names: []string{fmt.Sprintf("%s-api.%s", installConfig.Config.ObjectMeta.Name, installConfig.Config.BaseDomain)},
+					ServingCertificate: secretNameReference{
+						Name: apiServingCertSecretName,
+					},
+				},
+			},
+		}
+	}
+	if apiServerConfig.AuditProfile != "" {
+		config.Spec.Audit = &apiServerAudit{
+			Profile: string(apiServerConfig.AuditProfile),
+		}
+	}
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s manifests from InstallConfig", a.Name())
+	}
+
+	a.FileList = []*asset.File{
+		{
+			Filename: apiServerCfgFilename,
+			Data:     configData,
+		},
+	}
+
+	if apiServerConfig.ServingCertificate != nil {
+		secret := tlsSecret("openshift-config", apiServingCertSecretName, apiServerConfig.ServingCertificate)
+		secretData, err := yaml.Marshal(secret)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create %s manifests from InstallConfig", a.Name())
+		}
+		a.FileList = append(a.FileList, &asset.File{
+			Filename: apiServerSecretFilename,
+			Data:     secretData,
+		})
+	}
+
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (a *APIServer) Files() []*asset.File {
+	return a.FileList
+}
+
+// Load loads the already-rendered files back from disk.
+func (a *APIServer) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(apiServerCfgFilename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	fileList := []*asset.File{file}
+
+	config := &apiServer{}
+	if err := yaml.Unmarshal(file.Data, config); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal %s", apiServerCfgFilename)
+	}
+
+	// The secret is only rendered with a serving certificate.
+	if config.Spec.ServingCerts != nil {
+		file, err := f.FetchByName(apiServerSecretFilename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return false, errors.Errorf("%s is missing", apiServerSecretFilename)
+			}
+			return false, err
+		}
+		fileList = append(fileList, file)
+	}
+
+	a.FileList = fileList
+	return true, nil
+}
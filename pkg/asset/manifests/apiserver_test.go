@@ -0,0 +1,72 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestAPIServerGenerate(t *testing.T) {
+	cases := []struct {
+		name      string
+		apiServer *types.APIServer
+		expected  string
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name:      "default audit profile",
+			apiServer: &types.APIServer{AuditProfile: types.DefaultAuditProfile},
+			expected: `apiVersion: config.openshift.io/v1
+kind: APIServer
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  audit:
+    profile: Default
+`,
+		},
+		{
+			name:      "write request bodies audit profile",
+			apiServer: &types.APIServer{AuditProfile: types.WriteRequestBodiesAuditProfile},
+			expected: `apiVersion: config.openshift.io/v1
+kind: APIServer
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  audit:
+    profile: WriteRequestBodies
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			installConfig := &installconfig.InstallConfig{
+				Config: &types.InstallConfig{APIServer: tc.apiServer},
+			}
+			parents := asset.Parents{}
+			parents.Add(installConfig)
+
+			apiServer := &APIServer{}
+			if !assert.NoError(t, apiServer.Generate(parents)) {
+				return
+			}
+			if tc.expected == "" {
+				assert.Empty(t, apiServer.Files())
+				return
+			}
+			if !assert.Len(t, apiServer.Files(), 1) {
+				return
+			}
+			assert.Equal(t, "manifests/cluster-apiserver-02-config.yml", apiServer.Files()[0].Filename)
+			assert.Equal(t, tc.expected, string(apiServer.Files()[0].Data))
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package manifests
+
+import (
+	"encoding/base64"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/pkg/errors"
+
+	// TODO(flaper87): Migrate to ghodss asap
+	// This yaml is currently used only by the OpenStack
+	// clouds serialization. We're working on migrating
+	// clientconfig out of go-yaml. We'll use it here
+	// until that happens.
+	// https://github.com/openshift/installer/pull/854
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/openstack"
+)
+
+// cloudCreds returns the root credentials which the installer stores in the
+// kube-system namespace for the cloud-credential operator. It returns nil on
+// platforms without such credentials and in Manual credentials mode, where
+// the administrators create the credentials of each component themselves.
+func cloudCreds(installConfig *types.InstallConfig) (*cloudCredsSecretData, error) {
+	if installConfig.CredentialsMode == types.ManualCredentialsMode {
+		return nil, nil
+	}
+
+	switch installConfig.Platform.Name() {
+	case aws.Name:
+		ssn := session.Must(session.NewSessionWithOptions(session.Options{
+			SharedConfigState: session.SharedConfigEnable,
+		}))
+		creds, err := ssn.Config.Credentials.Get()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the AWS credentials")
+		}
+		return &cloudCredsSecretData{
+			AWS: &AwsCredsSecretData{
+				Base64encodeAccessKeyID:     base64.StdEncoding.EncodeToString([]byte(creds.AccessKeyID)),
+				Base64encodeSecretAccessKey: base64.StdEncoding.EncodeToString([]byte(creds.SecretAccessKey)),
+			},
+		}, nil
+	case openstack.Name:
+		opts := new(clientconfig.ClientOpts)
+		cloud, err := clientconfig.GetCloudFromYAML(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the OpenStack cloud")
+		}
+		clouds := map[string]map[string]*clientconfig.Cloud{
+			"clouds": {
+				"openstack": cloud,
+			},
+		}
+
+		marshalled, err := yaml.Marshal(clouds)
+		if err != nil {
+			return nil, err
+		}
+
+		return &cloudCredsSecretData{
+			OpenStack: &OpenStackCredsSecretData{
+				Base64encodeCloudCreds: base64.StdEncoding.EncodeToString(marshalled),
+			},
+		}, nil
+	default:
+		return nil, nil
+	}
+}
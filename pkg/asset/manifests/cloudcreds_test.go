@@ -0,0 +1,39 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/libvirt"
+)
+
+func TestCloudCreds(t *testing.T) {
+	cases := []struct {
+		name          string
+		installConfig *types.InstallConfig
+	}{
+		{
+			name: "manual credentials mode",
+			installConfig: &types.InstallConfig{
+				Platform:        types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+				CredentialsMode: types.ManualCredentialsMode,
+			},
+		},
+		{
+			name: "platform without credentials",
+			installConfig: &types.InstallConfig{
+				Platform: types.Platform{Libvirt: &libvirt.Platform{}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			creds, err := cloudCreds(tc.installConfig)
+			assert.NoError(t, err)
+			assert.Nil(t, creds)
+		})
+	}
+}
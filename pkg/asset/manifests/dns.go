@@ -1,6 +1,7 @@
 package manifests
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -9,7 +10,10 @@ import (
 
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	icaws "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	"github.com/openshift/installer/pkg/asset/templates/content"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
 
 	configv1 "github.com/openshift/api/config/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,9 +24,30 @@ var (
 	dnsCfgFilename = filepath.Join(manifestDir, "cluster-dns-02-config.yml")
 )
 
+// dns is the config.openshift.io/v1 DNS, whose vendored version lacks the
+// hosted zones.
+type dns struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              dnsSpec `json:"spec"`
+}
+
+type dnsSpec struct {
+	BaseDomain  string   `json:"baseDomain"`
+	PublicZone  *dnsZone `json:"publicZone,omitempty"`
+	PrivateZone *dnsZone `json:"privateZone,omitempty"`
+}
+
+// dnsZone identifies a hosted zone by its ID or, for zones which do not
+// exist yet when the manifests are generated, by its tags.
+type dnsZone struct {
+	ID   string            `json:"id,omitempty"`
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
 // DNS generates the cluster-dns-*.yml files.
 type DNS struct {
-	config   *configv1.DNS
+	config   *dns
 	FileList []*asset.File
 }
 
@@ -46,19 +71,15 @@ func (d *DNS) Generate(dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	dependencies.Get(installConfig)
 
-	d.config = &configv1.DNS{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: configv1.SchemeGroupVersion.String(),
-			Kind:       "DNS",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "cluster",
-			// not namespaced
-		},
-		Spec: configv1.DNSSpec{
-			BaseDomain: installConfig.Config.BaseDomain,
-		},
+	var publicZoneID string
+	if installConfig.Config.Platform.Name() == aws.Name {
+		var err error
+		publicZoneID, err = icaws.GetPublicZone(installConfig.Config.BaseDomain)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the public zone of %s", installConfig.Config.BaseDomain)
+		}
 	}
+	d.config = newDNSConfig(installConfig.Config, publicZoneID)
 
 	configData, err := yaml.Marshal(d.config)
 	if err != nil {
@@ -84,6 +105,37 @@ func (d *DNS) Generate(dependencies asset.Parents) error {
 	return nil
 }
 
+// newDNSConfig returns the DNS config of the cluster, with its hosted zones
+// on AWS, where the public zone of the base domain has the given ID.
+func newDNSConfig(config *types.InstallConfig, publicZoneID string) *dns {
+	dnsConfig := &dns{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: configv1.SchemeGroupVersion.String(),
+			Kind:       "DNS",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			// not namespaced
+		},
+		Spec: dnsSpec{
+			BaseDomain: config.BaseDomain,
+		},
+	}
+
+	if config.Platform.Name() == aws.Name {
+		dnsConfig.Spec.PublicZone = &dnsZone{ID: publicZoneID}
+		// The private zone is created with the cluster, so the ingress
+		// operator finds it by the tags which Terraform gives it.
+		dnsConfig.Spec.PrivateZone = &dnsZone{
+			Tags: map[string]string{
+				"Name": fmt.Sprintf("%s_int", config.ObjectMeta.Name),
+				fmt.Sprintf("kubernetes.io/cluster/%s", config.ObjectMeta.Name): "owned",
+			},
+		}
+	}
+	return dnsConfig
+}
+
 // Files returns the files generated by the asset.
 func (d *DNS) Files() []*asset.File {
 	return d.FileList
@@ -108,7 +160,7 @@ func (d *DNS) Load(f asset.FileFetcher) (bool, error) {
 		return false, err
 	}
 
-	dnsConfig := &configv1.DNS{}
+	dnsConfig := &dns{}
 	if err := yaml.Unmarshal(cfgFile.Data, dnsConfig); err != nil {
 		return false, errors.Wrapf(err, "failed to unmarshal %s", dnsCfgFilename)
 	}
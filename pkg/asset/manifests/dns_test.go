@@ -0,0 +1,83 @@
+package manifests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/data"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/none"
+)
+
+func TestDNSGenerate(t *testing.T) {
+	defer func(assets http.FileSystem) { data.Assets = assets }(data.Assets)
+	data.Assets = http.Dir("../../../data/data")
+
+	installConfig := &installconfig.InstallConfig{
+		Config: &types.InstallConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			BaseDomain: "test-domain",
+			Platform:   types.Platform{None: &none.Platform{}},
+		},
+	}
+	parents := asset.Parents{}
+	parents.Add(installConfig)
+
+	dns := &DNS{}
+	if !assert.NoError(t, dns.Generate(parents)) {
+		return
+	}
+	if !assert.Len(t, dns.Files(), 2) {
+		return
+	}
+	assert.Equal(t, "manifests/cluster-dns-01-crd.yaml", dns.Files()[0].Filename)
+	assert.Equal(t, "manifests/cluster-dns-02-config.yml", dns.Files()[1].Filename)
+	assert.Equal(t, `apiVersion: config.openshift.io/v1
+kind: DNS
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  baseDomain: test-domain
+`, string(dns.Files()[1].Data))
+
+	loaded := &DNS{}
+	found, err := loaded.Load(asset.NewMemoryFileFetcher(dns.Files()...))
+	if assert.NoError(t, err) && assert.True(t, found) {
+		assert.Equal(t, dns.config, loaded.config)
+	}
+}
+
+func TestNewDNSConfigAWS(t *testing.T) {
+	config := &types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		BaseDomain: "test-domain",
+		Platform:   types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+	}
+
+	dnsData, err := yaml.Marshal(newDNSConfig(config, "Z0123456789"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `apiVersion: config.openshift.io/v1
+kind: DNS
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  baseDomain: test-domain
+  privateZone:
+    tags:
+      Name: test-cluster_int
+      kubernetes.io/cluster/test-cluster: owned
+  publicZone:
+    id: Z0123456789
+`, string(dnsData))
+}
@@ -0,0 +1,102 @@
+package manifests
+
+import (
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const (
+	// extraManifestsDir is the directory of the manifests which the user
+	// adds to the generated ones, in manifests and openshift
+	// subdirectories.
+	extraManifestsDir = "extra-manifests"
+)
+
+// extraManifestPatterns are the names of the files loaded from the
+// subdirectories of the extra manifests directory.
+var extraManifestPatterns = []string{"*.yaml", "*.yml", "*.json"}
+
+// ExtraManifests is an asset which loads the manifests added to the
+// extra-manifests directory. Those in its manifests subdirectory are added
+// to the manifests directory, and those in its openshift subdirectory to
+// the openshift directory. It generates no files; they are only provided by
+// the user.
+type ExtraManifests struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*ExtraManifests)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (a *ExtraManifests) Name() string {
+	return "Extra Manifests"
+}
+
+// Dependencies returns no dependencies.
+func (a *ExtraManifests) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate generates no manifests; they are only provided by the user.
+func (a *ExtraManifests) Generate(asset.Parents) error {
+	a.FileList = nil
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (a *ExtraManifests) Files() []*asset.File {
+	return a.FileList
+}
+
+// Load returns the manifests in the subdirectories of the extra-manifests
+// directory, after checking that each is a Kubernetes object.
+func (a *ExtraManifests) Load(f asset.FileFetcher) (bool, error) {
+	a.FileList = nil
+	for _, dir := range []string{manifestDir, openshiftManifestDir} {
+		for _, pattern := range extraManifestPatterns {
+			files, err := f.FetchByPattern(filepath.Join(extraManifestsDir, dir, pattern))
+			if err != nil {
+				return false, err
+			}
+			for _, file := range files {
+				var object struct {
+					APIVersion string `json:"apiVersion"`
+					Kind       string `json:"kind"`
+				}
+				if err := yaml.Unmarshal(file.Data, &object); err != nil {
+					return false, errors.Wrapf(err, "failed to unmarshal %s", file.Filename)
+				}
+				if object.APIVersion == "" || object.Kind == "" {
+					return false, errors.Errorf("%s is not a Kubernetes object with an apiVersion and a kind", file.Filename)
+				}
+				a.FileList = append(a.FileList, file)
+			}
+		}
+	}
+	return len(a.FileList) > 0, nil
+}
+
+// merge adds the extra manifests for the directory to the generated files of
+// that directory. It fails if an extra manifest has the name of a generated
+// one, which it would silently replace in the bootstrap Ignition config.
+func (a *ExtraManifests) merge(dir string, files []*asset.File) ([]*asset.File, error) {
+	generated := map[string]bool{}
+	for _, file := range files {
+		generated[file.Filename] = true
+	}
+	for _, file := range a.FileList {
+		if filepath.Base(filepath.Dir(file.Filename)) != dir {
+			continue
+		}
+		filename := filepath.Join(dir, filepath.Base(file.Filename))
+		if generated[filename] {
+			return nil, errors.Errorf("%s has the name of the generated %s; rename it", file.Filename, filename)
+		}
+		files = append(files, &asset.File{Filename: filename, Data: file.Data})
+	}
+	return files, nil
+}
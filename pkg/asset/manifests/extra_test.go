@@ -0,0 +1,83 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestExtraManifests(t *testing.T) {
+	namespace := []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: extra\n")
+
+	cases := []struct {
+		name          string
+		files         []*asset.File
+		generated     []*asset.File
+		expectedFound bool
+		expectedFiles []string
+		expectedError string
+	}{
+		{
+			name: "no extra manifests",
+		},
+		{
+			name: "manifests and openshift",
+			files: []*asset.File{
+				{Filename: filepath.Join(extraManifestsDir, manifestDir, "99-extra.yaml"), Data: namespace},
+				{Filename: filepath.Join(extraManifestsDir, openshiftManifestDir, "99-extra.json"), Data: []byte(`{"apiVersion": "v1", "kind": "Namespace"}`)},
+			},
+			generated: []*asset.File{
+				{Filename: filepath.Join(manifestDir, "cluster-config.yaml")},
+			},
+			expectedFound: true,
+			expectedFiles: []string{
+				filepath.Join(manifestDir, "cluster-config.yaml"),
+				filepath.Join(manifestDir, "99-extra.yaml"),
+			},
+		},
+		{
+			name: "not a Kubernetes object",
+			files: []*asset.File{
+				{Filename: filepath.Join(extraManifestsDir, manifestDir, "99-extra.yaml"), Data: []byte("name: extra\n")},
+			},
+			expectedError: "extra-manifests/manifests/99-extra.yaml is not a Kubernetes object with an apiVersion and a kind",
+		},
+		{
+			name: "name of a generated manifest",
+			files: []*asset.File{
+				{Filename: filepath.Join(extraManifestsDir, manifestDir, "cluster-config.yaml"), Data: namespace},
+			},
+			generated: []*asset.File{
+				{Filename: filepath.Join(manifestDir, "cluster-config.yaml")},
+			},
+			expectedFound: true,
+			expectedError: "extra-manifests/manifests/cluster-config.yaml has the name of the generated manifests/cluster-config.yaml; rename it",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			extra := &ExtraManifests{}
+			found, err := extra.Load(asset.NewMemoryFileFetcher(tc.files...))
+			if err == nil {
+				assert.Equal(t, tc.expectedFound, found, "unexpected found")
+				var files []*asset.File
+				files, err = extra.merge(manifestDir, tc.generated)
+				if err == nil {
+					var filenames []string
+					for _, file := range files {
+						filenames = append(filenames, file.Filename)
+					}
+					assert.Equal(t, tc.expectedFiles, filenames)
+				}
+			}
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
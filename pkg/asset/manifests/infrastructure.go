@@ -24,6 +24,38 @@ var (
 	infraCfgFilename = filepath.Join(manifestDir, "cluster-infrastructure-02-config.yml")
 )
 
+// infrastructure is the config.openshift.io/v1 Infrastructure, whose vendored
+// version lacks the status fields besides the platform.
+type infrastructure struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            infrastructureStatus `json:"status"`
+}
+
+type infrastructureStatus struct {
+	InfrastructureName   string                `json:"infrastructureName"`
+	Platform             configv1.PlatformType `json:"platform"`
+	PlatformStatus       *platformStatus       `json:"platformStatus,omitempty"`
+	EtcdDiscoveryDomain  string                `json:"etcdDiscoveryDomain"`
+	APIServerURL         string                `json:"apiServerURL"`
+	APIServerInternalURL string                `json:"apiServerInternalURI"`
+}
+
+type platformStatus struct {
+	Type      configv1.PlatformType    `json:"type"`
+	AWS       *awsPlatformStatus       `json:"aws,omitempty"`
+	OpenStack *openStackPlatformStatus `json:"openstack,omitempty"`
+}
+
+type awsPlatformStatus struct {
+	Region string `json:"region"`
+}
+
+type openStackPlatformStatus struct {
+	CloudName string `json:"cloudName"`
+	Region    string `json:"region,omitempty"`
+}
+
 // Infrastructure generates the cluster-infrastructure-*.yml files.
 type Infrastructure struct {
 	FileList []*asset.File
@@ -65,7 +97,7 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 		platform = configv1.NonePlatform
 	}
 
-	config := &configv1.Infrastructure{
+	config := &infrastructure{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: configv1.SchemeGroupVersion.String(),
 			Kind:       "Infrastructure",
@@ -74,11 +106,32 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 			Name: "cluster",
 			// not namespaced
 		},
-		Status: configv1.InfrastructureStatus{
-			Platform: platform,
+		Status: infrastructureStatus{
+			InfrastructureName: installConfig.Config.ObjectMeta.Name,
+			Platform:           platform,
+			PlatformStatus: &platformStatus{
+				Type: platform,
+			},
+			EtcdDiscoveryDomain: installConfig.Config.BaseDomain,
+			// The API has no separate internal endpoint, so in-cluster
+			// clients use the same URL.
+			APIServerURL:         getAPIServerURL(installConfig.Config),
+			APIServerInternalURL: getAPIServerURL(installConfig.Config),
 		},
 	}
 
+	switch platform {
+	case configv1.AWSPlatform:
+		config.Status.PlatformStatus.AWS = &awsPlatformStatus{
+			Region: installConfig.Config.Platform.AWS.Region,
+		}
+	case configv1.OpenStackPlatform:
+		config.Status.PlatformStatus.OpenStack = &openStackPlatformStatus{
+			CloudName: installConfig.Config.Platform.OpenStack.Cloud,
+			Region:    installConfig.Config.Platform.OpenStack.Region,
+		}
+	}
+
 	configData, err := yaml.Marshal(config)
 	if err != nil {
 		return errors.Wrapf(err, "failed to marshal config: %#v", config)
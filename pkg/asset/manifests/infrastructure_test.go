@@ -0,0 +1,112 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/templates/content/openshift"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/none"
+	"github.com/openshift/installer/pkg/types/openstack"
+)
+
+func TestInfrastructureGenerate(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform types.Platform
+		expected string
+	}{
+		{
+			name:     "none",
+			platform: types.Platform{None: &none.Platform{}},
+			expected: `apiVersion: config.openshift.io/v1
+kind: Infrastructure
+metadata:
+  creationTimestamp: null
+  name: cluster
+status:
+  apiServerInternalURI: https://test-cluster-api.test-domain:6443
+  apiServerURL: https://test-cluster-api.test-domain:6443
+  etcdDiscoveryDomain: test-domain
+  infrastructureName: test-cluster
+  platform: None
+  platformStatus:
+    type: None
+`,
+		},
+		{
+			name:     "aws",
+			platform: types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			expected: `apiVersion: config.openshift.io/v1
+kind: Infrastructure
+metadata:
+  creationTimestamp: null
+  name: cluster
+status:
+  apiServerInternalURI: https://test-cluster-api.test-domain:6443
+  apiServerURL: https://test-cluster-api.test-domain:6443
+  etcdDiscoveryDomain: test-domain
+  infrastructureName: test-cluster
+  platform: AWS
+  platformStatus:
+    aws:
+      region: us-east-1
+    type: AWS
+`,
+		},
+		{
+			name:     "openstack",
+			platform: types.Platform{OpenStack: &openstack.Platform{Cloud: "test-cloud", Region: "regionOne"}},
+			expected: `apiVersion: config.openshift.io/v1
+kind: Infrastructure
+metadata:
+  creationTimestamp: null
+  name: cluster
+status:
+  apiServerInternalURI: https://test-cluster-api.test-domain:6443
+  apiServerURL: https://test-cluster-api.test-domain:6443
+  etcdDiscoveryDomain: test-domain
+  infrastructureName: test-cluster
+  platform: OpenStack
+  platformStatus:
+    openstack:
+      cloudName: test-cloud
+      region: regionOne
+    type: OpenStack
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			installConfig := &installconfig.InstallConfig{
+				Config: &types.InstallConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+					BaseDomain: "test-domain",
+					Platform:   tc.platform,
+				},
+			}
+			parents := asset.Parents{}
+			parents.Add(
+				installConfig,
+				&openshift.InfrastructureCRD{FileList: []*asset.File{{Data: []byte("crd")}}},
+			)
+
+			infra := &Infrastructure{}
+			if !assert.NoError(t, infra.Generate(parents)) {
+				return
+			}
+			if !assert.Len(t, infra.Files(), 2) {
+				return
+			}
+			assert.Equal(t, "manifests/cluster-infrastructure-01-crd.yaml", infra.Files()[0].Filename)
+			assert.Equal(t, "crd", string(infra.Files()[0].Data))
+			assert.Equal(t, "manifests/cluster-infrastructure-02-config.yml", infra.Files()[1].Filename)
+			assert.Equal(t, tc.expected, string(infra.Files()[1].Data))
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/machines"
 	"github.com/openshift/installer/pkg/asset/templates/content"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -17,8 +18,9 @@ import (
 )
 
 var (
-	ingCrdFilename = "cluster-ingress-01-crd.yaml"
-	ingCfgFilename = filepath.Join(manifestDir, "cluster-ingress-02-config.yml")
+	ingCrdFilename    = "cluster-ingress-01-crd.yaml"
+	ingCfgFilename    = filepath.Join(manifestDir, "cluster-ingress-02-config.yml")
+	ingSecretFilename = filepath.Join(manifestDir, "cluster-ingress-03-default-cert-secret.yml")
 )
 
 // Ingress generates the cluster-ingress-*.yml files.
@@ -34,6 +36,12 @@ func (*Ingress) Name() string {
 	return "Ingress Config"
 }
 
+// Sensitive returns true, because the manifests contain the private key of
+// the default certificate if the install-config has one.
+func (*Ingress) Sensitive() bool {
+	return true
+}
+
 // Dependencies returns all of the dependencies directly needed to generate
 // the asset.
 func (*Ingress) Dependencies() []asset.Asset {
@@ -42,7 +50,8 @@ func (*Ingress) Dependencies() []asset.Asset {
 	}
 }
 
-// Generate generates the ingress config and its CRD.
+// Generate generates the ingress config and its CRD, and the secret of the
+// default certificate from the install-config.
 func (ing *Ingress) Generate(dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	dependencies.Get(installConfig)
@@ -82,6 +91,19 @@ func (ing *Ingress) Generate(dependencies asset.Parents) error {
 		},
 	}
 
+	if installConfig.Config.Ingress != nil && installConfig.Config.Ingress.DefaultCertificate != nil {
+		// The default IngressController refers to the secret.
+		secret := tlsSecret("openshift-ingress", machines.DefaultCertificateSecretName, installConfig.Config.Ingress.DefaultCertificate)
+		secretData, err := yaml.Marshal(secret)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create %s manifests from InstallConfig", ing.Name())
+		}
+		ing.FileList = append(ing.FileList, &asset.File{
+			Filename: ingSecretFilename,
+			Data:     secretData,
+		})
+	}
+
 	return nil
 }
 
@@ -116,6 +138,13 @@ func (ing *Ingress) Load(f asset.FileFetcher) (bool, error) {
 
 	fileList := []*asset.File{crdFile, cfgFile}
 
+	secretFile, err := f.FetchByName(ingSecretFilename)
+	if err == nil {
+		fileList = append(fileList, secretFile)
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
 	ing.FileList, ing.config = fileList, ingressConfig
 
 	return true, nil
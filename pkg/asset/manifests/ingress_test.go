@@ -0,0 +1,76 @@
+package manifests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/data"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/machines"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/none"
+)
+
+// TestIngressDefaultCertificate checks that the default IngressController
+// serves the secret of the default certificate of the install-config.
+func TestIngressDefaultCertificate(t *testing.T) {
+	defer func(assets http.FileSystem) { data.Assets = assets }(data.Assets)
+	data.Assets = http.Dir("../../../data/data")
+
+	installConfig := &installconfig.InstallConfig{
+		Config: &types.InstallConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			BaseDomain: "test-domain",
+			Platform:   types.Platform{None: &none.Platform{}},
+			Ingress: &types.Ingress{
+				DefaultCertificate: &types.ServingCertificate{Certificate: "test-cert", Key: "test-key"},
+			},
+		},
+	}
+	parents := asset.Parents{}
+	parents.Add(installConfig)
+
+	ingress := &Ingress{}
+	if !assert.NoError(t, ingress.Generate(parents)) {
+		return
+	}
+	var secretFile *asset.File
+	for _, f := range ingress.Files() {
+		if f.Filename == ingSecretFilename {
+			secretFile = f
+		}
+	}
+	if !assert.NotNil(t, secretFile) {
+		return
+	}
+	secret := &corev1.Secret{}
+	if !assert.NoError(t, yaml.Unmarshal(secretFile.Data, secret)) {
+		return
+	}
+	assert.Equal(t, "openshift-ingress", secret.Namespace)
+	assert.NotEqual(t, "router-certs-default", secret.Name, "the secret managed by the ingress operator")
+	assert.Equal(t, []byte("test-cert"), secret.Data[corev1.TLSCertKey])
+	assert.Equal(t, []byte("test-key"), secret.Data[corev1.TLSPrivateKeyKey])
+
+	ingressControllerData, err := machines.IngressController(installConfig.Config)
+	if !assert.NoError(t, err) {
+		return
+	}
+	ingressController := struct {
+		Spec struct {
+			DefaultCertificate *corev1.LocalObjectReference `json:"defaultCertificate"`
+		} `json:"spec"`
+	}{}
+	if !assert.NoError(t, yaml.Unmarshal(ingressControllerData, &ingressController)) {
+		return
+	}
+	if assert.NotNil(t, ingressController.Spec.DefaultCertificate) {
+		assert.Equal(t, secret.Name, ingressController.Spec.DefaultCertificate.Name)
+	}
+}
@@ -0,0 +1,146 @@
+package manifests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// manifestSchemas are the types against which the objects of the known
+// kinds are strictly decoded, so that misspelled or misplaced fields fail
+// instead of being silently dropped by the API server. Objects of other
+// kinds are only checked for an apiVersion, a kind and a name.
+var manifestSchemas = map[metav1.TypeMeta]func() interface{}{
+	{APIVersion: "v1", Kind: "ConfigMap"}:                                         func() interface{} { return &corev1.ConfigMap{} },
+	{APIVersion: "v1", Kind: "Endpoints"}:                                         func() interface{} { return &corev1.Endpoints{} },
+	{APIVersion: "v1", Kind: "Namespace"}:                                         func() interface{} { return &corev1.Namespace{} },
+	{APIVersion: "v1", Kind: "Secret"}:                                            func() interface{} { return &corev1.Secret{} },
+	{APIVersion: "v1", Kind: "Service"}:                                           func() interface{} { return &corev1.Service{} },
+	{APIVersion: "v1", Kind: "ServiceAccount"}:                                    func() interface{} { return &corev1.ServiceAccount{} },
+	{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"}:             func() interface{} { return &rbacv1.ClusterRole{} },
+	{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding"}:      func() interface{} { return &rbacv1.ClusterRoleBinding{} },
+	{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "Role"}:                    func() interface{} { return &rbacv1.Role{} },
+	{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleBinding"}:             func() interface{} { return &rbacv1.RoleBinding{} },
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "ClusterRole"}:        func() interface{} { return &rbacv1beta1.ClusterRole{} },
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "ClusterRoleBinding"}: func() interface{} { return &rbacv1beta1.ClusterRoleBinding{} },
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "Role"}:               func() interface{} { return &rbacv1beta1.Role{} },
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "RoleBinding"}:        func() interface{} { return &rbacv1beta1.RoleBinding{} },
+}
+
+// lintManifests checks the YAML and JSON documents of the manifests before
+// they are written to disk, so that mistakes in the templates or in the
+// manifests of the user fail the installer with the file and line of the
+// document instead of the bootstrap of the cluster. Other files are
+// ignored.
+func lintManifests(files []*asset.File) error {
+	for _, file := range files {
+		switch filepath.Ext(file.Filename) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		for _, document := range splitDocuments(file.Data) {
+			if line, err := lintDocument(document.data); err != nil {
+				return errors.Wrapf(err, "%s:%d", file.Filename, document.line+line-1)
+			}
+		}
+	}
+	return nil
+}
+
+type document struct {
+	// line is the line of the file on which the document starts.
+	line int
+	data []byte
+}
+
+// splitDocuments splits a YAML stream on its "---" separators.
+func splitDocuments(data []byte) []document {
+	var documents []document
+	current := document{line: 1}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimRight(scanner.Text(), " \t") == "---" {
+			documents = append(documents, current)
+			current = document{line: line + 1}
+			continue
+		}
+		current.data = append(current.data, scanner.Bytes()...)
+		current.data = append(current.data, '\n')
+	}
+	return append(documents, current)
+}
+
+// yamlError matches the syntax errors of the YAML parser, whose lines are
+// relative to the document.
+var yamlError = regexp.MustCompile(`yaml: line ([0-9]+): (.*)$`)
+
+// lintDocument returns the line of the document on which it fails, or its
+// first line for errors which are not about a single line.
+func lintDocument(data []byte) (int, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		if match := yamlError.FindStringSubmatch(err.Error()); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			return line, errors.New(match[2])
+		}
+		return 1, err
+	}
+	if bytes.Equal(jsonData, []byte("null")) {
+		// empty or comment-only documents
+		return 1, nil
+	}
+	return 1, lintObject(jsonData)
+}
+
+func lintObject(data []byte) error {
+	var object struct {
+		metav1.TypeMeta `json:",inline"`
+		Metadata        metav1.ObjectMeta `json:"metadata"`
+		Items           []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return errors.Wrap(err, "not a Kubernetes object")
+	}
+	if object.APIVersion == "" || object.Kind == "" {
+		return errors.New("not a Kubernetes object with an apiVersion and a kind")
+	}
+
+	if object.APIVersion == "v1" && object.Kind == "List" {
+		for i, item := range object.Items {
+			if err := lintObject(item); err != nil {
+				return errors.Wrapf(err, "item %d", i)
+			}
+		}
+		return nil
+	}
+
+	if object.Metadata.Name == "" && object.Metadata.GenerateName == "" {
+		return errors.Errorf("%s has no metadata.name", object.Kind)
+	}
+
+	newSchema, ok := manifestSchemas[object.TypeMeta]
+	if !ok {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(newSchema()); err != nil {
+		return errors.Wrapf(err, "invalid %s %s", object.Kind, object.Metadata.Name)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestLintManifests(t *testing.T) {
+	cases := []struct {
+		name          string
+		data          string
+		expectedError string
+	}{
+		{
+			name: "valid",
+			data: `# comment
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: extra
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: extra
+spec:
+  anything: true
+`,
+		},
+		{
+			name: "syntax error in second document",
+			data: `apiVersion: v1
+kind: Namespace
+metadata:
+  name: extra
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: extra
+ labels: {}
+`,
+			expectedError: `^manifests/99-extra\.yaml:9: did not find expected key$`,
+		},
+		{
+			name: "no kind",
+			data: `apiVersion: v1
+metadata:
+  name: extra
+`,
+			expectedError: `^manifests/99-extra\.yaml:1: not a Kubernetes object with an apiVersion and a kind$`,
+		},
+		{
+			name: "no name",
+			data: `apiVersion: v1
+kind: Namespace
+metadata:
+labels: {}
+`,
+			expectedError: `^manifests/99-extra\.yaml:1: Namespace has no metadata\.name$`,
+		},
+		{
+			name: "unknown field",
+			data: `apiVersion: v1
+kind: Secret
+metadata:
+  name: extra
+  namespace: kube-system
+dta:
+  key: dmFsdWU=
+`,
+			expectedError: `^manifests/99-extra\.yaml:1: invalid Secret extra: json: unknown field "dta"$`,
+		},
+		{
+			name: "invalid item of a list",
+			data: `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: extra
+  data:
+    key: 1
+`,
+			expectedError: `^manifests/99-extra\.yaml:1: item 0: invalid ConfigMap extra: .*`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := lintManifests([]*asset.File{
+				{Filename: "manifests/99-extra.yaml", Data: []byte(tc.data)},
+				{Filename: "manifests/README", Data: []byte("not a manifest")},
+			})
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expectedError, err)
+			}
+		})
+	}
+}
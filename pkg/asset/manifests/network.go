@@ -102,6 +102,17 @@ func (no *Networking) Generate(dependencies asset.Parents) error {
 			// Default to network policy, operator provides all other defaults.
 			Mode: netopv1.SDNModePolicy,
 		}
+		if netConfig.OpenshiftSDN != nil {
+			defaultNet.OpenshiftSDNConfig.VXLANPort = netConfig.OpenshiftSDN.VXLANPort
+			defaultNet.OpenshiftSDNConfig.MTU = netConfig.OpenshiftSDN.MTU
+		}
+	}
+
+	var kubeProxyConfig *netopv1.ProxyConfig
+	if netConfig.KubeProxy != nil && netConfig.KubeProxy.IptablesSyncPeriod != "" {
+		kubeProxyConfig = &netopv1.ProxyConfig{
+			IptablesSyncPeriod: netConfig.KubeProxy.IptablesSyncPeriod,
+		}
 	}
 
 	no.config = &netopv1.NetworkConfig{
@@ -118,6 +129,7 @@ func (no *Networking) Generate(dependencies asset.Parents) error {
 			ServiceNetwork:  netConfig.ServiceCIDR.String(),
 			ClusterNetworks: clusterNets,
 			DefaultNetwork:  defaultNet,
+			KubeProxyConfig: kubeProxyConfig,
 		},
 	}
 
@@ -0,0 +1,244 @@
+package manifests
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+const (
+	// oauthCAKey is the key of the CA bundle in the config maps which the
+	// identity providers reference.
+	oauthCAKey = "ca.crt"
+)
+
+var (
+	oauthCfgFilename = filepath.Join(manifestDir, "cluster-oauth-02-config.yml")
+
+	// oauthFilePattern matches the config and the secrets and config maps
+	// of its identity providers.
+	oauthFilePattern = filepath.Join(manifestDir, "cluster-oauth-*.yml")
+)
+
+// oauth is the config.openshift.io/v1 OAuth, whose vendored version inlines
+// the provider configuration in a way which encoding/json does not support.
+type oauth struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              oauthSpec `json:"spec"`
+}
+
+type oauthSpec struct {
+	IdentityProviders []oauthIdentityProvider `json:"identityProviders"`
+}
+
+type oauthIdentityProvider struct {
+	Name          string                                     `json:"name"`
+	Challenge     bool                                       `json:"challenge"`
+	Login         bool                                       `json:"login"`
+	MappingMethod configv1.MappingMethodType                 `json:"mappingMethod"`
+	Type          configv1.IdentityProviderType              `json:"type"`
+	HTPasswd      *configv1.HTPasswdPasswordIdentityProvider `json:"htpasswd,omitempty"`
+	LDAP          *configv1.LDAPPasswordIdentityProvider     `json:"ldap,omitempty"`
+	OpenID        *configv1.OpenIDIdentityProvider           `json:"openID,omitempty"`
+}
+
+// OAuth generates the cluster-oauth-*.yml files, which configure the
+// identity providers of the install-config, and the secrets and config maps
+// which they reference in the openshift-config namespace. It generates no
+// files if the install-config has no identity providers.
+type OAuth struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*OAuth)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*OAuth) Name() string {
+	return "OAuth Config"
+}
+
+// Sensitive returns true, because the manifests contain the htpasswd files,
+// bind passwords, and client secrets of the identity providers.
+func (*OAuth) Sensitive() bool {
+	return true
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*OAuth) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the OAuth config and the secrets and config maps of its
+// identity providers.
+func (o *OAuth) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	o.FileList = nil
+	if len(installConfig.Config.IdentityProviders) == 0 {
+		return nil
+	}
+
+	config := &oauth{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: configv1.SchemeGroupVersion.String(),
+			Kind:       "OAuth",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			// not namespaced
+		},
+	}
+
+	var objects []interface{}
+	for i, idp := range installConfig.Config.IdentityProviders {
+		// The secrets and config maps are named after the index, because
+		// the identity provider names need not be valid object names.
+		prefix := fmt.Sprintf("idp-%d", i)
+		provider := oauthIdentityProvider{
+			Name:          idp.Name,
+			Login:         true,
+			MappingMethod: configv1.MappingMethodClaim,
+		}
+		switch {
+		case idp.HTPasswd != nil:
+			name := prefix + "-htpasswd"
+			provider.Challenge = true
+			provider.Type = configv1.IdentityProviderTypeHTPasswd
+			provider.HTPasswd = &configv1.HTPasswdPasswordIdentityProvider{
+				FileData: configv1.LocalSecretReference{Name: name},
+			}
+			objects = append(objects, oauthSecret(name, configv1.HTPasswdDataKey, idp.HTPasswd.FileData))
+		case idp.LDAP != nil:
+			provider.Challenge = true
+			ldap := &configv1.LDAPPasswordIdentityProvider{
+				URL:      idp.LDAP.URL,
+				BindDN:   idp.LDAP.BindDN,
+				Insecure: idp.LDAP.Insecure,
+				Attributes: configv1.LDAPAttributeMapping{
+					ID:                []string{"dn"},
+					PreferredUsername: []string{"uid"},
+					Name:              []string{"cn"},
+					Email:             []string{"mail"},
+				},
+			}
+			if idp.LDAP.BindPassword != "" {
+				name := prefix + "-bind-password"
+				ldap.BindPassword = configv1.LocalSecretReference{Name: name}
+				objects = append(objects, oauthSecret(name, configv1.BindPasswordKey, idp.LDAP.BindPassword))
+			}
+			if idp.LDAP.CA != "" {
+				name := prefix + "-ca"
+				ldap.CA = configv1.ConfigMapReference{Name: name}
+				objects = append(objects, configMap("openshift-config", name, genericData{oauthCAKey: idp.LDAP.CA}))
+			}
+			provider.Type, provider.LDAP = configv1.IdentityProviderTypeLDAP, ldap
+		case idp.OpenID != nil:
+			name := prefix + "-client-secret"
+			openID := &configv1.OpenIDIdentityProvider{
+				ClientID:     idp.OpenID.ClientID,
+				ClientSecret: configv1.LocalSecretReference{Name: name},
+				URLs: configv1.OpenIDURLs{
+					Authorize: idp.OpenID.AuthorizeURL,
+					Token:     idp.OpenID.TokenURL,
+					UserInfo:  idp.OpenID.UserInfoURL,
+				},
+				Claims: configv1.OpenIDClaims{
+					PreferredUsername: []string{"preferred_username", "email"},
+					Name:              []string{"name"},
+					Email:             []string{"email"},
+				},
+			}
+			objects = append(objects, oauthSecret(name, configv1.ClientSecretKey, idp.OpenID.ClientSecret))
+			if idp.OpenID.CA != "" {
+				name := prefix + "-ca"
+				openID.CA = configv1.ConfigMapReference{Name: name}
+				objects = append(objects, configMap("openshift-config", name, genericData{oauthCAKey: idp.OpenID.CA}))
+			}
+			provider.Type, provider.OpenID = configv1.IdentityProviderTypeOpenID, openID
+		default:
+			return errors.Errorf("identity provider %q has no provider configuration", idp.Name)
+		}
+		config.Spec.IdentityProviders = append(config.Spec.IdentityProviders, provider)
+	}
+
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s manifests from InstallConfig", o.Name())
+	}
+	o.FileList = []*asset.File{
+		{
+			Filename: oauthCfgFilename,
+			Data:     configData,
+		},
+	}
+
+	for i, object := range objects {
+		data, err := yaml.Marshal(object)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create %s manifests from InstallConfig", o.Name())
+		}
+		o.FileList = append(o.FileList, &asset.File{
+			Filename: filepath.Join(manifestDir, fmt.Sprintf("cluster-oauth-03-%d.yml", i)),
+			Data:     data,
+		})
+	}
+
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (o *OAuth) Files() []*asset.File {
+	return o.FileList
+}
+
+// Load loads the already-rendered files back from disk.
+func (o *OAuth) Load(f asset.FileFetcher) (bool, error) {
+	fileList, err := f.FetchByPattern(oauthFilePattern)
+	if err != nil {
+		return false, err
+	}
+	if len(fileList) == 0 {
+		return false, nil
+	}
+	if fileList[0].Filename != oauthCfgFilename {
+		return false, errors.Errorf("%s is missing", oauthCfgFilename)
+	}
+
+	config := &oauth{}
+	if err := yaml.Unmarshal(fileList[0].Data, config); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal %s", oauthCfgFilename)
+	}
+
+	o.FileList = fileList
+	return true, nil
+}
+
+func oauthSecret(name, key, value string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openshift-config",
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			key: []byte(value),
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestOAuthGenerate(t *testing.T) {
+	installConfig := &installconfig.InstallConfig{
+		Config: &types.InstallConfig{
+			IdentityProviders: []types.IdentityProvider{
+				{
+					Name:     "local",
+					HTPasswd: &types.HTPasswdIdentityProvider{FileData: "admin:$2y$05$hash"},
+				},
+			},
+		},
+	}
+	parents := asset.Parents{}
+	parents.Add(installConfig)
+
+	oauth := &OAuth{}
+	if !assert.NoError(t, oauth.Generate(parents)) {
+		return
+	}
+	if !assert.Len(t, oauth.Files(), 2) {
+		return
+	}
+	assert.Equal(t, "manifests/cluster-oauth-02-config.yml", oauth.Files()[0].Filename)
+	assert.Equal(t, `apiVersion: config.openshift.io/v1
+kind: OAuth
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  identityProviders:
+  - challenge: true
+    htpasswd:
+      fileData:
+        name: idp-0-htpasswd
+    login: true
+    mappingMethod: claim
+    name: local
+    type: HTPasswd
+`, string(oauth.Files()[0].Data))
+	assert.Equal(t, "manifests/cluster-oauth-03-0.yml", oauth.Files()[1].Filename)
+	assert.Contains(t, string(oauth.Files()[1].Data), "name: idp-0-htpasswd\n  namespace: openshift-config\n")
+}
@@ -2,24 +2,17 @@ package manifests
 
 import (
 	"encoding/base64"
+	"fmt"
 	"path/filepath"
+	"sort"
 
-	"github.com/aws/aws-sdk-go/aws/session"
-
-	// TODO(flaper87): Migrate to ghodss asap
-	// This yaml is currently used only by the OpenStack
-	// clouds serialization. We're working on migrating
-	// clientconfig out of go-yaml. We'll use it here
-	// until that happens.
-	// https://github.com/openshift/installer/pull/854
-	"gopkg.in/yaml.v2"
-
-	"github.com/gophercloud/utils/openstack/clientconfig"
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/machines"
 	"github.com/openshift/installer/pkg/asset/password"
 	"github.com/openshift/installer/pkg/asset/templates/content/openshift"
+	"github.com/openshift/installer/pkg/types"
 )
 
 const (
@@ -40,6 +33,11 @@ func (o *Openshift) Name() string {
 	return "Openshift Manifests"
 }
 
+// Sensitive returns true, because the manifests contain the cloud credentials and the kubeadmin password.
+func (o *Openshift) Sensitive() bool {
+	return true
+}
+
 // Dependencies returns all of the dependencies directly needed by the
 // Openshift asset
 func (o *Openshift) Dependencies() []asset.Asset {
@@ -48,12 +46,16 @@ func (o *Openshift) Dependencies() []asset.Asset {
 		&ClusterK8sIO{},
 		&machines.Worker{},
 		&machines.Master{},
+		&machines.Infra{},
 		&password.KubeadminPassword{},
 
 		&openshift.BindingDiscovery{},
 		&openshift.CloudCredsSecret{},
 		&openshift.KubeadminPasswordSecret{},
 		&openshift.RoleCloudCredsSecretReader{},
+
+		&ExtraManifests{},
+		&machine.ExternalConfigs{},
 	}
 }
 
@@ -64,52 +66,19 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 	clusterk8sio := &ClusterK8sIO{}
 	worker := &machines.Worker{}
 	master := &machines.Master{}
-	dependencies.Get(installConfig, clusterk8sio, worker, master, kubeadminPassword)
-	var cloudCreds cloudCredsSecretData
-	platform := installConfig.Config.Platform.Name()
-	switch platform {
-	case "aws":
-		ssn := session.Must(session.NewSessionWithOptions(session.Options{
-			SharedConfigState: session.SharedConfigEnable,
-		}))
-		creds, err := ssn.Config.Credentials.Get()
-		if err != nil {
-			return err
-		}
-		cloudCreds = cloudCredsSecretData{
-			AWS: &AwsCredsSecretData{
-				Base64encodeAccessKeyID:     base64.StdEncoding.EncodeToString([]byte(creds.AccessKeyID)),
-				Base64encodeSecretAccessKey: base64.StdEncoding.EncodeToString([]byte(creds.SecretAccessKey)),
-			},
-		}
-	case "openstack":
-		opts := new(clientconfig.ClientOpts)
-		cloud, err := clientconfig.GetCloudFromYAML(opts)
-		if err != nil {
-			return err
-		}
-		clouds := make(map[string]map[string]*clientconfig.Cloud)
-		clouds["clouds"] = map[string]*clientconfig.Cloud{
-			"openstack": cloud,
-		}
-
-		marshalled, err := yaml.Marshal(clouds)
-		if err != nil {
-			return err
-		}
-
-		credsEncoded := base64.StdEncoding.EncodeToString(marshalled)
-		cloudCreds = cloudCredsSecretData{
-			OpenStack: &OpenStackCredsSecretData{
-				Base64encodeCloudCreds: credsEncoded,
-			},
-		}
+	infra := &machines.Infra{}
+	dependencies.Get(installConfig, clusterk8sio, worker, master, infra, kubeadminPassword)
+	creds, err := cloudCreds(installConfig.Config)
+	if err != nil {
+		return err
 	}
 
 	templateData := &openshiftTemplateData{
-		CloudCreds:                   cloudCreds,
 		Base64EncodedKubeadminPwHash: base64.StdEncoding.EncodeToString(kubeadminPassword.PasswordHash),
 	}
+	if creds != nil {
+		templateData.CloudCreds = *creds
+	}
 
 	bindingDiscovery := &openshift.BindingDiscovery{}
 	cloudCredsSecret := &openshift.CloudCredsSecret{}
@@ -122,7 +91,6 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 		roleCloudCredsSecretReader)
 	assetData := map[string][]byte{
 		"99_binding-discovery.yaml":                             []byte(bindingDiscovery.Files()[0].Data),
-		"99_kubeadmin-password-secret.yaml":                     applyTemplateData(kubeadminPasswordSecret.Files()[0].Data, templateData),
 		"99_openshift-cluster-api_cluster.yaml":                 clusterk8sio.Raw,
 		"99_openshift-cluster-api_master-machines.yaml":         master.MachinesRaw,
 		"99_openshift-cluster-api_master-user-data-secret.yaml": master.UserDataSecretRaw,
@@ -130,8 +98,65 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 		"99_openshift-cluster-api_worker-user-data-secret.yaml": worker.UserDataSecretRaw,
 	}
 
-	switch platform {
-	case "aws", "openstack":
+	if kubeadminPassword.PasswordHash != nil {
+		assetData["99_kubeadmin-password-secret.yaml"] = applyTemplateData(kubeadminPasswordSecret.Files()[0].Data, templateData)
+	}
+	if worker.ClusterAutoscalerRaw != nil {
+		assetData["99_cluster-autoscaler.yaml"] = worker.ClusterAutoscalerRaw
+		assetData["99_openshift-cluster-api_worker-machineautoscalers.yaml"] = worker.MachineAutoscalersRaw
+	}
+	if worker.MachineHealthCheckRaw != nil {
+		assetData["99_openshift-cluster-api_worker-machinehealthcheck.yaml"] = worker.MachineHealthCheckRaw
+	}
+	if worker.UnhealthyConditionsRaw != nil {
+		assetData["99_openshift-cluster-api_node-unhealthy-conditions.yaml"] = worker.UnhealthyConditionsRaw
+	}
+	if infra.MachineHealthCheckRaw != nil {
+		assetData["99_openshift-cluster-api_infra-machinehealthcheck.yaml"] = infra.MachineHealthCheckRaw
+	}
+	if infra.MachineSetRaw != nil {
+		assetData["99_openshift-cluster-api_infra-machineset.yaml"] = infra.MachineSetRaw
+	}
+	if infra.MonitoringConfigRaw != nil {
+		assetData["99_openshift-monitoring_cluster-monitoring-config.yaml"] = infra.MonitoringConfigRaw
+	}
+	imageRegistryConfig, err := imageRegistryConfigManifest(installConfig.Config)
+	if err != nil {
+		return err
+	}
+	if imageRegistryConfig != nil {
+		assetData["99_openshift-image-registry_config.yaml"] = imageRegistryConfig
+	}
+	ingressController, err := machines.IngressController(installConfig.Config)
+	if err != nil {
+		return err
+	}
+	if ingressController != nil {
+		assetData["99_openshift-ingress-operator_default-ingresscontroller.yaml"] = ingressController
+	}
+
+	for _, role := range []string{"master", "worker"} {
+		for kind, machineConfig := range map[string]func(*types.InstallConfig, string) ([]byte, error){
+			"ssh":     machine.SSHMachineConfig,
+			"storage": machine.StorageMachineConfig,
+		} {
+			data, err := machineConfig(installConfig.Config, role)
+			if err != nil {
+				return err
+			}
+			if data != nil {
+				assetData[fmt.Sprintf("99_openshift-machineconfig_99-%s-%s.yaml", role, kind)] = data
+			}
+		}
+	}
+
+	externalConfigs := &machine.ExternalConfigs{}
+	dependencies.Get(externalConfigs)
+	for _, file := range externalConfigs.MachineConfigs() {
+		assetData["99_external_"+filepath.Base(file.Filename)] = file.Data
+	}
+
+	if creds != nil {
 		assetData["99_cloud-creds-secret.yaml"] = applyTemplateData(cloudCredsSecret.Files()[0].Data, templateData)
 		assetData["99_role-cloud-creds-secret-reader.yaml"] = applyTemplateData(roleCloudCredsSecretReader.Files()[0].Data, templateData)
 	}
@@ -144,6 +169,17 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 		})
 	}
 
+	extraManifests := &ExtraManifests{}
+	dependencies.Get(extraManifests)
+	o.FileList, err = extraManifests.merge(openshiftManifestDir, o.FileList)
+	if err != nil {
+		return err
+	}
+	if err := lintManifests(o.FileList); err != nil {
+		return err
+	}
+	sort.Slice(o.FileList, func(i, j int) bool { return o.FileList[i].Filename < o.FileList[j].Filename })
+
 	return nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -48,6 +49,11 @@ func (m *Manifests) Name() string {
 	return "Common Manifests"
 }
 
+// Sensitive returns true, because the manifests contain the pull secret and private keys.
+func (m *Manifests) Sensitive() bool {
+	return true
+}
+
 // Dependencies returns all of the dependencies directly needed by a
 // Manifests asset.
 func (m *Manifests) Dependencies() []asset.Asset {
@@ -55,6 +61,9 @@ func (m *Manifests) Dependencies() []asset.Asset {
 		&installconfig.ClusterID{},
 		&installconfig.InstallConfig{},
 		&Ingress{},
+		&APIServer{},
+		&OAuth{},
+		&Scheduler{},
 		&DNS{},
 		&Infrastructure{},
 		&Networking{},
@@ -65,7 +74,6 @@ func (m *Manifests) Dependencies() []asset.Asset {
 		&tls.ServiceServingCA{},
 		&tls.EtcdClientCertKey{},
 		&tls.MCSCertKey{},
-		&tls.KubeletCertKey{},
 
 		&bootkube.KubeCloudConfig{},
 		&bootkube.MachineConfigServerTLSSecret{},
@@ -82,17 +90,22 @@ func (m *Manifests) Dependencies() []asset.Asset {
 		&bootkube.OpenshiftServiceCertSignerNamespace{},
 		&bootkube.EtcdServiceKubeSystem{},
 		&bootkube.HostEtcdServiceKubeSystem{},
+
+		&ExtraManifests{},
 	}
 }
 
 // Generate generates the respective operator config.yml files
 func (m *Manifests) Generate(dependencies asset.Parents) error {
 	ingress := &Ingress{}
+	apiServer := &APIServer{}
+	oauth := &OAuth{}
+	scheduler := &Scheduler{}
 	dns := &DNS{}
 	network := &Networking{}
 	infra := &Infrastructure{}
 	installConfig := &installconfig.InstallConfig{}
-	dependencies.Get(installConfig, ingress, dns, network, infra)
+	dependencies.Get(installConfig, ingress, apiServer, oauth, scheduler, dns, network, infra)
 
 	// mao go to kube-system config map
 	m.KubeSysConfig = configMap("kube-system", "cluster-config-v1", genericData{
@@ -112,10 +125,28 @@ func (m *Manifests) Generate(dependencies asset.Parents) error {
 	m.FileList = append(m.FileList, m.generateBootKubeManifests(dependencies)...)
 
 	m.FileList = append(m.FileList, ingress.Files()...)
+	m.FileList = append(m.FileList, apiServer.Files()...)
+	m.FileList = append(m.FileList, oauth.Files()...)
+	m.FileList = append(m.FileList, scheduler.Files()...)
 	m.FileList = append(m.FileList, dns.Files()...)
 	m.FileList = append(m.FileList, network.Files()...)
 	m.FileList = append(m.FileList, infra.Files()...)
 
+	extraManifests := &ExtraManifests{}
+	dependencies.Get(extraManifests)
+	m.FileList, err = extraManifests.merge(manifestDir, m.FileList)
+	if err != nil {
+		return err
+	}
+	if err := lintManifests(m.FileList); err != nil {
+		return err
+	}
+
+	// Sort the files as FetchByPattern does, so that the generated
+	// manifests match the ones loaded from disk, and the Ignition configs
+	// embedding them are the same for the same inputs.
+	sort.Slice(m.FileList, func(i, j int) bool { return m.FileList[i].Filename < m.FileList[j].Filename })
+
 	return nil
 }
 
@@ -163,6 +194,7 @@ func (m *Manifests) generateBootKubeManifests(dependencies asset.Parents) []*ass
 		ServiceServingCaCert:            base64.StdEncoding.EncodeToString(serviceServingCA.Cert()),
 		ServiceServingCaKey:             base64.StdEncoding.EncodeToString(serviceServingCA.Key()),
 		CVOClusterID:                    clusterID.ClusterID,
+		CVOOverrides:                    installConfig.Config.ClusterVersionOverrides,
 		EtcdEndpointHostnames:           etcdEndpointHostnames,
 		EtcdEndpointDNSSuffix:           installConfig.Config.BaseDomain,
 	}
@@ -236,7 +268,9 @@ func applyTemplateData(data []byte, templateData interface{}) []byte {
 	return buf.Bytes()
 }
 
-// Load returns the manifests asset from disk.
+// Load returns the manifests asset from disk. Any files the user has added to
+// or edited in the manifests directory are kept as-is so that later targets
+// consume them instead of regenerated manifests.
 func (m *Manifests) Load(f asset.FileFetcher) (bool, error) {
 	fileList, err := f.FetchByPattern(filepath.Join(manifestDir, "*"))
 	if err != nil {
@@ -258,8 +292,7 @@ func (m *Manifests) Load(f asset.FileFetcher) (bool, error) {
 	}
 
 	if !found {
-		return false, nil
-
+		return false, errors.Errorf("%q is missing from the %q directory; remove the directory to regenerate the manifests", filepath.Base(kubeSysConfigPath), manifestDir)
 	}
 
 	m.FileList, m.KubeSysConfig = fileList, kubeSysConfig
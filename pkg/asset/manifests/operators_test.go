@@ -0,0 +1,81 @@
+package manifests
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/mock"
+)
+
+func TestManifestsLoad(t *testing.T) {
+	clusterConfig := &asset.File{
+		Filename: kubeSysConfigPath,
+		Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cluster-config-v1
+  namespace: kube-system
+data:
+  install-config: "edited"
+`),
+	}
+	userManifest := &asset.File{
+		Filename: filepath.Join(manifestDir, "99-user.yaml"),
+		Data:     []byte("kind: Namespace"),
+	}
+
+	cases := []struct {
+		name          string
+		files         []*asset.File
+		fetchError    error
+		expectedFound bool
+		expectedError bool
+	}{
+		{
+			name:          "edited manifests",
+			files:         []*asset.File{clusterConfig, userManifest},
+			expectedFound: true,
+		},
+		{
+			name: "no manifests",
+		},
+		{
+			name:          "missing cluster config",
+			files:         []*asset.File{userManifest},
+			expectedError: true,
+		},
+		{
+			name:          "error fetching files",
+			fetchError:    errors.New("fetch failed"),
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			fileFetcher.EXPECT().FetchByPattern(filepath.Join(manifestDir, "*")).
+				Return(tc.files, tc.fetchError)
+
+			m := &Manifests{}
+			found, err := m.Load(fileFetcher)
+			assert.Equal(t, tc.expectedFound, found, "unexpected found value returned from Load")
+			if tc.expectedError {
+				assert.Error(t, err, "expected error from Load")
+			} else {
+				assert.NoError(t, err, "unexpected error from Load")
+			}
+			if tc.expectedFound {
+				assert.Equal(t, tc.files, m.FileList, "unexpected files in Manifests")
+				assert.Equal(t, "edited", m.KubeSysConfig.Data["install-config"], "unexpected install-config in cluster config")
+			}
+		})
+	}
+}
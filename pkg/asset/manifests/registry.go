@@ -0,0 +1,134 @@
+package manifests
+
+import (
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// maxRegistryReplicas is the number of image registry replicas run on
+// user-provisioned workers, so that pulls survive the loss of a node.
+const maxRegistryReplicas = 2
+
+// imageRegistryConfig is the imageregistry.operator.openshift.io/v1 Config,
+// which is not vendored.
+type imageRegistryConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              imageRegistryConfigSpec `json:"spec"`
+}
+
+type imageRegistryConfigSpec struct {
+	ManagementState string                `json:"managementState"`
+	Replicas        int32                 `json:"replicas,omitempty"`
+	Storage         *imageRegistryStorage `json:"storage,omitempty"`
+}
+
+type imageRegistryStorage struct {
+	S3       *imageRegistryS3Storage    `json:"s3,omitempty"`
+	Swift    *imageRegistrySwiftStorage `json:"swift,omitempty"`
+	EmptyDir *struct{}                  `json:"emptyDir,omitempty"`
+}
+
+type imageRegistryS3Storage struct {
+	Bucket string `json:"bucket"`
+	Region string `json:"region"`
+}
+
+type imageRegistrySwiftStorage struct {
+	Container string `json:"container"`
+}
+
+// imageRegistryConfigManifest returns the manifest of the image registry
+// operator config, or nil if the install-config leaves the storage and the
+// replicas to the operator.
+func imageRegistryConfigManifest(installConfig *types.InstallConfig) ([]byte, error) {
+	registry := installConfig.ImageRegistry
+	replicas := imageRegistryReplicas(installConfig)
+	if registry == nil {
+		if replicas == 0 {
+			return nil, nil
+		}
+		registry = &types.ImageRegistry{}
+	}
+
+	config := &imageRegistryConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "imageregistry.operator.openshift.io/v1",
+			Kind:       "Config",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			// not namespaced
+		},
+		Spec: imageRegistryConfigSpec{
+			ManagementState: "Managed",
+			Replicas:        replicas,
+		},
+	}
+
+	switch storage := registry.Storage; {
+	case registry.Removed:
+		config.Spec.ManagementState = "Removed"
+	case storage.S3 != nil:
+		region := storage.S3.Region
+		if region == "" && installConfig.Platform.AWS != nil {
+			region = installConfig.Platform.AWS.Region
+		}
+		config.Spec.Storage = &imageRegistryStorage{
+			S3: &imageRegistryS3Storage{Bucket: storage.S3.Bucket, Region: region},
+		}
+	case storage.Swift != nil:
+		config.Spec.Storage = &imageRegistryStorage{
+			Swift: &imageRegistrySwiftStorage{Container: storage.Swift.Container},
+		}
+	case storage.EmptyDir != nil:
+		config.Spec.Storage = &imageRegistryStorage{EmptyDir: &struct{}{}}
+	case replicas == 0:
+		return nil, nil
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the image registry config")
+	}
+	return data, nil
+}
+
+// imageRegistryReplicas returns the number of image registry replicas
+// sized for the declared replicas of a user-provisioned worker pool, or 0
+// to leave them to the operator, which runs a single replica. The installer
+// cannot scale user-provisioned pools, so the replicas are only raised when
+// the pool declares enough workers for them, and the storage, S3 or Swift,
+// is shared between them.
+func imageRegistryReplicas(installConfig *types.InstallConfig) int32 {
+	var pool *types.MachinePool
+	for idx := range installConfig.Machines {
+		if installConfig.Machines[idx].Name == "worker" {
+			pool = &installConfig.Machines[idx]
+		}
+	}
+	if pool == nil || !pool.UserProvisioned || pool.Replicas == nil || *pool.Replicas < maxRegistryReplicas {
+		return 0
+	}
+
+	var storage types.ImageRegistryStorage
+	if registry := installConfig.ImageRegistry; registry != nil {
+		if registry.Removed {
+			return 0
+		}
+		storage = registry.Storage
+	}
+	switch {
+	case storage.S3 != nil, storage.Swift != nil:
+	case storage.EmptyDir != nil:
+		return 0
+	case installConfig.Platform.AWS == nil && installConfig.Platform.OpenStack == nil:
+		// the operator only defaults to shared storage, S3 or Swift, on
+		// AWS and OpenStack
+		return 0
+	}
+	return maxRegistryReplicas
+}
@@ -0,0 +1,174 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/libvirt"
+	"github.com/openshift/installer/pkg/types/openstack"
+)
+
+func TestImageRegistryConfigManifest(t *testing.T) {
+	cases := []struct {
+		name     string
+		registry *types.ImageRegistry
+		expected string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "s3",
+			registry: &types.ImageRegistry{
+				Storage: types.ImageRegistryStorage{
+					S3: &types.ImageRegistryS3Storage{Bucket: "test-registry"},
+				},
+			},
+			expected: `apiVersion: imageregistry.operator.openshift.io/v1
+kind: Config
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  managementState: Managed
+  storage:
+    s3:
+      bucket: test-registry
+      region: us-east-1
+`,
+		},
+		{
+			name: "emptyDir",
+			registry: &types.ImageRegistry{
+				Storage: types.ImageRegistryStorage{
+					EmptyDir: &types.ImageRegistryEmptyDirStorage{},
+				},
+			},
+			expected: `apiVersion: imageregistry.operator.openshift.io/v1
+kind: Config
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  managementState: Managed
+  storage:
+    emptyDir: {}
+`,
+		},
+		{
+			name:     "removed",
+			registry: &types.ImageRegistry{Removed: true},
+			expected: `apiVersion: imageregistry.operator.openshift.io/v1
+kind: Config
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  managementState: Removed
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := imageRegistryConfigManifest(&types.InstallConfig{
+				Platform:      types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+				ImageRegistry: tc.registry,
+			})
+			assert.NoError(t, err)
+			if tc.expected == "" {
+				assert.Nil(t, data)
+			} else {
+				assert.Equal(t, tc.expected, string(data))
+			}
+		})
+	}
+}
+
+func TestImageRegistryReplicas(t *testing.T) {
+	userProvisioned := func(replicas int64) []types.MachinePool {
+		return []types.MachinePool{{Name: "worker", Replicas: pointer.Int64Ptr(replicas), UserProvisioned: true}}
+	}
+	awsPlatform := types.Platform{AWS: &aws.Platform{Region: "us-east-1"}}
+	cases := []struct {
+		name     string
+		platform types.Platform
+		machines []types.MachinePool
+		registry *types.ImageRegistry
+		expected int32
+	}{
+		{
+			name:     "machine API workers",
+			platform: awsPlatform,
+			machines: []types.MachinePool{{Name: "worker", Replicas: pointer.Int64Ptr(3)}},
+		},
+		{
+			name:     "user-provisioned workers",
+			platform: awsPlatform,
+			machines: userProvisioned(3),
+			expected: 2,
+		},
+		{
+			name:     "single user-provisioned worker",
+			platform: awsPlatform,
+			machines: userProvisioned(1),
+		},
+		{
+			name:     "user-provisioned infra",
+			platform: awsPlatform,
+			machines: []types.MachinePool{{Name: "infra", Replicas: pointer.Int64Ptr(3), UserProvisioned: true}},
+		},
+		{
+			name:     "swift",
+			platform: types.Platform{OpenStack: &openstack.Platform{}},
+			machines: userProvisioned(2),
+			expected: 2,
+		},
+		{
+			name:     "libvirt",
+			platform: types.Platform{Libvirt: &libvirt.Platform{}},
+			machines: userProvisioned(2),
+		},
+		{
+			name:     "emptyDir",
+			platform: awsPlatform,
+			machines: userProvisioned(2),
+			registry: &types.ImageRegistry{Storage: types.ImageRegistryStorage{EmptyDir: &types.ImageRegistryEmptyDirStorage{}}},
+		},
+		{
+			name:     "removed",
+			platform: awsPlatform,
+			machines: userProvisioned(2),
+			registry: &types.ImageRegistry{Removed: true},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, imageRegistryReplicas(&types.InstallConfig{
+				Platform:      tc.platform,
+				Machines:      tc.machines,
+				ImageRegistry: tc.registry,
+			}))
+		})
+	}
+}
+
+func TestImageRegistryConfigManifestReplicas(t *testing.T) {
+	data, err := imageRegistryConfigManifest(&types.InstallConfig{
+		Platform: types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+		Machines: []types.MachinePool{{Name: "worker", Replicas: pointer.Int64Ptr(3), UserProvisioned: true}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `apiVersion: imageregistry.operator.openshift.io/v1
+kind: Config
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  managementState: Managed
+  replicas: 2
+`, string(data))
+}
@@ -0,0 +1,118 @@
+package manifests
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+var (
+	schedulerCfgFilename = filepath.Join(manifestDir, "cluster-scheduler-02-config.yml")
+)
+
+// scheduler is the config.openshift.io/v1 Scheduler, which is not vendored.
+type scheduler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              schedulerSpec `json:"spec"`
+}
+
+type schedulerSpec struct {
+	Profile             string `json:"profile,omitempty"`
+	DefaultNodeSelector string `json:"defaultNodeSelector,omitempty"`
+	MastersSchedulable  bool   `json:"mastersSchedulable"`
+}
+
+// Scheduler generates the cluster-scheduler-*.yml files, which configure the
+// default scheduler. It generates no files if the install-config has no
+// scheduler configuration.
+type Scheduler struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Scheduler)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*Scheduler) Name() string {
+	return "Scheduler Config"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*Scheduler) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the scheduler config.
+func (s *Scheduler) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	s.FileList = nil
+	schedulerConfig := installConfig.Config.Scheduler
+	if schedulerConfig == nil {
+		return nil
+	}
+
+	config := &scheduler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: configv1.SchemeGroupVersion.String(),
+			Kind:       "Scheduler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			// not namespaced
+		},
+		Spec: schedulerSpec{
+			Profile:             string(schedulerConfig.Profile),
+			DefaultNodeSelector: schedulerConfig.DefaultNodeSelector,
+			MastersSchedulable:  schedulerConfig.MastersSchedulable,
+		},
+	}
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s manifests from InstallConfig", s.Name())
+	}
+
+	s.FileList = []*asset.File{
+		{
+			Filename: schedulerCfgFilename,
+			Data:     configData,
+		},
+	}
+
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (s *Scheduler) Files() []*asset.File {
+	return s.FileList
+}
+
+// Load loads the already-rendered files back from disk.
+func (s *Scheduler) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(schedulerCfgFilename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	config := &scheduler{}
+	if err := yaml.Unmarshal(file.Data, config); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal %s", schedulerCfgFilename)
+	}
+
+	s.FileList = []*asset.File{file}
+	return true, nil
+}
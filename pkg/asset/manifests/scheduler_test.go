@@ -0,0 +1,76 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestSchedulerGenerate(t *testing.T) {
+	cases := []struct {
+		name      string
+		scheduler *types.Scheduler
+		expected  string
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name:      "empty config",
+			scheduler: &types.Scheduler{},
+			expected: `apiVersion: config.openshift.io/v1
+kind: Scheduler
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  mastersSchedulable: false
+`,
+		},
+		{
+			name: "full config",
+			scheduler: &types.Scheduler{
+				Profile:             types.HighNodeUtilizationSchedulerProfile,
+				DefaultNodeSelector: "node-role.kubernetes.io/worker=",
+				MastersSchedulable:  true,
+			},
+			expected: `apiVersion: config.openshift.io/v1
+kind: Scheduler
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  defaultNodeSelector: node-role.kubernetes.io/worker=
+  mastersSchedulable: true
+  profile: HighNodeUtilization
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			installConfig := &installconfig.InstallConfig{
+				Config: &types.InstallConfig{Scheduler: tc.scheduler},
+			}
+			parents := asset.Parents{}
+			parents.Add(installConfig)
+
+			scheduler := &Scheduler{}
+			if !assert.NoError(t, scheduler.Generate(parents)) {
+				return
+			}
+			if tc.expected == "" {
+				assert.Empty(t, scheduler.Files())
+				return
+			}
+			if !assert.Len(t, scheduler.Files(), 1) {
+				return
+			}
+			assert.Equal(t, "manifests/cluster-scheduler-02-config.yml", scheduler.Files()[0].Filename)
+			assert.Equal(t, tc.expected, string(scheduler.Files()[0].Data))
+		})
+	}
+}
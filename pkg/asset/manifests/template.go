@@ -1,5 +1,9 @@
 package manifests
 
+import (
+	"github.com/openshift/installer/pkg/types"
+)
+
 // AwsCredsSecretData holds encoded credentials and is used to generate cloud-creds secret
 type AwsCredsSecretData struct {
 	Base64encodeAccessKeyID     string
@@ -31,6 +35,7 @@ type bootkubeTemplateData struct {
 	ServiceServingCaKey             string
 	WorkerIgnConfig                 string
 	CVOClusterID                    string
+	CVOOverrides                    []types.ClusterVersionOverride
 	EtcdEndpointHostnames           []string
 	EtcdEndpointDNSSuffix           string
 }
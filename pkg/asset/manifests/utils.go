@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/openshift/installer/pkg/types"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -33,6 +34,24 @@ func configMap(namespace, name string, data genericData) *configurationObject {
 	}
 }
 
+func tlsSecret(namespace, name string, servingCertificate *types.ServingCertificate) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte(servingCertificate.Certificate),
+			corev1.TLSPrivateKeyKey: []byte(servingCertificate.Key),
+		},
+	}
+}
+
 func getAPIServerURL(ic *types.InstallConfig) string {
 	return fmt.Sprintf("https://%s-api.%s:6443", ic.ObjectMeta.Name, ic.BaseDomain)
 }
@@ -0,0 +1,105 @@
+package asset
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Migration upgrades an asset written by an earlier version of the
+// installer to the form the current version expects.
+type Migration struct {
+	// Description describes the upgrade to the user.
+	Description string
+
+	// Filename is the file in the asset directory upgraded by File.
+	Filename string
+
+	// File upgrades the contents of Filename. It returns nil if the
+	// contents are already current.
+	File func(data []byte) ([]byte, error)
+
+	// Asset is the asset whose entry in the state file is upgraded by
+	// State.
+	Asset Asset
+
+	// State upgrades the entry of Asset in the state file. It returns nil
+	// if the entry is already current.
+	State func(data json.RawMessage) (json.RawMessage, error)
+}
+
+// migrations are the registered migrations, in the order in which they
+// are applied.
+var migrations []Migration
+
+// RegisterMigration registers a migration to be applied by Migrate.
+// Migrations are applied in the order in which they are registered.
+func RegisterMigration(migration Migration) {
+	migrations = append(migrations, migration)
+}
+
+// Migrate applies the registered migrations to the state file and the
+// files in directory, and returns the descriptions of the migrations which
+// changed anything.
+func Migrate(directory string) ([]string, error) {
+	store := &StoreImpl{directory: directory}
+	if err := store.loadStateFile(); err != nil {
+		return nil, err
+	}
+
+	var applied []string
+	stateChanged := false
+	for _, migration := range migrations {
+		changed := false
+
+		if migration.File != nil {
+			path := filepath.Join(directory, migration.Filename)
+			data, err := ioutil.ReadFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return applied, err
+			}
+			if err == nil {
+				upgraded, err := migration.File(data)
+				if err != nil {
+					return applied, errors.Wrapf(err, "failed to upgrade %q", migration.Filename)
+				}
+				if upgraded != nil {
+					if err := ioutil.WriteFile(path, upgraded, 0644); err != nil {
+						return applied, err
+					}
+					changed = true
+				}
+			}
+		}
+
+		if migration.State != nil {
+			key := reflect.TypeOf(migration.Asset).String()
+			if data, ok := store.stateFileAssets[key]; ok {
+				upgraded, err := migration.State(data)
+				if err != nil {
+					return applied, errors.Wrapf(err, "failed to upgrade %q in the state file", migration.Asset.Name())
+				}
+				if upgraded != nil {
+					store.stateFileAssets[key] = upgraded
+					changed = true
+					stateChanged = true
+				}
+			}
+		}
+
+		if changed {
+			applied = append(applied, migration.Description)
+		}
+	}
+
+	if stateChanged {
+		if err := store.saveStateFile(); err != nil {
+			return applied, errors.Wrap(err, "failed to save state")
+		}
+	}
+	return applied, nil
+}
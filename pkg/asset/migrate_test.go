@@ -0,0 +1,74 @@
+package asset
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestMigrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "old.json"), []byte(`{"old":true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "current.json"), []byte(`{"new":true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	state := map[string]json.RawMessage{
+		"*asset.testStoreAssetA": json.RawMessage(`{"old":true}`),
+		"*asset.testStoreAssetB": json.RawMessage(`{"new":true}`),
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, stateFileName), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	upgrade := func(data []byte) ([]byte, error) {
+		if string(data) == `{"old":true}` {
+			return []byte(`{"new":true}`), nil
+		}
+		return nil, nil
+	}
+	upgradeState := func(data json.RawMessage) (json.RawMessage, error) {
+		return upgrade(data)
+	}
+
+	defer func(registered []Migration) { migrations = registered }(migrations)
+	migrations = nil
+	RegisterMigration(Migration{Description: "old file", Filename: "old.json", File: upgrade})
+	RegisterMigration(Migration{Description: "current file", Filename: "current.json", File: upgrade})
+	RegisterMigration(Migration{Description: "missing file", Filename: "missing.json", File: upgrade})
+	RegisterMigration(Migration{Description: "old state", Asset: &testStoreAssetA{}, State: upgradeState})
+	RegisterMigration(Migration{Description: "current state", Asset: &testStoreAssetB{}, State: upgradeState})
+	RegisterMigration(Migration{Description: "missing state", Asset: &testStoreAssetC{}, State: upgradeState})
+
+	applied, err := Migrate(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"old file", "old state"}, applied)
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, "old.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"new":true}`, string(data))
+
+	store := &StoreImpl{directory: dir}
+	assert.NoError(t, store.loadStateFile())
+	for key, entry := range store.stateFileAssets {
+		assert.JSONEq(t, `{"new":true}`, string(entry), key)
+	}
+
+	applied, err = Migrate(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, applied, "migrating again should change nothing")
+}
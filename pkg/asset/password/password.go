@@ -5,10 +5,14 @@ import (
 	"math/big"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/redact"
+	"github.com/openshift/installer/pkg/types"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// KubeadminPassword is the asset for the kubeadmin user password
+// KubeadminPassword is the asset for the kubeadmin user password. It is
+// empty if the install-config disables the kubeadmin user.
 type KubeadminPassword struct {
 	Password     string
 	PasswordHash []byte
@@ -16,18 +20,30 @@ type KubeadminPassword struct {
 
 var _ asset.Asset = (*KubeadminPassword)(nil)
 
-// Dependencies returns no dependencies.
+// Dependencies returns the dependency of the password.
 func (a *KubeadminPassword) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
-// Generate the kubeadmin password
-func (a *KubeadminPassword) Generate(asset.Parents) error {
-	err := a.generateRandomPasswordHash(23)
-	if err != nil {
-		return err
+// Generate the kubeadmin password, unless the install-config disables the
+// kubeadmin user.
+func (a *KubeadminPassword) Generate(parents asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(installConfig)
+
+	if installConfig.Config.Kubeadmin == types.DisabledKubeadminPolicy {
+		a.Password, a.PasswordHash = "", nil
+		return nil
 	}
-	return nil
+	return a.GenerateRandom()
+}
+
+// GenerateRandom generates a random kubeadmin password and its hash, for
+// example to reset the password of a running cluster.
+func (a *KubeadminPassword) GenerateRandom() error {
+	return a.generateRandomPasswordHash(23)
 }
 
 // generateRandomPasswordHash generates a hash of a random ASCII password
@@ -65,6 +81,7 @@ func (a *KubeadminPassword) generateRandomPasswordHash(length int) error {
 	if a.Password == "" {
 		a.Password = string(pw)
 	}
+	redact.AddSecret(a.Password)
 	bytes, err := bcrypt.GenerateFromPassword([]byte(a.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return err
@@ -77,3 +94,8 @@ func (a *KubeadminPassword) generateRandomPasswordHash(length int) error {
 func (a *KubeadminPassword) Name() string {
 	return "Kubeadmin Password"
 }
+
+// Sensitive returns true, because the asset contains the password.
+func (a *KubeadminPassword) Sensitive() bool {
+	return true
+}
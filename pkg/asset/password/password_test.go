@@ -0,0 +1,43 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestKubeadminPasswordGenerate(t *testing.T) {
+	cases := []struct {
+		name     string
+		policy   types.KubeadminPolicy
+		disabled bool
+	}{
+		{name: "default"},
+		{name: "disabled", policy: types.DisabledKubeadminPolicy, disabled: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parents := asset.Parents{}
+			parents.Add(&installconfig.InstallConfig{
+				Config: &types.InstallConfig{Kubeadmin: tc.policy},
+			})
+
+			pw := &KubeadminPassword{}
+			if !assert.NoError(t, pw.Generate(parents)) {
+				return
+			}
+			if tc.disabled {
+				assert.Empty(t, pw.Password)
+				assert.Nil(t, pw.PasswordHash)
+				return
+			}
+			assert.Regexp(t, `^[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}$`, pw.Password)
+			assert.NoError(t, bcrypt.CompareHashAndPassword(pw.PasswordHash, []byte(pw.Password)))
+		})
+	}
+}
@@ -0,0 +1,237 @@
+// Package pxe contains assets for netbooting RHCOS on bare metal.
+package pxe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
+	"github.com/openshift/installer/pkg/asset/ignition/hosts"
+	"github.com/openshift/installer/pkg/asset/ignition/machine"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/rhcos"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/none"
+)
+
+const (
+	pxeDir         = "pxe"
+	scriptFilename = "boot.ipxe"
+)
+
+// Artifacts is an asset that generates the files to serve from a PXE
+// server: an iPXE script which installs RHCOS to the machine's disk, and
+// the Ignition config of each role, which the script points the
+// installation at. For each host in the install-config, it also generates
+// a script which installs the host with its own Ignition config and static
+// network configuration.
+type Artifacts struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Artifacts)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (a *Artifacts) Name() string {
+	return "PXE Artifacts"
+}
+
+// Dependencies returns the assets on which the Artifacts asset depends.
+func (a *Artifacts) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+		&bootstrap.Bootstrap{},
+		&machine.Master{},
+		&machine.Worker{},
+		&hosts.Hosts{},
+	}
+}
+
+// netboot is the artifacts of the installer's RHCOS build which netboot
+// the RHCOS installer: its kernel and initramfs, and the raw disk image
+// it writes.
+type netboot struct {
+	Release   string
+	Kernel    *rhcos.Artifact
+	Initramfs *rhcos.Artifact
+	Metal     *rhcos.Artifact
+}
+
+func loadNetboot() (*netboot, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	stream, err := rhcos.LoadStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	arch, err := stream.Architecture(rhcos.DefaultArchitecture)
+	if err != nil {
+		return nil, err
+	}
+
+	kernel, err := arch.Artifact("metal", "pxe", "kernel")
+	if err != nil {
+		return nil, err
+	}
+	initramfs, err := arch.Artifact("metal", "pxe", "initramfs")
+	if err != nil {
+		return nil, err
+	}
+	metal, err := arch.Artifact("metal", "raw.gz", "disk")
+	if err != nil {
+		return nil, err
+	}
+	n := &netboot{
+		Release:   arch.Artifacts["metal"].Release,
+		Kernel:    kernel,
+		Initramfs: initramfs,
+		Metal:     metal,
+	}
+	return n, nil
+}
+
+// Generate generates the iPXE script and copies the Ignition configs
+// into the PXE directory.
+func (a *Artifacts) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	bootstrapIgn := &bootstrap.Bootstrap{}
+	masterIgn := &machine.Master{}
+	workerIgn := &machine.Worker{}
+	hostsIgn := &hosts.Hosts{}
+	dependencies.Get(installConfig, bootstrapIgn, masterIgn, workerIgn, hostsIgn)
+
+	if platform := installConfig.Config.Platform.Name(); platform != none.Name {
+		return errors.Errorf("PXE artifacts are only generated for the %q platform, not %q", none.Name, platform)
+	}
+
+	artifacts, err := loadNetboot()
+	if err != nil {
+		return err
+	}
+
+	script, err := renderScript(installConfig.Config.ObjectMeta.Name, artifacts)
+	if err != nil {
+		return err
+	}
+
+	a.FileList = []*asset.File{{
+		Filename: filepath.Join(pxeDir, scriptFilename),
+		Data:     script,
+	}}
+	for _, ign := range append([]*asset.File{bootstrapIgn.File, masterIgn.File, workerIgn.File}, hostsIgn.Files()...) {
+		a.FileList = append(a.FileList, &asset.File{
+			Filename: filepath.Join(pxeDir, ign.Filename),
+			Data:     ign.Data,
+		})
+	}
+	for _, host := range installConfig.Config.Hosts {
+		script, err := renderHostScript(installConfig.Config.ObjectMeta.Name, &host)
+		if err != nil {
+			return err
+		}
+		a.FileList = append(a.FileList, &asset.File{
+			Filename: filepath.Join(pxeDir, hostScriptFilename(host.Name)),
+			Data:     script,
+		})
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (a *Artifacts) Files() []*asset.File {
+	return a.FileList
+}
+
+// Load returns false, because the artifacts are always generated from the
+// Ignition configs.
+func (a *Artifacts) Load(f asset.FileFetcher) (found bool, err error) {
+	return false, nil
+}
+
+// scriptTemplate is the iPXE script. The role is asked for unless the
+// role variable is already set, e.g. by a per-host script which chains
+// this one, and the Ignition configs are fetched from base-url, which
+// defaults to the HTTP server on the DHCP next-server. A per-host script
+// can also set the ignition variable to the host's Ignition config, and
+// network-args to its static network configuration.
+var scriptTemplate = template.Must(template.New(scriptFilename).Parse(`#!ipxe
+# Installs RHCOS {{.Netboot.Release}} for the {{.ClusterName}} cluster.
+# Serve the Ignition configs next to this script at ${base-url}.
+
+isset ${base-url} || set base-url http://${next-server}
+
+isset ${role} && goto boot ||
+menu Install RHCOS for the {{.ClusterName}} cluster
+item bootstrap Bootstrap
+item master Master
+item worker Worker
+choose role || goto failed
+
+:boot
+isset ${ignition} || set ignition ${role}.ign
+isset ${network-args} || set network-args ip=dhcp
+kernel {{.Netboot.Kernel.Location}} initrd=initramfs.img ${network-args} rd.neednet=1 console=tty0 console=ttyS0 coreos.inst=yes coreos.inst.install_dev=sda coreos.inst.image_url={{.Netboot.Metal.Location}} coreos.inst.ignition_url=${base-url}/${ignition} || goto failed
+initrd --name initramfs.img {{.Netboot.Initramfs.Location}} || goto failed
+boot || goto failed
+
+:failed
+echo Failed to boot the RHCOS installer
+shell
+`))
+
+func renderScript(clusterName string, artifacts *netboot) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := scriptTemplate.Execute(buf, struct {
+		ClusterName string
+		Netboot     *netboot
+	}{
+		ClusterName: clusterName,
+		Netboot:     artifacts,
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to render the iPXE script")
+	}
+	return buf.Bytes(), nil
+}
+
+// hostScriptTemplate is the iPXE script of a host, which chains the main
+// script with the host's role, Ignition config and network configuration.
+var hostScriptTemplate = template.Must(template.New("host.ipxe").Parse(`#!ipxe
+# Installs RHCOS on {{.Host.Name}}, a {{.Host.Role}} of the {{.ClusterName}} cluster.
+
+set role {{.Host.Role}}
+set ignition {{.Ignition}}
+{{if .NetworkArgs}}set network-args {{.NetworkArgs}}
+{{end}}chain ../{{.Script}}
+`))
+
+func hostScriptFilename(host string) string {
+	return filepath.Join(filepath.Dir(hosts.Filename(host)), fmt.Sprintf("%s.ipxe", host))
+}
+
+func renderHostScript(clusterName string, host *types.Host) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := hostScriptTemplate.Execute(buf, struct {
+		ClusterName string
+		Host        *types.Host
+		Ignition    string
+		NetworkArgs string
+		Script      string
+	}{
+		ClusterName: clusterName,
+		Host:        host,
+		Ignition:    hosts.Filename(host.Name),
+		NetworkArgs: hosts.KernelArguments(host),
+		Script:      scriptFilename,
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to render the iPXE script of host %s", host.Name)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,55 @@
+package pxe
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/rhcos"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestRenderScript(t *testing.T) {
+	script, err := renderScript("test-cluster", &netboot{
+		Release:   "47.1",
+		Kernel:    &rhcos.Artifact{Location: "https://example.com/rhcos-kernel"},
+		Initramfs: &rhcos.Artifact{Location: "https://example.com/rhcos-initramfs.img"},
+		Metal:     &rhcos.Artifact{Location: "https://example.com/rhcos-metal.raw.gz"},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, string(script), "# Installs RHCOS 47.1 for the test-cluster cluster.")
+	assert.Contains(t, string(script), "kernel https://example.com/rhcos-kernel initrd=initramfs.img ")
+	assert.Contains(t, string(script), " coreos.inst.image_url=https://example.com/rhcos-metal.raw.gz coreos.inst.ignition_url=${base-url}/${ignition} ")
+	assert.Contains(t, string(script), "initrd --name initramfs.img https://example.com/rhcos-initramfs.img ")
+}
+
+func TestRenderHostScript(t *testing.T) {
+	script, err := renderHostScript("test-cluster", &types.Host{
+		Name: "master-0",
+		Role: "master",
+		NetworkConfig: &types.NetworkConfig{
+			Interfaces: []types.NetworkInterface{{
+				Name: "eno1",
+				NetworkAddresses: types.NetworkAddresses{
+					Addresses: []ipnet.IPNet{{IPNet: net.IPNet{IP: net.IPv4(10, 0, 0, 10).To4(), Mask: net.CIDRMask(24, 32)}}},
+					Gateway:   net.IPv4(10, 0, 0, 1),
+				},
+			}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `#!ipxe
+# Installs RHCOS on master-0, a master of the test-cluster cluster.
+
+set role master
+set ignition hosts/master-0.ign
+set network-args ip=10.0.0.10::10.0.0.1:255.255.255.0:master-0:eno1:none
+chain ../boot.ipxe
+`, string(script))
+
+	script, err = renderHostScript("test-cluster", &types.Host{Name: "worker-0", Role: "worker"})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(script), "network-args")
+}
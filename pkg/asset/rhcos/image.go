@@ -3,6 +3,7 @@ package rhcos
 
 import (
 	"context"
+	"net/url"
 	"os"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/rhcos"
+	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	"github.com/openshift/installer/pkg/types/none"
@@ -21,6 +23,8 @@ import (
 // Image is location of RHCOS image.
 // This stores the location of the image based on the platform.
 // eg. on AWS this contains ami-id, on Livirt this can be the URI for QEMU image etc.
+// On AWS, an AMI of another region is copied into the cluster's region, see
+// rhcos.SplitAMI.
 type Image string
 
 var _ asset.Asset = (*Image)(nil)
@@ -37,10 +41,13 @@ func (i *Image) Dependencies() []asset.Asset {
 	}
 }
 
-// Generate the RHCOS image location.
+// Generate the RHCOS image location. The OPENSHIFT_INSTALL_OS_IMAGE_OVERRIDE
+// environment variable overrides the osImage of the platform in the
+// install-config, which overrides the image of the installer's RHCOS
+// build. The image is recorded in the cluster's metadata.
 func (i *Image) Generate(p asset.Parents) error {
 	if oi, ok := os.LookupEnv("OPENSHIFT_INSTALL_OS_IMAGE_OVERRIDE"); ok && oi != "" {
-		logrus.Warn("Found override for OS Image. Please be warned, this is not advised")
+		logrus.Warnf("Using the OS image %s of OPENSHIFT_INSTALL_OS_IMAGE_OVERRIDE instead of the installer's RHCOS build", oi)
 		*i = Image(oi)
 		return nil
 	}
@@ -49,15 +56,19 @@ func (i *Image) Generate(p asset.Parents) error {
 	p.Get(ic)
 	config := ic.Config
 
+	if oi := osImage(config); oi != "" {
+		logrus.Infof("Using the OS image %s of the install-config instead of the installer's RHCOS build", oi)
+		*i = Image(oi)
+		return nil
+	}
+
 	var osimage string
 	var err error
-	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
-	defer cancel()
 	switch config.Platform.Name() {
 	case aws.Name:
-		osimage, err = rhcos.AMI(ctx, rhcos.DefaultChannel, config.Platform.AWS.Region)
+		osimage, err = ami(config.Platform.AWS.Region)
 	case libvirt.Name:
-		osimage, err = rhcos.QEMU(ctx, rhcos.DefaultChannel)
+		osimage, err = qemu()
 	case openstack.Name:
 		osimage = "rhcos"
 	case none.Name:
@@ -70,3 +81,76 @@ func (i *Image) Generate(p asset.Parents) error {
 	*i = Image(osimage)
 	return nil
 }
+
+// osImage returns the osImage of the platform of the install-config, if it
+// has one.
+func osImage(config *types.InstallConfig) string {
+	switch {
+	case config.Platform.AWS != nil:
+		return config.Platform.AWS.OSImage
+	case config.Platform.Libvirt != nil:
+		return config.Platform.Libvirt.OSImage
+	case config.Platform.OpenStack != nil:
+		return config.Platform.OpenStack.OSImage
+	}
+	return ""
+}
+
+// ami returns the AMI of the installer's RHCOS build in the region. If the
+// build has no AMI there, it returns the AMI of rhcos.AMICopySourceRegion,
+// which Terraform copies into the region.
+func ami(region string) (string, error) {
+	arch, err := defaultArchitecture()
+	if err != nil {
+		return "", err
+	}
+	id, err := arch.AMI(region)
+	if err == nil {
+		return id, nil
+	}
+	id, sourceErr := arch.AMI(rhcos.AMICopySourceRegion)
+	if sourceErr != nil {
+		return "", err
+	}
+	logrus.Infof("RHCOS has no AMI in %s, so its AMI %s in %s will be copied into it", region, id, rhcos.AMICopySourceRegion)
+	return rhcos.CopiedAMI(id, rhcos.AMICopySourceRegion), nil
+}
+
+// qemu returns the URL of the QEMU image of the installer's RHCOS build,
+// with its checksum and signature, which are verified when the image is
+// fetched.
+func qemu() (string, error) {
+	arch, err := defaultArchitecture()
+	if err != nil {
+		return "", err
+	}
+	artifact, err := arch.Artifact("qemu", "qcow2", "disk")
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(artifact.Location)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("sha256", artifact.SHA256)
+	if artifact.Signature != "" {
+		query.Set("signature", artifact.Signature)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// defaultArchitecture returns the artifacts and images of the installer's
+// RHCOS build for the architecture of the machines.
+func defaultArchitecture() (*rhcos.Arch, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	stream, err := rhcos.LoadStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return stream.Architecture(rhcos.DefaultArchitecture)
+}
@@ -0,0 +1,171 @@
+package asset
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Snapshot records the files in an asset directory, so that the changes an
+// invocation makes to them can be rolled back if it fails.
+type Snapshot struct {
+	directory string
+	ignored   map[string]bool
+	files     map[string]snapshotFile
+}
+
+type snapshotFile struct {
+	data []byte
+	mode os.FileMode
+}
+
+// NewSnapshot records the files in the directory, except for the ignored
+// ones, which are given relative to the directory.
+func NewSnapshot(directory string, ignored ...string) (*Snapshot, error) {
+	s := &Snapshot{
+		directory: directory,
+		ignored:   map[string]bool{},
+		files:     map[string]snapshotFile{},
+	}
+	for _, filename := range ignored {
+		s.ignored[filepath.Clean(filename)] = true
+	}
+
+	err := s.walk(func(filename string, path string, info os.FileInfo) error {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		s.files[filename] = snapshotFile{data: data, mode: info.Mode()}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to snapshot the asset directory")
+	}
+	return s, nil
+}
+
+// RunWithRollback snapshots the directory, except for the ignored files,
+// and runs fn. If fn fails, the files it wrote to the directory are rolled
+// back, unless one of the guarded files changed, such as the Terraform
+// state, whose loss would leak the resources it records. It returns the
+// error of fn.
+func RunWithRollback(directory string, ignored []string, guarded []string, fn func() error) error {
+	snapshot, err := NewSnapshot(directory, ignored...)
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	if err == nil {
+		return nil
+	}
+
+	for _, filename := range guarded {
+		changed, err2 := snapshot.Changed(filename)
+		if err2 != nil || changed {
+			logrus.Warnf("Not rolling back the asset directory, because %s was written and the cluster resources may have been created", filename)
+			return err
+		}
+	}
+	logrus.Info("Rolling back the files written to the asset directory")
+	if err2 := snapshot.Rollback(); err2 != nil {
+		logrus.Error(errors.Wrap(err2, "failed to roll back the asset directory"))
+	}
+	return err
+}
+
+// Changed returns whether the file, given relative to the directory, was
+// created, modified or removed since the snapshot.
+func (s *Snapshot) Changed(filename string) (bool, error) {
+	filename = filepath.Clean(filename)
+	data, err := ioutil.ReadFile(filepath.Join(s.directory, filename))
+	recorded, ok := s.files[filename]
+	if os.IsNotExist(err) {
+		return ok, nil
+	} else if err != nil {
+		return false, err
+	}
+	return !ok || !bytes.Equal(data, recorded.data), nil
+}
+
+// Rollback removes the files created since the snapshot, and restores the
+// files modified or removed since then.
+func (s *Snapshot) Rollback() error {
+	var created []string
+	err := s.walk(func(filename string, path string, info os.FileInfo) error {
+		if _, ok := s.files[filename]; !ok {
+			created = append(created, filename)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to read the asset directory")
+	}
+
+	for _, filename := range created {
+		logrus.Debugf("Rolling back: removing %s", filename)
+		path := filepath.Join(s.directory, filename)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to remove file")
+		}
+		for dir := filepath.Dir(path); dir != filepath.Clean(s.directory) && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+			if ok, err := isDirEmpty(dir); err != nil || !ok {
+				break
+			}
+			if err := os.Remove(dir); err != nil {
+				return errors.Wrap(err, "failed to remove directory")
+			}
+		}
+	}
+
+	for filename, f := range s.files {
+		changed, err := s.Changed(filename)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", filename)
+		}
+		if !changed {
+			continue
+		}
+		logrus.Debugf("Rolling back: restoring %s", filename)
+		path := filepath.Join(s.directory, filename)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return errors.Wrap(err, "failed to create dir")
+		}
+		if err := ioutil.WriteFile(path, f.data, f.mode); err != nil {
+			return errors.Wrap(err, "failed to write file")
+		}
+		if err := os.Chmod(path, f.mode); err != nil {
+			return errors.Wrap(err, "failed to set file mode")
+		}
+	}
+	return nil
+}
+
+// walk calls fn for the regular files in the directory, except for the
+// ignored ones.
+func (s *Snapshot) walk(fn func(filename string, path string, info os.FileInfo) error) error {
+	return filepath.Walk(s.directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == s.directory {
+				return nil
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		filename, err := filepath.Rel(s.directory, path)
+		if err != nil {
+			return err
+		}
+		if s.ignored[filename] {
+			return nil
+		}
+		return fn(filename, path, info)
+	})
+}
@@ -0,0 +1,143 @@
+package asset
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestSnapshotRollback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(filename string, data string) {
+		path := filepath.Join(dir, filename)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("install-config.yaml", "original")
+	write("modified", "original")
+	write("ignored", "original")
+
+	snapshot, err := NewSnapshot(dir, "ignored")
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.Remove(filepath.Join(dir, "install-config.yaml")))
+	write("modified", "modified")
+	write("ignored", "modified")
+	write("auth/kubeconfig", "created")
+
+	for filename, expected := range map[string]bool{
+		"install-config.yaml": true,
+		"modified":            true,
+		"ignored":             true,
+		"auth/kubeconfig":     true,
+		"missing":             false,
+	} {
+		changed, err := snapshot.Changed(filename)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, changed, filename)
+	}
+
+	assert.NoError(t, snapshot.Rollback())
+
+	for filename, expected := range map[string]string{
+		"install-config.yaml": "original",
+		"modified":            "original",
+		"ignored":             "modified",
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, filename))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(data), filename)
+	}
+	info, err := os.Stat(filepath.Join(dir, "install-config.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	_, err = os.Stat(filepath.Join(dir, "auth"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+type rollbackTestWritten struct{}
+
+func (a *rollbackTestWritten) Name() string                   { return "Written" }
+func (a *rollbackTestWritten) Dependencies() []Asset          { return nil }
+func (a *rollbackTestWritten) Generate(Parents) error         { return nil }
+func (a *rollbackTestWritten) Load(FileFetcher) (bool, error) { return false, nil }
+func (a *rollbackTestWritten) Files() []*File {
+	return []*File{{Filename: "written", Data: []byte("written")}}
+}
+
+type rollbackTestFailed struct{}
+
+func (a *rollbackTestFailed) Name() string                   { return "Failed" }
+func (a *rollbackTestFailed) Dependencies() []Asset          { return nil }
+func (a *rollbackTestFailed) Generate(Parents) error         { return errors.New("generate failed") }
+func (a *rollbackTestFailed) Load(FileFetcher) (bool, error) { return false, nil }
+func (a *rollbackTestFailed) Files() []*File                 { return nil }
+
+func TestRunWithRollback(t *testing.T) {
+	cases := []struct {
+		name       string
+		guarded    string
+		rolledBack bool
+	}{
+		{
+			name:       "rolled back",
+			guarded:    "metadata.json",
+			rolledBack: true,
+		},
+		{
+			name:    "guarded file written",
+			guarded: "written",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "TestRunWithRollback")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			if err := ioutil.WriteFile(filepath.Join(dir, "install-config.yaml"), []byte("original"), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			err = RunWithRollback(dir, nil, []string{tc.guarded}, func() error {
+				store, err := NewStore(dir)
+				if err != nil {
+					return err
+				}
+				for _, a := range []WritableAsset{&rollbackTestWritten{}, &rollbackTestFailed{}} {
+					if err := store.Fetch(a); err != nil {
+						return err
+					}
+					if err := PersistToFile(a, dir); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "generate failed")
+			}
+
+			data, err := ioutil.ReadFile(filepath.Join(dir, "install-config.yaml"))
+			assert.NoError(t, err)
+			assert.Equal(t, "original", string(data))
+			_, err = os.Stat(filepath.Join(dir, "written"))
+			assert.Equal(t, tc.rolledBack, os.IsNotExist(err))
+		})
+	}
+}
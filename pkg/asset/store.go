@@ -1,11 +1,16 @@
 package asset
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -15,12 +20,23 @@ const (
 	stateFileName = ".openshift_install_state.json"
 )
 
+// KeepConsumed is whether Fetch keeps the on-disk files of the assets
+// consumed by the fetched asset. By default they are removed once they are
+// recorded in the state file, so that later invocations do not read stale
+// inputs.
+var KeepConsumed = false
+
 // Store is a store for the states of assets.
 type Store interface {
 	// Fetch retrieves the state of the given asset, generating it and its
 	// dependencies if necessary.
 	Fetch(Asset) error
 
+	// Load retrieves the given asset if it is present in the store. It
+	// does not generate the asset or any of its dependencies, and returns
+	// a nil Asset if the asset has not been stored.
+	Load(Asset) (Asset, error)
+
 	// Destroy removes the asset from all its internal state and also from
 	// disk if possible.
 	Destroy(Asset) error
@@ -55,6 +71,12 @@ type assetState struct {
 	// presentOnDisk is true if the asset in on-disk. This is set whether the
 	// asset is sourced from on-disk or not. It is used in purging consumed assets.
 	presentOnDisk bool
+	// expired is true if the asset in the state file expired, so that it and
+	// its children are regenerated.
+	expired bool
+	// generating is held while the asset is generated, so that children
+	// fetching it in parallel wait for it to be generated once.
+	generating sync.Mutex
 }
 
 // StoreImpl is the implementation of Store.
@@ -63,15 +85,37 @@ type StoreImpl struct {
 	assets          map[reflect.Type]*assetState
 	stateFileAssets map[string]json.RawMessage
 	fileFetcher     FileFetcher
+
+	// parallelism is the number of assets which may be generated at once.
+	// The dependencies of an asset are fetched one after the other unless
+	// it is greater than one.
+	parallelism int
+	// generators bounds the assets being generated to parallelism. It is
+	// nil if the dependencies are fetched one after the other.
+	generators chan struct{}
+	// prompting is held for writing while an asset which may prompt is
+	// generated, and for reading while any other asset is.
+	prompting sync.RWMutex
+	// assetsLock protects assets while dependencies are fetched in
+	// parallel.
+	assetsLock sync.Mutex
+	// inMemory is true if the store has no asset directory, and so keeps
+	// its state only in memory and removes no files.
+	inMemory bool
+	// timings records how long the assets took to generate.
+	timings generationTimings
 }
 
 // NewStore returns an asset store that implements the Store interface.
+// It generates independent assets in parallel, up to one per CPU.
 func NewStore(dir string) (Store, error) {
 	store := &StoreImpl{
 		directory:   dir,
 		fileFetcher: &fileFetcher{directory: dir},
 		assets:      map[reflect.Type]*assetState{},
+		parallelism: runtime.NumCPU(),
 	}
+	store.generators = make(chan struct{}, store.parallelism)
 
 	if err := store.loadStateFile(); err != nil {
 		return nil, err
@@ -79,10 +123,29 @@ func NewStore(dir string) (Store, error) {
 	return store, nil
 }
 
+// NewStoreWithFileFetcher returns an asset store which loads the assets
+// supplied by the user, such as the install-config, with the given
+// FileFetcher instead of from an asset directory. The store keeps its state
+// in memory and writes nothing to disk, so programs embedding the installer
+// can fetch assets and use their Files without an asset directory.
+func NewStoreWithFileFetcher(fetcher FileFetcher) Store {
+	store := &StoreImpl{
+		fileFetcher:     fetcher,
+		assets:          map[reflect.Type]*assetState{},
+		stateFileAssets: map[string]json.RawMessage{},
+		parallelism:     runtime.NumCPU(),
+		inMemory:        true,
+	}
+	store.generators = make(chan struct{}, store.parallelism)
+	return store
+}
+
 // Fetch retrieves the state of the given asset, generating it and its
 // dependencies if necessary.
 func (s *StoreImpl) Fetch(asset Asset) error {
-	if err := s.fetch(asset, ""); err != nil {
+	err := s.fetch(asset, "")
+	s.timings.flush()
+	if err != nil {
 		return err
 	}
 	if err := s.saveStateFile(); err != nil {
@@ -94,6 +157,22 @@ func (s *StoreImpl) Fetch(asset Asset) error {
 	return nil
 }
 
+// Load retrieves the given asset if it is present in the store.
+func (s *StoreImpl) Load(asset Asset) (Asset, error) {
+	if sa, ok := s.assets[reflect.TypeOf(asset)]; ok && sa.source != unfetched {
+		return sa.asset, nil
+	}
+	if !s.isAssetInState(asset) {
+		return nil, nil
+	}
+
+	loaded := reflect.New(reflect.TypeOf(asset).Elem()).Interface().(Asset)
+	if err := s.loadAssetFromState(loaded); err != nil {
+		return nil, errors.Wrapf(err, "failed to load asset %q from state file", asset.Name())
+	}
+	return loaded, nil
+}
+
 // Destroy removes the asset from all its internal state and also from
 // disk if possible.
 func (s *StoreImpl) Destroy(asset Asset) error {
@@ -108,7 +187,7 @@ func (s *StoreImpl) Destroy(asset Asset) error {
 		return nil
 	}
 
-	if wa, ok := asset.(WritableAsset); ok {
+	if wa, ok := asset.(WritableAsset); ok && !s.inMemory {
 		if err := deleteAssetFromDisk(wa, s.directory); err != nil {
 			return err
 		}
@@ -122,6 +201,9 @@ func (s *StoreImpl) Destroy(asset Asset) error {
 // DestroyState removes the state file from disk
 func (s *StoreImpl) DestroyState() error {
 	s.stateFileAssets = nil
+	if s.inMemory {
+		return nil
+	}
 	path := filepath.Join(s.directory, stateFileName)
 	err := os.Remove(path)
 	if err != nil {
@@ -145,6 +227,10 @@ func (s *StoreImpl) loadStateFile() error {
 		}
 		return err
 	}
+	data, _, err = decryptState(data, statePassphrase())
+	if err != nil {
+		return errors.Wrapf(err, "failed to load state file %q", path)
+	}
 	err = json.Unmarshal(data, &assets)
 	if err != nil {
 		return errors.Wrapf(err, "failed to unmarshal state file %q", path)
@@ -162,6 +248,33 @@ func (s *StoreImpl) loadAssetFromState(asset Asset) error {
 	return json.Unmarshal(bytes, asset)
 }
 
+// changedFiles returns the names of the fetched files which differ from the
+// files of the asset in the state file, which are the ones the installer
+// wrote. Files which the asset in the state file does not have are not
+// considered changed.
+func (s *StoreImpl) changedFiles(asset WritableAsset, fetched []*File) ([]string, error) {
+	if len(fetched) == 0 || !s.isAssetInState(asset) {
+		return nil, nil
+	}
+
+	stateFileAsset := reflect.New(reflect.TypeOf(asset).Elem()).Interface().(WritableAsset)
+	if err := s.loadAssetFromState(stateFileAsset); err != nil {
+		return nil, err
+	}
+	written := map[string][]byte{}
+	for _, f := range stateFileAsset.Files() {
+		written[f.Filename] = f.Data
+	}
+
+	var changed []string
+	for _, f := range fetched {
+		if data, ok := written[f.Filename]; ok && !bytes.Equal(data, f.Data) {
+			changed = append(changed, f.Filename)
+		}
+	}
+	return changed, nil
+}
+
 // isAssetInState tests whether the asset is in the state file.
 func (s *StoreImpl) isAssetInState(asset Asset) bool {
 	_, ok := s.stateFileAssets[reflect.TypeOf(asset).String()]
@@ -183,19 +296,44 @@ func (s *StoreImpl) saveStateFile() error {
 		}
 		s.stateFileAssets[k.String()] = json.RawMessage(data)
 	}
+	if s.inMemory {
+		return nil
+	}
 	data, err := json.MarshalIndent(s.stateFileAssets, "", "    ")
 	if err != nil {
 		return err
 	}
+	if passphrase := statePassphrase(); passphrase != "" {
+		data, err = encryptState(data, passphrase)
+		if err != nil {
+			return errors.Wrap(err, "failed to encrypt state")
+		}
+	}
 
 	path := filepath.Join(s.directory, stateFileName)
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return err
 	}
-	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+
+	// Write the state to a temporary file and rename it over the state
+	// file, so that an interrupted invocation cannot leave a truncated
+	// state file behind and lose the assets generated by earlier ones.
+	tmp, err := ioutil.TempFile(filepath.Dir(path), stateFileName)
+	if err != nil {
 		return err
 	}
-	return nil
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
 }
 
 // fetch populates the given asset, generating it and its dependencies if
@@ -204,13 +342,19 @@ func (s *StoreImpl) saveStateFile() error {
 func (s *StoreImpl) fetch(asset Asset, indent string) error {
 	logrus.Debugf("%sFetching %q...", indent, asset.Name())
 
+	s.assetsLock.Lock()
 	assetState, ok := s.assets[reflect.TypeOf(asset)]
 	if !ok {
 		if _, err := s.load(asset, ""); err != nil {
+			s.assetsLock.Unlock()
 			return err
 		}
 		assetState = s.assets[reflect.TypeOf(asset)]
 	}
+	s.assetsLock.Unlock()
+
+	assetState.generating.Lock()
+	defer assetState.generating.Unlock()
 
 	// Return immediately if the asset has been fetched before,
 	// this is because we are doing a depth-first-search, it's guaranteed
@@ -222,17 +366,28 @@ func (s *StoreImpl) fetch(asset Asset, indent string) error {
 		return nil
 	}
 
-	// Re-generate the asset
+	// Re-generate the asset. Keep fetching the dependencies after one of
+	// them fails for lack of user input so that all the missing inputs are
+	// reported together.
 	dependencies := asset.Dependencies()
+	errs := s.fetchDependencies(dependencies, increaseIndent(indent))
 	parents := make(Parents, len(dependencies))
-	for _, d := range dependencies {
-		if err := s.fetch(d, increaseIndent(indent)); err != nil {
+	missing := &MissingInputsError{}
+	for i, d := range dependencies {
+		if err := errs[i]; err != nil {
+			if missingErr, ok := errors.Cause(err).(*MissingInputsError); ok {
+				missing.add(missingErr)
+				continue
+			}
 			return errors.Wrapf(err, "failed to fetch dependency of %q", asset.Name())
 		}
 		parents.Add(d)
 	}
+	if len(missing.Inputs) > 0 {
+		return missing
+	}
 	logrus.Debugf("%sGenerating %q...", indent, asset.Name())
-	if err := asset.Generate(parents); err != nil {
+	if err := s.generate(asset, parents); err != nil {
 		return errors.Wrapf(err, "failed to generate asset %q", asset.Name())
 	}
 	assetState.asset = asset
@@ -240,6 +395,70 @@ func (s *StoreImpl) fetch(asset Asset, indent string) error {
 	return nil
 }
 
+// fetchDependencies fetches the dependencies of an asset and returns the
+// error of each. Unless the store generates assets in parallel, the
+// dependencies are fetched in order, stopping at the first error which is
+// not for lack of user input. Otherwise only the dependencies which may
+// prompt are fetched in order.
+func (s *StoreImpl) fetchDependencies(dependencies []Asset, indent string) []error {
+	errs := make([]error, len(dependencies))
+	if s.parallelism <= 1 {
+		for i, d := range dependencies {
+			errs[i] = s.fetch(d, indent)
+			if _, ok := errors.Cause(errs[i]).(*MissingInputsError); errs[i] != nil && !ok {
+				break
+			}
+		}
+		return errs
+	}
+
+	// The dependencies which may prompt are fetched first, one at a time and
+	// in order, so that the user is asked the same questions in the same
+	// order on every run.
+	prompts := make([]bool, len(dependencies))
+	for i, d := range dependencies {
+		if prompter, ok := d.(Prompter); ok && prompter.Prompts() {
+			prompts[i] = true
+			errs[i] = s.fetch(d, indent)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, d := range dependencies {
+		if prompts[i] {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, d Asset) {
+			defer wg.Done()
+			errs[i] = s.fetch(d, indent)
+		}(i, d)
+	}
+	wg.Wait()
+	return errs
+}
+
+// generate generates the asset, waiting for one of the store's generators
+// to be free. Assets which may prompt the user are generated on their own.
+func (s *StoreImpl) generate(asset Asset, parents Parents) error {
+	if s.generators != nil {
+		s.generators <- struct{}{}
+		defer func() { <-s.generators }()
+	}
+
+	if prompter, ok := asset.(Prompter); ok && prompter.Prompts() {
+		s.prompting.Lock()
+		defer s.prompting.Unlock()
+	} else {
+		s.prompting.RLock()
+		defer s.prompting.RUnlock()
+	}
+
+	start := time.Now()
+	defer func() { s.timings.record(asset.Name(), time.Since(start)) }()
+	return asset.Generate(parents)
+}
+
 // load loads the asset and all of its ancestors from on-disk and the state file.
 func (s *StoreImpl) load(asset Asset, indent string) (*assetState, error) {
 	logrus.Debugf("%sLoading %q...", indent, asset.Name())
@@ -256,7 +475,7 @@ func (s *StoreImpl) load(asset Asset, indent string) (*assetState, error) {
 		if err != nil {
 			return nil, err
 		}
-		if state.anyParentsDirty || state.source == onDiskSource {
+		if state.anyParentsDirty || state.source == onDiskSource || state.expired {
 			anyParentsDirty = true
 		}
 	}
@@ -268,11 +487,27 @@ func (s *StoreImpl) load(asset Asset, indent string) (*assetState, error) {
 	)
 	if _, isWritable := asset.(WritableAsset); isWritable {
 		onDiskAsset = reflect.New(reflect.TypeOf(asset).Elem()).Interface().(WritableAsset)
+		fetcher := &recordingFileFetcher{FileFetcher: s.fileFetcher}
 		var err error
-		foundOnDisk, err = onDiskAsset.Load(s.fileFetcher)
+		foundOnDisk, err = onDiskAsset.Load(fetcher)
+		changed, changedErr := s.changedFiles(onDiskAsset, fetcher.fetched)
+		if changedErr != nil {
+			logrus.Debugf("%sCould not compare the on-disk %q with the state file: %v", indent, asset.Name(), changedErr)
+		}
 		if err != nil {
+			// A file which fails to load having been edited since the
+			// installer wrote it is more likely corrupted than one which
+			// was never written by the installer.
+			if len(changed) > 0 {
+				return nil, errors.Wrapf(err, "failed to load asset %q: %s changed since the installer wrote it and may be corrupted; restore it, or remove it to regenerate it", asset.Name(), strings.Join(changed, ", "))
+			}
 			return nil, errors.Wrapf(err, "failed to load asset %q", asset.Name())
 		}
+		if foundOnDisk {
+			for _, filename := range changed {
+				logrus.Warnf("%s was edited since the installer wrote it", filename)
+			}
+		}
 	}
 
 	// Try to load from state file.
@@ -307,6 +542,7 @@ func (s *StoreImpl) load(asset Asset, indent string) (*assetState, error) {
 	var (
 		assetToStore Asset
 		source       assetSource
+		expired      bool
 	)
 	switch {
 	// A parent is dirty. The asset must be re-generated.
@@ -318,9 +554,17 @@ func (s *StoreImpl) load(asset Asset, indent string) (*assetState, error) {
 	// The asset is on disk and that differs from what is in the source file.
 	// The asset is sourced from on disk.
 	case foundOnDisk && !onDiskMatchesStateFile:
+		if foundInStateFile {
+			logrus.Infof("Using the %q modified in the target directory and regenerating the assets which depend on it", asset.Name())
+		}
 		logrus.Debugf("%sUsing %q loaded from target directory", indent, asset.Name())
 		assetToStore = onDiskAsset
 		source = onDiskSource
+	// The asset in the state file expired. The asset must be re-generated.
+	case foundInStateFile && isExpired(stateFileAsset):
+		logrus.Infof("Regenerating the %q, because it has expired or is about to, and the assets which depend on it", asset.Name())
+		source = unfetched
+		expired = true
 	// The asset is in the state file. The asset is sourced from state file.
 	case foundInStateFile:
 		logrus.Debugf("%sUsing %q loaded from state file", indent, asset.Name())
@@ -336,15 +580,24 @@ func (s *StoreImpl) load(asset Asset, indent string) (*assetState, error) {
 		source:          source,
 		anyParentsDirty: anyParentsDirty,
 		presentOnDisk:   foundOnDisk,
+		expired:         expired,
 	}
 	s.assets[reflect.TypeOf(asset)] = state
 	return state, nil
 }
 
+func isExpired(a Asset) bool {
+	expirer, ok := a.(Expirer)
+	return ok && expirer.Expired()
+}
+
 // purge deletes the on-disk assets that are consumed already.
 // E.g., install-config.yaml will be deleted after fetching 'manifests'.
 // The target asset is excluded.
 func (s *StoreImpl) purge(excluded WritableAsset) error {
+	if KeepConsumed || s.inMemory {
+		return nil
+	}
 	for _, assetState := range s.assets {
 		if !assetState.presentOnDisk {
 			continue
@@ -1,11 +1,15 @@
 package asset
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
+	"sync"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,15 +17,18 @@ var (
 	// It is unfortunate that these need to be global variables. However, the
 	// asset store creates new assets by type, so the tests cannot store behavior
 	// state in the assets themselves.
-	generationLog []string
-	dependencies  map[reflect.Type][]Asset
-	onDiskAssets  map[reflect.Type]bool
+	generationLog     []string
+	generationLogLock sync.Mutex
+	dependencies      map[reflect.Type][]Asset
+	onDiskAssets      map[reflect.Type]bool
+	inputAssets       map[reflect.Type]bool
 )
 
 func clearAssetBehaviors() {
 	generationLog = []string{}
 	dependencies = map[reflect.Type][]Asset{}
 	onDiskAssets = map[reflect.Type]bool{}
+	inputAssets = map[reflect.Type]bool{}
 }
 
 func dependenciesTestStoreAsset(a Asset) []Asset {
@@ -29,6 +36,11 @@ func dependenciesTestStoreAsset(a Asset) []Asset {
 }
 
 func generateTestStoreAsset(a Asset) error {
+	if inputAssets[reflect.TypeOf(a)] {
+		return NewMissingInputError(a)
+	}
+	generationLogLock.Lock()
+	defer generationLogLock.Unlock()
 	generationLog = append(generationLog, a.Name())
 	return nil
 }
@@ -129,6 +141,44 @@ func (a *testStoreAssetD) Load(FileFetcher) (bool, error) {
 	return loadTestStoreAsset(a)
 }
 
+// testStorePromptingAssetE and testStorePromptingAssetF may prompt the user
+// when they are generated.
+type testStorePromptingAssetE struct{ testStoreAssetD }
+
+func (a *testStorePromptingAssetE) Name() string {
+	return "e"
+}
+
+func (a *testStorePromptingAssetE) Dependencies() []Asset {
+	return dependenciesTestStoreAsset(a)
+}
+
+func (a *testStorePromptingAssetE) Generate(Parents) error {
+	return generateTestStoreAsset(a)
+}
+
+func (a *testStorePromptingAssetE) Prompts() bool {
+	return true
+}
+
+type testStorePromptingAssetF struct{ testStoreAssetD }
+
+func (a *testStorePromptingAssetF) Name() string {
+	return "f"
+}
+
+func (a *testStorePromptingAssetF) Dependencies() []Asset {
+	return dependenciesTestStoreAsset(a)
+}
+
+func (a *testStorePromptingAssetF) Generate(Parents) error {
+	return generateTestStoreAsset(a)
+}
+
+func (a *testStorePromptingAssetF) Prompts() bool {
+	return true
+}
+
 func newTestStoreAsset(name string) Asset {
 	switch name {
 	case "a":
@@ -291,6 +341,64 @@ func TestStoreFetch(t *testing.T) {
 	}
 }
 
+// TestStoreFetchParallel tests that a store which generates assets in
+// parallel generates each asset once, after its dependencies.
+func TestStoreFetchParallel(t *testing.T) {
+	clearAssetBehaviors()
+	a, b, c, d := &testStoreAssetA{}, &testStoreAssetB{}, &testStoreAssetC{}, &testStoreAssetD{}
+	dependencies[reflect.TypeOf(a)] = []Asset{b, c, d}
+	dependencies[reflect.TypeOf(b)] = []Asset{d}
+	dependencies[reflect.TypeOf(c)] = []Asset{d}
+
+	dir, err := ioutil.TempDir("", "TestStoreFetchParallel")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	store := &StoreImpl{
+		directory:   dir,
+		assets:      map[reflect.Type]*assetState{},
+		parallelism: 2,
+		generators:  make(chan struct{}, 2),
+	}
+
+	err = store.Fetch(a)
+	assert.NoError(t, err, "error fetching asset")
+	assert.Len(t, generationLog, 4)
+	assert.Equal(t, "d", generationLog[0])
+	assert.ElementsMatch(t, []string{"b", "c"}, generationLog[1:3])
+	assert.Equal(t, "a", generationLog[3])
+}
+
+// TestStoreFetchParallelPrompts tests that a store which generates assets in
+// parallel generates the assets which may prompt in the order in which they
+// are declared.
+func TestStoreFetchParallelPrompts(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		clearAssetBehaviors()
+		a, b, c, e, f := &testStoreAssetA{}, &testStoreAssetB{}, &testStoreAssetC{}, &testStorePromptingAssetE{}, &testStorePromptingAssetF{}
+		dependencies[reflect.TypeOf(a)] = []Asset{b, f, c, e}
+
+		dir, err := ioutil.TempDir("", "TestStoreFetchParallelPrompts")
+		if err != nil {
+			t.Fatalf("failed to create temporary directory: %v", err)
+		}
+		defer os.RemoveAll(dir)
+		store := &StoreImpl{
+			directory:   dir,
+			assets:      map[reflect.Type]*assetState{},
+			parallelism: 4,
+			generators:  make(chan struct{}, 4),
+		}
+
+		err = store.Fetch(a)
+		assert.NoError(t, err, "error fetching asset")
+		assert.Equal(t, []string{"f", "e"}, generationLog[:2])
+		assert.ElementsMatch(t, []string{"b", "c"}, generationLog[2:4])
+		assert.Equal(t, "a", generationLog[4])
+	}
+}
+
 func TestStoreFetchOnDiskAssets(t *testing.T) {
 	cases := []struct {
 		name                  string
@@ -385,3 +493,338 @@ func TestStoreFetchOnDiskAssets(t *testing.T) {
 		})
 	}
 }
+
+// TestStoreFetchConsumed tests that Fetch removes the on-disk files of the
+// assets it consumes unless KeepConsumed is set.
+func TestStoreFetchConsumed(t *testing.T) {
+	cases := []struct {
+		name         string
+		keepConsumed bool
+		expectedKept bool
+	}{
+		{
+			name:         "consumed",
+			keepConsumed: false,
+			expectedKept: false,
+		},
+		{
+			name:         "kept",
+			keepConsumed: true,
+			expectedKept: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearAssetBehaviors()
+			defer func(keep bool) { KeepConsumed = keep }(KeepConsumed)
+			KeepConsumed = tc.keepConsumed
+
+			dir, err := ioutil.TempDir("", "TestStoreFetchConsumed")
+			if err != nil {
+				t.Fatalf("failed to create temporary directory: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			if err := ioutil.WriteFile(filepath.Join(dir, "b"), []byte{}, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			dependencies[reflect.TypeOf(&testStoreAssetA{})] = []Asset{&testStoreAssetB{}}
+			onDiskAssets[reflect.TypeOf(&testStoreAssetB{})] = true
+			store := &StoreImpl{
+				directory: dir,
+				assets:    map[reflect.Type]*assetState{},
+			}
+			err = store.Fetch(&testStoreAssetA{})
+			assert.NoError(t, err, "unexpected error")
+
+			_, err = os.Stat(filepath.Join(dir, "b"))
+			if tc.expectedKept {
+				assert.NoError(t, err, "consumed asset should be kept")
+			} else {
+				assert.True(t, os.IsNotExist(err), "consumed asset should be removed")
+			}
+		})
+	}
+}
+
+// TestStoreLoad tests the Load method of StoreImpl.
+func TestStoreLoad(t *testing.T) {
+	cases := []struct {
+		name           string
+		existingAssets []string
+		stateAssets    []string
+		target         string
+		expectedFound  bool
+	}{
+		{
+			name:          "absent asset",
+			target:        "a",
+			expectedFound: false,
+		},
+		{
+			name:           "fetched asset",
+			existingAssets: []string{"a"},
+			target:         "a",
+			expectedFound:  true,
+		},
+		{
+			name:          "asset in state file",
+			stateAssets:   []string{"a"},
+			target:        "a",
+			expectedFound: true,
+		},
+		{
+			name:           "other asset fetched",
+			existingAssets: []string{"b"},
+			stateAssets:    []string{"c"},
+			target:         "a",
+			expectedFound:  false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearAssetBehaviors()
+			store := &StoreImpl{
+				assets:          map[reflect.Type]*assetState{},
+				stateFileAssets: map[string]json.RawMessage{},
+			}
+			for _, name := range tc.existingAssets {
+				asset := newTestStoreAsset(name)
+				store.assets[reflect.TypeOf(asset)] = &assetState{
+					asset:  asset,
+					source: generatedSource,
+				}
+			}
+			for _, name := range tc.stateAssets {
+				store.stateFileAssets[reflect.TypeOf(newTestStoreAsset(name)).String()] = json.RawMessage("{}")
+			}
+			target := newTestStoreAsset(tc.target)
+			loaded, err := store.Load(target)
+			assert.NoError(t, err, "unexpected error")
+			if tc.expectedFound {
+				assert.IsType(t, target, loaded)
+			} else {
+				assert.Nil(t, loaded)
+			}
+			assert.Empty(t, generationLog)
+		})
+	}
+}
+
+// TestStoreFetchReentrant tests that a store reuses the assets generated
+// by an earlier store in the same directory.
+func TestStoreFetchReentrant(t *testing.T) {
+	clearAssetBehaviors()
+	dependencies[reflect.TypeOf(&testStoreAssetA{})] = []Asset{&testStoreAssetB{}}
+
+	dir, err := ioutil.TempDir("", "TestStoreFetchReentrant")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewStore(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Fetch(&testStoreAssetB{}))
+	assert.Equal(t, []string{"b"}, generationLog)
+
+	store, err = NewStore(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Fetch(&testStoreAssetA{}))
+	assert.Equal(t, []string{"b", "a"}, generationLog, "b should be reused from the state file")
+
+	store, err = NewStore(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Fetch(&testStoreAssetA{}))
+	assert.Equal(t, []string{"b", "a"}, generationLog, "a should be reused from the state file")
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1, "only the state file should be left in the directory") {
+		assert.Equal(t, stateFileName, files[0].Name())
+	}
+}
+
+func TestStoreFetchMissingInputs(t *testing.T) {
+	cases := []struct {
+		name                  string
+		assets                map[string][]string
+		inputAssets           []string
+		target                string
+		expectedGenerationLog []string
+		expectedMissing       []string
+	}{
+		{
+			name: "no missing inputs",
+			assets: map[string][]string{
+				"a": {"b"},
+				"b": {},
+			},
+			target:                "a",
+			expectedGenerationLog: []string{"b", "a"},
+		},
+		{
+			name: "missing target input",
+			assets: map[string][]string{
+				"a": {"b"},
+				"b": {},
+			},
+			inputAssets:           []string{"a"},
+			target:                "a",
+			expectedGenerationLog: []string{"b"},
+			expectedMissing:       []string{"a"},
+		},
+		{
+			name: "all missing inputs reported",
+			assets: map[string][]string{
+				"a": {"b", "c", "d"},
+				"b": {},
+				"c": {},
+				"d": {},
+			},
+			inputAssets:           []string{"b", "d"},
+			target:                "a",
+			expectedGenerationLog: []string{"c"},
+			expectedMissing:       []string{"b", "d"},
+		},
+		{
+			name: "shared missing input reported once",
+			assets: map[string][]string{
+				"a": {"b", "c"},
+				"b": {"d"},
+				"c": {"d"},
+				"d": {},
+			},
+			inputAssets:           []string{"d"},
+			target:                "a",
+			expectedGenerationLog: []string{},
+			expectedMissing:       []string{"d"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearAssetBehaviors()
+			store := &StoreImpl{
+				assets: map[reflect.Type]*assetState{},
+			}
+			assets := make(map[string]Asset, len(tc.assets))
+			for name := range tc.assets {
+				assets[name] = newTestStoreAsset(name)
+			}
+			for name, deps := range tc.assets {
+				dependenciesOfAsset := make([]Asset, len(deps))
+				for i, d := range deps {
+					dependenciesOfAsset[i] = assets[d]
+				}
+				dependencies[reflect.TypeOf(assets[name])] = dependenciesOfAsset
+			}
+			for _, name := range tc.inputAssets {
+				inputAssets[reflect.TypeOf(assets[name])] = true
+			}
+			err := store.fetch(assets[tc.target], "")
+			assert.EqualValues(t, tc.expectedGenerationLog, generationLog)
+			if tc.expectedMissing == nil {
+				assert.NoError(t, err, "unexpected error")
+				return
+			}
+			if assert.IsType(t, &MissingInputsError{}, errors.Cause(err)) {
+				assert.Equal(t, tc.expectedMissing, errors.Cause(err).(*MissingInputsError).Inputs)
+			}
+		})
+	}
+}
+
+// testStoreAssetExpiring is an asset which expires once testAssetExpired is
+// set.
+type testStoreAssetExpiring struct {
+	testStoreAssetA
+}
+
+var testAssetExpired bool
+
+func (a *testStoreAssetExpiring) Name() string {
+	return "expiring"
+}
+
+func (a *testStoreAssetExpiring) Generate(Parents) error {
+	return generateTestStoreAsset(a)
+}
+
+func (a *testStoreAssetExpiring) Expired() bool {
+	return testAssetExpired
+}
+
+func TestStoreFetchExpired(t *testing.T) {
+	clearAssetBehaviors()
+	defer func() { testAssetExpired = false }()
+	dependencies[reflect.TypeOf(&testStoreAssetB{})] = []Asset{&testStoreAssetExpiring{}}
+
+	dir, err := ioutil.TempDir("", "TestStoreFetchExpired")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fetch := func() []string {
+		generationLog = []string{}
+		store, err := NewStore(dir)
+		assert.NoError(t, err)
+		assert.NoError(t, store.Fetch(&testStoreAssetB{}))
+		return generationLog
+	}
+
+	assert.Equal(t, []string{"expiring", "b"}, fetch())
+	assert.Empty(t, fetch(), "the assets in the state file should be used")
+
+	testAssetExpired = true
+	assert.Equal(t, []string{"expiring", "b"}, fetch(), "the expired asset and its children should be regenerated")
+}
+
+func TestStoreChangedFiles(t *testing.T) {
+	written := &writablePersistAsset{
+		FileList: []*File{
+			{Filename: "unchanged", Data: []byte("data")},
+			{Filename: "changed", Data: []byte("data")},
+		},
+	}
+	data, err := json.Marshal(written)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &StoreImpl{
+		assets: map[reflect.Type]*assetState{},
+		stateFileAssets: map[string]json.RawMessage{
+			reflect.TypeOf(written).String(): data,
+		},
+	}
+
+	changed, err := store.changedFiles(&writablePersistAsset{}, []*File{
+		{Filename: "unchanged", Data: []byte("data")},
+		{Filename: "changed", Data: []byte("edited")},
+		{Filename: "added", Data: []byte("data")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"changed"}, changed)
+
+	changed, err = store.changedFiles(&testStoreAssetA{}, []*File{{Filename: "a", Data: []byte("data")}})
+	assert.NoError(t, err)
+	assert.Empty(t, changed, "assets absent from the state file have no changed files")
+}
+
+// TestStoreWithFileFetcher tests that a store with a FileFetcher keeps its
+// state in memory.
+func TestStoreWithFileFetcher(t *testing.T) {
+	clearAssetBehaviors()
+	dependencies[reflect.TypeOf(&testStoreAssetA{})] = []Asset{&testStoreAssetB{}}
+
+	store := NewStoreWithFileFetcher(NewMemoryFileFetcher())
+	assert.NoError(t, store.Fetch(&testStoreAssetA{}))
+	assert.Equal(t, []string{"b", "a"}, generationLog)
+
+	loaded, err := store.Load(&testStoreAssetB{})
+	assert.NoError(t, err)
+	assert.IsType(t, &testStoreAssetB{}, loaded)
+
+	_, err = os.Stat(stateFileName)
+	assert.True(t, os.IsNotExist(err), "the state file should not be written")
+}
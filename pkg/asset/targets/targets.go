@@ -0,0 +1,121 @@
+// Package targets defines the targets of the installer: the sets of assets
+// written to the asset directory by each 'create' command.
+//
+// Programs embedding the installer can fetch a target without the CLI, for
+// example from an install-config held in memory:
+//
+//	store := asset.NewStoreWithFileFetcher(asset.NewMemoryFileFetcher(
+//		&asset.File{Filename: "install-config.yaml", Data: installConfig},
+//	))
+//	for _, a := range targets.IgnitionConfigs() {
+//		if err := store.Fetch(a); err != nil {
+//			return err
+//		}
+//		for _, f := range a.Files() {
+//			// Use f.Filename and f.Data.
+//		}
+//	}
+//
+// Each call returns new assets, because fetching an asset fills it in, so
+// assets shared between stores would carry the files of one into another.
+//
+// The targets, the files they write, and the functions of package asset
+// used above follow the installer's versioning; the assets in the targets
+// and their fields do not.
+package targets
+
+import (
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/capi"
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
+	"github.com/openshift/installer/pkg/asset/ignition/hosts"
+	"github.com/openshift/installer/pkg/asset/ignition/machine"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/kubeconfig"
+	"github.com/openshift/installer/pkg/asset/manifests"
+	"github.com/openshift/installer/pkg/asset/pxe"
+	"github.com/openshift/installer/pkg/asset/templates"
+	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/asset/upi"
+)
+
+// InstallConfig returns the install-config targeted assets.
+func InstallConfig() []asset.WritableAsset {
+	return []asset.WritableAsset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// ManifestTemplates returns the manifest-templates targeted assets.
+func ManifestTemplates() []asset.WritableAsset {
+	return []asset.WritableAsset{
+		&templates.Templates{},
+	}
+}
+
+// Manifests returns the manifests targeted assets.
+func Manifests() []asset.WritableAsset {
+	return []asset.WritableAsset{
+		&manifests.Manifests{},
+		&manifests.Openshift{},
+	}
+}
+
+// IgnitionConfigs returns the ignition-configs targeted assets.
+func IgnitionConfigs() []asset.WritableAsset {
+	return []asset.WritableAsset{
+		&bootstrap.Bootstrap{},
+		&machine.Master{},
+		&machine.Worker{},
+		&hosts.Hosts{},
+		&kubeconfig.Admin{},
+		&kubeconfig.Users{},
+		&cluster.Metadata{},
+	}
+}
+
+// PXEConfig returns the pxe-config targeted assets.
+func PXEConfig() []asset.WritableAsset {
+	return []asset.WritableAsset{
+		&pxe.Artifacts{},
+		&kubeconfig.Admin{},
+		&kubeconfig.Users{},
+		&cluster.Metadata{},
+	}
+}
+
+// UPIArtifacts returns the upi-artifacts targeted assets.
+func UPIArtifacts() []asset.WritableAsset {
+	return []asset.WritableAsset{
+		&bootstrap.Bootstrap{},
+		&machine.Master{},
+		&machine.Worker{},
+		&kubeconfig.Admin{},
+		&kubeconfig.Users{},
+		&cluster.Metadata{},
+		&upi.Artifacts{},
+	}
+}
+
+// CAPIManifests returns the capi-manifests targeted assets.
+func CAPIManifests() []asset.WritableAsset {
+	return []asset.WritableAsset{
+		&capi.Manifests{},
+		&kubeconfig.Admin{},
+		&kubeconfig.Users{},
+		&cluster.Metadata{},
+	}
+}
+
+// Cluster returns the cluster targeted assets.
+func Cluster() []asset.WritableAsset {
+	return []asset.WritableAsset{
+		&cluster.TerraformVariables{},
+		&kubeconfig.Admin{},
+		&kubeconfig.Users{},
+		&tls.JournalCertKey{},
+		&cluster.Metadata{},
+		&cluster.Cluster{},
+	}
+}
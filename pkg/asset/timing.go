@@ -0,0 +1,54 @@
+package asset
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// generationTiming is how long the Generate of an asset took.
+type generationTiming struct {
+	name     string
+	duration time.Duration
+}
+
+// generationTimings records how long the Generate of each asset took during
+// a Fetch, to find the assets worth speeding up.
+type generationTimings struct {
+	lock    sync.Mutex
+	timings []generationTiming
+}
+
+// record records that the Generate of the named asset took duration.
+func (t *generationTimings) record(name string, duration time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.timings = append(t.timings, generationTiming{name: name, duration: duration})
+}
+
+// flush logs the recorded timings at debug level, slowest first, and forgets
+// them.
+func (t *generationTimings) flush() {
+	t.lock.Lock()
+	timings := t.timings
+	t.timings = nil
+	t.lock.Unlock()
+
+	if len(timings) == 0 || !logrus.IsLevelEnabled(logrus.DebugLevel) {
+		return
+	}
+
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].duration > timings[j].duration
+	})
+	var total time.Duration
+	for _, timing := range timings {
+		total += timing.duration
+	}
+	logrus.Debugf("Generated %d assets in %s:", len(timings), total.Round(time.Millisecond))
+	for _, timing := range timings {
+		logrus.Debugf("  %s: %s", timing.name, timing.duration.Round(time.Millisecond))
+	}
+}
@@ -0,0 +1,36 @@
+package asset
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerationTimings(t *testing.T) {
+	level := logrus.GetLevel()
+	defer logrus.SetLevel(level)
+	defer logrus.SetOutput(os.Stderr)
+	logrus.SetLevel(logrus.DebugLevel)
+	buf := &bytes.Buffer{}
+	logrus.SetOutput(buf)
+
+	timings := &generationTimings{}
+	timings.record("fast", time.Millisecond)
+	timings.record("slow", time.Second)
+	timings.flush()
+
+	output := buf.String()
+	assert.Contains(t, output, "Generated 2 assets in 1.001s:")
+	assert.Contains(t, output, "slow: 1s")
+	assert.Contains(t, output, "fast: 1ms")
+	assert.True(t, strings.Index(output, "slow: 1s") < strings.Index(output, "fast: 1ms"), "the slowest asset should be logged first")
+
+	buf.Reset()
+	timings.flush()
+	assert.Empty(t, buf.String())
+}
@@ -32,7 +32,7 @@ func (a *AdminCertKey) Generate(dependencies asset.Parents) error {
 		Subject:      pkix.Name{CommonName: "system:admin", Organization: []string{"system:masters"}},
 		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
-		Validity:     ValidityTenYears,
+		Validity:     CertValidity,
 	}
 
 	return a.CertKey.Generate(cfg, kubeCA, "admin", DoNotAppendParent)
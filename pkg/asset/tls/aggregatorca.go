@@ -5,9 +5,11 @@ import (
 	"crypto/x509/pkix"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 )
 
-// AggregatorCA is the asset that generates the aggregator-ca key/cert pair.
+// AggregatorCA is the asset that generates the aggregator-ca key/cert pair,
+// or takes it from the install-config if it has one.
 type AggregatorCA struct {
 	CertKey
 }
@@ -20,18 +22,27 @@ var _ asset.Asset = (*AggregatorCA)(nil)
 func (a *AggregatorCA) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&RootCA{},
+		&installconfig.InstallConfig{},
 	}
 }
 
 // Generate generates the cert/key pair based on its dependencies.
 func (a *AggregatorCA) Generate(dependencies asset.Parents) error {
 	rootCA := &RootCA{}
-	dependencies.Get(rootCA)
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(rootCA, installConfig)
+
+	if apiServer := installConfig.Config.APIServer; apiServer != nil && apiServer.AggregatorCA != nil {
+		a.KeyRaw = []byte(apiServer.AggregatorCA.Key)
+		a.CertRaw = []byte(apiServer.AggregatorCA.Certificate)
+		a.generateFiles("aggregator-ca")
+		return nil
+	}
 
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "aggregator", OrganizationalUnit: []string{"bootkube"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityTenYears,
+		Validity:  CAValidity,
 		IsCA:      true,
 	}
 
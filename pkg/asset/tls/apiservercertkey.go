@@ -39,19 +39,28 @@ func (a *APIServerCertKey) Generate(dependencies asset.Parents) error {
 		return errors.Wrap(err, "failed to get API Server address from InstallConfig")
 	}
 
+	dnsNames := []string{
+		apiAddress(installConfig.Config),
+		"kubernetes", "kubernetes.default",
+		"kubernetes.default.svc",
+		"kubernetes.default.svc.cluster.local",
+		"localhost",
+	}
+	ipAddresses := []net.IP{net.ParseIP(apiServerAddress), net.ParseIP("127.0.0.1")}
+	if apiServer := installConfig.Config.APIServer; apiServer != nil {
+		dnsNames = append(dnsNames, apiServer.AdditionalDNSNames...)
+		for _, ip := range apiServer.AdditionalIPAddresses {
+			ipAddresses = append(ipAddresses, net.ParseIP(ip))
+		}
+	}
+
 	cfg := &CertCfg{
 		Subject:      pkix.Name{CommonName: "system:kube-apiserver", Organization: []string{"kube-master"}},
 		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
-		Validity:     ValidityTenYears,
-		DNSNames: []string{
-			apiAddress(installConfig.Config),
-			"kubernetes", "kubernetes.default",
-			"kubernetes.default.svc",
-			"kubernetes.default.svc.cluster.local",
-			"localhost",
-		},
-		IPAddresses: []net.IP{net.ParseIP(apiServerAddress), net.ParseIP("127.0.0.1")},
+		Validity:     CertValidity,
+		DNSNames:     dnsNames,
+		IPAddresses:  ipAddresses,
 	}
 
 	return a.CertKey.Generate(cfg, kubeCA, "apiserver", AppendParent)
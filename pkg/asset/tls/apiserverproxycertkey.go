@@ -32,7 +32,7 @@ func (a *APIServerProxyCertKey) Generate(dependencies asset.Parents) error {
 		Subject:      pkix.Name{CommonName: "system:kube-apiserver-proxy", Organization: []string{"kube-master"}},
 		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
-		Validity:     ValidityTenYears,
+		Validity:     CertValidity,
 	}
 
 	return a.CertKey.Generate(cfg, aggregatorCA, "apiserver-proxy", DoNotAppendParent)
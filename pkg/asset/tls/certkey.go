@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"crypto/rsa"
 	"crypto/x509"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/redact"
 )
 
 // AppendParentChoice dictates whether the parent's cert is to be added to the
@@ -47,6 +49,23 @@ func (c *CertKey) Key() []byte {
 	return c.KeyRaw
 }
 
+// Sensitive returns true, because the asset contains a private key.
+func (c *CertKey) Sensitive() bool {
+	return true
+}
+
+// Expired returns true if the certificate has expired, or has less than a
+// tenth of its validity left, so that it is regenerated rather than
+// failing the bootstrap.
+func (c *CertKey) Expired() bool {
+	cert, err := PemToCertificate(c.CertRaw)
+	if err != nil {
+		return false
+	}
+	renewal := cert.NotAfter.Add(-cert.NotAfter.Sub(cert.NotBefore) / 10)
+	return !time.Now().Before(renewal)
+}
+
 // Generate generates a cert/key pair signed by the specified parent CA.
 func (c *CertKey) Generate(
 	cfg *CertCfg,
@@ -96,6 +115,9 @@ func (c *CertKey) CertFile() *asset.File {
 }
 
 func (c *CertKey) generateFiles(filenameBase string) {
+	// The key is in the bootstrap Ignition config, which Terraform logs.
+	redact.AddSecret(string(c.KeyRaw))
+
 	c.FileList = []*asset.File{
 		{
 			Filename: assetFilePath(filenameBase + ".key"),
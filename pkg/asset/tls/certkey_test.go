@@ -1,10 +1,13 @@
 package tls
 
 import (
+	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"math/big"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -88,3 +91,57 @@ func TestCertKeyGenerate(t *testing.T) {
 		})
 	}
 }
+
+func TestCertKeyExpired(t *testing.T) {
+	key, err := PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		notBefore time.Duration
+		notAfter  time.Duration
+		expected  bool
+	}{
+		{
+			name:      "valid",
+			notBefore: -time.Hour,
+			notAfter:  9 * time.Hour,
+		},
+		{
+			name:      "about to expire",
+			notBefore: -9 * time.Hour,
+			notAfter:  30 * time.Minute,
+			expected:  true,
+		},
+		{
+			name:      "expired",
+			notBefore: -2 * time.Hour,
+			notAfter:  -time.Hour,
+			expected:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: "test"},
+				NotBefore:    time.Now().Add(tt.notBefore),
+				NotAfter:     time.Now().Add(tt.notAfter),
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			certKey := &CertKey{CertRaw: CertToPem(cert)}
+			assert.Equal(t, tt.expected, certKey.Expired())
+		})
+	}
+}
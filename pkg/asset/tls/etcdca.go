@@ -5,37 +5,45 @@ import (
 	"crypto/x509/pkix"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/pkg/errors"
 )
 
-// EtcdCA is the asset that generates the etcd-ca key/cert pair.
+// EtcdCA is the asset that generates the etcd-ca key/cert pair. The etcd CA
+// is self-signed rather than signed by the root CA, so that the etcd peer,
+// serving and client certificates form their own hierarchy which can be
+// rotated without reissuing the rest of the cluster's certificates, and the
+// other way around.
 type EtcdCA struct {
 	CertKey
 }
 
 var _ asset.Asset = (*EtcdCA)(nil)
 
-// Dependencies returns the dependency of the the cert/key pair, which includes
-// the parent CA, and install config if it depends on the install config for
-// DNS names, etc.
+// Dependencies returns the dependency of the etcd-ca, which is empty.
 func (a *EtcdCA) Dependencies() []asset.Asset {
-	return []asset.Asset{
-		&RootCA{},
-	}
+	return []asset.Asset{}
 }
 
-// Generate generates the cert/key pair based on its dependencies.
+// Generate generates the etcd-ca key and cert pair.
 func (a *EtcdCA) Generate(dependencies asset.Parents) error {
-	rootCA := &RootCA{}
-	dependencies.Get(rootCA)
-
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "etcd", OrganizationalUnit: []string{"etcd"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityTenYears,
+		Validity:  RootCAValidity,
 		IsCA:      true,
 	}
 
-	return a.CertKey.Generate(cfg, rootCA, "etcd-client-ca", DoNotAppendParent)
+	key, crt, err := GenerateRootCertKey(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate EtcdCA")
+	}
+
+	a.KeyRaw = PrivateKeyToPem(key)
+	a.CertRaw = CertToPem(crt)
+
+	a.generateFiles("etcd-client-ca")
+
+	return nil
 }
 
 // Name returns the human-friendly name of the asset.
@@ -32,7 +32,7 @@ func (a *EtcdClientCertKey) Generate(dependencies asset.Parents) error {
 		Subject:      pkix.Name{CommonName: "etcd", OrganizationalUnit: []string{"etcd"}},
 		KeyUsages:    x509.KeyUsageKeyEncipherment,
 		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
-		Validity:     ValidityTenYears,
+		Validity:     CertValidity,
 	}
 
 	return a.CertKey.Generate(cfg, etcdCA, "etcd-client", DoNotAppendParent)
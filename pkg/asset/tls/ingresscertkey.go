@@ -38,7 +38,7 @@ func (a *IngressCertKey) Generate(dependencies asset.Parents) error {
 		Subject:      pkix.Name{CommonName: baseAddress, Organization: []string{"ingress"}},
 		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
-		Validity:     ValidityTenYears,
+		Validity:     CertValidity,
 		DNSNames: []string{
 			baseAddress,
 			fmt.Sprintf("*.%s", baseAddress),
@@ -33,7 +33,7 @@ func (a *JournalCertKey) Generate(dependencies asset.Parents) error {
 		Subject:      pkix.Name{CommonName: "journal-gatewayd", Organization: []string{"OpenShift Bootstrap"}},
 		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
-		Validity:     ValidityTenYears,
+		Validity:     CertValidity,
 	}
 
 	return a.CertKey.Generate(cfg, ca, "journal-gatewayd", DoNotAppendParent)
@@ -2,6 +2,7 @@ package tls
 
 import (
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/redact"
 	"github.com/pkg/errors"
 )
 
@@ -20,6 +21,11 @@ type KeyPair struct {
 	FileList []*asset.File
 }
 
+// Sensitive returns true, because the asset contains a private key.
+func (k *KeyPair) Sensitive() bool {
+	return true
+}
+
 // Generate generates the rsa private / public key pair.
 func (k *KeyPair) Generate(filenameBase string) error {
 	key, err := PrivateKey()
@@ -34,6 +40,7 @@ func (k *KeyPair) Generate(filenameBase string) error {
 
 	k.Pvt = PrivateKeyToPem(key)
 	k.Pub = pubkeyData
+	redact.AddSecret(string(k.Pvt))
 
 	k.FileList = []*asset.File{
 		{
@@ -7,7 +7,10 @@ import (
 	"github.com/openshift/installer/pkg/asset"
 )
 
-// KubeletCertKey is the asset that generates the kubelet key/cert pair.
+// KubeletCertKey is the asset that generates the kubelet key/cert pair. It
+// authenticates the kubelets as the node-bootstrapper service account, which
+// may only create certificate signing requests, and it is short-lived
+// because the kubelets replace it with the certificates they are issued.
 type KubeletCertKey struct {
 	CertKey
 }
@@ -32,7 +35,7 @@ func (a *KubeletCertKey) Generate(dependencies asset.Parents) error {
 		Subject:      pkix.Name{CommonName: "system:serviceaccount:openshift-machine-config-operator:node-bootstrapper", Organization: []string{"system:serviceaccounts:openshift-machine-config-operator"}},
 		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
-		Validity:     ValidityOneDay,
+		Validity:     minValidity(ValidityOneDay, CertValidity),
 	}
 
 	return a.CertKey.Generate(cfg, kubeCA, "kubelet", DoNotAppendParent)
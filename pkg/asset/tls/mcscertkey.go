@@ -3,6 +3,7 @@ package tls
 import (
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"net"
 
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
@@ -36,9 +37,16 @@ func (a *MCSCertKey) Generate(dependencies asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:      pkix.Name{CommonName: hostname},
 		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
-		Validity:     ValidityTenYears,
+		Validity:     CertValidity,
 		DNSNames:     []string{hostname},
 	}
+	if host, _ := installConfig.Config.MachineConfigServerEndpoint(); host != hostname {
+		if ip := net.ParseIP(host); ip != nil {
+			cfg.IPAddresses = append(cfg.IPAddresses, ip)
+		} else {
+			cfg.DNSNames = append(cfg.DNSNames, host)
+		}
+	}
 
 	return a.CertKey.Generate(cfg, rootCA, "machine-config-server", DoNotAppendParent)
 }
@@ -31,7 +31,7 @@ func (a *ServiceServingCA) Generate(dependencies asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "service-serving", OrganizationalUnit: []string{"bootkube"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityTenYears,
+		Validity:  CAValidity,
 		IsCA:      true,
 	}
 
@@ -29,6 +29,42 @@ const (
 	ValidityOneDay = time.Hour * 24
 )
 
+var (
+	// RootCAValidity is the validity of the self-signed CAs: the root CA
+	// and the etcd CA.
+	RootCAValidity = ValidityTenYears
+
+	// CAValidity is the validity of the CAs signed by the root CA.
+	CAValidity = ValidityTenYears
+
+	// CertValidity is the validity of the certificates which are not CAs.
+	// The kubelet bootstrap certificate is valid for at most a day.
+	CertValidity = ValidityTenYears
+)
+
+// CheckValidity returns an error if RootCAValidity, CAValidity and
+// CertValidity are not positive, or if a certificate would outlive the CA
+// signing it.
+func CheckValidity() error {
+	if RootCAValidity <= 0 || CAValidity <= 0 || CertValidity <= 0 {
+		return errors.New("certificate validities must be positive")
+	}
+	if CAValidity > RootCAValidity {
+		return errors.Errorf("the CA validity (%s) must not exceed the root CA validity (%s)", CAValidity, RootCAValidity)
+	}
+	if CertValidity > CAValidity {
+		return errors.Errorf("the certificate validity (%s) must not exceed the CA validity (%s)", CertValidity, CAValidity)
+	}
+	return nil
+}
+
+func minValidity(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
 // CertCfg contains all needed fields to configure a new certificate
 type CertCfg struct {
 	DNSNames     []string
@@ -105,3 +105,26 @@ func TestSignedCertificate(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckValidity(t *testing.T) {
+	defer func(rootCA, ca, cert time.Duration) {
+		RootCAValidity, CAValidity, CertValidity = rootCA, ca, cert
+	}(RootCAValidity, CAValidity, CertValidity)
+
+	cases := []struct {
+		rootCA, ca, cert time.Duration
+		err              bool
+	}{
+		{rootCA: ValidityTenYears, ca: ValidityTenYears, cert: ValidityTenYears},
+		{rootCA: time.Hour * 3, ca: time.Hour * 2, cert: time.Hour},
+		{rootCA: time.Hour, ca: time.Hour * 2, cert: time.Hour, err: true},
+		{rootCA: time.Hour * 2, ca: time.Hour, cert: time.Hour * 2, err: true},
+		{rootCA: time.Hour, ca: time.Hour, cert: 0, err: true},
+	}
+	for i, c := range cases {
+		RootCAValidity, CAValidity, CertValidity = c.rootCA, c.ca, c.cert
+		if err := CheckValidity(); (err != nil) != c.err {
+			t.Errorf("test case %d: expected error %t, got %v", i, c.err, err)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// Package upi contains assets for installing on user-provisioned
+// infrastructure, which the installer does not create.
+package upi
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
+	"github.com/openshift/installer/pkg/asset/ignition/machine"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/rhcos"
+	rhcospkg "github.com/openshift/installer/pkg/rhcos"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+const (
+	upiDir = "upi"
+
+	dnsRecordsFilename = "dns-records.json"
+	varsFilename       = "vars.yaml"
+	parametersFilename = "cloudformation-parameters.json"
+)
+
+// Artifacts is an asset that generates what the user needs to create the
+// infrastructure of the cluster: the DNS records the cluster expects, the
+// variables of the cluster for Ansible playbooks, and, on AWS, the
+// parameters of CloudFormation templates. Together with the Ignition
+// configs, they replace the resources which 'create cluster' would create
+// with Terraform.
+type Artifacts struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Artifacts)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (a *Artifacts) Name() string {
+	return "UPI Artifacts"
+}
+
+// Dependencies returns the assets on which the Artifacts asset depends.
+func (a *Artifacts) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.ClusterID{},
+		&installconfig.InstallConfig{},
+		new(rhcos.Image),
+		&bootstrap.Bootstrap{},
+		&machine.Master{},
+		&machine.Worker{},
+	}
+}
+
+// Generate generates the DNS records, the variables, and the
+// CloudFormation parameters of the cluster.
+func (a *Artifacts) Generate(dependencies asset.Parents) error {
+	clusterID := &installconfig.ClusterID{}
+	installConfig := &installconfig.InstallConfig{}
+	rhcosImage := new(rhcos.Image)
+	bootstrapIgn := &bootstrap.Bootstrap{}
+	masterIgn := &machine.Master{}
+	workerIgn := &machine.Worker{}
+	dependencies.Get(clusterID, installConfig, rhcosImage, bootstrapIgn, masterIgn, workerIgn)
+
+	vars, err := newVariables(clusterID.ClusterID, installConfig.Config, string(*rhcosImage))
+	if err != nil {
+		return err
+	}
+	vars.BootstrapIgnition = bootstrapIgn.File.Filename
+	vars.MasterIgnition = masterIgn.File.Filename
+	vars.WorkerIgnition = workerIgn.File.Filename
+
+	records, err := json.MarshalIndent(dnsRecords(installConfig.Config), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the DNS records")
+	}
+	varsData, err := yaml.Marshal(vars)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the variables")
+	}
+
+	a.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(upiDir, dnsRecordsFilename),
+			Data:     append(records, '\n'),
+		},
+		{
+			Filename: filepath.Join(upiDir, varsFilename),
+			Data:     varsData,
+		},
+	}
+
+	if installConfig.Config.Platform.Name() == aws.Name {
+		parameters, err := json.MarshalIndent(cloudFormationParameters(vars), "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the CloudFormation parameters")
+		}
+		a.FileList = append(a.FileList, &asset.File{
+			Filename: filepath.Join(upiDir, parametersFilename),
+			Data:     append(parameters, '\n'),
+		})
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (a *Artifacts) Files() []*asset.File {
+	return a.FileList
+}
+
+// Load returns false, because the artifacts are always generated from the
+// install config.
+func (a *Artifacts) Load(f asset.FileFetcher) (found bool, err error) {
+	return false, nil
+}
+
+// variables are the variables of the cluster with which the user creates
+// its infrastructure.
+type variables struct {
+	ClusterName        string `json:"cluster_name"`
+	InfrastructureName string `json:"infrastructure_name"`
+	BaseDomain         string `json:"base_domain"`
+	Platform           string `json:"platform"`
+	Region             string `json:"region,omitempty"`
+	MachineCIDR        string `json:"machine_cidr"`
+	MasterCount        int    `json:"master_count"`
+	WorkerCount        int    `json:"worker_count"`
+	RHCOSImage         string `json:"rhcos_image,omitempty"`
+	APIDNSName         string `json:"api_dns_name"`
+	AppsDNSName        string `json:"apps_dns_name"`
+	BootstrapIgnition  string `json:"bootstrap_ignition"`
+	MasterIgnition     string `json:"master_ignition"`
+	WorkerIgnition     string `json:"worker_ignition"`
+}
+
+func newVariables(clusterID string, config *types.InstallConfig, osImage string) (*variables, error) {
+	v := &variables{
+		ClusterName:        config.ObjectMeta.Name,
+		InfrastructureName: types.InfraID(config.ObjectMeta.Name, clusterID),
+		BaseDomain:         config.BaseDomain,
+		Platform:           config.Platform.Name(),
+		MachineCIDR:        config.Networking.MachineCIDR.String(),
+		MasterCount:        replicas(config, "master"),
+		WorkerCount:        replicas(config, "worker"),
+		RHCOSImage:         osImage,
+		APIDNSName:         apiDNSName(config),
+		AppsDNSName:        appsDNSName(config),
+	}
+	if config.Platform.AWS != nil {
+		v.Region = config.Platform.AWS.Region
+		if _, sourceRegion := rhcospkg.SplitAMI(osImage); sourceRegion != "" {
+			return nil, errors.Errorf("the AMI in %s is copied from %s by 'create cluster', so its ID is not known yet; set the osImage of the platform to an AMI of %s", v.Region, sourceRegion, v.Region)
+		}
+	}
+	return v, nil
+}
+
+// replicas returns the number of machines of the pool, which defaults to
+// one.
+func replicas(config *types.InstallConfig, pool string) int {
+	count := 0
+	for _, m := range config.Machines {
+		if m.Name != pool {
+			continue
+		}
+		if m.Replicas == nil {
+			count++
+		} else {
+			count += int(*m.Replicas)
+		}
+	}
+	return count
+}
+
+func apiDNSName(config *types.InstallConfig) string {
+	return fmt.Sprintf("%s-api.%s", config.ObjectMeta.Name, config.BaseDomain)
+}
+
+func appsDNSName(config *types.InstallConfig) string {
+	return fmt.Sprintf("*.apps.%s.%s", config.ObjectMeta.Name, config.BaseDomain)
+}
+
+// dnsRecord is a DNS record which the cluster expects.
+type dnsRecord struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// Values are the values of the record, if they do not depend on the
+	// infrastructure the user creates.
+	Values []string `json:"values,omitempty"`
+
+	// Target describes what the record points at, if its values depend
+	// on the infrastructure the user creates, such as load balancers.
+	Target string `json:"target,omitempty"`
+}
+
+// dnsRecords returns the DNS records which the cluster expects, the same
+// which 'create cluster' creates: the API and ingress records, and the
+// etcd records of the masters.
+func dnsRecords(config *types.InstallConfig) []dnsRecord {
+	apiTarget := "the load balancer of the API, which forwards port 6443 to the bootstrap machine and the masters"
+	// The machine config server is behind the API load balancer unless
+	// the install-config moves it to another host.
+	if host, port := config.MachineConfigServerEndpoint(); host == apiDNSName(config) {
+		apiTarget = fmt.Sprintf("the load balancer of the API, which forwards ports 6443 and %d to the bootstrap machine and the masters", port)
+	}
+	records := []dnsRecord{
+		{
+			Name:   apiDNSName(config),
+			Type:   "A",
+			Target: apiTarget,
+		},
+		{
+			Name:   appsDNSName(config),
+			Type:   "A",
+			Target: "the load balancer of the routers, which forwards ports 80 and 443 to the workers",
+		},
+	}
+
+	masters := replicas(config, "master")
+	if masters == 0 {
+		return records
+	}
+	srv := dnsRecord{
+		Name: fmt.Sprintf("_etcd-server-ssl._tcp.%s.%s", config.ObjectMeta.Name, config.BaseDomain),
+		Type: "SRV",
+	}
+	for i := 0; i < masters; i++ {
+		name := fmt.Sprintf("%s-etcd-%d.%s", config.ObjectMeta.Name, i, config.BaseDomain)
+		records = append(records, dnsRecord{
+			Name:   name,
+			Type:   "A",
+			Target: fmt.Sprintf("the address of master %d", i),
+		})
+		srv.Values = append(srv.Values, fmt.Sprintf("0 10 2380 %s.", name))
+	}
+	return append(records, srv)
+}
+
+// cloudFormationParameter is a parameter of a CloudFormation stack, in
+// the format of the --parameters file of 'aws cloudformation
+// create-stack'.
+type cloudFormationParameter struct {
+	ParameterKey   string `json:"ParameterKey"`
+	ParameterValue string `json:"ParameterValue"`
+}
+
+// cloudFormationParameters returns the parameters of the cluster for the
+// CloudFormation templates of its VPC, DNS, load balancers and machines.
+func cloudFormationParameters(v *variables) []cloudFormationParameter {
+	return []cloudFormationParameter{
+		{ParameterKey: "ClusterName", ParameterValue: v.ClusterName},
+		{ParameterKey: "InfrastructureName", ParameterValue: v.InfrastructureName},
+		{ParameterKey: "HostedZoneName", ParameterValue: v.BaseDomain},
+		{ParameterKey: "VpcCidr", ParameterValue: v.MachineCIDR},
+		{ParameterKey: "RhcosAmi", ParameterValue: v.RHCOSImage},
+		{ParameterKey: "MasterCount", ParameterValue: fmt.Sprint(v.MasterCount)},
+		{ParameterKey: "WorkerCount", ParameterValue: fmt.Sprint(v.WorkerCount)},
+	}
+}
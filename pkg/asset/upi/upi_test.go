@@ -0,0 +1,78 @@
+package upi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/rhcos"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func testInstallConfig() *types.InstallConfig {
+	three := int64(3)
+	return &types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		BaseDomain: "example.com",
+		Networking: &types.Networking{
+			MachineCIDR: ipnet.MustParseCIDR("10.0.0.0/16"),
+		},
+		Machines: []types.MachinePool{
+			{Name: "master", Replicas: &three},
+			{Name: "worker"},
+		},
+		Platform: types.Platform{
+			AWS: &aws.Platform{Region: "us-east-1"},
+		},
+	}
+}
+
+func TestDNSRecords(t *testing.T) {
+	records := dnsRecords(testInstallConfig())
+	assert.Len(t, records, 6)
+	assert.Equal(t, "test-cluster-api.example.com", records[0].Name)
+	assert.Contains(t, records[0].Target, "ports 6443 and 49500")
+	assert.Equal(t, "*.apps.test-cluster.example.com", records[1].Name)
+	assert.Equal(t, "test-cluster-etcd-2.example.com", records[4].Name)
+	assert.Equal(t, dnsRecord{
+		Name: "_etcd-server-ssl._tcp.test-cluster.example.com",
+		Type: "SRV",
+		Values: []string{
+			"0 10 2380 test-cluster-etcd-0.example.com.",
+			"0 10 2380 test-cluster-etcd-1.example.com.",
+			"0 10 2380 test-cluster-etcd-2.example.com.",
+		},
+	}, records[5])
+
+	config := testInstallConfig()
+	config.MachineConfigServer = &types.MachineConfigServer{Port: 22623}
+	assert.Contains(t, dnsRecords(config)[0].Target, "ports 6443 and 22623")
+
+	config.MachineConfigServer.Host = "192.0.2.10"
+	assert.Contains(t, dnsRecords(config)[0].Target, "forwards port 6443 to")
+}
+
+func TestCloudFormationParameters(t *testing.T) {
+	vars, err := newVariables("test-cluster-id", testInstallConfig(), "ami-0123456789")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "us-east-1", vars.Region)
+	assert.Equal(t, 3, vars.MasterCount)
+	assert.Equal(t, 1, vars.WorkerCount)
+
+	parameters := cloudFormationParameters(vars)
+	assert.Contains(t, parameters, cloudFormationParameter{ParameterKey: "InfrastructureName", ParameterValue: types.InfraID("test-cluster", "test-cluster-id")})
+	assert.Contains(t, parameters, cloudFormationParameter{ParameterKey: "RhcosAmi", ParameterValue: "ami-0123456789"})
+	assert.Contains(t, parameters, cloudFormationParameter{ParameterKey: "MasterCount", ParameterValue: "3"})
+}
+
+func TestCopiedAMI(t *testing.T) {
+	config := testInstallConfig()
+	config.Platform.AWS.Region = "eu-north-1"
+	_, err := newVariables("test-cluster-id", config, rhcos.CopiedAMI("ami-0123456789", rhcos.AMICopySourceRegion))
+	assert.EqualError(t, err, "the AMI in eu-north-1 is copied from us-east-1 by 'create cluster', so its ID is not known yet; set the osImage of the platform to an AMI of eu-north-1")
+}
@@ -0,0 +1,303 @@
+// Package validation checks the user-provided files in an asset directory
+// without generating or modifying any assets.
+package validation
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/defaults"
+	openstackvalidation "github.com/openshift/installer/pkg/types/openstack/validation"
+	"github.com/openshift/installer/pkg/types/validation"
+	"github.com/openshift/installer/pkg/validate"
+)
+
+const (
+	installConfigFilename = "install-config.yaml"
+	clusterConfigFilename = "cluster-config.yaml"
+
+	// IssueFileNotFound is the type of issues for required files which
+	// are missing.
+	IssueFileNotFound = "FileNotFound"
+
+	// IssueFileInvalid is the type of issues for files which cannot be
+	// parsed.
+	IssueFileInvalid = "FileInvalid"
+
+	// IssueRegistryAccess is the type of issues for images which the pull
+	// secret does not grant access to.
+	IssueRegistryAccess = "RegistryAccess"
+
+	// IssueDeprecated is the type of issues for deprecated fields, which
+	// still work.
+	IssueDeprecated = "Deprecated"
+
+	// SeverityError is the severity of issues which fail the install.
+	SeverityError = "error"
+
+	// SeverityWarning is the severity of issues which do not fail the
+	// install, but should be fixed.
+	SeverityWarning = "warning"
+)
+
+// manifestDirs are the directories holding the manifests.
+var manifestDirs = []string{"manifests", "openshift"}
+
+// Issue is a problem found in a file in the asset directory.
+type Issue struct {
+	// File is the path of the file, relative to the asset directory.
+	File string `json:"file"`
+
+	// Field is the path of the field in the file, if the issue is with a
+	// single field.
+	Field string `json:"field,omitempty"`
+
+	// Type is the type of the issue, e.g. "FieldValueRequired".
+	Type string `json:"type"`
+
+	// Severity is SeverityError or SeverityWarning.
+	Severity string `json:"severity"`
+
+	// Message describes the issue.
+	Message string `json:"message"`
+
+	// Suggestion describes how to fix the issue, if there is a usual fix.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// fieldSuggestions are the suggestions of the issues of field errors, by
+// the type of the error.
+var fieldSuggestions = map[field.ErrorType]string{
+	field.ErrorTypeRequired:     "set the field",
+	field.ErrorTypeNotSupported: "use one of the supported values",
+	field.ErrorTypeDuplicate:    "remove the duplicate value",
+	field.ErrorTypeForbidden:    "remove the field",
+	field.ErrorTypeTooLong:      "shorten the value",
+}
+
+func (i *Issue) String() string {
+	s := fmt.Sprintf("%s: %s", i.File, i.Message)
+	if i.Field != "" {
+		s = fmt.Sprintf("%s: %s: %s", i.File, i.Field, i.Message)
+	}
+	if i.Suggestion != "" {
+		s = fmt.Sprintf("%s (%s)", s, i.Suggestion)
+	}
+	return s
+}
+
+// Validate checks install-config.yaml and any manifests in directory and
+// returns the issues found. If openStackValidValuesFetcher is nil, the
+// OpenStack platform values are not checked against the cloud. An error is
+// only returned if the files cannot be read.
+func Validate(directory string, openStackValidValuesFetcher openstackvalidation.ValidValuesFetcher) ([]Issue, error) {
+	issues, err := validateInstallConfig(directory, openStackValidValuesFetcher)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dir := range manifestDirs {
+		manifestIssues, err := validateManifests(directory, dir)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, manifestIssues...)
+	}
+	return issues, nil
+}
+
+func validateInstallConfig(directory string, openStackValidValuesFetcher openstackvalidation.ValidValuesFetcher) ([]Issue, error) {
+	config, issues, err := loadInstallConfig(directory)
+	if config == nil {
+		return issues, err
+	}
+
+	for _, fieldErr := range validation.ValidateInstallConfig(config, openStackValidValuesFetcher) {
+		issues = append(issues, fieldIssue(installConfigFilename, fieldErr))
+	}
+	if config.Networking != nil && config.Networking.PodCIDR != nil {
+		issues = append(issues, Issue{
+			File:       installConfigFilename,
+			Field:      field.NewPath("networking", "podCIDR").String(),
+			Type:       IssueDeprecated,
+			Severity:   SeverityWarning,
+			Message:    "podCIDR is deprecated",
+			Suggestion: "use clusterNetworks instead",
+		})
+	}
+	return issues, nil
+}
+
+// loadInstallConfig reads install-config.yaml in directory and sets its
+// defaults. If it is missing or cannot be parsed, the issue is returned
+// instead.
+func loadInstallConfig(directory string) (*types.InstallConfig, []Issue, error) {
+	data, err := ioutil.ReadFile(filepath.Join(directory, installConfigFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, []Issue{{
+				File:       installConfigFilename,
+				Type:       IssueFileNotFound,
+				Severity:   SeverityError,
+				Message:    "the install-config is required",
+				Suggestion: "create it with 'openshift-install create install-config'",
+			}}, nil
+		}
+		return nil, nil, errors.Wrapf(err, "failed to read %s", installConfigFilename)
+	}
+
+	config := &types.InstallConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, []Issue{{
+			File:       installConfigFilename,
+			Type:       IssueFileInvalid,
+			Severity:   SeverityError,
+			Message:    err.Error(),
+			Suggestion: "fix the YAML syntax of the file",
+		}}, nil
+	}
+	defaults.SetInstallConfigDefaults(config)
+	return config, nil, nil
+}
+
+// ValidateRegistryAccess checks that the pull secret in install-config.yaml
+// in directory grants access to the release image, and to the mirrors of
+// its repository in the image content sources, and returns the issues
+// found. Unlike Validate, it contacts the registries. If install-config.yaml
+// is missing or invalid, no issues are returned, because Validate reports
+// them.
+func ValidateRegistryAccess(ctx context.Context, directory string, releaseImage string) ([]Issue, error) {
+	config, _, err := loadInstallConfig(directory)
+	if config == nil || validate.ImagePullSecret(config.PullSecret) != nil {
+		return nil, err
+	}
+
+	path := field.NewPath("pullSecret")
+	var issues []Issue
+	if err := validate.PullSecretGrantsAccess(ctx, config.PullSecret, releaseImage); err != nil {
+		issues = append(issues, Issue{
+			File:       installConfigFilename,
+			Field:      path.String(),
+			Type:       IssueRegistryAccess,
+			Severity:   SeverityError,
+			Message:    fmt.Sprintf("cannot pull the release image %s: %v", releaseImage, err),
+			Suggestion: "add the credentials of the registry to the pull secret",
+		})
+	}
+
+	repository := releaseImage
+	if i := strings.Index(repository, "@"); i >= 0 {
+		repository = repository[:i]
+	} else if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	for _, source := range config.ImageContentSources {
+		if source.Source != repository {
+			continue
+		}
+		for _, mirror := range source.Mirrors {
+			if err := validate.PullSecretGrantsRepositoryAccess(ctx, config.PullSecret, mirror); err != nil {
+				issues = append(issues, Issue{
+					File:       installConfigFilename,
+					Field:      path.String(),
+					Type:       IssueRegistryAccess,
+					Severity:   SeverityError,
+					Message:    fmt.Sprintf("cannot pull from the mirror %s: %v", mirror, err),
+					Suggestion: "add the credentials of the mirror to the pull secret",
+				})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// fieldIssue converts a field error into an issue. The bad value is left
+// out of the message, since it may be a secret like the pull secret.
+func fieldIssue(file string, err *field.Error) Issue {
+	message := err.Type.String()
+	if err.Detail != "" {
+		message = fmt.Sprintf("%s: %s", message, err.Detail)
+	}
+	return Issue{
+		File:       file,
+		Field:      err.Field,
+		Type:       string(err.Type),
+		Severity:   SeverityError,
+		Message:    message,
+		Suggestion: fieldSuggestions[err.Type],
+	}
+}
+
+func validateManifests(directory string, dir string) ([]Issue, error) {
+	paths, err := filepath.Glob(filepath.Join(directory, dir, "*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var issues []Issue
+	foundClusterConfig := false
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() {
+			continue
+		}
+
+		filename := filepath.Join(dir, info.Name())
+		if filename == filepath.Join("manifests", clusterConfigFilename) {
+			foundClusterConfig = true
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", filename)
+		}
+		issues = append(issues, validateManifest(filename, data)...)
+	}
+
+	if dir == "manifests" && len(paths) > 0 && !foundClusterConfig {
+		issues = append(issues, Issue{
+			File:       filepath.Join(dir, clusterConfigFilename),
+			Type:       IssueFileNotFound,
+			Severity:   SeverityError,
+			Message:    fmt.Sprintf("%s is required when the %s directory is present", clusterConfigFilename, dir),
+			Suggestion: "generate the manifests with 'openshift-install create manifests' before adding to them",
+		})
+	}
+	return issues, nil
+}
+
+// validateManifest checks that the manifest is a Kubernetes object.
+func validateManifest(filename string, data []byte) []Issue {
+	var object map[string]interface{}
+	if err := yaml.Unmarshal(data, &object); err != nil {
+		return []Issue{{
+			File:       filename,
+			Type:       IssueFileInvalid,
+			Severity:   SeverityError,
+			Message:    err.Error(),
+			Suggestion: "fix the YAML syntax of the file",
+		}}
+	}
+
+	var issues []Issue
+	for _, name := range []string{"apiVersion", "kind"} {
+		if value, _ := object[name].(string); value == "" {
+			issues = append(issues, fieldIssue(filename, field.Required(field.NewPath(name), "")))
+		}
+	}
+	return issues
+}
@@ -0,0 +1,156 @@
+package validation
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const validInstallConfig = `apiVersion: v1beta1
+metadata:
+  name: test-cluster
+baseDomain: example.com
+platform:
+  aws:
+    region: us-east-1
+pullSecret: '{"auths":{"example.com":{"auth":"authorization value"}}}'
+`
+
+const validManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+`
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		files    map[string]string
+		expected []Issue
+	}{
+		{
+			name: "valid install-config",
+			files: map[string]string{
+				"install-config.yaml": validInstallConfig,
+			},
+		},
+		{
+			name:  "missing install-config",
+			files: map[string]string{},
+			expected: []Issue{{
+				File:       "install-config.yaml",
+				Type:       IssueFileNotFound,
+				Severity:   SeverityError,
+				Message:    "the install-config is required",
+				Suggestion: "create it with 'openshift-install create install-config'",
+			}},
+		},
+		{
+			name: "invalid field",
+			files: map[string]string{
+				"install-config.yaml": validInstallConfig + "sshKey: not-a-key\n",
+			},
+			expected: []Issue{{
+				File:     "install-config.yaml",
+				Field:    "sshKey",
+				Type:     "FieldValueInvalid",
+				Severity: SeverityError,
+				Message:  "Invalid value: ssh: no key found",
+			}},
+		},
+		{
+			name: "deprecated field",
+			files: map[string]string{
+				"install-config.yaml": validInstallConfig + "networking:\n  podCIDR: 10.128.0.0/14\n",
+			},
+			expected: []Issue{{
+				File:       "install-config.yaml",
+				Field:      "networking.podCIDR",
+				Type:       IssueDeprecated,
+				Severity:   SeverityWarning,
+				Message:    "podCIDR is deprecated",
+				Suggestion: "use clusterNetworks instead",
+			}},
+		},
+		{
+			name: "unparseable install-config",
+			files: map[string]string{
+				"install-config.yaml": "baseDomain: [",
+			},
+			expected: []Issue{{
+				File:       "install-config.yaml",
+				Type:       IssueFileInvalid,
+				Severity:   SeverityError,
+				Message:    "error converting YAML to JSON: yaml: line 1: did not find expected node content",
+				Suggestion: "fix the YAML syntax of the file",
+			}},
+		},
+		{
+			name: "valid manifests",
+			files: map[string]string{
+				"install-config.yaml":           validInstallConfig,
+				"manifests/cluster-config.yaml": validManifest,
+				"openshift/99_config.yaml":      validManifest,
+			},
+		},
+		{
+			name: "manifests without cluster-config",
+			files: map[string]string{
+				"install-config.yaml":   validInstallConfig,
+				"manifests/extra.yaml":  validManifest,
+				"openshift/object.yaml": "metadata:\n  name: test\n",
+			},
+			expected: []Issue{
+				{
+					File:       "manifests/cluster-config.yaml",
+					Type:       IssueFileNotFound,
+					Severity:   SeverityError,
+					Message:    "cluster-config.yaml is required when the manifests directory is present",
+					Suggestion: "generate the manifests with 'openshift-install create manifests' before adding to them",
+				},
+				{
+					File:       "openshift/object.yaml",
+					Field:      "apiVersion",
+					Type:       "FieldValueRequired",
+					Severity:   SeverityError,
+					Message:    "Required value",
+					Suggestion: "set the field",
+				},
+				{
+					File:       "openshift/object.yaml",
+					Field:      "kind",
+					Type:       "FieldValueRequired",
+					Severity:   SeverityError,
+					Message:    "Required value",
+					Suggestion: "set the field",
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "TestValidate")
+			if err != nil {
+				t.Fatalf("failed to create temporary directory: %v", err)
+			}
+			defer os.RemoveAll(dir)
+
+			for name, data := range tc.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			issues, err := Validate(dir, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, issues)
+		})
+	}
+}
@@ -2,7 +2,10 @@ package aws
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
@@ -18,6 +21,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/destroy"
 )
 
 var (
@@ -27,7 +32,7 @@ var (
 // Filter holds the key/value pairs for the tags we will be matching against.
 //
 // A resource matches the filter if all of the key/value pairs are in its tags.
-type Filter map[string]string
+type Filter = destroy.Filter
 
 // ClusterUninstaller holds the various options for the cluster we want to delete
 type ClusterUninstaller struct {
@@ -51,6 +56,64 @@ type ClusterUninstaller struct {
 	Logger      logrus.FieldLogger
 	Region      string
 	ClusterName string
+
+	// ClusterID and BaseDomain find the resources of the cluster which are
+	// not tagged. They are optional, for clusters created before they were
+	// recorded in their metadata.
+	ClusterID  string
+	BaseDomain string
+
+	// Profile is the profile of the shared AWS credentials with which to
+	// destroy the cluster, instead of the default credentials.
+	Profile string
+
+	// deletions are the outcomes of the deletions of Run, by ARN.
+	deletions map[string]*Deletion
+
+	// deadline is when Run stops, if it is set.
+	deadline time.Time
+
+	// deleted are the ARNs of the deleted resources, including those
+	// deleted by an earlier Run which was resumed.
+	deleted map[string]struct{}
+
+	// iamUnmatched are the ARNs of the IAM roles and users which do not
+	// match the filters, whose tags need not be fetched again.
+	iamUnmatched map[string]struct{}
+
+	// attempts are the failed attempts to delete the resources, by ARN.
+	attempts map[string]*attempts
+
+	// includeTypes and excludeTypes scope Run to some resource types.
+	includeTypes []string
+	excludeTypes []string
+}
+
+// Deletion is the outcome of the deletion of a resource by Run.
+type Deletion struct {
+	ARN string
+
+	// Time is when the resource was deleted or, if it was not, when its
+	// deletion last failed.
+	Time time.Time
+
+	// Error is the last error deleting the resource, if it was not
+	// deleted.
+	Error error
+
+	// Skipped is why the resource was not deleted, if it was skipped.
+	Skipped string
+}
+
+// Deletions returns the outcomes of the deletions of Run, in the order in
+// which they happened.
+func (o *ClusterUninstaller) Deletions() []Deletion {
+	deletions := make([]Deletion, 0, len(o.deletions))
+	for _, deletion := range o.deletions {
+		deletions = append(deletions, *deletion)
+	}
+	sort.Slice(deletions, func(i, j int) bool { return deletions[i].Time.Before(deletions[j].Time) })
+	return deletions
 }
 
 func (o *ClusterUninstaller) validate() error {
@@ -70,80 +133,66 @@ func (o *ClusterUninstaller) Run() error {
 		return err
 	}
 
-	awsConfig := &aws.Config{Region: aws.String(o.Region)}
-
-	// Relying on appropriate AWS ENV vars (eg AWS_PROFILE, AWS_ACCESS_KEY_ID, etc)
-	awsSession, err := session.NewSession(awsConfig)
+	awsSession, err := o.session()
 	if err != nil {
 		return err
 	}
 
-	tagClients := []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI{
-		resourcegroupstaggingapi.New(awsSession),
-	}
-	tagClientNames := map[*resourcegroupstaggingapi.ResourceGroupsTaggingAPI]string{
-		tagClients[0]: o.Region,
+	tagClients, tagClientNames := o.tagClients(awsSession)
+
+	o.deletions = map[string]*Deletion{}
+	o.attempts = map[string]*attempts{}
+	if o.deleted == nil {
+		o.deleted = map[string]struct{}{}
 	}
-	if o.Region != "us-east-1" {
-		tagClient := resourcegroupstaggingapi.New(
-			awsSession, aws.NewConfig().WithRegion("us-east-1"),
-		)
-		tagClients = append(tagClients, tagClient)
-		tagClientNames[tagClient] = "us-east-1"
+	if o.iamUnmatched == nil {
+		o.iamUnmatched = map[string]struct{}{}
 	}
-
-	deleted := map[string]struct{}{}
+	deleted := o.deleted
 	iamClient := iam.New(awsSession)
 	iamRoleSearch := &iamRoleSearch{
-		client:  iamClient,
-		filters: o.Filters,
-		logger:  o.Logger,
+		client:    iamClient,
+		filters:   o.Filters,
+		logger:    o.Logger,
+		unmatched: o.iamUnmatched,
 	}
 	iamUserSearch := &iamUserSearch{
-		client:  iamClient,
-		filters: o.Filters,
-		logger:  o.Logger,
+		client:    iamClient,
+		filters:   o.Filters,
+		logger:    o.Logger,
+		unmatched: o.iamUnmatched,
 	}
 
 	var loopError error
 	for len(tagClients) > 0 || loopError != nil {
+		if o.timedOut() {
+			return errors.Errorf("timed out with %d resources deleted", len(deleted))
+		}
 		loopError = nil
+		var arns []string
 		nextTagClients := tagClients[:0]
 		for _, tagClient := range tagClients {
+			tagged, shared, err := o.tagged(tagClient, tagClientNames[tagClient])
+			if err != nil {
+				o.Logger.Info(err)
+				loopError = err
+			}
+
+			for _, arn := range shared {
+				if _, ok := o.deletions[arn]; !ok {
+					o.Logger.WithField("arn", arn).Info("Skipping shared resource")
+					o.deletions[arn] = &Deletion{ARN: arn, Time: time.Now(), Skipped: "shared with other clusters"}
+				}
+			}
+
 			matched := false
-			for _, filter := range o.Filters {
-				o.Logger.Debugf("search for and delete matching resources by tag in %s matching %#+v", tagClientNames[tagClient], filter)
-				tagFilters := make([]*resourcegroupstaggingapi.TagFilter, 0, len(filter))
-				for key, value := range filter {
-					tagFilters = append(tagFilters, &resourcegroupstaggingapi.TagFilter{
-						Key:    aws.String(key),
-						Values: []*string{aws.String(value)},
-					})
+			for _, arn := range tagged {
+				if o.outOfScope(arn) {
+					continue
 				}
-				err = tagClient.GetResourcesPages(
-					&resourcegroupstaggingapi.GetResourcesInput{TagFilters: tagFilters},
-					func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
-						for _, resource := range results.ResourceTagMappingList {
-							arn := *resource.ResourceARN
-							if _, ok := deleted[arn]; !ok {
-								matched = true
-								err := deleteARN(awsSession, arn, o.Logger)
-								if err != nil {
-									err = errors.Wrapf(err, "deleting %s", arn)
-									o.Logger.Debug(err)
-									continue
-								}
-								deleted[arn] = exists
-							}
-						}
-
-						return !lastPage
-					},
-				)
-				if err != nil {
-					err = errors.Wrapf(err, "get tagged resources")
-					o.Logger.Info(err)
-					loopError = err
+				if _, ok := deleted[arn]; !ok && !o.exhausted(arn) {
+					matched = true
+					arns = append(arns, arn)
 				}
 			}
 
@@ -156,7 +205,7 @@ func (o *ClusterUninstaller) Run() error {
 		tagClients = nextTagClients
 
 		o.Logger.Debug("search for IAM roles")
-		arns, err := iamRoleSearch.arns()
+		roleARNs, err := iamRoleSearch.arns()
 		if err != nil {
 			o.Logger.Info(err)
 			loopError = err
@@ -168,55 +217,243 @@ func (o *ClusterUninstaller) Run() error {
 			o.Logger.Info(err)
 			loopError = err
 		}
-		arns = append(arns, userARNs...)
+		for _, arn := range append(roleARNs, userARNs...) {
+			if o.outOfScope(arn) {
+				continue
+			}
+			if _, ok := deleted[arn]; !ok && !o.exhausted(arn) {
+				arns = append(arns, arn)
+			}
+		}
 
-		if len(arns) > 0 {
-			o.Logger.Debug("delete IAM roles and users")
+		attempted, err := o.deleteARNs(awsSession, arns)
+		if err != nil {
+			loopError = err
 		}
-		for _, arn := range arns {
-			if _, ok := deleted[arn]; !ok {
-				err = deleteARN(awsSession, arn, o.Logger)
-				if err != nil {
-					err = errors.Wrapf(err, "deleting %s", arn)
-					o.Logger.Debug(err)
-					loopError = err
-					continue
-				}
-				deleted[arn] = exists
-			}
+		if attempted == 0 && len(arns) > 0 {
+			// all the remaining resources are backing off
+			o.sleepUntil(o.nextAttempt())
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		if o.timedOut() {
+			return errors.Errorf("timed out with %d resources deleted", len(deleted))
 		}
+		err := o.deleteUntagged(awsSession)
+		if err == nil {
+			break
+		}
+		if attempt >= maxDeletionAttempts {
+			return errors.Wrapf(err, "gave up on deleting the untagged resources after %d attempts", attempt)
+		}
+		o.sleepUntil(time.Now().Add(deletionBackoff(attempt)))
 	}
 
+	var gaveUp []string
+	for arn := range o.attempts {
+		if o.exhausted(arn) {
+			gaveUp = append(gaveUp, arn)
+		}
+	}
+	if len(gaveUp) > 0 {
+		sort.Strings(gaveUp)
+		return errors.Errorf("gave up on deleting %d resources after %d attempts each: %s", len(gaveUp), maxDeletionAttempts, strings.Join(gaveUp, ", "))
+	}
 	return nil
 }
 
-func splitSlash(name string, input string) (base string, suffix string, err error) {
-	segments := strings.SplitN(input, "/", 2)
-	if len(segments) != 2 {
-		return "", "", errors.Errorf("%s %q does not contain the expected slash", name, input)
+// sleepUntil sleeps until the time, or the deadline if it is earlier.
+func (o *ClusterUninstaller) sleepUntil(t time.Time) {
+	if !o.deadline.IsZero() && o.deadline.Before(t) {
+		t = o.deadline
 	}
-	return segments[0], segments[1], nil
+	time.Sleep(time.Until(t))
 }
 
-func tagMatch(filters []Filter, tags map[string]string) bool {
-	for _, filter := range filters {
-		match := true
-		for filterKey, filterValue := range filter {
-			tagValue, ok := tags[filterKey]
-			if !ok {
-				match = false
+// deleteARNs deletes the resources in the order of the deletionStages and
+// the resources of each stage concurrently, and records the deleted ones.
+// Resources which are already gone count as deleted. It returns the number
+// of resources it tried to delete and the last error, after trying to
+// delete all the resources; the resources which failed, for example because
+// their dependents were not deleted yet, back off and are found again by
+// the next passes of Run until their retry budget is exhausted. Once the
+// deadline passed, it waits for the running deletions and starts no others.
+func (o *ClusterUninstaller) deleteARNs(awsSession *session.Session, arns []string) (int, error) {
+	attempted := 0
+	stages := make([][]string, len(deletionStages)+1)
+	for _, arn := range arns {
+		if !o.due(arn) {
+			continue
+		}
+		attempted++
+		stage := deletionStage(arn)
+		stages[stage] = append(stages[stage], arn)
+	}
+
+	var lock sync.Mutex
+	var lastError error
+	for _, stage := range stages {
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, maxConcurrentDeletions)
+		for _, arn := range stage {
+			if o.timedOut() {
 				break
 			}
-			if tagValue != filterValue {
-				match = false
-				break
+			wg.Add(1)
+			slots <- exists
+			go func(arn string) {
+				defer func() {
+					<-slots
+					wg.Done()
+				}()
+
+				err := deleteARN(awsSession, arn, o.Logger)
+				if err != nil && isNotFound(err) {
+					o.Logger.WithField("arn", arn).Info("Already deleted")
+					err = nil
+				}
+				lock.Lock()
+				defer lock.Unlock()
+				o.deletions[arn] = &Deletion{ARN: arn, Time: time.Now(), Error: err}
+				if err != nil {
+					err = errors.Wrapf(err, "deleting %s", arn)
+					if o.failed(arn) {
+						o.Logger.Info(errors.Wrap(err, "giving up"))
+					} else {
+						o.Logger.Debug(err)
+					}
+					lastError = err
+					return
+				}
+				o.deleted[arn] = exists
+			}(arn)
+		}
+		wg.Wait()
+	}
+	return attempted, lastError
+}
+
+// maxConcurrentDeletions bounds the concurrent deletions, so that large
+// clusters do not run into the request limits of the AWS APIs.
+const maxConcurrentDeletions = 10
+
+// deletionStages orders the deletion of the resources which others depend
+// on after those others, for example instances, network interfaces,
+// subnets and VPCs. The stages are lists of ARN services, or of services
+// and resource types like "ec2:instance". Resources which are not listed
+// are deleted in a last stage.
+var deletionStages = [][]string{
+	{"ec2:instance", "ec2:natgateway", "elasticloadbalancing", "route53", "s3"},
+	{"ec2:elastic-ip", "ec2:network-interface", "ec2:volume", "iam"},
+	{"ec2:internet-gateway", "ec2:route-table", "ec2:security-group", "ec2:subnet"},
+	{"ec2:vpc"},
+}
+
+// deletionStage returns the index of the deletion stage of the resource.
+func deletionStage(arnString string) int {
+	parsed, err := arn.Parse(arnString)
+	if err != nil {
+		return len(deletionStages)
+	}
+	resourceType := strings.SplitN(parsed.Resource, "/", 2)[0]
+	for i, stage := range deletionStages {
+		for _, entry := range stage {
+			if entry == parsed.Service || entry == parsed.Service+":"+resourceType {
+				return i
 			}
 		}
-		if match {
+	}
+	return len(deletionStages)
+}
+
+// tagged returns the ARNs of the resources which the tagging client finds
+// for any of the filters, and separately those of the matching resources
+// which are shared with other clusters, such as the VPC, subnets and hosted
+// zones of clusters installed into existing networks. The shared resources
+// are marked with a "shared" value for the cluster's
+// kubernetes.io/cluster/<name> tag, and must not be deleted.
+func (o *ClusterUninstaller) tagged(tagClient *resourcegroupstaggingapi.ResourceGroupsTaggingAPI, tagClientName string) (arns []string, shared []string, err error) {
+	sharedTag := fmt.Sprintf("kubernetes.io/cluster/%s", o.ClusterName)
+	for _, filter := range o.Filters {
+		o.Logger.Debugf("search for matching resources by tag in %s matching %#+v", tagClientName, filter)
+		tagFilters := make([]*resourcegroupstaggingapi.TagFilter, 0, len(filter))
+		for key, value := range filter {
+			tagFilters = append(tagFilters, &resourcegroupstaggingapi.TagFilter{
+				Key:    aws.String(key),
+				Values: []*string{aws.String(value)},
+			})
+		}
+		err = tagClient.GetResourcesPages(
+			&resourcegroupstaggingapi.GetResourcesInput{TagFilters: tagFilters},
+			func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+				for _, resource := range results.ResourceTagMappingList {
+					if isShared(resource.Tags, sharedTag) {
+						shared = append(shared, *resource.ResourceARN)
+					} else {
+						arns = append(arns, *resource.ResourceARN)
+					}
+				}
+				return !lastPage
+			},
+		)
+		if err != nil {
+			return arns, shared, errors.Wrapf(err, "get tagged resources in %s", tagClientName)
+		}
+	}
+	return arns, shared, nil
+}
+
+func isShared(tags []*resourcegroupstaggingapi.Tag, sharedTag string) bool {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == sharedTag && aws.StringValue(tag.Value) == "shared" {
 			return true
 		}
 	}
-	return len(filters) == 0
+	return false
+}
+
+func (o *ClusterUninstaller) session() (*session.Session, error) {
+	awsConfig := &aws.Config{Region: aws.String(o.Region)}
+	if o.Profile == "" {
+		// Relying on appropriate AWS ENV vars (eg AWS_PROFILE, AWS_ACCESS_KEY_ID, etc)
+		return session.NewSession(awsConfig)
+	}
+
+	return session.NewSessionWithOptions(session.Options{
+		Config:            *awsConfig,
+		Profile:           o.Profile,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+}
+
+// tagClients returns the tagging clients for the cluster's region and, if
+// that is not us-east-1, for us-east-1, where global resources like
+// Route 53 hosted zones are tagged. The clients' region names are
+// returned too.
+func (o *ClusterUninstaller) tagClients(awsSession *session.Session) ([]*resourcegroupstaggingapi.ResourceGroupsTaggingAPI, map[*resourcegroupstaggingapi.ResourceGroupsTaggingAPI]string) {
+	tagClients := []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI{
+		resourcegroupstaggingapi.New(awsSession),
+	}
+	tagClientNames := map[*resourcegroupstaggingapi.ResourceGroupsTaggingAPI]string{
+		tagClients[0]: o.Region,
+	}
+	if o.Region != "us-east-1" {
+		tagClient := resourcegroupstaggingapi.New(
+			awsSession, aws.NewConfig().WithRegion("us-east-1"),
+		)
+		tagClients = append(tagClients, tagClient)
+		tagClientNames[tagClient] = "us-east-1"
+	}
+	return tagClients, tagClientNames
+}
+
+func splitSlash(name string, input string) (base string, suffix string, err error) {
+	segments := strings.SplitN(input, "/", 2)
+	if len(segments) != 2 {
+		return "", "", errors.Errorf("%s %q does not contain the expected slash", name, input)
+	}
+	return segments[0], segments[1], nil
 }
 
 type iamRoleSearch struct {
@@ -257,7 +494,7 @@ func (search *iamRoleSearch) arns() ([]string, error) {
 					for _, tag := range role.Tags {
 						tags[*tag.Key] = *tag.Value
 					}
-					if tagMatch(search.filters, tags) {
+					if destroy.MatchAny(search.filters, tags) {
 						arns = append(arns, *role.Arn)
 					} else {
 						search.unmatched[*role.Arn] = exists
@@ -313,7 +550,7 @@ func (search *iamUserSearch) arns() ([]string, error) {
 					for _, tag := range user.Tags {
 						tags[*tag.Key] = *tag.Value
 					}
-					if tagMatch(search.filters, tags) {
+					if destroy.MatchAny(search.filters, tags) {
 						arns = append(arns, *user.Arn)
 					} else {
 						search.unmatched[*user.Arn] = exists
@@ -412,12 +649,16 @@ func deleteEC2(session *session.Session, arn arn.ARN, logger logrus.FieldLogger)
 	switch resourceType {
 	case "elastic-ip":
 		return deleteEC2ElasticIP(client, id, logger)
+	case "image":
+		return deleteEC2Image(client, id, logger)
 	case "instance":
 		return deleteEC2Instance(client, iam.New(session), id, logger)
 	case "internet-gateway":
 		return deleteEC2InternetGateway(client, id, logger)
 	case "natgateway":
 		return deleteEC2NATGateway(client, id, logger)
+	case "network-interface":
+		return deleteEC2NetworkInterface(client, id, logger)
 	case "route-table":
 		return deleteEC2RouteTable(client, id, logger)
 	case "security-group":
@@ -448,6 +689,44 @@ func deleteEC2ElasticIP(client *ec2.EC2, id string, logger logrus.FieldLogger) e
 	return nil
 }
 
+// deleteEC2Image deregisters the AMI and deletes its EBS snapshots, which
+// are not tagged themselves.
+func deleteEC2Image(client *ec2.EC2, id string, logger logrus.FieldLogger) error {
+	response, err := client.DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds: []*string{aws.String(id)},
+	})
+	if err != nil {
+		if err.(awserr.Error).Code() == "InvalidAMIID.NotFound" {
+			return nil
+		}
+		return err
+	}
+
+	for _, image := range response.Images {
+		_, err = client.DeregisterImage(&ec2.DeregisterImageInput{
+			ImageId: image.ImageId,
+		})
+		if err != nil {
+			return err
+		}
+		logger.Info("Deregistered")
+
+		for _, mapping := range image.BlockDeviceMappings {
+			if mapping.Ebs == nil || mapping.Ebs.SnapshotId == nil {
+				continue
+			}
+			_, err = client.DeleteSnapshot(&ec2.DeleteSnapshotInput{
+				SnapshotId: mapping.Ebs.SnapshotId,
+			})
+			if err != nil && err.(awserr.Error).Code() != "InvalidSnapshot.NotFound" {
+				return err
+			}
+			logger.WithField("snapshot", *mapping.Ebs.SnapshotId).Info("Deleted")
+		}
+	}
+	return nil
+}
+
 func deleteEC2Instance(ec2Client *ec2.EC2, iamClient *iam.IAM, id string, logger logrus.FieldLogger) error {
 	response, err := ec2Client.DescribeInstances(&ec2.DescribeInstancesInput{
 		InstanceIds: []*string{aws.String(id)},
@@ -485,6 +764,16 @@ func deleteEC2Instance(ec2Client *ec2.EC2, iamClient *iam.IAM, id string, logger
 				return err
 			}
 
+			// Wait for the termination, so that the network interfaces,
+			// subnets and security groups of the instance can be deleted
+			// in the later deletion stages.
+			err = ec2Client.WaitUntilInstanceTerminated(&ec2.DescribeInstancesInput{
+				InstanceIds: []*string{instance.InstanceId},
+			})
+			if err != nil {
+				return err
+			}
+
 			logger.Info("Deleted")
 		}
 	}
@@ -537,6 +826,21 @@ func deleteEC2NATGateway(client *ec2.EC2, id string, logger logrus.FieldLogger)
 	return nil
 }
 
+func deleteEC2NetworkInterface(client *ec2.EC2, id string, logger logrus.FieldLogger) error {
+	_, err := client.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{
+		NetworkInterfaceId: aws.String(id),
+	})
+	if err != nil {
+		if err.(awserr.Error).Code() == "InvalidNetworkInterfaceID.NotFound" {
+			return nil
+		}
+		return err
+	}
+
+	logger.Info("Deleted")
+	return nil
+}
+
 func deleteEC2RouteTable(client *ec2.EC2, id string, logger logrus.FieldLogger) error {
 	response, err := client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
 		RouteTableIds: []*string{aws.String(id)},
@@ -951,7 +1255,9 @@ func deleteIAM(session *session.Session, arn arn.ARN, logger logrus.FieldLogger)
 	case "role":
 		return deleteIAMRole(client, arn, logger)
 	case "user":
-		return deleteIAMUser(client, id, logger)
+		// Users minted with a path, like user/openshift/name, are
+		// named by the last segment.
+		return deleteIAMUser(client, id[strings.LastIndex(id, "/")+1:], logger)
 	default:
 		return errors.Errorf("unrecognized EC2 resource type %s", resourceType)
 	}
@@ -1077,19 +1383,24 @@ func deleteIAMRole(client *iam.IAM, roleARN arn.ARN, logger logrus.FieldLogger)
 	return nil
 }
 
+// deleteIAMUser deletes a user, such as those which the cloud-credential
+// operator mints for the components which need cloud credentials, after
+// removing its inline and attached policies, group memberships, access keys
+// and login profile, without which IAM refuses to delete it.
 func deleteIAMUser(client *iam.IAM, id string, logger logrus.FieldLogger) error {
 	var lastError error
 	err := client.ListUserPoliciesPages(
 		&iam.ListUserPoliciesInput{UserName: &id},
 		func(results *iam.ListUserPoliciesOutput, lastPage bool) bool {
 			for _, policy := range results.PolicyNames {
-				_, lastError = client.DeleteUserPolicy(&iam.DeleteUserPolicyInput{
+				_, err := client.DeleteUserPolicy(&iam.DeleteUserPolicyInput{
 					UserName:   &id,
 					PolicyName: policy,
 				})
-				if lastError != nil {
-					lastError = errors.Wrapf(lastError, "deleting IAM user policy %s", *policy)
+				if err != nil {
+					lastError = errors.Wrapf(err, "deleting IAM user policy %s", *policy)
 					logger.Info(lastError)
+					continue
 				}
 				logger.WithField("policy", *policy).Info("Deleted")
 			}
@@ -1102,21 +1413,78 @@ func deleteIAMUser(client *iam.IAM, id string, logger logrus.FieldLogger) error
 		return lastError
 	}
 	if err != nil {
+		if err.(awserr.Error).Code() == iam.ErrCodeNoSuchEntityException {
+			return nil
+		}
 		return errors.Wrap(err, "listing IAM user policies")
 	}
 
+	err = client.ListAttachedUserPoliciesPages(
+		&iam.ListAttachedUserPoliciesInput{UserName: &id},
+		func(results *iam.ListAttachedUserPoliciesOutput, lastPage bool) bool {
+			for _, policy := range results.AttachedPolicies {
+				_, err := client.DetachUserPolicy(&iam.DetachUserPolicyInput{
+					UserName:  &id,
+					PolicyArn: policy.PolicyArn,
+				})
+				if err != nil {
+					lastError = errors.Wrapf(err, "detaching IAM user policy %s", *policy.PolicyArn)
+					logger.Info(lastError)
+					continue
+				}
+				logger.WithField("policy", *policy.PolicyArn).Info("Detached")
+			}
+
+			return !lastPage
+		},
+	)
+
+	if lastError != nil {
+		return lastError
+	}
+	if err != nil {
+		return errors.Wrap(err, "listing attached IAM user policies")
+	}
+
+	err = client.ListGroupsForUserPages(
+		&iam.ListGroupsForUserInput{UserName: &id},
+		func(results *iam.ListGroupsForUserOutput, lastPage bool) bool {
+			for _, group := range results.Groups {
+				_, err := client.RemoveUserFromGroup(&iam.RemoveUserFromGroupInput{
+					UserName:  &id,
+					GroupName: group.GroupName,
+				})
+				if err != nil {
+					lastError = errors.Wrapf(err, "removing IAM user from group %s", *group.GroupName)
+					logger.Info(lastError)
+				}
+			}
+
+			return !lastPage
+		},
+	)
+
+	if lastError != nil {
+		return lastError
+	}
+	if err != nil {
+		return errors.Wrap(err, "listing IAM user groups")
+	}
+
 	err = client.ListAccessKeysPages(
 		&iam.ListAccessKeysInput{UserName: &id},
 		func(results *iam.ListAccessKeysOutput, lastPage bool) bool {
 			for _, key := range results.AccessKeyMetadata {
-				_, lastError := client.DeleteAccessKey(&iam.DeleteAccessKeyInput{
+				_, err := client.DeleteAccessKey(&iam.DeleteAccessKeyInput{
 					UserName:    &id,
 					AccessKeyId: key.AccessKeyId,
 				})
-				if lastError != nil {
-					lastError = errors.Wrapf(lastError, "deleting IAM access key %s", *key.AccessKeyId)
+				if err != nil {
+					lastError = errors.Wrapf(err, "deleting IAM access key %s", *key.AccessKeyId)
 					logger.Info(lastError)
+					continue
 				}
+				logger.WithField("access key", *key.AccessKeyId).Info("Deleted")
 			}
 
 			return !lastPage
@@ -1130,10 +1498,20 @@ func deleteIAMUser(client *iam.IAM, id string, logger logrus.FieldLogger) error
 		return errors.Wrap(err, "listing IAM access keys")
 	}
 
+	_, err = client.DeleteLoginProfile(&iam.DeleteLoginProfileInput{
+		UserName: &id,
+	})
+	if err != nil && err.(awserr.Error).Code() != iam.ErrCodeNoSuchEntityException {
+		return errors.Wrap(err, "deleting IAM login profile")
+	}
+
 	_, err = client.DeleteUser(&iam.DeleteUserInput{
 		UserName: &id,
 	})
 	if err != nil {
+		if err.(awserr.Error).Code() == iam.ErrCodeNoSuchEntityException {
+			return nil
+		}
 		return err
 	}
 
@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletionStage(t *testing.T) {
+	cases := []struct {
+		arn      string
+		expected int
+	}{
+		{arn: "arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789", expected: 0},
+		{arn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/test-int/0123456789", expected: 0},
+		{arn: "arn:aws:s3:::openshift-bootstrap-data-test", expected: 0},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:network-interface/eni-0123456789", expected: 1},
+		{arn: "arn:aws:iam::123456789012:role/test-master-role", expected: 1},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:subnet/subnet-0123456789", expected: 2},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:security-group/sg-0123456789", expected: 2},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123456789", expected: 3},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:dhcp-options/dopt-0123456789", expected: len(deletionStages)},
+		{arn: "not an arn", expected: len(deletionStages)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.arn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, deletionStage(tc.arn))
+		})
+	}
+}
+
+// TestDeletionStageOrder checks that the resources which others depend on
+// are deleted after them.
+func TestDeletionStageOrder(t *testing.T) {
+	before := func(dependent, dependency string) {
+		assert.True(t, deletionStage(dependent) < deletionStage(dependency), "%s before %s", dependent, dependency)
+	}
+	instance := "arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789"
+	natGateway := "arn:aws:ec2:us-east-1:123456789012:natgateway/nat-0123456789"
+	eip := "arn:aws:ec2:us-east-1:123456789012:elastic-ip/eipalloc-0123456789"
+	eni := "arn:aws:ec2:us-east-1:123456789012:network-interface/eni-0123456789"
+	subnet := "arn:aws:ec2:us-east-1:123456789012:subnet/subnet-0123456789"
+	securityGroup := "arn:aws:ec2:us-east-1:123456789012:security-group/sg-0123456789"
+	vpc := "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123456789"
+
+	before(instance, eni)
+	before(natGateway, eip)
+	before(eni, subnet)
+	before(eni, securityGroup)
+	before(subnet, vpc)
+	before(securityGroup, vpc)
+}
@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxDeletionAttempts is the retry budget of each resource, after
+	// which Run gives up on it instead of retrying it forever.
+	maxDeletionAttempts = 25
+
+	// deletionBackoffBase and deletionBackoffCap bound the exponential
+	// backoff between the attempts to delete a resource.
+	deletionBackoffBase = 2 * time.Second
+	deletionBackoffCap  = 2 * time.Minute
+)
+
+// attempts are the failed attempts to delete a resource.
+type attempts struct {
+	count int
+
+	// next is when the resource is retried.
+	next time.Time
+}
+
+// due returns true if the resource may be deleted now: it did not fail
+// yet, or its backoff passed and its retry budget is not exhausted.
+func (o *ClusterUninstaller) due(arn string) bool {
+	a, ok := o.attempts[arn]
+	return !ok || (a.count < maxDeletionAttempts && !time.Now().Before(a.next))
+}
+
+// exhausted returns true if Run gave up on the resource.
+func (o *ClusterUninstaller) exhausted(arn string) bool {
+	a, ok := o.attempts[arn]
+	return ok && a.count >= maxDeletionAttempts
+}
+
+// failed records a failed attempt to delete the resource, and returns
+// whether its retry budget is exhausted.
+func (o *ClusterUninstaller) failed(arn string) bool {
+	a, ok := o.attempts[arn]
+	if !ok {
+		a = &attempts{}
+		o.attempts[arn] = a
+	}
+	a.count++
+	a.next = time.Now().Add(deletionBackoff(a.count))
+	return a.count >= maxDeletionAttempts
+}
+
+// deletionBackoff returns the delay after the given number of failed
+// attempts, which doubles from deletionBackoffBase up to deletionBackoffCap.
+func deletionBackoff(count int) time.Duration {
+	delay := deletionBackoffBase << uint(count-1)
+	if delay > deletionBackoffCap || delay <= 0 {
+		return deletionBackoffCap
+	}
+	return delay
+}
+
+// nextAttempt returns when the first of the resources which are backing
+// off is retried, or the zero time if none are.
+func (o *ClusterUninstaller) nextAttempt() time.Time {
+	var next time.Time
+	for _, a := range o.attempts {
+		if a.count < maxDeletionAttempts && (next.IsZero() || a.next.Before(next)) {
+			next = a.next
+		}
+	}
+	return next
+}
+
+// isNotFound returns true for the errors of resources which are already
+// gone, for example because they were deleted by hand, so that deleting
+// them counts as done instead of being retried.
+func isNotFound(err error) bool {
+	err = errors.Cause(err)
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		switch reqErr.StatusCode() {
+		case http.StatusNotFound, http.StatusGone:
+			return true
+		}
+	}
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+	switch code := awsErr.Code(); code {
+	case "NotFound", "NoSuchEntity", "NoSuchBucket", "NoSuchHostedZone", "LoadBalancerNotFound", "TargetGroupNotFound":
+		return true
+	default:
+		return strings.HasSuffix(code, ".NotFound")
+	}
+}
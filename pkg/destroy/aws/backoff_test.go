@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletionBackoff(t *testing.T) {
+	cases := []struct {
+		count    int
+		expected time.Duration
+	}{
+		{count: 1, expected: 2 * time.Second},
+		{count: 2, expected: 4 * time.Second},
+		{count: 6, expected: 64 * time.Second},
+		{count: 7, expected: 2 * time.Minute},
+		{count: maxDeletionAttempts, expected: 2 * time.Minute},
+		{count: 100, expected: 2 * time.Minute},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprint(tc.count), func(t *testing.T) {
+			assert.Equal(t, tc.expected, deletionBackoff(tc.count))
+		})
+	}
+}
+
+func TestDeletionAttempts(t *testing.T) {
+	o := &ClusterUninstaller{attempts: map[string]*attempts{}}
+	arn := "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123456789"
+	assert.True(t, o.due(arn))
+	assert.True(t, o.nextAttempt().IsZero())
+
+	assert.False(t, o.failed(arn))
+	assert.False(t, o.due(arn), "backing off")
+	assert.False(t, o.exhausted(arn))
+	assert.False(t, o.nextAttempt().IsZero())
+
+	o.attempts[arn].next = time.Now().Add(-time.Second)
+	assert.True(t, o.due(arn), "backed off")
+
+	for i := 2; i < maxDeletionAttempts; i++ {
+		o.failed(arn)
+	}
+	assert.True(t, o.failed(arn))
+	assert.True(t, o.exhausted(arn))
+	o.attempts[arn].next = time.Now().Add(-time.Second)
+	assert.False(t, o.due(arn), "budget exhausted")
+	assert.True(t, o.nextAttempt().IsZero())
+}
+
+func TestIsNotFound(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name: "other error",
+			err:  errors.New("timeout"),
+		},
+		{
+			name: "other code",
+			err:  awserr.New("DependencyViolation", "the vpc has dependencies", nil),
+		},
+		{
+			name:     "not found code",
+			err:      awserr.New("NoSuchEntity", "the role was not found", nil),
+			expected: true,
+		},
+		{
+			name:     "not found suffix",
+			err:      awserr.New("InvalidVpcID.NotFound", "the vpc was not found", nil),
+			expected: true,
+		},
+		{
+			name:     "gone status",
+			err:      awserr.NewRequestFailure(awserr.New("Gone", "the bucket is gone", nil), http.StatusGone, "request-id"),
+			expected: true,
+		},
+		{
+			name: "forbidden status",
+			err:  awserr.NewRequestFailure(awserr.New("AccessDenied", "access denied", nil), http.StatusForbidden, "request-id"),
+		},
+		{
+			name:     "wrapped",
+			err:      errors.Wrap(awserr.New("NoSuchHostedZone", "the zone was not found", nil), "delete the hosted zone"),
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isNotFound(tc.err))
+		})
+	}
+}
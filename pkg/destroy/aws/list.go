@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// ARNs returns the ARNs of the resources which match the filters, and
+// which Run would delete along with the resources they contain, such as
+// the records in hosted zones and the objects in buckets. Shared resources,
+// which Run skips, are left out.
+func (o *ClusterUninstaller) ARNs() ([]string, error) {
+	err := o.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	awsSession, err := o.session()
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]struct{}{}
+	var arns []string
+	add := func(arn string) {
+		if _, ok := found[arn]; !ok {
+			found[arn] = exists
+			arns = append(arns, arn)
+		}
+	}
+
+	tagClients, tagClientNames := o.tagClients(awsSession)
+	for _, tagClient := range tagClients {
+		tagged, shared, err := o.tagged(tagClient, tagClientNames[tagClient])
+		if err != nil {
+			return nil, err
+		}
+		for _, arn := range shared {
+			o.Logger.Debugf("skip shared resource %s", arn)
+		}
+		for _, arn := range tagged {
+			if !o.outOfScope(arn) {
+				add(arn)
+			}
+		}
+	}
+
+	iamClient := iam.New(awsSession)
+	o.Logger.Debug("search for IAM roles")
+	roleARNs, err := (&iamRoleSearch{client: iamClient, filters: o.Filters, logger: o.Logger}).arns()
+	if err != nil {
+		return nil, err
+	}
+	o.Logger.Debug("search for IAM users")
+	userARNs, err := (&iamUserSearch{client: iamClient, filters: o.Filters, logger: o.Logger}).arns()
+	if err != nil {
+		return nil, err
+	}
+	for _, arn := range append(roleARNs, userARNs...) {
+		if !o.outOfScope(arn) {
+			add(arn)
+		}
+	}
+
+	if bucket := o.bootstrapBucketARN(s3.New(awsSession)); bucket != "" && !o.outOfScope(bucket) {
+		add(bucket)
+	}
+
+	return arns, nil
+}
@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// progress is the state of Run which is kept between runs.
+type progress struct {
+	Deleted      []string `json:"deleted"`
+	IAMUnmatched []string `json:"iamUnmatched"`
+}
+
+// SetDeadline makes Run stop with an error once the deadline passed, after
+// waiting for the deletions which are running.
+func (o *ClusterUninstaller) SetDeadline(deadline time.Time) {
+	o.deadline = deadline
+}
+
+func (o *ClusterUninstaller) timedOut() bool {
+	return !o.deadline.IsZero() && time.Now().After(o.deadline)
+}
+
+// Progress returns the resources which Run deleted, and the IAM roles and
+// users which it found not to belong to the cluster.
+func (o *ClusterUninstaller) Progress() ([]byte, error) {
+	return json.Marshal(&progress{
+		Deleted:      sortedKeys(o.deleted),
+		IAMUnmatched: sortedKeys(o.iamUnmatched),
+	})
+}
+
+// Resume makes Run continue from the progress of an earlier Run, so that it
+// neither retries the resources which were deleted, some of which the
+// tagging API still lists for a while, nor fetches the tags of the IAM roles
+// and users which do not belong to the cluster again.
+func (o *ClusterUninstaller) Resume(data []byte) error {
+	var p progress
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	o.deleted = setOf(p.Deleted)
+	o.iamUnmatched = setOf(p.IAMUnmatched)
+	return nil
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func setOf(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		set[key] = exists
+	}
+	return set
+}
@@ -0,0 +1,37 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress(t *testing.T) {
+	o := &ClusterUninstaller{
+		deleted: setOf([]string{
+			"arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123456789",
+			"arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789",
+		}),
+		iamUnmatched: setOf([]string{"arn:aws:iam::123456789012:role/other"}),
+	}
+	data, err := o.Progress()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `{"deleted":["arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789","arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123456789"],"iamUnmatched":["arn:aws:iam::123456789012:role/other"]}`, string(data))
+
+	resumed := &ClusterUninstaller{}
+	if assert.NoError(t, resumed.Resume(data)) {
+		assert.Equal(t, o.deleted, resumed.deleted)
+		assert.Equal(t, o.iamUnmatched, resumed.iamUnmatched)
+	}
+
+	assert.Error(t, resumed.Resume([]byte("deleted")))
+}
+
+func TestEmptyProgress(t *testing.T) {
+	data, err := (&ClusterUninstaller{}).Progress()
+	if assert.NoError(t, err) {
+		assert.Equal(t, `{"deleted":[],"iamUnmatched":[]}`, string(data))
+	}
+}
@@ -0,0 +1,92 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/destroy"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// New returns an AWS destroyer from ClusterMetadata.
+func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (destroy.Destroyer, error) {
+	filters := make([]Filter, 0, len(metadata.ClusterPlatformMetadata.AWS.Identifier))
+	for _, filter := range metadata.ClusterPlatformMetadata.AWS.Identifier {
+		filters = append(filters, filter)
+	}
+
+	return &ClusterUninstaller{
+		Filters:     filters,
+		Region:      metadata.ClusterPlatformMetadata.AWS.Region,
+		ClusterName: metadata.ClusterName,
+		ClusterID:   metadata.ClusterID,
+		BaseDomain:  metadata.ClusterPlatformMetadata.AWS.BaseDomain,
+		Logger:      logger,
+	}, nil
+}
+
+// List returns the resources the destroyer would delete.
+func (o *ClusterUninstaller) List() ([]destroy.Resource, error) {
+	arns, err := o.ARNs()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]destroy.Resource, 0, len(arns))
+	for _, arn := range arns {
+		resources = append(resources, destroy.Resource{Type: arnType(arn), ID: arn})
+	}
+	return resources, nil
+}
+
+// Report returns the resources which Run deleted, skipped, or failed to
+// delete.
+func (o *ClusterUninstaller) Report() *destroy.Report {
+	report := &destroy.Report{}
+	for _, deletion := range o.Deletions() {
+		entry := destroy.ReportEntry{
+			Resource: destroy.Resource{Type: arnType(deletion.ARN), ID: deletion.ARN},
+			Time:     deletion.Time,
+		}
+		if parsed, err := arn.Parse(deletion.ARN); err == nil {
+			entry.Region = parsed.Region
+		}
+		switch {
+		case deletion.Skipped != "":
+			entry.Reason = deletion.Skipped
+			report.Skipped = append(report.Skipped, entry)
+		case deletion.Error != nil:
+			entry.Reason = deletion.Error.Error()
+			report.Failed = append(report.Failed, entry)
+		default:
+			report.Deleted = append(report.Deleted, entry)
+		}
+	}
+	return report
+}
+
+// SetCredentials makes Run use the named profile of the shared AWS
+// credentials.
+func (o *ClusterUninstaller) SetCredentials(name string) {
+	o.Profile = name
+}
+
+// arnType returns the service and, if there is one, the resource type of
+// an ARN, such as "ec2:instance".
+func arnType(arnString string) string {
+	parsed, err := arn.Parse(arnString)
+	if err != nil {
+		return "unknown"
+	}
+
+	if i := strings.IndexAny(parsed.Resource, "/:"); i > 0 {
+		return parsed.Service + ":" + parsed.Resource[:i]
+	}
+	return parsed.Service
+}
+
+func init() {
+	destroy.Register("aws", New)
+}
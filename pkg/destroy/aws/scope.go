@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"time"
+
+	"github.com/openshift/installer/pkg/destroy"
+)
+
+// typeAliases are the friendlier names of the resource types which can be
+// passed to ScopeTypes besides the ARN types, such as "ec2:instance" or
+// "elasticloadbalancing", which destroy cluster --dry-run lists.
+var typeAliases = map[string][]string{
+	"buckets":        {"s3"},
+	"dns":            {"route53"},
+	"instances":      {"ec2:instance"},
+	"loadbalancers":  {"elasticloadbalancing"},
+	"natgateways":    {"ec2:natgateway"},
+	"securitygroups": {"ec2:security-group"},
+	"volumes":        {"ec2:volume"},
+}
+
+// ScopeTypes limits Run and List to the resources of the included types,
+// or of all types if none are included, except those of the excluded types.
+func (o *ClusterUninstaller) ScopeTypes(include, exclude []string) {
+	o.includeTypes = expandTypeAliases(include)
+	o.excludeTypes = expandTypeAliases(exclude)
+}
+
+func expandTypeAliases(types []string) []string {
+	var expanded []string
+	for _, t := range types {
+		if aliased, ok := typeAliases[t]; ok {
+			expanded = append(expanded, aliased...)
+		} else {
+			expanded = append(expanded, t)
+		}
+	}
+	return expanded
+}
+
+// typeInScope returns true if resources of the type may be deleted.
+func (o *ClusterUninstaller) typeInScope(resourceType string) bool {
+	if len(o.includeTypes) > 0 && !destroy.MatchType(resourceType, o.includeTypes) {
+		return false
+	}
+	return !destroy.MatchType(resourceType, o.excludeTypes)
+}
+
+// outOfScope returns true if the resource is not of the types to which
+// Run is scoped, and records it as skipped during Run.
+func (o *ClusterUninstaller) outOfScope(arn string) bool {
+	if o.typeInScope(arnType(arn)) {
+		return false
+	}
+	if o.deletions != nil {
+		if _, ok := o.deletions[arn]; !ok {
+			o.Logger.WithField("arn", arn).Debug("Skipping resource of an unselected type")
+			o.deletions[arn] = &Deletion{ARN: arn, Time: time.Now(), Skipped: "not of the selected resource types"}
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandTypeAliases(t *testing.T) {
+	assert.Nil(t, expandTypeAliases(nil))
+	assert.Equal(t,
+		[]string{"ec2:instance", "ec2:vpc", "elasticloadbalancing", "s3"},
+		expandTypeAliases([]string{"instances", "ec2:vpc", "loadbalancers", "buckets"}),
+	)
+}
+
+func TestTypeInScope(t *testing.T) {
+	cases := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		inScope  []string
+		outScope []string
+	}{
+		{
+			name:    "all",
+			inScope: []string{"ec2:instance", "route53", "s3"},
+		},
+		{
+			name:     "include",
+			include:  []string{"instances", "ec2:vpc", "route53"},
+			inScope:  []string{"ec2:instance", "ec2:vpc", "route53", "route53:hostedzone"},
+			outScope: []string{"ec2:volume", "s3", "ec2"},
+		},
+		{
+			name:     "exclude",
+			exclude:  []string{"buckets", "ec2:volume"},
+			inScope:  []string{"ec2:instance", "route53"},
+			outScope: []string{"s3", "ec2:volume"},
+		},
+		{
+			name:     "include and exclude",
+			include:  []string{"ec2"},
+			exclude:  []string{"natgateways"},
+			inScope:  []string{"ec2:instance", "ec2:vpc"},
+			outScope: []string{"ec2:natgateway", "s3"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := &ClusterUninstaller{}
+			o.ScopeTypes(tc.include, tc.exclude)
+			for _, resourceType := range tc.inScope {
+				assert.True(t, o.typeInScope(resourceType), resourceType)
+			}
+			for _, resourceType := range tc.outScope {
+				assert.False(t, o.typeInScope(resourceType), resourceType)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+
+	awstfvars "github.com/openshift/installer/pkg/tfvars/aws"
+)
+
+// deleteUntagged deletes the resources of the cluster which are found by
+// their names instead of their tags, because they cannot be tagged or their
+// tags may be missing: the records of the cluster in the public hosted zone
+// of the base domain, which the private zone does not mirror once it is
+// gone, and the bootstrap Ignition bucket, which leaks when the cluster is
+// destroyed before its tags are applied and then blocks reinstalls.
+func (o *ClusterUninstaller) deleteUntagged(awsSession *session.Session) error {
+	var lastError error
+	if o.BaseDomain != "" && o.typeInScope("route53:recordset") {
+		if err := o.deletePublicRecords(route53.New(awsSession)); err != nil {
+			o.Logger.Info(err)
+			lastError = err
+		}
+	}
+
+	if bucket := o.bootstrapBucketARN(s3.New(awsSession)); bucket != "" && !o.outOfScope(bucket) {
+		if _, ok := o.deleted[bucket]; !ok {
+			err := deleteARN(awsSession, bucket, o.Logger)
+			if err != nil && isNotFound(err) {
+				o.Logger.WithField("arn", bucket).Info("Already deleted")
+				err = nil
+			}
+			o.deletions[bucket] = &Deletion{ARN: bucket, Time: time.Now(), Error: err}
+			if err != nil {
+				err = errors.Wrapf(err, "deleting %s", bucket)
+				o.Logger.Info(err)
+				lastError = err
+			} else {
+				o.deleted[bucket] = exists
+			}
+		}
+	}
+
+	return lastError
+}
+
+// deletePublicRecords deletes the records of the public hosted zone of the
+// base domain which belong to the cluster: the API record and the records
+// in the cluster's subdomain, such as those of its routes.
+func (o *ClusterUninstaller) deletePublicRecords(client *route53.Route53) error {
+	zoneID, err := publicZoneID(client, o.BaseDomain)
+	if err != nil {
+		return errors.Wrapf(err, "find the public zone of %s", o.BaseDomain)
+	}
+	if zoneID == "" {
+		return nil
+	}
+
+	baseDomain := strings.TrimSuffix(o.BaseDomain, ".") + "."
+	apiName := fmt.Sprintf("%s-api.%s", o.ClusterName, baseDomain)
+	clusterSuffix := fmt.Sprintf(".%s.%s", o.ClusterName, baseDomain)
+	logger := o.Logger.WithField("public zone", zoneID)
+
+	var lastError error
+	err = client.ListResourceRecordSetsPages(
+		&route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(zoneID)},
+		func(results *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+			for _, recordSet := range results.ResourceRecordSets {
+				name := *recordSet.Name
+				if name != apiName && !strings.HasSuffix(name, clusterSuffix) {
+					continue
+				}
+				if err := deleteRoute53RecordSet(client, zoneID, recordSet, logger); err != nil {
+					lastError = errors.Wrapf(err, "deleting record set %s %s from public zone %s", *recordSet.Type, name, zoneID)
+					logger.Info(lastError)
+				}
+			}
+			return !lastPage
+		},
+	)
+	if lastError != nil {
+		return lastError
+	}
+	return err
+}
+
+// publicZoneID returns the ID of the public hosted zone of the domain, or
+// an empty string if it has none.
+func publicZoneID(client *route53.Route53, domain string) (string, error) {
+	name := strings.TrimSuffix(domain, ".") + "."
+	var id string
+	err := client.ListHostedZonesPages(&route53.ListHostedZonesInput{}, func(results *route53.ListHostedZonesOutput, lastPage bool) bool {
+		for _, zone := range results.HostedZones {
+			if *zone.Name == name && !*zone.Config.PrivateZone {
+				id = *zone.Id
+				return false
+			}
+		}
+		return !lastPage
+	})
+	return id, err
+}
+
+// bootstrapBucketARN returns the ARN of the bootstrap Ignition bucket of the
+// cluster, or an empty string if it does not exist or the cluster ID is not
+// known.
+func (o *ClusterUninstaller) bootstrapBucketARN(client *s3.S3) string {
+	if o.ClusterID == "" {
+		return ""
+	}
+	bucket := awstfvars.BootstrapIgnitionBucket(o.ClusterID)
+	_, err := client.HeadBucket(&s3.HeadBucketInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); !ok || (awsErr.Code() != "NotFound" && awsErr.Code() != s3.ErrCodeNoSuchBucket) {
+			o.Logger.Debug(errors.Wrapf(err, "look up bucket %s", bucket))
+		}
+		return ""
+	}
+	return fmt.Sprintf("arn:aws:s3:::%s", bucket)
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/cluster"
 	"github.com/openshift/installer/pkg/terraform"
 	"github.com/pkg/errors"
@@ -24,6 +25,16 @@ func Destroy(dir string) (err error) {
 		return errors.New("no platform configured in metadata")
 	}
 
+	if stage, ok := terraform.LookupStage(platform, "bootstrap"); ok {
+		if _, err := os.Stat(filepath.Join(dir, stage.StateFileName())); err == nil {
+			return destroyStage(dir, platform, stage)
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+		// The cluster was created before its platform was staged, so
+		// the bootstrap resources are in the state of the whole cluster.
+	}
+
 	copyNames := []string{terraform.StateFileName, cluster.TfVarsFileName}
 
 	if platform == "libvirt" {
@@ -44,7 +55,7 @@ func Destroy(dir string) (err error) {
 	defer os.RemoveAll(tempDir)
 
 	for _, filename := range copyNames {
-		err = copy(filepath.Join(dir, filename), filepath.Join(tempDir, filename))
+		err = copy(filepath.Join(dir, filename), filepath.Join(tempDir, filename), asset.DecryptSensitive)
 		if err != nil {
 			return errors.Wrapf(err, "failed to copy %s to the temporary directory", filename)
 		}
@@ -63,18 +74,64 @@ func Destroy(dir string) (err error) {
 	}
 
 	tempStateFilePath := filepath.Join(dir, terraform.StateFileName+".new")
-	err = copy(filepath.Join(tempDir, terraform.StateFileName), tempStateFilePath)
+	err = copy(filepath.Join(tempDir, terraform.StateFileName), tempStateFilePath, asset.EncryptSensitive)
 	if err != nil {
 		return errors.Wrapf(err, "failed to copy %s from the temporary directory", terraform.StateFileName)
 	}
 	return os.Rename(tempStateFilePath, filepath.Join(dir, terraform.StateFileName))
 }
 
-func copy(from string, to string) error {
+// destroyStage destroys the bootstrap stage, whose resources are in their
+// own state, which is the only state it changes.
+func destroyStage(dir string, platform string, stage terraform.Stage) error {
+	tempDir, err := ioutil.TempDir("", "openshift-install-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary directory for Terraform execution")
+	}
+	defer os.RemoveAll(tempDir)
+
+	// The stages before the bootstrap stage are copied for their outputs,
+	// which are its variables.
+	copyNames := []string{cluster.TfVarsFileName}
+	for _, s := range terraform.Stages(platform) {
+		copyNames = append(copyNames, s.StateFileName())
+		if s.Name == stage.Name {
+			break
+		}
+	}
+	for _, filename := range copyNames {
+		err = copy(filepath.Join(dir, filename), filepath.Join(tempDir, filename), asset.DecryptSensitive)
+		if err != nil {
+			return errors.Wrapf(err, "failed to copy %s to the temporary directory", filename)
+		}
+	}
+
+	err = terraform.DestroyStage(tempDir, platform, stage)
+	if err != nil {
+		return errors.Wrap(err, "Terraform destroy")
+	}
+
+	stateFileName := stage.StateFileName()
+	tempStateFilePath := filepath.Join(dir, stateFileName+".new")
+	err = copy(filepath.Join(tempDir, stateFileName), tempStateFilePath, asset.EncryptSensitive)
+	if err != nil {
+		return errors.Wrapf(err, "failed to copy %s from the temporary directory", stateFileName)
+	}
+	return os.Rename(tempStateFilePath, filepath.Join(dir, stateFileName))
+}
+
+// copy copies a file, transforming its contents, which decrypts the
+// Terraform state files copied to the temporary directory and encrypts
+// those copied back, if the asset directory is encrypted.
+func copy(from string, to string, transform func([]byte) ([]byte, error)) error {
 	data, err := ioutil.ReadFile(from)
 	if err != nil {
 		return err
 	}
 
+	data, err = transform(data)
+	if err != nil {
+		return err
+	}
 	return ioutil.WriteFile(to, data, 0666)
 }
@@ -1,6 +1,10 @@
 package destroy
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
@@ -14,11 +18,83 @@ type Destroyer interface {
 	Run() error
 }
 
+// Resource is a resource which a destroyer would delete.
+type Resource struct {
+	// Type is the kind of the resource, such as "ec2:instance".
+	Type string `json:"type"`
+
+	// ID identifies the resource, such as by its ARN.
+	ID string `json:"id"`
+}
+
+// Lister is implemented by destroyers which can list the resources they
+// would delete, without deleting them.
+type Lister interface {
+	List() ([]Resource, error)
+}
+
+// ProgressFilename is the name of the file, in the asset directory, in
+// which destroy cluster saves the progress of a Resumer which stopped.
+const ProgressFilename = "destroy-progress.json"
+
+// Resumer is implemented by destroyers which can stop at a deadline, and
+// resume from the progress of an earlier Run which stopped.
+type Resumer interface {
+	// SetDeadline makes Run stop with an error once the deadline passed.
+	SetDeadline(deadline time.Time)
+
+	// Progress returns the progress of Run.
+	Progress() ([]byte, error)
+
+	// Resume makes Run continue from the progress of an earlier Run.
+	Resume(progress []byte) error
+}
+
+// CredentialsSetter is implemented by destroyers which can use other
+// credentials than the default ones of the platform, to destroy clusters
+// from another machine or account context than the one which created them.
+type CredentialsSetter interface {
+	// SetCredentials selects the named credentials, such as the profile of
+	// the shared AWS credentials or the cloud of the OpenStack clouds.yaml.
+	SetCredentials(name string)
+}
+
+// TypeScoper is implemented by destroyers which can delete only some types
+// of resources, for surgical cleanups.
+type TypeScoper interface {
+	// ScopeTypes limits Run and List to the resources of the included
+	// types, or of all types if none are included, except those of the
+	// excluded types. The types are matched with MatchType.
+	ScopeTypes(include, exclude []string)
+}
+
+// MatchType returns true if the resource type, such as "ec2:instance", is
+// one of the types or belongs to one of them, such as "ec2".
+func MatchType(resourceType string, types []string) bool {
+	for _, t := range types {
+		if resourceType == t || strings.HasPrefix(resourceType, t+":") {
+			return true
+		}
+	}
+	return false
+}
+
 // NewFunc is an interface for creating platform-specific destroyers.
 type NewFunc func(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (Destroyer, error)
 
-// Registry maps ClusterMetadata.Platform() to per-platform Destroyer creators.
-var Registry = make(map[string]NewFunc)
+// registry maps ClusterMetadata.Platform() to per-platform Destroyer creators.
+var registry = make(map[string]NewFunc)
+
+// Register registers the Destroyer creator of a platform, named like
+// ClusterMetadata.Platform(). Platform packages register from their init
+// functions, so that a platform is added by importing its package, without
+// changes to this one. It panics if the platform was already registered.
+func Register(platform string, newFunc NewFunc) {
+	if _, ok := registry[platform]; ok {
+		panic(fmt.Sprintf("a destroyer is already registered for %q", platform))
+	}
+	registry[platform] = newFunc
+}
 
 // New returns a Destroyer based on `metadata.json` in `rootDir`.
 func New(logger logrus.FieldLogger, rootDir string) (Destroyer, error) {
@@ -26,13 +102,17 @@ func New(logger logrus.FieldLogger, rootDir string) (Destroyer, error) {
 	if err != nil {
 		return nil, err
 	}
+	return NewFromMetadata(logger, metadata)
+}
 
+// NewFromMetadata returns a Destroyer based on the cluster metadata.
+func NewFromMetadata(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (Destroyer, error) {
 	platform := metadata.Platform()
 	if platform == "" {
 		return nil, errors.New("no platform configured in metadata")
 	}
 
-	creator, ok := Registry[platform]
+	creator, ok := registry[platform]
 	if !ok {
 		return nil, errors.Errorf("no destroyers registered for %q", platform)
 	}
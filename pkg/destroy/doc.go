@@ -1,2 +1,8 @@
 // Package destroy contains tools for destroying clusters based on their metadata.
+//
+// Each platform has a subpackage which registers its Destroyer with
+// Register from an init function, and which is enabled by importing it.
+// Destroyers may also implement Lister, Reporter and Resumer, and can use
+// the Filter and Retry helpers for matching tags and for deletions which
+// must wait for the resources depending on the deleted ones.
 package destroy
@@ -0,0 +1,26 @@
+package destroy
+
+// Filter holds the key/value pairs of the tags which a resource must have to
+// match the filter.
+type Filter map[string]string
+
+// Match returns true if the tags have all the key/value pairs of the filter.
+func (f Filter) Match(tags map[string]string) bool {
+	for key, value := range f {
+		if tagValue, ok := tags[key]; !ok || tagValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchAny returns true if the tags match any of the filters, or if there
+// are no filters.
+func MatchAny(filters []Filter, tags map[string]string) bool {
+	for _, filter := range filters {
+		if filter.Match(tags) {
+			return true
+		}
+	}
+	return len(filters) == 0
+}
@@ -0,0 +1,43 @@
+package destroy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMatch(t *testing.T) {
+	tags := map[string]string{"openshiftClusterID": "test-id", "Name": "test-master-0"}
+	cases := []struct {
+		name     string
+		filter   Filter
+		expected bool
+	}{
+		{name: "empty", filter: Filter{}, expected: true},
+		{name: "match", filter: Filter{"openshiftClusterID": "test-id"}, expected: true},
+		{name: "other value", filter: Filter{"openshiftClusterID": "other-id"}},
+		{name: "missing key", filter: Filter{"openshiftClusterID": "test-id", "kubernetes.io/cluster/test": "owned"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.filter.Match(tags))
+		})
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	tags := map[string]string{"kubernetes.io/cluster/test": "owned"}
+	assert.True(t, MatchAny(nil, tags))
+	assert.True(t, MatchAny([]Filter{{"openshiftClusterID": "test-id"}, {"kubernetes.io/cluster/test": "owned"}}, tags))
+	assert.False(t, MatchAny([]Filter{{"openshiftClusterID": "test-id"}}, tags))
+}
+
+func TestMatchType(t *testing.T) {
+	types := []string{"ec2:instance", "s3"}
+	assert.True(t, MatchType("ec2:instance", types))
+	assert.True(t, MatchType("s3", types))
+	assert.True(t, MatchType("s3:bucket", types))
+	assert.False(t, MatchType("ec2", types))
+	assert.False(t, MatchType("ec2:instances", types))
+	assert.False(t, MatchType("s3control", types))
+}
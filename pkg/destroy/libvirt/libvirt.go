@@ -68,6 +68,79 @@ func (o *ClusterUninstaller) Run() error {
 	return nil
 }
 
+// List returns the domains, networks, and volumes or storage pool which
+// Run would delete.
+func (o *ClusterUninstaller) List() ([]destroy.Resource, error) {
+	conn, err := libvirt.NewConnect(o.LibvirtURI)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to Libvirt daemon")
+	}
+
+	var resources []destroy.Resource
+	domains, err := conn.ListAllDomains(0)
+	if err != nil {
+		return nil, errors.Wrap(err, "list domains")
+	}
+	for _, domain := range domains {
+		defer domain.Free()
+		dName, err := domain.GetName()
+		if err != nil {
+			return nil, errors.Wrap(err, "get domain name")
+		}
+		if o.Filter(dName) {
+			resources = append(resources, destroy.Resource{Type: "domain", ID: dName})
+		}
+	}
+
+	networks, err := conn.ListNetworks()
+	if err != nil {
+		return nil, errors.Wrap(err, "list networks")
+	}
+	for _, nName := range networks {
+		if o.Filter(nName) {
+			resources = append(resources, destroy.Resource{Type: "network", ID: nName})
+		}
+	}
+
+	pools, err := conn.ListStoragePools()
+	if err != nil {
+		return nil, errors.Wrap(err, "list storage pools")
+	}
+	tpool := "default"
+	for _, pname := range pools {
+		if o.Filter(pname) {
+			tpool = pname
+		}
+	}
+	if tpool != "default" {
+		// As in deleteVolumes, a matching pool is deleted whole.
+		return append(resources, destroy.Resource{Type: "pool", ID: tpool}), nil
+	}
+
+	pool, err := conn.LookupStoragePoolByName(tpool)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get storage pool %q", tpool)
+	}
+	defer pool.Free()
+
+	vols, err := pool.ListAllStorageVolumes(0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list volumes in %q", tpool)
+	}
+	for _, vol := range vols {
+		defer vol.Free()
+		vName, err := vol.GetName()
+		if err != nil {
+			return nil, errors.Wrapf(err, "get volume names in %q", tpool)
+		}
+		if o.Filter(vName) {
+			resources = append(resources, destroy.Resource{Type: "volume", ID: vName})
+		}
+	}
+
+	return resources, nil
+}
+
 // deleteDomains calls deleteDomainsSinglePass until it finds no
 // matching domains.  This guards against the machine-API launching
 // additional nodes after the initial list call.  We continue deleting
@@ -7,5 +7,5 @@ import (
 )
 
 func init() {
-	destroy.Registry["libvirt"] = New
+	destroy.Register("libvirt", New)
 }
@@ -0,0 +1,270 @@
+package openstack
+
+import (
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	sg "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/trunks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/containers"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/destroy"
+)
+
+// List returns the resources which Run would delete.
+func (o *ClusterUninstaller) List() ([]destroy.Resource, error) {
+	opts := &clientconfig.ClientOpts{
+		Cloud: o.Cloud,
+	}
+
+	var resources []destroy.Resource
+	for _, list := range []func(*clientconfig.ClientOpts, Filter) ([]destroy.Resource, error){
+		listServers,
+		listNetworkResources,
+		listContainers,
+	} {
+		found, err := list(opts, o.Filter)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, found...)
+	}
+	return resources, nil
+}
+
+func listServers(opts *clientconfig.ClientOpts, filter Filter) ([]destroy.Resource, error) {
+	conn, err := clientconfig.NewServiceClient("compute", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := servers.List(conn, servers.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, errors.Wrap(err, "list servers")
+	}
+	allServers, err := servers.ExtractServers(allPages)
+	if err != nil {
+		return nil, errors.Wrap(err, "list servers")
+	}
+
+	// As in deleteServers, servers are filtered client-side by their
+	// metadata.
+	serverObjects := []ObjectWithTags{}
+	for _, server := range allServers {
+		serverObjects = append(serverObjects, ObjectWithTags{ID: server.ID, Tags: server.Metadata})
+	}
+
+	var resources []destroy.Resource
+	for _, server := range filterObjects(serverObjects, filter) {
+		resources = append(resources, destroy.Resource{Type: "server", ID: server.ID})
+	}
+	return resources, nil
+}
+
+func listNetworkResources(opts *clientconfig.ClientOpts, filter Filter) ([]destroy.Resource, error) {
+	conn, err := clientconfig.NewServiceClient("network", opts)
+	if err != nil {
+		return nil, err
+	}
+	tags := strings.Join(filterTags(filter), ",")
+
+	var resources []destroy.Resource
+	add := func(resourceType string) func(ids []string, err error) error {
+		return func(ids []string, err error) error {
+			if err != nil {
+				return errors.Wrapf(err, "list %ss", resourceType)
+			}
+			for _, id := range ids {
+				resources = append(resources, destroy.Resource{Type: resourceType, ID: id})
+			}
+			return nil
+		}
+	}
+
+	if err := add("trunk")(listTrunks(conn, tags)); err != nil {
+		return nil, err
+	}
+	portIDs, err := listPorts(conn, tags)
+	if err := add("port")(portIDs, err); err != nil {
+		return nil, err
+	}
+	if err := add("floating-ip")(listFloatingIPs(conn, portIDs)); err != nil {
+		return nil, err
+	}
+	if err := add("security-group")(listSecurityGroups(conn, tags)); err != nil {
+		return nil, err
+	}
+	if err := add("router")(listRouters(conn, tags)); err != nil {
+		return nil, err
+	}
+	if err := add("subnet")(listSubnets(conn, tags)); err != nil {
+		return nil, err
+	}
+	if err := add("network")(listNetworks(conn, tags)); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+func listTrunks(conn *gophercloud.ServiceClient, tags string) ([]string, error) {
+	allPages, err := trunks.List(conn, trunks.ListOpts{TagsAny: tags}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allTrunks, err := trunks.ExtractTrunks(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(allTrunks))
+	for _, trunk := range allTrunks {
+		ids = append(ids, trunk.ID)
+	}
+	return ids, nil
+}
+
+func listPorts(conn *gophercloud.ServiceClient, tags string) ([]string, error) {
+	allPages, err := ports.List(conn, ports.ListOpts{TagsAny: tags}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(allPorts))
+	for _, port := range allPorts {
+		ids = append(ids, port.ID)
+	}
+	return ids, nil
+}
+
+// listFloatingIPs lists the floating IPs of the given ports, which
+// deletePorts deletes along with the ports.
+func listFloatingIPs(conn *gophercloud.ServiceClient, portIDs []string) ([]string, error) {
+	var ids []string
+	for _, portID := range portIDs {
+		allPages, err := floatingips.List(conn, floatingips.ListOpts{PortID: portID}).AllPages()
+		if err != nil {
+			return nil, err
+		}
+		allFIPs, err := floatingips.ExtractFloatingIPs(allPages)
+		if err != nil {
+			return nil, err
+		}
+		for _, fip := range allFIPs {
+			ids = append(ids, fip.ID)
+		}
+	}
+	return ids, nil
+}
+
+func listSecurityGroups(conn *gophercloud.ServiceClient, tags string) ([]string, error) {
+	allPages, err := sg.List(conn, sg.ListOpts{TagsAny: tags}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allGroups, err := sg.ExtractGroups(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(allGroups))
+	for _, group := range allGroups {
+		ids = append(ids, group.ID)
+	}
+	return ids, nil
+}
+
+func listRouters(conn *gophercloud.ServiceClient, tags string) ([]string, error) {
+	allPages, err := routers.List(conn, routers.ListOpts{TagsAny: tags}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allRouters, err := routers.ExtractRouters(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(allRouters))
+	for _, router := range allRouters {
+		ids = append(ids, router.ID)
+	}
+	return ids, nil
+}
+
+func listSubnets(conn *gophercloud.ServiceClient, tags string) ([]string, error) {
+	allPages, err := subnets.List(conn, subnets.ListOpts{TagsAny: tags}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allSubnets, err := subnets.ExtractSubnets(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(allSubnets))
+	for _, subnet := range allSubnets {
+		ids = append(ids, subnet.ID)
+	}
+	return ids, nil
+}
+
+func listNetworks(conn *gophercloud.ServiceClient, tags string) ([]string, error) {
+	allPages, err := networks.List(conn, networks.ListOpts{TagsAny: tags}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allNetworks, err := networks.ExtractNetworks(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(allNetworks))
+	for _, network := range allNetworks {
+		ids = append(ids, network.ID)
+	}
+	return ids, nil
+}
+
+func listContainers(opts *clientconfig.ClientOpts, filter Filter) ([]destroy.Resource, error) {
+	conn, err := clientconfig.NewServiceClient("object-store", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := containers.List(conn, containers.ListOpts{Full: false}).AllPages()
+	if err != nil {
+		return nil, errors.Wrap(err, "list containers")
+	}
+	allContainers, err := containers.ExtractNames(allPages)
+	if err != nil {
+		return nil, errors.Wrap(err, "list containers")
+	}
+
+	var resources []destroy.Resource
+	for _, container := range allContainers {
+		metadata, err := containers.Get(conn, container, nil).ExtractMetadata()
+		if err != nil {
+			return nil, errors.Wrapf(err, "get metadata of container %s", container)
+		}
+		for key, val := range filter {
+			// As in deleteContainers, Swift mangles the case of the
+			// metadata keys.
+			if metadata[strings.Title(strings.ToLower(key))] == val {
+				resources = append(resources, destroy.Resource{Type: "container", ID: container})
+				break
+			}
+		}
+	}
+	return resources, nil
+}
@@ -4,11 +4,11 @@ package openstack
 import (
 	"os"
 	"strings"
-	"time"
 
 	"github.com/openshift/installer/pkg/destroy"
 	"github.com/openshift/installer/pkg/types"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
@@ -21,12 +21,12 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/objects"
 	"github.com/gophercloud/utils/openstack/clientconfig"
 	"github.com/sirupsen/logrus"
-	"k8s.io/apimachinery/pkg/util/wait"
+	k8serrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 // Filter holds the key/value pairs for the tags we will be matching
 // against.
-type Filter map[string]string
+type Filter = destroy.Filter
 
 // ObjectWithTags is a generic way to represent an OpenStack object
 // and its tags so that filtering objects client-side can be done in a generic
@@ -40,7 +40,7 @@ type ObjectWithTags struct {
 }
 
 // deleteFunc type is the interface a function needs to implement to be called as a goroutine.
-// The (bool, error) return type mimics wait.ExponentialBackoff where the bool indicates successful
+// The (bool, error) return type mimics destroy.Retry where the bool indicates successful
 // completion, and the error is for unrecoverable errors.
 type deleteFunc func(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger) (bool, error)
 
@@ -57,7 +57,7 @@ type ClusterUninstaller struct {
 func (o *ClusterUninstaller) Run() error {
 	deleteFuncs := map[string]deleteFunc{}
 	populateDeleteFuncs(deleteFuncs)
-	returnChannel := make(chan string)
+	returnChannel := make(chan error)
 
 	opts := &clientconfig.ClientOpts{
 		Cloud: o.Cloud,
@@ -69,35 +69,33 @@ func (o *ClusterUninstaller) Run() error {
 	}
 
 	// wait for them to finish
+	var errs []error
 	for i := 0; i < len(deleteFuncs); i++ {
-		select {
-		case res := <-returnChannel:
-			o.Logger.Debugf("goroutine %v complete", res)
+		if err := <-returnChannel; err != nil {
+			errs = append(errs, err)
 		}
 	}
-
-	return nil
+	return k8serrors.NewAggregate(errs)
 }
 
-func deleteRunner(deleteFuncName string, dFunction deleteFunc, opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, channel chan string) {
-	backoffSettings := wait.Backoff{
-		Duration: time.Second * 10,
-		Factor:   1.3,
-		Steps:    100,
-	}
-
-	err := wait.ExponentialBackoff(backoffSettings, func() (bool, error) {
+// deleteRunner calls the delete function with destroy.Retry, which gives
+// up after about an hour, and sends its error, if any, to the channel.
+func deleteRunner(deleteFuncName string, dFunction deleteFunc, opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, channel chan error) {
+	err := destroy.Retry(logger, deleteFuncName, func() (bool, error) {
 		return dFunction(opts, filter, logger)
 	})
-
-	if err != nil {
-		logger.Fatalf("Unrecoverable error/timed out: %v", err)
-		os.Exit(1)
+	if err == nil {
+		logger.Debugf("goroutine %v complete", deleteFuncName)
 	}
+	channel <- err
+}
 
-	// record that the goroutine has run to completion
-	channel <- deleteFuncName
-	return
+// isNotFound returns true for the errors of resources which are gone, for
+// example because they were deleted by hand, so that deleting them counts
+// as done instead of failing.
+func isNotFound(err error) bool {
+	_, ok := err.(gophercloud.ErrDefault404)
+	return ok
 }
 
 // populateDeleteFuncs is the list of functions that will be launched as
@@ -115,35 +113,10 @@ func populateDeleteFuncs(funcs map[string]deleteFunc) {
 
 // filterObjects will do client-side filtering given an appropriately filled out
 // list of ObjectWithTags.
-func filterObjects(osObjects []ObjectWithTags, filters Filter) []ObjectWithTags {
-	objectsWithTags := []ObjectWithTags{}
+func filterObjects(osObjects []ObjectWithTags, filter Filter) []ObjectWithTags {
 	filteredObjects := []ObjectWithTags{}
-
-	// first find the objects that have all the desired tags
 	for _, object := range osObjects {
-		allTagsFound := true
-		for key := range filters {
-			if _, ok := object.Tags[key]; !ok {
-				// doesn't have one of the tags we're looking for so skip it
-				allTagsFound = false
-				break
-			}
-		}
-		if allTagsFound {
-			objectsWithTags = append(objectsWithTags, object)
-		}
-	}
-
-	// now check that the values match
-	for _, object := range objectsWithTags {
-		valuesMatch := true
-		for key, val := range filters {
-			if object.Tags[key] != val {
-				valuesMatch = false
-				break
-			}
-		}
-		if valuesMatch {
+		if filter.Match(object.Tags) {
 			filteredObjects = append(filteredObjects, object)
 		}
 	}
@@ -201,7 +174,9 @@ func deleteServers(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 	for _, server := range filteredServers {
 		logger.Debugf("Deleting Server: %+v", server.ID)
 		err = servers.Delete(conn, server.ID).ExtractErr()
-		if err != nil {
+		if isNotFound(err) {
+			logger.Infof("Server %s was already deleted", server.ID)
+		} else if err != nil {
 			logger.Fatalf("%v", err)
 			os.Exit(1)
 		}
@@ -251,7 +226,9 @@ func deletePorts(opts *clientconfig.ClientOpts, filter Filter, logger logrus.Fie
 		for _, fip := range allFIPs {
 			logger.Debugf("Deleting Floating IP: %+v", fip.ID)
 			err = floatingips.Delete(conn, fip.ID).ExtractErr()
-			if err != nil {
+			if isNotFound(err) {
+				logger.Infof("Floating IP %s was already deleted", fip.ID)
+			} else if err != nil {
 				logger.Fatalf("%v", err)
 				os.Exit(1)
 			}
@@ -259,7 +236,9 @@ func deletePorts(opts *clientconfig.ClientOpts, filter Filter, logger logrus.Fie
 
 		logger.Debugf("Deleting Port: %+v", port.ID)
 		err = ports.Delete(conn, port.ID).ExtractErr()
-		if err != nil {
+		if isNotFound(err) {
+			logger.Infof("Port %s was already deleted", port.ID)
+		} else if err != nil {
 			// This can fail when port is still in use so return/retry
 			return false, nil
 		}
@@ -295,7 +274,9 @@ func deleteSecurityGroups(opts *clientconfig.ClientOpts, filter Filter, logger l
 	for _, group := range allGroups {
 		logger.Debugf("Deleting Security Group: %+v", group.ID)
 		err = sg.Delete(conn, group.ID).ExtractErr()
-		if err != nil {
+		if isNotFound(err) {
+			logger.Infof("Security group %s was already deleted", group.ID)
+		} else if err != nil {
 			// This can fail when sg is still in use by servers
 			return false, nil
 		}
@@ -359,7 +340,9 @@ func deleteRouters(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 		}
 		logger.Debugf("Deleting Router: %+v\n", router.ID)
 		err = routers.Delete(conn, router.ID).ExtractErr()
-		if err != nil {
+		if isNotFound(err) {
+			logger.Infof("Router %s was already deleted", router.ID)
+		} else if err != nil {
 			logger.Fatalf("%v", err)
 			os.Exit(1)
 		}
@@ -395,7 +378,9 @@ func deleteSubnets(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 	for _, subnet := range allSubnets {
 		logger.Debugf("Deleting Subnet: %+v", subnet.ID)
 		err = subnets.Delete(conn, subnet.ID).ExtractErr()
-		if err != nil {
+		if isNotFound(err) {
+			logger.Infof("Subnet %s was already deleted", subnet.ID)
+		} else if err != nil {
 			// This can fail when subnet is still in use
 			return false, nil
 		}
@@ -431,7 +416,9 @@ func deleteNetworks(opts *clientconfig.ClientOpts, filter Filter, logger logrus.
 	for _, network := range allNetworks {
 		logger.Debugf("Deleting network: %+v", network.ID)
 		err = networks.Delete(conn, network.ID).ExtractErr()
-		if err != nil {
+		if isNotFound(err) {
+			logger.Infof("Network %s was already deleted", network.ID)
+		} else if err != nil {
 			// This can fail when network is still in use
 			return false, nil
 		}
@@ -486,14 +473,18 @@ func deleteContainers(opts *clientconfig.ClientOpts, filter Filter, logger logru
 				for _, object := range allObjects {
 					logger.Debugf("Deleting object: %+v\n", object)
 					_, err = objects.Delete(conn, container, object, nil).Extract()
-					if err != nil {
+					if isNotFound(err) {
+						logger.Infof("Object %s was already deleted", object)
+					} else if err != nil {
 						logger.Fatalf("%v", err)
 						os.Exit(1)
 					}
 				}
 				logger.Debugf("Deleting container: %+v\n", container)
 				_, err = containers.Delete(conn, container).Extract()
-				if err != nil {
+				if isNotFound(err) {
+					logger.Infof("Container %s was already deleted", container)
+				} else if err != nil {
 					logger.Fatalf("%v", err)
 					os.Exit(1)
 				}
@@ -533,7 +524,9 @@ func deleteTrunks(opts *clientconfig.ClientOpts, filter Filter, logger logrus.Fi
 	for _, trunk := range allTrunks {
 		logger.Debugf("Deleting Trunk: %+v", trunk.ID)
 		err = trunks.Delete(conn, trunk.ID).ExtractErr()
-		if err != nil {
+		if isNotFound(err) {
+			logger.Infof("Trunk %s was already deleted", trunk.ID)
+		} else if err != nil {
 			// This can fail when the trunk is still in use so return/retry
 			return false, nil
 		}
@@ -5,6 +5,11 @@ import (
 	"github.com/openshift/installer/pkg/destroy"
 )
 
+// SetCredentials makes Run use the named cloud of clouds.yaml.
+func (o *ClusterUninstaller) SetCredentials(name string) {
+	o.Cloud = name
+}
+
 func init() {
-	destroy.Registry["openstack"] = New
+	destroy.Register("openstack", New)
 }
@@ -0,0 +1,63 @@
+package destroy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ReportFilename is the name of the file, in the asset directory, to which
+// destroy cluster writes its report.
+const ReportFilename = "destroy-report.json"
+
+// Report lists the resources which a destroyer deleted, skipped, or failed
+// to delete, for audit trails and for finding orphaned resources.
+type Report struct {
+	Deleted []ReportEntry `json:"deleted"`
+	Skipped []ReportEntry `json:"skipped"`
+	Failed  []ReportEntry `json:"failed"`
+}
+
+// ReportEntry is a resource in a Report.
+type ReportEntry struct {
+	Resource
+
+	// Region is the region of the resource, if it is regional.
+	Region string `json:"region,omitempty"`
+
+	// Time is when the resource was deleted, skipped or, for failed
+	// resources, when its deletion last failed.
+	Time time.Time `json:"time"`
+
+	// Reason is why the resource was skipped or failed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Reporter is implemented by destroyers which report the resources they
+// deleted, skipped, or failed to delete after Run.
+type Reporter interface {
+	Report() *Report
+}
+
+// Write writes the report to ReportFilename in the directory.
+func (r *Report) Write(directory string) error {
+	// Write empty lists rather than nulls.
+	report := *r
+	for _, entries := range []*[]ReportEntry{&report.Deleted, &report.Skipped, &report.Failed} {
+		if *entries == nil {
+			*entries = []ReportEntry{}
+		}
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(directory, ReportFilename)
+	if err := ioutil.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", path)
+	}
+	return nil
+}
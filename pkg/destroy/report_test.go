@@ -0,0 +1,60 @@
+package destroy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestReportWrite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	report := &Report{
+		Deleted: []ReportEntry{{
+			Resource: Resource{Type: "ec2:instance", ID: "arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789"},
+			Region:   "us-east-1",
+			Time:     time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC),
+		}},
+		Failed: []ReportEntry{{
+			Resource: Resource{Type: "ec2:vpc", ID: "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123456789"},
+			Time:     time.Date(2019, 1, 2, 3, 4, 6, 0, time.UTC),
+			Reason:   "DependencyViolation",
+		}},
+	}
+	if !assert.NoError(t, report.Write(dir)) {
+		return
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, ReportFilename))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `{
+  "deleted": [
+    {
+      "type": "ec2:instance",
+      "id": "arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789",
+      "region": "us-east-1",
+      "time": "2019-01-02T03:04:05Z"
+    }
+  ],
+  "skipped": [],
+  "failed": [
+    {
+      "type": "ec2:vpc",
+      "id": "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123456789",
+      "time": "2019-01-02T03:04:06Z",
+      "reason": "DependencyViolation"
+    }
+  ]
+}
+`, string(data))
+	assert.Nil(t, report.Skipped, "Write must not change the report")
+}
@@ -0,0 +1,35 @@
+package destroy
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// RetryBackoff is the backoff with which Retry calls its function, which
+// gives up after about an hour: the 18 waits between its 19 attempts add up
+// to 10s * (1.3^18 - 1) / 0.3, or 62 minutes.
+var RetryBackoff = wait.Backoff{
+	Duration: 10 * time.Second,
+	Factor:   1.3,
+	Steps:    19,
+}
+
+// Retry calls the function, with RetryBackoff, until it returns true or an
+// error, for deletions which only succeed once the resources which depend
+// on the deleted ones are gone. The name identifies the function in the
+// logs and errors.
+func Retry(logger logrus.FieldLogger, name string, fn func() (bool, error)) error {
+	attempt := 0
+	err := wait.ExponentialBackoff(RetryBackoff, func() (bool, error) {
+		attempt++
+		done, err := fn()
+		if err == nil && !done {
+			logger.Debugf("%s not done after %d attempts, retrying", name, attempt)
+		}
+		return done, err
+	})
+	return errors.Wrap(err, name)
+}
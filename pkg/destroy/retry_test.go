@@ -0,0 +1,18 @@
+package destroy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBackoffGivesUpAfterAboutAnHour(t *testing.T) {
+	var total time.Duration
+	duration := RetryBackoff.Duration
+	for i := 1; i < RetryBackoff.Steps; i++ {
+		total += duration
+		duration = time.Duration(float64(duration) * RetryBackoff.Factor)
+	}
+	assert.InDelta(t, time.Hour.Minutes(), total.Minutes(), 5)
+}
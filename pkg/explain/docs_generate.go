@@ -0,0 +1,195 @@
+// +build ignore
+
+// This program generates zz_generated.docs.go from the doc comments of the
+// install-config types. Run it with "go generate ./pkg/explain".
+package main
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	outputFile = "zz_generated.docs.go"
+)
+
+func main() {
+	// Only document the types that are reachable from the install-config.
+	wanted := map[string]map[string]bool{}
+	collectTypes(wanted, reflect.TypeOf(types.InstallConfig{}))
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	docs := map[string]map[string]string{}
+	for pkgPath, names := range wanted {
+		pkg, err := build.Import(pkgPath, cwd, build.FindOnly)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if err := parseDir(docs, pkgPath, pkg.Dir, names); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by docs_generate.go; DO NOT EDIT.\n\npackage explain\n\n")
+	buf.WriteString("// typeDocs maps the name of each install-config type, qualified by its\n")
+	buf.WriteString("// package path, to the doc comments of the type (keyed by \"\") and of its\n")
+	buf.WriteString("// fields (keyed by the Go field name).\n")
+	buf.WriteString("var typeDocs = map[string]map[string]string{\n")
+	for _, typeName := range sortedKeys(docs) {
+		fmt.Fprintf(&buf, "%q: {\n", typeName)
+		for _, field := range sortedKeys(docs[typeName]) {
+			fmt.Fprintf(&buf, "%q: %q,\n", field, docs[typeName][field])
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	data, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if err := ioutil.WriteFile(outputFile, data, 0644); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// collectTypes adds t, and the struct types reachable through its fields,
+// to wanted, which is keyed by package path and then type name.
+func collectTypes(wanted map[string]map[string]bool, t reflect.Type) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.Name() == "" || isScalar(t) {
+		return
+	}
+	if wanted[t.PkgPath()][t.Name()] {
+		return
+	}
+	if wanted[t.PkgPath()] == nil {
+		wanted[t.PkgPath()] = map[string]bool{}
+	}
+	wanted[t.PkgPath()][t.Name()] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.PkgPath == "" {
+			collectTypes(wanted, field.Type)
+		}
+	}
+}
+
+// isScalar returns true for types which marshal themselves, and so do not
+// have documented fields. Keep this in sync with the explain package.
+func isScalar(t reflect.Type) bool {
+	ptr := reflect.PtrTo(t)
+	return ptr.Implements(jsonMarshaler) || ptr.Implements(textMarshaler)
+}
+
+var (
+	jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func parseDir(docs map[string]map[string]string, pkgPath string, dir string, names map[string]bool) error {
+	notTest := func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, notTest, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok || !names[typeSpec.Name.Name] {
+						continue
+					}
+
+					fields := map[string]string{}
+					if doc := typeSpec.Doc; doc != nil {
+						fields[""] = doc.Text()
+					} else if gen.Doc != nil {
+						fields[""] = gen.Doc.Text()
+					}
+					for _, field := range structType.Fields.List {
+						if field.Doc == nil {
+							continue
+						}
+						for _, name := range fieldNames(field) {
+							fields[name] = field.Doc.Text()
+						}
+					}
+					docs[pkgPath+"."+typeSpec.Name.Name] = fields
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fieldNames returns the Go names of the given field, which for embedded
+// fields is the name of the embedded type.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) > 0 {
+		names := make([]string, 0, len(field.Names))
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		return names
+	}
+
+	typ := field.Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch t := typ.(type) {
+	case *ast.Ident:
+		return []string{t.Name}
+	case *ast.SelectorExpr:
+		return []string{t.Sel.Name}
+	}
+	return nil
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]map[string]string:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]string:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,277 @@
+// Package explain prints the documentation of install-config fields.
+package explain
+
+//go:generate go run docs_generate.go
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+var (
+	jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// platformTypes are the types whose fields each hold the
+	// configuration of a single platform.
+	platformTypes = map[reflect.Type]bool{
+		reflect.TypeOf(types.Platform{}):            true,
+		reflect.TypeOf(types.MachinePoolPlatform{}): true,
+	}
+)
+
+// field is a field of an install-config type, as seen in its JSON form.
+type field struct {
+	// name is the JSON name of the field.
+	name string
+
+	// goName is the Go name of the field.
+	goName string
+
+	// typ is the type of the field.
+	typ reflect.Type
+
+	// owner is the struct type which declares the field.
+	owner reflect.Type
+}
+
+// doc is a parsed doc comment.
+type doc struct {
+	description string
+	defaults    string
+}
+
+// Explain writes the documentation for the install-config field at path to
+// out. The path is a dot-separated list of JSON field names, such as
+// "platform.aws.region"; an empty path explains the install-config itself.
+func Explain(out io.Writer, path string) error {
+	root := reflect.TypeOf(types.InstallConfig{})
+	if path == "" {
+		d := parseDoc(typeDocs[typeKey(root)][""])
+		fmt.Fprintf(out, "KIND:     %s\n", root.Name())
+		fmt.Fprintf(out, "VERSION:  %s\n", types.InstallConfigVersion)
+		writeSection(out, "DESCRIPTION", d.description)
+		writeFields(out, root)
+		return nil
+	}
+
+	var (
+		current   *field
+		platforms []string
+		parent    = root
+	)
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		if i > 0 {
+			parent = structType(current.typ)
+			if parent == nil {
+				return errors.Errorf("%q has no fields", strings.Join(segments[:i], "."))
+			}
+		}
+
+		current = lookupField(parent, segment)
+		if current == nil {
+			if i == 0 {
+				return errors.Errorf("%q is not an install-config field", segment)
+			}
+			return errors.Errorf("%q is not a field of %q", segment, strings.Join(segments[:i], "."))
+		}
+		if platformTypes[parent] {
+			platforms = []string{segment}
+		}
+	}
+
+	d := parseDoc(fieldDoc(current))
+	fmt.Fprintf(out, "FIELD:    %s <%s>\n", path, typeName(current.typ))
+	writeSection(out, "DESCRIPTION", d.description)
+	if d.defaults != "" {
+		writeSection(out, "DEFAULT", d.defaults)
+	}
+	if len(platforms) == 0 {
+		platforms = []string{"all"}
+	}
+	writeSection(out, "PLATFORMS", strings.Join(platforms, ", "))
+	if t := structType(current.typ); t != nil {
+		writeFields(out, t)
+	}
+	return nil
+}
+
+func writeSection(out io.Writer, title string, text string) {
+	fmt.Fprintf(out, "\n%s:\n", title)
+	if text == "" {
+		text = "<empty>"
+	}
+	fmt.Fprintln(out, indent(text, "    "))
+}
+
+func writeFields(out io.Writer, t reflect.Type) {
+	fields := jsonFields(t)
+	if len(fields) == 0 {
+		return
+	}
+
+	fmt.Fprintf(out, "\nFIELDS:\n")
+	for _, f := range fields {
+		fmt.Fprintf(out, "    %s <%s>\n", f.name, typeName(f.typ))
+		d := parseDoc(fieldDoc(&f))
+		if summary := strings.SplitN(d.description, "\n\n", 2)[0]; summary != "" {
+			fmt.Fprintln(out, indent(summary, "      "))
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+// jsonFields returns the fields of the struct type t as they appear in its
+// JSON form, with the fields of inlined structs promoted.
+func jsonFields(t reflect.Type) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			if embedded := indirect(f.Type); embedded.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(embedded)...)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, field{
+			name:   name,
+			goName: f.Name,
+			typ:    f.Type,
+			owner:  t,
+		})
+	}
+	return fields
+}
+
+func lookupField(t reflect.Type, name string) *field {
+	for _, f := range jsonFields(t) {
+		if f.name == name {
+			return &f
+		}
+	}
+	return nil
+}
+
+// structType returns the struct type whose fields are reached through a
+// value of type t (e.g. the element type of a slice), or nil if there is
+// none.
+func structType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			if isScalar(t) {
+				return nil
+			}
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// isScalar returns true for types which marshal themselves, and so do not
+// have documented fields (e.g. IP addresses, which are strings in JSON).
+func isScalar(t reflect.Type) bool {
+	ptr := reflect.PtrTo(t)
+	return ptr.Implements(jsonMarshaler) || ptr.Implements(textMarshaler)
+}
+
+// typeName returns the name of t as it appears in the install-config.
+func typeName(t reflect.Type) string {
+	if isScalar(t) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeName(t.Elem())
+	case reflect.Slice, reflect.Array:
+		return "[]" + typeName(t.Elem())
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", typeName(t.Key()), typeName(t.Elem()))
+	case reflect.Struct:
+		return "object"
+	default:
+		return t.Kind().String()
+	}
+}
+
+// fieldDoc returns the doc comment of f, falling back to the doc comment of
+// its type.
+func fieldDoc(f *field) string {
+	if comment := typeDocs[typeKey(f.owner)][f.goName]; parseDoc(comment).description != "" {
+		return comment
+	}
+	if t := structType(f.typ); t != nil {
+		return typeDocs[typeKey(t)][""]
+	}
+	return ""
+}
+
+func typeKey(t reflect.Type) string {
+	return t.PkgPath() + "." + t.Name()
+}
+
+// parseDoc splits a doc comment into its description and the description
+// of its default, which starts at the first line beginning with "Default".
+// Marker lines like "+optional" are dropped.
+func parseDoc(comment string) doc {
+	var description, defaults []string
+	for _, line := range strings.Split(strings.TrimSpace(comment), "\n") {
+		if strings.HasPrefix(line, "+") {
+			continue
+		}
+		if defaults != nil || strings.HasPrefix(line, "Default") {
+			defaults = append(defaults, line)
+			continue
+		}
+		description = append(description, line)
+	}
+	return doc{
+		description: strings.TrimSpace(strings.Join(description, "\n")),
+		defaults:    strings.TrimSpace(strings.Join(defaults, "\n")),
+	}
+}
+
+func indent(text string, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
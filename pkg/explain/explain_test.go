@@ -0,0 +1,109 @@
+package explain
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestExplain(t *testing.T) {
+	cases := []struct {
+		name          string
+		path          string
+		expected      []string
+		expectedError string
+	}{
+		{
+			name: "install-config",
+			path: "",
+			expected: []string{
+				"KIND:     InstallConfig\n",
+				"VERSION:  v1beta1\n",
+				"    baseDomain <string>\n      BaseDomain is the base domain to which the cluster should belong.\n",
+				"    machines <[]object>\n",
+			},
+		},
+		{
+			name: "platform field",
+			path: "platform.aws.region",
+			expected: []string{
+				"FIELD:    platform.aws.region <string>\n",
+				"DESCRIPTION:\n    Region specifies the AWS region where the cluster will be created.\n",
+				"PLATFORMS:\n    aws\n",
+			},
+		},
+		{
+			name: "default",
+			path: "networking.serviceCIDR",
+			expected: []string{
+				"FIELD:    networking.serviceCIDR <string>\n",
+				"DEFAULT:\n    Default is 172.30.0.0/16.\n",
+				"PLATFORMS:\n    all\n",
+			},
+		},
+		{
+			name: "slice elements",
+			path: "machines.platform.aws",
+			expected: []string{
+				"FIELD:    machines.platform.aws <object>\n",
+				"PLATFORMS:\n    aws\n",
+				"FIELDS:\n    zones <[]string>\n",
+			},
+		},
+		{
+			name:          "unknown field",
+			path:          "platform.gcp",
+			expectedError: `"gcp" is not a field of "platform"`,
+		},
+		{
+			name:          "unknown top-level field",
+			path:          "region",
+			expectedError: `"region" is not an install-config field`,
+		},
+		{
+			name:          "field of a scalar",
+			path:          "baseDomain.name",
+			expectedError: `"baseDomain" has no fields`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := Explain(&out, tc.path)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			for _, expected := range tc.expected {
+				assert.Contains(t, out.String(), expected)
+			}
+		})
+	}
+}
+
+// TestDocsGenerated checks that zz_generated.docs.go documents every
+// install-config type, so that it is regenerated when the types change.
+func TestDocsGenerated(t *testing.T) {
+	seen := map[reflect.Type]bool{}
+	var check func(t *testing.T, typ reflect.Type)
+	check = func(t *testing.T, typ reflect.Type) {
+		typ = structType(typ)
+		if typ == nil || seen[typ] {
+			return
+		}
+		seen[typ] = true
+
+		if _, ok := typeDocs[typeKey(typ)]; !ok {
+			t.Errorf("%s is not documented; run go generate ./pkg/explain", typeKey(typ))
+		}
+		for _, f := range jsonFields(typ) {
+			check(t, f.typ)
+		}
+	}
+	check(t, reflect.TypeOf(types.InstallConfig{}))
+}
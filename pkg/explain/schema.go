@@ -0,0 +1,162 @@
+package explain
+
+import (
+	"encoding/json"
+	"io"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	// SchemaID is the ID of the JSON Schema of the install-config, which
+	// is versioned with the install-config.
+	SchemaID = "urn:openshift:install-config:" + types.InstallConfigVersion
+
+	jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+)
+
+// versionPackage matches the names of packages named after their API
+// version, such as "v1beta1".
+var versionPackage = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+
+// Schema is a JSON Schema, or one of its subschemas.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	AllOf       []*Schema          `json:"allOf,omitempty"`
+
+	// AdditionalProperties is the schema of the values of maps.
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+
+	// MaxProperties is 1 for the objects which hold the configuration of
+	// a single platform.
+	MaxProperties *int `json:"maxProperties,omitempty"`
+
+	// Definitions are the schemas of the install-config types, which
+	// are referred to by $ref.
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// InstallConfigSchema returns the JSON Schema of the install-config, with
+// the documentation of its fields. The install-config types are in its
+// definitions, named like "aws.Platform", and the fields of each platform
+// are in the definitions of that platform's package. Like the installer,
+// the schema allows fields it does not know. It checks the form of the
+// fields, but not which are required or how they relate, which are left
+// to the validation of the install-config by the installer.
+func InstallConfigSchema() *Schema {
+	definitions := map[string]*Schema{}
+	root := reflect.TypeOf(types.InstallConfig{})
+	schema := structSchema(definitions, root)
+	schema.Schema = jsonSchemaDraft
+	schema.ID = SchemaID
+	schema.Title = root.Name() + " " + types.InstallConfigVersion
+	schema.Definitions = definitions
+	return schema
+}
+
+// WriteSchema writes the JSON Schema of the install-config to out.
+func WriteSchema(out io.Writer) error {
+	data, err := json.MarshalIndent(InstallConfigSchema(), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(append(data, '\n'))
+	return err
+}
+
+// typeSchema returns the schema of values of type t, adding the struct
+// types it refers to to definitions.
+func typeSchema(definitions map[string]*Schema, t reflect.Type) *Schema {
+	if isScalar(t) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeSchema(definitions, t.Elem())
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: typeSchema(definitions, t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: typeSchema(definitions, t.Elem())}
+	case reflect.Struct:
+		name := definitionName(t)
+		if _, ok := definitions[name]; !ok {
+			// Add a placeholder first, for recursive types.
+			definitions[name] = &Schema{}
+			definitions[name] = structSchema(definitions, t)
+		}
+		return &Schema{Ref: "#/definitions/" + name}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	default:
+		// Interfaces hold any value.
+		return &Schema{}
+	}
+}
+
+// structSchema returns the schema of the struct type t, with a property for
+// each of its JSON fields.
+func structSchema(definitions map[string]*Schema, t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:        "object",
+		Description: parseDoc(typeDocs[typeKey(t)][""]).description,
+		Properties:  map[string]*Schema{},
+	}
+	if platformTypes[t] {
+		one := 1
+		schema.MaxProperties = &one
+	}
+
+	for _, f := range jsonFields(t) {
+		property := typeSchema(definitions, f.typ)
+		d := parseDoc(fieldDoc(&f))
+		description := d.description
+		if d.defaults != "" {
+			description = strings.TrimSpace(description + "\n\n" + d.defaults)
+		}
+		if description != "" {
+			if property.Ref != "" {
+				// Keywords beside $ref are ignored by draft-07
+				// validators, so the reference is wrapped.
+				property = &Schema{Description: description, AllOf: []*Schema{property}}
+			} else {
+				property.Description = description
+			}
+		}
+		schema.Properties[f.name] = property
+	}
+	return schema
+}
+
+// definitionName returns the name of the definition of the struct type t,
+// such as "aws.Platform", or "meta.v1.ObjectMeta" for the packages named
+// after their API version.
+func definitionName(t reflect.Type) string {
+	dir, base := path.Split(t.PkgPath())
+	if versionPackage.MatchString(base) {
+		base = path.Base(dir) + "." + base
+	}
+	return base + "." + t.Name()
+}
@@ -0,0 +1,78 @@
+package explain
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallConfigSchema(t *testing.T) {
+	schema := InstallConfigSchema()
+	assert.Equal(t, "urn:openshift:install-config:v1beta1", schema.ID)
+	assert.Equal(t, "InstallConfig v1beta1", schema.Title)
+
+	baseDomain := schema.Properties["baseDomain"]
+	if assert.NotNil(t, baseDomain) {
+		assert.Equal(t, "string", baseDomain.Type)
+		assert.Equal(t, "BaseDomain is the base domain to which the cluster should belong.", baseDomain.Description)
+	}
+
+	platform := schema.Definitions["types.Platform"]
+	if assert.NotNil(t, platform) && assert.NotNil(t, platform.MaxProperties) {
+		assert.Equal(t, 1, *platform.MaxProperties)
+	}
+
+	aws := schema.Definitions["aws.Platform"]
+	if assert.NotNil(t, aws) {
+		assert.Equal(t, "Region specifies the AWS region where the cluster will be created.", aws.Properties["region"].Description)
+	}
+
+	networking := schema.Definitions["types.Networking"]
+	if assert.NotNil(t, networking) {
+		serviceCIDR := networking.Properties["serviceCIDR"]
+		assert.Equal(t, "string", serviceCIDR.Type)
+		assert.Contains(t, serviceCIDR.Description, "Default is 172.30.0.0/16.")
+	}
+
+	// Every reference is to a definition.
+	var check func(path string, s *Schema)
+	check = func(path string, s *Schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			if _, ok := schema.Definitions[strings.TrimPrefix(s.Ref, "#/definitions/")]; !ok {
+				t.Errorf("%s refers to the missing %s", path, s.Ref)
+			}
+		}
+		check(path+".items", s.Items)
+		check(path+".additionalProperties", s.AdditionalProperties)
+		for name, property := range s.Properties {
+			check(path+"."+name, property)
+		}
+		for _, sub := range s.AllOf {
+			check(path, sub)
+		}
+	}
+	check("", schema)
+	for name, definition := range schema.Definitions {
+		check(name, definition)
+	}
+}
+
+func TestWriteSchema(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteSchema(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "http://json-schema.org/draft-07/schema#", schema["$schema"])
+	assert.Contains(t, schema["definitions"], "meta.v1.ObjectMeta")
+}
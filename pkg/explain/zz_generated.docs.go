@@ -0,0 +1,392 @@
+// Code generated by docs_generate.go; DO NOT EDIT.
+
+package explain
+
+// typeDocs maps the name of each install-config type, qualified by its
+// package path, to the doc comments of the type (keyed by "") and of its
+// fields (keyed by the Go field name).
+var typeDocs = map[string]map[string]string{
+	"github.com/openshift/installer/pkg/types.APIServer": {
+		"":                      "APIServer is the configuration of the Kubernetes API server.\n",
+		"AdditionalDNSNames":    "AdditionalDNSNames are DNS names, in addition to the API URL of the\ncluster, for which the certificate generated for the API server is\nvalid, such as the name of an external load balancer.\n+optional\n",
+		"AdditionalIPAddresses": "AdditionalIPAddresses are IP addresses for which the certificate\ngenerated for the API server is valid, such as a virtual IP.\n+optional\n",
+		"AggregatorCA":          "AggregatorCA is the CA which signs the client certificate with which\nthe API server authenticates to aggregated API servers, instead of\none generated by the installer.\n+optional\n",
+		"AuditProfile":          "AuditProfile is the audit policy of the API server: Default, which\nlogs the metadata of all requests, WriteRequestBodies, which also\nlogs the bodies of the requests which modify objects, or\nAllRequestBodies, which logs the bodies of all requests.\n+optional\nDefault is Default.\n",
+		"ServingCertificate":    "ServingCertificate is the certificate served for the API URL of the\ncluster, instead of one signed by the cluster's root CA.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.BootstrapInPlace": {
+		"":                 "BootstrapInPlace is the configuration of a single-node cluster which\nbootstraps on its control-plane machine. The machine boots a live system\nwith the bootstrap Ignition config, brings up the control plane, then\ninstalls itself to the installation disk, with the state of the control\nplane, and reboots into the installed system.\n",
+		"InstallationDisk": "InstallationDisk is the disk to which the operating system is\ninstalled, such as /dev/sda.\n",
+	},
+	"github.com/openshift/installer/pkg/types.CertificateAuthority": {
+		"":            "CertificateAuthority is a PEM-encoded CA certificate and its private key.\n",
+		"Certificate": "Certificate is the PEM-encoded CA certificate.\n",
+		"Key":         "Key is the PEM-encoded PKCS #1 RSA private key of the certificate.\n",
+	},
+	"github.com/openshift/installer/pkg/types.ClusterVersionOverride": {
+		"":          "ClusterVersionOverride is a component of the release payload which the\ncluster-version operator leaves unmanaged.\n",
+		"Group":     "Group is the API group of the component, such as apps, or empty for\nthe core group.\n+optional\n",
+		"Kind":      "Kind is the kind of the component, such as Deployment.\n",
+		"Name":      "Name is the name of the component.\n",
+		"Namespace": "Namespace is the namespace of the component, or empty for\ncluster-scoped components.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.HTPasswdIdentityProvider": {
+		"":         "HTPasswdIdentityProvider authenticates users with an htpasswd file.\n",
+		"FileData": "FileData is the content of the htpasswd file, whose passwords must be\nbcrypt hashes, as written by htpasswd -B.\n",
+	},
+	"github.com/openshift/installer/pkg/types.Host": {
+		"":              "Host is a machine which is provisioned with its own Ignition config.\n",
+		"Name":          "Name is the hostname of the machine.\n",
+		"NetworkConfig": "NetworkConfig is the static network configuration of the machine.\n+optional\nDefault is to configure the network with DHCP.\n",
+		"Role":          "Role is the role of the machine: bootstrap, master, or worker.\n",
+	},
+	"github.com/openshift/installer/pkg/types.IdentityProvider": {
+		"":         "IdentityProvider is an identity provider of the cluster's OAuth server.\nExactly one of HTPasswd, LDAP, and OpenID must be set.\n",
+		"HTPasswd": "HTPasswd authenticates users with an htpasswd file.\n+optional\n",
+		"LDAP":     "LDAP authenticates users with an LDAP server.\n+optional\n",
+		"Name":     "Name is the name of the identity provider, which prefixes the names\nof its users' identities.\n",
+		"OpenID":   "OpenID authenticates users with an OpenID Connect provider.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.ImageContentSource": {
+		"":        "ImageContentSource is an image repository and its mirrors.\n",
+		"Mirrors": "Mirrors are the repositories from which the images of the source are\npulled instead, in order of preference. Images are only pulled from\nthe mirrors by digest.\n",
+		"Source":  "Source is the repository, such as\nquay.io/openshift-release-dev/ocp-release.\n",
+	},
+	"github.com/openshift/installer/pkg/types.ImageRegistry": {
+		"":        "ImageRegistry is the configuration of the internal image registry.\n",
+		"Removed": "Removed removes the registry, for clusters which neither build nor\npush images. No storage may be set then.\n+optional\n",
+		"Storage": "Storage is the storage of the registry.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.ImageRegistryEmptyDirStorage": {
+		"": "ImageRegistryEmptyDirStorage stores the images of the registry in an\nemptyDir volume.\n",
+	},
+	"github.com/openshift/installer/pkg/types.ImageRegistryS3Storage": {
+		"":       "ImageRegistryS3Storage stores the images of the registry in an S3 bucket.\n",
+		"Bucket": "Bucket is the name of the bucket, which the image registry operator\ncreates if it does not exist.\n",
+		"Region": "Region is the region of the bucket.\n+optional\nDefault is the region of the cluster.\n",
+	},
+	"github.com/openshift/installer/pkg/types.ImageRegistryStorage": {
+		"":         "ImageRegistryStorage is the storage of the internal image registry. At\nmost one of its fields may be set.\n",
+		"EmptyDir": "EmptyDir stores the images in the pod of the registry, so they are\nlost when it restarts. It is only meant for proofs of concept.\n+optional\n",
+		"S3":       "S3 stores the images in an S3 bucket. It is only supported on AWS.\n+optional\n",
+		"Swift":    "Swift stores the images in a Swift container. It is only supported\non OpenStack.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.ImageRegistrySwiftStorage": {
+		"":          "ImageRegistrySwiftStorage stores the images of the registry in a Swift\ncontainer.\n",
+		"Container": "Container is the name of the container, which the image registry\noperator creates if it does not exist.\n",
+	},
+	"github.com/openshift/installer/pkg/types.Ingress": {
+		"":                           "Ingress is the configuration of the default ingress controller.\n",
+		"DefaultCertificate":         "DefaultCertificate is the wildcard certificate served for the routes\nof the cluster, *.apps.<cluster name>.<base domain>, instead of one\ngenerated by the ingress operator.\n+optional\n",
+		"EndpointPublishingStrategy": "EndpointPublishingStrategy is how the routers are published:\nLoadBalancerService, HostNetwork, or Private.\n+optional\nDefault is LoadBalancerService on cloud platforms and HostNetwork\notherwise, as chosen by the ingress operator.\n",
+		"NodeSelector":               "NodeSelector selects the nodes on which the routers run.\n+optional\nDefault is the infra nodes if there is an infra pool, and the\nworkers otherwise.\n",
+		"Replicas":                   "Replicas is the number of routers of the default ingress controller.\n+optional\nDefault is 2, or fewer if the routers run on fewer user-provisioned\nmachines.\n",
+	},
+	"github.com/openshift/installer/pkg/types.InstallConfig": {
+		"":                        "InstallConfig is the configuration for an OpenShift install.\n",
+		"APIServer":               "APIServer is the configuration of the Kubernetes API server.\n+optional\n",
+		"BaseDomain":              "BaseDomain is the base domain to which the cluster should belong.\n",
+		"BootstrapInPlace":        "BootstrapInPlace configures a single-node cluster to bootstrap on its\nonly control-plane machine, instead of on a separate bootstrap\nmachine. It is only supported on the none platform.\n+optional\n",
+		"ClusterVersionOverrides": "ClusterVersionOverrides are the components of the release payload\nwhich the cluster-version operator leaves unmanaged, for example to\nrun clusters without some operators in CI or constrained\nenvironments.\n+optional\n",
+		"CredentialsMode":         "CredentialsMode is how the cluster gets its cloud credentials. In\nManual mode, the installer does not store its own credentials in the\ncluster, and administrators create the credentials of each component\nthemselves.\n+optional\nDefault is to store the installer's credentials in the cluster.\n",
+		"Hosts":                   "Hosts are machines which are provisioned with their own Ignition\nconfig, written to hosts/<name>.ign, for example to configure static\nIP addresses where there is no DHCP. They are only supported on the\nnone platform.\n+optional\n",
+		"IdentityProviders":       "IdentityProviders are the identity providers with which users log in\nto the cluster, so that administrators do not depend on the\ntemporary kubeadmin user.\n+optional\n",
+		"ImageContentSources":     "ImageContentSources are the mirrors from which the machines pull the\nimages of repositories, such as those of the release payload, for\nexample in disconnected networks.\n+optional\n",
+		"ImageRegistry":           "ImageRegistry is the configuration of the internal image registry.\n+optional\nDefault is to let the image registry operator choose the storage.\n",
+		"Ingress":                 "Ingress is the configuration of the default ingress controller.\n+optional\n",
+		"Kubeadmin":               "Kubeadmin is whether the installer creates the temporary kubeadmin\nuser and its password. When it is Disabled, for example because the\nidentity providers are configured or static passwords are not\nallowed, the only access to a new cluster is the certificate of the\nadmin kubeconfig.\n+optional\nDefault is to create the kubeadmin user.\n",
+		"Kubeconfigs":             "Kubeconfigs are the users, besides the admin, for whom a kubeconfig\nis generated, so that limited access to the cluster can be handed out\nwithout sharing the admin kubeconfig.\n+optional\n",
+		"MachineConfigServer":     "MachineConfigServer is the endpoint of the machine config server\nwhich the pointer Ignition configs of the machines reference, for\nexample a load balancer which serves it on a different port.\n+optional\n",
+		"Machines":                "Machines is the list of MachinePools that need to be installed.\n+optional\nDefault on AWS and OpenStack is 3 masters and 3 workers.\nDefault on Libvirt is 1 master and 1 worker.\n",
+		"NTPServers":              "NTPServers are the NTP servers, or pools, with which the machines\nsynchronize their clocks, instead of the default pools, for example\non networks from which those are unreachable.\n+optional\n",
+		"Networking":              "Networking defines the pod network provider in the cluster.\n",
+		"Platform":                "Platform is the configuration for the specific platform upon which to\nperform the installation.\n",
+		"Proxy":                   "Proxy is the HTTP proxy through which the machines reach the networks\noutside of the cluster, such as the registry of the release image.\n+optional\n",
+		"PullSecret":              "PullSecret is the secret to use when pulling images.\n",
+		"SSHKey":                  "SSHKey is the public ssh key to provide access to instances. It may\nhold several ed25519, ECDSA or RSA keys, one per line as in an\nauthorized_keys file.\n+optional\n",
+		"Scheduler":               "Scheduler is the configuration of the default scheduler.\n+optional\n",
+		"TypeMeta":                "+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.KubeProxy": {
+		"":                   "KubeProxy is the configuration of kube-proxy.\n",
+		"IptablesSyncPeriod": "IptablesSyncPeriod is the period with which the iptables rules are\nrefreshed, such as 30s or 1m.\n+optional\nDefault is 30s.\n",
+	},
+	"github.com/openshift/installer/pkg/types.Kubeconfig": {
+		"":       "Kubeconfig is a user for whom a kubeconfig is generated.\n",
+		"Groups": "Groups are the groups of the user, which are granted access by their\nrole bindings. For example, the system:cluster-readers group is bound\nto the cluster-reader role.\n+optional\n",
+		"Name":   "Name is the name of the user. The kubeconfig is written to\nauth/kubeconfig-<name>.\n",
+	},
+	"github.com/openshift/installer/pkg/types.LDAPIdentityProvider": {
+		"":             "LDAPIdentityProvider authenticates users with an LDAP server.\n",
+		"BindDN":       "BindDN is the DN with which to bind for the search.\n+optional\nDefault is an anonymous bind.\n",
+		"BindPassword": "BindPassword is the password with which to bind for the search.\n+optional\n",
+		"CA":           "CA is the PEM-encoded CA bundle with which to verify the certificate\nof the server.\n+optional\nDefault is the system CA bundle.\n",
+		"Insecure":     "Insecure connects to ldap:// URLs without StartTLS.\n+optional\n",
+		"URL":          "URL is the RFC 2255 URL of the LDAP server and of the search for\nusers, such as ldaps://ldap.example.com/ou=users,dc=example,dc=com?uid.\n",
+	},
+	"github.com/openshift/installer/pkg/types.MachineConfigServer": {
+		"":     "MachineConfigServer is the endpoint of the machine config server.\n",
+		"Host": "Host is the hostname or IP address of the endpoint.\n+optional\nDefault is the API hostname of the cluster, <name>-api.<base domain>.\n",
+		"Port": "Port is the port of the endpoint.\n+optional\nDefault is 49500.\n",
+	},
+	"github.com/openshift/installer/pkg/types.MachinePool": {
+		"":                  "MachinePool is a pool of machines to be installed.\n",
+		"Autoscaling":       "Autoscaling lets the cluster autoscaler scale the pool between a\nminimum and a maximum number of replicas. It is only supported for\nthe worker pool.\n",
+		"HealthCheck":       "HealthCheck lets the machine API replace the machines of the pool\nwhich become unhealthy. It is only supported for compute pools.\n",
+		"Name":              "Name is the name of the machine pool.\n",
+		"NameTemplate":      "NameTemplate is the template of the names of the machinesets of the\npool, after which their machines are named, of lowercase letters,\ndigits, hyphens and the {clusterName}, {role} and {zone}\nplaceholders, e.g. \"ocp-{role}-{zone}\". It is only supported for the\nworker pool.\nDefault is \"{clusterName}-{role}-{zone}\".\n+optional\n",
+		"PasswordHash":      "PasswordHash is the crypt(3) hash of the password of the core user\non the machines in the pool, for logging in on the console, such as\ngenerated by \"openssl passwd -6\".\n",
+		"Platform":          "Platform is configuration for machine pool specific to the platfrom.\n",
+		"ProviderSpecPatch": "ProviderSpecPatch is a patch applied to the providerSpec of the\nmachines of the pool, for the options of the machine API provider\nwhich the install-config does not cover.\n+optional\n",
+		"Replicas":          "Replicas is the count of machines for this machine pool.\nDefault is 1.\n",
+		"SSHKeys":           "SSHKeys are public SSH keys which, in addition to the sshKey of the\ncluster, provide access to the machines in the pool.\n",
+		"Storage":           "Storage is the disk configuration of the machines in the pool.\n",
+		"UserProvisioned":   "UserProvisioned marks the machines of the pool as provisioned by the\nuser, who boots them with the Ignition config of the pool, so the\ninstaller creates no machinesets for them. Their replicas still size\nthe cluster manifests, such as the replicas of the routers. It is not\nsupported for the master pool.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.MachinePoolAutoscaling": {
+		"":            "MachinePoolAutoscaling is the range of replicas within which the cluster\nautoscaler scales a machine pool.\n",
+		"MaxReplicas": "MaxReplicas is the maximum count of machines for the machine pool.\n",
+		"MinReplicas": "MinReplicas is the minimum count of machines for the machine pool.\n",
+	},
+	"github.com/openshift/installer/pkg/types.MachinePoolHealthCheck": {
+		"":                    "MachinePoolHealthCheck is the configuration of the remediation of the\nunhealthy machines of a machine pool.\n",
+		"UnhealthyConditions": "UnhealthyConditions are the node conditions which mark a machine\nunhealthy when they last longer than their timeout. The machine API\napplies the same conditions to all the pools, so the pools which set\nthem must set the same ones.\nDefault is the Ready condition being False or Unknown for 5 minutes.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.MachinePoolPlatform": {
+		"":          "MachinePoolPlatform is the platform-specific configuration for a machine\npool. Only one of the platforms should be set.\n",
+		"AWS":       "AWS is the configuration used when installing on AWS.\n",
+		"Libvirt":   "Libvirt is the configuration used when installing on libvirt.\n",
+		"OpenStack": "OpenStack is the configuration used when installing on OpenStack.\n",
+	},
+	"github.com/openshift/installer/pkg/types.MachinePoolStorage": {
+		"":           "MachinePoolStorage is the disk configuration of the machines in a pool.\n",
+		"Multipath":  "Multipath enables multipath for the root disk of the machines.\n",
+		"Partitions": "Partitions are additional partitions, which are created, formatted\nwith XFS and mounted on the first boot of the machines.\n",
+	},
+	"github.com/openshift/installer/pkg/types.NetworkAddresses": {
+		"":          "NetworkAddresses are the static addresses of a network interface.\n",
+		"Addresses": "Addresses are the addresses of the interface, in CIDR notation, such\nas 192.168.1.10/24.\n+optional\n",
+		"DNS":       "DNS are the addresses of the DNS servers reached through the\ninterface.\n+optional\n",
+		"Gateway":   "Gateway is the default gateway reached through the interface.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.NetworkBond": {
+		"":           "NetworkBond is a bond of network interfaces. Its addresses may be left\nout when only its VLANs have addresses.\n",
+		"Interfaces": "Interfaces are the names or MAC addresses of the interfaces in the\nbond.\n",
+		"Mode":       "Mode is the bonding mode, such as active-backup or 802.3ad.\n+optional\nDefault is active-backup.\n",
+		"Name":       "Name is the name of the bond, such as bond0.\n",
+	},
+	"github.com/openshift/installer/pkg/types.NetworkConfig": {
+		"":           "NetworkConfig is the static network configuration of a machine.\n",
+		"Bonds":      "Bonds are the bonds of network interfaces.\n+optional\n",
+		"Interfaces": "Interfaces are the network interfaces with static addresses.\n+optional\n",
+		"VLANs":      "VLANs are the VLAN sub-interfaces of network interfaces or bonds.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.NetworkInterface": {
+		"":           "NetworkInterface is the static configuration of a network interface,\nwhich is matched by either its name or its MAC address.\n",
+		"MACAddress": "MACAddress is the MAC address of the interface.\n+optional\n",
+		"Name":       "Name is the name of the interface, such as eno1.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.NetworkVLAN": {
+		"":          "NetworkVLAN is a VLAN sub-interface of a network interface or bond.\n",
+		"ID":        "ID is the VLAN ID.\n",
+		"Interface": "Interface is the name of the interface or bond of the VLAN.\n",
+		"Name":      "Name is the name of the VLAN interface.\n+optional\nDefault is <interface>.<id>.\n",
+	},
+	"github.com/openshift/installer/pkg/types.Networking": {
+		"":                "Networking defines the pod network provider in the cluster.\n",
+		"ClusterNetworks": "ClusterNetworks is the IP address space from which to assign pod IPs.\n+optional\nDefault is a single cluster network with a CIDR of 10.128.0.0/14\nand a host subnet length of 9. The default is only applicable if PodCIDR\nis not present.\n",
+		"KubeProxy":       "KubeProxy is the configuration of kube-proxy, which is only consumed\nby the OpenshiftSDN network type.\n+optional\n",
+		"MachineCIDR":     "MachineCIDR is the IP address space from which to assign machine IPs.\n+optional\nDefault is 10.0.0.0/16 for all platforms other than Libvirt.\nFor Libvirt, the default is 192.168.126.0/24.\n",
+		"OpenshiftSDN":    "OpenshiftSDN is the configuration of the OpenshiftSDN network type.\n+optional\n",
+		"PodCIDR":         "PodCIDR is deprecated (and badly named; it should have always\nbeen called ClusterCIDR. If no ClusterNetworks are specified,\nwe will fall back to the PodCIDR\nTODO(cdc) remove this.\n+optional\n",
+		"ServiceCIDR":     "ServiceCIDR is the IP address space from which to assign service IPs.\n+optional\nDefault is 172.30.0.0/16.\n",
+		"Type":            "Type is the network type to install\n+optional\nDefault is OpenshiftSDN.\n",
+	},
+	"github.com/openshift/installer/pkg/types.OpenIDIdentityProvider": {
+		"":             "OpenIDIdentityProvider authenticates users with an OpenID Connect provider.\n",
+		"AuthorizeURL": "AuthorizeURL is the authorization endpoint of the provider.\n",
+		"CA":           "CA is the PEM-encoded CA bundle with which to verify the certificates\nof the endpoints.\n+optional\nDefault is the system CA bundle.\n",
+		"ClientID":     "ClientID is the ID of the OAuth client registered with the provider.\n",
+		"ClientSecret": "ClientSecret is the secret of the OAuth client.\n",
+		"TokenURL":     "TokenURL is the token endpoint of the provider.\n",
+		"UserInfoURL":  "UserInfoURL is the userinfo endpoint of the provider.\n+optional\nDefault is to read the claims from the ID token.\n",
+	},
+	"github.com/openshift/installer/pkg/types.OpenshiftSDN": {
+		"":          "OpenshiftSDN is the configuration of the OpenshiftSDN network type.\n",
+		"MTU":       "MTU is the MTU of the VXLAN tunnel interface, which must leave room\nfor the 50-byte VXLAN header within the MTU of the machine network.\n+optional\nDefault is 1450.\n",
+		"VXLANPort": "VXLANPort is the UDP port of the VXLAN tunnel between the nodes, for\nexample when the default port is already used by the network.\n+optional\nDefault is 4789.\n",
+	},
+	"github.com/openshift/installer/pkg/types.Partition": {
+		"":          "Partition is an additional partition of the machines in a pool.\n",
+		"Device":    "Device is the disk of the partition (e.g. /dev/sdb).\n",
+		"MountPath": "MountPath is where the partition is mounted (e.g.\n/var/lib/containers).\n",
+		"SizeMiB":   "SizeMiB is the size of the partition, in MiB.\nDefault is the rest of the free space.\n",
+		"StartMiB":  "StartMiB is the offset of the partition on the disk, in MiB. It is\nrequired for partitions on the root disk, whose root partition grows\nto fill the disk on first boot.\nDefault is the start of the largest free space on the disk.\n",
+	},
+	"github.com/openshift/installer/pkg/types.Platform": {
+		"":          "Platform is the configuration for the specific platform upon which to perform\nthe installation. Only one of the platform configuration should be set.\n",
+		"AWS":       "AWS is the configuration used when installing on AWS.\n+optional\n",
+		"Libvirt":   "Libvirt is the configuration used when installing on libvirt.\n+optional\n",
+		"None":      "None is the empty configuration used when installing on an unsupported\nplatform.\n",
+		"OpenStack": "OpenStack is the configuration used when installing on OpenStack.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.ProviderSpecPatch": {
+		"":      "ProviderSpecPatch is a patch applied to the providerSpec of machines.\n",
+		"Patch": "Patch is the patch. It is an object for merge patches and a list\nof operations for JSON patches.\n",
+		"Type":  "Type is the type of the patch: merge or json.\nDefault is merge.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.Proxy": {
+		"":           "Proxy is the configuration of an HTTP proxy.\n",
+		"HTTPProxy":  "HTTPProxy is the URL of the proxy for HTTP requests, such as\nhttp://proxy.example.com:3128.\n+optional\n",
+		"HTTPSProxy": "HTTPSProxy is the URL of the proxy for HTTPS requests.\n+optional\n",
+		"NoProxy":    "NoProxy is a comma-separated list of the domains, IP addresses and\nCIDRs which are reached without the proxy, in addition to those of\nthe cluster.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types.Scheduler": {
+		"":                    "Scheduler is the configuration of the default scheduler.\n",
+		"DefaultNodeSelector": "DefaultNodeSelector is the label selector, for example\n\"node-role.kubernetes.io/worker=\", which is added to the pods of\nprojects without their own node selector.\n+optional\n",
+		"MastersSchedulable":  "MastersSchedulable allows the scheduler to place regular workloads on\nthe control-plane machines, for example in compact clusters without\ncompute machines.\n+optional\n",
+		"Profile":             "Profile is the scoring profile of the scheduler: LowNodeUtilization,\nwhich spreads pods across nodes, HighNodeUtilization, which packs\npods onto as few nodes as possible, or NoScoring, which skips scoring\nfor the lowest scheduling latency.\n+optional\nDefault is LowNodeUtilization.\n",
+	},
+	"github.com/openshift/installer/pkg/types.ServingCertificate": {
+		"":            "ServingCertificate is a PEM-encoded serving certificate and its private key.\n",
+		"Certificate": "Certificate is the PEM-encoded certificate, followed by the\nPEM-encoded intermediate certificates of its chain, if any.\n",
+		"Key":         "Key is the PEM-encoded private key of the certificate.\n",
+	},
+	"github.com/openshift/installer/pkg/types.UnhealthyCondition": {
+		"":        "UnhealthyCondition is a node condition which marks a machine unhealthy.\n",
+		"Status":  "Status is the status of the condition: True, False or Unknown.\n",
+		"Timeout": "Timeout is how long the condition must last, such as 5m.\n",
+		"Type":    "Type is the type of the node condition (e.g. Ready).\n",
+	},
+	"github.com/openshift/installer/pkg/types/aws.EC2RootVolume": {
+		"":     "EC2RootVolume defines the storage for an ec2 instance.\n",
+		"IOPS": "IOPS defines the iops for the storage.\n",
+		"Size": "Size defines the size of the storage.\n",
+		"Type": "Type defines the type of the storage.\n",
+	},
+	"github.com/openshift/installer/pkg/types/aws.MachinePool": {
+		"":              "MachinePool stores the configuration for a machine pool installed\non AWS.\n",
+		"EC2RootVolume": "EC2RootVolume defines the storage for ec2 instance.\n",
+		"IAMRoleName":   "IAMRoleName defines the IAM role associated\nwith the ec2 instance.\n",
+		"InstanceType":  "InstanceType defines the ec2 instance type.\neg. m4-large\n",
+		"OSImage":       "OSImage is the AMI which the machines of the pool boot, such as a\nderivative of RHCOS with extra drivers, instead of the osImage of\nthe platform. It is only supported for the compute pools, whose\nmachine sets boot it.\n+optional\n",
+		"Subnet":        "Subnet is the type of the subnets of the zones in which the machines\nare placed. The workers default to the private subnets. Subnet IDs\nare not supported, because the installer creates the VPC.\n+optional\n",
+		"Zones":         "Zones is list of availability zones that can be used.\n",
+	},
+	"github.com/openshift/installer/pkg/types/aws.Platform": {
+		"":                       "Platform stores all the global configuration that all machinesets\nuse.\n",
+		"DefaultMachinePlatform": "DefaultMachinePlatform is the default configuration used when\ninstalling on AWS for machine pools which do not define their own\nplatform configuration.\n+optional\n",
+		"OSImage":                "OSImage is the AMI of the RHCOS build which the machines boot,\nsuch as ami-0123456789abcdef0, instead of the AMI of the installer's\nRHCOS build in the region.\n+optional\n",
+		"Region":                 "Region specifies the AWS region where the cluster will be created.\n",
+		"UserTags":               "UserTags specifies additional tags for AWS resources created for the cluster.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types/libvirt.MachinePool": {
+		"": "MachinePool stores the configuration for a machine pool installed\non libvirt.\n",
+	},
+	"github.com/openshift/installer/pkg/types/libvirt.Network": {
+		"":       "Network is the configuration of the libvirt network.\n",
+		"IfName": "+optional\nDefault is tt0.\n",
+	},
+	"github.com/openshift/installer/pkg/types/libvirt.Platform": {
+		"":                       "Platform stores all the global configuration that all\nmachinesets use.\n",
+		"DefaultMachinePlatform": "DefaultMachinePlatform is the default configuration used when\ninstalling on libvirt for machine pools which do not define their\nown platform configuration.\n+optional\nDefault will set the image field to the latest RHCOS image.\n",
+		"MasterIPs":              "MasterIPs\n+optional\n",
+		"Network":                "Network\n+optional\n",
+		"OSImage":                "OSImage is the URL of the QEMU image of the RHCOS build which the\nmachines boot, such as file:///var/lib/rhcos/rhcos-qemu.qcow2,\ninstead of the image of the installer's RHCOS build.\n+optional\n",
+		"URI":                    "URI is the identifier for the libvirtd connection.  It must be\nreachable from both the host (where the installer is run) and the\ncluster (where the cluster-API controller pod will be running).\n+optional\nDefault is qemu+tcp://192.168.122.1/system\n",
+	},
+	"github.com/openshift/installer/pkg/types/none.Platform": {
+		"": "Platform stores any global configuration used for generic\nplatforms.\n",
+	},
+	"github.com/openshift/installer/pkg/types/openstack.MachinePool": {
+		"":           "MachinePool stores the configuration for a machine pool installed\non OpenStack.\n",
+		"FlavorName": "FlavorName defines the OpenStack Nova flavor.\neg. m1.large\n",
+		"OSImage":    "OSImage is the name of the Glance image which the machines of the\npool boot, instead of the osImage of the platform. It is only\nsupported for the compute pools, whose machine sets boot it.\n+optional\n",
+		"Zones":      "Zones is the list of Nova availability zones that can be used.\n+optional\n",
+	},
+	"github.com/openshift/installer/pkg/types/openstack.Platform": {
+		"":                       "Platform stores all the global configuration that all\nmachinesets use.\n",
+		"Cloud":                  "Cloud\nName of OpenStack cloud to use from clouds.yaml\n",
+		"DefaultMachinePlatform": "DefaultMachinePlatform is the default configuration used when\ninstalling on OpenStack for machine pools which do not define their own\nplatform configuration.\n+optional\n",
+		"ExternalNetwork":        "ExternalNetwork\nThe OpenStack external network to be used for installation.\n",
+		"FlavorName":             "FlavorName\nThe OpenStack compute flavor to use for servers.\n",
+		"OSImage":                "OSImage is the name of the Glance image of the RHCOS build which the\nmachines boot.\n+optional\nDefault is \"rhcos\".\n",
+		"Region":                 "Region specifies the OpenStack region where the cluster will be created.\n",
+		"TrunkSupport":           "TrunkSupport\nWhether OpenStack ports can be trunked\n",
+	},
+	"github.com/openshift/installer/vendor/github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1.ClusterNetwork": {
+		"": "ClusterNetwork is a subnet from which to allocate PodIPs. A network of size\n2^HostSubnetLength will be allocated when nodes join the cluster.\nNot all network providers support multiple ClusterNetworks\n",
+	},
+	"github.com/openshift/installer/vendor/k8s.io/apimachinery/pkg/apis/meta/v1.Initializer": {
+		"":     "Initializer is information about an initializer that has not yet completed.\n",
+		"Name": "name of the process that is responsible for initializing this object.\n",
+	},
+	"github.com/openshift/installer/vendor/k8s.io/apimachinery/pkg/apis/meta/v1.Initializers": {
+		"":        "Initializers tracks the progress of initialization.\n",
+		"Pending": "Pending is a list of initializers that must execute in order before this object is visible.\nWhen the last pending initializer is removed, and no failing result is set, the initializers\nstruct will be set to nil and the object is considered as initialized and visible to all\nclients.\n+patchMergeKey=name\n+patchStrategy=merge\n",
+		"Result":  "If result is set with the Failure field, the object will be persisted to storage and then deleted,\nensuring that other clients can observe the deletion.\n",
+	},
+	"github.com/openshift/installer/vendor/k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta": {
+		"":                "ListMeta describes metadata that synthetic resources must have, including lists and\nvarious status objects. A resource may have only one of {ObjectMeta, ListMeta}.\n",
+		"Continue":        "continue may be set if the user set a limit on the number of items returned, and indicates that\nthe server has more data available. The value is opaque and may be used to issue another request\nto the endpoint that served this list to retrieve the next set of available objects. Continuing a\nconsistent list may not be possible if the server configuration has changed or more than a few\nminutes have passed. The resourceVersion field returned when using this continue value will be\nidentical to the value in the first response, unless you have received this token from an error\nmessage.\n",
+		"ResourceVersion": "String that identifies the server's internal version of this object that\ncan be used by clients to determine when objects have changed.\nValue must be treated as opaque by clients and passed unmodified back to the server.\nPopulated by the system.\nRead-only.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#concurrency-control-and-consistency\n+optional\n",
+		"SelfLink":        "selfLink is a URL representing this object.\nPopulated by the system.\nRead-only.\n+optional\n",
+	},
+	"github.com/openshift/installer/vendor/k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta": {
+		"":                           "ObjectMeta is metadata that all persisted resources must have, which includes all objects\nusers must create.\n",
+		"Annotations":                "Annotations is an unstructured key value map stored with a resource that may be\nset by external tools to store and retrieve arbitrary metadata. They are not\nqueryable and should be preserved when modifying objects.\nMore info: http://kubernetes.io/docs/user-guide/annotations\n+optional\n",
+		"ClusterName":                "The name of the cluster which the object belongs to.\nThis is used to distinguish resources with same name and namespace in different clusters.\nThis field is not set anywhere right now and apiserver is going to ignore it if set in create or update request.\n+optional\n",
+		"CreationTimestamp":          "CreationTimestamp is a timestamp representing the server time when this object was\ncreated. It is not guaranteed to be set in happens-before order across separate operations.\nClients may not set this value. It is represented in RFC3339 form and is in UTC.\n\nPopulated by the system.\nRead-only.\nNull for lists.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata\n+optional\n",
+		"DeletionGracePeriodSeconds": "Number of seconds allowed for this object to gracefully terminate before\nit will be removed from the system. Only set when deletionTimestamp is also set.\nMay only be shortened.\nRead-only.\n+optional\n",
+		"DeletionTimestamp":          "DeletionTimestamp is RFC 3339 date and time at which this resource will be deleted. This\nfield is set by the server when a graceful deletion is requested by the user, and is not\ndirectly settable by a client. The resource is expected to be deleted (no longer visible\nfrom resource lists, and not reachable by name) after the time in this field, once the\nfinalizers list is empty. As long as the finalizers list contains items, deletion is blocked.\nOnce the deletionTimestamp is set, this value may not be unset or be set further into the\nfuture, although it may be shortened or the resource may be deleted prior to this time.\nFor example, a user may request that a pod is deleted in 30 seconds. The Kubelet will react\nby sending a graceful termination signal to the containers in the pod. After that 30 seconds,\nthe Kubelet will send a hard termination signal (SIGKILL) to the container and after cleanup,\nremove the pod from the API. In the presence of network partitions, this object may still\nexist after this timestamp, until an administrator or automated process can determine the\nresource is fully terminated.\nIf not set, graceful deletion of the object has not been requested.\n\nPopulated by the system when a graceful deletion is requested.\nRead-only.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata\n+optional\n",
+		"Finalizers":                 "Must be empty before the object is deleted from the registry. Each entry\nis an identifier for the responsible component that will remove the entry\nfrom the list. If the deletionTimestamp of the object is non-nil, entries\nin this list can only be removed.\n+optional\n+patchStrategy=merge\n",
+		"GenerateName":               "GenerateName is an optional prefix, used by the server, to generate a unique\nname ONLY IF the Name field has not been provided.\nIf this field is used, the name returned to the client will be different\nthan the name passed. This value will also be combined with a unique suffix.\nThe provided value has the same validation rules as the Name field,\nand may be truncated by the length of the suffix required to make the value\nunique on the server.\n\nIf this field is specified and the generated name exists, the server will\nNOT return a 409 - instead, it will either return 201 Created or 500 with Reason\nServerTimeout indicating a unique name could not be found in the time allotted, and the client\nshould retry (optionally after the time indicated in the Retry-After header).\n\nApplied only if Name is not specified.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#idempotency\n+optional\n",
+		"Generation":                 "A sequence number representing a specific generation of the desired state.\nPopulated by the system. Read-only.\n+optional\n",
+		"Initializers":               "An initializer is a controller which enforces some system invariant at object creation time.\nThis field is a list of initializers that have not yet acted on this object. If nil or empty,\nthis object has been completely initialized. Otherwise, the object is considered uninitialized\nand is hidden (in list/watch and get calls) from clients that haven't explicitly asked to\nobserve uninitialized objects.\n\nWhen an object is created, the system will populate this list with the current set of initializers.\nOnly privileged users may set or modify this list. Once it is empty, it may not be modified further\nby any user.\n",
+		"Labels":                     "Map of string keys and values that can be used to organize and categorize\n(scope and select) objects. May match selectors of replication controllers\nand services.\nMore info: http://kubernetes.io/docs/user-guide/labels\n+optional\n",
+		"Name":                       "Name must be unique within a namespace. Is required when creating resources, although\nsome resources may allow a client to request the generation of an appropriate name\nautomatically. Name is primarily intended for creation idempotence and configuration\ndefinition.\nCannot be updated.\nMore info: http://kubernetes.io/docs/user-guide/identifiers#names\n+optional\n",
+		"Namespace":                  "Namespace defines the space within each name must be unique. An empty namespace is\nequivalent to the \"default\" namespace, but \"default\" is the canonical representation.\nNot all objects are required to be scoped to a namespace - the value of this field for\nthose objects will be empty.\n\nMust be a DNS_LABEL.\nCannot be updated.\nMore info: http://kubernetes.io/docs/user-guide/namespaces\n+optional\n",
+		"OwnerReferences":            "List of objects depended by this object. If ALL objects in the list have\nbeen deleted, this object will be garbage collected. If this object is managed by a controller,\nthen an entry in this list will point to this controller, with the controller field set to true.\nThere cannot be more than one managing controller.\n+optional\n+patchMergeKey=uid\n+patchStrategy=merge\n",
+		"ResourceVersion":            "An opaque value that represents the internal version of this object that can\nbe used by clients to determine when objects have changed. May be used for optimistic\nconcurrency, change detection, and the watch operation on a resource or set of resources.\nClients must treat these values as opaque and passed unmodified back to the server.\nThey may only be valid for a particular resource or set of resources.\n\nPopulated by the system.\nRead-only.\nValue must be treated as opaque by clients and .\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#concurrency-control-and-consistency\n+optional\n",
+		"SelfLink":                   "SelfLink is a URL representing this object.\nPopulated by the system.\nRead-only.\n+optional\n",
+		"UID":                        "UID is the unique in time and space value for this object. It is typically generated by\nthe server on successful creation of a resource and is not allowed to change on PUT\noperations.\n\nPopulated by the system.\nRead-only.\nMore info: http://kubernetes.io/docs/user-guide/identifiers#uids\n+optional\n",
+	},
+	"github.com/openshift/installer/vendor/k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference": {
+		"":                   "OwnerReference contains enough information to let you identify an owning\nobject. Currently, an owning object must be in the same namespace, so there\nis no namespace field.\n",
+		"APIVersion":         "API version of the referent.\n",
+		"BlockOwnerDeletion": "If true, AND if the owner has the \"foregroundDeletion\" finalizer, then\nthe owner cannot be deleted from the key-value store until this\nreference is removed.\nDefaults to false.\nTo set this field, a user needs \"delete\" permission of the owner,\notherwise 422 (Unprocessable Entity) will be returned.\n+optional\n",
+		"Controller":         "If true, this reference points to the managing controller.\n+optional\n",
+		"Kind":               "Kind of the referent.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds\n",
+		"Name":               "Name of the referent.\nMore info: http://kubernetes.io/docs/user-guide/identifiers#names\n",
+		"UID":                "UID of the referent.\nMore info: http://kubernetes.io/docs/user-guide/identifiers#uids\n",
+	},
+	"github.com/openshift/installer/vendor/k8s.io/apimachinery/pkg/apis/meta/v1.Status": {
+		"":         "Status is a return value for calls that don't return other objects.\n",
+		"Code":     "Suggested HTTP return code for this status, 0 if not set.\n+optional\n",
+		"Details":  "Extended data associated with the reason.  Each reason may define its\nown extended details. This field is optional and the data returned\nis not guaranteed to conform to any schema except that defined by\nthe reason type.\n+optional\n",
+		"ListMeta": "Standard list metadata.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds\n+optional\n",
+		"Message":  "A human-readable description of the status of this operation.\n+optional\n",
+		"Reason":   "A machine-readable description of why this operation is in the\n\"Failure\" status. If this value is empty there\nis no information available. A Reason clarifies an HTTP status\ncode but does not override it.\n+optional\n",
+		"Status":   "Status of the operation.\nOne of: \"Success\" or \"Failure\".\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status\n+optional\n",
+	},
+	"github.com/openshift/installer/vendor/k8s.io/apimachinery/pkg/apis/meta/v1.StatusCause": {
+		"":        "StatusCause provides more information about an api.Status failure, including\ncases when multiple errors are encountered.\n",
+		"Field":   "The field of the resource that has caused this error, as named by its JSON\nserialization. May include dot and postfix notation for nested attributes.\nArrays are zero-indexed.  Fields may appear more than once in an array of\ncauses due to fields having multiple errors.\nOptional.\n\nExamples:\n  \"name\" - the field \"name\" on the current resource\n  \"items[0].name\" - the field \"name\" on the first array entry in \"items\"\n+optional\n",
+		"Message": "A human-readable description of the cause of the error.  This field may be\npresented as-is to a reader.\n+optional\n",
+		"Type":    "A machine-readable description of the cause of the error. If this value is\nempty there is no information available.\n+optional\n",
+	},
+	"github.com/openshift/installer/vendor/k8s.io/apimachinery/pkg/apis/meta/v1.StatusDetails": {
+		"":                  "StatusDetails is a set of additional properties that MAY be set by the\nserver to provide additional information about a response. The Reason\nfield of a Status object defines what attributes will be set. Clients\nmust ignore fields that do not match the defined type of each attribute,\nand should assume that any attribute may be empty, invalid, or under\ndefined.\n",
+		"Causes":            "The Causes array includes more details associated with the StatusReason\nfailure. Not all StatusReasons may provide detailed causes.\n+optional\n",
+		"Group":             "The group attribute of the resource associated with the status StatusReason.\n+optional\n",
+		"Kind":              "The kind attribute of the resource associated with the status StatusReason.\nOn some operations may differ from the requested resource Kind.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds\n+optional\n",
+		"Name":              "The name attribute of the resource associated with the status StatusReason\n(when there is a single name which can be described).\n+optional\n",
+		"RetryAfterSeconds": "If specified, the time in seconds before the operation should be retried. Some errors may indicate\nthe client must take an alternate action - for those errors this field may indicate how long to wait\nbefore taking the alternate action.\n+optional\n",
+		"UID":               "UID of the resource.\n(when there is a single resource which can be described).\nMore info: http://kubernetes.io/docs/user-guide/identifiers#uids\n+optional\n",
+	},
+	"github.com/openshift/installer/vendor/k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta": {
+		"":           "TypeMeta describes an individual object in an API response or request\nwith strings representing the type of the object and its API schema version.\nStructures that are versioned or persisted should inline TypeMeta.\n\n+k8s:deepcopy-gen=false\n",
+		"APIVersion": "APIVersion defines the versioned schema of this representation of an object.\nServers should convert recognized schemas to the latest internal value, and\nmay reject unrecognized values.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources\n+optional\n",
+		"Kind":       "Kind is a string value representing the REST resource this object represents.\nServers may infer this from the endpoint the client submits requests to.\nCannot be updated.\nIn CamelCase.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds\n+optional\n",
+	},
+}
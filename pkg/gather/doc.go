@@ -0,0 +1,3 @@
+// Package gather collects logs from cluster machines for debugging failed
+// installs.
+package gather
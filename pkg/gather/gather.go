@@ -0,0 +1,233 @@
+package gather
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/openshift/installer/data"
+	"github.com/openshift/installer/pkg/lineprinter"
+	"github.com/openshift/installer/pkg/redact"
+)
+
+const (
+	// gatherScript is the script run on each machine to collect its logs.
+	// It is also installed on the bootstrap machine.
+	gatherScript = "bootstrap/files/usr/local/bin/installer-gather.sh"
+)
+
+// Hosts are the addresses of the machines from which logs are gathered.
+type Hosts struct {
+	// Bootstrap is the address of the bootstrap machine. It must be
+	// reachable by the installer.
+	Bootstrap string
+
+	// Masters are the addresses of the master machines. They only need
+	// to be reachable from the bootstrap machine.
+	Masters []string
+}
+
+// Bootstrap gathers logs from the bootstrap machine and, through it, from
+// the masters, using the SSH private keys at keys (or the user's default
+// keys, if keys is empty), and adds the installer's files to them under
+// installer/. The logs are written to a tarball in directory, whose path is
+// returned. Failures to reach individual masters are logged but do not fail
+// the gathering, and neither do failures to reach the bootstrap machine, or
+// a missing bootstrap address, as long as there are installer files to
+// bundle.
+func Bootstrap(directory string, hosts *Hosts, keys []string, files []File) (_ string, err error) {
+	if hosts.Bootstrap == "" && len(files) == 0 {
+		return "", errors.New("no bootstrap host")
+	}
+
+	bundle := filepath.Join(directory, fmt.Sprintf("log-bundle-%s.tar.gz", time.Now().UTC().Format("20060102150405")))
+	file, err := os.Create(bundle)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create log bundle")
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(bundle)
+		}
+	}()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, f := range files {
+		data := redact.Bytes(f.Data)
+		header := &tar.Header{
+			Name:     path.Join("installer", f.Name),
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(data)),
+			ModTime:  time.Now(),
+		}
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return "", errors.Wrap(err, "failed to write log bundle")
+		}
+		if _, err = tarWriter.Write(data); err != nil {
+			return "", errors.Wrap(err, "failed to write log bundle")
+		}
+	}
+
+	if hosts.Bootstrap != "" {
+		if gatherErr := gatherMachines(hosts, keys, tarWriter); gatherErr != nil {
+			if len(files) == 0 {
+				return "", errors.Wrap(gatherErr, "failed to gather bootstrap logs")
+			}
+			logrus.Warnf("Failed to gather bootstrap logs: %v", gatherErr)
+		}
+	}
+
+	if err = tarWriter.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to write log bundle")
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to write log bundle")
+	}
+	return bundle, nil
+}
+
+// gatherMachines gathers logs from the bootstrap machine and, through it,
+// from the masters into tarWriter.
+func gatherMachines(hosts *Hosts, keys []string, tarWriter *tar.Writer) error {
+	script, err := readScript()
+	if err != nil {
+		return err
+	}
+
+	signers, err := loadSigners(keys)
+	if err != nil {
+		return err
+	}
+	config := clientConfig(signers)
+
+	bootstrap, err := dial(hosts.Bootstrap, config)
+	if err != nil {
+		return err
+	}
+	defer bootstrap.Close()
+
+	logrus.Infof("Gathering bootstrap logs from %s...", hosts.Bootstrap)
+	if err := gatherHost(bootstrap, script, tarWriter, "bootstrap"); err != nil {
+		return err
+	}
+
+	for _, master := range hosts.Masters {
+		logrus.Infof("Gathering master logs from %s...", master)
+		client, err := dialThrough(bootstrap, master, config)
+		if err != nil {
+			logrus.Warn(err)
+			continue
+		}
+		err = gatherHost(client, script, tarWriter, path.Join("control-plane", master))
+		client.Close()
+		if err != nil {
+			logrus.Warnf("Failed to gather logs from %s: %v", master, err)
+		}
+	}
+	return nil
+}
+
+func readScript() ([]byte, error) {
+	file, err := data.Assets.Open(gatherScript)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ioutil.ReadAll(file)
+}
+
+// gatherHost runs the gather script on the machine behind client and adds
+// the collected files to tarWriter, under prefix.
+func gatherHost(client *ssh.Client, script []byte, tarWriter *tar.Writer, prefix string) error {
+	session, err := client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	tDebug := &lineprinter.Trimmer{WrappedPrint: logrus.Debug}
+	lpDebug := &lineprinter.LinePrinter{Print: tDebug.Print}
+	defer lpDebug.Close()
+
+	// Buffer the remote tarball so a connection dropped half-way through
+	// doesn't leave a truncated entry in the bundle.
+	var stdout bytes.Buffer
+	session.Stdin = bytes.NewReader(script)
+	session.Stdout = &stdout
+	session.Stderr = lpDebug
+	if err := session.Run("sudo bash -s"); err != nil {
+		return errors.Wrap(err, "failed to run the gather script")
+	}
+
+	return copyArchive(&stdout, tarWriter, prefix)
+}
+
+// copyArchive copies the entries of the gzipped tarball in src to dst,
+// prefixing their names with prefix, and redacts the secrets of its regular
+// files.
+func copyArchive(src io.Reader, dst *tar.Writer, prefix string) error {
+	gzipReader, err := gzip.NewReader(src)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		if name == "" {
+			continue
+		}
+		header.Name = path.Join(prefix, name)
+		if header.Typeflag == tar.TypeDir {
+			header.Name += "/"
+		}
+
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			if err := dst.WriteHeader(header); err != nil {
+				return err
+			}
+			continue
+		}
+
+		data, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return err
+		}
+		data = redact.Bytes(data)
+		header.Size = int64(len(data))
+		if err := dst.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := dst.Write(data); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package gather
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyArchive(t *testing.T) {
+	var src bytes.Buffer
+	gzipWriter := gzip.NewWriter(&src)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, entry := range []struct {
+		name     string
+		typeflag byte
+		data     string
+	}{
+		{name: "./", typeflag: tar.TypeDir},
+		{name: "./journals/", typeflag: tar.TypeDir},
+		{name: "./journals/bootkube.log", typeflag: tar.TypeReg, data: "bootkube"},
+		{name: "../escape.log", typeflag: tar.TypeReg, data: "escape"},
+		{name: "./journals/kubelet.log", typeflag: tar.TypeReg, data: `{"auths":{"quay.io":{"auth":"dXNlcjpwYXNz"}}}`},
+	} {
+		header := &tar.Header{Name: entry.name, Typeflag: entry.typeflag, Mode: 0644, Size: int64(len(entry.data))}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(entry.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var dst bytes.Buffer
+	dstWriter := tar.NewWriter(&dst)
+	if err := copyArchive(&src, dstWriter, "control-plane/10.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dstWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	contents := map[string]string{}
+	tarReader := tar.NewReader(&dst)
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			break
+		}
+		data, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[header.Name] = string(data)
+	}
+
+	assert.Equal(t, map[string]string{
+		"control-plane/10.0.0.1/journals/":             "",
+		"control-plane/10.0.0.1/journals/bootkube.log": "bootkube",
+		"control-plane/10.0.0.1/escape.log":            "escape",
+		"control-plane/10.0.0.1/journals/kubelet.log":  `{"auths":{"quay.io":{"auth":"REDACTED"}}}`,
+	}, contents)
+}
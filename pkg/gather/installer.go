@@ -0,0 +1,53 @@
+package gather
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+)
+
+const (
+	// maxLineLength is the length of the longest line of the installer
+	// log which can be scanned.
+	maxLineLength = 1024 * 1024
+)
+
+// cloudErrorPattern matches the lines of the installer log which report
+// the errors of cloud APIs, from Terraform providers and from the
+// installer's own calls.
+var cloudErrorPattern = regexp.MustCompile(`(status code: [45][0-9][0-9]|[Rr]equest [Ii][Dd]:|RequestError|UnauthorizedOperation|AccessDenied|LimitExceeded)`)
+
+// File is a file from the installer's side of a failed install, which is
+// added to the log bundle under installer/.
+type File struct {
+	Name string
+	Data []byte
+}
+
+// RecentCloudErrors returns the last max lines of the installer log which
+// report the errors of cloud APIs.
+func RecentCloudErrors(log io.Reader, max int) ([]byte, error) {
+	var lines []string
+	scanner := bufio.NewScanner(log)
+	scanner.Buffer(nil, maxLineLength)
+	for scanner.Scan() {
+		if !cloudErrorPattern.MatchString(scanner.Text()) {
+			continue
+		}
+		lines = append(lines, scanner.Text())
+		if len(lines) > max {
+			lines = lines[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,72 @@
+package gather
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentCloudErrors(t *testing.T) {
+	log := strings.Join([]string{
+		`time="2019-03-01T10:00:00Z" level=info msg="Creating cluster..."`,
+		`time="2019-03-01T10:01:00Z" level=error msg="Error: Error launching source instance: UnauthorizedOperation: You are not authorized to perform this operation."`,
+		`time="2019-03-01T10:02:00Z" level=debug msg="module.vpc.aws_vpc.new_vpc: Creation complete"`,
+		`time="2019-03-01T10:03:00Z" level=error msg="Error: error creating EIP: AddressLimitExceeded: The maximum number of addresses has been reached.\n\tstatus code: 400, request id: 1234"`,
+		`time="2019-03-01T10:04:00Z" level=error msg="Error: Error creating IAM role: RequestError: send request failed"`,
+	}, "\n")
+
+	cloudErrors, err := RecentCloudErrors(strings.NewReader(log), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, strings.Join([]string{
+		`time="2019-03-01T10:03:00Z" level=error msg="Error: error creating EIP: AddressLimitExceeded: The maximum number of addresses has been reached.\n\tstatus code: 400, request id: 1234"`,
+		`time="2019-03-01T10:04:00Z" level=error msg="Error: Error creating IAM role: RequestError: send request failed"`,
+	}, "\n")+"\n", string(cloudErrors))
+}
+
+func TestBootstrapWithoutHost(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestBootstrapWithoutHost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = Bootstrap(dir, &Hosts{}, nil, nil)
+	assert.EqualError(t, err, "no bootstrap host")
+
+	// Without a bootstrap machine, the bundle only holds the installer's
+	// files.
+	bundle, err := Bootstrap(dir, &Hosts{}, nil, []File{{Name: "cloud-errors.log", Data: []byte("AccessDenied\n")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents := map[string]string{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			break
+		}
+		data, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[header.Name] = string(data)
+	}
+	assert.Equal(t, map[string]string{"installer/cloud-errors.log": "AccessDenied\n"}, contents)
+}
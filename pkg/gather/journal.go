@@ -0,0 +1,113 @@
+package gather
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/openshift/installer/pkg/lineprinter"
+)
+
+const (
+	// journalRetryInterval is how long to wait before connecting to the
+	// bootstrap machine again, which may still be booting.
+	journalRetryInterval = 10 * time.Second
+)
+
+// journalUnits are the units of the bootstrap machine whose journal is
+// followed while it bootstraps the control plane.
+var journalUnits = []string{"bootkube.service", "kubelet.service", "crio.service"}
+
+// FollowJournal follows the journal of the critical units of the
+// bootstrap machine at host, using the SSH private keys at keys (or the
+// user's default keys, if keys is empty), and passes each of its lines to
+// print until ctx is done. The machine may not accept connections yet, or
+// may drop them when it reboots, so connecting is retried until ctx is
+// done.
+func FollowJournal(ctx context.Context, host string, keys []string, print lineprinter.Print) error {
+	signers, err := loadSigners(keys)
+	if err != nil {
+		return err
+	}
+	config := clientConfig(signers)
+
+	// Once connected, a new connection only asks for the lines since the
+	// previous one was lost, which may repeat a few of them.
+	var since time.Time
+	for {
+		connected, err := followJournal(ctx, host, config, since, print)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if connected {
+			since = time.Now()
+		}
+		logrus.Debugf("Following the journal of %s again in %v: %v", host, journalRetryInterval, err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(journalRetryInterval):
+		}
+	}
+}
+
+// followJournal follows the journal of the bootstrap machine at host
+// until ctx is done or the connection is lost, and returns whether it
+// connected.
+func followJournal(ctx context.Context, host string, config *ssh.ClientConfig, since time.Time, print lineprinter.Print) (bool, error) {
+	client, err := dial(host, config)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return false, err
+	}
+	defer session.Close()
+
+	lp := &lineprinter.LinePrinter{Print: (&lineprinter.Trimmer{WrappedPrint: print}).Print}
+	defer lp.Close()
+	session.Stdout = lp
+	session.Stderr = lp
+	if err := session.Start(journalCommand(since)); err != nil {
+		return true, err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Wait()
+	}()
+	select {
+	case <-ctx.Done():
+		// Closing the connection ends the session.
+		client.Close()
+		<-done
+		return true, nil
+	case err := <-done:
+		if err == nil {
+			err = errors.New("journalctl exited")
+		}
+		return true, err
+	}
+}
+
+// journalCommand returns the command which follows the journal of the
+// journalUnits, from the boot or since the given time.
+func journalCommand(since time.Time) string {
+	args := []string{"sudo", "journalctl", "--follow", "--no-pager", "--lines=all"}
+	if !since.IsZero() {
+		args = append(args, fmt.Sprintf("--since=@%d", since.Unix()))
+	}
+	for _, unit := range journalUnits {
+		args = append(args, "--unit="+unit)
+	}
+	return strings.Join(args, " ")
+}
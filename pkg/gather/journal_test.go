@@ -0,0 +1,13 @@
+package gather
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalCommand(t *testing.T) {
+	assert.Equal(t, "sudo journalctl --follow --no-pager --lines=all --unit=bootkube.service --unit=kubelet.service --unit=crio.service", journalCommand(time.Time{}))
+	assert.Equal(t, "sudo journalctl --follow --no-pager --lines=all --since=@1500000000 --unit=bootkube.service --unit=kubelet.service --unit=crio.service", journalCommand(time.Unix(1500000000, 0)))
+}
@@ -0,0 +1,102 @@
+package gather
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sshUser    = "core"
+	sshPort    = "22"
+	sshTimeout = 30 * time.Second
+)
+
+// defaultKeyNames are the private keys under ~/.ssh that are tried when no
+// keys are explicitly configured.
+var defaultKeyNames = []string{"id_rsa", "id_ecdsa", "id_ed25519"}
+
+// loadSigners parses the private keys at the given paths. If no paths are
+// given, the default keys in the user's ~/.ssh directory are used.
+func loadSigners(paths []string) ([]ssh.Signer, error) {
+	explicit := len(paths) > 0
+	if !explicit {
+		home := os.Getenv("HOME")
+		if home == "" {
+			return nil, errors.New("no SSH keys configured and HOME is not set")
+		}
+		for _, name := range defaultKeyNames {
+			paths = append(paths, filepath.Join(home, ".ssh", name))
+		}
+	}
+
+	var signers []ssh.Signer
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			if !explicit && os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to read SSH key %q", path)
+		}
+
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			if !explicit {
+				logrus.Debugf("Skipping SSH key %q: %v", path, err)
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to parse SSH key %q", path)
+		}
+		signers = append(signers, signer)
+	}
+
+	if len(signers) == 0 {
+		return nil, errors.New("no usable SSH private keys found")
+	}
+	return signers, nil
+}
+
+func clientConfig(signers []ssh.Signer) *ssh.ClientConfig {
+	return &ssh.ClientConfig{
+		User: sshUser,
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(signers...)},
+		// The machines are freshly created and their host keys are not known
+		// ahead of time.
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         sshTimeout,
+	}
+}
+
+// dial opens an SSH connection to host.
+func dial(host string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	client, err := ssh.Dial("tcp", net.JoinHostPort(host, sshPort), config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s", host)
+	}
+	return client, nil
+}
+
+// dialThrough opens an SSH connection to host, tunnelled through jump. This
+// is used to reach machines which are only accessible from the bootstrap
+// machine.
+func dialThrough(jump *ssh.Client, host string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	address := net.JoinHostPort(host, sshPort)
+	conn, err := jump.Dial("tcp", address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s", host)
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "failed to connect to %s", host)
+	}
+	return ssh.NewClient(c, chans, reqs), nil
+}
@@ -0,0 +1,173 @@
+// Package installer creates and destroys clusters without the CLI and
+// without an asset directory, for controllers which manage many clusters.
+// The assets are kept in memory, and the files which the CLI would write to
+// the asset directory are returned to the caller instead:
+//
+//	cluster, err := installer.Create(installConfig, installer.Credentials{
+//		"AWS_ACCESS_KEY_ID":     keyID,
+//		"AWS_SECRET_ACCESS_KEY": secretKey,
+//	}, logs)
+//	// Keep cluster.Files, even if err is not nil, to destroy the cluster
+//	// later with installer.Destroy(cluster.Metadata, credentials, logs).
+//
+// The installer reads its cloud credentials and settings from the process
+// environment and logs with the standard logger of logrus, so Create and
+// Destroy run one at a time. Terraform still runs in a temporary directory,
+// which is removed when Create returns.
+//
+// Programs which create clusters must also import the package which builds
+// Terraform into them, which the package leaves to them so that it is
+// tested without Terraform:
+//
+//	import _ "github.com/openshift/installer/pkg/terraform/exec"
+package installer
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/asset/targets"
+	"github.com/openshift/installer/pkg/destroy"
+	"github.com/openshift/installer/pkg/redact"
+	"github.com/openshift/installer/pkg/types"
+
+	// Register the destroyers of the platforms.
+	_ "github.com/openshift/installer/pkg/destroy/aws"
+	_ "github.com/openshift/installer/pkg/destroy/libvirt"
+	_ "github.com/openshift/installer/pkg/destroy/openstack"
+)
+
+const (
+	installConfigFileName = "install-config.yaml"
+	metadataFileName      = "metadata.json"
+)
+
+// Credentials are the environment variables which hold the cloud
+// credentials of a cluster, such as AWS_ACCESS_KEY_ID and
+// AWS_SECRET_ACCESS_KEY, or OS_CLOUD and OS_CLIENT_CONFIG_FILE. They are
+// set only while Create or Destroy runs.
+type Credentials map[string]string
+
+// Cluster is a cluster created by Create.
+type Cluster struct {
+	// Files are the files which 'create cluster' would write to the asset
+	// directory, such as auth/kubeconfig and the Terraform state.
+	Files []*asset.File
+
+	// Metadata is the metadata of the cluster, with which it is destroyed.
+	Metadata *types.ClusterMetadata
+}
+
+// File returns the file of the cluster with the given name, or nil if
+// there is none.
+func (c *Cluster) File(name string) *asset.File {
+	for _, f := range c.Files {
+		if f.Filename == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// running serializes Create and Destroy, which change the environment and
+// the standard logger of the process.
+var running sync.Mutex
+
+// Create creates a cluster from the install-config with the credentials,
+// and writes its logs to w. If creating the cluster fails after its
+// metadata was generated, Create returns the cluster as far as it was
+// created along with the error, so that it can be destroyed.
+func Create(installConfig []byte, credentials Credentials, w io.Writer) (*Cluster, error) {
+	running.Lock()
+	defer running.Unlock()
+	defer setup(credentials, w)()
+
+	store := asset.NewStoreWithFileFetcher(asset.NewMemoryFileFetcher(
+		&asset.File{Filename: installConfigFileName, Data: installConfig},
+	))
+	c := &Cluster{}
+	for _, a := range targets.Cluster() {
+		err := store.Fetch(a)
+		// The Terraform state of a failed cluster is still needed to
+		// resume or destroy it.
+		if _, ok := a.(*cluster.Cluster); ok || err == nil {
+			c.Files = append(c.Files, a.Files()...)
+		}
+		if err == nil {
+			continue
+		}
+		if c.File(metadataFileName) == nil {
+			return nil, errors.Wrapf(err, "failed to fetch %s", a.Name())
+		}
+		if err2 := c.loadMetadata(); err2 != nil {
+			logrus.Error(err2)
+		}
+		return c, errors.Wrapf(err, "failed to fetch %s", a.Name())
+	}
+	return c, c.loadMetadata()
+}
+
+func (c *Cluster) loadMetadata() error {
+	c.Metadata = &types.ClusterMetadata{}
+	if err := json.Unmarshal(c.File(metadataFileName).Data, c.Metadata); err != nil {
+		return errors.Wrap(err, "failed to parse the cluster metadata")
+	}
+	return nil
+}
+
+// Destroy destroys the cluster of the metadata with the credentials, and
+// writes its logs to w.
+func Destroy(metadata *types.ClusterMetadata, credentials Credentials, w io.Writer) error {
+	running.Lock()
+	defer running.Unlock()
+	defer setup(credentials, w)()
+
+	destroyer, err := destroy.NewFromMetadata(logrus.StandardLogger(), metadata)
+	if err != nil {
+		return errors.Wrap(err, "failed while preparing to destroy cluster")
+	}
+	return errors.Wrap(destroyer.Run(), "failed to destroy cluster")
+}
+
+// setup sets the credentials in the environment, makes the standard logger
+// write to w with the secrets redacted, and keeps the assets from prompting
+// for input. It returns a function which restores all of those.
+func setup(credentials Credentials, w io.Writer) func() {
+	previous := map[string]*string{}
+	for name, value := range credentials {
+		if old, ok := os.LookupEnv(name); ok {
+			previous[name] = &old
+		} else {
+			previous[name] = nil
+		}
+		os.Setenv(name, value)
+	}
+
+	logger := logrus.StandardLogger()
+	out := logger.Out
+	writer := redact.NewWriter(w)
+	logger.SetOutput(writer)
+
+	interactive := asset.Interactive
+	asset.Interactive = false
+
+	return func() {
+		asset.Interactive = interactive
+		writer.Close()
+		logger.SetOutput(out)
+		for name, value := range previous {
+			if value == nil {
+				os.Unsetenv(name)
+			} else {
+				os.Setenv(name, *value)
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package installer
+
+import (
+	"bytes"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/data"
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/libvirt"
+)
+
+const testInstallConfig = `apiVersion: v1beta1
+baseDomain: test-domain
+metadata:
+  name: test-cluster
+platform:
+  libvirt:
+    URI: qemu+tcp://192.168.122.1/system
+    osImage: file:///var/lib/rhcos/rhcos-qemu.qcow2
+pullSecret: '{"auths":{"quay.io":{"auth":"dGVzdDp0ZXN0"}}}'
+sshKey: ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDFXqRtbB4ONnB/4pZ2cXt0tTpr1yiSKPxqqO6y2bQP9 test
+`
+
+// setupCreate sets up the creation of libvirt clusters, whose assets are
+// generated without a cloud API, and returns the function which restores
+// the previous settings.
+func setupCreate() func() {
+	assets, names := data.Assets, types.PlatformNames
+	skipDNS, skipProxy := cluster.SkipDNSPreflight, cluster.SkipProxyPreflight
+
+	data.Assets = http.Dir("../../data/data")
+	// The libvirt platform is otherwise only enabled by the libvirt build tag.
+	types.PlatformNames = append(append([]string{}, types.PlatformNames...), libvirt.Name)
+	sort.Strings(types.PlatformNames)
+	cluster.SkipDNSPreflight = true
+	cluster.SkipProxyPreflight = true
+
+	return func() {
+		data.Assets, types.PlatformNames = assets, names
+		cluster.SkipDNSPreflight, cluster.SkipProxyPreflight = skipDNS, skipProxy
+	}
+}
+
+// TestCreate creates a libvirt cluster, whose assets are generated without
+// a cloud API, up to Terraform, which is not built into the tests.
+func TestCreate(t *testing.T) {
+	defer setupCreate()()
+
+	cases := []struct {
+		name      string
+		kubeadmin string
+	}{
+		{name: "kubeadmin"},
+		{name: "kubeadmin disabled", kubeadmin: "kubeadmin: Disabled\n"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			c, err := Create([]byte(testInstallConfig+tc.kubeadmin), nil, &logs)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "Terraform is not built into this program")
+			}
+			assert.Contains(t, logs.String(), "Creating cluster...")
+			if !assert.NotNil(t, c) {
+				return
+			}
+			for _, name := range []string{"terraform.tfvars", "auth/kubeconfig", "metadata.json"} {
+				assert.NotNil(t, c.File(name), name)
+			}
+			assert.Nil(t, c.File("terraform.tfstate"))
+			if assert.NotNil(t, c.Metadata) {
+				assert.Equal(t, "test-cluster", c.Metadata.ClusterName)
+				assert.Equal(t, types.InfraID("test-cluster", c.Metadata.ClusterID), c.Metadata.InfraID)
+				assert.NotNil(t, c.Metadata.Libvirt)
+			}
+
+			// The bootstrap Ignition config, with the manifests, is in the
+			// Terraform variables.
+			tfvars := string(c.File("terraform.tfvars").Data)
+			if tc.kubeadmin == "" {
+				assert.NotEmpty(t, c.File("auth/kubeadmin-password").Data)
+				assert.Contains(t, tfvars, "99_kubeadmin-password-secret.yaml")
+			} else {
+				assert.Nil(t, c.File("auth/kubeadmin-password"))
+				assert.NotContains(t, tfvars, "99_kubeadmin-password-secret.yaml")
+			}
+		})
+	}
+}
+
+// TestCreateAfterCreate checks that a Create which fails before creating
+// the cluster returns none of the files of an earlier Create.
+func TestCreateAfterCreate(t *testing.T) {
+	defer setupCreate()()
+
+	var logs bytes.Buffer
+	c, _ := Create([]byte(testInstallConfig), nil, &logs)
+	if !assert.NotNil(t, c) || !assert.NotNil(t, c.File("auth/kubeadmin-password")) {
+		return
+	}
+
+	noneInstallConfig := strings.Replace(testInstallConfig, `  libvirt:
+    URI: qemu+tcp://192.168.122.1/system
+    osImage: file:///var/lib/rhcos/rhcos-qemu.qcow2
+`, "  none: {}\n", 1)
+	c, err := Create([]byte(noneInstallConfig), nil, &logs)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cluster cannot be created with platform set to 'none'")
+	}
+	if c != nil {
+		assert.Nil(t, c.File("auth/kubeadmin-password"))
+		assert.Nil(t, c.File(".openshift_install_apply_failed"))
+		for _, f := range c.Files {
+			assert.NotContains(t, f.Filename, "tfstate")
+		}
+	}
+}
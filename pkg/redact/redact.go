@@ -0,0 +1,211 @@
+// Package redact scrubs secrets, such as pull secrets, cloud credentials,
+// certificates and the kubeadmin password, from the logs, errors and log
+// bundles which the installer produces, so that they can be shared.
+package redact
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// Placeholder replaces the secrets.
+	Placeholder = "REDACTED"
+
+	// minSecretLength is the length of the shortest secret which is
+	// added, so that common words are not redacted everywhere.
+	minSecretLength = 8
+)
+
+// pattern is a regular expression for secrets whose first group, if any,
+// is kept.
+type pattern struct {
+	regexp      *regexp.Regexp
+	replacement string
+}
+
+// patterns match the secrets which are recognizable by their form, even
+// within JSON which was quoted again in a log line.
+var patterns = []pattern{
+	{
+		// PEM blocks, such as certificates and private keys.
+		regexp:      regexp.MustCompile(`(?s)-----BEGIN ([A-Z ]+)-----.*?-----END [A-Z ]+-----`),
+		replacement: "-----BEGIN $1-----" + Placeholder + "-----END $1-----",
+	},
+	{
+		// PEM blocks in base64 data URLs, such as the private keys in the
+		// files of Ignition configs.
+		regexp:      regexp.MustCompile(`(;base64,)LS0tLS1CRUdJTi[A-Za-z0-9+/=]*`),
+		replacement: "${1}" + Placeholder,
+	},
+	{
+		// The auths of pull secrets.
+		regexp:      regexp.MustCompile(`(\\*"auth\\*"\s*:\s*\\*")[^"\\]+`),
+		replacement: "${1}" + Placeholder,
+	},
+	{
+		// AWS secret access keys and session tokens.
+		regexp:      regexp.MustCompile(`(?i)((?:aws_secret_access_key|secret_?access_?key|aws_session_token|session_?token)\\*"?\s*[:=]\s*\\*"?)[A-Za-z0-9/+=]{16,}`),
+		replacement: "${1}" + Placeholder,
+	},
+	{
+		// Passwords, such as those of OpenStack clouds.
+		regexp:      regexp.MustCompile(`(?i)(password\\*"?\s*[:=]\s*\\*"?)[^"\\\s,}]+`),
+		replacement: "${1}" + Placeholder,
+	},
+}
+
+var secrets struct {
+	sync.RWMutex
+	replacer *strings.Replacer
+	values   map[string]struct{}
+}
+
+// AddSecret adds a secret, which is then redacted wherever it appears, as
+// is its base64 encoding. Secrets shorter than eight characters are
+// ignored.
+func AddSecret(secret string) {
+	if len(secret) < minSecretLength {
+		return
+	}
+
+	secrets.Lock()
+	defer secrets.Unlock()
+	if secrets.values == nil {
+		secrets.values = map[string]struct{}{}
+	}
+	secrets.values[secret] = struct{}{}
+	secrets.values[base64.StdEncoding.EncodeToString([]byte(secret))] = struct{}{}
+
+	// Longer secrets are replaced first, so that a secret which contains
+	// another is redacted as a whole.
+	values := make([]string, 0, len(secrets.values))
+	for value := range secrets.values {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	oldnew := make([]string, 0, 2*len(values))
+	for _, value := range values {
+		oldnew = append(oldnew, value, Placeholder)
+	}
+	secrets.replacer = strings.NewReplacer(oldnew...)
+}
+
+// AddPullSecret adds the pull secret and the auths of its registries.
+func AddPullSecret(pullSecret string) {
+	AddSecret(pullSecret)
+
+	var config struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal([]byte(pullSecret), &config); err != nil {
+		return
+	}
+	for _, registry := range config.Auths {
+		AddSecret(registry.Auth)
+		if credentials, err := base64.StdEncoding.DecodeString(registry.Auth); err == nil {
+			if i := strings.Index(string(credentials), ":"); i >= 0 {
+				AddSecret(string(credentials[i+1:]))
+			}
+		}
+	}
+}
+
+// String returns s with the secrets redacted.
+func String(s string) string {
+	secrets.RLock()
+	replacer := secrets.replacer
+	secrets.RUnlock()
+	if replacer != nil {
+		s = replacer.Replace(s)
+	}
+
+	for _, p := range patterns {
+		s = p.regexp.ReplaceAllString(s, p.replacement)
+	}
+	return s
+}
+
+// Bytes returns data with the secrets redacted.
+func Bytes(data []byte) []byte {
+	return []byte(String(string(data)))
+}
+
+// Writer redacts the secrets of the lines written to it before writing
+// them to the wrapped writer. The lines of PEM blocks which span several
+// lines are dropped, but for their first and last lines.
+type Writer struct {
+	w     io.Writer
+	buf   bytes.Buffer
+	inPEM bool
+
+	mu sync.Mutex
+}
+
+// NewWriter returns a Writer which writes to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+var (
+	pemBegin = regexp.MustCompile(`-----BEGIN [A-Z ]+-----`)
+	pemEnd   = regexp.MustCompile(`-----END [A-Z ]+-----`)
+)
+
+// Write writes the complete lines in p, and buffers the last line if it
+// is incomplete.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err == io.EOF {
+			w.buf.WriteString(line)
+			return len(p), nil
+		}
+		if err := w.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (w *Writer) writeLine(line string) error {
+	switch {
+	case w.inPEM:
+		if !pemEnd.MatchString(line) {
+			return nil
+		}
+		w.inPEM = false
+		line = Placeholder + pemEnd.FindString(line) + "\n"
+	case pemBegin.MatchString(line) && !pemEnd.MatchString(line):
+		w.inPEM = true
+		line = String(line[:pemBegin.FindStringIndex(line)[1]]) + "\n"
+	default:
+		line = String(line)
+	}
+	_, err := io.WriteString(w.w, line)
+	return err
+}
+
+// Close writes the incomplete last line, if any.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	line := w.buf.String()
+	w.buf.Reset()
+	return w.writeLine(line)
+}
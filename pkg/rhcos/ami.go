@@ -1,23 +1,26 @@
 package rhcos
 
 import (
-	"context"
-
-	"github.com/pkg/errors"
+	"strings"
 )
 
-// AMI fetches the HVM AMI ID of the latest Red Hat CoreOS release.
-func AMI(ctx context.Context, channel, region string) (string, error) {
-	meta, err := fetchLatestMetadata(ctx, channel)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to fetch RHCOS metadata")
-	}
+// AMICopySourceRegion is the region whose AMI is copied into regions in
+// which the build has no AMI.
+const AMICopySourceRegion = "us-east-1"
 
-	for _, ami := range meta.AMIs {
-		if ami.Name == region {
-			return ami.HVM, nil
-		}
-	}
+// CopiedAMI returns the OS image of the AMI in the source region, which
+// the installer copies into the region of the cluster, such as
+// ami-0123456789abcdef0,us-east-1.
+func CopiedAMI(id, sourceRegion string) string {
+	return id + "," + sourceRegion
+}
 
-	return "", errors.Errorf("no RHCOS AMIs found in %s", region)
+// SplitAMI returns the ID of the AMI of the OS image, and the region it is
+// copied from, which is empty if the AMI is in the region of the cluster.
+func SplitAMI(osImage string) (id, sourceRegion string) {
+	parts := strings.SplitN(osImage, ",", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return osImage, ""
 }
@@ -28,45 +28,39 @@ type metadata struct {
 		Name string `json:"name"`
 	} `json:"amis"`
 	Images struct {
-		QEMU struct {
-			Path   string `json:"path"`
-			SHA256 string `json:"sha256"`
-		} `json:"qemu"`
+		QEMU      image `json:"qemu"`
+		Kernel    image `json:"kernel"`
+		Initramfs image `json:"initramfs"`
+		Metal     image `json:"metal"`
 	} `json:"images"`
 	OSTreeVersion string `json:"ostree-version"`
 }
 
-func fetchLatestMetadata(ctx context.Context, channel string) (metadata, error) {
-	build := buildName
-	var err error
-	if build == "" {
-		build, err = fetchLatestBuild(ctx, channel)
-		if err != nil {
-			return metadata{}, errors.Wrap(err, "failed to fetch latest build")
-		}
-	}
+type image struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
 
-	url := fmt.Sprintf("%s/%s/%s/meta.json", baseURL, channel, build)
-	logrus.Debugf("Fetching RHCOS metadata from %q", url)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return metadata{}, errors.Wrap(err, "failed to build request")
+// resolveBuild returns the name of the build in the channel that the
+// installer uses.
+func resolveBuild(ctx context.Context, channel string) (string, error) {
+	if buildName != "" {
+		return buildName, nil
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req.WithContext(ctx))
+	build, err := fetchLatestBuild(ctx, channel)
 	if err != nil {
-		return metadata{}, errors.Wrapf(err, "failed to fetch metadata for build %s", build)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return metadata{}, errors.Errorf("incorrect HTTP response (%s)", resp.Status)
+		return "", errors.Wrap(err, "failed to fetch latest build")
 	}
+	return build, nil
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
+func fetchMetadata(ctx context.Context, channel string, build string) (metadata, error) {
+	url := fmt.Sprintf("%s/%s/%s/meta.json", baseURL, channel, build)
+	logrus.Debugf("Fetching RHCOS metadata from %q", url)
+	body, err := cachedGet(ctx, url)
 	if err != nil {
-		return metadata{}, errors.Wrap(err, "failed to read HTTP response")
+		return metadata{}, errors.Wrapf(err, "failed to fetch metadata for build %s", build)
 	}
 
 	var meta metadata
@@ -80,26 +74,10 @@ func fetchLatestMetadata(ctx context.Context, channel string) (metadata, error)
 func fetchLatestBuild(ctx context.Context, channel string) (string, error) {
 	url := fmt.Sprintf("%s/%s/builds.json", baseURL, channel)
 	logrus.Debugf("Fetching RHCOS builds from %q", url)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to build request")
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(req.WithContext(ctx))
+	body, err := cachedGet(ctx, url)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to fetch builds")
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", errors.Errorf("incorrect HTTP response (%s)", resp.Status)
-	}
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to read HTTP response")
-	}
 
 	var builds struct {
 		Builds []string `json:"builds"`
@@ -114,3 +92,28 @@ func fetchLatestBuild(ctx context.Context, channel string) (string, error) {
 
 	return builds.Builds[0], nil
 }
+
+// get fetches the body of the URL.
+func get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build request")
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("incorrect HTTP response (%s)", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read HTTP response")
+	}
+	return body, nil
+}
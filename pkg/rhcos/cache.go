@@ -0,0 +1,75 @@
+package rhcos
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// Cache is whether the responses of the RHCOS release server are
+	// cached on disk, so that repeated invocations don't fetch them again
+	// and can fall back to them when the server cannot be reached.
+	Cache = true
+
+	// cacheTTL is how long a cached response is used before it is fetched
+	// again.
+	cacheTTL = time.Hour
+
+	// cacheDir returns the directory of the cache, or an empty string if
+	// there is none.
+	cacheDir = func() string {
+		if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+			return filepath.Join(dir, "openshift-installer", "rhcos")
+		}
+		if home := os.Getenv("HOME"); home != "" {
+			return filepath.Join(home, ".cache", "openshift-installer", "rhcos")
+		}
+		return ""
+	}
+)
+
+// cachedGet fetches the body of the URL, or returns it from the cache if it
+// was cached less than cacheTTL ago. If fetching fails, an older cached
+// body is returned instead.
+func cachedGet(ctx context.Context, url string) ([]byte, error) {
+	dir := cacheDir()
+	if !Cache || dir == "" {
+		return get(ctx, url)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%x", sha256.Sum256([]byte(url))))
+	info, statErr := os.Stat(path)
+	if statErr == nil && time.Since(info.ModTime()) < cacheTTL {
+		if data, err := ioutil.ReadFile(path); err == nil {
+			logrus.Debugf("Using %q cached at %s", url, info.ModTime())
+			return data, nil
+		}
+	}
+
+	data, err := get(ctx, url)
+	if err != nil {
+		if statErr == nil {
+			if cached, readErr := ioutil.ReadFile(path); readErr == nil {
+				logrus.Warnf("Using %q cached at %s, because fetching it failed: %v", url, info.ModTime(), err)
+				return cached, nil
+			}
+		}
+		return nil, err
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err == nil {
+		err = ioutil.WriteFile(path, data, 0644)
+	}
+	if err != nil {
+		logrus.Debugf("Failed to cache %q: %v", url, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,69 @@
+package rhcos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedGet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestCachedGet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(f func() string) { cacheDir = f }(cacheDir)
+	cacheDir = func() string { return dir }
+	defer func(cache bool) { Cache = cache }(Cache)
+	Cache = true
+
+	requests := 0
+	body := "first"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(body))
+	}))
+	url := server.URL + "/maipo/builds.json"
+
+	data, err := cachedGet(context.Background(), url)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", string(data))
+
+	body = "second"
+	data, err = cachedGet(context.Background(), url)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", string(data), "the cached response should be used")
+	assert.Equal(t, 1, requests)
+
+	Cache = false
+	data, err = cachedGet(context.Background(), url)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(data), "the cache should be bypassed")
+	Cache = true
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	expired := time.Now().Add(-2 * cacheTTL)
+	if err := os.Chtimes(filepath.Join(dir, files[0].Name()), expired, expired); err != nil {
+		t.Fatal(err)
+	}
+	data, err = cachedGet(context.Background(), url)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(data), "the expired response should be fetched again")
+
+	server.Close()
+	if err := os.Chtimes(filepath.Join(dir, files[0].Name()), expired, expired); err != nil {
+		t.Fatal(err)
+	}
+	data, err = cachedGet(context.Background(), url)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(data), "the expired response should be used when fetching fails")
+}
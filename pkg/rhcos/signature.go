@@ -0,0 +1,53 @@
+package rhcos
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+
+	"github.com/openshift/installer/data"
+)
+
+// signingKeysFile is the armored OpenPGP public keys which sign the RHCOS
+// artifacts, in the data assets. hack/update-rhcos-signing-keys.sh fetches
+// them.
+const signingKeysFile = "rhcos-signing-keys.asc"
+
+// VerifySignature checks the detached OpenPGP signature of the artifact
+// against the RHCOS signing keys embedded in the installer.
+func VerifySignature(artifact io.Reader, signature io.Reader) error {
+	keyring, err := loadSigningKeys()
+	if err != nil {
+		return err
+	}
+	return verifySignature(keyring, artifact, signature)
+}
+
+// loadSigningKeys returns the RHCOS signing keys embedded in the installer.
+func loadSigningKeys() (openpgp.EntityList, error) {
+	file, err := data.Assets.Open(signingKeysFile)
+	if os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "this installer was built without the RHCOS signing keys; run hack/update-rhcos-signing-keys.sh and rebuild it")
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to open the RHCOS signing keys")
+	}
+	defer file.Close()
+
+	keyring, err := openpgp.ReadArmoredKeyRing(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the RHCOS signing keys")
+	}
+	if len(keyring) == 0 {
+		return nil, errors.New("no RHCOS signing keys")
+	}
+	return keyring, nil
+}
+
+func verifySignature(keyring openpgp.KeyRing, artifact io.Reader, signature io.Reader) error {
+	if _, err := openpgp.CheckDetachedSignature(keyring, artifact, signature); err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	return nil
+}
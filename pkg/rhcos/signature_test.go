@@ -0,0 +1,62 @@
+package rhcos
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/openpgp"
+
+	"github.com/openshift/installer/data"
+)
+
+func TestVerifySignature(t *testing.T) {
+	signer, err := openpgp.NewEntity("RHCOS", "", "rhcos@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := openpgp.NewEntity("Other", "", "other@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	artifact := []byte("rhcos")
+	signature := &bytes.Buffer{}
+	if err := openpgp.DetachSign(signature, signer, bytes.NewReader(artifact), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, verifySignature(openpgp.EntityList{signer}, bytes.NewReader(artifact), bytes.NewReader(signature.Bytes())))
+	assert.EqualError(t, verifySignature(openpgp.EntityList{signer}, bytes.NewReader([]byte("tampered")), bytes.NewReader(signature.Bytes())), "invalid signature: openpgp: invalid signature: hash tag doesn't match")
+	assert.EqualError(t, verifySignature(openpgp.EntityList{other}, bytes.NewReader(artifact), bytes.NewReader(signature.Bytes())), "invalid signature: openpgp: signature made by unknown entity")
+}
+
+// TestEmbeddedSigningKeys checks that the signing keys in the data assets
+// are an armored keyring.
+func TestEmbeddedSigningKeys(t *testing.T) {
+	defer func(assets http.FileSystem) { data.Assets = assets }(data.Assets)
+	data.Assets = http.Dir("../../data/data")
+
+	keyring, err := loadSigningKeys()
+	if os.IsNotExist(errors.Cause(err)) {
+		t.Skipf("%v", err)
+	}
+	if assert.NoError(t, err) {
+		for _, entity := range keyring {
+			assert.NotNil(t, entity.PrimaryKey)
+		}
+	}
+}
+
+func TestVerifySignatureWithoutSigningKeys(t *testing.T) {
+	defer func(assets http.FileSystem) { data.Assets = assets }(data.Assets)
+	data.Assets = http.Dir("testdata/does-not-exist")
+
+	err := VerifySignature(bytes.NewReader([]byte("rhcos")), bytes.NewReader(nil))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "this installer was built without the RHCOS signing keys")
+	}
+}
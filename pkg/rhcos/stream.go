@@ -0,0 +1,214 @@
+package rhcos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/data"
+)
+
+const (
+	// DefaultArchitecture is the architecture of the machines, as named
+	// by the stream metadata.
+	DefaultArchitecture = "x86_64"
+
+	// streamFile is the stream metadata of the RHCOS build the installer
+	// installs, in the data assets, if hack/update-rhcos-stream.sh pinned
+	// the build.
+	streamFile = "rhcos-stream.json"
+)
+
+// Stream is the CoreOS stream metadata of the RHCOS build the installer
+// installs: the artifacts of each platform and the cloud images, for each
+// architecture. It is the single source of the RHCOS images, so that
+// clusters provisioned by other tooling can use the same artifacts.
+type Stream struct {
+	// Stream is the name of the stream, the RHCOS channel of the build.
+	Stream string `json:"stream"`
+
+	// Architectures are the artifacts and images of the build, keyed by
+	// architecture.
+	Architectures map[string]Arch `json:"architectures"`
+}
+
+// Arch is the artifacts and cloud images of an architecture.
+type Arch struct {
+	// Artifacts are the downloadable images, keyed by platform, such as
+	// "qemu" or "metal".
+	Artifacts map[string]PlatformArtifacts `json:"artifacts"`
+
+	// Images are the images uploaded to clouds.
+	Images Images `json:"images,omitempty"`
+}
+
+// PlatformArtifacts are the downloadable images of a platform.
+type PlatformArtifacts struct {
+	// Release is the RHCOS build of the images.
+	Release string `json:"release"`
+
+	// Formats are the images, keyed by format, such as "qcow2" or "pxe".
+	Formats map[string]ImageFormat `json:"formats"`
+}
+
+// ImageFormat is the files of an image format, keyed by file, such as
+// "disk", or "kernel" and "initramfs" for netbooting.
+type ImageFormat map[string]Artifact
+
+// Artifact is a downloadable image.
+type Artifact struct {
+	// Location is the URL of the image.
+	Location string `json:"location"`
+
+	// SHA256 is the SHA-256 checksum of the image.
+	SHA256 string `json:"sha256"`
+
+	// Signature is the URL of the detached OpenPGP signature of the image
+	// by the RHCOS signing keys, if it is signed.
+	Signature string `json:"signature,omitempty"`
+}
+
+// Images are the cloud images of an architecture.
+type Images struct {
+	// AWS are the AMIs.
+	AWS *AWSImages `json:"aws,omitempty"`
+}
+
+// AWSImages are the AMIs of an architecture.
+type AWSImages struct {
+	// Regions are the AMIs, keyed by AWS region.
+	Regions map[string]RegionImage `json:"regions"`
+}
+
+// RegionImage is the cloud image of a region.
+type RegionImage struct {
+	// Release is the RHCOS build of the image.
+	Release string `json:"release"`
+
+	// Image is the ID of the image, such as an AMI ID.
+	Image string `json:"image"`
+}
+
+// LoadStream returns the stream metadata of the RHCOS build the installer
+// installs: the metadata embedded in the installer if the build was pinned
+// with hack/update-rhcos-stream.sh, or else the metadata of the build
+// fetched from the RHCOS release server.
+func LoadStream(ctx context.Context) (*Stream, error) {
+	file, err := data.Assets.Open(streamFile)
+	if os.IsNotExist(err) {
+		return FetchStream(ctx, DefaultChannel)
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to open the RHCOS stream metadata")
+	}
+	defer file.Close()
+
+	raw, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the RHCOS stream metadata")
+	}
+	return ParseStream(raw)
+}
+
+// FetchStream fetches the metadata of the RHCOS build in the channel that
+// the installer uses, the build pinned at build time with
+// RHCOS_BUILD_NAME or else the latest build, and returns it as stream
+// metadata.
+func FetchStream(ctx context.Context, channel string) (*Stream, error) {
+	build, err := resolveBuild(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := fetchMetadata(ctx, channel, build)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch RHCOS metadata")
+	}
+	return streamFromMetadata(channel, build, meta), nil
+}
+
+// streamFromMetadata converts the metadata of a build like
+// hack/update-rhcos-stream.sh does.
+func streamFromMetadata(channel string, build string, meta metadata) *Stream {
+	artifact := func(img image) Artifact {
+		return Artifact{
+			Location: fmt.Sprintf("%s/%s/%s/%s", baseURL, channel, build, img.Path),
+			SHA256:   img.SHA256,
+		}
+	}
+
+	arch := Arch{
+		Artifacts: map[string]PlatformArtifacts{
+			"qemu": {
+				Release: meta.OSTreeVersion,
+				Formats: map[string]ImageFormat{
+					"qcow2": {"disk": artifact(meta.Images.QEMU)},
+				},
+			},
+		},
+		Images: Images{
+			AWS: &AWSImages{Regions: make(map[string]RegionImage, len(meta.AMIs))},
+		},
+	}
+	if meta.Images.Kernel.Path != "" && meta.Images.Initramfs.Path != "" && meta.Images.Metal.Path != "" {
+		arch.Artifacts["metal"] = PlatformArtifacts{
+			Release: meta.OSTreeVersion,
+			Formats: map[string]ImageFormat{
+				"raw.gz": {"disk": artifact(meta.Images.Metal)},
+				"pxe": {
+					"kernel":    artifact(meta.Images.Kernel),
+					"initramfs": artifact(meta.Images.Initramfs),
+				},
+			},
+		}
+	}
+	for _, ami := range meta.AMIs {
+		arch.Images.AWS.Regions[ami.Name] = RegionImage{Release: meta.OSTreeVersion, Image: ami.HVM}
+	}
+
+	return &Stream{
+		Stream:        channel,
+		Architectures: map[string]Arch{DefaultArchitecture: arch},
+	}
+}
+
+// ParseStream parses stream metadata.
+func ParseStream(raw []byte) (*Stream, error) {
+	stream := &Stream{}
+	if err := json.Unmarshal(raw, stream); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the RHCOS stream metadata")
+	}
+	return stream, nil
+}
+
+// Architecture returns the artifacts and images of the architecture.
+func (s *Stream) Architecture(name string) (*Arch, error) {
+	arch, ok := s.Architectures[name]
+	if !ok {
+		return nil, errors.Errorf("RHCOS stream %s has no %s images", s.Stream, name)
+	}
+	return &arch, nil
+}
+
+// Artifact returns the file of the image of the platform in the format,
+// such as the "disk" of the "qcow2" image of "qemu".
+func (a *Arch) Artifact(platform, format, file string) (*Artifact, error) {
+	artifact, ok := a.Artifacts[platform].Formats[format][file]
+	if !ok {
+		return nil, errors.Errorf("no RHCOS %s %s %s image", platform, format, file)
+	}
+	return &artifact, nil
+}
+
+// AMI returns the ID of the AMI in the AWS region.
+func (a *Arch) AMI(region string) (string, error) {
+	if a.Images.AWS != nil {
+		if image, ok := a.Images.AWS.Regions[region]; ok {
+			return image.Image, nil
+		}
+	}
+	return "", errors.Errorf("no RHCOS AMIs found in %s", region)
+}
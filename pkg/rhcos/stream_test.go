@@ -0,0 +1,108 @@
+package rhcos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStream(t *testing.T) {
+	stream, err := ParseStream([]byte(`{
+  "stream": "maipo",
+  "architectures": {
+    "x86_64": {
+      "artifacts": {
+        "qemu": {
+          "release": "47.1",
+          "formats": {"qcow2": {"disk": {"location": "https://example.com/rhcos-qemu.qcow2", "sha256": "abc123"}}}
+        }
+      },
+      "images": {"aws": {"regions": {"us-east-1": {"release": "47.1", "image": "ami-1"}}}}
+    },
+    "ppc64le": {
+      "artifacts": {}
+    }
+  }
+}`))
+	assert.NoError(t, err)
+
+	arch, err := stream.Architecture("x86_64")
+	assert.NoError(t, err)
+	artifact, err := arch.Artifact("qemu", "qcow2", "disk")
+	assert.NoError(t, err)
+	assert.Equal(t, &Artifact{Location: "https://example.com/rhcos-qemu.qcow2", SHA256: "abc123"}, artifact)
+	_, err = arch.Artifact("metal", "pxe", "kernel")
+	assert.EqualError(t, err, "no RHCOS metal pxe kernel image")
+	ami, err := arch.AMI("us-east-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ami-1", ami)
+	_, err = arch.AMI("us-west-2")
+	assert.EqualError(t, err, "no RHCOS AMIs found in us-west-2")
+
+	arch, err = stream.Architecture("ppc64le")
+	assert.NoError(t, err)
+	_, err = arch.AMI("us-east-1")
+	assert.EqualError(t, err, "no RHCOS AMIs found in us-east-1")
+
+	_, err = stream.Architecture("aarch64")
+	assert.EqualError(t, err, "RHCOS stream maipo has no aarch64 images")
+}
+
+func TestFetchStream(t *testing.T) {
+	defer func(cache bool) { Cache = cache }(Cache)
+	Cache = false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/maipo/builds.json":
+			w.Write([]byte(`{"builds": ["47.2", "47.1"]}`))
+		case "/maipo/47.2/meta.json":
+			w.Write([]byte(`{
+  "ostree-version": "47.2",
+  "amis": [{"name": "us-east-1", "hvm": "ami-1"}],
+  "images": {
+    "qemu": {"path": "rhcos-qemu.qcow2", "sha256": "abc123"},
+    "metal": {"path": "rhcos-metal.raw.gz", "sha256": "def456"},
+    "kernel": {"path": "rhcos-kernel", "sha256": "aaa111"},
+    "initramfs": {"path": "rhcos-initramfs.img", "sha256": "bbb222"}
+  }
+}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	defer func(url string) { baseURL = url }(baseURL)
+	baseURL = server.URL
+
+	stream, err := FetchStream(context.Background(), "maipo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "maipo", stream.Stream)
+	arch, err := stream.Architecture(DefaultArchitecture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []struct {
+		platform, format, file string
+		expected               Artifact
+	}{
+		{"qemu", "qcow2", "disk", Artifact{Location: server.URL + "/maipo/47.2/rhcos-qemu.qcow2", SHA256: "abc123"}},
+		{"metal", "raw.gz", "disk", Artifact{Location: server.URL + "/maipo/47.2/rhcos-metal.raw.gz", SHA256: "def456"}},
+		{"metal", "pxe", "kernel", Artifact{Location: server.URL + "/maipo/47.2/rhcos-kernel", SHA256: "aaa111"}},
+		{"metal", "pxe", "initramfs", Artifact{Location: server.URL + "/maipo/47.2/rhcos-initramfs.img", SHA256: "bbb222"}},
+	} {
+		artifact, err := arch.Artifact(file.platform, file.format, file.file)
+		if assert.NoError(t, err) {
+			assert.Equal(t, &file.expected, artifact)
+		}
+	}
+	assert.Equal(t, "47.2", arch.Artifacts["qemu"].Release)
+	ami, err := arch.AMI("us-east-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ami-1", ami)
+}
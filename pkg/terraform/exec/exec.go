@@ -1,4 +1,5 @@
 // Package exec is glue between the vendored terraform codebase and installer.
+// Importing it sets terraform.Run.
 package exec
 
 import (
@@ -14,9 +15,21 @@ import (
 	"github.com/hashicorp/logutils"
 	"github.com/hashicorp/terraform/command"
 	"github.com/hashicorp/terraform/helper/logging"
+	"github.com/hashicorp/terraform/version"
 	"github.com/mitchellh/cli"
+
+	"github.com/openshift/installer/pkg/terraform"
 )
 
+// LogWriter, if set, receives the Terraform logs at all levels, including
+// those of the providers, while the stdout of the commands only receives
+// those at the level of TF_LOG, if it is set.
+var LogWriter io.Writer
+
+func init() {
+	terraform.Run = runner
+}
+
 type cmdFunc func(command.Meta) cli.Command
 
 var commands = map[string]cmdFunc{
@@ -29,16 +42,30 @@ var commands = map[string]cmdFunc{
 	"init": func(meta command.Meta) cli.Command {
 		return &command.InitCommand{Meta: meta}
 	},
+	"plan": func(meta command.Meta) cli.Command {
+		return &command.PlanCommand{Meta: meta}
+	},
 }
 
 func runner(cmd string, dir string, args []string, stdout, stderr io.Writer) int {
-	lf := ioutil.Discard
+	var writers []io.Writer
 	if level := logging.LogLevel(); level != "" {
-		lf = &logutils.LevelFilter{
+		writers = append(writers, &logutils.LevelFilter{
 			Levels:   logging.ValidLevels,
 			MinLevel: logutils.LogLevel(level),
 			Writer:   stdout,
-		}
+		})
+	} else if LogWriter != nil {
+		// The providers only log if TF_LOG is set in their environment.
+		os.Setenv(logging.EnvLog, "TRACE")
+		defer os.Unsetenv(logging.EnvLog)
+	}
+	if LogWriter != nil {
+		writers = append(writers, LogWriter)
+	}
+	lf := ioutil.Discard
+	if len(writers) > 0 {
+		lf = io.MultiWriter(writers...)
 	}
 	log.SetOutput(lf)
 	defer log.SetOutput(os.Stderr)
@@ -104,6 +131,11 @@ func Init(datadir string, args []string, stdout, stderr io.Writer) int {
 	return runner("init", datadir, args, stdout, stderr)
 }
 
+// Plan is wrapper around `terraform plan` subcommand.
+func Plan(datadir string, args []string, stdout, stderr io.Writer) int {
+	return runner("plan", datadir, args, stdout, stderr)
+}
+
 // makeShutdownCh creates an interrupt listener and returns a channel.
 // A message will be sent on the channel for every interrupt received.
 func makeShutdownCh() (<-chan struct{}, func()) {
@@ -124,3 +156,8 @@ func makeShutdownCh() (<-chan struct{}, func()) {
 
 	return resultCh, func() { signal.Reset(handle...) }
 }
+
+// Version returns the version of the vendored terraform.
+func Version() string {
+	return version.String()
+}
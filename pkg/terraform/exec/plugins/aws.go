@@ -12,4 +12,5 @@ func init() {
 		})
 	}
 	KnownPlugins["terraform-provider-aws"] = exec
+	KnownPluginVersions["terraform-provider-aws"] = "1.52.0"
 }
@@ -12,4 +12,5 @@ func init() {
 		})
 	}
 	KnownPlugins["terraform-provider-ignition"] = exec
+	KnownPluginVersions["terraform-provider-ignition"] = "1.0.1"
 }
@@ -16,4 +16,5 @@ func init() {
 		})
 	}
 	KnownPlugins["terraform-provider-libvirt"] = exec
+	KnownPluginVersions["terraform-provider-libvirt"] = "2ad0228349b2d3b487a2ada25d1a0eb40d73b7d1"
 }
@@ -12,4 +12,5 @@ func init() {
 		})
 	}
 	KnownPlugins["terraform-provider-openstack"] = exec
+	KnownPluginVersions["terraform-provider-openstack"] = "1.12.0"
 }
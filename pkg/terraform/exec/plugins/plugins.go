@@ -3,3 +3,7 @@ package plugins
 
 // KnownPlugins is a map of all the known plugin names to their exec functions.
 var KnownPlugins = map[string]func(){}
+
+// KnownPluginVersions is a map of all the known plugin names to the
+// versions vendored for them. Keep these in sync with Gopkg.toml.
+var KnownPluginVersions = map[string]string{}
@@ -0,0 +1,63 @@
+package terraform
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// planResourceRegexp matches the lines of 'terraform plan' output
+	// which introduce a resource change, e.g. "  + aws_instance.master[0]".
+	planResourceRegexp = regexp.MustCompile(`^\s*(\+|-|~|-/\+|<=) ((?:module\.[^.\s]+\.)*(?:data\.)?([^.\s]+)\.[^.\s]+)(?: \(.*\))?$`)
+
+	// planSummaryRegexp matches the final line of 'terraform plan' output.
+	planSummaryRegexp = regexp.MustCompile(`^Plan: (\d+) to add, (\d+) to change, (\d+) to destroy\.`)
+)
+
+// PlanSummary is a summary of the changes in a Terraform plan.
+type PlanSummary struct {
+	// Add, Change, and Destroy are the number of resources which would
+	// be created, updated in-place, and deleted.
+	Add, Change, Destroy int
+
+	// Created is the number of resources which would be created
+	// (including replacements), keyed by resource type.
+	Created map[string]int
+}
+
+// CreatedTypes returns the sorted types of the resources which would be
+// created.
+func (s *PlanSummary) CreatedTypes() []string {
+	types := make([]string, 0, len(s.Created))
+	for resourceType := range s.Created {
+		types = append(types, resourceType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ParsePlan reads the output of 'terraform plan' and summarizes it.
+func ParsePlan(r io.Reader) (*PlanSummary, error) {
+	summary := &PlanSummary{Created: map[string]int{}}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " ")
+		if match := planResourceRegexp.FindStringSubmatch(line); match != nil {
+			action, resourceType := match[1], match[3]
+			if action == "+" || action == "-/+" {
+				summary.Created[resourceType]++
+			}
+			continue
+		}
+		if match := planSummaryRegexp.FindStringSubmatch(line); match != nil {
+			summary.Add, _ = strconv.Atoi(match[1])
+			summary.Change, _ = strconv.Atoi(match[2])
+			summary.Destroy, _ = strconv.Atoi(match[3])
+		}
+	}
+	return summary, scanner.Err()
+}
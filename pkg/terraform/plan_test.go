@@ -0,0 +1,85 @@
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanResourceRegexp(t *testing.T) {
+	cases := []struct {
+		line         string
+		action       string
+		resourceType string
+	}{
+		{line: "  + aws_instance.master", action: "+", resourceType: "aws_instance"},
+		{line: "  + aws_instance.master[0]", action: "+", resourceType: "aws_instance"},
+		{line: "  - aws_route53_record.api", action: "-", resourceType: "aws_route53_record"},
+		{line: "  ~ module.vpc.aws_vpc.new_vpc", action: "~", resourceType: "aws_vpc"},
+		{line: "-/+ module.bootstrap.aws_instance.bootstrap (new resource required)", action: "-/+", resourceType: "aws_instance"},
+		{line: " <= module.vpc.data.aws_vpc.cluster_vpc", action: "<=", resourceType: "aws_vpc"},
+		{line: "      ami:                         \"ami-0123456789\""},
+		{line: "Plan: 1 to add, 0 to change, 0 to destroy."},
+	}
+	for _, tc := range cases {
+		t.Run(tc.line, func(t *testing.T) {
+			match := planResourceRegexp.FindStringSubmatch(tc.line)
+			if tc.action == "" {
+				assert.Nil(t, match)
+			} else if assert.NotNil(t, match) {
+				assert.Equal(t, tc.action, match[1])
+				assert.Equal(t, tc.resourceType, match[3])
+			}
+		})
+	}
+}
+
+func TestParsePlan(t *testing.T) {
+	plan := `
+An execution plan has been generated and is shown below.
+Resource actions are indicated with the following symbols:
+  + create
+  ~ update in-place
+-/+ destroy and then create replacement
+
+Terraform will perform the following actions:
+
+  + aws_instance.master[0]
+      id:                          <computed>
+      ami:                         "ami-0123456789"
+
+  + aws_instance.master[1]
+      id:                          <computed>
+
+  ~ module.vpc.aws_vpc.new_vpc
+      tags.%:                      "2" => "3"
+
+-/+ module.bootstrap.aws_instance.bootstrap (new resource required)
+      id:                          "i-0123456789" => <computed> (forces new resource)
+
+  - aws_route53_record.api
+
+
+Plan: 3 to add, 1 to change, 2 to destroy.
+`
+	summary, err := ParsePlan(strings.NewReader(plan))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, &PlanSummary{
+		Add:     3,
+		Change:  1,
+		Destroy: 2,
+		Created: map[string]int{"aws_instance": 3},
+	}, summary)
+	assert.Equal(t, []string{"aws_instance"}, summary.CreatedTypes())
+}
+
+func TestParseEmptyPlan(t *testing.T) {
+	summary, err := ParsePlan(strings.NewReader("No changes. Infrastructure is up-to-date.\n"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, &PlanSummary{Created: map[string]int{}}, summary)
+		assert.Empty(t, summary.CreatedTypes())
+	}
+}
@@ -0,0 +1,41 @@
+package terraform
+
+import (
+	"regexp"
+	"time"
+)
+
+const (
+	// maxApplyAttempts is how many times an apply which fails with a
+	// transient error is run before its failure is returned.
+	maxApplyAttempts = 3
+
+	// applyRetryDelay is the delay before the first retry of an apply,
+	// which grows with each attempt.
+	applyRetryDelay = 15 * time.Second
+)
+
+// transientErrors match the errors of the Terraform providers which go
+// away when the apply is retried, because the cloud throttled the
+// requests or had not yet propagated a resource which was just created.
+var transientErrors = []struct {
+	pattern *regexp.Regexp
+	reason  string
+}{
+	{regexp.MustCompile(`RequestLimitExceeded|Throttling|Rate exceeded|SlowDown`), "the requests were throttled"},
+	{regexp.MustCompile(`(?m)^.*iamInstanceProfile\.\w+ (is invalid|does not exist).*$|Invalid IAM Instance Profile`), "a new IAM instance profile was not propagated yet"},
+	{regexp.MustCompile(`NoSuchEntity`), "a new IAM role was not propagated yet"},
+	{regexp.MustCompile(`InvalidInstanceID\.NotFound|InvalidGroup\.NotFound|InvalidSubnetID\.NotFound|InvalidRouteTableID\.NotFound|InvalidVpcID\.NotFound`), "a new resource was not propagated yet"},
+	{regexp.MustCompile(`connection reset by peer|TLS handshake timeout|i/o timeout`), "the connection to the cloud failed"},
+}
+
+// transientError returns why the failure of an apply, whose errors are in
+// output, is transient, or an empty string if it is not known to be.
+func transientError(output string) string {
+	for _, transient := range transientErrors {
+		if transient.pattern.MatchString(output) {
+			return transient.reason
+		}
+	}
+	return ""
+}
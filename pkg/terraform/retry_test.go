@@ -0,0 +1,62 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransientError(t *testing.T) {
+	cases := []struct {
+		name     string
+		output   string
+		expected string
+	}{
+		{
+			name:     "throttled",
+			output:   "* aws_instance.master.0: Error launching source instance: RequestLimitExceeded: Request limit exceeded.",
+			expected: "the requests were throttled",
+		},
+		{
+			name:     "instance profile",
+			output:   "* aws_instance.bootstrap: Error launching source instance: InvalidParameterValue: Value (test-bootstrap-profile) for parameter iamInstanceProfile.name is invalid. Invalid IAM Instance Profile name",
+			expected: "a new IAM instance profile was not propagated yet",
+		},
+		{
+			name:     "instance profile ARN",
+			output:   "* aws_instance.master.0: Error launching source instance: InvalidParameterValue: Value (arn:aws:iam::0123456789:instance-profile/test-master-profile) for parameter iamInstanceProfile.arn does not exist",
+			expected: "a new IAM instance profile was not propagated yet",
+		},
+		{
+			name:   "instance profile in another error",
+			output: "* aws_instance.master.0: Error launching source instance: UnauthorizedOperation: You are not authorized to use iamInstanceProfile.name test-master-profile.\n* aws_s3_bucket_object.ignition: Error putting object: NoSuchBucket: The specified bucket does not exist",
+		},
+		{
+			name:     "role",
+			output:   "* aws_iam_role_policy.worker_policy: Error putting IAM role policy: NoSuchEntity: The role with name test-worker-role cannot be found.",
+			expected: "a new IAM role was not propagated yet",
+		},
+		{
+			name:     "resource",
+			output:   "* aws_route_table_association.route_net.1: InvalidRouteTableID.NotFound: The routeTable ID 'rtb-0123456789' does not exist",
+			expected: "a new resource was not propagated yet",
+		},
+		{
+			name:     "connection",
+			output:   "* provider.aws: dial tcp 52.94.0.1:443: i/o timeout",
+			expected: "the connection to the cloud failed",
+		},
+		{
+			name:   "permanent",
+			output: "* aws_instance.master.0: Error launching source instance: UnauthorizedOperation: You are not authorized to perform this operation.",
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, transientError(tc.output))
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// StageInputsFileName is the name of the var file, in the directory of a
+// stage, which holds the outputs of the stages applied before it.
+const StageInputsFileName = "stage-inputs.tfvars"
+
+// Stage is a root module of the Terraform modules of a platform, which is
+// applied with its own state, so that it can be retried or destroyed
+// without touching the resources of the other stages.
+type Stage struct {
+	// Name is the name of the stage, e.g. "bootstrap".
+	Name string
+
+	// Module is the path of the root module of the stage in the
+	// platform's modules, or "" for their top level.
+	Module string
+}
+
+// stages are the stages of the platforms which have more than one, in the
+// order they are applied. Later stages use the root outputs of earlier
+// ones as their variables.
+var stages = map[string][]Stage{
+	"aws": {
+		{Name: "infra"},
+		{Name: "bootstrap", Module: filepath.Join("stages", "bootstrap")},
+	},
+}
+
+// Stages returns the stages of the platform in the order they are applied.
+// Platforms which are not staged have a single stage with all their
+// resources.
+func Stages(platform string) []Stage {
+	if s, ok := stages[platform]; ok {
+		return s
+	}
+	return []Stage{{Name: "infra"}}
+}
+
+// LookupStage returns the stage of the platform with the given name.
+func LookupStage(platform string, name string) (Stage, bool) {
+	for _, stage := range Stages(platform) {
+		if stage.Name == name {
+			return stage, true
+		}
+	}
+	return Stage{}, false
+}
+
+// StateFileName returns the name of the state file of the stage. The top
+// level stage keeps StateFileName, so that the asset directories of
+// clusters which were created before their platform was staged still
+// work.
+func (s Stage) StateFileName() string {
+	if s.Module == "" {
+		return StateFileName
+	}
+	return fmt.Sprintf("terraform.%s.tfstate", s.Name)
+}
+
+// StateFileNames returns the names of the state files of the stages of
+// all platforms, starting with StateFileName, for the callers which do
+// not know the platform of an asset directory.
+func StateFileNames() []string {
+	platforms := make([]string, 0, len(stages))
+	for platform := range stages {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+
+	names := []string{StateFileName}
+	seen := map[string]bool{StateFileName: true}
+	for _, platform := range platforms {
+		for _, stage := range stages[platform] {
+			if name := stage.StateFileName(); !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// ApplyStages applies the stages of the platform in order, in the given
+// directory, which holds their state files and the var file. It stops at
+// the first stage which fails.
+func ApplyStages(dir string, platform string, extraArgs ...string) error {
+	for _, stage := range Stages(platform) {
+		if _, err := ApplyStage(dir, platform, stage, extraArgs...); err != nil {
+			return errors.Wrapf(err, "failed to apply the %s stage", stage.Name)
+		}
+	}
+	return nil
+}
+
+// ApplyStage applies the stage in the given directory, with the outputs
+// of the stages before it as its variables, and returns the absolute path
+// of its state file.
+func ApplyStage(dir string, platform string, stage Stage, extraArgs ...string) (path string, err error) {
+	args, err := stageArgs(dir, platform, stage)
+	if err != nil {
+		return "", err
+	}
+	return apply(dir, platform, stage, append(args, extraArgs...)...)
+}
+
+// DestroyStage destroys the resources of the stage in the given
+// directory.
+func DestroyStage(dir string, platform string, stage Stage, extraArgs ...string) error {
+	args, err := stageArgs(dir, platform, stage)
+	if err != nil {
+		return err
+	}
+	return destroy(dir, platform, stage, append(args, extraArgs...)...)
+}
+
+// stageArgs writes the root outputs of the stages before the given one,
+// read from their state files in dir, to the var file of the stage, and
+// returns the arguments which pass it to Terraform.
+func stageArgs(dir string, platform string, stage Stage) ([]string, error) {
+	if stage.Module == "" {
+		return nil, nil
+	}
+
+	inputs := map[string]interface{}{}
+	for _, earlier := range Stages(platform) {
+		if earlier.Name == stage.Name {
+			break
+		}
+		state, err := ReadState(filepath.Join(dir, earlier.StateFileName()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the outputs of the %s stage", earlier.Name)
+		}
+		for name, value := range state.Outputs() {
+			inputs[name] = value
+		}
+	}
+
+	path := filepath.Join(dir, stage.Module, StageInputsFileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(inputs, "", "  ")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal the inputs of the %s stage", stage.Name)
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return nil, errors.Wrapf(err, "failed to write the inputs of the %s stage", stage.Name)
+	}
+	return []string{fmt.Sprintf("-var-file=%s", path)}, nil
+}
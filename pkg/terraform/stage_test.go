@@ -0,0 +1,68 @@
+package terraform
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStages(t *testing.T) {
+	assert.Equal(t, []Stage{{Name: "infra"}, {Name: "bootstrap", Module: filepath.Join("stages", "bootstrap")}}, Stages("aws"))
+	assert.Equal(t, []Stage{{Name: "infra"}}, Stages("libvirt"))
+
+	stage, ok := LookupStage("aws", "bootstrap")
+	assert.True(t, ok)
+	assert.Equal(t, "terraform.bootstrap.tfstate", stage.StateFileName())
+	_, ok = LookupStage("libvirt", "bootstrap")
+	assert.False(t, ok)
+}
+
+func TestStateFileNames(t *testing.T) {
+	assert.Equal(t, []string{StateFileName, "terraform.bootstrap.tfstate"}, StateFileNames())
+}
+
+func TestStageArgs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestStageArgs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	infra, _ := LookupStage("aws", "infra")
+	bootstrap, _ := LookupStage("aws", "bootstrap")
+
+	args, err := stageArgs(dir, "aws", infra)
+	assert.NoError(t, err)
+	assert.Nil(t, args)
+
+	_, err = stageArgs(dir, "aws", bootstrap)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "failed to read the outputs of the infra stage")
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, infra.StateFileName()), []byte(testState), 0600); err != nil {
+		t.Fatal(err)
+	}
+	args, err = stageArgs(dir, "aws", bootstrap)
+	if !assert.NoError(t, err) {
+		return
+	}
+	path := filepath.Join(dir, "stages", "bootstrap", StageInputsFileName)
+	assert.Equal(t, []string{"-var-file=" + path}, args)
+
+	data, err := ioutil.ReadFile(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	inputs := map[string]interface{}{}
+	if assert.NoError(t, json.Unmarshal(data, &inputs)) {
+		assert.Equal(t, map[string]interface{}{
+			"vpc_id":     "vpc-0123456789",
+			"subnet_ids": []interface{}{"subnet-a", "subnet-b"},
+		}, inputs)
+	}
+}
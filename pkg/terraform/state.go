@@ -0,0 +1,126 @@
+package terraform
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// State is the subset of a Terraform state file needed to look up the
+// attributes of the resources it holds.
+type State struct {
+	Modules []StateModule `json:"modules"`
+}
+
+// StateModule is a module in a Terraform state file.
+type StateModule struct {
+	// Path is the module path, e.g. ["root", "bootstrap"].
+	Path []string `json:"path"`
+
+	// Outputs maps the names of the outputs of the module to them.
+	Outputs map[string]StateOutput `json:"outputs"`
+
+	// Resources maps resource keys (e.g. "aws_instance.master.0") to resources.
+	Resources map[string]StateResource `json:"resources"`
+}
+
+// StateOutput is an output of a module in a Terraform state file.
+type StateOutput struct {
+	// Type is "string", "list" or "map".
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// StateResource is a resource in a Terraform state file.
+type StateResource struct {
+	Type    string        `json:"type"`
+	Primary StateInstance `json:"primary"`
+}
+
+// StateInstance is an instance of a resource in a Terraform state file.
+type StateInstance struct {
+	ID         string            `json:"id"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// ReadState reads the Terraform state file at path.
+func ReadState(path string) (*State, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := ParseState(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %q", path)
+	}
+	return state, nil
+}
+
+// ParseState parses the contents of a Terraform state file.
+func ParseState(data []byte) (*State, error) {
+	state := &State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Outputs returns the values of the outputs of the root module.
+func (s *State) Outputs() map[string]interface{} {
+	outputs := map[string]interface{}{}
+	for _, m := range s.Modules {
+		if strings.Join(m.Path, ".") != "root" {
+			continue
+		}
+		for name, output := range m.Outputs {
+			outputs[name] = output.Value
+		}
+	}
+	return outputs
+}
+
+// LookupResource returns the instances of the resource with the given type
+// and name in module (e.g. "root" or "root.bootstrap"). Instances created
+// with count are returned in index order.
+func (s *State) LookupResource(module, resourceType, name string) []StateInstance {
+	prefix := resourceType + "." + name
+	for _, m := range s.Modules {
+		if strings.Join(m.Path, ".") != module {
+			continue
+		}
+
+		indexes := map[int]StateInstance{}
+		for key, resource := range m.Resources {
+			if key == prefix {
+				indexes[0] = resource.Primary
+				continue
+			}
+			if !strings.HasPrefix(key, prefix+".") {
+				continue
+			}
+			index, err := strconv.Atoi(strings.TrimPrefix(key, prefix+"."))
+			if err != nil {
+				continue
+			}
+			indexes[index] = resource.Primary
+		}
+
+		keys := make([]int, 0, len(indexes))
+		for index := range indexes {
+			keys = append(keys, index)
+		}
+		sort.Ints(keys)
+
+		instances := make([]StateInstance, 0, len(keys))
+		for _, index := range keys {
+			instances = append(instances, indexes[index])
+		}
+		return instances
+	}
+	return nil
+}
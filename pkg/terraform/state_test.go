@@ -0,0 +1,85 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testState = `{
+  "version": 3,
+  "modules": [
+    {
+      "path": ["root"],
+      "outputs": {
+        "vpc_id": {"type": "string", "value": "vpc-0123456789"},
+        "subnet_ids": {"type": "list", "value": ["subnet-a", "subnet-b"]}
+      },
+      "resources": {
+        "aws_instance.master.0": {"type": "aws_instance", "primary": {"id": "i-0", "attributes": {"private_ip": "10.0.0.10"}}},
+        "aws_instance.master.1": {"type": "aws_instance", "primary": {"id": "i-1"}},
+        "aws_instance.master.10": {"type": "aws_instance", "primary": {"id": "i-10"}},
+        "aws_instance.master.2": {"type": "aws_instance", "primary": {"id": "i-2"}},
+        "aws_instance.master_extra": {"type": "aws_instance", "primary": {"id": "i-extra"}},
+        "aws_route53_zone.int": {"type": "aws_route53_zone", "primary": {"id": "Z0123456789"}}
+      }
+    },
+    {
+      "path": ["root", "bootstrap"],
+      "outputs": {
+        "bootstrap_ip": {"type": "string", "value": "10.0.0.5"}
+      },
+      "resources": {
+        "aws_instance.bootstrap": {"type": "aws_instance", "primary": {"id": "i-bootstrap"}}
+      }
+    }
+  ]
+}`
+
+func TestLookupResource(t *testing.T) {
+	state, err := ParseState([]byte(testState))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cases := []struct {
+		name         string
+		module       string
+		resourceType string
+		resource     string
+		expected     []string
+	}{
+		{name: "count", module: "root", resourceType: "aws_instance", resource: "master", expected: []string{"i-0", "i-1", "i-2", "i-10"}},
+		{name: "single", module: "root", resourceType: "aws_route53_zone", resource: "int", expected: []string{"Z0123456789"}},
+		{name: "module", module: "root.bootstrap", resourceType: "aws_instance", resource: "bootstrap", expected: []string{"i-bootstrap"}},
+		{name: "other module", module: "root", resourceType: "aws_instance", resource: "bootstrap", expected: []string{}},
+		{name: "missing module", module: "root.vpc", resourceType: "aws_vpc", resource: "new_vpc"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			instances := state.LookupResource(tc.module, tc.resourceType, tc.resource)
+			if tc.expected == nil {
+				assert.Nil(t, instances)
+				return
+			}
+			ids := make([]string, 0, len(instances))
+			for _, instance := range instances {
+				ids = append(ids, instance.ID)
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+
+	instances := state.LookupResource("root", "aws_instance", "master")
+	assert.Equal(t, "10.0.0.10", instances[0].Attributes["private_ip"])
+}
+
+func TestOutputs(t *testing.T) {
+	state, err := ParseState([]byte(testState))
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"vpc_id":     "vpc-0123456789",
+			"subnet_ids": []interface{}{"subnet-a", "subnet-b"},
+		}, state.Outputs())
+	}
+}
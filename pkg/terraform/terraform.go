@@ -1,17 +1,24 @@
 package terraform
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/openshift/installer/data"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/lineprinter"
-	texec "github.com/openshift/installer/pkg/terraform/exec"
 	"github.com/openshift/installer/pkg/terraform/exec/plugins"
 )
 
@@ -21,28 +28,50 @@ const (
 
 	// VarFileName is the default name for Terraform var file.
 	VarFileName string = "terraform.tfvars"
+
+	// SnapshotDirName is the name of the directory, in SnapshotDir, to
+	// which the state is copied after each apply or destroy.
+	SnapshotDirName string = ".openshift_install_state"
 )
 
+// Run runs the Terraform command, such as apply, in-process with the data
+// directory and the arguments, and returns its exit code. It is set when the
+// terraform/exec package, which vendors Terraform, is imported, so that the
+// packages which only prepare the Terraform variables and modules are built
+// and tested without Terraform.
+var Run func(command string, datadir string, args []string, stdout, stderr io.Writer) int
+
+// SnapshotDir, if set, is the directory in whose SnapshotDirName Apply and
+// Destroy keep a copy of the state after each run, named by the time and
+// command, so that failed runs can be debugged even once the state of the
+// asset directory moved on.
+var SnapshotDir string
+
 // Apply unpacks the platform-specific Terraform modules into the
 // given directory and then runs 'terraform init' and 'terraform
-// apply'.  It returns the absolute path of the tfstate file, rooted
-// in the specified directory, along with any errors from Terraform.
+// apply' on the first of their stages.  It returns the absolute path
+// of the tfstate file, rooted in the specified directory, along with
+// any errors from Terraform.
 func Apply(dir string, platform string, extraArgs ...string) (path string, err error) {
-	err = unpackAndInit(dir, platform)
+	return apply(dir, platform, Stages(platform)[0], extraArgs...)
+}
+
+func apply(dir string, platform string, stage Stage, extraArgs ...string) (path string, err error) {
+	moduleDir, err := unpackAndInit(dir, platform, stage)
 	if err != nil {
 		return "", err
 	}
 
+	sf := filepath.Join(dir, stage.StateFileName())
 	defaultArgs := []string{
 		"-auto-approve",
 		"-input=false",
-		fmt.Sprintf("-state=%s", filepath.Join(dir, StateFileName)),
-		fmt.Sprintf("-state-out=%s", filepath.Join(dir, StateFileName)),
+		fmt.Sprintf("-state=%s", sf),
+		fmt.Sprintf("-state-out=%s", sf),
 		fmt.Sprintf("-var-file=%s", filepath.Join(dir, VarFileName)),
 	}
 	args := append(defaultArgs, extraArgs...)
-	args = append(args, dir)
-	sf := filepath.Join(dir, StateFileName)
+	args = append(args, moduleDir)
 
 	tDebug := &lineprinter.Trimmer{WrappedPrint: logrus.Debug}
 	tError := &lineprinter.Trimmer{WrappedPrint: logrus.Error}
@@ -51,30 +80,49 @@ func Apply(dir string, platform string, extraArgs ...string) (path string, err e
 	defer lpDebug.Close()
 	defer lpError.Close()
 
-	if exitCode := texec.Apply(dir, args, lpDebug, lpError); exitCode != 0 {
-		return sf, errors.New("failed to apply using Terraform")
+	// Applies which fail with a transient error are retried, picking up
+	// from the state of the failed attempt.
+	for attempt := 1; ; attempt++ {
+		var stderr bytes.Buffer
+		exitCode := Run("apply", moduleDir, args, lpDebug, io.MultiWriter(lpError, &stderr))
+		snapshotState(sf, "apply")
+		if exitCode == 0 {
+			return sf, nil
+		}
+
+		reason := transientError(stderr.String())
+		if reason == "" || attempt >= maxApplyAttempts {
+			return sf, errors.New("failed to apply using Terraform")
+		}
+		delay := time.Duration(attempt) * applyRetryDelay
+		logrus.Warnf("Terraform apply failed because %s; retrying in %s (attempt %d of %d)", reason, delay, attempt+1, maxApplyAttempts)
+		time.Sleep(delay)
 	}
-	return sf, nil
 }
 
 // Destroy unpacks the platform-specific Terraform modules into the
 // given directory and then runs 'terraform init' and 'terraform
-// destroy'.
+// destroy' on the first of their stages.
 func Destroy(dir string, platform string, extraArgs ...string) (err error) {
-	err = unpackAndInit(dir, platform)
+	return destroy(dir, platform, Stages(platform)[0], extraArgs...)
+}
+
+func destroy(dir string, platform string, stage Stage, extraArgs ...string) (err error) {
+	moduleDir, err := unpackAndInit(dir, platform, stage)
 	if err != nil {
 		return err
 	}
 
+	sf := filepath.Join(dir, stage.StateFileName())
 	defaultArgs := []string{
 		"-auto-approve",
 		"-input=false",
-		fmt.Sprintf("-state=%s", filepath.Join(dir, StateFileName)),
-		fmt.Sprintf("-state-out=%s", filepath.Join(dir, StateFileName)),
+		fmt.Sprintf("-state=%s", sf),
+		fmt.Sprintf("-state-out=%s", sf),
 		fmt.Sprintf("-var-file=%s", filepath.Join(dir, VarFileName)),
 	}
 	args := append(defaultArgs, extraArgs...)
-	args = append(args, dir)
+	args = append(args, moduleDir)
 
 	tDebug := &lineprinter.Trimmer{WrappedPrint: logrus.Debug}
 	tError := &lineprinter.Trimmer{WrappedPrint: logrus.Error}
@@ -83,21 +131,93 @@ func Destroy(dir string, platform string, extraArgs ...string) (err error) {
 	defer lpDebug.Close()
 	defer lpError.Close()
 
-	if exitCode := texec.Destroy(dir, args, lpDebug, lpError); exitCode != 0 {
+	exitCode := Run("destroy", moduleDir, args, lpDebug, lpError)
+	snapshotState(sf, "destroy")
+	if exitCode != 0 {
 		return errors.New("failed to destroy using Terraform")
 	}
 	return nil
 }
 
+// snapshotState copies the state file to the SnapshotDir, if it is set. A
+// failed snapshot is only logged, because it is not needed to install.
+func snapshotState(stateFile string, command string) {
+	if SnapshotDir == "" {
+		return
+	}
+
+	data, err := ioutil.ReadFile(stateFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logrus.Debug(errors.Wrap(err, "failed to read the Terraform state to snapshot"))
+		}
+		return
+	}
+
+	data, err = asset.EncryptSensitive(data)
+	if err != nil {
+		logrus.Debug(errors.Wrap(err, "failed to encrypt the Terraform state snapshot"))
+		return
+	}
+
+	snapshotDir := filepath.Join(SnapshotDir, SnapshotDirName)
+	if err := os.MkdirAll(snapshotDir, 0700); err != nil {
+		logrus.Debug(errors.Wrap(err, "failed to create the Terraform state snapshot directory"))
+		return
+	}
+	name := fmt.Sprintf("%s-%s-%s", time.Now().UTC().Format("20060102T150405Z"), command, filepath.Base(stateFile))
+	if err := ioutil.WriteFile(filepath.Join(snapshotDir, name), data, 0600); err != nil {
+		logrus.Debug(errors.Wrap(err, "failed to snapshot the Terraform state"))
+		return
+	}
+	logrus.Debugf("Snapshotted the Terraform state to %s", filepath.Join(SnapshotDirName, name))
+}
+
+// Plan unpacks the platform-specific Terraform modules into the given
+// directory and then runs 'terraform init' and 'terraform plan' on the
+// first of their stages. It returns a summary of the changes Terraform
+// would make. The later stages are not planned, because their variables
+// are the outputs of the stages before them, which are not known until
+// those are applied.
+func Plan(dir string, platform string, extraArgs ...string) (*PlanSummary, error) {
+	stage := Stages(platform)[0]
+	moduleDir, err := unpackAndInit(dir, platform, stage)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultArgs := []string{
+		"-input=false",
+		fmt.Sprintf("-state=%s", filepath.Join(dir, stage.StateFileName())),
+		fmt.Sprintf("-var-file=%s", filepath.Join(dir, VarFileName)),
+	}
+	args := append(defaultArgs, extraArgs...)
+	args = append(args, moduleDir)
+
+	tDebug := &lineprinter.Trimmer{WrappedPrint: logrus.Debug}
+	tError := &lineprinter.Trimmer{WrappedPrint: logrus.Error}
+	lpDebug := &lineprinter.LinePrinter{Print: tDebug.Print}
+	lpError := &lineprinter.LinePrinter{Print: tError.Print}
+	defer lpDebug.Close()
+	defer lpError.Close()
+
+	var plan bytes.Buffer
+	if exitCode := Run("plan", moduleDir, args, io.MultiWriter(lpDebug, &plan), lpError); exitCode != 0 {
+		return nil, errors.New("failed to plan using Terraform")
+	}
+	return ParsePlan(&plan)
+}
+
 // unpack unpacks the platform-specific Terraform modules into the
-// given directory.
-func unpack(dir string, platform string) (err error) {
+// given directory, and the shared configuration into the root module of
+// the stage.
+func unpack(dir string, platform string, stage Stage) (err error) {
 	err = data.Unpack(dir, platform)
 	if err != nil {
 		return err
 	}
 
-	err = data.Unpack(filepath.Join(dir, "config.tf"), "config.tf")
+	err = data.Unpack(filepath.Join(dir, stage.Module, "config.tf"), "config.tf")
 	if err != nil {
 		return err
 	}
@@ -106,15 +226,26 @@ func unpack(dir string, platform string) (err error) {
 }
 
 // unpackAndInit unpacks the platform-specific Terraform modules into
-// the given directory and then runs 'terraform init'.
-func unpackAndInit(dir string, platform string) (err error) {
-	err = unpack(dir, platform)
+// the given directory and then runs 'terraform init' in the root module
+// of the stage, whose directory it returns. The root module is also the
+// data directory of its stage, so that each stage is initialized on its
+// own.
+func unpackAndInit(dir string, platform string, stage Stage) (moduleDir string, err error) {
+	if Run == nil {
+		return "", errors.New("Terraform is not built into this program; import github.com/openshift/installer/pkg/terraform/exec")
+	}
+
+	err = unpack(dir, platform, stage)
 	if err != nil {
-		return errors.Wrap(err, "failed to unpack Terraform modules")
+		return "", errors.Wrap(err, "failed to unpack Terraform modules")
 	}
 
-	if err := setupEmbeddedPlugins(dir); err != nil {
-		return errors.Wrap(err, "failed to setup embedded Terraform plugins")
+	if err := checkEmbeddedPlugins(dir); err != nil {
+		return "", err
+	}
+	moduleDir = filepath.Join(dir, stage.Module)
+	if err := setupEmbeddedPlugins(moduleDir); err != nil {
+		return "", errors.Wrap(err, "failed to setup embedded Terraform plugins")
 	}
 
 	tDebug := &lineprinter.Trimmer{WrappedPrint: logrus.Debug}
@@ -127,11 +258,11 @@ func unpackAndInit(dir string, platform string) (err error) {
 	args := []string{
 		"-get-plugins=false",
 	}
-	args = append(args, dir)
-	if exitCode := texec.Init(dir, args, lpDebug, lpError); exitCode != 0 {
-		return errors.New("failed to initialize Terraform")
+	args = append(args, moduleDir)
+	if exitCode := Run("init", moduleDir, args, lpDebug, lpError); exitCode != 0 {
+		return "", errors.New("failed to initialize Terraform")
 	}
-	return nil
+	return moduleDir, nil
 }
 
 func setupEmbeddedPlugins(dir string) error {
@@ -160,3 +291,57 @@ func setupEmbeddedPlugins(dir string) error {
 	}
 	return nil
 }
+
+// providerReference matches the provider blocks, resources and data sources
+// of Terraform configurations, whose provider is named by the prefix of
+// their type.
+var providerReference = regexp.MustCompile(`(?m)^\s*(?:provider\s+"([a-z0-9]+)"|(?:resource|data)\s+"([a-z0-9]+)_)`)
+
+// checkEmbeddedPlugins fails if the Terraform modules in the directory use
+// providers which are not embedded in the installer, because 'terraform
+// init' never downloads them, so that installs work without reaching the
+// Terraform registry, such as in disconnected environments.
+func checkEmbeddedPlugins(dir string) error {
+	required := map[string]struct{}{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && info.Name() == "plugins" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".tf" {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range providerReference.FindAllStringSubmatch(string(data), -1) {
+			required[match[1]+match[2]] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to find the Terraform providers of the modules")
+	}
+
+	var missing []string
+	for provider := range required {
+		if provider == "terraform" {
+			// built into Terraform, like terraform_remote_state
+			continue
+		}
+		if _, ok := plugins.KnownPlugins["terraform-provider-"+provider]; !ok {
+			missing = append(missing, provider)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return errors.Errorf("the Terraform providers %s are not embedded in this installer; build it with the tags of their platforms, such as libvirt", strings.Join(missing, ", "))
+	}
+	return nil
+}
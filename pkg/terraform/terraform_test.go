@@ -0,0 +1,76 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestApplyWithoutTerraform relies on the tests not importing terraform/exec,
+// which sets Run.
+func TestApplyWithoutTerraform(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestApplyWithoutTerraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = Apply(dir, "aws")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "import github.com/openshift/installer/pkg/terraform/exec")
+	}
+}
+
+func TestCheckEmbeddedPlugins(t *testing.T) {
+	cases := []struct {
+		name  string
+		files map[string]string
+		err   string
+	}{
+		{
+			name: "embedded",
+			files: map[string]string{
+				"main.tf":             "provider \"aws\" {\n  region = \"us-east-1\"\n}\n\ndata \"terraform_remote_state\" \"infra\" {}\n",
+				"bootstrap/main.tf":   "resource \"aws_instance\" \"bootstrap\" {}\n\ndata \"ignition_config\" \"bootstrap\" {}\n",
+				"plugins/readme.tf":   "resource \"unknown_thing\" \"ignored\" {}\n",
+				"bootstrap/README.md": "resource \"unknown_thing\" \"ignored\" {}\n",
+			},
+		},
+		{
+			name: "missing",
+			files: map[string]string{
+				"main.tf":     "provider \"google\" {}\n",
+				"vpc/main.tf": "  resource \"azurerm_virtual_network\" \"vnet\" {}\n  resource \"aws_vpc\" \"vpc\" {}\n",
+			},
+			err: "the Terraform providers azurerm, google are not embedded in this installer",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "TestCheckEmbeddedPlugins")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			for name, data := range tc.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = checkEmbeddedPlugins(dir)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
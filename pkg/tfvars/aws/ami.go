@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/rhcos"
+)
+
+// UseAMI sets the AMI of the machines from the OS image. If the AMI is in
+// another region, see rhcos.SplitAMI, Terraform copies it into the region
+// of the cluster, and the copy is encrypted if the snapshots of the source
+// AMI are.
+func (a *AWS) UseAMI(osImage string) error {
+	id, sourceRegion := rhcos.SplitAMI(osImage)
+	a.EC2AMIOverride = id
+	a.AMIRegion = sourceRegion
+	if sourceRegion == "" {
+		return nil
+	}
+
+	ssn, err := session.NewSessionWithOptions(session.Options{
+		Config:            aws.Config{Region: aws.String(sourceRegion)},
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create an AWS session")
+	}
+	a.AMIEncrypted, err = amiEncrypted(ec2.New(ssn), id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe the AMI %s in %s", id, sourceRegion)
+	}
+	return nil
+}
+
+// amiEncrypted returns whether any of the EBS snapshots of the AMI are
+// encrypted.
+func amiEncrypted(client *ec2.EC2, id string) (bool, error) {
+	output, err := client.DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds: []*string{aws.String(id)},
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(output.Images) == 0 {
+		return false, errors.New("not found")
+	}
+
+	for _, mapping := range output.Images[0].BlockDeviceMappings {
+		if mapping.Ebs != nil && aws.BoolValue(mapping.Ebs.Encrypted) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
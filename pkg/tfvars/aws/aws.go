@@ -2,11 +2,14 @@ package aws
 
 // AWS converts AWS related config.
 type AWS struct {
-	EC2AMIOverride string            `json:"aws_ec2_ami_override,omitempty"`
-	ExtraTags      map[string]string `json:"aws_extra_tags,omitempty"`
-	Master         `json:",inline"`
-	Region         string `json:"aws_region,omitempty"`
-	Worker         `json:",inline"`
+	AMIEncrypted            bool              `json:"aws_ami_encrypted,omitempty"`
+	AMIRegion               string            `json:"aws_ami_region,omitempty"`
+	BootstrapIgnitionBucket string            `json:"aws_bootstrap_ignition_bucket,omitempty"`
+	EC2AMIOverride          string            `json:"aws_ec2_ami_override,omitempty"`
+	ExtraTags               map[string]string `json:"aws_extra_tags,omitempty"`
+	Master                  `json:",inline"`
+	Region                  string `json:"aws_region,omitempty"`
+	Worker                  `json:",inline"`
 }
 
 // Master converts master related config.
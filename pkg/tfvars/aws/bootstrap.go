@@ -0,0 +1,40 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+const (
+	// bootstrapIgnitionKey is the key of the bootstrap Ignition config in
+	// its S3 bucket.
+	bootstrapIgnitionKey = "bootstrap.ign"
+)
+
+// BootstrapIgnitionURLExpiry is how long the presigned URL of the bootstrap
+// Ignition config is valid. The URL is presigned each time Terraform is
+// applied, and the bootstrap machine fetches it on its first boot, while
+// Terraform creates the cluster.
+var BootstrapIgnitionURLExpiry = time.Hour
+
+// BootstrapIgnitionBucket returns the name of the S3 bucket which holds the
+// bootstrap Ignition config of the cluster. Bucket names are global, so it
+// is named after the cluster ID.
+func BootstrapIgnitionBucket(clusterID string) string {
+	return fmt.Sprintf("openshift-bootstrap-data-%s", clusterID)
+}
+
+// PresignBootstrapIgnitionURL returns the presigned URL of the bootstrap
+// Ignition config in the bucket, from which the bootstrap machine fetches
+// it without S3 permissions of its own. The bucket need not exist yet.
+func PresignBootstrapIgnitionURL(client s3iface.S3API, bucket string) (string, error) {
+	req, _ := client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(bootstrapIgnitionKey),
+	})
+	return req.Presign(BootstrapIgnitionURLExpiry)
+}
@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootstrapIgnitionBucket(t *testing.T) {
+	assert.Equal(t, "openshift-bootstrap-data-0123-abcd", BootstrapIgnitionBucket("0123-abcd"))
+}
+
+func TestPresignBootstrapIgnitionURL(t *testing.T) {
+	ssn, err := session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("test-key-id", "test-secret-key", ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	presigned, err := PresignBootstrapIgnitionURL(s3.New(ssn), BootstrapIgnitionBucket("0123-abcd"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	u, err := url.Parse(presigned)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "https", u.Scheme)
+	assert.Equal(t, "openshift-bootstrap-data-0123-abcd.s3.amazonaws.com", u.Host)
+	assert.Equal(t, "/bootstrap.ign", u.Path)
+	query := u.Query()
+	assert.Equal(t, "3600", query.Get("X-Amz-Expires"))
+	assert.Contains(t, query.Get("X-Amz-Credential"), "test-key-id/")
+	assert.NotEmpty(t, query.Get("X-Amz-Signature"))
+}
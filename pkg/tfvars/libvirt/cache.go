@@ -2,89 +2,284 @@ package libvirt
 
 import (
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
-	"github.com/gregjones/httpcache"
-	"github.com/gregjones/httpcache/diskcache"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
+
+	"github.com/openshift/installer/pkg/rhcos"
+)
+
+var (
+	// RefreshCache is whether cached images are fetched again, replacing
+	// the cached copies.
+	RefreshCache = false
+
+	// CacheSize is the size, in bytes, above which the least recently used
+	// images are evicted from the cache.
+	CacheSize int64 = 20 << 30
 )
 
-// UseCachedImage leaves non-file:// image URIs unalterered.
+// UseCachedImage leaves file:// image URIs, and absolute paths, which it
+// converts to file:// URIs, unaltered.
 // Other URIs are retrieved with a local cache at
-// $XDG_CACHE_HOME/openshift-install/libvirt [1].  This allows you to
-// use the same remote image URI multiple times without needing to
-// worry about redundant downloads, although you will want to
-// periodically blow away your cache.
+// $XDG_CACHE_HOME/openshift-install/libvirt [1], which defaults to
+// ~/.cache/openshift-install/libvirt.  This allows you to use the same
+// remote image URI multiple times, from any asset directory, without
+// needing to worry about redundant downloads.  Once the cached images
+// exceed CacheSize, the least recently used ones are evicted.  Images
+// without a checksum are keyed by their ETag, for which the image is
+// requested again, and its download abandoned once the headers show it is
+// cached.
+//
+// The image may have a sha256 query parameter with the SHA-256 checksum of
+// the file, such as http://mirror.example.com/rhcos.qcow2?sha256=<hex>,
+// which is verified before the image is used. It may also have a signature
+// query parameter with the URL or absolute path of the detached OpenPGP
+// signature of the file, which is verified against the RHCOS signing keys.
+// The cache of images with a checksum is
+// keyed by their checksum, so that they are not fetched again once cached,
+// and so that servers which send no ETag, such as the plain HTTP servers of
+// disconnected labs, can serve them.
 //
 // [1]: https://standards.freedesktop.org/basedir-spec/basedir-spec-0.7.html
 func (libvirt *Libvirt) UseCachedImage() (err error) {
-	if strings.HasPrefix(libvirt.Image, "file://") {
+	image, checksum, signature, err := splitVerification(libvirt.Image)
+	if err != nil {
+		return err
+	}
+	if filepath.IsAbs(image) {
+		image = fmt.Sprintf("file://%s", filepath.ToSlash(image))
+	}
+	if strings.HasPrefix(image, "file://") {
+		path := strings.TrimPrefix(image, "file://")
+		if checksum != "" {
+			if err := verifyChecksum(path, checksum); err != nil {
+				return err
+			}
+		}
+		if signature != "" {
+			if err := verifySignature(path, signature); err != nil {
+				return err
+			}
+		}
+		libvirt.Image = image
 		return nil
 	}
 
-	logrus.Infof("Fetching OS image: %s", filepath.Base(libvirt.Image))
+	cacheDir := filepath.Join(baseCacheDir(), "openshift-install", "libvirt")
 
-	// FIXME: Use os.UserCacheDir() once we bump to Go 1.11
-	// baseCacheDir, err := os.UserCacheDir()
-	// if err != nil {
-	// 	return err
-	// }
-	baseCacheDir := filepath.Join(os.Getenv("HOME"), ".cache")
+	// Older installers also kept a copy of every image in an HTTP cache,
+	// outside of the bound of CacheSize.
+	if err := os.RemoveAll(filepath.Join(cacheDir, "http")); err != nil {
+		logrus.Warnf("Failed to remove the HTTP cache of OS images: %v", err)
+	}
 
-	cacheDir := filepath.Join(baseCacheDir, "openshift-install", "libvirt")
-	httpCacheDir := filepath.Join(cacheDir, "http")
-	err = os.MkdirAll(httpCacheDir, 0777)
+	imageCacheDir := filepath.Join(cacheDir, "image")
+	err = os.MkdirAll(imageCacheDir, 0777)
 	if err != nil {
 		return err
 	}
 
-	cache := diskcache.New(httpCacheDir)
-	transport := httpcache.NewTransport(cache)
-	resp, err := transport.Client().Get(libvirt.Image)
+	if checksum != "" && !RefreshCache {
+		imagePath := filepath.Join(imageCacheDir, checksum)
+		if _, err := os.Stat(imagePath); err == nil {
+			logrus.Debugf("Using cached OS image %q", imagePath)
+			libvirt.Image = fmt.Sprintf("file://%s", filepath.ToSlash(imagePath))
+			return touch(imagePath)
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	logrus.Infof("Fetching OS image: %s", filepath.Base(image))
+
+	resp, err := http.Get(image)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("%s while getting %s", resp.Status, libvirt.Image)
+		return fmt.Errorf("%s while getting %s", resp.Status, image)
 	}
 	defer resp.Body.Close()
 
-	key, err := cacheKey(resp.Header.Get("ETag"))
-	if err != nil {
-		return fmt.Errorf("invalid ETag for %s: %v", libvirt.Image, err)
-	}
-
-	imageCacheDir := filepath.Join(cacheDir, "image")
-	err = os.MkdirAll(imageCacheDir, 0777)
-	if err != nil {
-		return err
+	key := checksum
+	if key == "" {
+		key, err = cacheKey(resp.Header.Get("ETag"))
+		if err != nil {
+			return fmt.Errorf("invalid ETag for %s (add a sha256 parameter with the checksum of the image to cache it by its checksum instead): %v", image, err)
+		}
 	}
 
 	imagePath := filepath.Join(imageCacheDir, key)
+	if RefreshCache {
+		if err := os.Remove(imagePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
 	_, err = os.Stat(imagePath)
 	if err == nil {
 		logrus.Debugf("Using cached OS image %q", imagePath)
+		if err := touch(imagePath); err != nil {
+			return err
+		}
 	} else {
 		if !os.IsNotExist(err) {
 			return err
 		}
 
-		err = cacheImage(resp.Body, imagePath)
+		err = cacheImage(resp.Body, imagePath, checksum, signature)
 		if err != nil {
 			return err
 		}
+		if err := evict(imageCacheDir, imagePath); err != nil {
+			logrus.Warnf("Failed to evict OS images from the cache: %v", err)
+		}
 	}
 
 	libvirt.Image = fmt.Sprintf("file://%s", filepath.ToSlash(imagePath))
 	return nil
 }
 
+// baseCacheDir returns $XDG_CACHE_HOME, or ~/.cache if it is unset.
+//
+// FIXME: Use os.UserCacheDir() once we bump to Go 1.11
+func baseCacheDir() string {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.Getenv("HOME"), ".cache")
+}
+
+// touch marks the cached image as used now, so that it is evicted after
+// the images which were used less recently.
+func touch(imagePath string) error {
+	now := time.Now()
+	return os.Chtimes(imagePath, now, now)
+}
+
+// evict removes the least recently used images from the image cache
+// directory until their total size is at most CacheSize. The image which
+// was just cached is kept, even if it exceeds CacheSize by itself.
+func evict(imageCacheDir string, keep string) error {
+	files, err := ioutil.ReadDir(imageCacheDir)
+	if err != nil {
+		return err
+	}
+
+	var images []os.FileInfo
+	var size int64
+	for _, file := range files {
+		if !file.Mode().IsRegular() || filepath.Ext(file.Name()) != "" || filepath.Join(imageCacheDir, file.Name()) == keep {
+			continue
+		}
+		images = append(images, file)
+		size += file.Size()
+	}
+	if info, err := os.Stat(keep); err == nil {
+		size += info.Size()
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].ModTime().Before(images[j].ModTime())
+	})
+	for _, image := range images {
+		if size <= CacheSize {
+			break
+		}
+		path := filepath.Join(imageCacheDir, image.Name())
+		logrus.Debugf("Evicting OS image %q from the cache", path)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		size -= image.Size()
+	}
+	return nil
+}
+
+// splitVerification splits the sha256 and signature query parameters, if
+// any, from the image.
+func splitVerification(image string) (string, string, string, error) {
+	u, err := url.Parse(image)
+	if err != nil {
+		return "", "", "", err
+	}
+	query := u.Query()
+	checksum := strings.ToLower(query.Get("sha256"))
+	signature := query.Get("signature")
+	if checksum == "" && signature == "" {
+		return image, "", "", nil
+	}
+	query.Del("sha256")
+	query.Del("signature")
+	u.RawQuery = query.Encode()
+	return u.String(), checksum, signature, nil
+}
+
+// verifyChecksum checks that the SHA-256 checksum of the file is the
+// expected one.
+func verifyChecksum(path string, checksum string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != checksum {
+		return fmt.Errorf("the SHA-256 checksum of %s is %s, not %s", path, actual, checksum)
+	}
+	return nil
+}
+
+// verifySignature checks the file against its detached OpenPGP signature,
+// at a URL or an absolute path.
+func verifySignature(path string, signature string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var sig io.ReadCloser
+	if filepath.IsAbs(signature) || strings.HasPrefix(signature, "file://") {
+		sig, err = os.Open(strings.TrimPrefix(signature, "file://"))
+	} else {
+		var resp *http.Response
+		resp, err = http.Get(signature)
+		if err == nil && resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			err = fmt.Errorf("%s while getting %s", resp.Status, signature)
+		}
+		if err == nil {
+			sig = resp.Body
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch the signature of the OS image: %v", err)
+	}
+	defer sig.Close()
+
+	if err := rhcos.VerifySignature(file, sig); err != nil {
+		return fmt.Errorf("the OS image %s failed verification against the signature %s: %v", path, signature, err)
+	}
+	return nil
+}
+
 func cacheKey(etag string) (key string, err error) {
 	if etag == "" {
 		return "", fmt.Errorf("caching is not supported when ETag is unset")
@@ -104,7 +299,7 @@ func cacheKey(etag string) (key string, err error) {
 	return hex.EncodeToString(hashed[:]), nil
 }
 
-func cacheImage(reader io.Reader, imagePath string) (err error) {
+func cacheImage(reader io.Reader, imagePath string, checksum string, signature string) (err error) {
 	logrus.Debugf("Unpacking OS image into %q...", imagePath)
 
 	flockPath := fmt.Sprintf("%s.lock", imagePath)
@@ -148,7 +343,8 @@ func cacheImage(reader io.Reader, imagePath string) (err error) {
 		}
 	}()
 
-	_, err = io.Copy(file, reader)
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), reader)
 	if err != nil {
 		return err
 	}
@@ -159,5 +355,16 @@ func cacheImage(reader io.Reader, imagePath string) (err error) {
 	}
 	closed = true
 
+	if actual := hex.EncodeToString(hash.Sum(nil)); checksum != "" && actual != checksum {
+		os.Remove(tempPath)
+		return fmt.Errorf("the SHA-256 checksum of the OS image is %s, not %s", actual, checksum)
+	}
+	if signature != "" {
+		if err := verifySignature(tempPath, signature); err != nil {
+			os.Remove(tempPath)
+			return err
+		}
+	}
+
 	return os.Rename(tempPath, imagePath)
 }
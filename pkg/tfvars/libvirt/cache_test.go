@@ -0,0 +1,236 @@
+package libvirt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+
+	"github.com/openshift/installer/data"
+)
+
+func TestUseCachedImage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestUseCachedImage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(home string) { os.Setenv("HOME", home) }(os.Getenv("HOME"))
+	os.Setenv("HOME", dir)
+	defer func(cache string) { os.Setenv("XDG_CACHE_HOME", cache) }(os.Getenv("XDG_CACHE_HOME"))
+	os.Unsetenv("XDG_CACHE_HOME")
+
+	content := []byte("rhcos")
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+	wrongChecksum := hex.EncodeToString(make([]byte, sha256.Size))
+
+	path := filepath.Join(dir, "rhcos-qemu.qcow2")
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The RHCOS signing keys, and detached signatures of the image by a
+	// signing key and by another key.
+	signer, err := openpgp.NewEntity("RHCOS", "", "rhcos@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := openpgp.NewEntity("Other", "", "other@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := &bytes.Buffer{}
+	writer, err := armor.Encode(keys, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signer.Serialize(writer); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+	if err := ioutil.WriteFile(filepath.Join(dir, "rhcos-signing-keys.asc"), keys.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(assets http.FileSystem) { data.Assets = assets }(data.Assets)
+	data.Assets = http.Dir(dir)
+
+	signaturePath := filepath.Join(dir, "rhcos-qemu.qcow2.sig")
+	otherSignaturePath := filepath.Join(dir, "other.sig")
+	for path, entity := range map[string]*openpgp.Entity{signaturePath: signer, otherSignaturePath: other} {
+		signature := &bytes.Buffer{}
+		if err := openpgp.DetachSign(signature, entity, bytes.NewReader(content), nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, signature.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A plain file server, which sends no ETag.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	cachedPath := filepath.Join(dir, ".cache", "openshift-install", "libvirt", "image", checksum)
+
+	cases := []struct {
+		name          string
+		image         string
+		expectedImage string
+		expectedError string
+	}{
+		{
+			name:          "file URI",
+			image:         "file://" + path,
+			expectedImage: "file://" + path,
+		},
+		{
+			name:          "path",
+			image:         path,
+			expectedImage: "file://" + path,
+		},
+		{
+			name:          "path with checksum",
+			image:         path + "?sha256=" + checksum,
+			expectedImage: "file://" + path,
+		},
+		{
+			name:          "path with wrong checksum",
+			image:         path + "?sha256=" + wrongChecksum,
+			expectedError: "the SHA-256 checksum of " + path + " is " + checksum + ", not " + wrongChecksum,
+		},
+		{
+			name:          "path with signature",
+			image:         path + "?signature=" + signaturePath,
+			expectedImage: "file://" + path,
+		},
+		{
+			name:          "path with signature of another key",
+			image:         path + "?signature=" + otherSignaturePath,
+			expectedError: "the OS image " + path + " failed verification against the signature " + otherSignaturePath + ": invalid signature: openpgp: signature made by unknown entity",
+		},
+		{
+			name:          "URL without ETag or checksum",
+			image:         server.URL + "/rhcos-qemu.qcow2",
+			expectedError: "invalid ETag for " + server.URL + "/rhcos-qemu.qcow2 (add a sha256 parameter with the checksum of the image to cache it by its checksum instead): caching is not supported when ETag is unset",
+		},
+		{
+			name:          "URL with wrong checksum",
+			image:         server.URL + "/rhcos-qemu.qcow2?sha256=" + wrongChecksum,
+			expectedError: "the SHA-256 checksum of the OS image is " + checksum + ", not " + wrongChecksum,
+		},
+		{
+			name:          "URL with checksum and signature of another key",
+			image:         server.URL + "/rhcos-qemu.qcow2?sha256=" + checksum + "&signature=" + otherSignaturePath,
+			expectedError: "the OS image " + cachedPath + ".tmp failed verification against the signature " + otherSignaturePath + ": invalid signature: openpgp: signature made by unknown entity",
+		},
+		{
+			name:          "URL with checksum",
+			image:         server.URL + "/rhcos-qemu.qcow2?sha256=" + checksum,
+			expectedImage: "file://" + cachedPath,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			libvirt := &Libvirt{Image: tc.image}
+			err := libvirt.UseCachedImage()
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedImage, libvirt.Image)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+
+	// Once cached, the image is not fetched again, unless the cache is
+	// refreshed.
+	server.Close()
+	libvirt := &Libvirt{Image: server.URL + "/rhcos-qemu.qcow2?sha256=" + checksum}
+	assert.NoError(t, libvirt.UseCachedImage())
+	assert.Equal(t, "file://"+cachedPath, libvirt.Image)
+
+	defer func() { RefreshCache = false }()
+	RefreshCache = true
+	libvirt = &Libvirt{Image: server.URL + "/rhcos-qemu.qcow2?sha256=" + checksum}
+	assert.Error(t, libvirt.UseCachedImage())
+	RefreshCache = false
+
+	// The cache is under $XDG_CACHE_HOME, if it is set.
+	os.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "xdg"))
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer server.Close()
+	libvirt = &Libvirt{Image: server.URL + "/rhcos-qemu.qcow2?sha256=" + checksum}
+	assert.NoError(t, libvirt.UseCachedImage())
+	assert.Equal(t, "file://"+filepath.Join(dir, "xdg", "openshift-install", "libvirt", "image", checksum), libvirt.Image)
+
+	// Images with an ETag are only kept in the image cache, which removes
+	// the HTTP cache of older installers.
+	httpCacheDir := filepath.Join(dir, "xdg", "openshift-install", "libvirt", "http")
+	if err := os.MkdirAll(httpCacheDir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	etagServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"test-etag"`)
+		w.Write(content)
+	}))
+	defer etagServer.Close()
+	key, err := cacheKey(`"test-etag"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	libvirt = &Libvirt{Image: etagServer.URL + "/rhcos-qemu.qcow2"}
+	assert.NoError(t, libvirt.UseCachedImage())
+	assert.Equal(t, "file://"+filepath.Join(dir, "xdg", "openshift-install", "libvirt", "image", key), libvirt.Image)
+	_, err = os.Stat(httpCacheDir)
+	assert.True(t, os.IsNotExist(err), "the HTTP cache was not removed")
+}
+
+func TestEvict(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestEvict")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Images a to d, from the least to the most recently used, of 10
+	// bytes each, and the lock of another image being cached.
+	now := time.Now()
+	for i, name := range []string{"a", "b", "c", "d", "e.lock"} {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, make([]byte, 10), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := now.Add(time.Duration(i-5) * time.Hour)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer func(size int64) { CacheSize = size }(CacheSize)
+	CacheSize = 25
+
+	// a was just cached, so it is kept although it is the oldest.
+	assert.NoError(t, evict(dir, filepath.Join(dir, "a")))
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	assert.Equal(t, []string{"a", "d", "e.lock"}, names)
+}
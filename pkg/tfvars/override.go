@@ -0,0 +1,69 @@
+package tfvars
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// overridableVariables are the Terraform variables which users may override,
+// which tune the providers without changing the identity, topology or
+// Ignition configs of the cluster, on which the other assets depend. The
+// variables of the masters which their Machine manifests also record, such
+// as their instance type, image, root volume and tags, are not overridable,
+// so that the masters match their manifests.
+var overridableVariables = map[string]struct{}{
+	"openstack_extra_tags":          {},
+	"openstack_master_extra_sg_ids": {},
+}
+
+// Overridable returns true if users may override the Terraform variable,
+// including the OpenStack credentials besides the cloud, which clouds.yaml
+// provides by default.
+func Overridable(name string) bool {
+	if _, ok := overridableVariables[name]; ok {
+		return true
+	}
+	return strings.HasPrefix(name, "openstack_credentials_") && name != "openstack_credentials_cloud"
+}
+
+// Override returns the Terraform variables with the overrides, read from
+// the named file, merged over them. Overrides of variables which are not
+// overridable are ignored with a warning.
+func Override(data []byte, overrides []byte, filename string) ([]byte, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(overrides, &values); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s", filename)
+	}
+	if len(values) == 0 {
+		return data, nil
+	}
+
+	var variables map[string]json.RawMessage
+	if err := json.Unmarshal(data, &variables); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the Terraform variables")
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !Overridable(name) {
+			logrus.Warnf("Ignoring %s from %s, which is not an overridable Terraform variable", name, filename)
+			continue
+		}
+		if _, ok := variables[name]; ok {
+			logrus.Warnf("Overriding the generated Terraform variable %s with the value from %s", name, filename)
+		} else {
+			logrus.Warnf("Setting the Terraform variable %s from %s", name, filename)
+		}
+		variables[name] = values[name]
+	}
+
+	return json.MarshalIndent(variables, "", "  ")
+}
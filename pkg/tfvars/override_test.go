@@ -0,0 +1,96 @@
+package tfvars
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverride(t *testing.T) {
+	cases := []struct {
+		name          string
+		overrides     string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:      "no overrides",
+			overrides: `{}`,
+			expected:  `{"cluster_id":"test-id","aws_extra_tags":{}}`,
+		},
+		{
+			name:      "overridable",
+			overrides: `{"openstack_extra_tags":{"team":"test"},"openstack_master_extra_sg_ids":["sg-id"],"openstack_credentials_user_name":"test-user"}`,
+			expected: `{
+  "aws_extra_tags": {},
+  "cluster_id": "test-id",
+  "openstack_credentials_user_name": "test-user",
+  "openstack_extra_tags": {
+    "team": "test"
+  },
+  "openstack_master_extra_sg_ids": [
+    "sg-id"
+  ]
+}`,
+		},
+		{
+			name:      "not overridable",
+			overrides: `{"cluster_id":"other-id","aws_master_ec2_type":"m5.large","aws_ec2_ami_override":"ami-0123456789","openstack_credentials_cloud":"other"}`,
+			expected: `{
+  "aws_extra_tags": {},
+  "cluster_id": "test-id"
+}`,
+		},
+		{
+			name:      "recorded by the master machines",
+			overrides: `{"aws_extra_tags":{"team":"test"},"aws_master_root_volume_iops":100,"aws_master_root_volume_size":200,"aws_master_root_volume_type":"io1","libvirt_master_memory":"8192","libvirt_master_vcpu":"4","openstack_base_image":"other-image","openstack_master_flavor_name":"m1.xlarge","openstack_trunk_support":"1"}`,
+			expected: `{
+  "aws_extra_tags": {},
+  "cluster_id": "test-id"
+}`,
+		},
+		{
+			name:          "invalid overrides",
+			overrides:     `[]`,
+			expectedError: "failed to unmarshal terraform.tfvars.override.json: json: cannot unmarshal array",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := Override([]byte(`{"cluster_id":"test-id","aws_extra_tags":{}}`), []byte(tc.overrides), "terraform.tfvars.override.json")
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, string(data))
+			}
+		})
+	}
+}
+
+func TestOverridable(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected bool
+	}{
+		{name: "openstack_extra_tags", expected: true},
+		{name: "openstack_master_extra_sg_ids", expected: true},
+		{name: "openstack_credentials_password", expected: true},
+		{name: "openstack_credentials_cloud"},
+		{name: "openstack_master_flavor_name"},
+		{name: "openstack_base_image"},
+		{name: "openstack_trunk_support"},
+		{name: "aws_extra_tags"},
+		{name: "aws_master_root_volume_size"},
+		{name: "libvirt_master_memory"},
+		{name: "cluster_id"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Overridable(tc.name))
+		})
+	}
+}
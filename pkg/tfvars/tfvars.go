@@ -13,6 +13,7 @@ import (
 
 type config struct {
 	ClusterID   string `json:"cluster_id,omitempty"`
+	InfraID     string `json:"infra_id,omitempty"`
 	Name        string `json:"cluster_name,omitempty"`
 	BaseDomain  string `json:"base_domain,omitempty"`
 	MachineCIDR string `json:"machine_cidr"`
@@ -31,6 +32,7 @@ type config struct {
 func TFVars(clusterID string, cfg *types.InstallConfig, osImage, bootstrapIgn, masterIgn string) ([]byte, error) {
 	config := &config{
 		ClusterID:   clusterID,
+		InfraID:     types.InfraID(cfg.ObjectMeta.Name, clusterID),
 		Name:        cfg.ObjectMeta.Name,
 		BaseDomain:  cfg.BaseDomain,
 		MachineCIDR: cfg.Networking.MachineCIDR.String(),
@@ -67,6 +69,9 @@ func TFVars(clusterID string, cfg *types.InstallConfig, osImage, bootstrapIgn, m
 					IAMRoleName: m.Platform.AWS.IAMRoleName,
 				}
 			}
+		case "infra":
+			// The infra machines are created by the machine API, with
+			// the worker instance profile.
 		default:
 			return nil, errors.Errorf("unrecognized machine pool %q", m.Name)
 		}
@@ -74,8 +79,11 @@ func TFVars(clusterID string, cfg *types.InstallConfig, osImage, bootstrapIgn, m
 
 	if cfg.Platform.AWS != nil {
 		config.AWS.Region = cfg.Platform.AWS.Region
+		config.AWS.BootstrapIgnitionBucket = aws.BootstrapIgnitionBucket(clusterID)
 		config.AWS.ExtraTags = cfg.Platform.AWS.UserTags
-		config.AWS.EC2AMIOverride = osImage
+		if err := config.AWS.UseAMI(osImage); err != nil {
+			return nil, errors.Wrap(err, "failed to use the AMI")
+		}
 	} else if cfg.Platform.Libvirt != nil {
 		masterIPs := make([]string, len(cfg.Platform.Libvirt.MasterIPs))
 		for i, ip := range cfg.Platform.Libvirt.MasterIPs {
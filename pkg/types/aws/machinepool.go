@@ -16,8 +16,35 @@ type MachinePool struct {
 
 	// EC2RootVolume defines the storage for ec2 instance.
 	EC2RootVolume `json:"rootVolume"`
+
+	// Subnet is the type of the subnets of the zones in which the machines
+	// are placed. The workers default to the private subnets. Subnet IDs
+	// are not supported, because the installer creates the VPC.
+	// +optional
+	Subnet SubnetType `json:"subnet,omitempty"`
+
+	// OSImage is the AMI which the machines of the pool boot, such as a
+	// derivative of RHCOS with extra drivers, instead of the osImage of
+	// the platform. It is only supported for the compute pools, whose
+	// machine sets boot it.
+	// +optional
+	OSImage string `json:"osImage,omitempty"`
 }
 
+// SubnetType is the type of the subnets which the installer creates in each
+// availability zone.
+type SubnetType string
+
+const (
+	// PublicSubnet is the subnet of a zone which is routed through the
+	// internet gateway of the VPC, in which the masters are placed.
+	PublicSubnet SubnetType = "public"
+
+	// PrivateSubnet is the subnet of a zone which is routed through the
+	// NAT gateway of the zone.
+	PrivateSubnet SubnetType = "private"
+)
+
 // Set sets the values from `required` to `a`.
 func (a *MachinePool) Set(required *MachinePool) {
 	if required == nil || a == nil {
@@ -44,6 +71,13 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if required.EC2RootVolume.Type != "" {
 		a.EC2RootVolume.Type = required.EC2RootVolume.Type
 	}
+
+	if required.Subnet != "" {
+		a.Subnet = required.Subnet
+	}
+	if required.OSImage != "" {
+		a.OSImage = required.OSImage
+	}
 }
 
 // EC2RootVolume defines the storage for an ec2 instance.
@@ -4,6 +4,10 @@ package aws
 type Metadata struct {
 	Region string `json:"region"`
 
+	// BaseDomain is the base domain of the cluster, in whose public hosted
+	// zone the records of the cluster are created.
+	BaseDomain string `json:"baseDomain,omitempty"`
+
 	// Identifier holds a slice of filter maps.  The maps hold the
 	// key/value pairs for the tags we will be matching against.  A
 	// resource matches the map if all of the key/value pairs are in its
@@ -10,6 +10,12 @@ type Platform struct {
 	// +optional
 	UserTags map[string]string `json:"userTags,omitempty"`
 
+	// OSImage is the AMI of the RHCOS build which the machines boot,
+	// such as ami-0123456789abcdef0, instead of the AMI of the installer's
+	// RHCOS build in the region.
+	// +optional
+	OSImage string `json:"osImage,omitempty"`
+
 	// DefaultMachinePlatform is the default configuration used when
 	// installing on AWS for machine pools which do not define their own
 	// platform configuration.
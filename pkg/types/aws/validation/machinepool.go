@@ -1,11 +1,15 @@
 package validation
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types/aws"
 )
 
+var validSubnetValues = []string{string(aws.PrivateSubnet), string(aws.PublicSubnet)}
+
 // ValidateMachinePool checks that the specified machine pool is valid.
 func ValidateMachinePool(p *aws.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -15,5 +19,24 @@ func ValidateMachinePool(p *aws.MachinePool, fldPath *field.Path) field.ErrorLis
 	if p.Size < 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), p.IOPS, "Storage size must be positive"))
 	}
+	zones := map[string]bool{}
+	for i, zone := range p.Zones {
+		if zones[zone] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("zones").Index(i), zone))
+		}
+		zones[zone] = true
+	}
+	if p.OSImage != "" && !amiRegexp.MatchString(p.OSImage) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("osImage"), p.OSImage, "must be an AMI ID, such as ami-0123456789abcdef0"))
+	}
+	switch p.Subnet {
+	case "", aws.PublicSubnet, aws.PrivateSubnet:
+	default:
+		if strings.HasPrefix(string(p.Subnet), "subnet-") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet"), p.Subnet, "subnet IDs are not supported, because the installer creates the VPC of the cluster and its subnets; use public or private"))
+			break
+		}
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("subnet"), p.Subnet, validSubnetValues))
+	}
 	return allErrs
 }
@@ -56,6 +56,48 @@ func TestValidateMachinePool(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "duplicate zones",
+			pool: &aws.MachinePool{
+				Zones: []string{"us-east-1a", "us-east-1b", "us-east-1a"},
+			},
+			valid: false,
+		},
+		{
+			name: "private subnet",
+			pool: &aws.MachinePool{
+				Subnet: aws.PrivateSubnet,
+			},
+			valid: true,
+		},
+		{
+			name: "invalid subnet",
+			pool: &aws.MachinePool{
+				Subnet: "intranet",
+			},
+			valid: false,
+		},
+		{
+			name: "subnet ID",
+			pool: &aws.MachinePool{
+				Subnet: "subnet-0123456789abcdef0",
+			},
+			valid: false,
+		},
+		{
+			name: "OS image",
+			pool: &aws.MachinePool{
+				OSImage: "ami-0123456789abcdef0",
+			},
+			valid: true,
+		},
+		{
+			name: "invalid OS image",
+			pool: &aws.MachinePool{
+				OSImage: "rhcos",
+			},
+			valid: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"regexp"
 	"sort"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -33,6 +34,8 @@ var (
 		"us-west-2":      "Oregon",
 	}
 
+	amiRegexp = regexp.MustCompile(`^ami-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
 	validRegionValues = func() []string {
 		validValues := make([]string, len(Regions))
 		i := 0
@@ -51,8 +54,17 @@ func ValidatePlatform(p *aws.Platform, fldPath *field.Path) field.ErrorList {
 	if _, ok := Regions[p.Region]; !ok {
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("region"), p.Region, validRegionValues))
 	}
+	if p.OSImage != "" && !amiRegexp.MatchString(p.OSImage) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("osImage"), p.OSImage, "must be an AMI ID, such as ami-0123456789abcdef0"))
+	}
 	if p.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
+		if p.DefaultMachinePlatform.Subnet != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("defaultMachinePlatform", "subnet"), p.DefaultMachinePlatform.Subnet, "the subnet must be set for each machine pool, because the masters are always placed in the public subnets"))
+		}
+		if p.DefaultMachinePlatform.OSImage != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("defaultMachinePlatform", "osImage"), "set the osImage of the platform for all the machines instead"))
+		}
 	}
 	return allErrs
 }
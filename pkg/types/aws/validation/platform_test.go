@@ -29,6 +29,22 @@ func TestValidatePlatform(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid OS image",
+			platform: &aws.Platform{
+				Region:  "us-east-1",
+				OSImage: "ami-0123456789abcdef0",
+			},
+			valid: true,
+		},
+		{
+			name: "invalid OS image",
+			platform: &aws.Platform{
+				Region:  "us-east-1",
+				OSImage: "rhcos-410.8",
+			},
+			valid: false,
+		},
 		{
 			name: "valid machine pool",
 			platform: &aws.Platform{
@@ -49,6 +65,16 @@ func TestValidatePlatform(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "default subnet",
+			platform: &aws.Platform{
+				Region: "us-east-1",
+				DefaultMachinePlatform: &aws.MachinePool{
+					Subnet: aws.PrivateSubnet,
+				},
+			},
+			valid: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1,33 +1,143 @@
 package types
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	"github.com/openshift/installer/pkg/types/openstack"
 )
 
+// ClusterMetadataVersion is the version of the layout of ClusterMetadata
+// supported by this installer. Fields may be added to a version, but not
+// changed or removed.
+const ClusterMetadataVersion = "v1"
+
 // ClusterMetadata contains information
 // regarding the cluster that was created by installer.
 type ClusterMetadata struct {
-	ClusterName             string `json:"clusterName"`
-	ClusterID               string `json:"clusterID"`
+	// APIVersion is the version of the layout of the metadata. Metadata
+	// written by earlier installers has none, and the layout of
+	// ClusterMetadataVersion.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	ClusterName string `json:"clusterName"`
+	ClusterID   string `json:"clusterID"`
+
+	// InfraID is the prefix of the names of the cluster's resources whose
+	// length the platform limits, as returned by InfraID.
+	InfraID string `json:"infraID,omitempty"`
+
+	// OSImage is the RHCOS image which the machines were created from,
+	// such as an AMI, with which the cluster can be reproduced.
+	OSImage string `json:"osImage,omitempty"`
+
 	ClusterPlatformMetadata `json:",inline"`
+
+	// PartialDestroys are the destroys which deleted only some types of the
+	// resources of the cluster, which is then partially destroyed.
+	PartialDestroys []PartialDestroy `json:"partialDestroys,omitempty"`
+
+	// Timeline are the milestones of the creation of the cluster, in the
+	// order in which they were reached. A resumed creation appends its
+	// milestones to those of the failed attempts.
+	Timeline []Milestone `json:"timeline,omitempty"`
+
+	// Extensions hold the data of tools other than the installer, keyed by
+	// the name of the tool, such as a domain name. The installer does not
+	// read them, and preserves them when it updates the metadata.
+	Extensions map[string]json.RawMessage `json:"extensions,omitempty"`
+}
+
+// MergeRecords adds the records of the previous metadata of the cluster,
+// which the metadata generated for a resumed creation does not have: its
+// timeline, partial destroys and extensions. Extensions of the metadata
+// take precedence over the previous ones of the same name.
+func (m *ClusterMetadata) MergeRecords(previous *ClusterMetadata) {
+	if len(previous.Timeline) > 0 {
+		m.Timeline = append(append([]Milestone{}, previous.Timeline...), m.Timeline...)
+	}
+	if len(previous.PartialDestroys) > 0 {
+		m.PartialDestroys = append(append([]PartialDestroy{}, previous.PartialDestroys...), m.PartialDestroys...)
+	}
+	if len(previous.Extensions) > 0 {
+		extensions := make(map[string]json.RawMessage, len(previous.Extensions)+len(m.Extensions))
+		for name, data := range previous.Extensions {
+			extensions[name] = data
+		}
+		for name, data := range m.Extensions {
+			extensions[name] = data
+		}
+		m.Extensions = extensions
+	}
+}
+
+const (
+	// MilestoneAssetsGenerated is reached when the assets which the
+	// cluster is created from have been generated.
+	MilestoneAssetsGenerated = "assetsGenerated"
+
+	// MilestoneTerraformStarted is reached when Terraform starts creating
+	// the cluster's resources.
+	MilestoneTerraformStarted = "terraformStarted"
+
+	// MilestoneTerraformFinished is reached when Terraform is done, even
+	// if it failed.
+	MilestoneTerraformFinished = "terraformFinished"
+
+	// MilestoneBootstrapComplete is reached when the bootstrap machine has
+	// handed the control plane over to the masters.
+	MilestoneBootstrapComplete = "bootstrapComplete"
+
+	// MilestoneInstallComplete is reached when the cluster version
+	// reports the install complete.
+	MilestoneInstallComplete = "installComplete"
+)
+
+// Milestone is a step of the creation of a cluster, and when it was
+// reached.
+type Milestone struct {
+	Name string    `json:"name"`
+	Time time.Time `json:"time"`
+}
+
+// PartialDestroy is a destroy of only some types of the resources of a
+// cluster.
+type PartialDestroy struct {
+	Time time.Time `json:"time"`
+
+	// ResourceTypes are the types which were destroyed, or empty for all
+	// but the excluded ones.
+	ResourceTypes []string `json:"resourceTypes,omitempty"`
+
+	// ExcludedResourceTypes are the types which were kept.
+	ExcludedResourceTypes []string `json:"excludedResourceTypes,omitempty"`
 }
 
 // ClusterPlatformMetadata contains metadata for platfrom.
 type ClusterPlatformMetadata struct {
+	// PlatformName is the name of the platform, whose section holds the
+	// metadata of the platform. Metadata written by earlier installers has
+	// none, and the platform of the section which is set.
+	PlatformName string `json:"platform,omitempty"`
+
 	AWS       *aws.Metadata       `json:"aws,omitempty"`
 	OpenStack *openstack.Metadata `json:"openstack,omitempty"`
 	Libvirt   *libvirt.Metadata   `json:"libvirt,omitempty"`
 }
 
-// Platform returns a string representation of the platform
-// (e.g. "aws" if AWS is non-nil).  It returns an empty string if no
-// platform is configured.
+// Platform returns a string representation of the platform: PlatformName
+// if it is set, or else the platform of the section which is set (e.g.
+// "aws" if AWS is non-nil).  It returns an empty string if no platform is
+// configured.
 func (cpm *ClusterPlatformMetadata) Platform() string {
 	if cpm == nil {
 		return ""
 	}
+	if cpm.PlatformName != "" {
+		return cpm.PlatformName
+	}
 	if cpm.AWS != nil {
 		return "aws"
 	}
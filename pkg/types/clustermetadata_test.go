@@ -0,0 +1,78 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterMetadataExtensions(t *testing.T) {
+	data := []byte(`{"apiVersion":"v1","clusterName":"test-cluster","clusterID":"id","platform":"aws","aws":{"region":"us-east-1","identifier":null},"extensions":{"example.com":{"owner":"team","nested":[1,2]}}}`)
+
+	metadata := &ClusterMetadata{}
+	if err := json.Unmarshal(data, metadata); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "aws", metadata.Platform())
+
+	out, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.JSONEq(t, string(data), string(out))
+}
+
+func TestClusterMetadataPlatform(t *testing.T) {
+	metadata := &ClusterMetadata{}
+	if err := json.Unmarshal([]byte(`{"clusterName":"test-cluster","libvirt":{"uri":"qemu:///system"}}`), metadata); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "libvirt", metadata.Platform())
+}
+
+// TestClusterMetadataMergeRecords tests that the metadata generated for a
+// resumed creation keeps the records of the failed attempt.
+func TestClusterMetadataMergeRecords(t *testing.T) {
+	first := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	previous := &ClusterMetadata{
+		ClusterName: "test-cluster",
+		ClusterID:   "id",
+		Timeline: []Milestone{
+			{Name: MilestoneAssetsGenerated, Time: first},
+			{Name: MilestoneTerraformStarted, Time: first},
+		},
+		PartialDestroys: []PartialDestroy{{Time: first, ResourceTypes: []string{"instance"}}},
+		Extensions: map[string]json.RawMessage{
+			"example.com": json.RawMessage(`{"owner":"team"}`),
+			"example.org": json.RawMessage(`"old"`),
+		},
+	}
+	metadata := &ClusterMetadata{
+		ClusterName: "test-cluster",
+		ClusterID:   "id",
+		Timeline:    []Milestone{{Name: MilestoneAssetsGenerated, Time: second}},
+		Extensions:  map[string]json.RawMessage{"example.org": json.RawMessage(`"new"`)},
+	}
+
+	metadata.MergeRecords(previous)
+	assert.Equal(t, []Milestone{
+		{Name: MilestoneAssetsGenerated, Time: first},
+		{Name: MilestoneTerraformStarted, Time: first},
+		{Name: MilestoneAssetsGenerated, Time: second},
+	}, metadata.Timeline)
+	assert.Equal(t, previous.PartialDestroys, metadata.PartialDestroys)
+	assert.Equal(t, map[string]json.RawMessage{
+		"example.com": json.RawMessage(`{"owner":"team"}`),
+		"example.org": json.RawMessage(`"new"`),
+	}, metadata.Extensions)
+	assert.Len(t, previous.Timeline, 2, "the previous metadata should not change")
+
+	empty := &ClusterMetadata{ClusterID: "id"}
+	empty.MergeRecords(&ClusterMetadata{ClusterID: "id"})
+	assert.Nil(t, empty.Timeline)
+	assert.Nil(t, empty.PartialDestroys)
+	assert.Nil(t, empty.Extensions)
+}
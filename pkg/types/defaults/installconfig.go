@@ -43,13 +43,13 @@ func SetInstallConfigDefaults(c *types.InstallConfig) {
 			},
 		}
 	}
+	numberOfMasters := int64(3)
+	numberOfWorkers := int64(3)
+	if c.Platform.Libvirt != nil {
+		numberOfMasters = 1
+		numberOfWorkers = 1
+	}
 	if len(c.Machines) == 0 {
-		numberOfMasters := int64(3)
-		numberOfWorkers := int64(3)
-		if c.Platform.Libvirt != nil {
-			numberOfMasters = 1
-			numberOfWorkers = 1
-		}
 		c.Machines = []types.MachinePool{
 			{
 				Name:     "master",
@@ -61,6 +61,11 @@ func SetInstallConfigDefaults(c *types.InstallConfig) {
 			},
 		}
 	}
+	for i := range c.Machines {
+		if c.Machines[i].Name == "infra" && c.Machines[i].Replicas == nil {
+			c.Machines[i].Replicas = func(x int64) *int64 { return &x }(numberOfWorkers)
+		}
+	}
 	switch {
 	case c.Platform.AWS != nil:
 		awsdefaults.SetPlatformDefaults(c.Platform.AWS)
@@ -196,6 +196,21 @@ func TestSetInstallConfigDefaults(t *testing.T) {
 				return c
 			}(),
 		},
+		{
+			name: "Infra pool present",
+			config: &types.InstallConfig{
+				Machines: []types.MachinePool{{Name: "master"}, {Name: "worker"}, {Name: "infra"}},
+			},
+			expected: func() *types.InstallConfig {
+				c := defaultInstallConfig()
+				c.Machines = []types.MachinePool{
+					{Name: "master"},
+					{Name: "worker"},
+					{Name: "infra", Replicas: func(x int64) *int64 { return &x }(3)},
+				}
+				return c
+			}(),
+		},
 		{
 			name: "AWS platform present",
 			config: &types.InstallConfig{
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// InfraIDMaxLength is the maximum length of an infra ID. It leaves
+	// room for the suffixes of the names of the AWS load balancers and
+	// target groups, such as "-aint", which are limited to 32 characters.
+	InfraIDMaxLength = 27
+
+	infraIDSuffixLength = 5
+)
+
+// InfraID returns the infra ID of the cluster, which prefixes the names of
+// the cluster's resources whose length the platform limits. It is the
+// cluster name, truncated to leave room for the suffix and without a
+// trailing dash, then a dash and the first 5 hexadecimal digits of the
+// SHA-256 digest of the cluster ID. It is unique to the cluster, and the
+// same for the same cluster name and ID, so it can be computed again from
+// metadata.json, which records it.
+func InfraID(clusterName, clusterID string) string {
+	prefix := clusterName
+	if max := InfraIDMaxLength - infraIDSuffixLength - 1; len(prefix) > max {
+		prefix = prefix[:max]
+	}
+	prefix = strings.TrimRight(prefix, "-.")
+
+	digest := sha256.Sum256([]byte(clusterID))
+	return prefix + "-" + hex.EncodeToString(digest[:])[:infraIDSuffixLength]
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfraID(t *testing.T) {
+	cases := []struct {
+		name        string
+		clusterName string
+		expected    string
+	}{
+		{
+			name:        "short",
+			clusterName: "test-cluster",
+			expected:    "test-cluster-",
+		},
+		{
+			name:        "truncated",
+			clusterName: "a-very-long-cluster-name-for-tests",
+			expected:    "a-very-long-cluster-n-",
+		},
+		{
+			name:        "truncated at a dash",
+			clusterName: "a-very-long-cluster--name",
+			expected:    "a-very-long-cluster-",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			id := InfraID(tc.clusterName, "c7f2e6a4-5f5e-4a7e-9d0c-3d1c9b6e1f2a")
+			assert.True(t, len(id) <= InfraIDMaxLength, "%q is longer than %d characters", id, InfraIDMaxLength)
+			assert.Equal(t, tc.expected, id[:len(id)-5])
+			assert.Regexp(t, "^[0-9a-f]{5}$", id[len(id)-5:])
+			assert.Equal(t, id, InfraID(tc.clusterName, "c7f2e6a4-5f5e-4a7e-9d0c-3d1c9b6e1f2a"))
+			assert.NotEqual(t, id, InfraID(tc.clusterName, "0b8e6c1e-2f0d-4c39-8b6a-52f1c4a0d8e7"))
+		})
+	}
+}
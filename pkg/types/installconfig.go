@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"net"
+
 	netopv1 "github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1"
 	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types/aws"
@@ -10,6 +13,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// InstallConfigVersion is the version supported by this package.
+	InstallConfigVersion = "v1beta1"
+
+	// DefaultMachineConfigServerPort is the port on which the machine
+	// config server serves the Ignition configs of the machines.
+	DefaultMachineConfigServerPort = 49500
+)
+
 var (
 	// PlatformNames is a slice with all the visibly-supported
 	// platform names in alphabetical order. This is the list of
@@ -33,7 +45,9 @@ type InstallConfig struct {
 
 	metav1.ObjectMeta `json:"metadata"`
 
-	// SSHKey is the public ssh key to provide access to instances.
+	// SSHKey is the public ssh key to provide access to instances. It may
+	// hold several ed25519, ECDSA or RSA keys, one per line as in an
+	// authorized_keys file.
 	// +optional
 	SSHKey string `json:"sshKey,omitempty"`
 
@@ -55,8 +69,114 @@ type InstallConfig struct {
 
 	// PullSecret is the secret to use when pulling images.
 	PullSecret string `json:"pullSecret"`
+
+	// APIServer is the configuration of the Kubernetes API server.
+	// +optional
+	APIServer *APIServer `json:"apiServer,omitempty"`
+
+	// Ingress is the configuration of the default ingress controller.
+	// +optional
+	Ingress *Ingress `json:"ingress,omitempty"`
+
+	// Kubeconfigs are the users, besides the admin, for whom a kubeconfig
+	// is generated, so that limited access to the cluster can be handed out
+	// without sharing the admin kubeconfig.
+	// +optional
+	Kubeconfigs []Kubeconfig `json:"kubeconfigs,omitempty"`
+
+	// Proxy is the HTTP proxy through which the machines reach the networks
+	// outside of the cluster, such as the registry of the release image.
+	// +optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+
+	// ImageContentSources are the mirrors from which the machines pull the
+	// images of repositories, such as those of the release payload, for
+	// example in disconnected networks.
+	// +optional
+	ImageContentSources []ImageContentSource `json:"imageContentSources,omitempty"`
+
+	// NTPServers are the NTP servers, or pools, with which the machines
+	// synchronize their clocks, instead of the default pools, for example
+	// on networks from which those are unreachable.
+	// +optional
+	NTPServers []string `json:"ntpServers,omitempty"`
+
+	// Hosts are machines which are provisioned with their own Ignition
+	// config, written to hosts/<name>.ign, for example to configure static
+	// IP addresses where there is no DHCP. They are only supported on the
+	// none platform.
+	// +optional
+	Hosts []Host `json:"hosts,omitempty"`
+
+	// BootstrapInPlace configures a single-node cluster to bootstrap on its
+	// only control-plane machine, instead of on a separate bootstrap
+	// machine. It is only supported on the none platform.
+	// +optional
+	BootstrapInPlace *BootstrapInPlace `json:"bootstrapInPlace,omitempty"`
+
+	// MachineConfigServer is the endpoint of the machine config server
+	// which the pointer Ignition configs of the machines reference, for
+	// example a load balancer which serves it on a different port.
+	// +optional
+	MachineConfigServer *MachineConfigServer `json:"machineConfigServer,omitempty"`
+
+	// ClusterVersionOverrides are the components of the release payload
+	// which the cluster-version operator leaves unmanaged, for example to
+	// run clusters without some operators in CI or constrained
+	// environments.
+	// +optional
+	ClusterVersionOverrides []ClusterVersionOverride `json:"clusterVersionOverrides,omitempty"`
+
+	// IdentityProviders are the identity providers with which users log in
+	// to the cluster, so that administrators do not depend on the
+	// temporary kubeadmin user.
+	// +optional
+	IdentityProviders []IdentityProvider `json:"identityProviders,omitempty"`
+
+	// Kubeadmin is whether the installer creates the temporary kubeadmin
+	// user and its password. When it is Disabled, for example because the
+	// identity providers are configured or static passwords are not
+	// allowed, the only access to a new cluster is the certificate of the
+	// admin kubeconfig.
+	// +optional
+	// Default is to create the kubeadmin user.
+	Kubeadmin KubeadminPolicy `json:"kubeadmin,omitempty"`
+
+	// ImageRegistry is the configuration of the internal image registry.
+	// +optional
+	// Default is to let the image registry operator choose the storage.
+	ImageRegistry *ImageRegistry `json:"imageRegistry,omitempty"`
+
+	// Scheduler is the configuration of the default scheduler.
+	// +optional
+	Scheduler *Scheduler `json:"scheduler,omitempty"`
+
+	// CredentialsMode is how the cluster gets its cloud credentials. In
+	// Manual mode, the installer does not store its own credentials in the
+	// cluster, and administrators create the credentials of each component
+	// themselves.
+	// +optional
+	// Default is to store the installer's credentials in the cluster.
+	CredentialsMode CredentialsMode `json:"credentialsMode,omitempty"`
 }
 
+// CredentialsMode is how the cluster gets its cloud credentials.
+type CredentialsMode string
+
+const (
+	// ManualCredentialsMode keeps the installer's credentials out of the
+	// cluster.
+	ManualCredentialsMode CredentialsMode = "Manual"
+)
+
+// KubeadminPolicy is whether the installer creates the kubeadmin user.
+type KubeadminPolicy string
+
+const (
+	// DisabledKubeadminPolicy omits the kubeadmin user and its password.
+	DisabledKubeadminPolicy KubeadminPolicy = "Disabled"
+)
+
 // MasterCount returns the number of replicas in the master machine pool,
 // defaulting to one if no machine pool was found.
 func (c *InstallConfig) MasterCount() int {
@@ -68,6 +188,21 @@ func (c *InstallConfig) MasterCount() int {
 	return 1
 }
 
+// MachineConfigServerEndpoint returns the host and port of the machine
+// config server which the pointer Ignition configs reference.
+func (c *InstallConfig) MachineConfigServerEndpoint() (string, int) {
+	host, port := fmt.Sprintf("%s-api.%s", c.ObjectMeta.Name, c.BaseDomain), DefaultMachineConfigServerPort
+	if mcs := c.MachineConfigServer; mcs != nil {
+		if mcs.Host != "" {
+			host = mcs.Host
+		}
+		if mcs.Port != 0 {
+			port = mcs.Port
+		}
+	}
+	return host, port
+}
+
 // Platform is the configuration for the specific platform upon which to perform
 // the installation. Only one of the platform configuration should be set.
 type Platform struct {
@@ -141,4 +276,510 @@ type Networking struct {
 	// TODO(cdc) remove this.
 	// +optional
 	PodCIDR *ipnet.IPNet `json:"podCIDR,omitempty"`
+
+	// KubeProxy is the configuration of kube-proxy, which is only consumed
+	// by the OpenshiftSDN network type.
+	// +optional
+	KubeProxy *KubeProxy `json:"kubeProxy,omitempty"`
+
+	// OpenshiftSDN is the configuration of the OpenshiftSDN network type.
+	// +optional
+	OpenshiftSDN *OpenshiftSDN `json:"openshiftSDN,omitempty"`
+}
+
+// KubeProxy is the configuration of kube-proxy.
+type KubeProxy struct {
+	// IptablesSyncPeriod is the period with which the iptables rules are
+	// refreshed, such as 30s or 1m.
+	// +optional
+	// Default is 30s.
+	IptablesSyncPeriod string `json:"iptablesSyncPeriod,omitempty"`
+}
+
+// OpenshiftSDN is the configuration of the OpenshiftSDN network type.
+type OpenshiftSDN struct {
+	// VXLANPort is the UDP port of the VXLAN tunnel between the nodes, for
+	// example when the default port is already used by the network.
+	// +optional
+	// Default is 4789.
+	VXLANPort *uint32 `json:"vxlanPort,omitempty"`
+
+	// MTU is the MTU of the VXLAN tunnel interface, which must leave room
+	// for the 50-byte VXLAN header within the MTU of the machine network.
+	// +optional
+	// Default is 1450.
+	MTU *uint32 `json:"mtu,omitempty"`
+}
+
+// APIServer is the configuration of the Kubernetes API server.
+type APIServer struct {
+	// ServingCertificate is the certificate served for the API URL of the
+	// cluster, instead of one signed by the cluster's root CA.
+	// +optional
+	ServingCertificate *ServingCertificate `json:"servingCertificate,omitempty"`
+
+	// AdditionalDNSNames are DNS names, in addition to the API URL of the
+	// cluster, for which the certificate generated for the API server is
+	// valid, such as the name of an external load balancer.
+	// +optional
+	AdditionalDNSNames []string `json:"additionalDNSNames,omitempty"`
+
+	// AdditionalIPAddresses are IP addresses for which the certificate
+	// generated for the API server is valid, such as a virtual IP.
+	// +optional
+	AdditionalIPAddresses []string `json:"additionalIPAddresses,omitempty"`
+
+	// AggregatorCA is the CA which signs the client certificate with which
+	// the API server authenticates to aggregated API servers, instead of
+	// one generated by the installer.
+	// +optional
+	AggregatorCA *CertificateAuthority `json:"aggregatorCA,omitempty"`
+
+	// AuditProfile is the audit policy of the API server: Default, which
+	// logs the metadata of all requests, WriteRequestBodies, which also
+	// logs the bodies of the requests which modify objects, or
+	// AllRequestBodies, which logs the bodies of all requests.
+	// +optional
+	// Default is Default.
+	AuditProfile AuditProfile `json:"auditProfile,omitempty"`
+}
+
+// Scheduler is the configuration of the default scheduler.
+type Scheduler struct {
+	// Profile is the scoring profile of the scheduler: LowNodeUtilization,
+	// which spreads pods across nodes, HighNodeUtilization, which packs
+	// pods onto as few nodes as possible, or NoScoring, which skips scoring
+	// for the lowest scheduling latency.
+	// +optional
+	// Default is LowNodeUtilization.
+	Profile SchedulerProfile `json:"profile,omitempty"`
+
+	// DefaultNodeSelector is the label selector, for example
+	// "node-role.kubernetes.io/worker=", which is added to the pods of
+	// projects without their own node selector.
+	// +optional
+	DefaultNodeSelector string `json:"defaultNodeSelector,omitempty"`
+
+	// MastersSchedulable allows the scheduler to place regular workloads on
+	// the control-plane machines, for example in compact clusters without
+	// compute machines.
+	// +optional
+	MastersSchedulable bool `json:"mastersSchedulable,omitempty"`
+}
+
+// SchedulerProfile is a scoring profile of the scheduler.
+type SchedulerProfile string
+
+const (
+	// LowNodeUtilizationSchedulerProfile spreads pods across nodes.
+	LowNodeUtilizationSchedulerProfile SchedulerProfile = "LowNodeUtilization"
+
+	// HighNodeUtilizationSchedulerProfile packs pods onto as few nodes as
+	// possible.
+	HighNodeUtilizationSchedulerProfile SchedulerProfile = "HighNodeUtilization"
+
+	// NoScoringSchedulerProfile skips scoring.
+	NoScoringSchedulerProfile SchedulerProfile = "NoScoring"
+)
+
+// AuditProfile is an audit policy of the API server.
+type AuditProfile string
+
+const (
+	// DefaultAuditProfile logs the metadata of all requests.
+	DefaultAuditProfile AuditProfile = "Default"
+
+	// WriteRequestBodiesAuditProfile also logs the request bodies of the
+	// requests which modify objects.
+	WriteRequestBodiesAuditProfile AuditProfile = "WriteRequestBodies"
+
+	// AllRequestBodiesAuditProfile also logs the request bodies of all
+	// requests.
+	AllRequestBodiesAuditProfile AuditProfile = "AllRequestBodies"
+)
+
+// Ingress is the configuration of the default ingress controller.
+type Ingress struct {
+	// DefaultCertificate is the wildcard certificate served for the routes
+	// of the cluster, *.apps.<cluster name>.<base domain>, instead of one
+	// generated by the ingress operator.
+	// +optional
+	DefaultCertificate *ServingCertificate `json:"defaultCertificate,omitempty"`
+
+	// Replicas is the number of routers of the default ingress controller.
+	// +optional
+	// Default is 2, or fewer if the routers run on fewer user-provisioned
+	// machines.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// NodeSelector selects the nodes on which the routers run.
+	// +optional
+	// Default is the infra nodes if there is an infra pool, and the
+	// workers otherwise.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// EndpointPublishingStrategy is how the routers are published:
+	// LoadBalancerService, HostNetwork, or Private.
+	// +optional
+	// Default is LoadBalancerService on cloud platforms and HostNetwork
+	// otherwise, as chosen by the ingress operator.
+	EndpointPublishingStrategy EndpointPublishingStrategy `json:"endpointPublishingStrategy,omitempty"`
+}
+
+// EndpointPublishingStrategy is how the routers of an ingress controller are
+// published.
+type EndpointPublishingStrategy string
+
+const (
+	// LoadBalancerServiceStrategy publishes the routers with a load
+	// balancer service.
+	LoadBalancerServiceStrategy EndpointPublishingStrategy = "LoadBalancerService"
+
+	// HostNetworkStrategy publishes the routers on the ports of the nodes
+	// on which they run.
+	HostNetworkStrategy EndpointPublishingStrategy = "HostNetwork"
+
+	// PrivateStrategy does not publish the routers outside of the cluster.
+	PrivateStrategy EndpointPublishingStrategy = "Private"
+)
+
+// Kubeconfig is a user for whom a kubeconfig is generated.
+type Kubeconfig struct {
+	// Name is the name of the user. The kubeconfig is written to
+	// auth/kubeconfig-<name>.
+	Name string `json:"name"`
+
+	// Groups are the groups of the user, which are granted access by their
+	// role bindings. For example, the system:cluster-readers group is bound
+	// to the cluster-reader role.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// BootstrapInPlace is the configuration of a single-node cluster which
+// bootstraps on its control-plane machine. The machine boots a live system
+// with the bootstrap Ignition config, brings up the control plane, then
+// installs itself to the installation disk, with the state of the control
+// plane, and reboots into the installed system.
+type BootstrapInPlace struct {
+	// InstallationDisk is the disk to which the operating system is
+	// installed, such as /dev/sda.
+	InstallationDisk string `json:"installationDisk"`
+}
+
+// MachineConfigServer is the endpoint of the machine config server.
+type MachineConfigServer struct {
+	// Host is the hostname or IP address of the endpoint.
+	// +optional
+	// Default is the API hostname of the cluster, <name>-api.<base domain>.
+	Host string `json:"host,omitempty"`
+
+	// Port is the port of the endpoint.
+	// +optional
+	// Default is 49500.
+	Port int `json:"port,omitempty"`
+}
+
+// ClusterVersionOverride is a component of the release payload which the
+// cluster-version operator leaves unmanaged.
+type ClusterVersionOverride struct {
+	// Kind is the kind of the component, such as Deployment.
+	Kind string `json:"kind"`
+
+	// Group is the API group of the component, such as apps, or empty for
+	// the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Namespace is the namespace of the component, or empty for
+	// cluster-scoped components.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the component.
+	Name string `json:"name"`
+}
+
+// IdentityProvider is an identity provider of the cluster's OAuth server.
+// Exactly one of HTPasswd, LDAP, and OpenID must be set.
+type IdentityProvider struct {
+	// Name is the name of the identity provider, which prefixes the names
+	// of its users' identities.
+	Name string `json:"name"`
+
+	// HTPasswd authenticates users with an htpasswd file.
+	// +optional
+	HTPasswd *HTPasswdIdentityProvider `json:"htpasswd,omitempty"`
+
+	// LDAP authenticates users with an LDAP server.
+	// +optional
+	LDAP *LDAPIdentityProvider `json:"ldap,omitempty"`
+
+	// OpenID authenticates users with an OpenID Connect provider.
+	// +optional
+	OpenID *OpenIDIdentityProvider `json:"openID,omitempty"`
+}
+
+// HTPasswdIdentityProvider authenticates users with an htpasswd file.
+type HTPasswdIdentityProvider struct {
+	// FileData is the content of the htpasswd file, whose passwords must be
+	// bcrypt hashes, as written by htpasswd -B.
+	FileData string `json:"fileData"`
+}
+
+// LDAPIdentityProvider authenticates users with an LDAP server.
+type LDAPIdentityProvider struct {
+	// URL is the RFC 2255 URL of the LDAP server and of the search for
+	// users, such as ldaps://ldap.example.com/ou=users,dc=example,dc=com?uid.
+	URL string `json:"url"`
+
+	// BindDN is the DN with which to bind for the search.
+	// +optional
+	// Default is an anonymous bind.
+	BindDN string `json:"bindDN,omitempty"`
+
+	// BindPassword is the password with which to bind for the search.
+	// +optional
+	BindPassword string `json:"bindPassword,omitempty"`
+
+	// CA is the PEM-encoded CA bundle with which to verify the certificate
+	// of the server.
+	// +optional
+	// Default is the system CA bundle.
+	CA string `json:"ca,omitempty"`
+
+	// Insecure connects to ldap:// URLs without StartTLS.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// OpenIDIdentityProvider authenticates users with an OpenID Connect provider.
+type OpenIDIdentityProvider struct {
+	// ClientID is the ID of the OAuth client registered with the provider.
+	ClientID string `json:"clientID"`
+
+	// ClientSecret is the secret of the OAuth client.
+	ClientSecret string `json:"clientSecret"`
+
+	// AuthorizeURL is the authorization endpoint of the provider.
+	AuthorizeURL string `json:"authorizeURL"`
+
+	// TokenURL is the token endpoint of the provider.
+	TokenURL string `json:"tokenURL"`
+
+	// UserInfoURL is the userinfo endpoint of the provider.
+	// +optional
+	// Default is to read the claims from the ID token.
+	UserInfoURL string `json:"userInfoURL,omitempty"`
+
+	// CA is the PEM-encoded CA bundle with which to verify the certificates
+	// of the endpoints.
+	// +optional
+	// Default is the system CA bundle.
+	CA string `json:"ca,omitempty"`
+}
+
+// ImageRegistry is the configuration of the internal image registry.
+type ImageRegistry struct {
+	// Removed removes the registry, for clusters which neither build nor
+	// push images. No storage may be set then.
+	// +optional
+	Removed bool `json:"removed,omitempty"`
+
+	// Storage is the storage of the registry.
+	// +optional
+	Storage ImageRegistryStorage `json:"storage,omitempty"`
+}
+
+// ImageRegistryStorage is the storage of the internal image registry. At
+// most one of its fields may be set.
+type ImageRegistryStorage struct {
+	// S3 stores the images in an S3 bucket. It is only supported on AWS.
+	// +optional
+	S3 *ImageRegistryS3Storage `json:"s3,omitempty"`
+
+	// Swift stores the images in a Swift container. It is only supported
+	// on OpenStack.
+	// +optional
+	Swift *ImageRegistrySwiftStorage `json:"swift,omitempty"`
+
+	// EmptyDir stores the images in the pod of the registry, so they are
+	// lost when it restarts. It is only meant for proofs of concept.
+	// +optional
+	EmptyDir *ImageRegistryEmptyDirStorage `json:"emptyDir,omitempty"`
+}
+
+// ImageRegistryS3Storage stores the images of the registry in an S3 bucket.
+type ImageRegistryS3Storage struct {
+	// Bucket is the name of the bucket, which the image registry operator
+	// creates if it does not exist.
+	Bucket string `json:"bucket"`
+
+	// Region is the region of the bucket.
+	// +optional
+	// Default is the region of the cluster.
+	Region string `json:"region,omitempty"`
+}
+
+// ImageRegistrySwiftStorage stores the images of the registry in a Swift
+// container.
+type ImageRegistrySwiftStorage struct {
+	// Container is the name of the container, which the image registry
+	// operator creates if it does not exist.
+	Container string `json:"container"`
+}
+
+// ImageRegistryEmptyDirStorage stores the images of the registry in an
+// emptyDir volume.
+type ImageRegistryEmptyDirStorage struct{}
+
+// Proxy is the configuration of an HTTP proxy.
+type Proxy struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests, such as
+	// http://proxy.example.com:3128.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of the domains, IP addresses and
+	// CIDRs which are reached without the proxy, in addition to those of
+	// the cluster.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// ImageContentSource is an image repository and its mirrors.
+type ImageContentSource struct {
+	// Source is the repository, such as
+	// quay.io/openshift-release-dev/ocp-release.
+	Source string `json:"source"`
+
+	// Mirrors are the repositories from which the images of the source are
+	// pulled instead, in order of preference. Images are only pulled from
+	// the mirrors by digest.
+	Mirrors []string `json:"mirrors"`
+}
+
+// Host is a machine which is provisioned with its own Ignition config.
+type Host struct {
+	// Name is the hostname of the machine.
+	Name string `json:"name"`
+
+	// Role is the role of the machine: bootstrap, master, or worker.
+	Role string `json:"role"`
+
+	// NetworkConfig is the static network configuration of the machine.
+	// +optional
+	// Default is to configure the network with DHCP.
+	NetworkConfig *NetworkConfig `json:"networkConfig,omitempty"`
+}
+
+// NetworkConfig is the static network configuration of a machine.
+type NetworkConfig struct {
+	// Interfaces are the network interfaces with static addresses.
+	// +optional
+	Interfaces []NetworkInterface `json:"interfaces,omitempty"`
+
+	// Bonds are the bonds of network interfaces.
+	// +optional
+	Bonds []NetworkBond `json:"bonds,omitempty"`
+
+	// VLANs are the VLAN sub-interfaces of network interfaces or bonds.
+	// +optional
+	VLANs []NetworkVLAN `json:"vlans,omitempty"`
+}
+
+// NetworkInterface is the static configuration of a network interface,
+// which is matched by either its name or its MAC address.
+type NetworkInterface struct {
+	// Name is the name of the interface, such as eno1.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// MACAddress is the MAC address of the interface.
+	// +optional
+	MACAddress string `json:"macAddress,omitempty"`
+
+	NetworkAddresses `json:",inline"`
+}
+
+// NetworkBond is a bond of network interfaces. Its addresses may be left
+// out when only its VLANs have addresses.
+type NetworkBond struct {
+	// Name is the name of the bond, such as bond0.
+	Name string `json:"name"`
+
+	// Interfaces are the names or MAC addresses of the interfaces in the
+	// bond.
+	Interfaces []string `json:"interfaces"`
+
+	// Mode is the bonding mode, such as active-backup or 802.3ad.
+	// +optional
+	// Default is active-backup.
+	Mode string `json:"mode,omitempty"`
+
+	NetworkAddresses `json:",inline"`
+}
+
+// NetworkVLAN is a VLAN sub-interface of a network interface or bond.
+type NetworkVLAN struct {
+	// ID is the VLAN ID.
+	ID int `json:"id"`
+
+	// Interface is the name of the interface or bond of the VLAN.
+	Interface string `json:"interface"`
+
+	// Name is the name of the VLAN interface.
+	// +optional
+	// Default is <interface>.<id>.
+	Name string `json:"name,omitempty"`
+
+	NetworkAddresses `json:",inline"`
+}
+
+// DeviceName returns the name of the VLAN interface.
+func (v *NetworkVLAN) DeviceName() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return fmt.Sprintf("%s.%d", v.Interface, v.ID)
+}
+
+// NetworkAddresses are the static addresses of a network interface.
+type NetworkAddresses struct {
+	// Addresses are the addresses of the interface, in CIDR notation, such
+	// as 192.168.1.10/24.
+	// +optional
+	Addresses []ipnet.IPNet `json:"addresses,omitempty"`
+
+	// Gateway is the default gateway reached through the interface.
+	// +optional
+	Gateway net.IP `json:"gateway,omitempty"`
+
+	// DNS are the addresses of the DNS servers reached through the
+	// interface.
+	// +optional
+	DNS []net.IP `json:"dns,omitempty"`
+}
+
+// ServingCertificate is a PEM-encoded serving certificate and its private key.
+type ServingCertificate struct {
+	// Certificate is the PEM-encoded certificate, followed by the
+	// PEM-encoded intermediate certificates of its chain, if any.
+	Certificate string `json:"certificate"`
+
+	// Key is the PEM-encoded private key of the certificate.
+	Key string `json:"key"`
+}
+
+// CertificateAuthority is a PEM-encoded CA certificate and its private key.
+type CertificateAuthority struct {
+	// Certificate is the PEM-encoded CA certificate.
+	Certificate string `json:"certificate"`
+
+	// Key is the PEM-encoded PKCS #1 RSA private key of the certificate.
+	Key string `json:"key"`
 }
@@ -14,6 +14,12 @@ type Platform struct {
 	// Default is qemu+tcp://192.168.122.1/system
 	URI string `json:"URI,omitempty"`
 
+	// OSImage is the URL of the QEMU image of the RHCOS build which the
+	// machines boot, such as file:///var/lib/rhcos/rhcos-qemu.qcow2,
+	// instead of the image of the installer's RHCOS build.
+	// +optional
+	OSImage string `json:"osImage,omitempty"`
+
 	// DefaultMachinePlatform is the default configuration used when
 	// installing on libvirt for machine pools which do not define their
 	// own platform configuration.
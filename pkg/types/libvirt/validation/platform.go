@@ -1,18 +1,30 @@
 package validation
 
 import (
+	"errors"
+	"net/url"
+	"path"
+	"regexp"
+
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types/libvirt"
 	"github.com/openshift/installer/pkg/validate"
 )
 
+var sha256Regexp = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
 // ValidatePlatform checks that the specified platform is valid.
 func ValidatePlatform(p *libvirt.Platform, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if err := validate.URI(p.URI); err != nil {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("uri"), p.URI, err.Error()))
 	}
+	if p.OSImage != "" {
+		if err := validateOSImage(p.OSImage); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("osImage"), p.OSImage, err.Error()))
+		}
+	}
 	if p.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
 	}
@@ -25,3 +37,19 @@ func ValidatePlatform(p *libvirt.Platform, fldPath *field.Path) field.ErrorList
 	}
 	return allErrs
 }
+
+// validateOSImage checks that the image is an absolute path or a URI, with
+// an optional sha256 query parameter with its SHA-256 checksum.
+func validateOSImage(image string) error {
+	u, err := url.Parse(image)
+	if err != nil {
+		return err
+	}
+	if !u.IsAbs() && !path.IsAbs(u.Path) {
+		return errors.New("must be an absolute path or a URI")
+	}
+	if checksum, ok := u.Query()["sha256"]; ok && (len(checksum) != 1 || !sha256Regexp.MatchString(checksum[0])) {
+		return errors.New("the sha256 parameter must be a SHA-256 checksum of 64 hexadecimal digits")
+	}
+	return nil
+}
@@ -38,6 +38,42 @@ func TestValidatePlatform(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "valid OS image",
+			platform: func() *libvirt.Platform {
+				p := validPlatform()
+				p.OSImage = "file:///var/lib/rhcos/rhcos-qemu.qcow2"
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "OS image path with checksum",
+			platform: func() *libvirt.Platform {
+				p := validPlatform()
+				p.OSImage = "/var/lib/rhcos/rhcos-qemu.qcow2?sha256=e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid OS image checksum",
+			platform: func() *libvirt.Platform {
+				p := validPlatform()
+				p.OSImage = "http://mirror.example.com/rhcos-qemu.qcow2?sha256=e3b0c442"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "invalid OS image",
+			platform: func() *libvirt.Platform {
+				p := validPlatform()
+				p.OSImage = "rhcos-qemu.qcow2"
+				return p
+			}(),
+			valid: false,
+		},
 		{
 			name: "missing network",
 			platform: func() *libvirt.Platform {
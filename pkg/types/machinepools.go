@@ -1,6 +1,8 @@
 package types
 
 import (
+	"encoding/json"
+
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	"github.com/openshift/installer/pkg/types/openstack"
@@ -17,6 +19,139 @@ type MachinePool struct {
 
 	// Platform is configuration for machine pool specific to the platfrom.
 	Platform MachinePoolPlatform `json:"platform"`
+
+	// Storage is the disk configuration of the machines in the pool.
+	Storage *MachinePoolStorage `json:"storage,omitempty"`
+
+	// SSHKeys are public SSH keys which, in addition to the sshKey of the
+	// cluster, provide access to the machines in the pool.
+	SSHKeys []string `json:"sshKeys,omitempty"`
+
+	// PasswordHash is the crypt(3) hash of the password of the core user
+	// on the machines in the pool, for logging in on the console, such as
+	// generated by "openssl passwd -6".
+	PasswordHash string `json:"passwordHash,omitempty"`
+
+	// Autoscaling lets the cluster autoscaler scale the pool between a
+	// minimum and a maximum number of replicas. It is only supported for
+	// the worker pool.
+	Autoscaling *MachinePoolAutoscaling `json:"autoscaling,omitempty"`
+
+	// HealthCheck lets the machine API replace the machines of the pool
+	// which become unhealthy. It is only supported for compute pools.
+	HealthCheck *MachinePoolHealthCheck `json:"healthCheck,omitempty"`
+
+	// ProviderSpecPatch is a patch applied to the providerSpec of the
+	// machines of the pool, for the options of the machine API provider
+	// which the install-config does not cover.
+	// +optional
+	ProviderSpecPatch *ProviderSpecPatch `json:"providerSpecPatch,omitempty"`
+
+	// NameTemplate is the template of the names of the machinesets of the
+	// pool, after which their machines are named, of lowercase letters,
+	// digits, hyphens and the {clusterName}, {role} and {zone}
+	// placeholders, e.g. "ocp-{role}-{zone}". It is only supported for the
+	// worker pool.
+	// Default is "{clusterName}-{role}-{zone}".
+	// +optional
+	NameTemplate string `json:"nameTemplate,omitempty"`
+
+	// UserProvisioned marks the machines of the pool as provisioned by the
+	// user, who boots them with the Ignition config of the pool, so the
+	// installer creates no machinesets for them. Their replicas still size
+	// the cluster manifests, such as the replicas of the routers. It is not
+	// supported for the master pool.
+	// +optional
+	UserProvisioned bool `json:"userProvisioned,omitempty"`
+}
+
+// ProviderSpecPatchType is the type of a providerSpec patch.
+type ProviderSpecPatchType string
+
+const (
+	// MergePatchType is a JSON merge patch (RFC 7386), which is merged
+	// into the providerSpec, replacing its lists.
+	MergePatchType ProviderSpecPatchType = "merge"
+
+	// JSONPatchType is a JSON patch (RFC 6902), which is a list of
+	// operations on the providerSpec.
+	JSONPatchType ProviderSpecPatchType = "json"
+)
+
+// ProviderSpecPatch is a patch applied to the providerSpec of machines.
+type ProviderSpecPatch struct {
+	// Type is the type of the patch: merge or json.
+	// Default is merge.
+	// +optional
+	Type ProviderSpecPatchType `json:"type,omitempty"`
+
+	// Patch is the patch. It is an object for merge patches and a list
+	// of operations for JSON patches.
+	Patch json.RawMessage `json:"patch"`
+}
+
+// MachinePoolAutoscaling is the range of replicas within which the cluster
+// autoscaler scales a machine pool.
+type MachinePoolAutoscaling struct {
+	// MinReplicas is the minimum count of machines for the machine pool.
+	MinReplicas int64 `json:"minReplicas"`
+
+	// MaxReplicas is the maximum count of machines for the machine pool.
+	MaxReplicas int64 `json:"maxReplicas"`
+}
+
+// MachinePoolHealthCheck is the configuration of the remediation of the
+// unhealthy machines of a machine pool.
+type MachinePoolHealthCheck struct {
+	// UnhealthyConditions are the node conditions which mark a machine
+	// unhealthy when they last longer than their timeout. The machine API
+	// applies the same conditions to all the pools, so the pools which set
+	// them must set the same ones.
+	// Default is the Ready condition being False or Unknown for 5 minutes.
+	// +optional
+	UnhealthyConditions []UnhealthyCondition `json:"unhealthyConditions,omitempty"`
+}
+
+// UnhealthyCondition is a node condition which marks a machine unhealthy.
+type UnhealthyCondition struct {
+	// Type is the type of the node condition (e.g. Ready).
+	Type string `json:"type"`
+
+	// Status is the status of the condition: True, False or Unknown.
+	Status string `json:"status"`
+
+	// Timeout is how long the condition must last, such as 5m.
+	Timeout string `json:"timeout"`
+}
+
+// MachinePoolStorage is the disk configuration of the machines in a pool.
+type MachinePoolStorage struct {
+	// Partitions are additional partitions, which are created, formatted
+	// with XFS and mounted on the first boot of the machines.
+	Partitions []Partition `json:"partitions,omitempty"`
+
+	// Multipath enables multipath for the root disk of the machines.
+	Multipath bool `json:"multipath,omitempty"`
+}
+
+// Partition is an additional partition of the machines in a pool.
+type Partition struct {
+	// Device is the disk of the partition (e.g. /dev/sdb).
+	Device string `json:"device"`
+
+	// MountPath is where the partition is mounted (e.g.
+	// /var/lib/containers).
+	MountPath string `json:"mountPath"`
+
+	// StartMiB is the offset of the partition on the disk, in MiB. It is
+	// required for partitions on the root disk, whose root partition grows
+	// to fill the disk on first boot.
+	// Default is the start of the largest free space on the disk.
+	StartMiB int `json:"startMiB,omitempty"`
+
+	// SizeMiB is the size of the partition, in MiB.
+	// Default is the rest of the free space.
+	SizeMiB int `json:"sizeMiB,omitempty"`
 }
 
 // MachinePoolPlatform is the platform-specific configuration for a machine
@@ -3,9 +3,19 @@ package openstack
 // MachinePool stores the configuration for a machine pool installed
 // on OpenStack.
 type MachinePool struct {
+	// Zones is the list of Nova availability zones that can be used.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
 	// FlavorName defines the OpenStack Nova flavor.
 	// eg. m1.large
 	FlavorName string `json:"type"`
+
+	// OSImage is the name of the Glance image which the machines of the
+	// pool boot, instead of the osImage of the platform. It is only
+	// supported for the compute pools, whose machine sets boot it.
+	// +optional
+	OSImage string `json:"osImage,omitempty"`
 }
 
 // Set sets the values from `required` to `a`.
@@ -14,7 +24,14 @@ func (o *MachinePool) Set(required *MachinePool) {
 		return
 	}
 
+	if len(required.Zones) > 0 {
+		o.Zones = required.Zones
+	}
+
 	if required.FlavorName != "" {
 		o.FlavorName = required.FlavorName
 	}
+	if required.OSImage != "" {
+		o.OSImage = required.OSImage
+	}
 }
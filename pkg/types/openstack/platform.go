@@ -24,6 +24,12 @@ type Platform struct {
 	// The OpenStack compute flavor to use for servers.
 	FlavorName string `json:"computeFlavor"`
 
+	// OSImage is the name of the Glance image of the RHCOS build which the
+	// machines boot.
+	// +optional
+	// Default is "rhcos".
+	OSImage string `json:"osImage,omitempty"`
+
 	// TrunkSupport
 	// Whether OpenStack ports can be trunked
 	TrunkSupport string `json:"trunkSupport"`
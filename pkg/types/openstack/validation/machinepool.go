@@ -8,5 +8,15 @@ import (
 
 // ValidateMachinePool checks that the specified machine pool is valid.
 func ValidateMachinePool(p *openstack.MachinePool, fldPath *field.Path) field.ErrorList {
-	return field.ErrorList{}
+	allErrs := field.ErrorList{}
+	// Each zone gets its own machineset, which is named after the zone.
+	seen := map[string]bool{}
+	for i, zone := range p.Zones {
+		if seen[zone] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("zones").Index(i), zone))
+		}
+		seen[zone] = true
+	}
+	return allErrs
 }
+
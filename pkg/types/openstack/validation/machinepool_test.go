@@ -20,6 +20,20 @@ func TestValidateMachinePool(t *testing.T) {
 			pool:  &openstack.MachinePool{},
 			valid: true,
 		},
+		{
+			name: "zones",
+			pool: &openstack.MachinePool{
+				Zones: []string{"nova-a", "nova-b"},
+			},
+			valid: true,
+		},
+		{
+			name: "duplicate zones",
+			pool: &openstack.MachinePool{
+				Zones: []string{"nova-a", "nova-a"},
+			},
+			valid: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -8,46 +8,52 @@ import (
 	"github.com/openshift/installer/pkg/types/openstack"
 )
 
-// ValidatePlatform checks that the specified platform is valid.
+// ValidatePlatform checks that the specified platform is valid. If fetcher
+// is nil, the values are not checked against the cloud.
 func ValidatePlatform(p *openstack.Platform, fldPath *field.Path, fetcher ValidValuesFetcher) field.ErrorList {
 	allErrs := field.ErrorList{}
-	validClouds, err := fetcher.GetCloudNames()
-	if err != nil {
-		allErrs = append(allErrs, field.InternalError(fldPath.Child("cloud"), errors.New("could not retrieve valid clouds")))
-	} else if !isValidValue(p.Cloud, validClouds) {
-		allErrs = append(allErrs, field.NotSupported(fldPath.Child("cloud"), p.Cloud, validClouds))
-	} else {
-		validRegions, err := fetcher.GetRegionNames(p.Cloud)
+	if fetcher != nil {
+		validClouds, err := fetcher.GetCloudNames()
 		if err != nil {
-			allErrs = append(allErrs, field.InternalError(fldPath.Child("region"), errors.New("could not retrieve valid regions")))
-		} else if !isValidValue(p.Region, validRegions) {
-			allErrs = append(allErrs, field.NotSupported(fldPath.Child("region"), p.Region, validRegions))
-		}
-		validNetworks, err := fetcher.GetNetworkNames(p.Cloud)
-		if err != nil {
-			allErrs = append(allErrs, field.InternalError(fldPath.Child("externalNetwork"), errors.New("could not retrieve valid networks")))
-		} else if !isValidValue(p.ExternalNetwork, validNetworks) {
-			allErrs = append(allErrs, field.NotSupported(fldPath.Child("externalNetwork"), p.ExternalNetwork, validNetworks))
-		}
-		validFlavors, err := fetcher.GetFlavorNames(p.Cloud)
-		if err != nil {
-			allErrs = append(allErrs, field.InternalError(fldPath.Child("computeFlavor"), errors.New("could not retrieve valid flavors")))
-		} else if !isValidValue(p.FlavorName, validFlavors) {
-			allErrs = append(allErrs, field.NotSupported(fldPath.Child("computeFlavor"), p.FlavorName, validFlavors))
-		}
-		netExts, err := fetcher.GetNetworkExtensionsAliases(p.Cloud)
-		if err != nil {
-			allErrs = append(allErrs, field.InternalError(fldPath.Child("trunkSupport"), errors.New("could not retrieve networking extension aliases")))
+			allErrs = append(allErrs, field.InternalError(fldPath.Child("cloud"), errors.New("could not retrieve valid clouds")))
+		} else if !isValidValue(p.Cloud, validClouds) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("cloud"), p.Cloud, validClouds))
 		} else {
-			if isValidValue("trunk", netExts) {
-				p.TrunkSupport = "1"
+			validRegions, err := fetcher.GetRegionNames(p.Cloud)
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(fldPath.Child("region"), errors.New("could not retrieve valid regions")))
+			} else if !isValidValue(p.Region, validRegions) {
+				allErrs = append(allErrs, field.NotSupported(fldPath.Child("region"), p.Region, validRegions))
+			}
+			validNetworks, err := fetcher.GetNetworkNames(p.Cloud)
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(fldPath.Child("externalNetwork"), errors.New("could not retrieve valid networks")))
+			} else if !isValidValue(p.ExternalNetwork, validNetworks) {
+				allErrs = append(allErrs, field.NotSupported(fldPath.Child("externalNetwork"), p.ExternalNetwork, validNetworks))
+			}
+			validFlavors, err := fetcher.GetFlavorNames(p.Cloud)
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(fldPath.Child("computeFlavor"), errors.New("could not retrieve valid flavors")))
+			} else if !isValidValue(p.FlavorName, validFlavors) {
+				allErrs = append(allErrs, field.NotSupported(fldPath.Child("computeFlavor"), p.FlavorName, validFlavors))
+			}
+			netExts, err := fetcher.GetNetworkExtensionsAliases(p.Cloud)
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(fldPath.Child("trunkSupport"), errors.New("could not retrieve networking extension aliases")))
 			} else {
-				p.TrunkSupport = "0"
+				if isValidValue("trunk", netExts) {
+					p.TrunkSupport = "1"
+				} else {
+					p.TrunkSupport = "0"
+				}
 			}
 		}
 	}
 	if p.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
+		if p.DefaultMachinePlatform.OSImage != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("defaultMachinePlatform", "osImage"), "set the osImage of the platform for all the machines instead"))
+		}
 	}
 	return allErrs
 }
@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/libvirt"
+	"github.com/openshift/installer/pkg/types/openstack"
+)
+
+// ValidateClusterMetadata checks that the cluster metadata has a version
+// which this installer supports, and a single platform section which
+// matches its platform name, if it has one. Metadata of a later version,
+// or of a platform which this installer does not know, is refused rather
+// than rewritten without the fields this installer does not know.
+func ValidateClusterMetadata(m *types.ClusterMetadata) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch m.APIVersion {
+	case "", types.ClusterMetadataVersion:
+	default:
+		allErrs = append(allErrs, field.Invalid(field.NewPath("apiVersion"), m.APIVersion, fmt.Sprintf("this installer only supports the %s metadata; use the installer which created the cluster", types.ClusterMetadataVersion)))
+	}
+	if m.ClusterName == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("clusterName"), "the name of the cluster is required"))
+	}
+
+	var sections []string
+	if m.AWS != nil {
+		sections = append(sections, aws.Name)
+	}
+	if m.Libvirt != nil {
+		sections = append(sections, libvirt.Name)
+	}
+	if m.OpenStack != nil {
+		sections = append(sections, openstack.Name)
+	}
+	fldPath := field.NewPath("platform")
+	switch {
+	case len(sections) > 1:
+		allErrs = append(allErrs, field.Invalid(fldPath, m.Platform(), fmt.Sprintf("must only have the section of a single platform; cannot have both %q and %q", sections[0], sections[1])))
+	case len(sections) == 0 && m.PlatformName != "":
+		allErrs = append(allErrs, field.NotSupported(fldPath, m.PlatformName, []string{aws.Name, libvirt.Name, openstack.Name}))
+	case len(sections) == 1 && m.PlatformName != "" && m.PlatformName != sections[0]:
+		allErrs = append(allErrs, field.Invalid(fldPath, m.PlatformName, fmt.Sprintf("does not match the %q section", sections[0])))
+	}
+	return allErrs
+}
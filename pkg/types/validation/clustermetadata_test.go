@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/libvirt"
+)
+
+func TestValidateClusterMetadata(t *testing.T) {
+	cases := []struct {
+		name     string
+		metadata *types.ClusterMetadata
+		valid    bool
+	}{
+		{
+			name: "current",
+			metadata: &types.ClusterMetadata{
+				APIVersion:  types.ClusterMetadataVersion,
+				ClusterName: "test-cluster",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					PlatformName: aws.Name,
+					AWS:          &aws.Metadata{Region: "us-east-1"},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "unversioned",
+			metadata: &types.ClusterMetadata{
+				ClusterName: "test-cluster",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					AWS: &aws.Metadata{Region: "us-east-1"},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "later version",
+			metadata: &types.ClusterMetadata{
+				APIVersion:  "v2",
+				ClusterName: "test-cluster",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					AWS: &aws.Metadata{Region: "us-east-1"},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "no cluster name",
+			metadata: &types.ClusterMetadata{
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					AWS: &aws.Metadata{Region: "us-east-1"},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "unknown platform",
+			metadata: &types.ClusterMetadata{
+				ClusterName: "test-cluster",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					PlatformName: "other",
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "mismatched platform",
+			metadata: &types.ClusterMetadata{
+				ClusterName: "test-cluster",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					PlatformName: libvirt.Name,
+					AWS:          &aws.Metadata{Region: "us-east-1"},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "multiple platforms",
+			metadata: &types.ClusterMetadata{
+				ClusterName: "test-cluster",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					AWS:     &aws.Metadata{Region: "us-east-1"},
+					Libvirt: &libvirt.Metadata{URI: "qemu:///system"},
+				},
+			},
+			valid: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateClusterMetadata(tc.metadata).ToAggregate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
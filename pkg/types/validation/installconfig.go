@@ -3,10 +3,17 @@ package validation
 import (
 	"fmt"
 	"net"
+	"net/url"
+	"reflect"
+	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	netopv1 "github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types"
@@ -14,29 +21,30 @@ import (
 	awsvalidation "github.com/openshift/installer/pkg/types/aws/validation"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	libvirtvalidation "github.com/openshift/installer/pkg/types/libvirt/validation"
+	"github.com/openshift/installer/pkg/types/none"
 	"github.com/openshift/installer/pkg/types/openstack"
 	openstackvalidation "github.com/openshift/installer/pkg/types/openstack/validation"
 	"github.com/openshift/installer/pkg/validate"
 )
 
-const (
-	installConfigVersion = "v1beta1"
-)
-
 // ValidateInstallConfig checks that the specified install config is valid.
 func ValidateInstallConfig(c *types.InstallConfig, openStackValidValuesFetcher openstackvalidation.ValidValuesFetcher) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if c.TypeMeta.APIVersion == "" {
 		return field.ErrorList{field.Required(field.NewPath("apiVersion"), "install-config version required")}
 	}
-	if c.TypeMeta.APIVersion != installConfigVersion {
-		return field.ErrorList{field.Invalid(field.NewPath("apiVersion"), c.TypeMeta.APIVersion, fmt.Sprintf("install-config version must be %q", installConfigVersion))}
+	if c.TypeMeta.APIVersion != types.InstallConfigVersion {
+		return field.ErrorList{field.Invalid(field.NewPath("apiVersion"), c.TypeMeta.APIVersion, fmt.Sprintf("install-config version must be %q", types.InstallConfigVersion))}
 	}
 	if c.ObjectMeta.Name == "" {
 		allErrs = append(allErrs, field.Required(field.NewPath("metadata", "name"), "cluster name required"))
+	} else {
+		allErrs = append(allErrs, validateClusterName(c.ObjectMeta.Name, c.Platform.Name(), field.NewPath("metadata", "name"))...)
 	}
 	if c.SSHKey != "" {
-		if err := validate.SSHPublicKey(c.SSHKey); err != nil {
+		if err := validate.SSHPublicKeys(c.SSHKey); err == validate.ErrPrivateSSHKey {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("sshKey"), "<redacted>", err.Error()))
+		} else if err != nil {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("sshKey"), c.SSHKey, err.Error()))
 		}
 	}
@@ -44,7 +52,7 @@ func ValidateInstallConfig(c *types.InstallConfig, openStackValidValuesFetcher o
 		allErrs = append(allErrs, field.Invalid(field.NewPath("baseDomain"), c.BaseDomain, err.Error()))
 	}
 	if c.Networking != nil {
-		allErrs = append(allErrs, validateNetworking(c.Networking, field.NewPath("networking"))...)
+		allErrs = append(allErrs, validateNetworking(c.Networking, nodeCount(c.Machines), field.NewPath("networking"))...)
 	} else {
 		allErrs = append(allErrs, field.Required(field.NewPath("networking"), "networking is required"))
 	}
@@ -53,23 +61,135 @@ func ValidateInstallConfig(c *types.InstallConfig, openStackValidValuesFetcher o
 	if err := validate.ImagePullSecret(c.PullSecret); err != nil {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("pullSecret"), c.PullSecret, err.Error()))
 	}
+	if c.APIServer != nil {
+		allErrs = append(allErrs, validateAPIServer(c.APIServer, fmt.Sprintf("%s-api.%s", c.ObjectMeta.Name, c.BaseDomain), field.NewPath("apiServer"))...)
+	}
+	allErrs = append(allErrs, validateKubeconfigs(c.Kubeconfigs, field.NewPath("kubeconfigs"))...)
+	if c.Proxy != nil {
+		allErrs = append(allErrs, validateProxy(c.Proxy, field.NewPath("proxy"))...)
+	}
+	allErrs = append(allErrs, validateImageContentSources(c.ImageContentSources, field.NewPath("imageContentSources"))...)
+	for i, server := range c.NTPServers {
+		if net.ParseIP(server) != nil {
+			continue
+		}
+		if err := validate.DomainName(server); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("ntpServers").Index(i), server, err.Error()))
+		}
+	}
+	if len(c.Hosts) > 0 {
+		if platform := c.Platform.Name(); platform != none.Name {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("hosts"), platform, fmt.Sprintf("hosts are only supported on the %q platform", none.Name)))
+		}
+		allErrs = append(allErrs, validateHosts(c.Hosts, field.NewPath("hosts"))...)
+	}
+	if c.BootstrapInPlace != nil {
+		allErrs = append(allErrs, validateBootstrapInPlace(c, field.NewPath("bootstrapInPlace"))...)
+	}
+	if c.MachineConfigServer != nil {
+		allErrs = append(allErrs, validateMachineConfigServer(c.MachineConfigServer, field.NewPath("machineConfigServer"))...)
+	}
+	allErrs = append(allErrs, validateClusterVersionOverrides(c.ClusterVersionOverrides, field.NewPath("clusterVersionOverrides"))...)
+	allErrs = append(allErrs, validateIdentityProviders(c.IdentityProviders, field.NewPath("identityProviders"))...)
+	if c.ImageRegistry != nil {
+		allErrs = append(allErrs, validateImageRegistry(c.ImageRegistry, c.Platform.Name(), field.NewPath("imageRegistry"))...)
+	}
+	switch c.CredentialsMode {
+	case "", types.ManualCredentialsMode:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("credentialsMode"), c.CredentialsMode, []string{string(types.ManualCredentialsMode)}))
+	}
+	switch c.Kubeadmin {
+	case "", types.DisabledKubeadminPolicy:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("kubeadmin"), c.Kubeadmin, []string{string(types.DisabledKubeadminPolicy)}))
+	}
+	if c.Scheduler != nil {
+		allErrs = append(allErrs, validateScheduler(c.Scheduler, field.NewPath("scheduler"))...)
+	}
+	if c.Ingress != nil {
+		// The console route stands in for the routes served with the
+		// default certificate.
+		allErrs = append(allErrs, validateIngress(c.Ingress, fmt.Sprintf("console-openshift-console.apps.%s.%s", c.ObjectMeta.Name, c.BaseDomain), field.NewPath("ingress"))...)
+	}
+	return allErrs
+}
+
+// validateNetworking checks the networking of a cluster with the given
+// number of nodes.
+// clusterNameMaxLength is the maximum length of cluster names. It leaves
+// room for the longest DNS label built from the name, "<name>-etcd-<index>",
+// within the 63 characters of a label.
+const clusterNameMaxLength = 54
+
+// platformClusterNameMaxLengths are the lower maximum lengths of the
+// cluster names of the platforms which build names of limited length from
+// them. The names of the AWS load balancers and target groups, which are
+// limited to 32 characters, are built from the infra ID, which truncates
+// the cluster name instead.
+var platformClusterNameMaxLengths = map[string]int{
+	// The IAM role of the bootstrap machine, "<name>-bootstrap-role", is
+	// limited to 64 characters.
+	aws.Name: 49,
+}
+
+func validateClusterName(name string, platform string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if err := validate.DNSLabel(name); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, err.Error()))
+	}
+	max, reason := clusterNameMaxLength, "the DNS labels built from it"
+	if platformMax, ok := platformClusterNameMaxLengths[platform]; ok {
+		max, reason = platformMax, fmt.Sprintf("the %s resource names built from it", platform)
+	}
+	if len(name) > max {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, fmt.Sprintf("must be no more than %d characters, to fit %s", max, reason)))
+	}
 	return allErrs
 }
 
-func validateNetworking(n *types.Networking, fldPath *field.Path) field.ErrorList {
+func validateNetworking(n *types.Networking, nodes int, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if !validate.ValidNetworkTypes[n.Type] {
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), n.Type, validate.ValidNetworkTypeValues))
 	}
 	if err := validate.SubnetCIDR(&n.MachineCIDR.IPNet); err != nil {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("machineCIDR"), n.MachineCIDR, err.Error()))
+	} else if addresses := machineAddresses(&n.MachineCIDR.IPNet); nodes > addresses {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("machineCIDR"), n.MachineCIDR.String(), fmt.Sprintf("machineCIDR has room for %d machine addresses, but the machine pools request %d nodes", addresses, nodes)))
 	}
 	if err := validate.SubnetCIDR(&n.ServiceCIDR.IPNet); err != nil {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceCIDR"), n.ServiceCIDR, err.Error()))
 	}
+	if validate.DoCIDRsOverlap(&n.MachineCIDR.IPNet, &n.ServiceCIDR.IPNet) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceCIDR"), n.ServiceCIDR.String(), "serviceCIDR must not overlap with machineCIDR"))
+	}
+
+	clusterNetworks := make([]*net.IPNet, len(n.ClusterNetworks))
+	hostSubnets := 0
 	for i, cn := range n.ClusterNetworks {
-		allErrs = append(allErrs, validateClusterNetwork(&cn, fldPath.Child("clusterNetworks").Index(i), &n.ServiceCIDR.IPNet)...)
+		cnPath := fldPath.Child("clusterNetworks").Index(i)
+		allErrs = append(allErrs, validateClusterNetwork(&cn, cnPath, &n.MachineCIDR.IPNet, &n.ServiceCIDR.IPNet)...)
+		_, cidr, err := net.ParseCIDR(cn.CIDR)
+		if err != nil {
+			continue
+		}
+		for j, other := range clusterNetworks[:i] {
+			if other != nil && validate.DoCIDRsOverlap(cidr, other) {
+				allErrs = append(allErrs, field.Invalid(cnPath.Child("cidr"), cn.CIDR, fmt.Sprintf("cluster network CIDR must not overlap with %s", fldPath.Child("clusterNetworks").Index(j).Child("cidr"))))
+			}
+		}
+		clusterNetworks[i] = cidr
+		if ones, bits := cidr.Mask.Size(); cn.HostSubnetLength <= uint32(bits-ones) {
+			hostSubnets += 1 << (uint32(bits-ones) - cn.HostSubnetLength)
+		}
 	}
+	if len(n.ClusterNetworks) == 1 && clusterNetworks[0] != nil && nodes > hostSubnets {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterNetworks").Index(0).Child("hostSubnetLength"), n.ClusterNetworks[0].HostSubnetLength, fmt.Sprintf("cluster network host subnet length leaves room for %d nodes in %s, but the machine pools request %d", hostSubnets, n.ClusterNetworks[0].CIDR, nodes)))
+	} else if len(n.ClusterNetworks) > 1 && nodes > hostSubnets {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterNetworks"), nodes, fmt.Sprintf("the host subnet lengths of the cluster networks leave room for %d nodes, but the machine pools request %d", hostSubnets, nodes)))
+	}
+
 	if n.PodCIDR != nil {
 		if err := validate.SubnetCIDR(&n.PodCIDR.IPNet); err != nil {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("podCIDR"), n.PodCIDR, err.Error()))
@@ -77,6 +197,9 @@ func validateNetworking(n *types.Networking, fldPath *field.Path) field.ErrorLis
 		if validate.DoCIDRsOverlap(&n.ServiceCIDR.IPNet, &n.PodCIDR.IPNet) {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("podCIDR"), n.PodCIDR, "podCIDR must not overlap with serviceCIDR"))
 		}
+		if validate.DoCIDRsOverlap(&n.MachineCIDR.IPNet, &n.PodCIDR.IPNet) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("podCIDR"), n.PodCIDR.String(), "podCIDR must not overlap with machineCIDR"))
+		}
 	}
 	if len(n.ClusterNetworks) == 0 && n.PodCIDR == nil {
 		allErrs = append(allErrs, field.Invalid(fldPath, n, "either clusterNetworks or podCIDR is required"))
@@ -84,13 +207,51 @@ func validateNetworking(n *types.Networking, fldPath *field.Path) field.ErrorLis
 	if len(n.ClusterNetworks) != 0 && n.PodCIDR != nil {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("podCIDR"), n.PodCIDR, "cannot use podCIDR when clusterNetworks is used"))
 	}
+	if n.KubeProxy != nil {
+		allErrs = append(allErrs, validateKubeProxy(n.KubeProxy, fldPath.Child("kubeProxy"))...)
+	}
+	if n.OpenshiftSDN != nil {
+		if n.Type != netopv1.NetworkTypeOpenshiftSDN {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("openshiftSDN"), n.OpenshiftSDN, fmt.Sprintf("only supported with the %s network type", netopv1.NetworkTypeOpenshiftSDN)))
+		}
+		allErrs = append(allErrs, validateOpenshiftSDN(n.OpenshiftSDN, fldPath.Child("openshiftSDN"))...)
+	}
+	return allErrs
+}
+
+func validateKubeProxy(kp *types.KubeProxy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if kp.IptablesSyncPeriod != "" {
+		if period, err := time.ParseDuration(kp.IptablesSyncPeriod); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("iptablesSyncPeriod"), kp.IptablesSyncPeriod, err.Error()))
+		} else if period <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("iptablesSyncPeriod"), kp.IptablesSyncPeriod, "must be positive"))
+		}
+	}
 	return allErrs
 }
 
-func validateClusterNetwork(cn *netopv1.ClusterNetwork, fldPath *field.Path, serviceCIDR *net.IPNet) field.ErrorList {
+func validateOpenshiftSDN(sdn *types.OpenshiftSDN, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if sdn.VXLANPort != nil && (*sdn.VXLANPort < 1 || *sdn.VXLANPort > 65535) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("vxlanPort"), *sdn.VXLANPort, "must be between 1 and 65535"))
+	}
+	if sdn.MTU != nil && (*sdn.MTU < 576 || *sdn.MTU > 9000) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("mtu"), *sdn.MTU, "must be between 576 and 9000"))
+	}
+	return allErrs
+}
+
+func validateClusterNetwork(cn *netopv1.ClusterNetwork, fldPath *field.Path, machineCIDR *net.IPNet, serviceCIDR *net.IPNet) field.ErrorList {
 	allErrs := field.ErrorList{}
 	_, cidr, err := net.ParseCIDR(cn.CIDR)
 	if err == nil {
+		if err := validate.ReservedCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR, err.Error()))
+		}
+		if validate.DoCIDRsOverlap(cidr, machineCIDR) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR, "cluster network CIDR must not overlap with machineCIDR"))
+		}
 		if validate.DoCIDRsOverlap(cidr, serviceCIDR) {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR, "cluster network CIDR must not overlap with serviceCIDR"))
 		}
@@ -103,6 +264,33 @@ func validateClusterNetwork(cn *netopv1.ClusterNetwork, fldPath *field.Path, ser
 	return allErrs
 }
 
+// nodeCount returns the number of nodes which the machine pools request.
+// Pools without replicas are defaulted before validation, so they are not
+// counted.
+func nodeCount(pools []types.MachinePool) int {
+	count := 0
+	for _, p := range pools {
+		if p.Replicas != nil && *p.Replicas > 0 {
+			count += int(*p.Replicas)
+		}
+	}
+	return count
+}
+
+// machineAddresses returns the number of addresses of the CIDR which can
+// be assigned to machines, without its network and broadcast addresses.
+func machineAddresses(cidr *net.IPNet) int {
+	ones, bits := cidr.Mask.Size()
+	if bits-ones > 30 {
+		return 1 << 30
+	}
+	addresses := 1 << uint(bits-ones)
+	if addresses > 2 {
+		addresses -= 2
+	}
+	return addresses
+}
+
 func validateMachinePools(pools []types.MachinePool, fldPath *field.Path, platform string) field.ErrorList {
 	allErrs := field.ErrorList{}
 	poolNames := map[string]bool{}
@@ -119,6 +307,28 @@ func validateMachinePools(pools []types.MachinePool, fldPath *field.Path, platfo
 	if !poolNames["worker"] {
 		allErrs = append(allErrs, field.Required(fldPath, "must specify a machine pool with a name of 'worker'"))
 	}
+	allErrs = append(allErrs, validateUnhealthyConditions(pools, fldPath)...)
+	return allErrs
+}
+
+// validateUnhealthyConditions checks that the pools which set unhealthy
+// conditions set the same ones, since the machine API applies the same
+// conditions to all the health checks.
+func validateUnhealthyConditions(pools []types.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	var first *types.MachinePool
+	for i, p := range pools {
+		if p.HealthCheck == nil || len(p.HealthCheck.UnhealthyConditions) == 0 {
+			continue
+		}
+		if first == nil {
+			first = &pools[i]
+			continue
+		}
+		if !reflect.DeepEqual(first.HealthCheck.UnhealthyConditions, p.HealthCheck.UnhealthyConditions) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("healthCheck", "unhealthyConditions"), p.HealthCheck.UnhealthyConditions, fmt.Sprintf("must be the same as the unhealthy conditions of the %s pool", first.Name)))
+		}
+	}
 	return allErrs
 }
 
@@ -152,3 +362,533 @@ func validatePlatform(platform *types.Platform, fldPath *field.Path, openStackVa
 	}
 	return allErrs
 }
+
+func validateAPIServer(a *types.APIServer, hostname string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, name := range a.AdditionalDNSNames {
+		if err := validate.DomainName(name); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("additionalDNSNames").Index(i), name, err.Error()))
+		}
+	}
+	for i, ip := range a.AdditionalIPAddresses {
+		if net.ParseIP(ip) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("additionalIPAddresses").Index(i), ip, "invalid IP address"))
+		}
+	}
+	if a.ServingCertificate != nil {
+		allErrs = append(allErrs, validateServingCertificate(a.ServingCertificate, hostname, fldPath.Child("servingCertificate"))...)
+	}
+	switch a.AuditProfile {
+	case "", types.DefaultAuditProfile, types.WriteRequestBodiesAuditProfile, types.AllRequestBodiesAuditProfile:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("auditProfile"), a.AuditProfile, []string{string(types.DefaultAuditProfile), string(types.WriteRequestBodiesAuditProfile), string(types.AllRequestBodiesAuditProfile)}))
+	}
+	if ca := a.AggregatorCA; ca != nil {
+		fldPath := fldPath.Child("aggregatorCA")
+		switch {
+		case ca.Certificate == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("certificate"), "certificate required"))
+		case ca.Key == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("key"), "key required"))
+		default:
+			// The value is left out, because it holds the private key.
+			if err := validate.CertificateAuthority(ca.Certificate, ca.Key); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath, "", err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateIngress(i *types.Ingress, hostname string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if i.DefaultCertificate != nil {
+		allErrs = append(allErrs, validateServingCertificate(i.DefaultCertificate, hostname, fldPath.Child("defaultCertificate"))...)
+	}
+	if i.Replicas != nil && *i.Replicas < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *i.Replicas, "replicas must be positive"))
+	}
+	for key, value := range i.NodeSelector {
+		for _, msg := range k8svalidation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nodeSelector"), key, msg))
+		}
+		for _, msg := range k8svalidation.IsValidLabelValue(value) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nodeSelector").Key(key), value, msg))
+		}
+	}
+	switch i.EndpointPublishingStrategy {
+	case "", types.LoadBalancerServiceStrategy, types.HostNetworkStrategy, types.PrivateStrategy:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("endpointPublishingStrategy"), i.EndpointPublishingStrategy, []string{string(types.LoadBalancerServiceStrategy), string(types.HostNetworkStrategy), string(types.PrivateStrategy)}))
+	}
+	return allErrs
+}
+
+// adminGroups are the groups which are bound to the cluster-admin role.
+var adminGroups = map[string]bool{
+	"system:cluster-admins": true,
+	"system:masters":        true,
+}
+
+func validateKubeconfigs(kubeconfigs []types.Kubeconfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := map[string]bool{}
+	for i, k := range kubeconfigs {
+		fldPath := fldPath.Index(i)
+		switch {
+		case k.Name == "kubelet":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), k.Name, "name is reserved for the kubelet kubeconfig"))
+		case names[k.Name]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), k.Name))
+		default:
+			if err := validate.ClusterName(k.Name); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), k.Name, err.Error()))
+			}
+		}
+		names[k.Name] = true
+		for j, group := range k.Groups {
+			if group == "" || adminGroups[group] {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("groups").Index(j), group, "groups must be non-empty and must not grant admin access"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateMachineConfigServer(mcs *types.MachineConfigServer, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if mcs.Host != "" && net.ParseIP(mcs.Host) == nil {
+		if err := validate.DomainName(mcs.Host); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("host"), mcs.Host, err.Error()))
+		}
+	}
+	if mcs.Port < 0 || mcs.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), mcs.Port, "port must be between 1 and 65535"))
+	}
+	return allErrs
+}
+
+func validateClusterVersionOverrides(overrides []types.ClusterVersionOverride, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[types.ClusterVersionOverride]bool{}
+	for i, o := range overrides {
+		fldPath := fldPath.Index(i)
+		if o.Kind == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("kind"), "kind is required"))
+		}
+		if o.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name is required"))
+		}
+		if seen[o] {
+			allErrs = append(allErrs, field.Duplicate(fldPath, o))
+		}
+		seen[o] = true
+	}
+	return allErrs
+}
+
+func validateIdentityProviders(idps []types.IdentityProvider, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := map[string]bool{}
+	for i, idp := range idps {
+		fldPath := fldPath.Index(i)
+		switch {
+		case idp.Name == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name is required"))
+		case strings.Contains(idp.Name, ":"):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), idp.Name, "name must not contain colons"))
+		case names[idp.Name]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), idp.Name))
+		}
+		names[idp.Name] = true
+
+		providers := 0
+		if idp.HTPasswd != nil {
+			providers++
+			if idp.HTPasswd.FileData == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("htpasswd", "fileData"), "fileData is required"))
+			}
+		}
+		if idp.LDAP != nil {
+			providers++
+			allErrs = append(allErrs, validateLDAPIdentityProvider(idp.LDAP, fldPath.Child("ldap"))...)
+		}
+		if idp.OpenID != nil {
+			providers++
+			allErrs = append(allErrs, validateOpenIDIdentityProvider(idp.OpenID, fldPath.Child("openID"))...)
+		}
+		if providers != 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath, idp.Name, "exactly one of htpasswd, ldap, and openID must be set"))
+		}
+	}
+	return allErrs
+}
+
+func validateLDAPIdentityProvider(ldap *types.LDAPIdentityProvider, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if u, err := url.Parse(ldap.URL); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), ldap.URL, err.Error()))
+	} else if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), ldap.URL, "scheme must be one of ldap, ldaps"))
+	} else if u.Scheme == "ldaps" && ldap.Insecure {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("insecure"), ldap.Insecure, "insecure cannot be used with ldaps URLs"))
+	}
+	if ldap.BindPassword != "" && ldap.BindDN == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("bindDN"), "bindDN is required with bindPassword"))
+	}
+	if ldap.CA != "" {
+		if err := validate.CABundle(ldap.CA); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ca"), "", err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateOpenIDIdentityProvider(openID *types.OpenIDIdentityProvider, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if openID.ClientID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clientID"), "clientID is required"))
+	}
+	if openID.ClientSecret == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clientSecret"), "clientSecret is required"))
+	}
+	for _, u := range []struct {
+		name     string
+		value    string
+		optional bool
+	}{
+		{name: "authorizeURL", value: openID.AuthorizeURL},
+		{name: "tokenURL", value: openID.TokenURL},
+		{name: "userInfoURL", value: openID.UserInfoURL, optional: true},
+	} {
+		if u.value == "" && u.optional {
+			continue
+		}
+		if parsed, err := url.Parse(u.value); err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(u.name), u.value, "must be an https URL"))
+		}
+	}
+	if openID.CA != "" {
+		if err := validate.CABundle(openID.CA); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ca"), "", err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// s3BucketName matches the S3 bucket names which are also valid in virtual
+// host-style URLs.
+var s3BucketName = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+func validateImageRegistry(r *types.ImageRegistry, platform string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath = fldPath.Child("storage")
+	storages := 0
+	if s3 := r.Storage.S3; s3 != nil {
+		storages++
+		if platform != aws.Name {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("s3"), platform, fmt.Sprintf("s3 storage is only supported on the %q platform", aws.Name)))
+		}
+		if !s3BucketName.MatchString(s3.Bucket) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("s3", "bucket"), s3.Bucket, "must be 3 to 63 lowercase letters, digits, dots, and hyphens, starting and ending with a letter or digit"))
+		}
+	}
+	if swift := r.Storage.Swift; swift != nil {
+		storages++
+		if platform != openstack.Name {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("swift"), platform, fmt.Sprintf("swift storage is only supported on the %q platform", openstack.Name)))
+		}
+		if swift.Container == "" || strings.Contains(swift.Container, "/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("swift", "container"), swift.Container, "must be a non-empty name without slashes"))
+		}
+	}
+	if r.Storage.EmptyDir != nil {
+		storages++
+	}
+	switch {
+	case r.Removed && storages > 0:
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "storage cannot be set when the registry is removed"))
+	case storages > 1:
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "at most one of s3, swift, and emptyDir may be set"))
+	}
+	return allErrs
+}
+
+func validateScheduler(s *types.Scheduler, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch s.Profile {
+	case "", types.LowNodeUtilizationSchedulerProfile, types.HighNodeUtilizationSchedulerProfile, types.NoScoringSchedulerProfile:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("profile"), s.Profile, []string{string(types.LowNodeUtilizationSchedulerProfile), string(types.HighNodeUtilizationSchedulerProfile), string(types.NoScoringSchedulerProfile)}))
+	}
+	if s.DefaultNodeSelector != "" {
+		if _, err := labels.Parse(s.DefaultNodeSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("defaultNodeSelector"), s.DefaultNodeSelector, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateProxy(p *types.Proxy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p.HTTPProxy == "" && p.HTTPSProxy == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "httpProxy or httpsProxy required"))
+	}
+	if p.HTTPProxy != "" {
+		if err := validateProxyURL(p.HTTPProxy, "http"); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("httpProxy"), p.HTTPProxy, err.Error()))
+		}
+	}
+	if p.HTTPSProxy != "" {
+		if err := validateProxyURL(p.HTTPSProxy, "http", "https"); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("httpsProxy"), p.HTTPSProxy, err.Error()))
+		}
+	}
+	if p.NoProxy != "" {
+		for _, v := range strings.Split(p.NoProxy, ",") {
+			v = strings.TrimSpace(v)
+			if v == "*" || net.ParseIP(v) != nil {
+				continue
+			}
+			if _, _, err := net.ParseCIDR(v); err == nil {
+				continue
+			}
+			if err := validate.DomainName(strings.TrimPrefix(v, ".")); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("noProxy"), v, "must be a domain name, an IP address or a CIDR"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateProxyURL(v string, schemes ...string) error {
+	u, err := url.Parse(v)
+	if err != nil {
+		return err
+	}
+	if !contains(schemes, u.Scheme) {
+		return errors.Errorf("scheme must be one of %s", strings.Join(schemes, ", "))
+	}
+	if u.Hostname() == "" {
+		return errors.New("host required")
+	}
+	if u.Path != "" && u.Path != "/" {
+		return errors.New("path not allowed")
+	}
+	return nil
+}
+
+func validateImageContentSources(sources []types.ImageContentSource, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	for i, source := range sources {
+		fldPath := fldPath.Index(i)
+		if seen[source.Source] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("source"), source.Source))
+		} else if err := validate.ImageRepository(source.Source); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("source"), source.Source, err.Error()))
+		}
+		seen[source.Source] = true
+		if len(source.Mirrors) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("mirrors"), "at least one mirror required"))
+		}
+		for j, mirror := range source.Mirrors {
+			if err := validate.ImageRepository(mirror); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("mirrors").Index(j), mirror, err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateBootstrapInPlace(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if platform := c.Platform.Name(); platform != none.Name {
+		allErrs = append(allErrs, field.Invalid(fldPath, platform, fmt.Sprintf("bootstrap in place is only supported on the %q platform", none.Name)))
+	}
+	if n := c.MasterCount(); n != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, n, "bootstrap in place requires exactly one master replica"))
+	}
+	for i, h := range c.Hosts {
+		if h.Role == "bootstrap" {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("hosts").Index(i).Child("role"), h.Role, "there is no bootstrap machine when bootstrapping in place"))
+		}
+	}
+	disk := c.BootstrapInPlace.InstallationDisk
+	if disk == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("installationDisk"), "installation disk required"))
+	} else if !strings.HasPrefix(disk, "/dev/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("installationDisk"), disk, "must be a device path under /dev/"))
+	}
+	return allErrs
+}
+
+var validHostRoles = []string{"bootstrap", "master", "worker"}
+
+func validateHosts(hosts []types.Host, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := map[string]bool{}
+	bootstrap := false
+	for i, h := range hosts {
+		fldPath := fldPath.Index(i)
+		if names[h.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), h.Name))
+		} else if err := validate.DomainName(h.Name); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), h.Name, err.Error()))
+		}
+		names[h.Name] = true
+		switch h.Role {
+		case "bootstrap":
+			if bootstrap {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("role"), h.Role, "only one host may be the bootstrap machine"))
+			}
+			bootstrap = true
+		case "master", "worker":
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("role"), h.Role, validHostRoles))
+		}
+		if h.NetworkConfig != nil {
+			allErrs = append(allErrs, validateNetworkConfig(h.NetworkConfig, fldPath.Child("networkConfig"))...)
+		}
+	}
+	return allErrs
+}
+
+var validBondModes = []string{"802.3ad", "active-backup", "balance-alb", "balance-rr", "balance-tlb", "balance-xor", "broadcast"}
+
+func validateNetworkConfig(n *types.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(n.Interfaces) == 0 && len(n.Bonds) == 0 && len(n.VLANs) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("interfaces"), "at least one interface, bond or VLAN required"))
+	}
+
+	// matches are the names and MAC addresses of the configured physical
+	// interfaces, and devices are the names of all configured interfaces.
+	matches := map[string]bool{}
+	devices := map[string]bool{}
+	for i, iface := range n.Interfaces {
+		fldPath := fldPath.Child("interfaces").Index(i)
+		match := iface.Name
+		switch {
+		case iface.Name == "" && iface.MACAddress == "":
+			allErrs = append(allErrs, field.Required(fldPath, "either name or macAddress required"))
+		case iface.Name != "" && iface.MACAddress != "":
+			allErrs = append(allErrs, field.Invalid(fldPath, iface.Name, "only one of name and macAddress may be set"))
+		case iface.MACAddress != "":
+			mac, err := net.ParseMAC(iface.MACAddress)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("macAddress"), iface.MACAddress, err.Error()))
+				break
+			}
+			match = mac.String()
+		}
+		if match != "" {
+			if matches[match] {
+				allErrs = append(allErrs, field.Duplicate(fldPath, match))
+			}
+			matches[match] = true
+		}
+		if iface.Name != "" {
+			devices[iface.Name] = true
+		}
+		allErrs = append(allErrs, validateNetworkAddresses(&iface.NetworkAddresses, true, fldPath)...)
+	}
+
+	members := map[string]bool{}
+	for i, bond := range n.Bonds {
+		fldPath := fldPath.Child("bonds").Index(i)
+		switch {
+		case bond.Name == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name required"))
+		case devices[bond.Name] || matches[bond.Name]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), bond.Name))
+		}
+		devices[bond.Name] = true
+		if bond.Mode != "" && !contains(validBondModes, bond.Mode) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), bond.Mode, validBondModes))
+		}
+		if len(bond.Interfaces) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("interfaces"), "at least one interface required"))
+		}
+		for j, member := range bond.Interfaces {
+			if mac, err := net.ParseMAC(member); err == nil {
+				member = mac.String()
+			}
+			if matches[member] || members[member] || devices[member] {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("interfaces").Index(j), bond.Interfaces[j], "interface is configured already"))
+			}
+			members[member] = true
+		}
+		allErrs = append(allErrs, validateNetworkAddresses(&bond.NetworkAddresses, false, fldPath)...)
+	}
+
+	for i, vlan := range n.VLANs {
+		fldPath := fldPath.Child("vlans").Index(i)
+		if vlan.ID < 1 || vlan.ID > 4094 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), vlan.ID, "must be between 1 and 4094"))
+		}
+		switch {
+		case vlan.Interface == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("interface"), "interface required"))
+		case members[vlan.Interface]:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("interface"), vlan.Interface, "interface is in a bond; use the bond instead"))
+		}
+		name := vlan.DeviceName()
+		if devices[name] || matches[name] || members[name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), name))
+		}
+		devices[name] = true
+		allErrs = append(allErrs, validateNetworkAddresses(&vlan.NetworkAddresses, true, fldPath)...)
+	}
+	return allErrs
+}
+
+func validateNetworkAddresses(a *types.NetworkAddresses, required bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if required && len(a.Addresses) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("addresses"), "at least one address required"))
+	}
+	for j, address := range a.Addresses {
+		ones, bits := address.Mask.Size()
+		if address.IP.IsUnspecified() || (ones < bits && address.IP.Equal(address.IP.Mask(address.Mask))) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("addresses").Index(j), address.String(), "must be a host address, not a network"))
+		}
+	}
+	if a.Gateway != nil {
+		reachable := false
+		for _, address := range a.Addresses {
+			if address.Contains(a.Gateway) {
+				reachable = true
+			}
+		}
+		if !reachable {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("gateway"), a.Gateway.String(), "gateway must be in the subnet of one of the addresses"))
+		}
+	}
+	return allErrs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func validateServingCertificate(c *types.ServingCertificate, hostname string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case c.Certificate == "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("certificate"), "certificate required"))
+	case c.Key == "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), "key required"))
+	default:
+		// The value is left out, because it holds the private key.
+		if err := validate.ServingCertificate(c.Certificate, c.Key, hostname); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, "", err.Error()))
+		}
+	}
+	return allErrs
+}
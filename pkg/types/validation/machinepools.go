@@ -1,9 +1,15 @@
 package validation
 
 import (
+	"encoding/json"
 	"fmt"
+	"path"
+	"regexp"
 	"sort"
+	"strings"
+	"time"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types"
@@ -11,16 +17,36 @@ import (
 	awsvalidation "github.com/openshift/installer/pkg/types/aws/validation"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	libvirtvalidation "github.com/openshift/installer/pkg/types/libvirt/validation"
+	"github.com/openshift/installer/pkg/types/none"
 	"github.com/openshift/installer/pkg/types/openstack"
 	openstackvalidation "github.com/openshift/installer/pkg/types/openstack/validation"
+	"github.com/openshift/installer/pkg/validate"
 )
 
 var (
+	// validMountPath matches the mount paths of additional partitions,
+	// which are the /var directory and its subdirectories. Their systemd
+	// mount units are named after them, so they need no escaping.
+	validMountPath = regexp.MustCompile(`^/var(/[a-zA-Z0-9_]+)*$`)
+
+	// validNameTemplate matches the machineset name templates, which are
+	// DNS labels once their placeholders are replaced.
+	validNameTemplate = regexp.MustCompile(`^([a-z0-9-]|\{(clusterName|role|zone)\})+$`)
+
 	validMachinePoolNames = map[string]bool{
+		"infra":  true,
 		"master": true,
 		"worker": true,
 	}
 
+	validConditionStatuses = map[string]bool{
+		"True":    true,
+		"False":   true,
+		"Unknown": true,
+	}
+
+	validConditionStatusValues = []string{"False", "True", "Unknown"}
+
 	validMachinePoolNameValues = func() []string {
 		validValues := make([]string, len(validMachinePoolNames))
 		i := 0
@@ -45,6 +71,198 @@ func ValidateMachinePool(p *types.MachinePool, fldPath *field.Path, platform str
 		}
 	}
 	allErrs = append(allErrs, validateMachinePoolPlatform(&p.Platform, fldPath.Child("platform"), platform)...)
+	if p.Name == "master" && p.Platform.AWS != nil && p.Platform.AWS.Subnet != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("platform", "aws", "subnet"), "the masters are always placed in the public subnets"))
+	}
+	if p.Name == "master" {
+		if p.Platform.AWS != nil && p.Platform.AWS.OSImage != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("platform", "aws", "osImage"), "the masters boot the osImage of the platform"))
+		}
+		if p.Platform.OpenStack != nil && p.Platform.OpenStack.OSImage != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("platform", "openstack", "osImage"), "the masters boot the osImage of the platform"))
+		}
+	}
+	if p.Name == "infra" {
+		allErrs = append(allErrs, validateInfraMachinePool(p, fldPath, platform)...)
+	}
+	if p.UserProvisioned {
+		allErrs = append(allErrs, validateUserProvisionedMachinePool(p, fldPath)...)
+	}
+	if p.Storage != nil {
+		allErrs = append(allErrs, validateMachinePoolStorage(p.Storage, fldPath.Child("storage"))...)
+	}
+	for i, key := range p.SSHKeys {
+		if err := validate.SSHPublicKey(key); err == validate.ErrPrivateSSHKey {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sshKeys").Index(i), "<redacted>", err.Error()))
+		} else if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sshKeys").Index(i), key, err.Error()))
+		}
+	}
+	if p.PasswordHash != "" {
+		if err := validate.PasswordHash(p.PasswordHash); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("passwordHash"), "<redacted>", err.Error()))
+		}
+	}
+	if p.Autoscaling != nil {
+		allErrs = append(allErrs, validateMachinePoolAutoscaling(p, fldPath, platform)...)
+	}
+	if p.HealthCheck != nil {
+		allErrs = append(allErrs, validateMachinePoolHealthCheck(p, fldPath.Child("healthCheck"), platform)...)
+	}
+	if p.ProviderSpecPatch != nil {
+		allErrs = append(allErrs, validateProviderSpecPatch(p.ProviderSpecPatch, fldPath.Child("providerSpecPatch"), platform)...)
+	}
+	if p.NameTemplate != "" {
+		allErrs = append(allErrs, validateNameTemplate(p, fldPath.Child("nameTemplate"), platform)...)
+	}
+	return allErrs
+}
+
+// validateInfraMachinePool checks the infra pool for the options which its
+// machines, booting with the worker Ignition config and instance profile,
+// take from the worker pool instead.
+func validateInfraMachinePool(p *types.MachinePool, fldPath *field.Path, platform string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if platform == none.Name && !p.UserProvisioned {
+		allErrs = append(allErrs, field.Required(fldPath.Child("userProvisioned"), fmt.Sprintf("infra pools must be user-provisioned on platform %q", platform)))
+	}
+	if p.Storage != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("storage"), "the infra machines use the storage of the worker pool"))
+	}
+	if len(p.SSHKeys) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sshKeys"), "the infra machines use the SSH keys of the worker pool"))
+	}
+	if p.PasswordHash != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("passwordHash"), "the infra machines use the password of the worker pool"))
+	}
+	if p.Platform.AWS != nil && p.Platform.AWS.IAMRoleName != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("platform", "aws", "iamRoleName"), "the infra machines use the instance profile of the worker pool"))
+	}
+	return allErrs
+}
+
+func validateUserProvisionedMachinePool(p *types.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p.Name == "master" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("userProvisioned"), "the masters are always provisioned by the installer"))
+	}
+	if p.Autoscaling != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("autoscaling"), "user-provisioned machines cannot be autoscaled"))
+	}
+	if p.HealthCheck != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("healthCheck"), "user-provisioned machines cannot be health checked"))
+	}
+	return allErrs
+}
+
+func validateNameTemplate(p *types.MachinePool, fldPath *field.Path, platform string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p.Name != "worker" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "name templates are only supported for the worker pool"))
+	}
+	if platform == none.Name {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("name templates are not supported on platform %q", platform)))
+	}
+	if !validNameTemplate.MatchString(p.NameTemplate) {
+		allErrs = append(allErrs, field.Invalid(fldPath, p.NameTemplate, "name template must consist of lowercase alphanumeric characters, '-' and the {clusterName}, {role} and {zone} placeholders"))
+	}
+	return allErrs
+}
+
+func validateProviderSpecPatch(p *types.ProviderSpecPatch, fldPath *field.Path, platform string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if platform == none.Name {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("providerSpec patches are not supported on platform %q", platform)))
+	}
+	switch p.Type {
+	case "", types.MergePatchType:
+		var patch map[string]interface{}
+		if err := json.Unmarshal(p.Patch, &patch); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("patch"), string(p.Patch), "merge patch must be an object"))
+		}
+	case types.JSONPatchType:
+		if _, err := jsonpatch.DecodePatch(p.Patch); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("patch"), string(p.Patch), err.Error()))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), p.Type, []string{string(types.JSONPatchType), string(types.MergePatchType)}))
+	}
+	return allErrs
+}
+
+func validateMachinePoolHealthCheck(p *types.MachinePool, fldPath *field.Path, platform string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p.Name == "master" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "health checks are only supported for compute pools"))
+	}
+	if platform == none.Name {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("health checks are not supported on platform %q", platform)))
+	}
+	for i, c := range p.HealthCheck.UnhealthyConditions {
+		fldPath := fldPath.Child("unhealthyConditions").Index(i)
+		if c.Type == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("type"), "condition type is required"))
+		}
+		if !validConditionStatuses[c.Status] {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("status"), c.Status, validConditionStatusValues))
+		}
+		if timeout, err := time.ParseDuration(c.Timeout); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), c.Timeout, err.Error()))
+		} else if timeout <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), c.Timeout, "timeout must be positive"))
+		}
+	}
+	return allErrs
+}
+
+func validateMachinePoolAutoscaling(p *types.MachinePool, poolPath *field.Path, platform string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := poolPath.Child("autoscaling")
+	if p.Name != "worker" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "autoscaling is only supported for the worker pool"))
+	}
+	if platform == none.Name {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("autoscaling is not supported on platform %q", platform)))
+	}
+	a := p.Autoscaling
+	if a.MinReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), a.MinReplicas, "minimum number of replicas must not be negative"))
+	}
+	if a.MaxReplicas <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicas"), a.MaxReplicas, "maximum number of replicas must be positive"))
+	} else if a.MaxReplicas < a.MinReplicas {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicas"), a.MaxReplicas, "maximum number of replicas must not be less than the minimum"))
+	}
+	if p.Replicas != nil && (*p.Replicas < a.MinReplicas || *p.Replicas > a.MaxReplicas) {
+		allErrs = append(allErrs, field.Invalid(poolPath.Child("replicas"), *p.Replicas, "number of replicas must be within the autoscaling range"))
+	}
+	return allErrs
+}
+
+func validateMachinePoolStorage(s *types.MachinePoolStorage, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	mountPaths := map[string]bool{}
+	for i, p := range s.Partitions {
+		fldPath := fldPath.Child("partitions").Index(i)
+		if !strings.HasPrefix(p.Device, "/dev/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("device"), p.Device, "device must be under /dev/"))
+		}
+		switch {
+		case !path.IsAbs(p.MountPath) || path.Clean(p.MountPath) != p.MountPath:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mountPath"), p.MountPath, "mount path must be an absolute, clean path"))
+		case !validMountPath.MatchString(p.MountPath):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mountPath"), p.MountPath, "mount path must be a subdirectory of /var of alphanumeric and underscore characters"))
+		case mountPaths[p.MountPath]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("mountPath"), p.MountPath))
+		}
+		mountPaths[p.MountPath] = true
+		if p.StartMiB < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("startMiB"), p.StartMiB, "start must not be negative"))
+		}
+		if p.SizeMiB < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sizeMiB"), p.SizeMiB, "size must not be negative"))
+		}
+	}
 	return allErrs
 }
 
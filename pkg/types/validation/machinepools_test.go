@@ -70,6 +70,28 @@ func TestValidateMachinePool(t *testing.T) {
 			platform: "aws",
 			valid:    false,
 		},
+		{
+			name: "worker OS image",
+			pool: &types.MachinePool{
+				Name: "worker",
+				Platform: types.MachinePoolPlatform{
+					AWS: &aws.MachinePool{OSImage: "ami-0123456789abcdef0"},
+				},
+			},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "master OS image",
+			pool: &types.MachinePool{
+				Name: "master",
+				Platform: types.MachinePoolPlatform{
+					AWS: &aws.MachinePool{OSImage: "ami-0123456789abcdef0"},
+				},
+			},
+			platform: "aws",
+			valid:    false,
+		},
 		{
 			name: "valid libvirt",
 			pool: &types.MachinePool{
@@ -115,6 +137,325 @@ func TestValidateMachinePool(t *testing.T) {
 			platform: "aws",
 			valid:    false,
 		},
+		{
+			name: "worker subnet",
+			pool: &types.MachinePool{
+				Name: "worker",
+				Platform: types.MachinePoolPlatform{
+					AWS: &aws.MachinePool{Subnet: aws.PublicSubnet},
+				},
+			},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "master subnet",
+			pool: &types.MachinePool{
+				Name: "master",
+				Platform: types.MachinePoolPlatform{
+					AWS: &aws.MachinePool{Subnet: aws.PrivateSubnet},
+				},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "valid name template",
+			pool: &types.MachinePool{
+				Name:         "worker",
+				NameTemplate: "ocp-{role}-{zone}",
+			},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "invalid name template",
+			pool: &types.MachinePool{
+				Name:         "worker",
+				NameTemplate: "{clusterName}_{index}",
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "master name template",
+			pool: &types.MachinePool{
+				Name:         "master",
+				NameTemplate: "{clusterName}-{role}",
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "infra",
+			pool: &types.MachinePool{
+				Name: "infra",
+				Platform: types.MachinePoolPlatform{
+					AWS: &aws.MachinePool{InstanceType: "m5.xlarge"},
+				},
+			},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "infra with SSH keys",
+			pool: &types.MachinePool{
+				Name:    "infra",
+				SSHKeys: []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIE4H0sh3tJQuEYdoDlR+XXGDvDB/nAvQUwEVJ8OOhRfR ops@example.com"},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name:     "infra on none",
+			pool:     &types.MachinePool{Name: "infra"},
+			platform: "none",
+			valid:    false,
+		},
+		{
+			name:     "user-provisioned infra on none",
+			pool:     &types.MachinePool{Name: "infra", UserProvisioned: true},
+			platform: "none",
+			valid:    true,
+		},
+		{
+			name:     "user-provisioned workers",
+			pool:     &types.MachinePool{Name: "worker", UserProvisioned: true},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name:     "user-provisioned masters",
+			pool:     &types.MachinePool{Name: "master", UserProvisioned: true},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "autoscaled user-provisioned workers",
+			pool: &types.MachinePool{
+				Name:            "worker",
+				UserProvisioned: true,
+				Autoscaling:     &types.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 3},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "valid storage",
+			pool: &types.MachinePool{
+				Name: "worker",
+				Storage: &types.MachinePoolStorage{
+					Partitions: []types.Partition{
+						{Device: "/dev/sdb", MountPath: "/var/lib/containers"},
+						{Device: "/dev/sda", MountPath: "/var/log", StartMiB: 25000, SizeMiB: 10000},
+					},
+					Multipath: true,
+				},
+			},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "invalid partition device",
+			pool: &types.MachinePool{
+				Name: "worker",
+				Storage: &types.MachinePoolStorage{
+					Partitions: []types.Partition{{Device: "sdb", MountPath: "/var"}},
+				},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "partition mounted outside of /var",
+			pool: &types.MachinePool{
+				Name: "worker",
+				Storage: &types.MachinePoolStorage{
+					Partitions: []types.Partition{{Device: "/dev/sdb", MountPath: "/opt"}},
+				},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "duplicate partition mount paths",
+			pool: &types.MachinePool{
+				Name: "worker",
+				Storage: &types.MachinePoolStorage{
+					Partitions: []types.Partition{
+						{Device: "/dev/sdb", MountPath: "/var/lib/containers"},
+						{Device: "/dev/sdc", MountPath: "/var/lib/containers"},
+					},
+				},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "valid SSH keys and password hash",
+			pool: &types.MachinePool{
+				Name:         "master",
+				SSHKeys:      []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIE4H0sh3tJQuEYdoDlR+XXGDvDB/nAvQUwEVJ8OOhRfR ops@example.com"},
+				PasswordHash: "$6$saltsalt$8HLKCpH5ycA6sXVNyVZ6euq5v2Rfd2VXFHoXzbOFsp9KsOP5I5B8ny.WHpJkqGFUOzDQ.iAAzRgHYJat6w1ID1",
+			},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "invalid SSH key",
+			pool: &types.MachinePool{
+				Name:    "master",
+				SSHKeys: []string{"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAACAQDxL"},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "plain password",
+			pool: &types.MachinePool{
+				Name:         "master",
+				PasswordHash: "password",
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "negative partition size",
+			pool: &types.MachinePool{
+				Name: "worker",
+				Storage: &types.MachinePoolStorage{
+					Partitions: []types.Partition{{Device: "/dev/sdb", MountPath: "/var", SizeMiB: -1}},
+				},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "valid autoscaling",
+			pool: &types.MachinePool{
+				Name:        "worker",
+				Replicas:    func(x int64) *int64 { return &x }(3),
+				Autoscaling: &types.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 12},
+			},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "autoscaling masters",
+			pool: &types.MachinePool{
+				Name:        "master",
+				Autoscaling: &types.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 5},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "autoscaling without machinesets",
+			pool: &types.MachinePool{
+				Name:        "worker",
+				Autoscaling: &types.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 5},
+			},
+			platform: "none",
+			valid:    false,
+		},
+		{
+			name: "autoscaling maximum below minimum",
+			pool: &types.MachinePool{
+				Name:        "worker",
+				Autoscaling: &types.MachinePoolAutoscaling{MinReplicas: 5, MaxReplicas: 3},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "valid health check",
+			pool: &types.MachinePool{
+				Name: "worker",
+				HealthCheck: &types.MachinePoolHealthCheck{
+					UnhealthyConditions: []types.UnhealthyCondition{{Type: "Ready", Status: "Unknown", Timeout: "10m"}},
+				},
+			},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "health check masters",
+			pool: &types.MachinePool{
+				Name:        "master",
+				HealthCheck: &types.MachinePoolHealthCheck{},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "invalid unhealthy condition",
+			pool: &types.MachinePool{
+				Name: "worker",
+				HealthCheck: &types.MachinePoolHealthCheck{
+					UnhealthyConditions: []types.UnhealthyCondition{{Type: "Ready", Status: "false", Timeout: "300"}},
+				},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "health check infra",
+			pool: &types.MachinePool{
+				Name:        "infra",
+				HealthCheck: &types.MachinePoolHealthCheck{},
+			},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "valid merge patch",
+			pool: &types.MachinePool{
+				Name:              "worker",
+				ProviderSpecPatch: &types.ProviderSpecPatch{Patch: []byte(`{"spotMarketOptions":{}}`)},
+			},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "valid JSON patch",
+			pool: &types.MachinePool{
+				Name: "worker",
+				ProviderSpecPatch: &types.ProviderSpecPatch{
+					Type:  types.JSONPatchType,
+					Patch: []byte(`[{"op":"add","path":"/tenancy","value":"dedicated"}]`),
+				},
+			},
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "merge patch which is not an object",
+			pool: &types.MachinePool{
+				Name:              "worker",
+				ProviderSpecPatch: &types.ProviderSpecPatch{Patch: []byte(`[{"op":"add","path":"/tenancy","value":"dedicated"}]`)},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "invalid patch type",
+			pool: &types.MachinePool{
+				Name:              "worker",
+				ProviderSpecPatch: &types.ProviderSpecPatch{Type: "strategic", Patch: []byte(`{}`)},
+			},
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "replicas outside autoscaling range",
+			pool: &types.MachinePool{
+				Name:        "worker",
+				Replicas:    func(x int64) *int64 { return &x }(1),
+				Autoscaling: &types.MachinePoolAutoscaling{MinReplicas: 2, MaxReplicas: 5},
+			},
+			platform: "aws",
+			valid:    false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,240 @@
+package validate
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// manifestMediaTypes are the media types of the image manifests a
+// registry is asked for, which include the manifest lists of release
+// payloads.
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+}
+
+// PullSecretGrantsAccess checks that the pull secret grants access to the
+// image, such as quay.io/openshift-release-dev/ocp-release:4.1.0, by
+// requesting its manifest from its registry with the credentials of the
+// registry in the pull secret, and returns an error if not.
+func PullSecretGrantsAccess(ctx context.Context, secret string, image string) error {
+	return pullSecretGrantsAccess(ctx, http.DefaultClient, secret, image)
+}
+
+// PullSecretGrantsRepositoryAccess checks that the pull secret grants
+// access to the repository, such as a mirror of the release payload, by
+// listing its tags, and returns an error if not. Mirrors are only pulled
+// from by digest, so they may not have the tag of the release image.
+func PullSecretGrantsRepositoryAccess(ctx context.Context, secret string, repository string) error {
+	return pullSecretGrantsRepositoryAccess(ctx, http.DefaultClient, secret, repository)
+}
+
+// RegistryReachable checks that the registry of the image, or of the
+// repository, responds to the base endpoint of its API through the proxy,
+// such as http://proxy.example.com:3128, and returns an error if not. The
+// registry may respond with any status, since no credentials are sent.
+func RegistryReachable(ctx context.Context, proxy string, image string) error {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return fmt.Errorf("invalid proxy: %v", err)
+	}
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+	return registryReachable(ctx, client, image)
+}
+
+func registryReachable(ctx context.Context, client *http.Client, image string) error {
+	host, _, _, err := splitImage(image)
+	if err != nil {
+		return err
+	}
+	_, err = registryRequest(ctx, client, http.MethodGet, fmt.Sprintf("https://%s/v2/", host), "")
+	return err
+}
+
+func pullSecretGrantsAccess(ctx context.Context, client *http.Client, secret string, image string) error {
+	host, repository, reference, err := splitImage(image)
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repository, reference)
+	return checkRegistryAccess(ctx, client, secret, host, repository, http.MethodHead, endpoint)
+}
+
+func pullSecretGrantsRepositoryAccess(ctx context.Context, client *http.Client, secret string, repository string) error {
+	if err := ImageRepository(repository); err != nil {
+		return err
+	}
+	parts := strings.SplitN(repository, "/", 2)
+	endpoint := fmt.Sprintf("https://%s/v2/%s/tags/list", parts[0], parts[1])
+	return checkRegistryAccess(ctx, client, secret, parts[0], parts[1], http.MethodGet, endpoint)
+}
+
+// splitImage splits an image into its registry host, its repository path
+// and its tag or digest, which defaults to the latest tag.
+func splitImage(image string) (host, repository, reference string, err error) {
+	name := image
+	reference = "latest"
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, reference = name[:i], name[i+1:]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, reference = name[:i], name[i+1:]
+	}
+	if err := ImageRepository(name); err != nil {
+		return "", "", "", fmt.Errorf("invalid image %q: %v", image, err)
+	}
+	parts := strings.SplitN(name, "/", 2)
+	return parts[0], parts[1], reference, nil
+}
+
+// checkRegistryAccess sends the request, authenticating with the
+// credentials of the host in the pull secret as the registry asks: with
+// basic authentication, or with a bearer token from its token service.
+func checkRegistryAccess(ctx context.Context, client *http.Client, secret string, host string, repository string, method string, endpoint string) error {
+	username, password, err := registryCredentials(secret, host)
+	if err != nil {
+		return err
+	}
+
+	response, err := registryRequest(ctx, client, method, endpoint, "")
+	if err != nil {
+		return err
+	}
+	if response.StatusCode == http.StatusUnauthorized {
+		challenge := response.Header.Get("WWW-Authenticate")
+		var authorization string
+		switch {
+		case strings.HasPrefix(strings.ToLower(challenge), "basic"):
+			authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+		case strings.HasPrefix(strings.ToLower(challenge), "bearer"):
+			token, err := registryToken(ctx, client, challenge, repository, username, password)
+			if err != nil {
+				return err
+			}
+			authorization = "Bearer " + token
+		default:
+			return fmt.Errorf("%s asked for unsupported authentication %q", host, challenge)
+		}
+		response, err = registryRequest(ctx, client, method, endpoint, authorization)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("the pull secret does not grant access to %s/%s", host, repository)
+	case http.StatusNotFound:
+		return fmt.Errorf("%s/%s was not found, or the pull secret does not grant access to it", host, repository)
+	default:
+		return fmt.Errorf("%s responded with %s", host, response.Status)
+	}
+}
+
+func registryRequest(ctx context.Context, client *http.Client, method string, endpoint string, authorization string) (*http.Response, error) {
+	request, err := http.NewRequest(method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+	return response, nil
+}
+
+// registryCredentials returns the credentials of the host in the pull
+// secret.
+func registryCredentials(secret string, host string) (username, password string, err error) {
+	var s struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal([]byte(secret), &s); err != nil {
+		return "", "", err
+	}
+	auth, ok := s.Auths[host]
+	if !ok || auth.Auth == "" {
+		return "", "", fmt.Errorf("the pull secret has no credentials for %s", host)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid credentials for %s: %v", host, err)
+	}
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid credentials for %s: must be a username and password separated by a colon", host)
+	}
+	return parts[0], parts[1], nil
+}
+
+// registryToken returns a token to pull from the repository from the token
+// service in the bearer challenge of a registry.
+func registryToken(ctx context.Context, client *http.Client, challenge string, repository string, username string, password string) (string, error) {
+	params := map[string]string{}
+	for _, param := range strings.Split(challenge[len("bearer"):], ",") {
+		parts := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(parts) == 2 {
+			params[strings.ToLower(parts[0])] = strings.Trim(parts[1], `"`)
+		}
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme != "https" {
+		return "", fmt.Errorf("invalid token service %q", params["realm"])
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", repository))
+	realm.RawQuery = query.Encode()
+
+	request, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	request = request.WithContext(ctx)
+	request.SetBasicAuth(username, password)
+	response, err := client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		return "", errors.New("the token service rejected the credentials in the pull secret")
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("the token service responded with %s", response.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode the token: %v", err)
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", errors.New("the token service returned no token")
+}
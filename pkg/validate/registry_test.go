@@ -0,0 +1,135 @@
+package validate
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRegistry returns a registry which serves the manifest of
+// release:latest, and the tags of release, to the user "user" with the
+// password "pass", with a bearer token if bearer is set and with basic
+// authentication otherwise.
+func newTestRegistry(bearer bool) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if user, pass, _ := r.BasicAuth(); user != "user" || pass != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Query().Get("scope") != "repository:ocp/release:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `{"token": "secret-token"}`)
+			return
+		}
+
+		authorized := false
+		if bearer {
+			authorized = r.Header.Get("Authorization") == "Bearer secret-token"
+		} else {
+			user, pass, _ := r.BasicAuth()
+			authorized = user == "user" && pass == "pass"
+		}
+		if !authorized {
+			if bearer {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test-registry"`, server.URL))
+			} else {
+				w.Header().Set("WWW-Authenticate", `Basic realm="test-registry"`)
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/ocp/release/manifests/latest", "/v2/ocp/release/tags/list":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func testPullSecret(host, credentials string) string {
+	return fmt.Sprintf(`{"auths": {%q: {"auth": %q}}}`, host, base64.StdEncoding.EncodeToString([]byte(credentials)))
+}
+
+func TestPullSecretGrantsAccess(t *testing.T) {
+	for _, bearer := range []bool{true, false} {
+		t.Run(fmt.Sprintf("bearer=%t", bearer), func(t *testing.T) {
+			server := newTestRegistry(bearer)
+			defer server.Close()
+			host := strings.TrimPrefix(server.URL, "https://")
+
+			cases := []struct {
+				name   string
+				secret string
+				image  string
+				err    string
+			}{
+				{
+					name:   "valid",
+					secret: testPullSecret(host, "user:pass"),
+					image:  host + "/ocp/release:latest",
+				},
+				{
+					name:   "default tag",
+					secret: testPullSecret(host, "user:pass"),
+					image:  host + "/ocp/release",
+				},
+				{
+					name:   "wrong password",
+					secret: testPullSecret(host, "user:wrong"),
+					image:  host + "/ocp/release:latest",
+					err:    "(the pull secret does not grant access to|the token service rejected)",
+				},
+				{
+					name:   "other registry",
+					secret: testPullSecret("quay.io", "user:pass"),
+					image:  host + "/ocp/release:latest",
+					err:    "the pull secret has no credentials for " + host,
+				},
+				{
+					name:   "missing image",
+					secret: testPullSecret(host, "user:pass"),
+					image:  host + "/ocp/release:missing",
+					err:    "was not found",
+				},
+			}
+			for _, tc := range cases {
+				t.Run(tc.name, func(t *testing.T) {
+					err := pullSecretGrantsAccess(context.Background(), server.Client(), tc.secret, tc.image)
+					if tc.err == "" {
+						assert.NoError(t, err)
+					} else {
+						assert.Regexp(t, tc.err, err)
+					}
+				})
+			}
+
+			err := pullSecretGrantsRepositoryAccess(context.Background(), server.Client(), testPullSecret(host, "user:pass"), host+"/ocp/release")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRegistryReachable(t *testing.T) {
+	server := newTestRegistry(true)
+	host := strings.TrimPrefix(server.URL, "https://")
+	client := server.Client()
+
+	assert.NoError(t, registryReachable(context.Background(), client, host+"/ocp/release:latest"))
+	assert.NoError(t, registryReachable(context.Background(), client, host+"/ocp/release"))
+
+	server.Close()
+	assert.Error(t, registryReachable(context.Background(), client, host+"/ocp/release:latest"))
+}
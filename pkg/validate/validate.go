@@ -2,12 +2,17 @@
 package validate
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net"
 	"net/url"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	netopv1 "github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1"
@@ -22,6 +27,26 @@ var (
 		return cidr
 	}()
 
+	// reservedCIDRs are the ranges which the networks of the cluster
+	// must not use, because the hosts reserve them for themselves.
+	reservedCIDRs = []struct {
+		name string
+		cidr *net.IPNet
+	}{
+		{name: "the link-local range, which includes the cloud metadata service at 169.254.169.254", cidr: mustParseCIDR("169.254.0.0/16")},
+		{name: "the loopback range", cidr: mustParseCIDR("127.0.0.0/8")},
+		{name: "the multicast range", cidr: mustParseCIDR("224.0.0.0/4")},
+	}
+
+	// imagePathComponent is a component of the path of an image
+	// repository, as defined by the Docker distribution reference grammar.
+	imagePathComponent = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*$`)
+
+	// passwordHash is a crypt(3) password hash with the MD5, bcrypt,
+	// SHA-256, SHA-512 or yescrypt method, such as generated by
+	// "openssl passwd -6".
+	passwordHash = regexp.MustCompile(`^\$(1|2[abxy]|5|6|y)\$([^$:\s]+\$)+[./0-9A-Za-z]+$`)
+
 	// ValidNetworkTypes is a collection of the valid network types.
 	ValidNetworkTypes = map[netopv1.NetworkType]bool{
 		netopv1.NetworkTypeOpenshiftSDN:  true,
@@ -93,6 +118,22 @@ func ClusterName(v string) error {
 	return validateSubdomain(v)
 }
 
+// DNSLabel checks if the given string is a valid RFC 1123 DNS label, such
+// as a cluster name which prefixes the DNS names of the cluster, and
+// returns an error if not.
+func DNSLabel(v string) error {
+	validationMessages := validation.IsDNS1123Label(v)
+	if len(validationMessages) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(validationMessages))
+	for i, m := range validationMessages {
+		errs[i] = errors.New(m)
+	}
+	return k8serrors.NewAggregate(errs)
+}
+
 // SubnetCIDR checks if the given IP net is a valid CIDR for a master nodes or worker nodes subnet and returns an error if not.
 func SubnetCIDR(cidr *net.IPNet) error {
 	if cidr.IP.To4() == nil {
@@ -104,19 +145,95 @@ func SubnetCIDR(cidr *net.IPNet) error {
 	if DoCIDRsOverlap(cidr, dockerBridgeCIDR) {
 		return fmt.Errorf("overlaps with default Docker Bridge subnet (%v)", cidr.String())
 	}
+	return ReservedCIDR(cidr)
+}
+
+// ReservedCIDR checks that the CIDR does not overlap with the ranges which
+// the hosts reserve, such as the link-local range of the cloud metadata
+// service, and returns an error if it does.
+func ReservedCIDR(cidr *net.IPNet) error {
+	for _, reserved := range reservedCIDRs {
+		if DoCIDRsOverlap(cidr, reserved.cidr) {
+			return fmt.Errorf("overlaps with %s (%s)", reserved.name, reserved.cidr)
+		}
+	}
 	return nil
 }
 
+func mustParseCIDR(s string) *net.IPNet {
+	_, cidr, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return cidr
+}
+
 // DoCIDRsOverlap returns true if one of the CIDRs is a subset of the other.
 func DoCIDRsOverlap(acidr, bcidr *net.IPNet) bool {
 	return acidr.Contains(bcidr.IP) || bcidr.Contains(acidr.IP)
 }
 
-// SSHPublicKey checks if the given string is a valid SSH public key
-// and returns an error if not.
+// ErrPrivateSSHKey is returned by SSHPublicKey and SSHPublicKeys for
+// private keys pasted instead of their public keys, which callers must not
+// log.
+var ErrPrivateSSHKey = errors.New("is a private key; use its public key instead, such as the contents of the .pub file next to it")
+
+// SSHPublicKey checks if the given string is a single valid SSH public key
+// and returns an error if not. DSA keys are rejected, because the OpenSSH
+// of the machines does not accept them.
 func SSHPublicKey(v string) error {
-	_, _, _, _, err := ssh.ParseAuthorizedKey([]byte(v))
-	return err
+	if strings.Contains(v, "PRIVATE KEY-----") {
+		return ErrPrivateSSHKey
+	}
+	key, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(v))
+	if err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(string(rest))) > 0 {
+		return errors.New("must be a single key")
+	}
+	if key.Type() == ssh.KeyAlgoDSA {
+		return fmt.Errorf("%s keys are not accepted by the machines; use an ed25519, ECDSA or RSA key", key.Type())
+	}
+	return nil
+}
+
+// SSHPublicKeys checks if the given string holds one or more valid SSH
+// public keys, one per line as in an authorized_keys file, and returns an
+// error naming each invalid key by its position if not. Blank lines and
+// comments are skipped.
+func SSHPublicKeys(v string) error {
+	if strings.Contains(v, "PRIVATE KEY-----") {
+		return ErrPrivateSSHKey
+	}
+	var keys []string
+	for _, line := range strings.Split(v, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			keys = append(keys, line)
+		}
+	}
+	if len(keys) == 0 {
+		return errors.New("must contain a key")
+	}
+	if len(keys) == 1 {
+		return SSHPublicKey(keys[0])
+	}
+	var errs []error
+	for i, key := range keys {
+		if err := SSHPublicKey(key); err != nil {
+			errs = append(errs, fmt.Errorf("key %d: %v", i+1, err))
+		}
+	}
+	return k8serrors.NewAggregate(errs)
+}
+
+// PasswordHash checks if the given string is a crypt(3) password hash,
+// rather than a plain password, and returns an error if not.
+func PasswordHash(v string) error {
+	if !passwordHash.MatchString(v) {
+		return errors.New("must be a crypt(3) hash, such as generated by openssl passwd -6")
+	}
+	return nil
 }
 
 // URI validates if the URI is a valid absolute URI.
@@ -130,3 +247,76 @@ func URI(uri string) error {
 	}
 	return nil
 }
+
+// ImageRepository checks that the given string is an image repository,
+// such as quay.io/openshift-release-dev/ocp-release, without a tag or
+// digest, and returns an error if not.
+func ImageRepository(v string) error {
+	parts := strings.Split(v, "/")
+	if len(parts) < 2 {
+		return errors.New("must be a registry host followed by a repository path")
+	}
+
+	host := parts[0]
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.HasSuffix(host, "]") {
+		if _, err := strconv.ParseUint(host[i+1:], 10, 16); err != nil {
+			return fmt.Errorf("invalid registry port %q", host[i+1:])
+		}
+		host = host[:i]
+	}
+	if ip := net.ParseIP(strings.Trim(host, "[]")); ip == nil {
+		if err := DomainName(host); err != nil {
+			return fmt.Errorf("invalid registry host %q: %v", host, err)
+		}
+	}
+
+	for _, component := range parts[1:] {
+		if !imagePathComponent.MatchString(component) {
+			return fmt.Errorf("invalid repository path component %q; tags and digests are not allowed", component)
+		}
+	}
+	return nil
+}
+
+// ServingCertificate checks that the PEM-encoded certificate and key are a
+// valid key pair, and that the certificate is valid for the hostname.
+func ServingCertificate(certificate, key, hostname string) error {
+	pair, err := tls.X509KeyPair([]byte(certificate), []byte(key))
+	if err != nil {
+		return err
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return err
+	}
+	return leaf.VerifyHostname(hostname)
+}
+
+// CertificateAuthority checks that the PEM-encoded certificate and PKCS #1
+// RSA key are a valid key pair, and that the certificate is a CA.
+func CertificateAuthority(certificate, key string) error {
+	pair, err := tls.X509KeyPair([]byte(certificate), []byte(key))
+	if err != nil {
+		return err
+	}
+	if block, _ := pem.Decode([]byte(key)); block == nil || block.Type != "RSA PRIVATE KEY" {
+		return errors.New("key must be a PKCS #1 RSA private key")
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return err
+	}
+	if !leaf.IsCA {
+		return errors.New("certificate is not a CA")
+	}
+	return nil
+}
+
+// CABundle checks that the given string is a PEM-encoded bundle of one or
+// more certificates, and returns an error if not.
+func CABundle(v string) error {
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(v)) {
+		return errors.New("must be a PEM-encoded bundle of certificates")
+	}
+	return nil
+}
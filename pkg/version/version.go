@@ -0,0 +1,16 @@
+// Package version includes the version information for the installer.
+package version
+
+var (
+	// Raw is the string representation of the version. This will be
+	// replaced with the calculated version at build time.
+	Raw = "was not built correctly"
+
+	// Commit is the commit hash from which the installer was built.
+	// Set in hack/build.sh.
+	Commit = ""
+
+	// DefaultReleaseImage is the release image the installer installs
+	// unless it is overridden. Set in hack/build.sh.
+	DefaultReleaseImage = "registry.svc.ci.openshift.org/openshift/origin-release:v4.0"
+)